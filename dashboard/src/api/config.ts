@@ -8,6 +8,10 @@ export interface ServiceMapping {
 
 export interface ConfigResponse {
   service_mappings: ServiceMapping[]
+  // The rest of the configuration keyed like the config file, with
+  // credentials shown as [REDACTED]
+  runtime: Record<string, unknown>
+  loaded_at?: string
 }
 
 export async function getConfig(): Promise<ConfigResponse> {
@@ -1,7 +1,7 @@
 import { describe, it, expect, vi, beforeEach } from 'vitest'
-import { getIncidents, getIncident, triggerRemediation } from './incidents'
+import { getIncidents, getIncident, getIncidentDiagnosis, triggerRemediation } from './incidents'
 import { apiClient } from './client'
-import type { Incident, IncidentListResponse } from './types'
+import type { Incident, IncidentDiagnosis, IncidentListResponse } from './types'
 
 vi.mock('./client')
 
@@ -71,6 +71,23 @@ describe('Incidents API', () => {
     })
   })
 
+  describe('getIncidentDiagnosis', () => {
+    it('fetches the rendered diagnosis of an incident', async () => {
+      const mockDiagnosis: IncidentDiagnosis = {
+        incident_id: 'inc_123',
+        markdown: 'The pool was **exhausted**.',
+        html: '<p>The pool was <strong>exhausted</strong>.</p>\n',
+      }
+
+      vi.mocked(apiClient.get).mockResolvedValue({ data: mockDiagnosis })
+
+      const result = await getIncidentDiagnosis('inc_123')
+
+      expect(apiClient.get).toHaveBeenCalledWith('/incidents/inc_123/diagnosis')
+      expect(result).toEqual(mockDiagnosis)
+    })
+  })
+
   describe('triggerRemediation', () => {
     it('triggers remediation for an incident', async () => {
       vi.mocked(apiClient.post).mockResolvedValue({ data: {} })
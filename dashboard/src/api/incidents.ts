@@ -1,6 +1,7 @@
 import { apiClient } from './client'
 import type {
   Incident,
+  IncidentDiagnosis,
   IncidentEvent,
   IncidentFilters,
   IncidentListResponse,
@@ -37,6 +38,15 @@ export const getIncidentEvents = async (
   return response.data
 }
 
+export const getIncidentDiagnosis = async (
+  id: string
+): Promise<IncidentDiagnosis> => {
+  const response = await apiClient.get<IncidentDiagnosis>(
+    `/incidents/${id}/diagnosis`
+  )
+  return response.data
+}
+
 export const triggerRemediation = async (id: string): Promise<void> => {
   await apiClient.post(`/incidents/${id}/trigger`)
 }
@@ -0,0 +1,48 @@
+import { describe, it, expect, vi, beforeEach } from 'vitest'
+import { getLiveToken, liveTailURL } from './live'
+import { apiClient } from './client'
+import type { LiveToken } from './types'
+
+vi.mock('./client', () => ({
+  API_BASE_URL: '/api/v1',
+  apiClient: { post: vi.fn() },
+}))
+
+describe('Live tail API', () => {
+  beforeEach(() => {
+    vi.clearAllMocks()
+  })
+
+  describe('getLiveToken', () => {
+    it('requests a token for the incident', async () => {
+      const mockToken: LiveToken = { token: '1700000000.abc.dashboard', expires_at: '2024-01-15T10:01:00Z' }
+
+      vi.mocked(apiClient.post).mockResolvedValue({ data: mockToken })
+
+      const result = await getLiveToken('inc_123')
+
+      expect(apiClient.post).toHaveBeenCalledWith('/incidents/inc_123/live/token')
+      expect(result).toEqual(mockToken)
+    })
+  })
+
+  describe('liveTailURL', () => {
+    it('resolves a path base against the page, over wss for https pages', () => {
+      expect(liveTailURL('inc_123', 'a.b.c', '/api/v1', 'https://dashboard.example.com/incidents/inc_123')).toBe(
+        'wss://dashboard.example.com/api/v1/incidents/inc_123/live?token=a.b.c'
+      )
+    })
+
+    it('uses an absolute base as is, over ws for http', () => {
+      expect(liveTailURL('inc_123', 'a.b.c', 'http://localhost:8080/api/v1/', 'http://localhost:3000/')).toBe(
+        'ws://localhost:8080/api/v1/incidents/inc_123/live?token=a.b.c'
+      )
+    })
+
+    it('leaves the token out when none is needed', () => {
+      expect(liveTailURL('inc_123', '', '/api/v1', 'http://localhost:3000/')).toBe(
+        'ws://localhost:3000/api/v1/incidents/inc_123/live'
+      )
+    })
+  })
+})
@@ -0,0 +1,35 @@
+import { API_BASE_URL, apiClient } from './client'
+import type { LiveMessage, LiveToken } from './types'
+
+// Browsers cannot set headers on websocket requests, so the live tail is
+// opened with a short-lived token issued to the API key instead
+export const getLiveToken = async (id: string): Promise<LiveToken> => {
+  const response = await apiClient.post<LiveToken>(`/incidents/${id}/live/token`)
+  return response.data
+}
+
+// Builds the websocket URL of an incident's live tail. A base URL that is a
+// path is resolved against the page's location.
+export const liveTailURL = (
+  id: string,
+  token: string,
+  base: string = API_BASE_URL,
+  location: string = window.location.href
+): string => {
+  const url = new URL(`${base.replace(/\/$/, '')}/incidents/${encodeURIComponent(id)}/live`, location)
+  url.protocol = url.protocol === 'https:' ? 'wss:' : 'ws:'
+  if (token) url.searchParams.set('token', token)
+  return url.toString()
+}
+
+// Opens the live tail of an incident, passing each message to onMessage.
+// Resolves to a function that closes it.
+export const openLiveTail = async (
+  id: string,
+  onMessage: (message: LiveMessage) => void
+): Promise<() => void> => {
+  const { token } = await getLiveToken(id)
+  const socket = new WebSocket(liveTailURL(id, token))
+  socket.onmessage = (message) => onMessage(JSON.parse(message.data) as LiveMessage)
+  return () => socket.close()
+}
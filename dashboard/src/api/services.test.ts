@@ -0,0 +1,45 @@
+import { describe, it, expect, vi, beforeEach } from 'vitest'
+import { getServices } from './services'
+import { apiClient } from './client'
+import type { ServiceListResponse } from './types'
+
+vi.mock('./client')
+
+describe('Services API', () => {
+  beforeEach(() => {
+    vi.clearAllMocks()
+  })
+
+  it('fetches the per-service aggregates', async () => {
+    const mockResponse: ServiceListResponse = {
+      start: '2024-03-01T00:00:00Z',
+      end: '2024-03-08T00:00:00Z',
+      services: [
+        {
+          service_name: 'api-gateway',
+          repository: 'org/api-gateway',
+          tenant: 'default',
+          open_incidents: 2,
+          open_by_status: { pending: 2 },
+          open_by_severity: { high: 2 },
+          last_incident_at: '2024-03-07T12:00:00Z',
+          total_incidents: 10,
+          resolved_incidents: 8,
+          failed_incidents: 2,
+          success_rate: 0.8,
+          mean_time_to_resolve_seconds: 600,
+        },
+      ],
+      total: 1,
+    }
+
+    vi.mocked(apiClient.get).mockResolvedValue({ data: mockResponse })
+
+    const result = await getServices({ start: '2024-03-01T00:00:00Z' })
+
+    expect(apiClient.get).toHaveBeenCalledWith(
+      expect.stringContaining('/services?start=2024-03-01')
+    )
+    expect(result).toEqual(mockResponse)
+  })
+})
@@ -0,0 +1,17 @@
+import { apiClient } from './client'
+import type { ServiceListResponse } from './types'
+
+export const getServices = async (range?: {
+  start?: string
+  end?: string
+}): Promise<ServiceListResponse> => {
+  const params = new URLSearchParams()
+
+  if (range?.start) params.append('start', range.start)
+  if (range?.end) params.append('end', range.end)
+
+  const response = await apiClient.get<ServiceListResponse>(
+    `/services?${params.toString()}`
+  )
+  return response.data
+}
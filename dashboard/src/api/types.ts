@@ -6,6 +6,9 @@ export type IncidentStatus =
   | 'resolved'
   | 'failed'
   | 'no_fix_needed'
+  | 'suppressed'
+  | 'unmapped'
+  | 'manual_attention'
 
 export interface Incident {
   id: string
@@ -14,18 +17,89 @@ export interface Incident {
   error_message: string
   stack_trace?: string
   severity: string
+  severity_defaulted?: boolean
+  suggested_severity?: string
   status: IncidentStatus
   provider: string
-  provider_data: Record<string, unknown>
+  provider_ref?: string
+  affected_users?: number
+  error_rate?: number
+  downtime_minutes?: number
+  provider_data?: Record<string, unknown> // left out of incident lists, like stack_trace
   workflow_run_id?: number
   pull_request_url?: string
   diagnosis?: string
+  summary?: IncidentSummary
+  similar?: SimilarIncident[]
+  dispatch_attempts?: DispatchAttempt[] // on the detail endpoint only
+  correlated_alerts?: CorrelatedAlert[] // on the detail endpoint only
   created_at: string
   updated_at: string
   triggered_at?: string
   completed_at?: string
 }
 
+export type RootCauseCategory =
+  | 'code'
+  | 'configuration'
+  | 'dependency'
+  | 'infrastructure'
+  | 'capacity'
+  | 'data'
+  | 'network'
+  | 'unknown'
+
+export interface IncidentSummary {
+  text: string
+  root_cause_category: RootCauseCategory
+  model: string
+  generated_at: string
+}
+
+export interface IncidentDiagnosis {
+  incident_id: string
+  markdown: string
+  // Rendered and sanitized by the service, safe to insert as HTML
+  html: string
+}
+
+export interface SimilarIncident {
+  incident_id: string
+  service_name: string
+  status: IncidentStatus
+  severity: string
+  error_message: string
+  diagnosis?: string
+  pull_request_url?: string
+  score: number
+  created_at: string
+}
+
+export interface DispatchAttempt {
+  id: number
+  incident_id: string
+  repository: string
+  attempt: number
+  outcome: 'succeeded' | 'failed' | 'circuit_open'
+  http_status?: number
+  run_id?: number
+  error?: string
+  attempted_at: string
+}
+
+export interface CorrelatedAlert {
+  id: number
+  incident_id: string
+  provider: string
+  provider_ref?: string
+  error_message: string
+  stack_trace?: string
+  severity: string
+  fingerprint: string
+  provider_data?: Record<string, unknown>
+  received_at: string
+}
+
 export interface IncidentEvent {
   id: string
   incident_id: string
@@ -34,6 +108,23 @@ export interface IncidentEvent {
   created_at: string
 }
 
+export interface LiveLogEntry {
+  timestamp: string
+  level: 'debug' | 'info' | 'warn' | 'error'
+  message: string
+  fields?: Record<string, unknown>
+}
+
+export type LiveMessage =
+  | { type: 'event'; event: { id: number; incident_id: string; event_type: string; event_data: Record<string, unknown>; created_at: string } }
+  | { type: 'status'; status: IncidentStatus; updated_at: string }
+  | { type: 'log'; log: LiveLogEntry }
+
+export interface LiveToken {
+  token: string
+  expires_at: string
+}
+
 export interface IncidentFilters {
   status?: IncidentStatus
   service?: string
@@ -53,3 +144,29 @@ export interface IncidentStats {
   mean_time_to_resolution: number
   by_status: Record<IncidentStatus, number>
 }
+
+export interface ServiceOverview {
+  service_name: string
+  repository: string
+  team?: string
+  tenant: string
+  open_incidents: number
+  open_by_status: Partial<Record<IncidentStatus, number>>
+  open_by_severity: Record<string, number>
+  last_incident_at?: string
+  total_incidents: number
+  resolved_incidents: number
+  failed_incidents: number
+  success_rate: number
+  mean_time_to_resolve_seconds: number
+  affected_users: number
+  downtime_minutes: number
+  peak_error_rate: number
+}
+
+export interface ServiceListResponse {
+  start: string
+  end: string
+  services: ServiceOverview[]
+  total: number
+}
@@ -4,8 +4,13 @@ import { QueryClient, QueryClientProvider } from '@tanstack/react-query'
 import { BrowserRouter, Route, Routes } from 'react-router-dom'
 import { IncidentDetailPage } from './IncidentDetailPage'
 import * as incidentsApi from '@/api/incidents'
+import { openLiveTail } from '@/api/live'
 import type { Incident, IncidentEvent } from '@/api/types'
 
+vi.mock('@/api/live', () => ({
+  openLiveTail: vi.fn(() => Promise.resolve(() => {})),
+}))
+
 const mockIncident: Incident = {
   id: 'inc_test_123',
   service_name: 'api-gateway',
@@ -92,6 +97,77 @@ describe('IncidentDetailPage', () => {
     expect(screen.getByText(/at UserService\.getUser/)).toBeInTheDocument()
   })
 
+  it('should display the summary when available', async () => {
+    vi.spyOn(incidentsApi, 'getIncident').mockResolvedValue({
+      ...mockIncident,
+      summary: {
+        text: 'The user lookup dereferences a missing user.',
+        root_cause_category: 'code',
+        model: 'llama3.1',
+        generated_at: '2024-01-15T10:00:30Z',
+      },
+    })
+    vi.spyOn(incidentsApi, 'getIncidentEvents').mockResolvedValue(mockEvents)
+
+    window.history.pushState({}, '', '/incidents/inc_test_123')
+    renderWithRouter()
+
+    await waitFor(() => {
+      expect(screen.getByText('Summary')).toBeInTheDocument()
+    })
+
+    expect(screen.getByText('The user lookup dereferences a missing user.')).toBeInTheDocument()
+    expect(screen.getByText('code')).toBeInTheDocument()
+  })
+
+  it('should display similar past incidents when available', async () => {
+    vi.spyOn(incidentsApi, 'getIncident').mockResolvedValue({
+      ...mockIncident,
+      similar: [
+        {
+          incident_id: 'inc_past_1',
+          service_name: 'user-service',
+          status: 'resolved',
+          severity: 'high',
+          error_message: 'NullPointerException in UserRepository',
+          diagnosis: 'Missing user row not handled',
+          pull_request_url: 'https://github.com/org/user-service/pull/7',
+          score: 0.93,
+          created_at: '2024-01-02T10:00:00Z',
+        },
+      ],
+    })
+    vi.spyOn(incidentsApi, 'getIncidentEvents').mockResolvedValue(mockEvents)
+
+    window.history.pushState({}, '', '/incidents/inc_test_123')
+    renderWithRouter()
+
+    await waitFor(() => {
+      expect(screen.getByText('Similar Past Incidents')).toBeInTheDocument()
+    })
+
+    expect(screen.getByText('user-service')).toBeInTheDocument()
+    expect(screen.getByText('Missing user row not handled')).toBeInTheDocument()
+    expect(screen.getByText(/93% similar/)).toBeInTheDocument()
+  })
+
+  it('should display the suggested severity when available', async () => {
+    vi.spyOn(incidentsApi, 'getIncident').mockResolvedValue({
+      ...mockIncident,
+      severity: 'medium',
+      severity_defaulted: true,
+      suggested_severity: 'critical',
+    })
+    vi.spyOn(incidentsApi, 'getIncidentEvents').mockResolvedValue(mockEvents)
+
+    window.history.pushState({}, '', '/incidents/inc_test_123')
+    renderWithRouter()
+
+    await waitFor(() => {
+      expect(screen.getByText('SUGGESTED: CRITICAL')).toBeInTheDocument()
+    })
+  })
+
   it('should display timeline events', async () => {
     vi.spyOn(incidentsApi, 'getIncident').mockResolvedValue(mockIncident)
     vi.spyOn(incidentsApi, 'getIncidentEvents').mockResolvedValue(mockEvents)
@@ -144,6 +220,27 @@ describe('IncidentDetailPage', () => {
     expect(screen.getByText(/Workflow is currently active/)).toBeInTheDocument()
   })
 
+  it('should follow the live tail while the workflow is active', async () => {
+    const activeIncident = { ...mockIncident, status: 'in_progress' as const }
+    vi.spyOn(incidentsApi, 'getIncident').mockResolvedValue(activeIncident)
+    vi.spyOn(incidentsApi, 'getIncidentEvents').mockResolvedValue(mockEvents)
+    vi.mocked(openLiveTail).mockImplementation(async (_id, onMessage) => {
+      onMessage({
+        type: 'log',
+        log: { timestamp: '2024-01-15T10:02:00Z', level: 'info', message: 'running tests' },
+      })
+      return () => {}
+    })
+
+    window.history.pushState({}, '', '/incidents/inc_test_123')
+    renderWithRouter()
+
+    await waitFor(() => {
+      expect(screen.getByText(/running tests/)).toBeInTheDocument()
+    })
+    expect(openLiveTail).toHaveBeenCalledWith('inc_test_123', expect.any(Function))
+  })
+
   it('should enable trigger button when workflow is not active', async () => {
     const failedIncident = { ...mockIncident, status: 'failed' as const }
     vi.spyOn(incidentsApi, 'getIncident').mockResolvedValue(failedIncident)
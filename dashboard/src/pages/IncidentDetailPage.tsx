@@ -1,10 +1,12 @@
-import { useParams, useNavigate } from 'react-router-dom'
+import { useCallback, useEffect, useState } from 'react'
+import { Link, useParams, useNavigate } from 'react-router-dom'
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query'
-import { getIncident, getIncidentEvents, triggerRemediation } from '@/api/incidents'
+import { getIncident, getIncidentDiagnosis, getIncidentEvents, triggerRemediation } from '@/api/incidents'
+import { openLiveTail } from '@/api/live'
 import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card'
 import { Badge } from '@/components/ui/badge'
 import { Button } from '@/components/ui/button'
-import type { IncidentStatus } from '@/api/types'
+import type { IncidentStatus, LiveMessage } from '@/api/types'
 
 const statusColors: Record<IncidentStatus, string> = {
   pending: 'bg-yellow-500',
@@ -14,6 +16,9 @@ const statusColors: Record<IncidentStatus, string> = {
   resolved: 'bg-green-700',
   failed: 'bg-red-500',
   no_fix_needed: 'bg-gray-500',
+  suppressed: 'bg-gray-400',
+  unmapped: 'bg-orange-500',
+  manual_attention: 'bg-amber-600',
 }
 
 const severityColors: Record<string, string> = {
@@ -23,6 +28,73 @@ const severityColors: Record<string, string> = {
   low: 'bg-blue-500',
 }
 
+// How many live tail messages are kept on the page
+const maxLiveMessages = 200
+
+// Follows a running remediation over the incident's live tail
+function LiveTail({ id, onStatus }: { id: string; onStatus: () => void }) {
+  const [messages, setMessages] = useState<LiveMessage[]>([])
+  const [failed, setFailed] = useState(false)
+
+  useEffect(() => {
+    let closed = false
+    let close: (() => void) | undefined
+    openLiveTail(id, (message) => {
+      if (message.type === 'status') onStatus()
+      setMessages((previous) => [...previous, message].slice(-maxLiveMessages))
+    })
+      .then((closeTail) => {
+        if (closed) closeTail()
+        else close = closeTail
+      })
+      .catch(() => setFailed(true))
+    return () => {
+      closed = true
+      close?.()
+    }
+  }, [id, onStatus])
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Live Progress</CardTitle>
+        <CardDescription>Events, status changes and logs of the running remediation</CardDescription>
+      </CardHeader>
+      <CardContent>
+        {failed ? (
+          <p className="text-sm text-destructive">Failed to open the live tail</p>
+        ) : messages.length === 0 ? (
+          <p className="text-sm text-muted-foreground">Waiting for progress...</p>
+        ) : (
+          <div className="space-y-1 font-mono text-xs max-h-96 overflow-y-auto">
+            {messages.map((message, i) => (
+              <div key={i}>
+                {message.type === 'event' && (
+                  <span>
+                    {new Date(message.event.created_at).toLocaleTimeString()}{' '}
+                    {message.event.event_type.replace('_', ' ').toUpperCase()}
+                  </span>
+                )}
+                {message.type === 'status' && (
+                  <span className="font-semibold">
+                    {new Date(message.updated_at).toLocaleTimeString()} Status changed to{' '}
+                    {message.status.replace('_', ' ')}
+                  </span>
+                )}
+                {message.type === 'log' && (
+                  <span className={message.log.level === 'error' ? 'text-destructive' : 'text-muted-foreground'}>
+                    {new Date(message.log.timestamp).toLocaleTimeString()} [{message.log.level}] {message.log.message}
+                  </span>
+                )}
+              </div>
+            ))}
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  )
+}
+
 export function IncidentDetailPage() {
   const { id } = useParams<{ id: string }>()
   const navigate = useNavigate()
@@ -42,6 +114,13 @@ export function IncidentDetailPage() {
     refetchInterval: 10000,
   })
 
+  // The service renders and sanitizes the diagnosis, so its HTML can be inserted as is
+  const { data: diagnosis } = useQuery({
+    queryKey: ['incident-diagnosis', id, incident?.diagnosis],
+    queryFn: () => getIncidentDiagnosis(id!),
+    enabled: !!id && !!incident?.diagnosis,
+  })
+
   const triggerMutation = useMutation({
     mutationFn: () => triggerRemediation(id!),
     onSuccess: () => {
@@ -50,6 +129,12 @@ export function IncidentDetailPage() {
     },
   })
 
+  // A status change on the live tail refreshes the incident and its timeline
+  const refreshIncident = useCallback(() => {
+    queryClient.invalidateQueries({ queryKey: ['incident', id] })
+    queryClient.invalidateQueries({ queryKey: ['incident-events', id] })
+  }, [queryClient, id])
+
   if (incidentLoading) {
     return (
       <div className="flex items-center justify-center h-64">
@@ -88,6 +173,11 @@ export function IncidentDetailPage() {
           <Badge className={severityColors[incident.severity] || 'bg-gray-500'}>
             {incident.severity.toUpperCase()}
           </Badge>
+          {incident.suggested_severity && (
+            <Badge variant="outline" title="The provider gave no severity; suggested from the error and service history">
+              SUGGESTED: {incident.suggested_severity.toUpperCase()}
+            </Badge>
+          )}
         </div>
       </div>
 
@@ -100,6 +190,19 @@ export function IncidentDetailPage() {
           </CardDescription>
         </CardHeader>
         <CardContent className="space-y-4">
+          {incident.summary && (
+            <div>
+              <div className="flex items-center gap-2 mb-1">
+                <h4 className="font-semibold">Summary</h4>
+                <Badge variant="outline">{incident.summary.root_cause_category}</Badge>
+              </div>
+              <p className="text-sm">{incident.summary.text}</p>
+              <p className="text-xs text-muted-foreground mt-1">
+                Written by {incident.summary.model} on{' '}
+                {new Date(incident.summary.generated_at).toLocaleString()}
+              </p>
+            </div>
+          )}
           <div>
             <h4 className="font-semibold mb-1">Service</h4>
             <p className="text-sm">{incident.service_name}</p>
@@ -123,7 +226,11 @@ export function IncidentDetailPage() {
           {incident.diagnosis && (
             <div>
               <h4 className="font-semibold mb-1">Diagnosis</h4>
-              <p className="text-sm">{incident.diagnosis}</p>
+              {diagnosis ? (
+                <div className="text-sm space-y-2" dangerouslySetInnerHTML={{ __html: diagnosis.html }} />
+              ) : (
+                <p className="text-sm whitespace-pre-wrap">{incident.diagnosis}</p>
+              )}
             </div>
           )}
         </CardContent>
@@ -183,6 +290,48 @@ export function IncidentDetailPage() {
         </CardContent>
       </Card>
 
+      {/* Similar Past Incidents */}
+      {incident.similar && incident.similar.length > 0 && (
+        <Card>
+          <CardHeader>
+            <CardTitle>Similar Past Incidents</CardTitle>
+            <CardDescription>Past incidents with a similar error, and what was done about them</CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-4">
+            {incident.similar.map((similar) => (
+              <div key={similar.incident_id} className="border-l-2 border-muted pl-4">
+                <div className="flex items-center gap-2">
+                  <Link to={`/incidents/${similar.incident_id}`} className="font-semibold text-sm hover:underline">
+                    {similar.service_name}
+                  </Link>
+                  <Badge className={statusColors[similar.status]}>
+                    {similar.status.replace('_', ' ').toUpperCase()}
+                  </Badge>
+                  <span className="text-xs text-muted-foreground">
+                    {Math.round(similar.score * 100)}% similar, {new Date(similar.created_at).toLocaleString()}
+                  </span>
+                </div>
+                <p className="text-sm text-destructive mt-1">{similar.error_message}</p>
+                {similar.diagnosis && <p className="text-sm mt-1">{similar.diagnosis}</p>}
+                {similar.pull_request_url && (
+                  <a
+                    href={similar.pull_request_url}
+                    target="_blank"
+                    rel="noopener noreferrer"
+                    className="text-sm text-blue-600 hover:underline"
+                  >
+                    View Pull Request →
+                  </a>
+                )}
+              </div>
+            ))}
+          </CardContent>
+        </Card>
+      )}
+
+      {/* Live Progress */}
+      {isWorkflowActive && <LiveTail id={incident.id} onStatus={refreshIncident} />}
+
       {/* Timeline */}
       <Card>
         <CardHeader>
@@ -25,7 +25,10 @@ const incidentArbitrary = fc.record({
     'pr_created',
     'resolved',
     'failed',
-    'no_fix_needed'
+    'no_fix_needed',
+    'suppressed',
+    'unmapped',
+    'manual_attention'
   ),
   provider: fc.constantFrom('datadog', 'pagerduty', 'grafana', 'sentry'),
   provider_data: fc.dictionary(fc.string(), fc.anything()),
@@ -110,7 +113,10 @@ describe('IncidentListPage Property Tests', () => {
             'pr_created',
             'resolved',
             'failed',
-            'no_fix_needed'
+            'no_fix_needed',
+            'suppressed',
+            'unmapped',
+            'manual_attention'
           ]
           expect(validStatuses).toContain(incident.status)
         }
@@ -15,6 +15,9 @@ const statusColors: Record<IncidentStatus, string> = {
   resolved: 'bg-green-700',
   failed: 'bg-red-500',
   no_fix_needed: 'bg-gray-500',
+  suppressed: 'bg-gray-400',
+  unmapped: 'bg-orange-500',
+  manual_attention: 'bg-amber-600',
 }
 
 const statusLabels: Record<IncidentStatus, string> = {
@@ -25,6 +28,9 @@ const statusLabels: Record<IncidentStatus, string> = {
   resolved: 'Resolved',
   failed: 'Failed',
   no_fix_needed: 'No Fix Needed',
+  suppressed: 'Suppressed',
+  unmapped: 'Unmapped',
+  manual_attention: 'Manual Attention',
 }
 
 export function IncidentListPage() {
@@ -90,6 +96,9 @@ export function IncidentListPage() {
                 <option value="resolved">Resolved</option>
                 <option value="failed">Failed</option>
                 <option value="no_fix_needed">No Fix Needed</option>
+                <option value="suppressed">Suppressed</option>
+                <option value="unmapped">Unmapped</option>
+                <option value="manual_attention">Manual Attention</option>
               </select>
             </div>
             <div>
@@ -16,6 +16,7 @@ export default defineConfig({
       '/api': {
         target: process.env.VITE_API_PROXY_TARGET || 'http://localhost:8080',
         changeOrigin: true,
+        ws: true,
       },
     },
   },
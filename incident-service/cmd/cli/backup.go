@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// exportVersion is the version of the export format written by export.
+// import refuses files of other versions.
+const exportVersion = 1
+
+// exportRecord is a line of an export file. The first line is the header;
+// incidents follow, each after the incidents it references, and the links
+// between them come last.
+type exportRecord struct {
+	Kind       string                  `json:"kind"` // header, incident or link
+	Version    int                     `json:"version,omitempty"`
+	ExportedAt *time.Time              `json:"exported_at,omitempty"`
+	Since      *time.Time              `json:"since,omitempty"`
+	Incident   *models.Incident        `json:"incident,omitempty"`
+	Events     []*models.IncidentEvent `json:"events,omitempty"`
+	Link       *models.IncidentLink    `json:"link,omitempty"`
+}
+
+// backupStore is the part of the incident repository export and import use
+type backupStore interface {
+	ExportIncidents(since time.Time) ([]*models.Incident, error)
+	GetEventsByIncidentID(incidentID string) ([]*models.IncidentEvent, error)
+	ListIncidentLinks(ids []string) ([]models.IncidentLink, error)
+	ImportIncident(incident *models.Incident, events []*models.IncidentEvent) (bool, error)
+	ImportIncidentLink(link models.IncidentLink) (bool, error)
+}
+
+// openStore connects to the database of the configuration at path
+func openStore(path string) (backupStore, func() error, error) {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	cipher, err := database.NewCipherFromConfig(cfg.Database.Encryption)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to set up database encryption: %w", err)
+	}
+	db, err := database.Connect(cfg.Database.DatabaseDSN())
+	if err != nil {
+		return nil, nil, err
+	}
+	db.SetCipher(cipher)
+	return database.NewIncidentRepository(db), db.Close, nil
+}
+
+func newExportCommand(c *cli) *cobra.Command {
+	var since, out, configPath string
+	cmd := &cobra.Command{
+		Use:   "export --out FILE",
+		Short: "Export incidents, their events and links as NDJSON, straight from the database",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.export(since, out, configPath)
+		},
+	}
+	cmd.Flags().StringVar(&since, "since", "", "only incidents created at or after this time, RFC 3339 (default: all)")
+	cmd.Flags().StringVar(&out, "out", "", "file to write, - for stdout")
+	cmd.Flags().StringVar(&configPath, "config", envOr("CONFIG_PATH", "config.yaml"), "configuration of the database to export from")
+	return cmd
+}
+
+func (c *cli) export(since, out, configPath string) error {
+	if out == "" {
+		return fmt.Errorf("%w: --out is required", errUsage)
+	}
+
+	var start time.Time
+	if since != "" {
+		var err error
+		if start, err = time.Parse(time.RFC3339, since); err != nil {
+			return fmt.Errorf("%w: --since must be RFC 3339: %v", errUsage, err)
+		}
+	}
+
+	store, closeStore, err := openStore(configPath)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	w := c.out
+	if out != "-" {
+		file, err := os.Create(out)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", out, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	incidents, events, links, err := writeExport(w, store, start, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if out != "-" {
+		fmt.Fprintf(c.out, "exported %d incidents, %d events and %d links to %s\n", incidents, events, links, out)
+	}
+	return nil
+}
+
+// writeExport writes the incidents created since start, with their events and links, as NDJSON
+func writeExport(w io.Writer, store backupStore, since, now time.Time) (incidents, events, links int, err error) {
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+
+	header := exportRecord{Kind: "header", Version: exportVersion, ExportedAt: &now}
+	if !since.IsZero() {
+		header.Since = &since
+	}
+	if err := encoder.Encode(header); err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to write export: %w", err)
+	}
+
+	exported, err := store.ExportIncidents(since)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	ids := make([]string, 0, len(exported))
+	for _, incident := range exported {
+		timeline, err := store.GetEventsByIncidentID(incident.ID)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		if err := encoder.Encode(exportRecord{Kind: "incident", Incident: incident, Events: timeline}); err != nil {
+			return 0, 0, 0, fmt.Errorf("failed to write export: %w", err)
+		}
+		ids = append(ids, incident.ID)
+		events += len(timeline)
+	}
+
+	exportedLinks, err := store.ListIncidentLinks(ids)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	for i := range exportedLinks {
+		if err := encoder.Encode(exportRecord{Kind: "link", Link: &exportedLinks[i]}); err != nil {
+			return 0, 0, 0, fmt.Errorf("failed to write export: %w", err)
+		}
+	}
+
+	if err := buffered.Flush(); err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to write export: %w", err)
+	}
+	return len(exported), events, len(exportedLinks), nil
+}
+
+func newImportCommand(c *cli) *cobra.Command {
+	var configPath string
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import an export file into the database, skipping incidents that exist",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.importFile(args[0], configPath)
+		},
+	}
+	cmd.Flags().StringVar(&configPath, "config", envOr("CONFIG_PATH", "config.yaml"), "configuration of the database to import into")
+	return cmd
+}
+
+func (c *cli) importFile(path, configPath string) error {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer file.Close()
+		r = file
+	}
+
+	store, closeStore, err := openStore(configPath)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	result, err := readImport(r, store)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "imported %d incidents (%d already present) and %d links (%d already present)\n",
+		result.Incidents, result.SkippedIncidents, result.Links, result.SkippedLinks)
+	return nil
+}
+
+// importResult counts what an import stored, and what it skipped because it already existed
+type importResult struct {
+	Incidents        int
+	SkippedIncidents int
+	Links            int
+	SkippedLinks     int
+}
+
+// readImport stores the records of an export file in order. Incidents that
+// already exist are left as they are, along with their events.
+func readImport(r io.Reader, store backupStore) (*importResult, error) {
+	scanner := bufio.NewScanner(r)
+	// Incidents with long stack traces and timelines make for long lines
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+
+	result := &importResult{}
+	line := 0
+	for scanner.Scan() {
+		line++
+		var record exportRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return result, fmt.Errorf("line %d: invalid record: %w", line, err)
+		}
+
+		if line == 1 {
+			if record.Kind != "header" {
+				return result, fmt.Errorf("line 1: not an export file, the header is missing")
+			}
+			if record.Version != exportVersion {
+				return result, fmt.Errorf("line 1: unsupported export version %d, expected %d", record.Version, exportVersion)
+			}
+			continue
+		}
+
+		switch record.Kind {
+		case "incident":
+			if record.Incident == nil {
+				return result, fmt.Errorf("line %d: incident record without an incident", line)
+			}
+			imported, err := store.ImportIncident(record.Incident, record.Events)
+			if err != nil {
+				return result, fmt.Errorf("line %d: %w", line, err)
+			}
+			if imported {
+				result.Incidents++
+			} else {
+				result.SkippedIncidents++
+			}
+		case "link":
+			if record.Link == nil {
+				return result, fmt.Errorf("line %d: link record without a link", line)
+			}
+			imported, err := store.ImportIncidentLink(*record.Link)
+			if err != nil {
+				return result, fmt.Errorf("line %d: %w", line, err)
+			}
+			if imported {
+				result.Links++
+			} else {
+				result.SkippedLinks++
+			}
+		default:
+			return result, fmt.Errorf("line %d: unknown record kind %q", line, record.Kind)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("failed to read import: %w", err)
+	}
+	if line == 0 {
+		return result, fmt.Errorf("empty import file")
+	}
+
+	return result, nil
+}
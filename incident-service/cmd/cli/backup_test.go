@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// memoryStore is a backupStore that, like the database, refuses incidents
+// referencing a duplicate that does not exist
+type memoryStore struct {
+	incidents []*models.Incident
+	events    map[string][]*models.IncidentEvent
+	links     []models.IncidentLink
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{events: make(map[string][]*models.IncidentEvent)}
+}
+
+func (s *memoryStore) get(id string) *models.Incident {
+	for _, incident := range s.incidents {
+		if incident.ID == id {
+			return incident
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) ExportIncidents(since time.Time) ([]*models.Incident, error) {
+	var exported []*models.Incident
+	for _, incident := range s.incidents {
+		if !incident.CreatedAt.Before(since) {
+			exported = append(exported, incident)
+		}
+	}
+	return database.ImportOrder(exported), nil
+}
+
+func (s *memoryStore) GetEventsByIncidentID(id string) ([]*models.IncidentEvent, error) {
+	return s.events[id], nil
+}
+
+func (s *memoryStore) ListIncidentLinks(ids []string) ([]models.IncidentLink, error) {
+	return s.links, nil
+}
+
+func (s *memoryStore) ImportIncident(incident *models.Incident, events []*models.IncidentEvent) (bool, error) {
+	if s.get(incident.ID) != nil {
+		return false, nil
+	}
+	if incident.DuplicateOf != nil && s.get(*incident.DuplicateOf) == nil {
+		return false, fmt.Errorf("duplicate_of references missing incident %s", *incident.DuplicateOf)
+	}
+	s.incidents = append(s.incidents, incident)
+	s.events[incident.ID] = events
+	return true, nil
+}
+
+func (s *memoryStore) ImportIncidentLink(link models.IncidentLink) (bool, error) {
+	for _, existing := range s.links {
+		if existing.IncidentID == link.IncidentID && existing.RelatedID == link.RelatedID {
+			return false, nil
+		}
+	}
+	s.links = append(s.links, link)
+	return true, nil
+}
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	target := "inc_target"
+
+	source := newMemoryStore()
+	source.incidents = []*models.Incident{
+		{ID: "inc_old", CreatedAt: now.Add(-48 * time.Hour)},
+		// The duplicate was created before the incident it was merged into
+		{ID: "inc_merged", DuplicateOf: &target, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: target, CreatedAt: now.Add(-time.Hour)},
+	}
+	source.events["inc_merged"] = []*models.IncidentEvent{
+		{EventType: models.EventIncidentReceived, EventData: map[string]interface{}{}, CreatedAt: now.Add(-2 * time.Hour)},
+	}
+	source.links = []models.IncidentLink{{IncidentID: "inc_merged", RelatedID: target, Reason: "same root cause", CreatedAt: now}}
+
+	var buf bytes.Buffer
+	incidents, events, links, err := writeExport(&buf, source, now.Add(-24*time.Hour), now)
+	if err != nil {
+		t.Fatalf("writeExport failed: %v", err)
+	}
+	if incidents != 2 || events != 1 || links != 1 {
+		t.Errorf("exported %d incidents, %d events, %d links", incidents, events, links)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || !strings.Contains(lines[0], `"kind":"header"`) || !strings.Contains(lines[1], `"id":"inc_target"`) {
+		t.Fatalf("unexpected export:\n%s", buf.String())
+	}
+
+	destination := newMemoryStore()
+	result, err := readImport(bytes.NewReader(buf.Bytes()), destination)
+	if err != nil {
+		t.Fatalf("readImport failed: %v", err)
+	}
+	if *result != (importResult{Incidents: 2, Links: 1}) {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if len(destination.events["inc_merged"]) != 1 {
+		t.Errorf("expected the timeline to be imported, got %v", destination.events)
+	}
+
+	// Importing again changes nothing
+	result, err = readImport(bytes.NewReader(buf.Bytes()), destination)
+	if err != nil {
+		t.Fatalf("readImport failed: %v", err)
+	}
+	if *result != (importResult{SkippedIncidents: 2, SkippedLinks: 1}) {
+		t.Errorf("unexpected result of the second import %+v", result)
+	}
+}
+
+func TestReadImport_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"empty", "", "empty import file"},
+		{"no header", `{"kind":"incident","incident":{"id":"inc_1"}}`, "header is missing"},
+		{"other version", `{"kind":"header","version":2}`, "unsupported export version 2"},
+		{"unknown kind", "{\"kind\":\"header\",\"version\":1}\n{\"kind\":\"dispatch\"}", `line 2: unknown record kind "dispatch"`},
+		{"missing reference", "{\"kind\":\"header\",\"version\":1}\n{\"kind\":\"incident\",\"incident\":{\"id\":\"inc_1\",\"duplicate_of\":\"inc_2\"}}", "line 2: duplicate_of references missing incident"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readImport(strings.NewReader(tt.input), newMemoryStore())
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// apiClient calls the incident service management API
+type apiClient struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// newAPIClient creates a client for the API at baseURL. An empty apiKey
+// sends no credentials, for services without API keys configured.
+func newAPIClient(baseURL, apiKey string, timeout time.Duration) *apiClient {
+	return &apiClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// apiError is a non-2xx response from the API
+type apiError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *apiError) Error() string {
+	if e.StatusCode == http.StatusUnauthorized {
+		return "unauthorized: set --api-key or INCIDENT_SERVICE_API_KEY"
+	}
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// do sends a request to path with the query and JSON body, if any, and
+// returns the raw response body
+func (c *apiClient) do(method, path string, query url.Values, body interface{}) ([]byte, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, target, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", c.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &apiError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(data))}
+	}
+
+	return data, nil
+}
+
+// getJSON decodes the response of a GET request into out
+func (c *apiClient) getJSON(path string, query url.Values, out interface{}) ([]byte, error) {
+	data, err := c.do(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return data, nil
+}
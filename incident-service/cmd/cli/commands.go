@@ -0,0 +1,386 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// incidentList is the response of GET /api/v1/incidents
+type incidentList struct {
+	Incidents []*models.Incident `json:"incidents"`
+	Total     int                `json:"total"`
+}
+
+func newIncidentsListCommand(c *cli) *cobra.Command {
+	var status, service, team string
+	var limit int
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List incidents, newest first",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.incidentsList(status, service, team, limit)
+		},
+	}
+	cmd.Flags().StringVar(&status, "status", "", "only incidents with this status")
+	cmd.Flags().StringVar(&service, "service", "", "only incidents of this service")
+	cmd.Flags().StringVar(&team, "team", "", "only incidents owned by this team")
+	cmd.Flags().IntVar(&limit, "limit", 50, "show at most this many incidents, 0 for all")
+	return cmd
+}
+
+func (c *cli) incidentsList(status, service, team string, limit int) error {
+	query := url.Values{}
+	for param, value := range map[string]string{"status": status, "service_name": service, "team": team} {
+		if value != "" {
+			query.Set(param, value)
+		}
+	}
+	if !c.json {
+		query.Set("fields", "status,severity,service_name,created_at,error_message")
+	}
+
+	var list incidentList
+	data, err := c.client.getJSON("/api/v1/incidents", query, &list)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return c.printRaw(data)
+	}
+
+	incidents := list.Incidents
+	if limit > 0 && len(incidents) > limit {
+		incidents = incidents[:limit]
+	}
+
+	tw := c.table()
+	fmt.Fprintln(tw, "ID\tSTATUS\tSEVERITY\tSERVICE\tCREATED\tERROR")
+	for _, incident := range incidents {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			incident.ID,
+			incident.Status,
+			incident.Severity,
+			incident.ServiceName,
+			formatTime(incident.CreatedAt),
+			truncate(incident.ErrorMessage, 60),
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(incidents) < list.Total {
+		fmt.Fprintf(c.out, "\nshowing %d of %d incidents, use --limit 0 for all\n", len(incidents), list.Total)
+	}
+	return nil
+}
+
+// incidentDetail is the part of GET /api/v1/incidents/{id} the CLI shows
+type incidentDetail struct {
+	models.Incident
+	Runbooks []config.Link `json:"runbooks"`
+	Related  []struct {
+		IncidentID string `json:"incident_id"`
+		Status     string `json:"status"`
+		Reason     string `json:"reason"`
+	} `json:"related"`
+}
+
+func newIncidentsGetCommand(c *cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <id>",
+		Short: "Show an incident and its timeline",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.incidentsGet(args[0])
+		},
+	}
+}
+
+func (c *cli) incidentsGet(incidentID string) error {
+	id := url.PathEscape(incidentID)
+
+	var incident incidentDetail
+	data, err := c.client.getJSON("/api/v1/incidents/"+id, nil, &incident)
+	if err != nil {
+		return err
+	}
+	var events []*models.IncidentEvent
+	eventData, err := c.client.getJSON("/api/v1/incidents/"+id+"/events", nil, &events)
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		return c.printRaw([]byte(fmt.Sprintf(`{"incident":%s,"events":%s}`, data, eventData)))
+	}
+
+	tw := c.table()
+	fields := [][2]string{
+		{"ID", incident.ID},
+		{"Service", incident.ServiceName},
+		{"Team", incident.Team},
+		{"Repository", incident.Repository},
+		{"Status", string(incident.Status)},
+		{"Severity", incident.Severity},
+		{"Provider", incident.Provider},
+		{"Occurrences", fmt.Sprint(incident.OccurrenceCount)},
+		{"Created", formatTime(incident.CreatedAt)},
+		{"Error", incident.ErrorMessage},
+	}
+	if incident.PullRequestURL != nil {
+		fields = append(fields, [2]string{"Pull request", *incident.PullRequestURL})
+	}
+	if incident.Diagnosis != nil {
+		fields = append(fields, [2]string{"Diagnosis", truncate(*incident.Diagnosis, 200)})
+	}
+	if incident.DuplicateOf != nil {
+		fields = append(fields, [2]string{"Duplicate of", *incident.DuplicateOf})
+	}
+	for _, runbook := range incident.Runbooks {
+		fields = append(fields, [2]string{"Runbook", runbook.Title + " " + runbook.URL})
+	}
+	for _, related := range incident.Related {
+		fields = append(fields, [2]string{"Related", strings.TrimSpace(related.IncidentID + " (" + related.Status + ") " + related.Reason)})
+	}
+	for _, field := range fields {
+		if field[1] != "" {
+			fmt.Fprintf(tw, "%s:\t%s\n", field[0], field[1])
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.out, "\nTimeline:")
+	tw = c.table()
+	for _, event := range events {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", formatTime(event.CreatedAt), event.EventType, formatEventData(event.EventData))
+	}
+	return tw.Flush()
+}
+
+func newIncidentsRetryCommand(c *cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "retry <id>",
+		Short: "Retry the remediation of a failed incident, or one needing manual attention",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.incidentsRetry(args[0])
+		},
+	}
+}
+
+func (c *cli) incidentsRetry(id string) error {
+	data, err := c.client.do(http.MethodPost, "/api/v1/incidents/"+url.PathEscape(id)+"/retry", nil, nil)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return c.printRaw(data)
+	}
+
+	var incident models.Incident
+	if err := json.Unmarshal(data, &incident); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	fmt.Fprintf(c.out, "incident %s is %s, remediation dispatched to %s\n", incident.ID, incident.Status, incident.Repository)
+	return nil
+}
+
+// queueStatus is the response of GET /api/v1/queue
+type queueStatus struct {
+	Active       int `json:"active"`
+	Queued       int `json:"queued"`
+	MaxPerRepo   int `json:"max_workflows_per_repo"`
+	Repositories map[string]struct {
+		Active    int      `json:"active"`
+		Queued    int      `json:"queued"`
+		Incidents []string `json:"incidents"`
+	} `json:"repositories"`
+}
+
+func newQueueShowCommand(c *cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Show running workflows and queued incidents per repository",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.queueShow()
+		},
+	}
+}
+
+func (c *cli) queueShow() error {
+	var status queueStatus
+	data, err := c.client.getJSON("/api/v1/queue", nil, &status)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return c.printRaw(data)
+	}
+
+	fmt.Fprintf(c.out, "%d running, %d queued, at most %d workflows per repository\n\n", status.Active, status.Queued, status.MaxPerRepo)
+	if len(status.Repositories) == 0 {
+		fmt.Fprintln(c.out, "no workflows running or queued")
+		return nil
+	}
+
+	repositories := make([]string, 0, len(status.Repositories))
+	for repository := range status.Repositories {
+		repositories = append(repositories, repository)
+	}
+	sort.Strings(repositories)
+
+	tw := c.table()
+	fmt.Fprintln(tw, "REPOSITORY\tRUNNING\tQUEUED\tNEXT")
+	for _, repository := range repositories {
+		repo := status.Repositories[repository]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\n", repository, repo.Active, repo.Queued, strings.Join(repo.Incidents, ", "))
+	}
+	return tw.Flush()
+}
+
+// statisticsResponse is the response of GET /api/v1/statistics
+type statisticsResponse struct {
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	Statistics struct {
+		TotalIncidents    int     `json:"total_incidents"`
+		ResolvedIncidents int     `json:"resolved_incidents"`
+		FailedIncidents   int     `json:"failed_incidents"`
+		SuccessRate       float64 `json:"success_rate"`
+		MeanTimeToResolve float64 `json:"mean_time_to_resolve_seconds"`
+	} `json:"statistics"`
+}
+
+func newStatsCommand(c *cli) *cobra.Command {
+	var start, end, service, team string
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show incident statistics (default: last 7 days)",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.stats(start, end, service, team)
+		},
+	}
+	cmd.Flags().StringVar(&start, "start", "", "start of the range, RFC 3339")
+	cmd.Flags().StringVar(&end, "end", "", "end of the range, RFC 3339")
+	cmd.Flags().StringVar(&service, "service", "", "only incidents of this service")
+	cmd.Flags().StringVar(&team, "team", "", "only incidents owned by this team")
+	return cmd
+}
+
+func (c *cli) stats(start, end, service, team string) error {
+	query := url.Values{}
+	for param, value := range map[string]string{"start": start, "end": end, "service_name": service, "team": team} {
+		if value != "" {
+			query.Set(param, value)
+		}
+	}
+
+	var response statisticsResponse
+	data, err := c.client.getJSON("/api/v1/statistics", query, &response)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return c.printRaw(data)
+	}
+
+	stats := response.Statistics
+	tw := c.table()
+	fmt.Fprintf(tw, "Range:\t%s to %s\n", formatTime(response.Start), formatTime(response.End))
+	fmt.Fprintf(tw, "Incidents:\t%d\n", stats.TotalIncidents)
+	fmt.Fprintf(tw, "Resolved:\t%d\n", stats.ResolvedIncidents)
+	fmt.Fprintf(tw, "Failed:\t%d\n", stats.FailedIncidents)
+	fmt.Fprintf(tw, "Success rate:\t%.1f%%\n", stats.SuccessRate*100)
+	fmt.Fprintf(tw, "MTTR:\t%s\n", (time.Duration(stats.MeanTimeToResolve) * time.Second).String())
+	return tw.Flush()
+}
+
+func newConfigValidateCommand(c *cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate [path]",
+		Short: "Validate a configuration file (default: $CONFIG_PATH or config.yaml)",
+		Args:  usageArgs(cobra.MaximumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := envOr("CONFIG_PATH", "config.yaml")
+			if len(args) == 1 {
+				path = args[0]
+			}
+			return c.configValidate(path)
+		},
+	}
+}
+
+func (c *cli) configValidate(path string) error {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return fmt.Errorf("%s is invalid: %w", path, err)
+	}
+
+	fmt.Fprintf(c.out, "%s is valid: %d service mappings, %d teams, %d custom rules\n",
+		path, len(cfg.ServiceMappings), len(cfg.Teams), len(cfg.CustomRules))
+	return nil
+}
+
+// printRaw writes an API response as indented JSON
+func (c *cli) printRaw(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	encoder := json.NewEncoder(c.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// table returns a writer aligning tab-separated columns
+func (c *cli) table() *tabwriter.Writer {
+	return tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// formatEventData renders event data as sorted key=value pairs
+func formatEventData(data map[string]interface{}) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", key, data[key]))
+	}
+	return truncate(strings.Join(pairs, " "), 120)
+}
+
+// truncate shortens s to at most n runes on a single line
+func truncate(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,141 @@
+// Command cli is an operator tool for routine incident service tasks, such as
+// listing and retrying incidents or checking the dispatch queues, without
+// hand-written curl and jq. Most commands talk to the service's HTTP API;
+// export and import connect to its database directly.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// cli holds what every command needs
+type cli struct {
+	client *apiClient
+	out    io.Writer
+	json   bool // print raw API responses
+}
+
+// errUsage reports invalid arguments; the command's usage is printed
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	root := newRootCommand()
+	root.SetArgs(os.Args[1:])
+	os.Exit(execute(root))
+}
+
+// newRootCommand returns the cli command with all its subcommands
+func newRootCommand() *cobra.Command {
+	c := &cli{}
+	var server, apiKey string
+	var timeout time.Duration
+
+	root := &cobra.Command{
+		Use:           "cli",
+		Short:         "Operate the incident service",
+		Args:          cobra.ArbitraryArgs,
+		RunE:          runGroup,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			c.client = newAPIClient(server, apiKey, timeout)
+			c.out = cmd.OutOrStdout()
+		},
+	}
+	root.CompletionOptions.DisableDefaultCmd = true
+	root.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	})
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&server, "server", envOr("INCIDENT_SERVICE_URL", "http://localhost:8080"), "incident service base URL (env INCIDENT_SERVICE_URL)")
+	flags.StringVar(&apiKey, "api-key", os.Getenv("INCIDENT_SERVICE_API_KEY"), "API key (env INCIDENT_SERVICE_API_KEY)")
+	flags.BoolVar(&c.json, "json", false, "print raw JSON responses")
+	flags.DurationVar(&timeout, "timeout", 30*time.Second, "request timeout")
+
+	root.AddCommand(
+		newGroupCommand("incidents", "List, inspect and retry incidents",
+			newIncidentsListCommand(c),
+			newIncidentsGetCommand(c),
+			newIncidentsRetryCommand(c),
+		),
+		newGroupCommand("queue", "Inspect the dispatch queues",
+			newQueueShowCommand(c),
+		),
+		newStatsCommand(c),
+		newGroupCommand("config", "Check configuration files",
+			newConfigValidateCommand(c),
+		),
+		newExportCommand(c),
+		newImportCommand(c),
+		newGroupCommand("webhook", "Send provider webhooks",
+			newWebhookSendCommand(c),
+		),
+	)
+	return root
+}
+
+// newGroupCommand returns a command that only groups its subcommands, e.g.
+// "incidents"
+func newGroupCommand(name, short string, subcommands ...*cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   name,
+		Short: short,
+		Args:  cobra.ArbitraryArgs,
+		RunE:  runGroup,
+	}
+	cmd.AddCommand(subcommands...)
+	return cmd
+}
+
+// runGroup prints the help of a command run without a subcommand, and
+// rejects subcommands it does not have
+func runGroup(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%w: unknown command %q for %q", errUsage, strings.Join(args, " "), cmd.CommandPath())
+	}
+	return cmd.Help()
+}
+
+// usageArgs reports invalid positional arguments as usage errors
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return fmt.Errorf("%w: %v", errUsage, err)
+		}
+		return nil
+	}
+}
+
+// execute runs the command line and returns the exit code
+func execute(root *cobra.Command) int {
+	cmd, err := root.ExecuteC()
+	if err == nil {
+		return 0
+	}
+	stderr := root.ErrOrStderr()
+	if errors.Is(err, errUsage) {
+		if err != errUsage {
+			fmt.Fprintln(stderr, err)
+		}
+		fmt.Fprint(stderr, cmd.UsageString())
+		return 2
+	}
+	fmt.Fprintf(stderr, "error: %v\n", err)
+	return 1
+}
+
+// envOr returns the environment variable, or fallback when it is unset
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+)
+
+// newTestAPI serves canned responses for the paths the CLI calls, and
+// records the requests it received
+func newTestAPI(t *testing.T, responses map[string]interface{}) (*httptest.Server, *[]*http.Request) {
+	t.Helper()
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		response, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			http.Error(w, "incident not found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// runCLI runs the root command with args and returns the exit code and output
+func runCLI(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	root := newRootCommand()
+	root.SetArgs(args)
+	root.SetOut(&stdout)
+	root.SetErr(&stderr)
+	code := execute(root)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestIncidentsList(t *testing.T) {
+	server, requests := newTestAPI(t, map[string]interface{}{
+		"GET /api/v1/incidents": map[string]interface{}{
+			"incidents": []map[string]interface{}{
+				{"id": "inc_2", "service_name": "api", "status": "failed", "severity": "high", "error_message": "connection refused"},
+				{"id": "inc_1", "service_name": "api", "status": "resolved", "severity": "low", "error_message": "timeout"},
+			},
+			"total": 2,
+		},
+	})
+
+	code, stdout, stderr := runCLI("--server", server.URL, "--api-key", "test-key", "incidents", "list", "--status", "failed", "--limit", "1")
+	if code != 0 {
+		t.Fatalf("exit code %d, stderr: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "inc_2") || strings.Contains(stdout, "inc_1") {
+		t.Errorf("expected only the first incident, got:\n%s", stdout)
+	}
+	if !strings.Contains(stdout, "showing 1 of 2 incidents") {
+		t.Errorf("expected a truncation note, got:\n%s", stdout)
+	}
+	if got := (*requests)[0].URL.Query().Get("status"); got != "failed" {
+		t.Errorf("expected the status filter to be sent, got %q", got)
+	}
+	if got := (*requests)[0].URL.Query().Get("fields"); got == "" {
+		t.Error("expected only the shown fields to be asked for")
+	}
+}
+
+func TestIncidentsRetry(t *testing.T) {
+	server, requests := newTestAPI(t, map[string]interface{}{
+		"POST /api/v1/incidents/inc_1/retry": map[string]interface{}{"id": "inc_1", "status": "pending", "repository": "org/api"},
+	})
+
+	code, stdout, stderr := runCLI("--server", server.URL, "--api-key", "test-key", "incidents", "retry", "inc_1")
+	if code != 0 {
+		t.Fatalf("exit code %d, stderr: %s", code, stderr)
+	}
+	if (*requests)[0].Method != http.MethodPost {
+		t.Errorf("expected a POST, got %s", (*requests)[0].Method)
+	}
+	if !strings.Contains(stdout, "incident inc_1 is pending") {
+		t.Errorf("unexpected output:\n%s", stdout)
+	}
+}
+
+func TestQueueShow_JSON(t *testing.T) {
+	server, _ := newTestAPI(t, map[string]interface{}{
+		"GET /api/v1/queue": map[string]interface{}{"active": 1, "queued": 0, "repositories": map[string]interface{}{}},
+	})
+
+	code, stdout, stderr := runCLI("--server", server.URL, "--api-key", "test-key", "--json", "queue", "show")
+	if code != 0 {
+		t.Fatalf("exit code %d, stderr: %s", code, stderr)
+	}
+	var status map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &status); err != nil || status["active"] != float64(1) {
+		t.Errorf("expected the raw response, got %s (%v)", stdout, err)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	server, _ := newTestAPI(t, map[string]interface{}{})
+
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stderr string
+	}{
+		{"unknown command", []string{"incidents", "delete"}, 2, "unknown command"},
+		{"unknown root command", []string{"incident", "list"}, 2, "unknown command"},
+		{"missing argument", []string{"incidents", "get"}, 2, "cli incidents get <id>"},
+		{"unknown flag", []string{"incidents", "list", "--bogus"}, 2, "unknown flag: --bogus"},
+		{"missing flag", []string{"webhook", "send", "--provider", "sentry"}, 2, "--provider and --file are required"},
+		{"missing API key", []string{"--server", server.URL, "queue", "show"}, 1, "unauthorized"},
+		{"API error", []string{"--server", server.URL, "--api-key", "test-key", "incidents", "get", "inc_x"}, 1, "incident not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := runCLI(tt.args...)
+			if code != tt.code {
+				t.Errorf("expected exit code %d, got %d", tt.code, code)
+			}
+			if !strings.Contains(stderr, tt.stderr) {
+				t.Errorf("expected stderr to contain %q, got:\n%s", tt.stderr, stderr)
+			}
+		})
+	}
+}
+
+func TestRun_Help(t *testing.T) {
+	code, stdout, stderr := runCLI("incidents")
+	if code != 0 || !strings.Contains(stdout, "retry") || stderr != "" {
+		t.Errorf("expected the incidents help, got %d: %s%s", code, stdout, stderr)
+	}
+
+	// Persistent flags are accepted after the subcommand too
+	server, _ := newTestAPI(t, map[string]interface{}{
+		"GET /api/v1/queue": map[string]interface{}{"active": 0, "queued": 0, "repositories": map[string]interface{}{}},
+	})
+	code, stdout, stderr = runCLI("queue", "show", "--server", server.URL, "--api-key", "test-key", "--timeout", "5s")
+	if code != 0 || !strings.Contains(stdout, "no workflows running or queued") {
+		t.Errorf("unexpected output, got %d: %s%s", code, stdout, stderr)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("server:\n  port: 8080\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, _, stderr := runCLI("config", "validate", invalid)
+	if code != 1 || !strings.Contains(stderr, "invalid.yaml is invalid") {
+		t.Errorf("expected the invalid config to be reported, got %d: %s", code, stderr)
+	}
+
+	t.Setenv("GITHUB_TOKEN", "test_token_for_validation")
+	t.Setenv("DATABASE_HOST", "localhost")
+	t.Setenv("DATABASE_NAME", "test_db")
+	code, stdout, stderr := runCLI("config", "validate", filepath.Join("..", "..", "..", "config.yaml"))
+	if code != 0 || !strings.Contains(stdout, "is valid") {
+		t.Errorf("expected the root config to be valid, got %d: %s%s", code, stdout, stderr)
+	}
+}
+
+func TestWebhookSend(t *testing.T) {
+	t.Setenv("SENTRY_WEBHOOK_SECRET", "s3cret")
+
+	var provider string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provider = r.URL.Query().Get("provider")
+		if err := adapters.NewSentryAdapter().Validate(r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"accepted"}`))
+	}))
+	defer server.Close()
+
+	payload := filepath.Join(t.TempDir(), "payload.json")
+	if err := os.WriteFile(payload, []byte(`{"action":"created"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI("--server", server.URL, "webhook", "send", "--provider", "sentry", "--file", payload)
+	if code != 0 {
+		t.Fatalf("exit code %d, stderr: %s", code, stderr)
+	}
+	if provider != "sentry" || !strings.Contains(stdout, "202 Accepted") {
+		t.Errorf("unexpected request for %q, output:\n%s", provider, stdout)
+	}
+
+	code, _, stderr = runCLI("--server", server.URL, "webhook", "send", "--provider", "sentry", "--file", payload, "--secret", "wrong")
+	if code != 1 || !strings.Contains(stderr, "invalid signature") {
+		t.Errorf("expected the wrong secret to be rejected, got %d: %s", code, stderr)
+	}
+
+	code, stdout, _ = runCLI("webhook", "send", "--provider", "pagerduty", "--file", payload, "--secret", "s3cret", "--dry-run")
+	if code != 0 || !strings.Contains(stdout, "X-Pagerduty-Signature: v1=") || !strings.Contains(stdout, "provider=pagerduty") {
+		t.Errorf("unexpected dry run output:\n%s", stdout)
+	}
+
+	code, _, stderr = runCLI("webhook", "send", "--provider", "nagios", "--file", payload)
+	if code != 2 || !strings.Contains(stderr, "unsupported provider") {
+		t.Errorf("expected an unsupported provider to be a usage error, got %d: %s", code, stderr)
+	}
+}
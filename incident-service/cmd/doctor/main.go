@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+)
+
+// Check outcomes. A warning is reported but does not fail the run.
+const (
+	statusPass = "PASS"
+	statusWarn = "WARN"
+	statusFail = "FAIL"
+)
+
+// result is the outcome of a single check
+type result struct {
+	Check  string
+	Status string
+	Detail string
+}
+
+func main() {
+	migrationsDir := flag.String("migrations", "migrations", "directory holding the migration files")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of each network check")
+	flag.Parse()
+
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		printReport(os.Stdout, []result{{"config", statusFail, err.Error()}})
+		os.Exit(1)
+	}
+
+	results := []result{{"config", statusPass, configPath}}
+	results = append(results, checkDatabase(cfg, *migrationsDir)...)
+	results = append(results, checkRedis(cfg))
+
+	client := github.NewClient(cfg.GitHub.APIURL, cfg.GitHub.Token, cfg.GitHub.WorkflowName, cfg.Concurrency.MaxWorkflowsPerRepo)
+	results = append(results, checkGitHub(context.Background(), client, cfg, *timeout)...)
+	results = append(results, checkSecrets(os.Getenv)...)
+
+	if !printReport(os.Stdout, results) {
+		os.Exit(1)
+	}
+}
+
+// checkDatabase checks that the database is reachable and every migration has been applied
+func checkDatabase(cfg *config.Config, migrationsDir string) []result {
+	db, err := database.Connect(cfg.Database.DatabaseDSN())
+	if err != nil {
+		return []result{{"database", statusFail, err.Error()}}
+	}
+	defer db.Close()
+
+	results := []result{{"database", statusPass, fmt.Sprintf("%s:%d/%s", cfg.Database.Host, cfg.Database.Port, cfg.Database.Database)}}
+
+	files, err := migrationFiles(migrationsDir)
+	if err != nil {
+		return append(results, result{"schema", statusFail, err.Error()})
+	}
+	applied, err := appliedVersions(db.DB)
+	if err != nil {
+		return append(results, result{"schema", statusFail, err.Error()})
+	}
+
+	return append(results, checkSchema(files, applied))
+}
+
+// migrationFiles returns the up migrations in dir, in the order they apply
+func migrationFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migration files: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no migration files in %s", dir)
+	}
+	sort.Strings(files)
+
+	versions := make([]string, 0, len(files))
+	for _, file := range files {
+		if !strings.HasSuffix(file, ".down.sql") {
+			versions = append(versions, filepath.Base(file))
+		}
+	}
+	return versions, nil
+}
+
+// appliedVersions returns the migrations recorded in schema_migrations
+func appliedVersions(db *sql.DB) (map[string]bool, error) {
+	rows, err := db.Query("SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations (run cmd/migrate first): %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]bool)
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("failed to scan migration: %w", err)
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// checkSchema compares the migration files with the applied migrations.
+// Pending migrations fail the check; applied migrations missing from disk
+// usually mean the binary is older than the database and only warn.
+func checkSchema(files []string, applied map[string]bool) result {
+	var pending []string
+	known := make(map[string]bool, len(files))
+	for _, version := range files {
+		known[version] = true
+		if !applied[version] {
+			pending = append(pending, version)
+		}
+	}
+
+	var unknown []string
+	for version := range applied {
+		if !known[version] {
+			unknown = append(unknown, version)
+		}
+	}
+	sort.Strings(unknown)
+
+	switch {
+	case len(pending) > 0:
+		return result{"schema", statusFail, fmt.Sprintf("%d pending migrations: %s", len(pending), strings.Join(pending, ", "))}
+	case len(unknown) > 0:
+		return result{"schema", statusWarn, fmt.Sprintf("applied migrations not found on disk: %s", strings.Join(unknown, ", "))}
+	default:
+		return result{"schema", statusPass, "up to date at " + files[len(files)-1]}
+	}
+}
+
+// checkRedis checks that Redis is reachable
+func checkRedis(cfg *config.Config) result {
+	client, err := database.ConnectRedis(cfg.Redis.RedisAddr(), cfg.Redis.Password, cfg.Redis.DB)
+	if err != nil {
+		return result{"redis", statusFail, err.Error()}
+	}
+	defer client.Close()
+
+	return result{"redis", statusPass, cfg.Redis.RedisAddr()}
+}
+
+// checkGitHub checks the token's scopes and that every repository a service
+// is remediated in exists, with the mapped branch and the remediation
+// workflow on it
+func checkGitHub(ctx context.Context, client *github.Client, cfg *config.Config, timeout time.Duration) []result {
+	results := []result{checkToken(ctx, client, timeout)}
+
+	seen := make(map[string]bool)
+	for i := range cfg.ServiceMappings {
+		for _, target := range cfg.ServiceMappings[i].Targets() {
+			name := "repository " + target.Repository + "@" + target.Branch
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			checkCtx, cancel := context.WithTimeout(ctx, timeout)
+			problems, err := client.CheckTarget(checkCtx, target.Repository, target.Branch)
+			cancel()
+
+			switch {
+			case err != nil:
+				results = append(results, result{name, statusFail, err.Error()})
+			case len(problems) > 0:
+				results = append(results, result{name, statusFail, strings.Join(problems, ", ")})
+			default:
+				results = append(results, result{name, statusPass, cfg.GitHub.WorkflowName + " can be dispatched"})
+			}
+		}
+	}
+
+	return results
+}
+
+// checkToken checks that a classic token has the repo scope needed to
+// dispatch workflows. Fine-grained and app tokens do not report their
+// permissions, which the workflow checks then cover.
+func checkToken(ctx context.Context, client *github.Client, timeout time.Duration) result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	scopes, ok, err := client.TokenScopes(ctx)
+	if err != nil {
+		return result{"github token", statusFail, err.Error()}
+	}
+	if !ok {
+		return result{"github token", statusWarn, "token does not report scopes; make sure it can write actions"}
+	}
+	for _, scope := range scopes {
+		if scope == "repo" {
+			return result{"github token", statusPass, "scopes: " + strings.Join(scopes, ", ")}
+		}
+	}
+	return result{"github token", statusFail, fmt.Sprintf("missing repo scope (has: %s)", strings.Join(scopes, ", "))}
+}
+
+// checkSecrets reports webhook providers without a secret, whose requests are accepted unverified
+func checkSecrets(getenv func(string) string) []result {
+	providers := adapters.NewRegistry().List()
+	sort.Strings(providers)
+
+	results := make([]result, 0, len(providers))
+	for _, provider := range providers {
+		name, ok := adapters.SecretEnv(provider)
+		if !ok {
+			continue
+		}
+		if getenv(name) == "" {
+			results = append(results, result{provider + " webhook secret", statusWarn, name + " is not set; webhooks are not verified"})
+		} else {
+			results = append(results, result{provider + " webhook secret", statusPass, name + " is set"})
+		}
+	}
+	return results
+}
+
+// printReport writes one line per check and a summary, and reports whether every check passed or warned
+func printReport(w io.Writer, results []result) bool {
+	width := 0
+	for _, r := range results {
+		if len(r.Check) > width {
+			width = len(r.Check)
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[r.Status]++
+		fmt.Fprintf(w, "[%s] %-*s  %s\n", r.Status, width, r.Check, r.Detail)
+	}
+	fmt.Fprintf(w, "\n%d passed, %d warnings, %d failed\n", counts[statusPass], counts[statusWarn], counts[statusFail])
+
+	return counts[statusFail] == 0
+}
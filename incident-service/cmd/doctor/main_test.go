@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+)
+
+func TestMigrationFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"002_b.sql", "001_a.sql", "20240501120000_c.up.sql", "20240501120000_c.down.sql", "README.md"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := migrationFiles(dir)
+	if err != nil {
+		t.Fatalf("migrationFiles failed: %v", err)
+	}
+	if want := []string{"001_a.sql", "002_b.sql", "20240501120000_c.up.sql"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("got %v, want %v", files, want)
+	}
+
+	if _, err := migrationFiles(t.TempDir()); err == nil {
+		t.Error("expected error for a directory without migrations")
+	}
+}
+
+func TestCheckSchema(t *testing.T) {
+	files := []string{"001_a.sql", "002_b.sql"}
+
+	tests := []struct {
+		name    string
+		applied map[string]bool
+		status  string
+		detail  string
+	}{
+		{"up to date", map[string]bool{"001_a.sql": true, "002_b.sql": true}, statusPass, "002_b.sql"},
+		{"pending", map[string]bool{"001_a.sql": true}, statusFail, "1 pending migrations: 002_b.sql"},
+		{"newer database", map[string]bool{"001_a.sql": true, "002_b.sql": true, "003_c.sql": true}, statusWarn, "003_c.sql"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkSchema(files, tt.applied)
+			if got.Status != tt.status || !strings.Contains(got.Detail, tt.detail) {
+				t.Errorf("got %+v, want %s containing %q", got, tt.status, tt.detail)
+			}
+		})
+	}
+}
+
+func TestCheckGitHub(t *testing.T) {
+	scopes := "repo, read:org"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rate_limit":
+			w.Header().Set("X-OAuth-Scopes", scopes)
+			_, _ = w.Write([]byte(`{}`))
+		case "/repos/org/api", "/repos/org/web":
+			_, _ = w.Write([]byte(`{"default_branch": "main"}`))
+		case "/repos/org/api/branches/main", "/repos/org/web/branches/main", "/repos/org/api/contents/.github/workflows/remediate.yml":
+			_, _ = w.Write([]byte(`{}`))
+		case "/repos/org/api/actions/workflows/remediate.yml":
+			_, _ = w.Write([]byte(`{"id": 1, "path": ".github/workflows/remediate.yml", "state": "active"}`))
+		case "/repos/org/web/actions/workflows/remediate.yml":
+			_, _ = w.Write([]byte(`{"id": 2, "path": ".github/workflows/remediate.yml", "state": "disabled_manually"}`))
+		default:
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		GitHub: config.GitHubConfig{WorkflowName: "remediate.yml"},
+		ServiceMappings: []config.ServiceMapping{
+			{ServiceName: "api", Repository: "org/api", Branch: "main", Repositories: []config.RepositoryTarget{{Repository: "org/web"}}},
+			{ServiceName: "worker", Repository: "org/api", Branch: "main"},
+			{ServiceName: "billing", Repository: "org/billing", Branch: "main"},
+			{ServiceName: "search", Repository: "org/api", Branch: "develop"},
+		},
+	}
+	client := github.NewClient(server.URL, "token", "remediate.yml", 2)
+
+	results := checkGitHub(context.Background(), client, cfg, time.Second)
+	got := make(map[string]string)
+	for _, r := range results {
+		got[r.Check] = r.Status
+	}
+	want := map[string]string{
+		"github token":                statusPass,
+		"repository org/api@main":     statusPass,
+		"repository org/web@main":     statusFail,
+		"repository org/billing@main": statusFail,
+		"repository org/api@develop":  statusFail,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	scopes = "read:org"
+	if r := checkToken(context.Background(), client, time.Second); r.Status != statusFail {
+		t.Errorf("expected a token without repo scope to fail, got %+v", r)
+	}
+}
+
+func TestCheckSecrets(t *testing.T) {
+	env := map[string]string{"SENTRY_WEBHOOK_SECRET": "s3cret"}
+	results := checkSecrets(func(name string) string { return env[name] })
+
+	if len(results) != 5 {
+		t.Fatalf("expected a result per provider, got %+v", results)
+	}
+	for _, r := range results {
+		want := statusWarn
+		if r.Check == "sentry webhook secret" {
+			want = statusPass
+		}
+		if r.Status != want {
+			t.Errorf("%s: got %s, want %s", r.Check, r.Status, want)
+		}
+	}
+}
+
+func TestPrintReport(t *testing.T) {
+	var buf bytes.Buffer
+	ok := printReport(&buf, []result{
+		{"config", statusPass, "config.yaml"},
+		{"redis", statusWarn, "slow"},
+	})
+	if !ok {
+		t.Error("warnings should not fail the report")
+	}
+	if !strings.Contains(buf.String(), "[PASS] config  config.yaml") || !strings.Contains(buf.String(), "1 passed, 1 warnings, 0 failed") {
+		t.Errorf("unexpected report:\n%s", buf.String())
+	}
+
+	if printReport(&bytes.Buffer{}, []result{{"redis", statusFail, "refused"}}) {
+		t.Error("a failed check should fail the report")
+	}
+}
@@ -0,0 +1,477 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+)
+
+// webhookPath is the ingestion endpoint payloads are posted to
+const webhookPath = "/api/v1/webhooks/incidents"
+
+func main() {
+	baseURL := flag.String("url", "http://localhost:8080", "base URL of the incident service")
+	rate := flag.Float64("rate", 10, "requests per second")
+	duration := flag.Duration("duration", 30*time.Second, "how long to send requests")
+	concurrency := flag.Int("concurrency", 8, "maximum number of requests in flight")
+	providers := flag.String("providers", "datadog,sentry,pagerduty,grafana", "comma separated providers to generate payloads for")
+	services := flag.String("services", "api-gateway,user-service,payment-service,notification-service", "comma separated service names used in generated payloads")
+	payloads := flag.String("payloads", "", "directory of <provider>*.json payloads to replay instead of generating them")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "random seed for generated payloads")
+	timeout := flag.Duration("timeout", 10*time.Second, "per request timeout")
+	progress := flag.Duration("progress", 5*time.Second, "interval between progress lines; 0 disables them")
+	flag.Parse()
+
+	if *rate <= 0 || *duration <= 0 || *concurrency < 1 {
+		fmt.Fprintln(os.Stderr, "--rate, --duration and --concurrency must be positive")
+		os.Exit(2)
+	}
+
+	var source payloadSource
+	var err error
+	if *payloads != "" {
+		source, err = loadReplay(*payloads)
+	} else {
+		source, err = newGenerator(rand.New(rand.NewSource(*seed)), splitList(*providers), splitList(*services))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to prepare payloads: %v\n", err)
+		os.Exit(2)
+	}
+
+	l := &loadgen{
+		client:  &http.Client{Timeout: *timeout},
+		url:     strings.TrimRight(*baseURL, "/") + webhookPath,
+		source:  source,
+		secrets: secretsFromEnv(),
+		stats:   newStats(),
+	}
+
+	fmt.Printf("sending %.1f req/s for %s to %s with %d workers\n", *rate, *duration, l.url, *concurrency)
+	l.run(*rate, *duration, *concurrency, *progress)
+	fmt.Print(l.stats.report(*duration))
+}
+
+// payload is a single webhook request body for a provider
+type payload struct {
+	Provider string
+	Body     []byte
+}
+
+// payloadSource yields the payloads sent by the load generator
+type payloadSource interface {
+	next() payload
+}
+
+// generator builds provider-shaped payloads with unique IDs. Messages are
+// drawn from a small set of templates, so repeated errors for a service also
+// exercise deduplication.
+type generator struct {
+	mu        sync.Mutex
+	r         *rand.Rand
+	providers []string
+	services  []string
+	run       string
+	seq       int
+}
+
+// errorTemplates are the error messages generated incidents report
+var errorTemplates = []struct {
+	Title string
+	Trace string
+}{
+	{"NullPointerException in request handler", "at com.example.Handler.handle(Handler.java:42)"},
+	{"TypeError: Cannot read property 'id' of undefined", "at processOrder (src/orders.js:88:17)"},
+	{"Database connection pool exhausted", "Traceback (most recent call last):\n  File \"app/db.py\", line 31, in connect"},
+	{"Timeout calling downstream inventory API", "at InventoryClient.fetch (src/clients/inventory.ts:57:11)"},
+	{"KeyError: 'user_id' in session middleware", "Traceback (most recent call last):\n  File \"app/middleware.py\", line 12, in load_session"},
+	{"Out of memory while rendering report", "at ReportRenderer.render (src/reports/render.go:133)"},
+}
+
+// newGenerator creates a generator for the given providers and services
+func newGenerator(r *rand.Rand, providers, services []string) (*generator, error) {
+	if len(providers) == 0 || len(services) == 0 {
+		return nil, fmt.Errorf("at least one provider and one service are required")
+	}
+	for _, provider := range providers {
+		if _, ok := adapters.SecretEnv(provider); !ok {
+			return nil, fmt.Errorf("unsupported provider: %s", provider)
+		}
+	}
+
+	return &generator{
+		r:         r,
+		providers: providers,
+		services:  services,
+		run:       fmt.Sprintf("%x", r.Int63()&0xffffff),
+	}, nil
+}
+
+// next builds the next payload, cycling through the providers
+func (g *generator) next() payload {
+	g.mu.Lock()
+	g.seq++
+	seq := g.seq
+	provider := g.providers[seq%len(g.providers)]
+	service := g.services[g.r.Intn(len(g.services))]
+	tmpl := errorTemplates[g.r.Intn(len(errorTemplates))]
+	g.mu.Unlock()
+
+	id := fmt.Sprintf("loadgen-%s-%d", g.run, seq)
+	now := time.Now().UTC()
+
+	var body interface{}
+	switch provider {
+	case "datadog":
+		body = adapters.DatadogPayload{
+			ID:             id,
+			Title:          tmpl.Title,
+			Body:           tmpl.Trace,
+			AlertType:      "error",
+			Priority:       "normal",
+			Tags:           []string{"service:" + service, "env:loadtest"},
+			DateHappened:   now.Unix(),
+			AggregationKey: service + "-errors",
+			SourceTypeName: "ALERT",
+		}
+	case "sentry":
+		body = adapters.SentryPayload{
+			Action: "created",
+			Data: adapters.SentryData{
+				Issue: adapters.SentryIssue{
+					ID:       id,
+					Title:    tmpl.Title,
+					Culprit:  service + " handler",
+					Level:    "error",
+					Platform: "other",
+					Project:  service,
+				},
+				Event: adapters.SentryEvent{
+					EventID:   id,
+					Timestamp: now.Format(time.RFC3339),
+					Tags:      [][]string{{"service", service}},
+				},
+			},
+		}
+	case "pagerduty":
+		body = adapters.PagerDutyPayload{
+			Event: adapters.PagerDutyEvent{
+				ID:           id,
+				EventType:    "incident.triggered",
+				ResourceType: "incident",
+				OccurredAt:   now.Format(time.RFC3339),
+				Data: adapters.PagerDutyIncidentData{
+					ID:      id,
+					Type:    "incident",
+					Title:   tmpl.Title,
+					Service: adapters.PagerDutyService{ID: "P" + service, Summary: service},
+					Urgency: "low",
+					Body:    adapters.PagerDutyIncidentBody{Details: "Stack trace:\n" + tmpl.Trace},
+				},
+			},
+		}
+	case "splunk":
+		body = adapters.SplunkPayload{
+			IncidentID:   id,
+			DetectorID:   "D" + service,
+			Detector:     service + " errors",
+			Rule:         "Error rate above threshold",
+			Severity:     "Minor",
+			Status:       "anomalous",
+			MessageTitle: tmpl.Title,
+			Timestamp:    now.Format(time.RFC3339),
+			Dimensions:   map[string]interface{}{"service": service, "env": "loadtest"},
+		}
+	default:
+		// Grafana incident IDs include the rule ID, so it is unique per request
+		body = adapters.GrafanaPayload{
+			Title:       tmpl.Title,
+			State:       "alerting",
+			Message:     tmpl.Trace,
+			RuleID:      id,
+			RuleName:    service + " errors",
+			Labels:      map[string]string{"service": service, "severity": "warning"},
+			Annotations: map[string]string{"summary": tmpl.Title},
+		}
+	}
+
+	data, _ := json.Marshal(body)
+	return payload{Provider: provider, Body: data}
+}
+
+// replay cycles through payloads read from disk
+type replay struct {
+	mu       sync.Mutex
+	payloads []payload
+	i        int
+}
+
+// loadReplay reads every <provider>*.json file in dir. The provider is the
+// part of the file name before the first '-', '_' or '.'.
+func loadReplay(dir string) (*replay, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payloads: %w", err)
+	}
+	sort.Strings(files)
+
+	r := &replay{}
+	for _, file := range files {
+		name := filepath.Base(file)
+		provider := name[:strings.IndexAny(name, "-_.")]
+		if _, ok := adapters.SecretEnv(provider); !ok {
+			return nil, fmt.Errorf("cannot tell the provider of %s", name)
+		}
+
+		body, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		r.payloads = append(r.payloads, payload{Provider: provider, Body: body})
+	}
+	if len(r.payloads) == 0 {
+		return nil, fmt.Errorf("no payloads found in %s", dir)
+	}
+
+	return r, nil
+}
+
+// next returns the next payload, starting over after the last one
+func (r *replay) next() payload {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p := r.payloads[r.i%len(r.payloads)]
+	r.i++
+	return p
+}
+
+// secretsFromEnv reads the webhook secrets the adapters validate against, so
+// generated requests are signed the way the service expects
+func secretsFromEnv() map[string]string {
+	secrets := make(map[string]string)
+	for _, provider := range []string{"datadog", "grafana", "pagerduty", "sentry", "splunk"} {
+		name, _ := adapters.SecretEnv(provider)
+		secrets[provider] = os.Getenv(name)
+	}
+	return secrets
+}
+
+// loadgen sends payloads at a fixed rate and records the outcome of each request
+type loadgen struct {
+	client  *http.Client
+	url     string
+	source  payloadSource
+	secrets map[string]string
+	stats   *stats
+}
+
+// run sends requests at rate per second for duration using at most
+// concurrency workers. Ticks that find every worker busy are counted as
+// skipped rather than queued, so a slow server shows up in the report
+// instead of silently lowering the rate.
+func (l *loadgen) run(rate float64, duration time.Duration, concurrency int, progress time.Duration) {
+	jobs := make(chan payload)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				l.send(p)
+			}
+		}()
+	}
+
+	interval := time.Duration(float64(time.Second) / rate)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var progressC <-chan time.Time
+	if progress > 0 {
+		progressTicker := time.NewTicker(progress)
+		defer progressTicker.Stop()
+		progressC = progressTicker.C
+	}
+
+	start := time.Now()
+	deadline := time.After(duration)
+	for done := false; !done; {
+		select {
+		case <-ticker.C:
+			select {
+			case jobs <- l.source.next():
+			default:
+				l.stats.skip()
+			}
+		case <-progressC:
+			fmt.Println(l.stats.progress(time.Since(start)))
+		case <-deadline:
+			done = true
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+}
+
+// send posts a single payload and records its latency and outcome
+func (l *loadgen) send(p payload) {
+	req, err := http.NewRequest(http.MethodPost, l.url+"?provider="+p.Provider, bytes.NewReader(p.Body))
+	if err != nil {
+		l.stats.fail(err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := adapters.Sign(req.Header, p.Provider, l.secrets[p.Provider], p.Body); err != nil {
+		l.stats.fail(err)
+		return
+	}
+
+	start := time.Now()
+	resp, err := l.client.Do(req)
+	if err != nil {
+		l.stats.fail(err)
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	l.stats.record(resp.StatusCode, time.Since(start))
+}
+
+// stats accumulates request outcomes
+type stats struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+	statuses  map[int]int
+	errors    map[string]int
+	skipped   int
+}
+
+// newStats creates empty stats
+func newStats() *stats {
+	return &stats{
+		statuses: make(map[int]int),
+		errors:   make(map[string]int),
+	}
+}
+
+func (s *stats) record(status int, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses[status]++
+	s.latencies = append(s.latencies, latency)
+}
+
+func (s *stats) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors[err.Error()]++
+}
+
+func (s *stats) skip() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.skipped++
+}
+
+// progress returns a one line summary of the requests completed so far
+func (s *stats) progress(elapsed time.Duration) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	failed := 0
+	for status, n := range s.statuses {
+		if status >= 400 {
+			failed += n
+		}
+	}
+	for _, n := range s.errors {
+		failed += n
+	}
+
+	return fmt.Sprintf("[%s] completed=%d failed=%d skipped=%d",
+		elapsed.Round(time.Second), len(s.latencies), failed, s.skipped)
+}
+
+// report returns the final summary: outcomes by status code, throughput and
+// latency percentiles of the requests that got a response
+func (s *stats) report(duration time.Duration) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var b strings.Builder
+	total := len(s.latencies)
+	for _, n := range s.errors {
+		total += n
+	}
+
+	fmt.Fprintf(&b, "\nrequests: %d (%.1f/s), skipped: %d\n", total, float64(total)/duration.Seconds(), s.skipped)
+
+	codes := make([]int, 0, len(s.statuses))
+	for code := range s.statuses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		fmt.Fprintf(&b, "  %d %-22s %d\n", code, http.StatusText(code), s.statuses[code])
+	}
+
+	messages := make([]string, 0, len(s.errors))
+	for message := range s.errors {
+		messages = append(messages, message)
+	}
+	sort.Strings(messages)
+	for _, message := range messages {
+		fmt.Fprintf(&b, "  error: %s (%d)\n", message, s.errors[message])
+	}
+
+	if len(s.latencies) > 0 {
+		sorted := append([]time.Duration(nil), s.latencies...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		fmt.Fprintf(&b, "latency: p50=%s p90=%s p99=%s max=%s\n",
+			percentile(sorted, 50), percentile(sorted, 90), percentile(sorted, 99), sorted[len(sorted)-1])
+	}
+
+	return b.String()
+}
+
+// percentile returns the nearest-rank percentile p of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank].Round(time.Microsecond)
+}
+
+// splitList splits a comma separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
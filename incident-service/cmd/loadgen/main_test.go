@@ -0,0 +1,146 @@
+package main
+
+import (
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+)
+
+func TestGenerator_PayloadsParse(t *testing.T) {
+	providers := []string{"datadog", "sentry", "pagerduty", "grafana"}
+	g, err := newGenerator(rand.New(rand.NewSource(1)), providers, []string{"checkout"})
+	if err != nil {
+		t.Fatalf("newGenerator failed: %v", err)
+	}
+
+	registry := adapters.NewRegistry()
+	ids := make(map[string]bool)
+	for i := 0; i < 40; i++ {
+		p := g.next()
+		adapter, ok := registry.Get(p.Provider)
+		if !ok {
+			t.Fatalf("generated payload for unknown provider %q", p.Provider)
+		}
+
+		incident, err := adapter.Parse(p.Body)
+		if err != nil {
+			t.Fatalf("%s payload rejected: %v\n%s", p.Provider, err, p.Body)
+		}
+		if incident.ServiceName != "checkout" {
+			t.Errorf("%s payload parsed to service %q", p.Provider, incident.ServiceName)
+		}
+		if ids[incident.ID] {
+			t.Errorf("duplicate incident ID %s", incident.ID)
+		}
+		ids[incident.ID] = true
+	}
+}
+
+func TestNewGenerator_RejectsUnknownProvider(t *testing.T) {
+	if _, err := newGenerator(rand.New(rand.NewSource(1)), []string{"nagios"}, []string{"checkout"}); err == nil {
+		t.Error("expected error for unsupported provider")
+	}
+	if _, err := newGenerator(rand.New(rand.NewSource(1)), []string{"datadog"}, nil); err == nil {
+		t.Error("expected error without services")
+	}
+}
+
+func TestLoadReplay(t *testing.T) {
+	dir := t.TempDir()
+	for name, body := range map[string]string{
+		"sentry-created.json": `{"action":"created"}`,
+		"datadog_1.json":      `{"id":"1"}`,
+		"notes.txt":           "ignored",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err := loadReplay(dir)
+	if err != nil {
+		t.Fatalf("loadReplay failed: %v", err)
+	}
+	got := []string{r.next().Provider, r.next().Provider, r.next().Provider}
+	if strings.Join(got, ",") != "datadog,sentry,datadog" {
+		t.Errorf("unexpected replay order %v", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "nagios.json"), []byte(`{}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadReplay(dir); err == nil {
+		t.Error("expected error for a payload of an unknown provider")
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	var sorted []time.Duration
+	for i := 1; i <= 100; i++ {
+		sorted = append(sorted, time.Duration(i)*time.Millisecond)
+	}
+
+	cases := map[float64]time.Duration{50: 50 * time.Millisecond, 90: 90 * time.Millisecond, 99: 99 * time.Millisecond, 100: 100 * time.Millisecond}
+	for p, want := range cases {
+		if got := percentile(sorted, p); got != want {
+			t.Errorf("p%.0f = %s, want %s", p, got, want)
+		}
+	}
+	if got := percentile(nil, 50); got != 0 {
+		t.Errorf("percentile of no samples = %s, want 0", got)
+	}
+}
+
+func TestLoadgen_SignsAndRecords(t *testing.T) {
+	var mu sync.Mutex
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+
+		if r.URL.Path != webhookPath || r.URL.Query().Get("provider") != "datadog" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("X-Datadog-Signature") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	g, err := newGenerator(rand.New(rand.NewSource(1)), []string{"datadog"}, []string{"checkout"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := &loadgen{
+		client:  server.Client(),
+		url:     server.URL + webhookPath,
+		source:  g,
+		secrets: map[string]string{"datadog": "s3cret"},
+		stats:   newStats(),
+	}
+	l.run(200, 100*time.Millisecond, 4, 0)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if requests == 0 {
+		t.Fatal("no requests were sent")
+	}
+	if l.stats.statuses[http.StatusAccepted] != requests {
+		t.Errorf("expected %d accepted requests, got %v", requests, l.stats.statuses)
+	}
+	if report := l.stats.report(time.Second); !strings.Contains(report, "202 Accepted") || !strings.Contains(report, "p99=") {
+		t.Errorf("unexpected report:\n%s", report)
+	}
+}
@@ -1,17 +1,102 @@
 package main
 
 import (
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	_ "github.com/lib/pq"
 	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
 )
 
+const usage = `usage: migrate [command]
+
+commands:
+  up                 apply pending migrations (default)
+  status             list migrations and whether they are applied
+  create <name>      create timestamped up and down migration files
+  force <version>    mark migrations up to version as applied, and later ones
+                     as pending, without running them
+`
+
+// migrationsDir holds the migration files, relative to the working directory
+const migrationsDir = "migrations"
+
 func main() {
+	args := os.Args[1:]
+	command := "up"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	// Creating files needs no database
+	switch command {
+	case "create":
+		if len(args) != 1 {
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(2)
+		}
+		paths, err := createMigration(migrationsDir, args[0], time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to create migration: %v\n", err)
+			os.Exit(1)
+		}
+		for _, path := range paths {
+			fmt.Printf("created %s\n", path)
+		}
+		return
+	case "up", "status":
+		if len(args) != 0 {
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(2)
+		}
+	case "force":
+		if len(args) != 1 {
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(2)
+		}
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+
+	db, err := connect()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	switch command {
+	case "up":
+		err = runMigrations(db)
+		if err == nil {
+			fmt.Println("migrations completed successfully")
+		}
+	case "status":
+		err = printStatus(db)
+	case "force":
+		err = forceVersion(db, args[0])
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s failed: %v\n", command, err)
+		os.Exit(1)
+	}
+}
+
+// connect opens the database from the configuration
+func connect() (*sql.DB, error) {
 	// Load configuration
 	configPath := os.Getenv("CONFIG_PATH")
 	if configPath == "" {
@@ -20,8 +105,7 @@ func main() {
 
 	cfg, err := config.Load(configPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// Override with TEST_ environment variables if present
@@ -47,83 +131,344 @@ func main() {
 	// Connect to database
 	db, err := sql.Open("postgres", cfg.Database.DatabaseDSN())
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to connect to database: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
-	defer db.Close()
 
 	// Verify connection
 	if err := db.Ping(); err != nil {
-		fmt.Fprintf(os.Stderr, "failed to ping database: %v\n", err)
-		os.Exit(1)
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	// Run migrations
-	if err := runMigrations(db); err != nil {
-		fmt.Fprintf(os.Stderr, "migration failed: %v\n", err)
-		os.Exit(1)
+	return db, nil
+}
+
+// migration is an up migration file. Numbered files (001_name.sql) are up
+// migrations; timestamped ones come in name.up.sql and name.down.sql pairs.
+type migration struct {
+	Version  string // file name, as recorded in schema_migrations
+	Path     string
+	Checksum string // SHA-256 of the file contents
+}
+
+// appliedMigration is a row of schema_migrations
+type appliedMigration struct {
+	AppliedAt time.Time
+	Checksum  sql.NullString // null for migrations applied before checksums were tracked
+}
+
+// loadMigrations returns the up migrations in dir, in the order they apply
+func loadMigrations(dir string) ([]migration, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migration files: %w", err)
 	}
 
-	fmt.Println("migrations completed successfully")
+	sort.Strings(files)
+
+	migrations := make([]migration, 0, len(files))
+	for _, file := range files {
+		// Down migrations are kept for rolling back by hand
+		if strings.HasSuffix(file, ".down.sql") {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration file %s: %w", file, err)
+		}
+		migrations = append(migrations, migration{
+			Version:  filepath.Base(file),
+			Path:     file,
+			Checksum: checksum(content),
+		})
+	}
+
+	return migrations, nil
 }
 
-func runMigrations(db *sql.DB) error {
-	// Create migrations table if it doesn't exist
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// ensureMigrationsTable creates schema_migrations, and adds the checksum
+// column to tables created before checksums were tracked
+func ensureMigrationsTable(db *sql.DB) error {
 	_, err := db.Exec(`
 		CREATE TABLE IF NOT EXISTS schema_migrations (
 			version VARCHAR(255) PRIMARY KEY,
 			applied_at TIMESTAMP NOT NULL DEFAULT NOW()
-		)
+		);
+		ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS checksum VARCHAR(64);
 	`)
 	if err != nil {
 		return fmt.Errorf("failed to create migrations table: %w", err)
 	}
+	return nil
+}
 
-	// Get list of migration files
-	migrationsDir := "migrations"
-	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.sql"))
+// appliedMigrations returns the recorded migrations by version
+func appliedMigrations(db *sql.DB) (map[string]appliedMigration, error) {
+	rows, err := db.Query("SELECT version, applied_at, checksum FROM schema_migrations")
 	if err != nil {
-		return fmt.Errorf("failed to list migration files: %w", err)
+		return nil, fmt.Errorf("failed to list applied migrations: %w", err)
 	}
+	defer rows.Close()
 
-	sort.Strings(files)
+	applied := make(map[string]appliedMigration)
+	for rows.Next() {
+		var version string
+		var record appliedMigration
+		if err := rows.Scan(&version, &record.AppliedAt, &record.Checksum); err != nil {
+			return nil, fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+		applied[version] = record
+	}
 
-	// Apply each migration
-	for _, file := range files {
-		version := filepath.Base(file)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating applied migrations: %w", err)
+	}
 
-		// Check if migration already applied
-		var exists bool
-		err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE version = $1)", version).Scan(&exists)
-		if err != nil {
-			return fmt.Errorf("failed to check migration status: %w", err)
+	return applied, nil
+}
+
+// verifyChecksums fails if an applied migration was edited afterwards. It
+// records the checksum of migrations applied before checksums were tracked.
+func verifyChecksums(db *sql.DB, migrations []migration, applied map[string]appliedMigration) error {
+	var modified []string
+	for _, m := range migrations {
+		record, ok := applied[m.Version]
+		if !ok {
+			continue
+		}
+		if !record.Checksum.Valid {
+			if _, err := db.Exec("UPDATE schema_migrations SET checksum = $2 WHERE version = $1", m.Version, m.Checksum); err != nil {
+				return fmt.Errorf("failed to record checksum of %s: %w", m.Version, err)
+			}
+			continue
 		}
+		if record.Checksum.String != m.Checksum {
+			modified = append(modified, m.Version)
+		}
+	}
+
+	if len(modified) > 0 {
+		return fmt.Errorf("applied migrations were modified: %s; restore them, or run force to accept the changes", strings.Join(modified, ", "))
+	}
+	return nil
+}
+
+func runMigrations(db *sql.DB) error {
+	if err := ensureMigrationsTable(db); err != nil {
+		return err
+	}
 
-		if exists {
-			fmt.Printf("skipping migration %s (already applied)\n", version)
+	migrations, err := loadMigrations(migrationsDir)
+	if err != nil {
+		return err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+	if err := verifyChecksums(db, migrations, applied); err != nil {
+		return err
+	}
+
+	// Apply each migration
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; ok {
+			fmt.Printf("skipping migration %s (already applied)\n", m.Version)
 			continue
 		}
 
-		// Read migration file
-		content, err := os.ReadFile(file)
+		content, err := os.ReadFile(m.Path)
+		if err != nil {
+			return fmt.Errorf("failed to read migration file %s: %w", m.Path, err)
+		}
+
+		// Apply and record the migration together, so a failure leaves nothing half done
+		fmt.Printf("applying migration %s...\n", m.Version)
+		tx, err := db.Begin()
 		if err != nil {
-			return fmt.Errorf("failed to read migration file %s: %w", file, err)
+			return fmt.Errorf("failed to begin migration %s: %w", m.Version, err)
+		}
+		if _, err := tx.Exec(string(content)); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to apply migration %s: %w", m.Version, err)
+		}
+		if _, err := tx.Exec("INSERT INTO schema_migrations (version, checksum) VALUES ($1, $2)", m.Version, checksum(content)); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to record migration %s: %w", m.Version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration %s: %w", m.Version, err)
+		}
+
+		fmt.Printf("migration %s applied successfully\n", m.Version)
+	}
+
+	return nil
+}
+
+// printStatus lists every migration with its state: applied, pending,
+// modified after it was applied, or recorded without a file
+func printStatus(db *sql.DB) error {
+	if err := ensureMigrationsTable(db); err != nil {
+		return err
+	}
+
+	migrations, err := loadMigrations(migrationsDir)
+	if err != nil {
+		return err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tSTATE\tAPPLIED AT")
+
+	pending := 0
+	known := make(map[string]bool, len(migrations))
+	for _, m := range migrations {
+		known[m.Version] = true
+		record, ok := applied[m.Version]
+		switch {
+		case !ok:
+			pending++
+			fmt.Fprintf(w, "%s\tpending\t\n", m.Version)
+		case record.Checksum.Valid && record.Checksum.String != m.Checksum:
+			fmt.Fprintf(w, "%s\tmodified\t%s\n", m.Version, record.AppliedAt.Format(time.RFC3339))
+		default:
+			fmt.Fprintf(w, "%s\tapplied\t%s\n", m.Version, record.AppliedAt.Format(time.RFC3339))
 		}
+	}
 
-		// Execute migration
-		fmt.Printf("applying migration %s...\n", version)
-		if _, err := db.Exec(string(content)); err != nil {
-			return fmt.Errorf("failed to apply migration %s: %w", version, err)
+	var missing []string
+	for version := range applied {
+		if !known[version] {
+			missing = append(missing, version)
 		}
+	}
+	sort.Strings(missing)
+	for _, version := range missing {
+		fmt.Fprintf(w, "%s\tmissing file\t%s\n", version, applied[version].AppliedAt.Format(time.RFC3339))
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\n%d applied, %d pending\n", len(migrations)-pending, pending)
+	return nil
+}
 
-		// Record migration
-		_, err = db.Exec("INSERT INTO schema_migrations (version) VALUES ($1)", version)
+// forceVersion records the migrations up to and including version as
+// applied, with their current checksums, and the later ones as pending,
+// without running any of them. It recovers from a migration that failed
+// halfway and was finished by hand, and accepts edits to applied migrations.
+func forceVersion(db *sql.DB, version string) error {
+	if err := ensureMigrationsTable(db); err != nil {
+		return err
+	}
+
+	migrations, err := loadMigrations(migrationsDir)
+	if err != nil {
+		return err
+	}
+	target, err := findMigration(migrations, version)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for i, m := range migrations {
+		if i <= target {
+			_, err = tx.Exec(`
+				INSERT INTO schema_migrations (version, checksum) VALUES ($1, $2)
+				ON CONFLICT (version) DO UPDATE SET checksum = EXCLUDED.checksum
+			`, m.Version, m.Checksum)
+		} else {
+			_, err = tx.Exec("DELETE FROM schema_migrations WHERE version = $1", m.Version)
+		}
 		if err != nil {
-			return fmt.Errorf("failed to record migration %s: %w", version, err)
+			return fmt.Errorf("failed to force migration %s: %w", m.Version, err)
 		}
+	}
 
-		fmt.Printf("migration %s applied successfully\n", version)
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
 	}
 
+	fmt.Printf("schema forced to %s\n", migrations[target].Version)
 	return nil
 }
+
+// findMigration returns the index of the migration named by version: its
+// file name, or the number or timestamp it starts with
+func findMigration(migrations []migration, version string) (int, error) {
+	match := -1
+	for i, m := range migrations {
+		prefix, _, _ := strings.Cut(m.Version, "_")
+		if m.Version != version && prefix != version {
+			continue
+		}
+		if match >= 0 {
+			return 0, fmt.Errorf("version %s is ambiguous: %s and %s", version, migrations[match].Version, m.Version)
+		}
+		match = i
+	}
+	if match < 0 {
+		return 0, fmt.Errorf("no migration with version %s", version)
+	}
+	return match, nil
+}
+
+var nonWord = regexp.MustCompile(`[^a-z0-9]+`)
+
+// createMigration writes empty up and down migration files named after the
+// UTC time and name, e.g. 20240501120000_add_incident_tags.up.sql
+func createMigration(dir, name string, now time.Time) ([]string, error) {
+	name = strings.Trim(nonWord.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, fmt.Errorf("migration name must contain letters or digits")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create migrations directory: %w", err)
+	}
+
+	base := now.UTC().Format("20060102150405") + "_" + name
+	files := []struct {
+		suffix string
+		header string
+	}{
+		{".up.sql", "-- " + name + "\n"},
+		{".down.sql", "-- Roll back " + base + ".up.sql by hand; migrate never runs down migrations\n"},
+	}
+
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		path := filepath.Join(dir, base+file.suffix)
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		_, err = f.WriteString(file.header)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateMigration(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	paths, err := createMigration(dir, "Add incident tags!", now)
+	if err != nil {
+		t.Fatalf("createMigration() error = %v", err)
+	}
+
+	want := []string{
+		filepath.Join(dir, "20240501123000_add_incident_tags.up.sql"),
+		filepath.Join(dir, "20240501123000_add_incident_tags.down.sql"),
+	}
+	if len(paths) != 2 || paths[0] != want[0] || paths[1] != want[1] {
+		t.Fatalf("expected %v, got %v", want, paths)
+	}
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected %s to exist: %v", path, err)
+		}
+	}
+
+	// Existing files are never overwritten
+	if _, err := createMigration(dir, "add incident tags", now); err == nil {
+		t.Error("expected creating the same migration twice to fail")
+	}
+	if _, err := createMigration(dir, "!!!", now); err == nil {
+		t.Error("expected a name without letters or digits to be rejected")
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"002_create_events.sql":                 "CREATE TABLE events ();",
+		"001_create_incidents.sql":              "CREATE TABLE incidents ();",
+		"20240501123000_add_tags.up.sql":        "ALTER TABLE incidents ADD COLUMN tags TEXT;",
+		"20240501123000_add_tags.down.sql":      "ALTER TABLE incidents DROP COLUMN tags;",
+		"20240502090000_add_tag_index.up.sql":   "CREATE INDEX ON incidents(tags);",
+		"20240502090000_add_tag_index.down.sql": "DROP INDEX incidents_tags_idx;",
+		"README.md":                             "not a migration",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	migrations, err := loadMigrations(dir)
+	if err != nil {
+		t.Fatalf("loadMigrations() error = %v", err)
+	}
+
+	var versions []string
+	for _, m := range migrations {
+		versions = append(versions, m.Version)
+	}
+	want := "001_create_incidents.sql 002_create_events.sql 20240501123000_add_tags.up.sql 20240502090000_add_tag_index.up.sql"
+	if got := strings.Join(versions, " "); got != want {
+		t.Errorf("expected up migrations in order %q, got %q", want, got)
+	}
+	if migrations[0].Checksum != checksum([]byte("CREATE TABLE incidents ();")) {
+		t.Errorf("unexpected checksum %s", migrations[0].Checksum)
+	}
+
+	for version, wantIndex := range map[string]int{
+		"002":                                 1,
+		"20240501123000":                      2,
+		"20240502090000_add_tag_index.up.sql": 3,
+	} {
+		if index, err := findMigration(migrations, version); err != nil || index != wantIndex {
+			t.Errorf("findMigration(%q) = %d, %v, want %d", version, index, err, wantIndex)
+		}
+	}
+	if _, err := findMigration(migrations, "003"); err == nil {
+		t.Error("expected an unknown version to be rejected")
+	}
+}
+
+func TestLoadMigrations_Repository(t *testing.T) {
+	// The repository's own migrations must apply in numbered order
+	migrations, err := loadMigrations(filepath.Join("..", "..", "migrations"))
+	if err != nil {
+		t.Fatalf("loadMigrations() error = %v", err)
+	}
+	if len(migrations) == 0 || migrations[0].Version != "001_create_incidents.sql" {
+		t.Fatalf("expected 001_create_incidents.sql first, got %+v", migrations)
+	}
+	seen := make(map[string]bool)
+	for _, m := range migrations {
+		prefix, _, _ := strings.Cut(m.Version, "_")
+		if seen[prefix] {
+			t.Errorf("duplicate migration version %s", prefix)
+		}
+		seen[prefix] = true
+	}
+}
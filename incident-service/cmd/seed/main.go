@@ -0,0 +1,339 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// seedPrefix starts the ID of every seeded incident, so --reset removes
+// seeded data and nothing else
+const seedPrefix = "inc_seed_"
+
+func main() {
+	count := flag.Int("count", 200, "number of incidents to create")
+	days := flag.Int("days", 30, "spread incidents over this many days before now")
+	seed := flag.Int64("seed", 1, "random seed; the same seed generates the same incidents")
+	reset := flag.Bool("reset", false, "delete previously seeded incidents first")
+	flag.Parse()
+
+	if *count < 1 || *days < 1 {
+		fmt.Fprintln(os.Stderr, "--count and --days must be positive")
+		os.Exit(2)
+	}
+
+	// Load configuration
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	db, err := database.Connect(cfg.Database.DatabaseDSN())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to database: %v\n", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	cipher, err := database.NewCipherFromConfig(cfg.Database.Encryption)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to set up database encryption: %v\n", err)
+		os.Exit(1)
+	}
+	db.SetCipher(cipher)
+
+	if *reset {
+		result, err := db.Exec("DELETE FROM incidents WHERE id LIKE $1", seedPrefix+"%")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to delete seeded incidents: %v\n", err)
+			os.Exit(1)
+		}
+		deleted, _ := result.RowsAffected()
+		fmt.Printf("deleted %d seeded incidents\n", deleted)
+	}
+
+	now := time.Now().UTC()
+	window := time.Duration(*days) * 24 * time.Hour
+	incidents := generate(rand.New(rand.NewSource(*seed)), seedServices(cfg), now, window, *count)
+
+	repo := database.NewIncidentRepository(db)
+	created, skipped := 0, 0
+	for _, seeded := range incidents {
+		imported, err := repo.ImportIncident(seeded.Incident, seeded.Events)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to seed incident: %v\n", err)
+			os.Exit(1)
+		}
+		if imported {
+			created++
+		} else {
+			skipped++
+		}
+	}
+	fmt.Printf("seeded %d incidents over the last %d days (%d already present): %s\n", created, *days, skipped, describe(incidents))
+
+	// Statistics are read from rollups, so bring them up to date for the seeded range
+	start := now.Add(-window)
+	for _, granularity := range []database.RollupGranularity{database.RollupHourly, database.RollupDaily} {
+		buckets, err := repo.RefreshRollups(granularity, granularity.Truncate(start), now.Add(time.Hour))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to refresh %s rollups: %v\n", granularity, err)
+			os.Exit(1)
+		}
+		fmt.Printf("refreshed %d %s rollup buckets\n", buckets, granularity)
+	}
+}
+
+// seedService is a service incidents are generated for
+type seedService struct {
+	Name       string
+	Repository string
+	Team       string
+}
+
+// seedServices returns the configured service mappings, or sample services
+// when none are configured
+func seedServices(cfg *config.Config) []seedService {
+	var services []seedService
+	for _, mapping := range cfg.ServiceMappings {
+		services = append(services, seedService{
+			Name:       mapping.ServiceName,
+			Repository: mapping.Repository,
+			Team:       cfg.TeamFor(mapping.ServiceName),
+		})
+	}
+	if len(services) > 0 {
+		return services
+	}
+
+	return []seedService{
+		{Name: "api-gateway", Repository: "your-org/api-gateway", Team: "platform"},
+		{Name: "user-service", Repository: "your-org/user-service", Team: "identity"},
+		{Name: "payment-service", Repository: "your-org/payment-service", Team: "payments"},
+		{Name: "notification-service", Repository: "your-org/notification-service", Team: "platform"},
+	}
+}
+
+// seededIncident is a generated incident with its timeline
+type seededIncident struct {
+	Incident *models.Incident
+	Events   []*models.IncidentEvent
+}
+
+// weighted picks one of the choices with probability proportional to its weight
+type weighted[T any] []struct {
+	value  T
+	weight int
+}
+
+func (w weighted[T]) pick(r *rand.Rand) T {
+	total := 0
+	for _, choice := range w {
+		total += choice.weight
+	}
+	n := r.Intn(total)
+	for _, choice := range w {
+		if n < choice.weight {
+			return choice.value
+		}
+		n -= choice.weight
+	}
+	return w[len(w)-1].value
+}
+
+var severities = weighted[string]{
+	{"critical", 10},
+	{"high", 30},
+	{"medium", 40},
+	{"low", 20},
+}
+
+var providers = weighted[string]{
+	{"datadog", 35},
+	{"sentry", 35},
+	{"grafana", 20},
+	{"pagerduty", 10},
+}
+
+// outcomes are the statuses incidents end up in once remediation is over
+var outcomes = weighted[models.IncidentStatus]{
+	{models.StatusPRCreated, 30},
+	{models.StatusResolved, 35},
+	{models.StatusFailed, 20},
+	{models.StatusNoFixNeeded, 15},
+}
+
+// activeStatuses are the statuses of incidents still being worked on
+var activeStatuses = weighted[models.IncidentStatus]{
+	{models.StatusPending, 30},
+	{models.StatusWorkflowTriggered, 30},
+	{models.StatusInProgress, 40},
+}
+
+// sampleErrors are the errors incidents report, with a stack trace frame
+var sampleErrors = []struct {
+	message string
+	frame   string
+}{
+	{"TypeError: Cannot read properties of undefined (reading 'id')", "at handler (src/routes/users.js:42:18)"},
+	{"connection refused: dial tcp 10.0.3.12:5432", "main.(*Store).Query(store.go:88)"},
+	{"context deadline exceeded while calling inventory-service", "main.(*Client).Reserve(client.go:131)"},
+	{"NullPointerException in PaymentProcessor.charge", "at com.example.payments.PaymentProcessor.charge(PaymentProcessor.java:57)"},
+	{"redis: connection pool timeout", "main.(*Cache).Get(cache.go:23)"},
+	{"KeyError: 'currency'", "File \"app/billing/invoice.py\", line 112, in total"},
+	{"panic: runtime error: index out of range [3] with length 3", "main.parseHeaders(headers.go:64)"},
+	{"HTTP 503 from upstream auth-service", "at AuthClient.verify (src/clients/auth.ts:77:11)"},
+	{"OutOfMemoryError: Java heap space", "at com.example.reports.ReportBuilder.render(ReportBuilder.java:203)"},
+	{"duplicate key value violates unique constraint \"users_email_key\"", "main.(*UserRepo).Insert(users.go:45)"},
+}
+
+var diagnoses = []string{
+	"Missing nil check before dereferencing the user record.",
+	"Connection pool exhausted under load; pool size raised and retries added.",
+	"Upstream timeout was shorter than the p99 latency of the dependency.",
+	"Input validation did not reject requests without a currency.",
+	"Off-by-one error when slicing the header list.",
+}
+
+// generate creates count incidents spread over window before now. Incidents
+// from the last two hours are still being remediated; older ones have an
+// outcome, with timestamps and events to match.
+func generate(r *rand.Rand, services []seedService, now time.Time, window time.Duration, count int) []seededIncident {
+	incidents := make([]seededIncident, 0, count)
+	for i := 0; i < count; i++ {
+		service := services[r.Intn(len(services))]
+		sample := sampleErrors[r.Intn(len(sampleErrors))]
+		createdAt := now.Add(-time.Duration(r.Int63n(int64(window)))).Truncate(time.Second)
+
+		stackTrace := sample.message + "\n    " + sample.frame
+		incident := &models.Incident{
+			ID:              fmt.Sprintf("%s%04d", seedPrefix, i+1),
+			ServiceName:     service.Name,
+			Repository:      service.Repository,
+			ErrorMessage:    sample.message,
+			StackTrace:      &stackTrace,
+			Severity:        severities.pick(r),
+			Provider:        providers.pick(r),
+			ProviderData:    map[string]interface{}{"seeded": true, "environment": "production"},
+			CreatedAt:       createdAt,
+			OccurrenceCount: 1,
+			Team:            service.Team,
+		}
+		incident.Fingerprint = models.ComputeFingerprint(incident.ErrorMessage, incident.StackTrace)
+		if r.Intn(4) == 0 {
+			incident.OccurrenceCount += r.Intn(10)
+		}
+
+		if now.Sub(createdAt) < 2*time.Hour {
+			incident.Status = activeStatuses.pick(r)
+		} else {
+			incident.Status = outcomes.pick(r)
+		}
+
+		events := []*models.IncidentEvent{{
+			EventType: models.EventIncidentReceived,
+			EventData: map[string]interface{}{
+				"provider":     incident.Provider,
+				"service_name": incident.ServiceName,
+				"severity":     incident.Severity,
+			},
+			CreatedAt: createdAt,
+		}}
+		at := createdAt
+		step := func(min, max time.Duration) time.Time {
+			at = at.Add(min + time.Duration(r.Int63n(int64(max-min)))).Truncate(time.Second)
+			if at.After(now) {
+				at = now.Truncate(time.Second)
+			}
+			return at
+		}
+		addEvent := func(eventType models.IncidentEventType, data map[string]interface{}) {
+			events = append(events, &models.IncidentEvent{EventType: eventType, EventData: data, CreatedAt: at})
+		}
+
+		if incident.Status != models.StatusPending {
+			triggeredAt := step(5*time.Second, 2*time.Minute)
+			runID := 9000000000 + r.Int63n(1000000000)
+			incident.TriggeredAt = &triggeredAt
+			incident.WorkflowRunID = &runID
+			addEvent(models.EventWorkflowTriggered, map[string]interface{}{"workflow_run_id": runID})
+		}
+		if incident.Status != models.StatusPending && incident.Status != models.StatusWorkflowTriggered {
+			step(10*time.Second, time.Minute)
+			addEvent(models.EventWorkflowInProgress, map[string]interface{}{})
+		}
+
+		switch incident.Status {
+		case models.StatusPRCreated, models.StatusResolved:
+			prURL := fmt.Sprintf("https://github.com/%s/pull/%d", incident.Repository, 100+r.Intn(900))
+			diagnosis := diagnoses[r.Intn(len(diagnoses))]
+			incident.PullRequestURL = &prURL
+			incident.Diagnosis = &diagnosis
+			completedAt := step(3*time.Minute, 45*time.Minute)
+			incident.CompletedAt = &completedAt
+			addEvent(models.EventPRCreated, map[string]interface{}{
+				"status":           "success",
+				"pull_request_url": prURL,
+				"diagnosis":        diagnosis,
+			})
+			if incident.Status == models.StatusResolved {
+				resolvedAt := step(30*time.Minute, 6*time.Hour)
+				incident.CompletedAt = &resolvedAt
+				addEvent(models.EventIncidentResolved, map[string]interface{}{"pull_request_url": prURL})
+			}
+		case models.StatusFailed:
+			completedAt := step(2*time.Minute, 30*time.Minute)
+			incident.CompletedAt = &completedAt
+			incident.RetryCount = r.Intn(4)
+			addEvent(models.EventIncidentFailed, map[string]interface{}{
+				"status":    "failed",
+				"diagnosis": "Generated fix did not pass the test suite.",
+			})
+		case models.StatusNoFixNeeded:
+			diagnosis := "Transient failure of a dependency; no code change needed."
+			incident.Diagnosis = &diagnosis
+			completedAt := step(2*time.Minute, 20*time.Minute)
+			incident.CompletedAt = &completedAt
+			addEvent(models.EventStatusChanged, map[string]interface{}{
+				"old_status": string(models.StatusInProgress),
+				"new_status": string(models.StatusNoFixNeeded),
+			})
+		}
+
+		incident.UpdatedAt = at
+		incidents = append(incidents, seededIncident{Incident: incident, Events: events})
+	}
+
+	return incidents
+}
+
+// describe summarizes generated incidents by status, for logging
+func describe(incidents []seededIncident) string {
+	counts := make(map[models.IncidentStatus]int)
+	for _, seeded := range incidents {
+		counts[seeded.Incident.Status]++
+	}
+	var parts []string
+	for _, status := range []models.IncidentStatus{
+		models.StatusPending, models.StatusWorkflowTriggered, models.StatusInProgress,
+		models.StatusPRCreated, models.StatusResolved, models.StatusFailed, models.StatusNoFixNeeded,
+	} {
+		if counts[status] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[status], status))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestGenerate(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	window := 30 * 24 * time.Hour
+	services := seedServices(&config.Config{})
+
+	incidents := generate(rand.New(rand.NewSource(7)), services, now, window, 300)
+	if len(incidents) != 300 {
+		t.Fatalf("expected 300 incidents, got %d", len(incidents))
+	}
+
+	statuses := make(map[models.IncidentStatus]int)
+	for _, seeded := range incidents {
+		incident := seeded.Incident
+		statuses[incident.Status]++
+
+		if incident.CreatedAt.Before(now.Add(-window)) || incident.CreatedAt.After(now) {
+			t.Errorf("%s created outside the window at %s", incident.ID, incident.CreatedAt)
+		}
+		if incident.Fingerprint == "" || incident.Team == "" {
+			t.Errorf("%s is missing its fingerprint or team", incident.ID)
+		}
+
+		// Finished incidents are old enough to have an outcome, with a completion time
+		switch incident.Status {
+		case models.StatusPending, models.StatusWorkflowTriggered, models.StatusInProgress:
+			if now.Sub(incident.CreatedAt) >= 2*time.Hour || incident.CompletedAt != nil {
+				t.Errorf("%s is %s but was created at %s", incident.ID, incident.Status, incident.CreatedAt)
+			}
+		default:
+			if incident.CompletedAt == nil || incident.CompletedAt.Before(incident.CreatedAt) {
+				t.Errorf("%s is %s without a valid completion time", incident.ID, incident.Status)
+			}
+		}
+		if (incident.Status == models.StatusPRCreated || incident.Status == models.StatusResolved) && incident.PullRequestURL == nil {
+			t.Errorf("%s is %s without a pull request", incident.ID, incident.Status)
+		}
+
+		// The timeline starts at creation and only moves forward
+		if seeded.Events[0].EventType != models.EventIncidentReceived || !seeded.Events[0].CreatedAt.Equal(incident.CreatedAt) {
+			t.Errorf("%s timeline does not start with its creation", incident.ID)
+		}
+		for i := 1; i < len(seeded.Events); i++ {
+			if seeded.Events[i].CreatedAt.Before(seeded.Events[i-1].CreatedAt) || seeded.Events[i].CreatedAt.After(now) {
+				t.Errorf("%s timeline is out of order", incident.ID)
+			}
+		}
+	}
+
+	for _, status := range []models.IncidentStatus{models.StatusPRCreated, models.StatusResolved, models.StatusFailed, models.StatusNoFixNeeded} {
+		if statuses[status] == 0 {
+			t.Errorf("expected some %s incidents, got %v", status, statuses)
+		}
+	}
+
+	// The same seed generates the same data, so reseeding skips existing incidents
+	again := generate(rand.New(rand.NewSource(7)), services, now, window, 300)
+	if !reflect.DeepEqual(incidents[42].Incident, again[42].Incident) {
+		t.Error("expected the same seed to generate the same incidents")
+	}
+}
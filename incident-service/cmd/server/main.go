@@ -9,10 +9,23 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/your-org/ai-sre-platform/incident-service/internal/api"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/blob"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/chaos"
 	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
 	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/enrichment"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/errorreporting"
 	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/leader"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/severity"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/shutdown"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/similarity"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/slo"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/summary"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/workers"
 )
 
 func main() {
@@ -28,18 +41,66 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Set up logging
+	logger, err := api.NewLoggerFromConfig(cfg.Logging)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to set up logging: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.Close()
+
+	// Set up error reporting for the service's own errors and panics
+	flushErrors, err := errorreporting.Setup(cfg.ErrorReporting)
+	if err != nil {
+		logger.Error("failed to set up error reporting", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+	defer flushErrors(2 * time.Second)
+	if errorreporting.Enabled() {
+		logger.SetErrorReporter(errorreporting.CaptureLog)
+	}
+
+	// Set up tracing
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		logger.Error("failed to set up tracing", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = shutdownTracing(ctx)
+	}()
+
 	// Connect to database
 	db, err := database.Connect(cfg.Database.DatabaseDSN())
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to connect to database: %v\n", err)
+		logger.Error("failed to connect to database", map[string]interface{}{
+			"error": err.Error(),
+		})
 		os.Exit(1)
 	}
 	defer db.Close()
 
+	cipher, err := database.NewCipherFromConfig(cfg.Database.Encryption)
+	if err != nil {
+		logger.Error("failed to set up database encryption", map[string]interface{}{
+			"error": err.Error(),
+		})
+		os.Exit(1)
+	}
+	db.SetCipher(cipher)
+
 	// Connect to Redis
 	redis, err := database.ConnectRedis(cfg.Redis.RedisAddr(), cfg.Redis.Password, cfg.Redis.DB)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to connect to redis: %v\n", err)
+		logger.Error("failed to connect to redis", map[string]interface{}{
+			"error": err.Error(),
+		})
 		os.Exit(1)
 	}
 	defer redis.Close()
@@ -51,10 +112,35 @@ func main() {
 		cfg.GitHub.WorkflowName,
 		cfg.Concurrency.MaxWorkflowsPerRepo,
 	)
+	if cfg.Concurrency.DispatchShards > 0 {
+		githubClient.SetDispatchShards(cfg.Concurrency.DispatchShards)
+	}
+	if cfg.GitHub.MaxIdleConns > 0 {
+		githubClient.SetMaxIdleConns(cfg.GitHub.MaxIdleConns)
+	}
+	// Test environments can inject failures into GitHub calls and webhooks
+	var injector *chaos.Injector
+	if cfg.Chaos.Enabled {
+		injector = chaos.New(cfg.Chaos)
+		githubClient.WrapTransport(injector.Transport)
+		logger.Warn("chaos mode enabled, failures are injected into GitHub calls and webhooks", map[string]interface{}{
+			"seed": cfg.Chaos.Seed,
+		})
+	}
+	// Replicas share workflow slots and queues through Redis, so that the
+	// limit holds across all of them
+	if cfg.Concurrency.Store == config.ConcurrencyStoreRedis {
+		githubClient.SetSlotStore(database.NewRedisSlotStore(redis, "incident-service:dispatch:"))
+	}
+	prometheus.MustRegister(github.NewMetricsCollector(githubClient))
 
 	// Create server
-	server := api.NewServer(cfg, db, redis, githubClient)
-	logger := server.Logger()
+	server := api.NewServer(cfg, db, redis, githubClient, logger)
+
+	// Custom rules may set an incident's repository or skip its remediation
+	if len(cfg.CustomRules) > 0 {
+		server.SetRules(config.NewRuleEngine(cfg.CustomRules))
+	}
 
 	// Log startup
 	logger.Info("starting incident service", map[string]interface{}{
@@ -62,6 +148,320 @@ func main() {
 		"version": "0.1.0",
 	})
 
+	// Background workers are stopped together on shutdown
+	coordinator := shutdown.NewCoordinator()
+
+	// Singleton workers must not run on several replicas at once. With leader
+	// election they run only on the replica holding the lease, otherwise always.
+	var elector *leader.Elector
+	if cfg.LeaderElection.Enabled {
+		elector = leader.NewElector(cfg.LeaderElection, leader.NewRedisLease(redis, cfg.LeaderElection.LeaseKey()), server.Metrics().LeaderElectionIsLeader, logger)
+		logger.Info("leader election enabled", map[string]interface{}{
+			"holder": elector.Holder(),
+			"key":    cfg.LeaderElection.LeaseKey(),
+		})
+	}
+	goSingleton := func(interval time.Duration, newWorker func() leader.Worker) {
+		if elector != nil {
+			elector.Go(interval, newWorker)
+			return
+		}
+		worker := newWorker()
+		coordinator.Go(func() { worker.Start(interval) }, worker.Stop)
+	}
+
+	// Start notification retry worker
+	if server.Notifier().Enabled() {
+		interval := cfg.Notifications.Retry.CheckInterval
+		if interval == 0 {
+			interval = 30 * time.Second
+		}
+		goSingleton(interval, func() leader.Worker {
+			return workers.NewNotificationRetryWorker(server.Notifier(), logger)
+		})
+	}
+
+	// Start escalation worker
+	if cfg.Escalation.Enabled {
+		interval := cfg.Escalation.CheckInterval
+		if interval == 0 {
+			interval = time.Minute
+		}
+		goSingleton(interval, func() leader.Worker {
+			return workers.NewEscalationWorker(cfg.Escalation, database.NewIncidentRepository(db), server.Notifier(), logger)
+		})
+	}
+
+	// Start digest worker
+	if cfg.Digests.Enabled {
+		interval := cfg.Digests.CheckInterval
+		if interval == 0 {
+			interval = time.Minute
+		}
+		goSingleton(interval, func() leader.Worker {
+			return workers.NewDigestWorker(cfg.Digests, cfg.Notifications.DashboardURL, database.NewIncidentRepository(db), server.Notifier(), logger)
+		})
+	}
+
+	// Start GitHub reconciliation worker
+	if cfg.Reconciliation.Enabled {
+		interval := cfg.Reconciliation.CheckInterval
+		if interval == 0 {
+			interval = 5 * time.Minute
+		}
+		goSingleton(interval, func() leader.Worker {
+			return workers.NewReconciler(cfg.Reconciliation, database.NewIncidentRepository(db), githubClient, server.Notifier(), server.ReleaseWorkflowSlot, logger)
+		})
+	}
+
+	// Start workflow log worker, which keeps the failing step of failed runs
+	if cfg.WorkflowLogs.Enabled {
+		interval := cfg.WorkflowLogs.CheckInterval
+		if interval == 0 {
+			interval = 2 * time.Minute
+		}
+		goSingleton(interval, func() leader.Worker {
+			return workers.NewWorkflowLogWorker(cfg.WorkflowLogs, database.NewIncidentRepository(db), githubClient, logger)
+		})
+	}
+
+	// Start stale incident worker
+	if cfg.StaleIncidents.Enabled {
+		interval := cfg.StaleIncidents.CheckInterval
+		if interval == 0 {
+			interval = time.Minute
+		}
+		goSingleton(interval, func() leader.Worker {
+			return workers.NewStaleIncidentWorker(cfg.StaleIncidents, database.NewIncidentRepository(db), server.Notifier(), server.ReleaseWorkflowSlot, server.RemoveQueued, logger)
+		})
+	}
+
+	// Start remediation retry scheduler
+	if cfg.Retries.Enabled {
+		interval := cfg.Retries.CheckInterval
+		if interval == 0 {
+			interval = time.Minute
+		}
+		goSingleton(interval, func() leader.Worker {
+			return workers.NewRetryScheduler(cfg.Retries, database.NewIncidentRepository(db), server.DispatchIncident, logger)
+		})
+	}
+
+	// Start maintenance worker, which dispatches remediations deferred by a
+	// maintenance window or a dispatch burst, or held for their turn in
+	// slow-drip mode. Slow-drip turns are only as fine as its checks.
+	if cfg.Maintenance.Enabled || cfg.SlowDrip.Enabled || cfg.Concurrency.BurstThreshold > 0 {
+		interval := cfg.Maintenance.CheckInterval
+		if interval == 0 {
+			interval = time.Minute
+		}
+		if cfg.SlowDrip.Enabled && cfg.SlowDrip.DispatchInterval() < interval {
+			interval = cfg.SlowDrip.DispatchInterval()
+		}
+		goSingleton(interval, func() leader.Worker {
+			return workers.NewMaintenanceWorker(database.NewIncidentRepository(db), server.DispatchIncident, logger)
+		})
+	}
+
+	// Fetch the iCal feeds of deploy freeze calendars. Every replica fetches,
+	// since the calendar is kept in memory.
+	if calendar := server.Freezes(); calendar != nil && calendar.HasFeeds() {
+		freezeWorker := workers.NewFreezeCalendarWorker(calendar, logger)
+		coordinator.Go(func() { freezeWorker.Start(cfg.Freezes.RefreshInterval()) }, freezeWorker.Stop)
+	}
+
+	// Start KPI worker
+	if cfg.KPIs.Enabled {
+		interval := cfg.KPIs.RefreshInterval
+		if interval == 0 {
+			interval = time.Minute
+		}
+		metrics := server.Metrics()
+		kpiWorker := workers.NewKPIWorker(cfg.KPIs, database.NewIncidentRepository(db), workers.KPIGauges{
+			OpenIncidents: metrics.OpenIncidents,
+			SuccessRate:   metrics.IncidentSuccessRate,
+			MTTR:          metrics.IncidentMTTR,
+			LastRefresh:   metrics.KPILastRefresh,
+		}, logger)
+		coordinator.Go(func() { kpiWorker.Start(interval) }, kpiWorker.Stop)
+	}
+
+	// Start rollup worker
+	if cfg.Rollups.Enabled {
+		interval := cfg.Rollups.RefreshInterval
+		if interval == 0 {
+			interval = 5 * time.Minute
+		}
+		goSingleton(interval, func() leader.Worker {
+			return workers.NewRollupWorker(cfg.Rollups, database.NewIncidentRepository(db), logger)
+		})
+	}
+
+	// Start SLO worker
+	if len(cfg.SLOs.Objectives) > 0 {
+		interval := cfg.SLOs.RefreshInterval
+		if interval == 0 {
+			interval = 5 * time.Minute
+		}
+		metrics := server.Metrics()
+		sloWorker := workers.NewSLOWorker(slo.NewEvaluator(cfg.SLOs.Objectives, database.NewIncidentRepository(db)), workers.SLOGauges{
+			Compliance:           metrics.SLOCompliance,
+			Target:               metrics.SLOTarget,
+			ErrorBudgetRemaining: metrics.SLOErrorBudgetRemaining,
+			Eligible:             metrics.SLOEligibleIncidents,
+		}, logger)
+		coordinator.Go(func() { sloWorker.Start(interval) }, sloWorker.Stop)
+	}
+
+	// Start SLA worker
+	if cfg.SLAs.Enabled {
+		interval := cfg.SLAs.CheckInterval
+		if interval == 0 {
+			interval = time.Minute
+		}
+		goSingleton(interval, func() leader.Worker {
+			return workers.NewSLAWorker(cfg.SLAs, database.NewIncidentRepository(db), server.Notifier(), logger)
+		})
+	}
+
+	// Start storm release worker
+	if detector := server.Storms(); detector != nil {
+		interval := cfg.Storms.CheckInterval
+		if interval == 0 {
+			interval = time.Minute
+		}
+		stormWorker := workers.NewStormWorker(detector, database.NewIncidentRepository(db), server.Notifier(), logger)
+		coordinator.Go(func() { stormWorker.Start(interval) }, stormWorker.Stop)
+	}
+
+	// Check that the MCP servers answer, warning about those that do not
+	if len(cfg.MCPServers) > 0 {
+		checkCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		server.CheckMCPServers(checkCtx)
+		cancel()
+	}
+
+	// Check that each mapped repository has its branch and the remediation
+	// workflow, warning about those that do not instead of failing at
+	// dispatch time. Each repository takes a few requests, so the check runs
+	// in the background.
+	if len(cfg.ServiceMappings) > 0 {
+		go server.CheckServiceMappings(context.Background())
+	}
+
+	// Gather context from MCP servers before dispatching remediation
+	if cfg.Enrichment.Enabled {
+		enricher, err := enrichment.NewEnricher(cfg.Enrichment, cfg.MCPServers)
+		if err != nil {
+			logger.Error("failed to set up context enrichment", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		server.SetEnricher(enricher)
+	}
+
+	// Summarize incidents with a language model
+	if cfg.Summaries.Enabled {
+		summarizer, err := summary.NewSummarizer(cfg.Summaries)
+		if err != nil {
+			logger.Error("failed to set up incident summaries", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		server.SetSummarizer(summarizer)
+	}
+
+	// Look up similar past incidents by the embeddings of their errors
+	if cfg.Similarity.Enabled {
+		embedder, err := similarity.NewEmbedder(cfg.Similarity)
+		if err != nil {
+			logger.Error("failed to set up similarity search", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		server.SetEmbedder(embedder)
+	}
+
+	if injector != nil {
+		server.SetChaos(injector)
+	}
+
+	// Keep the files attached to incidents in the configured blob store
+	if cfg.Attachments.Enabled {
+		store, err := blob.New(cfg.Attachments)
+		if err != nil {
+			logger.Error("failed to set up attachment storage", map[string]interface{}{
+				"error": err.Error(),
+			})
+			os.Exit(1)
+		}
+		server.SetAttachmentStore(store)
+	}
+
+	// Suggest severities for incidents whose provider gave none that maps,
+	// retraining the model on every replica since it lives in memory
+	if cfg.Severity.Enabled {
+		classifier := severity.NewClassifier(cfg.Severity)
+		server.SetSeverityClassifier(classifier)
+		trainer := workers.NewSeverityTrainer(cfg.Severity, database.NewIncidentRepository(db), classifier, logger)
+		coordinator.Go(trainer.Start, trainer.Stop)
+	}
+
+	// Buffer webhooks that cannot be stored during a database outage, and
+	// drain them once it recovers. Every replica drains, since a disk buffer
+	// is local to its replica and popping a Redis buffer is atomic.
+	if bufferCfg := cfg.Ingestion.Buffer; bufferCfg.Enabled {
+		var buffer database.WebhookBuffer
+		if bufferCfg.BufferBackend() == config.BufferBackendDisk {
+			disk, err := database.NewDiskWebhookBuffer(bufferCfg.Path, bufferCfg.Limit())
+			if err != nil {
+				logger.Error("failed to set up ingestion buffer", map[string]interface{}{
+					"error": err.Error(),
+				})
+				os.Exit(1)
+			}
+			disk.SetCipher(cipher)
+			buffer = disk
+		} else {
+			shared := database.NewRedisWebhookBuffer(redis, bufferCfg.BufferKey(), bufferCfg.Limit())
+			shared.SetCipher(cipher)
+			buffer = shared
+		}
+		server.SetWebhookBuffer(buffer)
+
+		bufferWorker := workers.NewIngestionBufferWorker(buffer, db.Health, server.ProcessBuffered, server.Metrics().IngestionBufferDepth, logger)
+		coordinator.Go(func() { bufferWorker.Start(bufferCfg.Interval()) }, bufferWorker.Stop)
+	}
+
+	// Keep the in-memory workflow slots and queues across restarts. Queued
+	// incidents of the last run are restored before requests are served, so
+	// that workflows finishing now dispatch them.
+	var queueSnapshots *workers.QueueSnapshotWorker
+	if cfg.Concurrency.Store != config.ConcurrencyStoreRedis {
+		queueSnapshots = workers.NewQueueSnapshotWorker(githubClient, database.NewIncidentRepository(db), logger)
+		restoreCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		if _, err := queueSnapshots.Restore(restoreCtx); err != nil {
+			logger.Error("failed to restore workflow queues", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		cancel()
+		coordinator.Go(func() { queueSnapshots.Start(cfg.Concurrency.SnapshotEvery()) }, queueSnapshots.Stop)
+	}
+
+	// Release the slots of workflows that never reported back
+	slotWatchdog := workers.NewSlotWatchdogWorker(server.ExpireWorkflowSlots, logger)
+	coordinator.Go(func() { slotWatchdog.Start(time.Minute) }, slotWatchdog.Stop)
+
+	// Campaign for the lease once every singleton worker is registered. The
+	// coordinator stops workers in reverse order, so the elector stops first.
+	if elector != nil {
+		coordinator.Go(elector.Run, elector.Stop)
+	}
+
 	// Create HTTP server
 	httpServer := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.Server.Port),
@@ -94,11 +494,36 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// Stop accepting requests, then let in-flight requests finish
 	if err := httpServer.Shutdown(ctx); err != nil {
 		logger.Error("server shutdown error", map[string]interface{}{
 			"error": err.Error(),
 		})
 	}
 
+	// Stop the workers, letting any check in progress finish
+	if err := coordinator.Shutdown(ctx); err != nil {
+		logger.Error("worker shutdown error", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	// Process accepted webhooks, wait for background dispatches and
+	// notifications, and flush due notification retries
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Error("background task shutdown error", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	// Save the slots and queues as the background dispatches left them
+	if queueSnapshots != nil {
+		if err := queueSnapshots.Save(ctx); err != nil {
+			logger.Error("failed to save workflow queues", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+
 	logger.Info("server stopped", nil)
 }
@@ -18,6 +18,14 @@ type WebhookAdapter interface {
 	ProviderName() string
 }
 
+// Versioned is implemented by adapters that parse several generations of
+// their provider's payloads, each along its own path
+type Versioned interface {
+	// PayloadVersion names the generation of a payload, e.g. legacy or
+	// unified for Grafana
+	PayloadVersion(body []byte) string
+}
+
 // Registry manages webhook adapters
 type Registry struct {
 	adapters map[string]WebhookAdapter
@@ -34,6 +42,7 @@ func NewRegistry() *Registry {
 	r.Register(NewPagerDutyAdapter())
 	r.Register(NewGrafanaAdapter())
 	r.Register(NewSentryAdapter())
+	r.Register(NewSplunkAdapter())
 
 	return r
 }
@@ -43,6 +52,13 @@ func (r *Registry) Register(adapter WebhookAdapter) {
 	r.adapters[adapter.ProviderName()] = adapter
 }
 
+// Wrap replaces every registered adapter with wrap's wrapper of it
+func (r *Registry) Wrap(wrap func(WebhookAdapter) WebhookAdapter) {
+	for name, adapter := range r.adapters {
+		r.adapters[name] = wrap(adapter)
+	}
+}
+
 // Get retrieves an adapter by provider name
 func (r *Registry) Get(provider string) (WebhookAdapter, bool) {
 	adapter, ok := r.adapters[provider]
@@ -53,6 +53,10 @@ func TestProperty_ProviderFormatTransformation(t *testing.T) {
 				t.Logf("Missing incident ID")
 				return false
 			}
+			if incident.ProviderRef != id {
+				t.Logf("Expected provider reference %q, got %q", id, incident.ProviderRef)
+				return false
+			}
 			if incident.ServiceName == "" {
 				t.Logf("Missing service name")
 				return false
@@ -377,6 +381,16 @@ func TestProperty_MalformedDataErrorHandling(t *testing.T) {
 		gen.SliceOf(gen.UInt8()),
 	))
 
+	properties.Property("Random bytes don't crash Splunk parser", prop.ForAll(
+		func(randomBytes []byte) bool {
+			adapter := NewSplunkAdapter()
+			_, err := adapter.Parse(randomBytes)
+			// We expect an error, but the parser should not panic
+			return err != nil
+		},
+		gen.SliceOf(gen.UInt8()),
+	))
+
 	// Test that invalid JSON structures return errors
 	properties.Property("Invalid JSON returns error for Datadog", prop.ForAll(
 		func(invalidJSON string) bool {
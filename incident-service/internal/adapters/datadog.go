@@ -1,6 +1,7 @@
 package adapters
 
 import (
+	"bytes"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -18,6 +19,7 @@ import (
 // DatadogAdapter handles Datadog webhook payloads
 type DatadogAdapter struct {
 	secret string
+	acceptedEvents // alert types; every alert type by default
 }
 
 // NewDatadogAdapter creates a new Datadog adapter
@@ -41,7 +43,7 @@ func (a *DatadogAdapter) Validate(r *http.Request) error {
 
 	signature := r.Header.Get("X-Datadog-Signature")
 	if signature == "" {
-		return fmt.Errorf("missing X-Datadog-Signature header")
+		return failure(ReasonSignature, "missing X-Datadog-Signature header")
 	}
 
 	body, err := io.ReadAll(r.Body)
@@ -55,7 +57,7 @@ func (a *DatadogAdapter) Validate(r *http.Request) error {
 	expectedSignature := hex.EncodeToString(mac.Sum(nil))
 
 	if !hmac.Equal([]byte(signature), []byte(expectedSignature)) {
-		return fmt.Errorf("invalid signature")
+		return failure(ReasonSignature, "invalid signature")
 	}
 
 	return nil
@@ -65,25 +67,30 @@ func (a *DatadogAdapter) Validate(r *http.Request) error {
 func (a *DatadogAdapter) Parse(body []byte) (*models.Incident, error) {
 	var payload DatadogPayload
 	if err := json.Unmarshal(body, &payload); err != nil {
-		return nil, fmt.Errorf("failed to parse datadog payload: %w", err)
+		return nil, failure(ReasonSchema, "failed to parse datadog payload: %w", err)
 	}
 
 	// Validate required fields
 	if payload.ID == "" {
-		return nil, fmt.Errorf("missing required field: id")
+		return nil, failure(ReasonSchema, "missing required field: id")
 	}
 	if payload.Title == "" {
-		return nil, fmt.Errorf("missing required field: title")
+		return nil, failure(ReasonSchema, "missing required field: title")
+	}
+
+	// Only process the accepted alert types
+	if !a.accepts(payload.AlertType, nil) {
+		return nil, failure(ReasonUnsupportedEvent, "unsupported alert type: %s", payload.AlertType)
 	}
 
 	// Extract service name from tags
 	serviceName := extractServiceFromTags(payload.Tags)
 	if serviceName == "" {
-		serviceName = "unknown"
+		serviceName = UnknownService
 	}
 
 	// Map priority to severity
-	severity := mapDatadogSeverity(payload.Priority)
+	severity, mapped := mapDatadogSeverity(payload.Priority)
 
 	// Construct error message
 	errorMessage := payload.Title
@@ -110,6 +117,9 @@ func (a *DatadogAdapter) Parse(body []byte) (*models.Incident, error) {
 	if payload.Snapshot != "" {
 		providerData["snapshot_url"] = payload.Snapshot
 	}
+	if payload.AggregationKey != "" {
+		providerData["aggregation_key"] = payload.AggregationKey
+	}
 
 	incident := &models.Incident{
 		ID:           incidentID,
@@ -120,14 +130,22 @@ func (a *DatadogAdapter) Parse(body []byte) (*models.Incident, error) {
 		Severity:     severity,
 		Status:       models.StatusPending,
 		Provider:     "datadog",
+		ProviderRef:  payload.ID,
 		ProviderData: providerData,
 		CreatedAt:    time.Now().UTC(),
 		UpdatedAt:    time.Now().UTC(),
 	}
 
+	incident.SeverityDefaulted = !mapped
+
 	return incident, nil
 }
 
+// PayloadSchema returns the type Datadog payloads are decoded into
+func (a *DatadogAdapter) PayloadSchema(body []byte) interface{} {
+	return &DatadogPayload{}
+}
+
 // DatadogPayload represents a Datadog webhook payload
 type DatadogPayload struct {
 	ID             string   `json:"id"`
@@ -142,6 +160,48 @@ type DatadogPayload struct {
 	Snapshot       string   `json:"snapshot"`
 }
 
+// UnmarshalJSON accepts the alert id as a string or, from templates that
+// leave $ID unquoted, a number, and the tags as a list or as the
+// comma-separated string that $TAGS expands to
+func (p *DatadogPayload) UnmarshalJSON(data []byte) error {
+	type payload DatadogPayload
+	var raw struct {
+		payload
+		ID   json.RawMessage `json:"id"`
+		Tags json.RawMessage `json:"tags"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	id, err := stringOrNumber(raw.ID)
+	if err != nil {
+		return fmt.Errorf("id: %w", err)
+	}
+	*p = DatadogPayload(raw.payload)
+	p.ID = id
+
+	tags := bytes.TrimSpace(raw.Tags)
+	if len(tags) == 0 {
+		return nil
+	}
+	if tags[0] != '"' {
+		if err := json.Unmarshal(tags, &p.Tags); err != nil {
+			return fmt.Errorf("tags: %w", err)
+		}
+		return nil
+	}
+	var list string
+	if err := json.Unmarshal(tags, &list); err != nil {
+		return fmt.Errorf("tags: %w", err)
+	}
+	for _, tag := range strings.Split(list, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			p.Tags = append(p.Tags, tag)
+		}
+	}
+	return nil
+}
+
 // extractServiceFromTags extracts service name from Datadog tags
 func extractServiceFromTags(tags []string) string {
 	for _, tag := range tags {
@@ -152,18 +212,19 @@ func extractServiceFromTags(tags []string) string {
 	return ""
 }
 
-// mapDatadogSeverity maps Datadog priority to internal severity
-func mapDatadogSeverity(priority string) string {
+// mapDatadogSeverity maps Datadog priority to internal severity. It reports
+// false when the priority is missing or unknown and medium is assumed.
+func mapDatadogSeverity(priority string) (string, bool) {
 	switch strings.ToLower(priority) {
 	case "p1":
-		return "critical"
+		return "critical", true
 	case "p2":
-		return "high"
+		return "high", true
 	case "p3":
-		return "medium"
+		return "medium", true
 	case "p4", "low":
-		return "low"
+		return "low", true
 	default:
-		return "medium"
+		return "medium", false
 	}
 }
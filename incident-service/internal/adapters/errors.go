@@ -0,0 +1,51 @@
+package adapters
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Failure reasons reported when a webhook cannot be turned into an incident
+const (
+	ReasonSignature        = "signature"         // missing or invalid signature or credentials
+	ReasonSchema           = "schema"            // malformed JSON or missing required fields
+	ReasonUnsupportedEvent = "unsupported_event" // a valid event the platform does not act on
+	ReasonMissingService   = "missing_service"   // no service name could be determined
+	ReasonReplay           = "replay"            // a stale or already accepted delivery
+	ReasonSource           = "source"            // sent from outside the provider's allowed address ranges
+	ReasonInvalidField     = "invalid_field"     // a field too long or with a value the platform does not accept
+	ReasonOther            = "other"
+)
+
+// UnknownService is the service name used when a payload does not identify a service
+const UnknownService = "unknown"
+
+// FailureError is a webhook validation or parse error classified by reason
+type FailureError struct {
+	Reason string
+	Err    error
+}
+
+// Error returns the underlying error message
+func (e *FailureError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *FailureError) Unwrap() error {
+	return e.Err
+}
+
+// failure creates a classified error with a formatted message
+func failure(reason, format string, args ...interface{}) error {
+	return &FailureError{Reason: reason, Err: fmt.Errorf(format, args...)}
+}
+
+// FailureReason returns the reason of a classified error, or ReasonOther
+func FailureReason(err error) string {
+	var failureErr *FailureError
+	if errors.As(err, &failureErr) {
+		return failureErr.Reason
+	}
+	return ReasonOther
+}
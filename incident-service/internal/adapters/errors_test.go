@@ -0,0 +1,59 @@
+package adapters
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFailureReasons(t *testing.T) {
+	parse := func(adapter WebhookAdapter, body string) error {
+		_, err := adapter.Parse([]byte(body))
+		return err
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"malformed json", parse(&SentryAdapter{}, `{`), ReasonSchema},
+		{"missing required field", parse(&DatadogAdapter{}, `{"title": "boom"}`), ReasonSchema},
+		{"unsupported sentry action", parse(&SentryAdapter{}, `{"action": "resolved"}`), ReasonUnsupportedEvent},
+		{"unsupported pagerduty event", parse(&PagerDutyAdapter{}, `{"event": {"event_type": "incident.acknowledged"}}`), ReasonUnsupportedEvent},
+		{"grafana without service", parse(&GrafanaAdapter{}, `{"state": "alerting", "title": "boom"}`), ReasonMissingService},
+		{"splunk without incident", parse(&SplunkAdapter{}, `{"status": "anomalous"}`), ReasonSchema},
+		{"cleared splunk alert", parse(&SplunkAdapter{}, `{"incidentId": "1", "status": "ok"}`), ReasonUnsupportedEvent},
+		{"unclassified", errors.New("boom"), ReasonOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := FailureReason(tt.err); got != tt.expected {
+				t.Errorf("FailureReason() = %s, want %s (error: %v)", got, tt.expected, tt.err)
+			}
+		})
+	}
+}
+
+func TestFailureReasons_Signature(t *testing.T) {
+	adapters := []WebhookAdapter{
+		&SentryAdapter{secret: "secret"},
+		&DatadogAdapter{secret: "secret"},
+		&PagerDutyAdapter{secret: "secret"},
+		&GrafanaAdapter{secret: "secret"},
+		&SplunkAdapter{secret: "secret"},
+	}
+
+	for _, adapter := range adapters {
+		req := httptest.NewRequest("POST", "/api/v1/webhooks/incidents", bytes.NewReader([]byte(`{}`)))
+		err := adapter.Validate(req)
+		if got := FailureReason(err); got != ReasonSignature {
+			t.Errorf("%s: FailureReason() = %s, want %s (error: %v)", adapter.ProviderName(), got, ReasonSignature, err)
+		}
+	}
+}
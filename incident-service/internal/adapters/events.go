@@ -0,0 +1,62 @@
+package adapters
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EventFilter is implemented by adapters that only create incidents from
+// some of their provider's events, such as firing alerts but not resolved
+// ones
+type EventFilter interface {
+	// SetAcceptedEvents replaces the adapter's default event types or
+	// states that create incidents
+	SetAcceptedEvents(events []string)
+}
+
+// acceptedEvents is the EventFilter of an adapter
+type acceptedEvents struct {
+	events []string
+}
+
+// SetAcceptedEvents replaces the adapter's default event types or states
+// that create incidents
+func (a *acceptedEvents) SetAcceptedEvents(events []string) {
+	a.events = append([]string(nil), events...)
+}
+
+// accepts reports whether event creates incidents, ignoring case. Until
+// events are set, the adapter's defaults are accepted; with no defaults,
+// every event is.
+func (a *acceptedEvents) accepts(event string, defaults []string) bool {
+	events := a.events
+	if events == nil {
+		events = defaults
+	}
+	if len(events) == 0 {
+		return true
+	}
+	for _, accepted := range events {
+		if strings.EqualFold(accepted, event) {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptEvents sets the events that create incidents for each provider named
+// in events. Providers that are not named keep their defaults.
+func (r *Registry) AcceptEvents(events map[string][]string) error {
+	for provider, accepted := range events {
+		adapter, ok := r.adapters[provider]
+		if !ok {
+			return fmt.Errorf("unknown provider %q", provider)
+		}
+		filter, ok := adapter.(EventFilter)
+		if !ok {
+			return fmt.Errorf("provider %q does not filter events", provider)
+		}
+		filter.SetAcceptedEvents(accepted)
+	}
+	return nil
+}
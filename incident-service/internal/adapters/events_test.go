@@ -0,0 +1,62 @@
+package adapters
+
+import (
+	"testing"
+)
+
+func TestRegistry_AcceptEvents(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.AcceptEvents(map[string][]string{
+		"grafana":   {"alerting", "firing", "pending"},
+		"sentry":    {"created", "Regression"},
+		"pagerduty": {"incident.triggered", "incident.reopened"},
+		"datadog":   {"error"},
+	}); err != nil {
+		t.Fatalf("AcceptEvents() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		provider string
+		body     string
+		accepted bool
+	}{
+		{"grafana pending", "grafana", `{"state": "pending", "ruleName": "checkout", "title": "latency"}`, true},
+		{"grafana ok", "grafana", `{"state": "ok", "ruleName": "checkout", "title": "latency"}`, false},
+		{"sentry regression, ignoring case", "sentry", `{"action": "regression", "data": {"issue": {"id": "1", "title": "boom", "project": "checkout"}}}`, true},
+		{"sentry resolved", "sentry", `{"action": "resolved", "data": {"issue": {"id": "1", "title": "boom", "project": "checkout"}}}`, false},
+		{"pagerduty reopened", "pagerduty", `{"event": {"event_type": "incident.reopened", "data": {"id": "P1", "title": "boom"}}}`, true},
+		{"pagerduty acknowledged", "pagerduty", `{"event": {"event_type": "incident.acknowledged", "data": {"id": "P1", "title": "boom"}}}`, false},
+		{"datadog error", "datadog", `{"id": "1", "title": "boom", "alert_type": "error"}`, true},
+		{"datadog recovery", "datadog", `{"id": "1", "title": "boom", "alert_type": "success"}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, _ := registry.Get(tt.provider)
+			_, err := adapter.Parse([]byte(tt.body))
+			if tt.accepted && err != nil {
+				t.Errorf("expected the event accepted, got %v", err)
+			}
+			if !tt.accepted && FailureReason(err) != ReasonUnsupportedEvent {
+				t.Errorf("expected the event ignored as unsupported, got %v", err)
+			}
+		})
+	}
+
+	if err := registry.AcceptEvents(map[string][]string{"newrelic": {"open"}}); err == nil {
+		t.Error("expected an unknown provider rejected")
+	}
+}
+
+func TestAcceptedEvents_Defaults(t *testing.T) {
+	// Adapters accept their defaults until events are set, and Datadog every
+	// alert type
+	datadog := NewDatadogAdapter()
+	if _, err := datadog.Parse([]byte(`{"id": "1", "title": "boom", "alert_type": "success"}`)); err != nil {
+		t.Errorf("expected every Datadog alert type accepted, got %v", err)
+	}
+	grafana := NewGrafanaAdapter()
+	if _, err := grafana.Parse([]byte(`{"state": "pending", "ruleName": "checkout", "title": "latency"}`)); FailureReason(err) != ReasonUnsupportedEvent {
+		t.Errorf("expected pending Grafana alerts ignored by default, got %v", err)
+	}
+}
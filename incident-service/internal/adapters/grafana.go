@@ -14,8 +14,13 @@ import (
 // GrafanaAdapter handles Grafana webhook payloads
 type GrafanaAdapter struct {
 	secret string
+	acceptedEvents // alert states
 }
 
+// DefaultGrafanaStates are the alert states that create incidents by
+// default: firing alerts, in legacy and unified alerting
+var DefaultGrafanaStates = []string{"alerting", "firing"}
+
 // NewGrafanaAdapter creates a new Grafana adapter
 func NewGrafanaAdapter() *GrafanaAdapter {
 	return &GrafanaAdapter{
@@ -37,13 +42,13 @@ func (a *GrafanaAdapter) Validate(r *http.Request) error {
 
 	authHeader := r.Header.Get("Authorization")
 	if authHeader == "" {
-		return fmt.Errorf("missing Authorization header")
+		return failure(ReasonSignature, "missing Authorization header")
 	}
 
 	// Grafana sends "Bearer <secret>"
 	expectedAuth := "Bearer " + a.secret
 	if authHeader != expectedAuth {
-		return fmt.Errorf("invalid authorization")
+		return failure(ReasonSignature, "invalid authorization")
 	}
 
 	return nil
@@ -51,14 +56,23 @@ func (a *GrafanaAdapter) Validate(r *http.Request) error {
 
 // Parse transforms Grafana payload to internal Incident
 func (a *GrafanaAdapter) Parse(body []byte) (*models.Incident, error) {
+	// Both generations of payloads are read into the legacy form
+	version := a.PayloadVersion(body)
 	var payload GrafanaPayload
-	if err := json.Unmarshal(body, &payload); err != nil {
-		return nil, fmt.Errorf("failed to parse grafana payload: %w", err)
+	var err error
+	switch version {
+	case GrafanaUnified:
+		payload, err = parseGrafanaUnified(body)
+	default:
+		payload, err = parseGrafanaLegacy(body)
+	}
+	if err != nil {
+		return nil, failure(ReasonSchema, "failed to parse grafana %s payload: %w", version, err)
 	}
 
-	// Only process firing alerts
-	if payload.State != "alerting" && payload.State != "firing" {
-		return nil, fmt.Errorf("unsupported alert state: %s", payload.State)
+	// Only process the accepted states, by default firing alerts
+	if !a.accepts(payload.State, DefaultGrafanaStates) {
+		return nil, failure(ReasonUnsupportedEvent, "unsupported alert state: %s", payload.State)
 	}
 
 	// Extract service name from labels
@@ -66,9 +80,12 @@ func (a *GrafanaAdapter) Parse(body []byte) (*models.Incident, error) {
 	if serviceName == "" {
 		serviceName = payload.RuleName
 	}
+	if serviceName == "" {
+		return nil, failure(ReasonMissingService, "missing service: no service label or rule name")
+	}
 
 	// Map state to severity
-	severity := mapGrafanaSeverity(payload.State, payload.Labels)
+	severity, mapped := mapGrafanaSeverity(payload.State, payload.Labels)
 
 	// Construct error message from title and message
 	errorMessage := payload.Title
@@ -87,10 +104,11 @@ func (a *GrafanaAdapter) Parse(body []byte) (*models.Incident, error) {
 
 	// Store provider data
 	providerData := map[string]interface{}{
-		"rule_id":   payload.RuleID,
-		"rule_name": payload.RuleName,
-		"state":     payload.State,
-		"labels":    payload.Labels,
+		"rule_id":         payload.RuleID,
+		"rule_name":       payload.RuleName,
+		"state":           payload.State,
+		"labels":          payload.Labels,
+		"payload_version": version,
 	}
 	if payload.RuleURL != "" {
 		providerData["rule_url"] = payload.RuleURL
@@ -105,15 +123,50 @@ func (a *GrafanaAdapter) Parse(body []byte) (*models.Incident, error) {
 		Severity:     severity,
 		Status:       models.StatusPending,
 		Provider:     "grafana",
+		ProviderRef:  payload.RuleID,
 		ProviderData: providerData,
 		CreatedAt:    time.Now().UTC(),
 		UpdatedAt:    time.Now().UTC(),
 	}
 
+	incident.SeverityDefaulted = !mapped
+
 	return incident, nil
 }
 
-// GrafanaPayload represents a Grafana webhook payload
+// Generations of Grafana webhook payloads
+const (
+	GrafanaLegacy  = "legacy"  // dashboard alerting, before Grafana 8: one rule per webhook
+	GrafanaUnified = "unified" // unified alerting, Grafana 8 and later: a group of alerts per webhook
+)
+
+// PayloadVersion tells unified alerting payloads, which carry a list of
+// alerts and the receiver they were routed to, from legacy ones
+func (a *GrafanaAdapter) PayloadVersion(body []byte) string {
+	var probe struct {
+		Receiver string          `json:"receiver"`
+		Alerts   json.RawMessage `json:"alerts"`
+	}
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return GrafanaLegacy
+	}
+	if probe.Receiver != "" || (len(probe.Alerts) > 0 && string(probe.Alerts) != "null") {
+		return GrafanaUnified
+	}
+	return GrafanaLegacy
+}
+
+// PayloadSchema returns the type payloads of body's generation are decoded
+// into
+func (a *GrafanaAdapter) PayloadSchema(body []byte) interface{} {
+	if a.PayloadVersion(body) == GrafanaUnified {
+		return &GrafanaUnifiedPayload{}
+	}
+	return &GrafanaPayload{}
+}
+
+// GrafanaPayload represents a legacy Grafana webhook payload, the form both
+// generations are parsed into
 type GrafanaPayload struct {
 	Title       string            `json:"title"`
 	State       string            `json:"state"`
@@ -123,6 +176,105 @@ type GrafanaPayload struct {
 	RuleURL     string            `json:"ruleUrl"`
 	Labels      map[string]string `json:"labels"`
 	Annotations map[string]string `json:"annotations"`
+
+	// Dashboard alerting sends the rule's tags rather than labels
+	Tags map[string]string `json:"tags"`
+}
+
+// UnmarshalJSON accepts the rule id as the number that dashboard alerting
+// sends or as a string
+func (p *GrafanaPayload) UnmarshalJSON(data []byte) error {
+	type payload GrafanaPayload
+	var raw struct {
+		payload
+		RuleID json.RawMessage `json:"ruleId"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	ruleID, err := stringOrNumber(raw.RuleID)
+	if err != nil {
+		return fmt.Errorf("ruleId: %w", err)
+	}
+	*p = GrafanaPayload(raw.payload)
+	p.RuleID = ruleID
+	return nil
+}
+
+// GrafanaUnifiedPayload represents a unified alerting webhook payload
+type GrafanaUnifiedPayload struct {
+	Receiver          string            `json:"receiver"`
+	Status            string            `json:"status"`
+	State             string            `json:"state"`
+	Title             string            `json:"title"`
+	Message           string            `json:"message"`
+	GroupKey          string            `json:"groupKey"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Alerts            []GrafanaAlert    `json:"alerts"`
+}
+
+// GrafanaAlert is one alert of a unified alerting webhook
+type GrafanaAlert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	Fingerprint  string            `json:"fingerprint"`
+	GeneratorURL string            `json:"generatorURL"`
+}
+
+// parseGrafanaLegacy parses a dashboard alerting payload, or one of a
+// single rule with labels
+func parseGrafanaLegacy(body []byte) (GrafanaPayload, error) {
+	var payload GrafanaPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return GrafanaPayload{}, err
+	}
+	if len(payload.Labels) == 0 {
+		payload.Labels = payload.Tags
+	}
+	return payload, nil
+}
+
+// parseGrafanaUnified parses a unified alerting payload into the legacy
+// form. The group is described by its common labels and annotations, falling
+// back to those of its first alert, and identified by the first alert's
+// fingerprint.
+func parseGrafanaUnified(body []byte) (GrafanaPayload, error) {
+	var unified GrafanaUnifiedPayload
+	if err := json.Unmarshal(body, &unified); err != nil {
+		return GrafanaPayload{}, err
+	}
+
+	payload := GrafanaPayload{
+		Title:       unified.Title,
+		State:       unified.State,
+		Message:     unified.Message,
+		Labels:      unified.CommonLabels,
+		Annotations: unified.CommonAnnotations,
+	}
+	// Grafana only sends the legacy state for compatibility
+	if payload.State == "" {
+		payload.State = unified.Status
+	}
+	if len(unified.Alerts) > 0 {
+		first := unified.Alerts[0]
+		if len(payload.Labels) == 0 {
+			payload.Labels = first.Labels
+		}
+		if len(payload.Annotations) == 0 {
+			payload.Annotations = first.Annotations
+		}
+		payload.RuleID = first.Fingerprint
+		payload.RuleURL = first.GeneratorURL
+	}
+	payload.RuleName = payload.Labels["alertname"]
+	if payload.RuleID == "" {
+		payload.RuleID = unified.GroupKey
+	}
+	return payload, nil
 }
 
 // extractServiceFromLabels extracts service name from Grafana labels
@@ -140,22 +292,24 @@ func extractServiceFromLabels(labels map[string]string) string {
 	return ""
 }
 
-// mapGrafanaSeverity maps Grafana alert state to internal severity
-func mapGrafanaSeverity(state string, labels map[string]string) string {
+// mapGrafanaSeverity maps Grafana alert state to internal severity. It
+// reports false when no severity label says how severe the alert is and the
+// severity is guessed from the state.
+func mapGrafanaSeverity(state string, labels map[string]string) (string, bool) {
 	// Check if severity is explicitly set in labels
 	if severity, ok := labels["severity"]; ok {
 		switch strings.ToLower(severity) {
 		case "critical", "high", "medium", "low":
-			return strings.ToLower(severity)
+			return strings.ToLower(severity), true
 		}
 	}
 
 	// Default mapping based on state
 	switch strings.ToLower(state) {
 	case "alerting", "firing":
-		return "high"
+		return "high", false
 	default:
-		return "medium"
+		return "medium", false
 	}
 }
 
@@ -0,0 +1,27 @@
+package adapters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// stringOrNumber decodes an identifier that some versions of a provider's
+// payloads send as a string and others as a number, e.g. Grafana's ruleId.
+// A missing or null value decodes to "".
+func stringOrNumber(raw json.RawMessage) (string, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return "", nil
+	}
+	if raw[0] == '"' {
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return s, err
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return "", fmt.Errorf("expected a string or a number, got %s", raw)
+	}
+	return n.String(), nil
+}
@@ -18,8 +18,12 @@ import (
 // PagerDutyAdapter handles PagerDuty webhook payloads
 type PagerDutyAdapter struct {
 	secret string
+	acceptedEvents // event types
 }
 
+// DefaultPagerDutyEvents are the event types that create incidents by default
+var DefaultPagerDutyEvents = []string{"incident.triggered"}
+
 // NewPagerDutyAdapter creates a new PagerDuty adapter
 func NewPagerDutyAdapter() *PagerDutyAdapter {
 	return &PagerDutyAdapter{
@@ -41,7 +45,7 @@ func (a *PagerDutyAdapter) Validate(r *http.Request) error {
 
 	signature := r.Header.Get("X-PagerDuty-Signature")
 	if signature == "" {
-		return fmt.Errorf("missing X-PagerDuty-Signature header")
+		return failure(ReasonSignature, "missing X-PagerDuty-Signature header")
 	}
 
 	body, err := io.ReadAll(r.Body)
@@ -55,22 +59,45 @@ func (a *PagerDutyAdapter) Validate(r *http.Request) error {
 	expectedSignature := "v1=" + hex.EncodeToString(mac.Sum(nil))
 
 	if !hmac.Equal([]byte(signature), []byte(expectedSignature)) {
-		return fmt.Errorf("invalid signature")
+		return failure(ReasonSignature, "invalid signature")
 	}
 
 	return nil
 }
 
+// Delivery returns when PagerDuty sent the webhook, from the event's
+// occurred_at, which the signature covers. Each delivery is identified by
+// its signature.
+func (a *PagerDutyAdapter) Delivery(r *http.Request, body []byte) (*Delivery, bool, error) {
+	if a.secret == "" {
+		return nil, false, nil
+	}
+
+	var payload PagerDutyPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, false, failure(ReasonSchema, "failed to parse pagerduty payload: %w", err)
+	}
+	if payload.Event.OccurredAt == "" {
+		return nil, false, failure(ReasonReplay, "missing event.occurred_at")
+	}
+	sentAt, err := time.Parse(time.RFC3339, payload.Event.OccurredAt)
+	if err != nil {
+		return nil, false, failure(ReasonReplay, "invalid event.occurred_at: %w", err)
+	}
+
+	return &Delivery{SentAt: sentAt, Nonce: r.Header.Get("X-PagerDuty-Signature")}, true, nil
+}
+
 // Parse transforms PagerDuty payload to internal Incident
 func (a *PagerDutyAdapter) Parse(body []byte) (*models.Incident, error) {
 	var payload PagerDutyPayload
 	if err := json.Unmarshal(body, &payload); err != nil {
-		return nil, fmt.Errorf("failed to parse pagerduty payload: %w", err)
+		return nil, failure(ReasonSchema, "failed to parse pagerduty payload: %w", err)
 	}
 
-	// Only process incident.triggered events
-	if payload.Event.EventType != "incident.triggered" {
-		return nil, fmt.Errorf("unsupported event type: %s", payload.Event.EventType)
+	// Only process the accepted event types
+	if !a.accepts(payload.Event.EventType, DefaultPagerDutyEvents) {
+		return nil, failure(ReasonUnsupportedEvent, "unsupported event type: %s", payload.Event.EventType)
 	}
 
 	data := payload.Event.Data
@@ -78,11 +105,11 @@ func (a *PagerDutyAdapter) Parse(body []byte) (*models.Incident, error) {
 	// Extract service name
 	serviceName := data.Service.Summary
 	if serviceName == "" {
-		serviceName = "unknown"
+		serviceName = UnknownService
 	}
 
 	// Map urgency to severity
-	severity := mapPagerDutySeverity(data.Urgency)
+	severity, mapped := mapPagerDutySeverity(data.Urgency)
 
 	// Extract error message
 	errorMessage := data.Title
@@ -117,14 +144,22 @@ func (a *PagerDutyAdapter) Parse(body []byte) (*models.Incident, error) {
 		Severity:     severity,
 		Status:       models.StatusPending,
 		Provider:     "pagerduty",
+		ProviderRef:  data.ID,
 		ProviderData: providerData,
 		CreatedAt:    time.Now().UTC(),
 		UpdatedAt:    time.Now().UTC(),
 	}
 
+	incident.SeverityDefaulted = !mapped
+
 	return incident, nil
 }
 
+// PayloadSchema returns the type PagerDuty payloads are decoded into
+func (a *PagerDutyAdapter) PayloadSchema(body []byte) interface{} {
+	return &PagerDutyPayload{}
+}
+
 // PagerDutyPayload represents a PagerDuty webhook payload
 type PagerDutyPayload struct {
 	Event PagerDutyEvent `json:"event"`
@@ -161,14 +196,15 @@ type PagerDutyIncidentBody struct {
 	Details string `json:"details"`
 }
 
-// mapPagerDutySeverity maps PagerDuty urgency to internal severity
-func mapPagerDutySeverity(urgency string) string {
+// mapPagerDutySeverity maps PagerDuty urgency to internal severity. It
+// reports false when the urgency is missing or unknown and medium is assumed.
+func mapPagerDutySeverity(urgency string) (string, bool) {
 	switch strings.ToLower(urgency) {
 	case "high":
-		return "critical"
+		return "critical", true
 	case "low":
-		return "medium"
+		return "medium", true
 	default:
-		return "medium"
+		return "medium", false
 	}
 }
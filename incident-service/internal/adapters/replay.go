@@ -0,0 +1,21 @@
+package adapters
+
+import (
+	"net/http"
+	"time"
+)
+
+// Delivery identifies one webhook delivery, for rejecting replays
+type Delivery struct {
+	SentAt time.Time // when the provider sent the webhook
+	Nonce  string    // unique to the delivery, such as its signature
+}
+
+// ReplayProtected is implemented by adapters of providers that say when a
+// webhook was sent
+type ReplayProtected interface {
+	// Delivery identifies a validated request. It returns false when the
+	// adapter does not verify signatures, as unsigned deliveries can be
+	// forged outright.
+	Delivery(r *http.Request, body []byte) (*Delivery, bool, error)
+}
@@ -0,0 +1,56 @@
+package adapters
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDelivery_PagerDuty(t *testing.T) {
+	adapter := &PagerDutyAdapter{secret: "s3cret"}
+	body := []byte(`{"event":{"id":"evt_1","event_type":"incident.triggered","occurred_at":"2024-05-01T12:00:00Z"}}`)
+	req := httptest.NewRequest("POST", "/api/v1/webhooks/incidents", bytes.NewReader(body))
+	if err := Sign(req.Header, "pagerduty", "s3cret", body); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	delivery, ok, err := adapter.Delivery(req, body)
+	if err != nil || !ok {
+		t.Fatalf("Delivery() = %v, %v", ok, err)
+	}
+	if !delivery.SentAt.Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)) || delivery.Nonce != req.Header.Get("X-PagerDuty-Signature") {
+		t.Errorf("unexpected delivery %+v", delivery)
+	}
+
+	if _, _, err := adapter.Delivery(req, []byte(`{"event":{"id":"evt_1"}}`)); FailureReason(err) != ReasonReplay {
+		t.Errorf("expected a replay failure without occurred_at, got %v", err)
+	}
+
+	// Unsigned deliveries are not protected
+	if _, ok, err := (&PagerDutyAdapter{}).Delivery(req, body); ok || err != nil {
+		t.Errorf("expected no delivery without a secret, got %v, %v", ok, err)
+	}
+}
+
+func TestDelivery_Sentry(t *testing.T) {
+	adapter := &SentryAdapter{secret: "s3cret"}
+	body := []byte(`{"action":"created"}`)
+	req := httptest.NewRequest("POST", "/api/v1/webhooks/incidents", bytes.NewReader(body))
+	if err := Sign(req.Header, "sentry", "s3cret", body); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	delivery, ok, err := adapter.Delivery(req, body)
+	if err != nil || !ok {
+		t.Fatalf("Delivery() = %v, %v", ok, err)
+	}
+	if time.Since(delivery.SentAt) > time.Minute || delivery.Nonce != req.Header.Get("Sentry-Hook-Signature") {
+		t.Errorf("unexpected delivery %+v", delivery)
+	}
+
+	req.Header.Del("Sentry-Hook-Timestamp")
+	if _, _, err := adapter.Delivery(req, body); FailureReason(err) != ReasonReplay {
+		t.Errorf("expected a replay failure without a timestamp, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package adapters
 
 import (
+	"bytes"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -9,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,8 +20,12 @@ import (
 // SentryAdapter handles Sentry webhook payloads
 type SentryAdapter struct {
 	secret string
+	acceptedEvents // issue actions
 }
 
+// DefaultSentryActions are the issue actions that create incidents by default
+var DefaultSentryActions = []string{"created"}
+
 // NewSentryAdapter creates a new Sentry adapter
 func NewSentryAdapter() *SentryAdapter {
 	return &SentryAdapter{
@@ -41,7 +47,7 @@ func (a *SentryAdapter) Validate(r *http.Request) error {
 
 	signature := r.Header.Get("Sentry-Hook-Signature")
 	if signature == "" {
-		return fmt.Errorf("missing Sentry-Hook-Signature header")
+		return failure(ReasonSignature, "missing Sentry-Hook-Signature header")
 	}
 
 	body, err := io.ReadAll(r.Body)
@@ -55,22 +61,50 @@ func (a *SentryAdapter) Validate(r *http.Request) error {
 	expectedSignature := hex.EncodeToString(mac.Sum(nil))
 
 	if !hmac.Equal([]byte(signature), []byte(expectedSignature)) {
-		return fmt.Errorf("invalid signature")
+		return failure(ReasonSignature, "invalid signature")
 	}
 
 	return nil
 }
 
+// Delivery returns when Sentry sent the webhook, from the
+// Sentry-Hook-Timestamp header. Each delivery is identified by its signature.
+func (a *SentryAdapter) Delivery(r *http.Request, body []byte) (*Delivery, bool, error) {
+	if a.secret == "" {
+		return nil, false, nil
+	}
+
+	timestamp := r.Header.Get("Sentry-Hook-Timestamp")
+	if timestamp == "" {
+		return nil, false, failure(ReasonReplay, "missing Sentry-Hook-Timestamp header")
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, false, failure(ReasonReplay, "invalid Sentry-Hook-Timestamp header: %w", err)
+	}
+
+	return &Delivery{SentAt: time.Unix(seconds, 0), Nonce: r.Header.Get("Sentry-Hook-Signature")}, true, nil
+}
+
 // Parse transforms Sentry payload to internal Incident
 func (a *SentryAdapter) Parse(body []byte) (*models.Incident, error) {
+	// Both generations of payloads are read into the integration platform form
+	version := a.PayloadVersion(body)
 	var payload SentryPayload
-	if err := json.Unmarshal(body, &payload); err != nil {
-		return nil, fmt.Errorf("failed to parse sentry payload: %w", err)
+	var err error
+	switch version {
+	case SentryV1:
+		payload, err = parseSentryV1(body)
+	default:
+		payload, err = parseSentryV2(body)
+	}
+	if err != nil {
+		return nil, failure(ReasonSchema, "failed to parse sentry %s payload: %w", version, err)
 	}
 
-	// Only process created events
-	if payload.Action != "created" {
-		return nil, fmt.Errorf("unsupported action: %s", payload.Action)
+	// Only process the accepted actions, by default created issues
+	if !a.accepts(payload.Action, DefaultSentryActions) {
+		return nil, failure(ReasonUnsupportedEvent, "unsupported action: %s", payload.Action)
 	}
 
 	// Extract service name from tags or project
@@ -79,11 +113,11 @@ func (a *SentryAdapter) Parse(body []byte) (*models.Incident, error) {
 		serviceName = payload.Data.Issue.Project
 	}
 	if serviceName == "" {
-		serviceName = "unknown"
+		serviceName = UnknownService
 	}
 
 	// Map level to severity
-	severity := mapSentrySeverity(payload.Data.Issue.Level)
+	severity, mapped := mapSentrySeverity(payload.Data.Issue.Level)
 
 	// Extract error message
 	errorMessage := payload.Data.Issue.Title
@@ -99,11 +133,12 @@ func (a *SentryAdapter) Parse(body []byte) (*models.Incident, error) {
 
 	// Store provider data
 	providerData := map[string]interface{}{
-		"issue_id":   payload.Data.Issue.ID,
-		"event_id":   payload.Data.Event.EventID,
-		"issue_url":  payload.URL,
-		"platform":   payload.Data.Issue.Platform,
-		"culprit":    payload.Data.Issue.Culprit,
+		"issue_id":        payload.Data.Issue.ID,
+		"event_id":        payload.Data.Event.EventID,
+		"issue_url":       payload.URL,
+		"platform":        payload.Data.Issue.Platform,
+		"culprit":         payload.Data.Issue.Culprit,
+		"payload_version": version,
 	}
 
 	incident := &models.Incident{
@@ -115,15 +150,102 @@ func (a *SentryAdapter) Parse(body []byte) (*models.Incident, error) {
 		Severity:     severity,
 		Status:       models.StatusPending,
 		Provider:     "sentry",
+		ProviderRef:  payload.Data.Issue.ID,
 		ProviderData: providerData,
 		CreatedAt:    time.Now().UTC(),
 		UpdatedAt:    time.Now().UTC(),
 	}
 
+	incident.SeverityDefaulted = !mapped
+
 	return incident, nil
 }
 
-// SentryPayload represents a Sentry webhook payload
+// Generations of Sentry webhook payloads
+const (
+	SentryV1 = "v1" // the legacy webhooks plugin: the issue's fields at the top level
+	SentryV2 = "v2" // the integration platform: an action with the issue under data
+)
+
+// PayloadVersion tells the legacy webhooks plugin's payloads, which have no
+// action and carry the project at the top level, from integration platform
+// ones
+func (a *SentryAdapter) PayloadVersion(body []byte) string {
+	var probe struct {
+		Action  string          `json:"action"`
+		Data    json.RawMessage `json:"data"`
+		Project json.RawMessage `json:"project"`
+	}
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return SentryV2
+	}
+	if probe.Action == "" && len(probe.Data) == 0 && len(probe.Project) > 0 {
+		return SentryV1
+	}
+	return SentryV2
+}
+
+// PayloadSchema returns the type payloads of body's generation are decoded
+// into
+func (a *SentryAdapter) PayloadSchema(body []byte) interface{} {
+	if a.PayloadVersion(body) == SentryV1 {
+		return &SentryV1Payload{}
+	}
+	return &SentryPayload{}
+}
+
+// parseSentryV2 parses an integration platform payload
+func parseSentryV2(body []byte) (SentryPayload, error) {
+	var payload SentryPayload
+	err := json.Unmarshal(body, &payload)
+	return payload, err
+}
+
+// SentryV1Payload represents a payload of the legacy webhooks plugin, sent
+// whenever an alert rule fires for an issue
+type SentryV1Payload struct {
+	ID          string      `json:"id"`
+	Project     string      `json:"project"`
+	ProjectName string      `json:"project_name"`
+	ProjectSlug string      `json:"project_slug"`
+	Level       string      `json:"level"`
+	Culprit     string      `json:"culprit"`
+	Message     string      `json:"message"`
+	URL         string      `json:"url"`
+	Event       SentryEvent `json:"event"`
+}
+
+// parseSentryV1 parses a legacy webhooks plugin payload into the
+// integration platform form. The plugin only notifies of alerts firing, so
+// every payload is taken as a created issue.
+func parseSentryV1(body []byte) (SentryPayload, error) {
+	var legacy SentryV1Payload
+	if err := json.Unmarshal(body, &legacy); err != nil {
+		return SentryPayload{}, err
+	}
+
+	project := legacy.ProjectSlug
+	if project == "" {
+		project = legacy.Project
+	}
+	return SentryPayload{
+		Action: "created",
+		URL:    legacy.URL,
+		Data: SentryData{
+			Issue: SentryIssue{
+				ID:       legacy.ID,
+				Title:    legacy.Message,
+				Culprit:  legacy.Culprit,
+				Level:    legacy.Level,
+				Platform: legacy.Event.Platform,
+				Project:  project,
+			},
+			Event: legacy.Event,
+		},
+	}, nil
+}
+
+// SentryPayload represents a Sentry integration platform webhook payload
 type SentryPayload struct {
 	Action string     `json:"action"`
 	Data   SentryData `json:"data"`
@@ -146,12 +268,90 @@ type SentryIssue struct {
 	Project  string `json:"project"`
 }
 
+// UnmarshalJSON accepts the project as the slug that older integration
+// platform webhooks send or as the object of current ones, whose slug it
+// keeps
+func (i *SentryIssue) UnmarshalJSON(data []byte) error {
+	type issue SentryIssue
+	var raw struct {
+		issue
+		Project json.RawMessage `json:"project"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*i = SentryIssue(raw.issue)
+
+	project := bytes.TrimSpace(raw.Project)
+	if len(project) > 0 && project[0] == '{' {
+		var object struct {
+			Slug string `json:"slug"`
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(project, &object); err != nil {
+			return fmt.Errorf("project: %w", err)
+		}
+		i.Project = object.Slug
+		if i.Project == "" {
+			i.Project = object.Name
+		}
+		return nil
+	}
+	var err error
+	if i.Project, err = stringOrNumber(project); err != nil {
+		return fmt.Errorf("project: %w", err)
+	}
+	return nil
+}
+
 // SentryEvent represents a Sentry event
 type SentryEvent struct {
 	EventID   string                   `json:"event_id"`
 	Timestamp string                   `json:"timestamp"`
 	Exception *SentryException         `json:"exception"`
 	Tags      [][]string               `json:"tags"`
+	Platform  string                   `json:"platform"`
+}
+
+// UnmarshalJSON accepts tags as the [key, value] pairs of webhooks or as the
+// {"key", "value"} objects of Sentry's API, and the timestamp as a string or
+// as the seconds since the epoch that the legacy webhooks plugin sends
+func (e *SentryEvent) UnmarshalJSON(data []byte) error {
+	type event SentryEvent
+	var raw struct {
+		event
+		Timestamp json.RawMessage `json:"timestamp"`
+		Tags      json.RawMessage `json:"tags"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*e = SentryEvent(raw.event)
+
+	var err error
+	if e.Timestamp, err = stringOrNumber(raw.Timestamp); err != nil {
+		return fmt.Errorf("timestamp: %w", err)
+	}
+
+	tags := bytes.TrimSpace(raw.Tags)
+	if len(tags) == 0 || bytes.Equal(tags, []byte("null")) {
+		return nil
+	}
+	if err := json.Unmarshal(tags, &e.Tags); err == nil {
+		return nil
+	}
+	var objects []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(tags, &objects); err != nil {
+		return fmt.Errorf("tags: %w", err)
+	}
+	e.Tags = make([][]string, 0, len(objects))
+	for _, tag := range objects {
+		e.Tags = append(e.Tags, []string{tag.Key, tag.Value})
+	}
+	return nil
 }
 
 // SentryException represents exception data
@@ -191,19 +391,20 @@ func extractServiceFromSentryTags(tags [][]string) string {
 	return ""
 }
 
-// mapSentrySeverity maps Sentry level to internal severity
-func mapSentrySeverity(level string) string {
+// mapSentrySeverity maps Sentry level to internal severity. It reports false
+// when the level is missing or unknown and medium is assumed.
+func mapSentrySeverity(level string) (string, bool) {
 	switch strings.ToLower(level) {
 	case "fatal":
-		return "critical"
+		return "critical", true
 	case "error":
-		return "high"
+		return "high", true
 	case "warning":
-		return "medium"
+		return "medium", true
 	case "info", "debug":
-		return "low"
+		return "low", true
 	default:
-		return "medium"
+		return "medium", false
 	}
 }
 
@@ -0,0 +1,52 @@
+package adapters
+
+import "testing"
+
+func TestSeverityMappingReportsDefaults(t *testing.T) {
+	tests := []struct {
+		name         string
+		mapSeverity  func() (string, bool)
+		wantSeverity string
+		wantMapped   bool
+	}{
+		{"datadog priority", func() (string, bool) { return mapDatadogSeverity("P1") }, "critical", true},
+		{"datadog without priority", func() (string, bool) { return mapDatadogSeverity("") }, "medium", false},
+		{"grafana severity label", func() (string, bool) {
+			return mapGrafanaSeverity("alerting", map[string]string{"severity": "Low"})
+		}, "low", true},
+		{"grafana state only", func() (string, bool) { return mapGrafanaSeverity("alerting", nil) }, "high", false},
+		{"pagerduty urgency", func() (string, bool) { return mapPagerDutySeverity("high") }, "critical", true},
+		{"pagerduty without urgency", func() (string, bool) { return mapPagerDutySeverity("") }, "medium", false},
+		{"sentry level", func() (string, bool) { return mapSentrySeverity("warning") }, "medium", true},
+		{"sentry unknown level", func() (string, bool) { return mapSentrySeverity("verbose") }, "medium", false},
+		{"splunk severity", func() (string, bool) { return mapSplunkSeverity("Major") }, "high", true},
+		{"splunk without severity", func() (string, bool) { return mapSplunkSeverity("") }, "medium", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			severity, mapped := tt.mapSeverity()
+			if severity != tt.wantSeverity || mapped != tt.wantMapped {
+				t.Errorf("got %s, %v, want %s, %v", severity, mapped, tt.wantSeverity, tt.wantMapped)
+			}
+		})
+	}
+}
+
+func TestParseFlagsDefaultedSeverity(t *testing.T) {
+	incident, err := NewDatadogAdapter().Parse([]byte(`{"id": "1", "title": "boom", "tags": ["service:api"]}`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if !incident.SeverityDefaulted {
+		t.Error("expected an incident without priority to be flagged")
+	}
+
+	incident, err = NewDatadogAdapter().Parse([]byte(`{"id": "1", "title": "boom", "priority": "P2", "tags": ["service:api"]}`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if incident.SeverityDefaulted {
+		t.Error("expected an incident with a priority not to be flagged")
+	}
+}
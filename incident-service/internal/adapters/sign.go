@@ -0,0 +1,61 @@
+package adapters
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// secretEnv maps each provider to the environment variable its adapter reads
+// the webhook secret from
+var secretEnv = map[string]string{
+	"datadog":   "DATADOG_WEBHOOK_SECRET",
+	"grafana":   "GRAFANA_WEBHOOK_SECRET",
+	"pagerduty": "PAGERDUTY_WEBHOOK_SECRET",
+	"sentry":    "SENTRY_WEBHOOK_SECRET",
+	"splunk":    "SPLUNK_WEBHOOK_SECRET",
+}
+
+// SecretEnv returns the environment variable holding the webhook secret of a provider
+func SecretEnv(provider string) (string, bool) {
+	name, ok := secretEnv[provider]
+	return name, ok
+}
+
+// Sign sets the authentication header a provider sends with its webhooks, so
+// that tools posting synthetic payloads pass the adapter's Validate. Nothing
+// is set when the secret is empty, matching adapters that skip validation.
+func Sign(header http.Header, provider, secret string, body []byte) error {
+	if secret == "" {
+		return nil
+	}
+
+	switch provider {
+	case "datadog":
+		header.Set("X-Datadog-Signature", hmacHex(secret, body))
+	case "sentry":
+		header.Set("Sentry-Hook-Signature", hmacHex(secret, body))
+		header.Set("Sentry-Hook-Timestamp", strconv.FormatInt(time.Now().Unix(), 10))
+	case "pagerduty":
+		header.Set("X-PagerDuty-Signature", "v1="+hmacHex(secret, body))
+	case "grafana":
+		header.Set("Authorization", "Bearer "+secret)
+	case "splunk":
+		header.Set(SplunkSecretHeader, secret)
+	default:
+		return fmt.Errorf("unsupported provider: %s", provider)
+	}
+
+	return nil
+}
+
+// hmacHex returns the hex encoded HMAC-SHA256 of body
+func hmacHex(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
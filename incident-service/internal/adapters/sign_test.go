@@ -0,0 +1,52 @@
+package adapters
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSign_PassesValidation(t *testing.T) {
+	body := []byte(`{"id":"1","title":"boom"}`)
+	cases := map[string]WebhookAdapter{
+		"datadog":   &DatadogAdapter{secret: "s3cret"},
+		"sentry":    &SentryAdapter{secret: "s3cret"},
+		"pagerduty": &PagerDutyAdapter{secret: "s3cret"},
+		"grafana":   &GrafanaAdapter{secret: "s3cret"},
+		"splunk":    &SplunkAdapter{secret: "s3cret"},
+	}
+
+	for provider, adapter := range cases {
+		t.Run(provider, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v1/webhooks/incidents", bytes.NewReader(body))
+			if err := Sign(req.Header, provider, "s3cret", body); err != nil {
+				t.Fatalf("Sign failed: %v", err)
+			}
+			if err := adapter.Validate(req); err != nil {
+				t.Errorf("signed request rejected: %v", err)
+			}
+
+			req = httptest.NewRequest("POST", "/api/v1/webhooks/incidents", bytes.NewReader(body))
+			if err := Sign(req.Header, provider, "wrong", body); err != nil {
+				t.Fatalf("Sign failed: %v", err)
+			}
+			if err := adapter.Validate(req); err == nil {
+				t.Error("request signed with the wrong secret was accepted")
+			}
+		})
+	}
+}
+
+func TestSign_EmptySecretAndUnknownProvider(t *testing.T) {
+	req := httptest.NewRequest("POST", "/", nil)
+	if err := Sign(req.Header, "datadog", "", nil); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if len(req.Header) != 0 {
+		t.Errorf("expected no headers without a secret, got %v", req.Header)
+	}
+
+	if err := Sign(req.Header, "nagios", "s3cret", nil); err == nil {
+		t.Error("expected error for unsupported provider")
+	}
+}
@@ -0,0 +1,183 @@
+package adapters
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// SplunkSecretHeader carries the shared secret set as a custom header on the
+// Splunk Observability Cloud webhook integration
+const SplunkSecretHeader = "X-Splunk-Webhook-Secret"
+
+// SplunkAdapter handles Splunk Observability Cloud detector webhook payloads
+type SplunkAdapter struct {
+	secret         string
+	acceptedEvents // detector statuses
+}
+
+// DefaultSplunkStatuses are the detector statuses that create incidents by
+// default: alerts that have just triggered
+var DefaultSplunkStatuses = []string{"anomalous"}
+
+// NewSplunkAdapter creates a new Splunk Observability adapter
+func NewSplunkAdapter() *SplunkAdapter {
+	return &SplunkAdapter{
+		secret: os.Getenv("SPLUNK_WEBHOOK_SECRET"),
+	}
+}
+
+// ProviderName returns the provider name
+func (a *SplunkAdapter) ProviderName() string {
+	return "splunk"
+}
+
+// Validate checks the shared secret header (optional secret)
+func (a *SplunkAdapter) Validate(r *http.Request) error {
+	if a.secret == "" {
+		// If no secret is configured, skip validation
+		return nil
+	}
+
+	secret := r.Header.Get(SplunkSecretHeader)
+	if secret == "" {
+		return failure(ReasonSignature, "missing %s header", SplunkSecretHeader)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(a.secret)) != 1 {
+		return failure(ReasonSignature, "invalid shared secret")
+	}
+
+	return nil
+}
+
+// Parse transforms Splunk Observability payload to internal Incident
+func (a *SplunkAdapter) Parse(body []byte) (*models.Incident, error) {
+	var payload SplunkPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, failure(ReasonSchema, "failed to parse splunk payload: %w", err)
+	}
+
+	if payload.IncidentID == "" {
+		return nil, failure(ReasonSchema, "missing required field: incidentId")
+	}
+
+	// Only process the accepted statuses, by default triggered alerts
+	if !a.accepts(payload.Status, DefaultSplunkStatuses) {
+		return nil, failure(ReasonUnsupportedEvent, "unsupported detector status: %s", payload.Status)
+	}
+
+	// Extract service name from the dimensions of the alerting time series
+	serviceName := extractServiceFromDimensions(payload.Dimensions)
+	if serviceName == "" {
+		serviceName = UnknownService
+	}
+
+	// Map severity
+	severity, mapped := mapSplunkSeverity(payload.Severity)
+
+	// Construct error message from title and description
+	errorMessage := payload.MessageTitle
+	if errorMessage == "" {
+		errorMessage = fmt.Sprintf("%s: %s", payload.Detector, payload.Rule)
+	}
+	if payload.Description != "" {
+		errorMessage = fmt.Sprintf("%s: %s", errorMessage, payload.Description)
+	}
+
+	// Create incident ID
+	incidentID := fmt.Sprintf("inc_splunk_%s", payload.IncidentID)
+
+	// Store provider data
+	providerData := map[string]interface{}{
+		"incident_id":  payload.IncidentID,
+		"detector_id":  payload.DetectorID,
+		"detector":     payload.Detector,
+		"rule":         payload.Rule,
+		"status":       payload.Status,
+		"dimensions":   payload.Dimensions,
+		"detector_url": payload.DetectorURL,
+	}
+	if payload.RunbookURL != "" {
+		providerData["runbook_url"] = payload.RunbookURL
+	}
+
+	incident := &models.Incident{
+		ID:           incidentID,
+		ServiceName:  serviceName,
+		Repository:   "", // Will be mapped later
+		ErrorMessage: errorMessage,
+		Severity:     severity,
+		Status:       models.StatusPending,
+		Provider:     "splunk",
+		ProviderRef:  payload.IncidentID,
+		ProviderData: providerData,
+		CreatedAt:    time.Now().UTC(),
+		UpdatedAt:    time.Now().UTC(),
+	}
+
+	incident.SeverityDefaulted = !mapped
+
+	return incident, nil
+}
+
+// PayloadSchema returns the type Splunk payloads are decoded into
+func (a *SplunkAdapter) PayloadSchema(body []byte) interface{} {
+	return &SplunkPayload{}
+}
+
+// SplunkPayload represents a Splunk Observability Cloud detector webhook
+// payload
+type SplunkPayload struct {
+	IncidentID   string                 `json:"incidentId"`
+	DetectorID   string                 `json:"detectorId"`
+	Detector     string                 `json:"detector"`
+	DetectorURL  string                 `json:"detectorUrl"`
+	Rule         string                 `json:"rule"`
+	Severity     string                 `json:"severity"`
+	Status       string                 `json:"status"`
+	Description  string                 `json:"description"`
+	MessageTitle string                 `json:"messageTitle"`
+	MessageBody  string                 `json:"messageBody"`
+	RunbookURL   string                 `json:"runbookUrl"`
+	Timestamp    string                 `json:"timestamp"`
+	Dimensions   map[string]interface{} `json:"dimensions"`
+}
+
+// splunkServiceDimensions are the dimensions naming the service of an alert,
+// most specific first
+var splunkServiceDimensions = []string{"service", "sf_service", "service.name", "app", "application"}
+
+// extractServiceFromDimensions extracts service name from Splunk dimensions
+func extractServiceFromDimensions(dimensions map[string]interface{}) string {
+	for _, name := range splunkServiceDimensions {
+		if service, ok := dimensions[name].(string); ok && service != "" {
+			return service
+		}
+	}
+	return ""
+}
+
+// mapSplunkSeverity maps Splunk detector severity to internal severity. It
+// reports false when the severity is missing or unknown and medium is
+// assumed.
+func mapSplunkSeverity(severity string) (string, bool) {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return "critical", true
+	case "major":
+		return "high", true
+	case "minor":
+		return "medium", true
+	case "warning", "info":
+		return "low", true
+	default:
+		return "medium", false
+	}
+}
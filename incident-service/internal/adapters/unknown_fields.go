@@ -0,0 +1,152 @@
+package adapters
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Schema is implemented by adapters that can tell which fields of their
+// provider's payloads they read
+type Schema interface {
+	// PayloadSchema returns a pointer to a value of the type body is decoded
+	// into, whose json tags name the fields the adapter reads
+	PayloadSchema(body []byte) interface{}
+}
+
+// UnknownField is a field of a payload that its adapter does not read
+type UnknownField struct {
+	Path string `json:"path"` // e.g. data.issue.assignedTo; items of a list share the path alert[]
+	Type string `json:"type"` // JSON type: object, array, string, number, bool or null
+}
+
+// UnknownFields returns the fields of a payload that the adapter drops
+// because its schema has no place for them, such as data a provider added
+// after the adapter was written, sorted by path. The fields inside an unknown
+// object are not listed, nor are the keys of objects read as maps, such as
+// labels. It returns nil for adapters without a Schema and for payloads that
+// are not JSON.
+func UnknownFields(adapter WebhookAdapter, body []byte) []UnknownField {
+	schema, ok := adapter.(Schema)
+	if !ok {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil
+	}
+
+	unknown := make(map[string]string)
+	collectUnknown("", value, reflect.TypeOf(schema.PayloadSchema(body)), unknown)
+
+	fields := make([]UnknownField, 0, len(unknown))
+	for path, typ := range unknown {
+		fields = append(fields, UnknownField{Path: path, Type: typ})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Path < fields[j].Path })
+	return fields
+}
+
+// rawMessageType is decoded later, by custom unmarshalers, so it takes anything
+var rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+
+// collectUnknown adds to unknown the fields of value that t has no place
+// for. Values whose JSON type does not match t, which custom unmarshalers
+// convert, are taken as read.
+func collectUnknown(path string, value interface{}, t reflect.Type, unknown map[string]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == rawMessageType {
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := structFields(t)
+			for key, field := range v {
+				fieldType, ok := fields[strings.ToLower(key)]
+				if !ok {
+					unknown[joinPath(path, key)] = jsonType(field)
+					continue
+				}
+				collectUnknown(joinPath(path, key), field, fieldType, unknown)
+			}
+		case reflect.Map:
+			for key, field := range v {
+				collectUnknown(joinPath(path, key), field, t.Elem(), unknown)
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for _, item := range v {
+				collectUnknown(path+"[]", item, t.Elem(), unknown)
+			}
+		}
+	}
+}
+
+// structFields returns the types of the fields encoding/json decodes into a
+// struct, by lowercased name, as it matches keys ignoring case
+func structFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for key, fieldType := range structFields(embedded) {
+					if _, ok := fields[key]; !ok {
+						fields[key] = fieldType
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+	return fields
+}
+
+// joinPath appends a key to a path of dotted keys
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	default:
+		return "null"
+	}
+}
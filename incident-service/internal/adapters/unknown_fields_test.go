@@ -0,0 +1,66 @@
+package adapters
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnknownFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		adapter WebhookAdapter
+		body    string
+		want    []UnknownField
+	}{
+		{
+			"grafana unified alerting",
+			NewGrafanaAdapter(),
+			`{"receiver": "reanimator", "status": "firing", "orgId": 1, "truncatedAlerts": 0,
+			  "commonLabels": {"service": "inventory", "team": "backend"},
+			  "alerts": [{"status": "firing", "labels": {"service": "inventory"}, "values": {"B": 42}, "startsAt": "2024-10-14T11:20:00Z"}]}`,
+			[]UnknownField{
+				{Path: "alerts[].startsAt", Type: "string"},
+				{Path: "alerts[].values", Type: "object"},
+				{Path: "orgId", Type: "number"},
+				{Path: "truncatedAlerts", Type: "number"},
+			},
+		},
+		{
+			"sentry project object read by a custom unmarshaler",
+			NewSentryAdapter(),
+			`{"action": "created", "data": {"issue": {"id": "1", "project": {"slug": "checkout"}, "assignedTo": null}}}`,
+			[]UnknownField{{Path: "data.issue.assignedTo", Type: "null"}},
+		},
+		{
+			"datadog keys matched ignoring case",
+			NewDatadogAdapter(),
+			`{"ID": "1", "Title": "boom", "tags": "service:api,env:prod", "incident": {"public_id": 42}}`,
+			[]UnknownField{{Path: "incident", Type: "object"}},
+		},
+		{
+			"every field read",
+			NewSplunkAdapter(),
+			`{"incidentId": "FRh7cvUA4AA", "status": "anomalous", "dimensions": {"sf_service": "checkout"}}`,
+			[]UnknownField{},
+		},
+		{"not json", NewSplunkAdapter(), `{`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnknownFields(tt.adapter, []byte(tt.body)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnknownFields() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnknownFields_EveryAdapterHasASchema(t *testing.T) {
+	registry := NewRegistry()
+	for _, provider := range registry.List() {
+		adapter, _ := registry.Get(provider)
+		if _, ok := adapter.(Schema); !ok {
+			t.Errorf("%s adapter does not implement Schema", provider)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package adapters
+
+import "testing"
+
+func TestPayloadVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		adapter Versioned
+		payload string
+		want    string
+	}{
+		{"grafana dashboard alerting", NewGrafanaAdapter(), `{"ruleId": 57, "state": "alerting", "tags": {}}`, GrafanaLegacy},
+		{"grafana rule with labels", NewGrafanaAdapter(), `{"ruleId": "r1", "state": "firing", "labels": {"service": "api"}}`, GrafanaLegacy},
+		{"grafana unified alerting", NewGrafanaAdapter(), `{"receiver": "reanimator", "status": "firing", "alerts": []}`, GrafanaUnified},
+		{"grafana alerts without receiver", NewGrafanaAdapter(), `{"status": "firing", "alerts": [{"fingerprint": "f"}]}`, GrafanaUnified},
+		{"grafana invalid JSON", NewGrafanaAdapter(), `{invalid`, GrafanaLegacy},
+		{"sentry webhooks plugin", NewSentryAdapter(), `{"id": "1", "project": "web", "message": "boom"}`, SentryV1},
+		{"sentry integration platform", NewSentryAdapter(), `{"action": "created", "data": {"issue": {"project": "web"}}}`, SentryV2},
+		{"sentry invalid JSON", NewSentryAdapter(), `[1, 2]`, SentryV2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.adapter.PayloadVersion([]byte(tt.payload)); got != tt.want {
+				t.Errorf("PayloadVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_RecordsPayloadVersion(t *testing.T) {
+	incident, err := NewGrafanaAdapter().Parse([]byte(`{
+		"receiver": "reanimator", "status": "firing", "title": "[FIRING:1] Errors",
+		"commonLabels": {"alertname": "Errors", "service": "api"},
+		"alerts": [{"status": "firing", "fingerprint": "abc123", "generatorURL": "https://grafana.example.com/alerting/1"}]
+	}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if incident.ProviderRef != "abc123" || incident.ProviderData["payload_version"] != GrafanaUnified || incident.ProviderData["rule_name"] != "Errors" {
+		t.Errorf("unexpected unified alerting incident: %+v", incident)
+	}
+
+	incident, err = NewSentryAdapter().Parse([]byte(`{"id": "42", "project_slug": "web", "project": "web", "level": "fatal", "message": "boom"}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if incident.ServiceName != "web" || incident.Severity != "critical" || incident.ProviderData["payload_version"] != SentryV1 {
+		t.Errorf("unexpected webhooks plugin incident: %+v", incident)
+	}
+}
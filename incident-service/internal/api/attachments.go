@@ -0,0 +1,343 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/blob"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// multipartOverhead is allowed on top of the attachment size limit for the
+// multipart boundaries and part headers of an upload
+const multipartOverhead = 64 << 10
+
+// SetAttachmentStore keeps the files attached to incidents in store.
+// Without one, the attachment endpoints answer 404.
+func (s *Server) SetAttachmentStore(store blob.Store) {
+	s.attachments = store
+}
+
+// handleListIncidentAttachments lists the attachments of an incident
+func (s *Server) handleListIncidentAttachments(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAttachments(w) {
+		return
+	}
+	id := chi.URLParam(r, "id")
+
+	repository := s.repository.WithContext(r.Context())
+	if _, err := repository.GetByID(id); err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+
+	attachments, err := repository.ListIncidentAttachments(id)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to list incident attachments", map[string]interface{}{
+			"error": err.Error(),
+			"id":    id,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"attachments": attachments,
+		"total":       len(attachments),
+	})
+}
+
+// handleUploadAttachment attaches the file of a multipart/form-data upload,
+// sent in its "file" field, to an incident. Files larger than the configured
+// size limit, and files beyond the incident's attachment limit, are refused.
+func (s *Server) handleUploadAttachment(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAttachments(w) {
+		return
+	}
+	id := chi.URLParam(r, "id")
+	logger := s.loggerFrom(r.Context()).With(map[string]interface{}{
+		"incident_id": id,
+	})
+
+	sizeLimit := s.config.Attachments.SizeLimit()
+	countLimit := s.config.Attachments.IncidentLimit()
+
+	repository := s.repository.WithContext(r.Context())
+	if _, err := repository.GetByID(id); err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+	existing, err := repository.ListIncidentAttachments(id)
+	if err != nil {
+		logger.Error("failed to list incident attachments", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if len(existing) >= countLimit {
+		http.Error(w, fmt.Sprintf("incident already has %d attachments, the most it may have", countLimit), http.StatusConflict)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, sizeLimit+multipartOverhead)
+	filename, declaredType, data, err := readUpload(r, sizeLimit)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.Is(err, errUploadTooLarge) || errors.As(err, &maxBytesErr) {
+			http.Error(w, fmt.Sprintf("attachment exceeds the %d byte limit", sizeLimit), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	attachment := &models.IncidentAttachment{
+		ID:          newAttachmentID(),
+		IncidentID:  id,
+		Filename:    filename,
+		ContentType: attachmentContentType(declaredType, data),
+		Size:        int64(len(data)),
+		SHA256:      hex.EncodeToString(sum[:]),
+		UploadedBy:  principalFrom(r.Context()).name,
+	}
+	if err := attachment.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	attachment.StorageKey = url.PathEscape(id) + "/" + attachment.ID
+
+	if err := s.attachments.Put(r.Context(), attachment.StorageKey, attachment.ContentType, data); err != nil {
+		logger.Error("failed to store attachment", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if err := repository.CreateIncidentAttachment(attachment, countLimit); err != nil {
+		s.deleteAttachmentBlob(r, attachment)
+		if errors.Is(err, database.ErrAttachmentLimit) {
+			http.Error(w, fmt.Sprintf("incident already has %d attachments, the most it may have", countLimit), http.StatusConflict)
+			return
+		}
+		logger.Error("failed to record attachment", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	s.logAttachmentEvent(r, models.EventAttachmentAdded, attachment)
+	s.recordAudit(r, models.AuditIncidentAttached, id, nil, attachmentAuditState(attachment))
+	logger.Info("attachment added", map[string]interface{}{
+		"attachment_id": attachment.ID,
+		"size":          attachment.Size,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(attachment)
+}
+
+// handleDownloadAttachment sends the file of an attachment. It is always
+// sent as a download, and sandboxed, so that an uploaded page or image
+// cannot run script in the API's origin.
+func (s *Server) handleDownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAttachments(w) {
+		return
+	}
+	id := chi.URLParam(r, "id")
+	attachmentID := chi.URLParam(r, "attachmentID")
+
+	attachment, err := s.repository.WithContext(r.Context()).GetIncidentAttachment(id, attachmentID)
+	if err != nil {
+		http.Error(w, "attachment not found", http.StatusNotFound)
+		return
+	}
+
+	body, err := s.attachments.Get(r.Context(), attachment.StorageKey)
+	if errors.Is(err, blob.ErrNotFound) {
+		http.Error(w, "attachment content not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to read attachment", map[string]interface{}{
+			"error":         err.Error(),
+			"incident_id":   id,
+			"attachment_id": attachmentID,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "sandbox")
+	_, _ = io.Copy(w, body)
+}
+
+// handleDeleteAttachment removes an attachment from an incident, and its
+// file from the store
+func (s *Server) handleDeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAttachments(w) {
+		return
+	}
+	id := chi.URLParam(r, "id")
+	attachmentID := chi.URLParam(r, "attachmentID")
+
+	repository := s.repository.WithContext(r.Context())
+	attachment, err := repository.GetIncidentAttachment(id, attachmentID)
+	if err != nil {
+		http.Error(w, "attachment not found", http.StatusNotFound)
+		return
+	}
+
+	deleted, err := repository.DeleteIncidentAttachment(id, attachmentID)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to delete attachment", map[string]interface{}{
+			"error":         err.Error(),
+			"incident_id":   id,
+			"attachment_id": attachmentID,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if !deleted {
+		http.Error(w, "attachment not found", http.StatusNotFound)
+		return
+	}
+	s.deleteAttachmentBlob(r, attachment)
+
+	s.logAttachmentEvent(r, models.EventAttachmentRemoved, attachment)
+	s.recordAudit(r, models.AuditIncidentDetached, id, attachmentAuditState(attachment), nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requireAttachments answers 404 when no attachment store is set, and
+// reports whether there is one
+func (s *Server) requireAttachments(w http.ResponseWriter) bool {
+	if s.attachments == nil {
+		http.Error(w, "attachments are not enabled", http.StatusNotFound)
+		return false
+	}
+	return true
+}
+
+// deleteAttachmentBlob removes the file of an attachment, logging any
+// failure. A file left behind takes space but is never served again.
+func (s *Server) deleteAttachmentBlob(r *http.Request, attachment *models.IncidentAttachment) {
+	if err := s.attachments.Delete(r.Context(), attachment.StorageKey); err != nil {
+		s.loggerFrom(r.Context()).Warn("failed to delete attachment file", map[string]interface{}{
+			"error":         err.Error(),
+			"incident_id":   attachment.IncidentID,
+			"attachment_id": attachment.ID,
+			"storage_key":   attachment.StorageKey,
+		})
+	}
+}
+
+// logAttachmentEvent records an attachment change on the incident's
+// timeline, logging any failure
+func (s *Server) logAttachmentEvent(r *http.Request, eventType models.IncidentEventType, attachment *models.IncidentAttachment) {
+	event := &models.IncidentEvent{
+		IncidentID: attachment.IncidentID,
+		EventType:  eventType,
+		EventData: map[string]interface{}{
+			"attachment_id": attachment.ID,
+			"filename":      attachment.Filename,
+			"size":          attachment.Size,
+		},
+	}
+	if err := s.repository.WithContext(r.Context()).LogEvent(event); err != nil {
+		s.loggerFrom(r.Context()).Error("failed to log attachment event", map[string]interface{}{
+			"error":       err.Error(),
+			"incident_id": attachment.IncidentID,
+			"event_type":  eventType,
+		})
+	}
+}
+
+// attachmentAuditState is what the audit log records of an attachment
+func attachmentAuditState(attachment *models.IncidentAttachment) map[string]interface{} {
+	return map[string]interface{}{
+		"attachment_id": attachment.ID,
+		"filename":      attachment.Filename,
+		"content_type":  attachment.ContentType,
+		"size":          attachment.Size,
+		"sha256":        attachment.SHA256,
+	}
+}
+
+// errUploadTooLarge is returned by readUpload for files over the size limit
+var errUploadTooLarge = errors.New("upload too large")
+
+// readUpload reads the "file" field of a multipart/form-data request,
+// skipping any other field. It returns the file's name, declared content
+// type and content.
+func readUpload(r *http.Request, sizeLimit int64) (string, string, []byte, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return "", "", nil, fmt.Errorf("expected a multipart/form-data body with a file field")
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return "", "", nil, fmt.Errorf("file field is required")
+		}
+		if err != nil {
+			return "", "", nil, fmt.Errorf("invalid multipart body: %w", err)
+		}
+		if part.FormName() != "file" {
+			_, _ = io.Copy(io.Discard, part)
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(part, sizeLimit+1))
+		if err != nil {
+			return "", "", nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		if int64(len(data)) > sizeLimit {
+			return "", "", nil, errUploadTooLarge
+		}
+		if len(data) == 0 {
+			return "", "", nil, fmt.Errorf("file is empty")
+		}
+		return part.FileName(), part.Header.Get("Content-Type"), data, nil
+	}
+}
+
+// attachmentContentType returns the declared content type of an upload when
+// it is a valid media type, and otherwise sniffs it from the content
+func attachmentContentType(declared string, data []byte) string {
+	if declared != "" && declared != "application/octet-stream" {
+		if mediaType, params, err := mime.ParseMediaType(declared); err == nil {
+			return mime.FormatMediaType(mediaType, params)
+		}
+	}
+	return http.DetectContentType(data)
+}
+
+// newAttachmentID returns a random attachment ID
+func newAttachmentID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/blob"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// newUploadRequest builds a multipart upload of content as the file field
+func newUploadRequest(t *testing.T, url, filename, contentType string, content []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	_ = writer.WriteField("note", "from the war room")
+	header := make(map[string][]string)
+	header["Content-Disposition"] = []string{`form-data; name="file"; filename="` + filename + `"`}
+	if contentType != "" {
+		header["Content-Type"] = []string{contentType}
+	}
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		t.Fatalf("failed to create part: %v", err)
+	}
+	_, _ = part.Write(content)
+	_ = writer.Close()
+
+	req := httptest.NewRequest("POST", url, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestAttachmentHandlers_Disabled(t *testing.T) {
+	server := &Server{logger: NewLogger()}
+
+	handlers := []http.HandlerFunc{
+		server.handleListIncidentAttachments,
+		server.handleUploadAttachment,
+		server.handleDownloadAttachment,
+		server.handleDeleteAttachment,
+	}
+	for _, handler := range handlers {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest("GET", "/api/v1/incidents/inc_1/attachments", nil))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status %d without an attachment store, got %d", http.StatusNotFound, w.Code)
+		}
+	}
+}
+
+func TestReadUpload(t *testing.T) {
+	req := newUploadRequest(t, "/", "trace.txt", "text/plain", []byte("goroutine 1 [running]"))
+	filename, contentType, data, err := readUpload(req, 1024)
+	if err != nil {
+		t.Fatalf("readUpload() error = %v", err)
+	}
+	if filename != "trace.txt" || contentType != "text/plain" || string(data) != "goroutine 1 [running]" {
+		t.Errorf("readUpload() = %q, %q, %q", filename, contentType, data)
+	}
+
+	req = newUploadRequest(t, "/", "trace.txt", "text/plain", bytes.Repeat([]byte("x"), 1025))
+	if _, _, _, err := readUpload(req, 1024); err != errUploadTooLarge {
+		t.Errorf("expected errUploadTooLarge, got %v", err)
+	}
+
+	req = newUploadRequest(t, "/", "trace.txt", "text/plain", nil)
+	if _, _, _, err := readUpload(req, 1024); err == nil || !strings.Contains(err.Error(), "empty") {
+		t.Errorf("expected empty files to be refused, got %v", err)
+	}
+
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`{"file": "x"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if _, _, _, err := readUpload(req, 1024); err == nil {
+		t.Error("expected a JSON body to be refused")
+	}
+}
+
+func TestAttachmentContentType(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	tests := []struct {
+		declared string
+		data     []byte
+		want     string
+	}{
+		{"image/svg+xml", []byte("<svg/>"), "image/svg+xml"},
+		{"text/plain; charset=utf-8", []byte("log"), "text/plain; charset=utf-8"},
+		{"", png, "image/png"},
+		{"application/octet-stream", png, "image/png"},
+		{"not a type", []byte("log line"), "text/plain; charset=utf-8"},
+	}
+	for _, tt := range tests {
+		if got := attachmentContentType(tt.declared, tt.data); got != tt.want {
+			t.Errorf("attachmentContentType(%q) = %q, want %q", tt.declared, got, tt.want)
+		}
+	}
+}
+
+func TestAttachments_RoundTrip(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	cfg := &config.Config{
+		Server:      config.ServerConfig{Port: 8080},
+		Attachments: config.AttachmentsConfig{Enabled: true, MaxSize: 1024, MaxPerIncident: 1},
+	}
+	redis, _ := database.ConnectRedis("localhost:6379", "", 0)
+	server := NewServer(cfg, db, redis, nil, NewLogger())
+	store, err := blob.NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskStore() error = %v", err)
+	}
+	server.SetAttachmentStore(store)
+
+	incident := &models.Incident{
+		ID:           "test-incident-attachments",
+		ServiceName:  "checkout",
+		ErrorMessage: "test error",
+		Status:       models.StatusPending,
+		Provider:     "test",
+		ProviderData: map[string]interface{}{},
+	}
+	if err := database.NewIncidentRepository(db).Create(incident); err != nil {
+		t.Fatalf("failed to create test incident: %v", err)
+	}
+	defer func() {
+		_, _ = db.Exec("DELETE FROM incidents WHERE id = $1", incident.ID)
+	}()
+	base := "/api/v1/incidents/" + incident.ID + "/attachments"
+
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, newUploadRequest(t, base, "big.log", "text/plain", bytes.Repeat([]byte("x"), 2048)))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status %d for a file over the limit, got %d", http.StatusRequestEntityTooLarge, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	server.router.ServeHTTP(w, newUploadRequest(t, base, "page.html", "text/html", []byte("<script>alert(1)</script>")))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var attachment models.IncidentAttachment
+	if err := json.NewDecoder(w.Body).Decode(&attachment); err != nil {
+		t.Fatalf("failed to decode attachment: %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	server.router.ServeHTTP(w, newUploadRequest(t, base, "second.log", "text/plain", []byte("x")))
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status %d past the per-incident limit, got %d", http.StatusConflict, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	server.router.ServeHTTP(w, httptest.NewRequest("GET", base+"/"+attachment.ID, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if body, _ := io.ReadAll(w.Body); string(body) != "<script>alert(1)</script>" {
+		t.Errorf("unexpected download: %q", body)
+	}
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename=page.html` {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+	if w.Header().Get("Content-Security-Policy") != "sandbox" || w.Header().Get("X-Content-Type-Options") != "nosniff" {
+		t.Errorf("expected the download to be sandboxed, got %v", w.Header())
+	}
+
+	w = httptest.NewRecorder()
+	server.router.ServeHTTP(w, httptest.NewRequest("DELETE", base+"/"+attachment.ID, nil))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+	w = httptest.NewRecorder()
+	server.router.ServeHTTP(w, httptest.NewRequest("GET", base+"/"+attachment.ID, nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected the deleted attachment to be gone, got %d", w.Code)
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestParseAuditFilter(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/v1/audit?actor=ops-cli&action=incident.retry&resource_id=inc_1&since=2024-05-01T00:00:00Z&limit=50", nil)
+	filter, err := parseAuditFilter(req)
+	if err != nil {
+		t.Fatalf("parseAuditFilter() error = %v", err)
+	}
+	if filter.Actor != "ops-cli" || filter.Action != models.AuditIncidentRetried || filter.ResourceID != "inc_1" || filter.Limit != 50 {
+		t.Errorf("unexpected filter %+v", filter)
+	}
+	if filter.Since == nil || filter.Since.Format("2006-01-02") != "2024-05-01" || filter.Until != nil {
+		t.Errorf("unexpected time range %v - %v", filter.Since, filter.Until)
+	}
+
+	for _, query := range []string{"since=yesterday", "until=2024-05-01", "limit=0", "limit=1001", "limit=ten"} {
+		if _, err := parseAuditFilter(httptest.NewRequest("GET", "/api/v1/audit?"+query, nil)); err == nil {
+			t.Errorf("expected %q to be rejected", query)
+		}
+	}
+}
+
+func TestIncidentAuditState_OmitsPayloads(t *testing.T) {
+	diagnosis := "the database password is hunter2"
+	state := incidentAuditState(&models.Incident{
+		Status:       models.StatusFailed,
+		Diagnosis:    &diagnosis,
+		ProviderData: map[string]interface{}{"token": "secret"},
+	})
+	if state["status"] != models.StatusFailed {
+		t.Errorf("expected the status, got %v", state)
+	}
+	for _, key := range []string{"diagnosis", "provider_data", "stack_trace"} {
+		if _, ok := state[key]; ok {
+			t.Errorf("expected %s to be left out", key)
+		}
+	}
+}
+
+func TestRequireAPIKey_SetsPrincipal(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		auth config.AuthConfig
+		key  string
+		want string
+	}{
+		{"no auth", config.AuthConfig{}, "", anonymousPrincipal},
+		{"api key", config.AuthConfig{APIKeys: []config.APIKey{{Name: "ops-cli", Key: "cli-secret"}}}, "cli-secret", "ops-cli"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{config: &config.Config{Server: config.ServerConfig{Auth: tt.auth}}, logger: NewLogger()}
+
+			var principal, requestID string
+			handler := requestLoggerMiddleware(s.logger)(s.requireAPIKey(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal = principalFrom(r.Context()).name
+				requestID = requestIDFrom(r.Context())
+			})))
+
+			req := httptest.NewRequest("POST", "/api/v1/incidents/inc_1/retry", nil)
+			req.Header.Set("X-API-Key", tt.key)
+			req.Header.Set(requestIDHeader, "req-42")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if principal != tt.want || requestID != "req-42" {
+				t.Errorf("got principal %q and request ID %q", principal, requestID)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// anonymousPrincipal is the principal of management requests when no API
+// keys are configured
+const anonymousPrincipal = "anonymous"
+
+// principal is the caller of a management request
+type principal struct {
+	name   string
+	tenant string // the only tenant the principal serves, or empty for all of them
+	teams  map[string]bool
+	scoped bool // limited to the incidents of its teams
+	admin  bool // an admin API key, or anyone when no keys are configured
+}
+
+// principalContextKey is the context key for the authenticated principal
+type principalContextKey struct{}
+
+// principalFrom returns the caller of the request ctx belongs to. Without
+// API keys, that is an unscoped anonymous principal.
+func principalFrom(ctx context.Context) *principal {
+	if p, ok := ctx.Value(principalContextKey{}).(*principal); ok {
+		return p
+	}
+	return &principal{name: anonymousPrincipal, admin: true}
+}
+
+// restricted reports whether the principal is limited to some incidents
+func (p *principal) restricted() bool {
+	return p.scoped || p.tenant != ""
+}
+
+// canAccess reports whether the principal may see and act on the incident
+func (p *principal) canAccess(incident *models.Incident) bool {
+	return p.allows(incident.TenantID, incident.Team)
+}
+
+func (p *principal) allows(tenant, team string) bool {
+	if p.tenant != "" && tenant != p.tenant {
+		return false
+	}
+	return !p.scoped || p.teams[team]
+}
+
+// visibleRelated leaves out the related incidents the principal cannot access
+func (p *principal) visibleRelated(related []models.RelatedIncident) []models.RelatedIncident {
+	if !p.restricted() {
+		return related
+	}
+	visible := []models.RelatedIncident{}
+	for _, incident := range related {
+		if p.allows(incident.TenantID, incident.Team) {
+			visible = append(visible, incident)
+		}
+	}
+	return visible
+}
+
+// teamFilter returns the teams whose incidents a list may hold, or nil when
+// the principal is not scoped
+func (p *principal) teamFilter() []string {
+	if !p.scoped {
+		return nil
+	}
+	teams := make([]string, 0, len(p.teams))
+	for team := range p.teams {
+		teams = append(teams, team)
+	}
+	sort.Strings(teams)
+	return teams
+}
+
+// scopeIncidents limits an incident filter to the incidents the principal
+// can access. It reports false when the filter asks for another tenant.
+func (p *principal) scopeIncidents(filter *database.IncidentFilter) bool {
+	filter.Teams = p.teamFilter()
+	return p.scopeTenant(&filter.TenantID)
+}
+
+// scopeRollups limits a rollup filter like scopeIncidents
+func (p *principal) scopeRollups(filter *database.RollupFilter) bool {
+	filter.Teams = p.teamFilter()
+	return p.scopeTenant(&filter.TenantID)
+}
+
+func (p *principal) scopeTenant(tenant **string) bool {
+	if p.tenant == "" {
+		return true
+	}
+	if *tenant != nil && **tenant != p.tenant {
+		return false
+	}
+	*tenant = &p.tenant
+	return true
+}
+
+// requireAPIKey rejects management API requests without one of the
+// configured API keys, sent as a bearer token or an X-API-Key header. It lets
+// every request through when no keys are configured.
+func (s *Server) requireAPIKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.config == nil || !s.config.Server.Auth.Enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		apiKey, ok := s.apiKey(requestAPIKey(r))
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="incident-service"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		s.loggerFrom(r.Context()).Debug("api key accepted", map[string]interface{}{
+			"api_key_name": apiKey.Name,
+		})
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalContextKey{}, s.keyPrincipal(apiKey))))
+	})
+}
+
+// requestAPIKey returns the API key a request was sent with, as a bearer
+// token or an X-API-Key header
+func requestAPIKey(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
+
+// keyPrincipal returns the principal of requests made with an API key
+func (s *Server) keyPrincipal(apiKey *config.APIKey) *principal {
+	p := &principal{
+		name:   apiKey.Name,
+		tenant: apiKey.Tenant,
+		teams:  make(map[string]bool, len(apiKey.Teams)),
+		scoped: s.config.Server.Auth.ScopeByTeam && !apiKey.Admin,
+		admin:  apiKey.Admin,
+	}
+	for _, team := range apiKey.Teams {
+		p.teams[team] = true
+	}
+	return p
+}
+
+// apiKey returns the configured API key matching key. Every key is
+// compared, so the time taken does not reveal which one matched.
+func (s *Server) apiKey(key string) (*config.APIKey, bool) {
+	if key == "" {
+		return nil, false
+	}
+
+	var match *config.APIKey
+	for i, apiKey := range s.config.Server.Auth.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey.Key)) == 1 {
+			match = &s.config.Server.Auth.APIKeys[i]
+		}
+	}
+	return match, match != nil
+}
+
+// requireIncidentAccess answers requests for an incident outside the
+// principal's tenant or teams as if the incident did not exist, so that
+// restricted keys cannot probe for other incidents
+func (s *Server) requireIncidentAccess(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := principalFrom(r.Context())
+		if !p.restricted() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		incident, err := s.repository.WithContext(r.Context()).GetByID(chi.URLParam(r, "id"))
+		if err != nil || !p.canAccess(incident) {
+			http.Error(w, "incident not found", http.StatusNotFound)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireUnscoped limits an endpoint to principals that see every incident
+func requireUnscoped(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if principalFrom(r.Context()).restricted() {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireAdmin limits an endpoint that acts on every team's work to admin
+// principals
+func requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !principalFrom(r.Context()).admin {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireAllTenants limits an endpoint that spans tenants to principals that
+// serve all of them
+func requireAllTenants(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if principalFrom(r.Context()).tenant != "" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,261 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func newAuthTestServer(auth config.AuthConfig) *Server {
+	s := &Server{
+		config: &config.Config{
+			Server:      config.ServerConfig{Auth: auth},
+			Concurrency: config.ConcurrencyConfig{MaxWorkflowsPerRepo: 1},
+		},
+		githubClient: github.NewClient("https://api.github.com", "token", "remediate.yml", 1),
+		logger:       NewLogger(),
+		router:       chi.NewRouter(),
+		startedAt:    time.Now(),
+	}
+	s.setupRoutes()
+	return s
+}
+
+func TestRequireAPIKey(t *testing.T) {
+	s := newAuthTestServer(config.AuthConfig{APIKeys: []config.APIKey{
+		{Name: "ops-cli", Key: "cli-secret"},
+		{Name: "dashboard", Key: "dashboard-secret"},
+	}})
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{"no key", "", "", http.StatusUnauthorized},
+		{"wrong bearer token", "Authorization", "Bearer wrong", http.StatusUnauthorized},
+		{"empty bearer token", "Authorization", "Bearer ", http.StatusUnauthorized},
+		{"bearer token", "Authorization", "Bearer cli-secret", http.StatusOK},
+		{"X-API-Key header", "X-API-Key", "dashboard-secret", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/queue", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			w := httptest.NewRecorder()
+			s.Router().ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+
+	// Metrics stay open to scrapers without a key
+	w := httptest.NewRecorder()
+	s.Router().ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected metrics without a key, got %d", w.Code)
+	}
+}
+
+func TestRequireAPIKey_NoKeysConfigured(t *testing.T) {
+	s := newAuthTestServer(config.AuthConfig{})
+
+	w := httptest.NewRecorder()
+	s.Router().ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/queue", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected an open API without keys, got %d", w.Code)
+	}
+}
+
+func TestHandleGetQueue(t *testing.T) {
+	gh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer gh.Close()
+
+	s := newAuthTestServer(config.AuthConfig{})
+	s.githubClient = github.NewClient(gh.URL, "token", "remediate.yml", 1)
+
+	// The first incident takes the repository's only slot, the rest queue up
+	for _, id := range []string{"inc_1", "inc_2", "inc_3"} {
+		_, _ = s.githubClient.DispatchWorkflow(context.Background(), &models.Incident{ID: id, Repository: "org/api"}, "main", github.DispatchOptions{})
+	}
+
+	w := httptest.NewRecorder()
+	s.Router().ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/queue", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var status QueueStatus
+	if err := json.NewDecoder(w.Body).Decode(&status); err != nil {
+		t.Fatalf("failed to decode queue status: %v", err)
+	}
+	if status.MaxPerRepo != 1 || status.Queued != 2 {
+		t.Errorf("unexpected queue status: %+v", status)
+	}
+	repo := status.Repositories["org/api"]
+	if len(repo.Incidents) != 2 || repo.Incidents[0] != "inc_2" {
+		t.Errorf("expected inc_2 next in the queue, got %+v", repo)
+	}
+	if len(repo.Leases) != 1 || repo.Leases[0].IncidentID != "inc_1" {
+		t.Errorf("expected inc_1 holding the slot, got %+v", repo.Leases)
+	}
+}
+
+func TestRequireAPIKey_ScopesByTeam(t *testing.T) {
+	s := newAuthTestServer(config.AuthConfig{ScopeByTeam: true, APIKeys: []config.APIKey{
+		{Name: "payments", Key: "payments-secret", Teams: []string{"payments"}},
+		{Name: "sre", Key: "sre-secret", Teams: []string{"platform"}, Admin: true},
+	}})
+
+	tests := []struct {
+		key        string
+		wantScoped bool
+	}{
+		{"payments-secret", true},
+		{"sre-secret", false},
+	}
+
+	for _, tt := range tests {
+		var p *principal
+		handler := s.requireAPIKey(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p = principalFrom(r.Context())
+		}))
+		req := httptest.NewRequest("GET", "/api/v1/incidents", nil)
+		req.Header.Set("X-API-Key", tt.key)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		if p == nil || p.scoped != tt.wantScoped {
+			t.Errorf("%s: expected scoped=%v, got %+v", tt.key, tt.wantScoped, p)
+		}
+	}
+
+	// The audit log spans every team, so it is closed to scoped keys
+	req := httptest.NewRequest("GET", "/api/v1/audit", nil)
+	req.Header.Set("X-API-Key", "payments-secret")
+	w := httptest.NewRecorder()
+	s.Router().ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected scoped keys to be refused the audit log, got %d", w.Code)
+	}
+
+	// Resetting a queue frees other teams' slots, so it takes an admin key
+	req = httptest.NewRequest("POST", "/api/v1/queue/org/checkout/reset", nil)
+	req.Header.Set("X-API-Key", "payments-secret")
+	w = httptest.NewRecorder()
+	s.Router().ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected scoped keys to be refused a queue reset, got %d", w.Code)
+	}
+
+	// An unmapped service has no team yet, so mapping it takes an admin key too
+	req = httptest.NewRequest("POST", "/api/v1/services/batch-jobs/mapping", strings.NewReader(`{"repository": "org/batch-jobs"}`))
+	req.Header.Set("X-API-Key", "payments-secret")
+	w = httptest.NewRecorder()
+	s.Router().ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected scoped keys to be refused mapping a service, got %d", w.Code)
+	}
+}
+
+func TestPrincipal_Access(t *testing.T) {
+	scoped := &principal{name: "payments", teams: map[string]bool{"payments": true, "billing": true}, scoped: true}
+	unscoped := principalFrom(context.Background())
+
+	own := &models.Incident{ID: "inc_1", Team: "payments"}
+	other := &models.Incident{ID: "inc_2", Team: "search"}
+	unowned := &models.Incident{ID: "inc_3"}
+
+	if !scoped.canAccess(own) || scoped.canAccess(other) || scoped.canAccess(unowned) {
+		t.Error("expected a scoped principal to access only its teams' incidents")
+	}
+	if !unscoped.canAccess(other) || !unscoped.canAccess(unowned) {
+		t.Error("expected an unscoped principal to access every incident")
+	}
+
+	related := []models.RelatedIncident{{IncidentID: "inc_1", Team: "payments"}, {IncidentID: "inc_2", Team: "search"}}
+	if visible := scoped.visibleRelated(related); len(visible) != 1 || visible[0].IncidentID != "inc_1" {
+		t.Errorf("expected only inc_1 to be visible, got %+v", visible)
+	}
+	if visible := unscoped.visibleRelated(related); len(visible) != 2 {
+		t.Errorf("expected every related incident to be visible, got %+v", visible)
+	}
+
+	if teams := scoped.teamFilter(); len(teams) != 2 || teams[0] != "billing" || teams[1] != "payments" {
+		t.Errorf("unexpected team filter %v", teams)
+	}
+	if teams := unscoped.teamFilter(); teams != nil {
+		t.Errorf("expected no team filter, got %v", teams)
+	}
+}
+
+func TestPrincipal_TenantIsolation(t *testing.T) {
+	p := &principal{name: "retail", tenant: "retail"}
+
+	if !p.canAccess(&models.Incident{ID: "inc_1", TenantID: "retail"}) || p.canAccess(&models.Incident{ID: "inc_2", TenantID: "wholesale"}) {
+		t.Error("expected a tenant key to access only its tenant's incidents")
+	}
+	related := []models.RelatedIncident{{IncidentID: "inc_1", TenantID: "retail"}, {IncidentID: "inc_2", TenantID: "wholesale"}}
+	if visible := p.visibleRelated(related); len(visible) != 1 || visible[0].IncidentID != "inc_1" {
+		t.Errorf("expected only inc_1 to be visible, got %+v", visible)
+	}
+
+	filter := &database.IncidentFilter{}
+	if !p.scopeIncidents(filter) || filter.TenantID == nil || *filter.TenantID != "retail" {
+		t.Errorf("expected lists to be limited to the key's tenant, got %+v", filter)
+	}
+	other := "wholesale"
+	if p.scopeRollups(&database.RollupFilter{TenantID: &other}) {
+		t.Error("expected statistics of another tenant to be refused")
+	}
+	if unscoped := principalFrom(context.Background()); !unscoped.scopeRollups(&database.RollupFilter{TenantID: &other}) {
+		t.Error("expected keys without a tenant to read any tenant's statistics")
+	}
+}
+
+func TestRequireAPIKey_TenantKeys(t *testing.T) {
+	s := newAuthTestServer(config.AuthConfig{APIKeys: []config.APIKey{
+		{Name: "retail", Key: "retail-secret", Tenant: "retail"},
+		{Name: "ops", Key: "ops-secret"},
+	}})
+
+	tests := []struct {
+		key  string
+		path string
+		want int
+	}{
+		// Endpoints spanning tenants are closed to tenant keys
+		{"retail-secret", "/api/v1/queue", http.StatusForbidden},
+		{"retail-secret", "/api/v1/config", http.StatusForbidden},
+		{"retail-secret", "/api/v1/audit", http.StatusForbidden},
+		{"retail-secret", "/api/v1/statistics?tenant=wholesale", http.StatusForbidden},
+		{"retail-secret", "/api/v1/incidents?tenant=wholesale", http.StatusForbidden},
+		{"ops-secret", "/api/v1/queue", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.path, nil)
+		req.Header.Set("X-API-Key", tt.key)
+		w := httptest.NewRecorder()
+		s.Router().ServeHTTP(w, req)
+		if w.Code != tt.want {
+			t.Errorf("%s %s: expected %d, got %d", tt.key, tt.path, tt.want, w.Code)
+		}
+	}
+}
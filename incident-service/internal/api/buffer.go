@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+)
+
+// SetWebhookBuffer keeps webhooks that cannot be stored while the database is
+// unavailable in buffer, to be processed again with ProcessBuffered
+func (s *Server) SetWebhookBuffer(buffer database.WebhookBuffer) {
+	s.buffer = buffer
+}
+
+// databaseDown reports whether the database is unreachable, as opposed to
+// rejecting a single write
+func (s *Server) databaseDown() bool {
+	return s.db != nil && s.db.Health() != nil
+}
+
+// bufferWebhook keeps a webhook that failed to store during a database
+// outage, and reports whether it was kept
+func (s *Server) bufferWebhook(ctx context.Context, job ingestJob, logger *Logger) bool {
+	if s.buffer == nil || !s.databaseDown() {
+		return false
+	}
+
+	webhook := &database.BufferedWebhook{
+		Provider:   job.provider,
+		Body:       job.body,
+		ReceivedAt: job.receivedAt,
+		RequestID:  requestIDFrom(ctx),
+	}
+	if err := s.buffer.Push(ctx, webhook); err != nil {
+		fields := map[string]interface{}{
+			"error": err.Error(),
+		}
+		if errors.Is(err, database.ErrBufferFull) {
+			logger.Error("webhook buffer full, dropping webhook", fields)
+		} else {
+			logger.Error("failed to buffer webhook", fields)
+		}
+		return false
+	}
+
+	if depth, err := s.buffer.Len(ctx); err == nil {
+		s.metrics.SetBufferDepth(depth)
+	}
+	logger.Warn("database unavailable, webhook buffered", nil)
+	return true
+}
+
+// ProcessBuffered parses and stores a webhook buffered during a database
+// outage. It is buffered again if the database is still unavailable.
+func (s *Server) ProcessBuffered(ctx context.Context, webhook *database.BufferedWebhook) {
+	logger := s.logger.With(map[string]interface{}{
+		"provider": webhook.Provider,
+	})
+
+	adapter, ok := s.adapters.Get(webhook.Provider)
+	if !ok {
+		logger.Error("dropping buffered webhook of unsupported provider", nil)
+		return
+	}
+
+	if webhook.RequestID != "" {
+		ctx = context.WithValue(ctx, requestIDContextKey{}, webhook.RequestID)
+		logger = logger.With(map[string]interface{}{
+			"request_id": webhook.RequestID,
+		})
+	}
+
+	s.processWebhook(ingestJob{
+		ctx:        ContextWithLogger(ctx, logger),
+		provider:   webhook.Provider,
+		adapter:    adapter,
+		body:       webhook.Body,
+		receivedAt: webhook.ReceivedAt,
+		replayed:   true,
+	})
+}
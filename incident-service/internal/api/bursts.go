@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// burstReason is the reason recorded on remediations deferred by a burst
+const burstReason = "burst"
+
+// deferForBurst holds back the remediation of an incident that is not
+// urgent while remediations are dispatched in a burst, and reports whether
+// it did. The maintenance worker dispatches it once the burst has ended,
+// most severe first. Dispatches a person asked for are never held back.
+func (s *Server) deferForBurst(ctx context.Context, inc *models.Incident, logger *Logger) bool {
+	if s.bursts == nil || manualDispatch(ctx) {
+		return false
+	}
+	admitted, until := s.bursts.Admit(inc.Severity, time.Now())
+	if admitted {
+		return false
+	}
+
+	repository := s.repository.WithContext(ctx)
+	inc.DeferredUntil = &until
+	if err := repository.Update(inc); err != nil {
+		// Without its deferral stored, nothing would dispatch it later
+		inc.DeferredUntil = nil
+		logger.Error("failed to defer remediation during dispatch burst", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return false
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: inc.ID,
+		EventType:  models.EventQueuedForRemediation,
+		EventData: map[string]interface{}{
+			"reason":         burstReason,
+			"severity":       inc.Severity,
+			"deferred_until": until,
+		},
+	}
+	if err := repository.LogEvent(event); err != nil {
+		logger.Error("failed to log queue event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	logger.Info("remediation deferred until the dispatch burst ends", map[string]interface{}{
+		"severity":       inc.Severity,
+		"deferred_until": until,
+	})
+	return true
+}
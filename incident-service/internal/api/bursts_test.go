@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestDispatchIncident_DefersDuringBurst(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	var dispatches int32
+	githubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/dispatches") {
+			atomic.AddInt32(&dispatches, 1)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer githubServer.Close()
+
+	cfg := &config.Config{
+		Server:      config.ServerConfig{Port: 8080},
+		Concurrency: config.ConcurrencyConfig{MaxWorkflowsPerRepo: 10, BurstThreshold: 1, BurstWindow: time.Hour},
+	}
+	server := NewServer(cfg, db, nil, github.NewClient(githubServer.URL, "test-token", "test-workflow.yml", 10), NewLogger())
+	repository := database.NewIncidentRepository(db)
+
+	create := func(id, severity string) *models.Incident {
+		incident := &models.Incident{
+			ID:           id,
+			ServiceName:  "checkout",
+			Repository:   "org/burst-test",
+			ErrorMessage: "test error " + id,
+			Severity:     severity,
+			Status:       models.StatusPending,
+			Provider:     "test",
+		}
+		if err := repository.Create(incident); err != nil {
+			t.Fatalf("failed to create test incident: %v", err)
+		}
+		t.Cleanup(func() {
+			_, _ = db.Exec("DELETE FROM incidents WHERE id = $1", id)
+		})
+		return incident
+	}
+
+	// The first dispatch fills the window; after it only urgent incidents
+	// are dispatched right away
+	for _, incident := range []*models.Incident{
+		create("test-incident-burst-first", "low"),
+		create("test-incident-burst-low", "low"),
+		create("test-incident-burst-critical", "critical"),
+	} {
+		if err := server.DispatchIncident(context.Background(), incident); err != nil {
+			t.Fatalf("DispatchIncident(%s) error = %v", incident.ID, err)
+		}
+	}
+	if n := atomic.LoadInt32(&dispatches); n != 2 {
+		t.Errorf("expected the first and the critical incident dispatched, got %d dispatches", n)
+	}
+
+	deferred, err := repository.GetByID("test-incident-burst-low")
+	if err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+	if deferred.Status != models.StatusPending || deferred.DeferredUntil == nil || time.Until(*deferred.DeferredUntil) < 50*time.Minute {
+		t.Errorf("expected the low incident pending until the burst ends, got %s until %v", deferred.Status, deferred.DeferredUntil)
+	}
+	events, err := repository.GetEventsByIncidentID(deferred.ID)
+	if err != nil {
+		t.Fatalf("failed to get events: %v", err)
+	}
+	queued := false
+	for _, event := range events {
+		if event.EventType == models.EventQueuedForRemediation && event.EventData["reason"] == burstReason {
+			queued = true
+		}
+	}
+	if !queued {
+		t.Errorf("expected a %s event for the burst, got %+v", models.EventQueuedForRemediation, events)
+	}
+
+	// A person asking for the dispatch is not held back
+	if err := server.dispatchIncident(withManualDispatch(context.Background()), deferred, server.logger); err != nil {
+		t.Fatalf("dispatchIncident() error = %v", err)
+	}
+	if n := atomic.LoadInt32(&dispatches); n != 3 {
+		t.Errorf("expected the manual dispatch to go out during the burst, got %d dispatches", n)
+	}
+}
@@ -0,0 +1,9 @@
+package api
+
+import "github.com/your-org/ai-sre-platform/incident-service/internal/chaos"
+
+// SetChaos injects the failures of injector into webhook validation and
+// parsing, for integration tests of the failure paths
+func (s *Server) SetChaos(injector *chaos.Injector) {
+	s.adapters.Wrap(injector.Adapter)
+}
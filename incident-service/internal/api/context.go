@@ -0,0 +1,98 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// contextToken signs access to the full context of an incident until expires
+func contextToken(key, incidentID string, expires time.Time) string {
+	return fmt.Sprintf("%d.%s", expires.Unix(), contextSignature(key, incidentID, expires.Unix()))
+}
+
+// contextSignature is the hex encoded HMAC-SHA256 of an incident ID and the
+// expiry of its token
+func contextSignature(key, incidentID string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	fmt.Fprintf(mac, "%s\n%d", incidentID, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyContextToken reports whether token signs access to the context of
+// the incident and has not expired at now
+func verifyContextToken(key, incidentID, token string, now time.Time) bool {
+	expiry, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	expires, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || now.Unix() >= expires {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(contextSignature(key, incidentID, expires)))
+}
+
+// contextURL returns the signed URL the remediation workflow of an incident
+// fetches the incident's full context from, or "" when no context URL is
+// configured
+func (s *Server) contextURL(inc *models.Incident) string {
+	if s.config == nil || s.config.WorkflowInputs.ContextURL == "" {
+		return ""
+	}
+	cfg := s.config.WorkflowInputs
+	token := contextToken(cfg.SigningKey, inc.ID, time.Now().Add(cfg.TTL()))
+	return fmt.Sprintf("%s/api/v1/incidents/%s/context?token=%s",
+		strings.TrimSuffix(cfg.ContextURL, "/"), url.PathEscape(inc.ID), url.QueryEscape(token))
+}
+
+// handleGetIncidentContext returns the whole incident to its remediation
+// workflow: the stack trace, provider data and enrichment that may have been
+// cut from the workflow's inputs, with the service's runbooks and the alerts
+// other providers sent about the same outage. It takes the token of the
+// context URL passed at dispatch instead of an API key.
+func (s *Server) handleGetIncidentContext(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if s.config == nil || s.config.WorkflowInputs.ContextURL == "" {
+		http.Error(w, "incident context is not enabled", http.StatusNotFound)
+		return
+	}
+	if !verifyContextToken(s.config.WorkflowInputs.SigningKey, id, r.URL.Query().Get("token"), time.Now()) {
+		http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+		return
+	}
+
+	incident, err := s.repository.WithContext(r.Context()).GetByID(id)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to get incident", map[string]interface{}{
+			"error": err.Error(),
+			"id":    id,
+		})
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+	response := s.newIncidentResponse(incident)
+	response.CorrelatedAlerts, err = s.repository.WithContext(r.Context()).ListCorrelatedAlerts(id)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to list correlated alerts", map[string]interface{}{
+			"error": err.Error(),
+			"id":    id,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestContextURL(t *testing.T) {
+	key := strings.Repeat("k", 32)
+	server := &Server{config: &config.Config{WorkflowInputs: config.WorkflowInputsConfig{
+		ContextURL: "https://incidents.example.com/",
+		SigningKey: key,
+		TokenTTL:   10 * time.Minute,
+	}}}
+
+	before := time.Now()
+	raw := server.contextURL(&models.Incident{ID: "inc_1"})
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("contextURL() = %q, not a URL: %v", raw, err)
+	}
+	if parsed.Host != "incidents.example.com" || parsed.Path != "/api/v1/incidents/inc_1/context" {
+		t.Errorf("contextURL() = %q, want the incident's context endpoint", raw)
+	}
+
+	token := parsed.Query().Get("token")
+	expires, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		t.Fatalf("expected an expiry and a signature in the token, got %q", token)
+	}
+	want := contextToken(key, "inc_1", before.Add(10*time.Minute))
+	if wantExpires, _, _ := strings.Cut(want, "."); expires < wantExpires {
+		t.Errorf("token expires at %s, want %s or later", expires, wantExpires)
+	}
+	if signature == "" || token == contextToken(key, "inc_2", before.Add(10*time.Minute)) {
+		t.Error("expected the token signed for the incident")
+	}
+
+	server.config.WorkflowInputs.ContextURL = ""
+	if got := server.contextURL(&models.Incident{ID: "inc_1"}); got != "" {
+		t.Errorf("contextURL() = %q, want none without a context URL", got)
+	}
+}
+
+func TestVerifyContextToken(t *testing.T) {
+	key := strings.Repeat("k", 32)
+	now := time.Now()
+	token := contextToken(key, "inc_1", now.Add(time.Minute))
+
+	tests := []struct {
+		name       string
+		key        string
+		incidentID string
+		token      string
+		now        time.Time
+		want       bool
+	}{
+		{"valid", key, "inc_1", token, now, true},
+		{"expired", key, "inc_1", token, now.Add(time.Minute), false},
+		{"other incident", key, "inc_2", token, now, false},
+		{"other key", strings.Repeat("x", 32), "inc_1", token, now, false},
+		{"extended expiry", key, "inc_1", strings.Replace(token, ".", "0.", 1), now, false},
+		{"no signature", key, "inc_1", strings.SplitN(token, ".", 2)[0], now, false},
+		{"malformed expiry", key, "inc_1", "soon." + contextSignature(key, "inc_1", 0), now, false},
+		{"empty", key, "inc_1", "", now, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := verifyContextToken(tt.key, tt.incidentID, tt.token, tt.now); got != tt.want {
+				t.Errorf("verifyContextToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleGetIncidentContext_RejectsTokens(t *testing.T) {
+	// The endpoint takes the signed token instead of an API key, and turns
+	// requests away before the repository is used
+	key := strings.Repeat("k", 32)
+	server := newAuthTestServer(config.AuthConfig{APIKeys: []config.APIKey{{Name: "ops-cli", Key: "cli-secret"}}})
+	server.config.WorkflowInputs = config.WorkflowInputsConfig{ContextURL: "https://incidents.example.com", SigningKey: key}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"no token", ""},
+		{"expired token", contextToken(key, "inc_1", time.Now().Add(-time.Second))},
+		{"token of another incident", contextToken(key, "inc_2", time.Now().Add(time.Minute))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/incidents/inc_1/context?token="+url.QueryEscape(tt.token), nil)
+			w := httptest.NewRecorder()
+			server.Router().ServeHTTP(w, req)
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("expected status %d, got %d", http.StatusUnauthorized, w.Code)
+			}
+		})
+	}
+
+	server.config.WorkflowInputs.ContextURL = ""
+	req := httptest.NewRequest("GET", "/api/v1/incidents/inc_1/context?token="+url.QueryEscape(contextToken(key, "inc_1", time.Now().Add(time.Minute))), nil)
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d without a context URL, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestHandleGetIncidentContext_ReturnsIncident(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	key := strings.Repeat("k", 32)
+	cfg := &config.Config{
+		Server:         config.ServerConfig{Port: 8080, Auth: config.AuthConfig{APIKeys: []config.APIKey{{Name: "ops-cli", Key: "cli-secret"}}}},
+		WorkflowInputs: config.WorkflowInputsConfig{ContextURL: "https://incidents.example.com", SigningKey: key},
+	}
+	redis, _ := database.ConnectRedis("localhost:6379", "", 0)
+	server := NewServer(cfg, db, redis, nil, NewLogger())
+
+	stackTrace := strings.Repeat("at handler (app.js:1:1)\n", 5000)
+	enrichment := `{"sentry":{"events":3}}`
+	incident := &models.Incident{
+		ID:           "test-incident-context",
+		ServiceName:  "checkout",
+		ErrorMessage: "test error",
+		StackTrace:   &stackTrace,
+		Enrichment:   &enrichment,
+		Status:       models.StatusPending,
+		Provider:     "test",
+		ProviderData: map[string]interface{}{"level": "error"},
+	}
+	if err := database.NewIncidentRepository(db).Create(incident); err != nil {
+		t.Fatalf("failed to create test incident: %v", err)
+	}
+	defer func() {
+		_, _ = db.Exec("DELETE FROM incidents WHERE id = $1", incident.ID)
+	}()
+
+	contextURL, err := url.Parse(server.contextURL(incident))
+	if err != nil {
+		t.Fatalf("failed to parse context URL: %v", err)
+	}
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, httptest.NewRequest("GET", contextURL.RequestURI(), nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response IncidentResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.StackTrace == nil || *response.StackTrace != stackTrace {
+		t.Error("expected the full stack trace")
+	}
+	if response.Enrichment == nil || *response.Enrichment != enrichment {
+		t.Error("expected the enrichment")
+	}
+	if response.ProviderData["level"] != "error" {
+		t.Errorf("expected the provider data, got %v", response.ProviderData)
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// lockCorrelation keeps other replicas from storing an incident of the same
+// service until unlock is called, when correlation is enabled. Alerts of
+// several providers arriving at once would otherwise each find no incident to
+// join and each create one.
+func (s *Server) lockCorrelation(ctx context.Context, incident *models.Incident, logger *Logger) (unlock func()) {
+	if s.config == nil || !s.config.Correlation.Enabled {
+		return func() {}
+	}
+
+	key := fmt.Sprintf("incident-service:correlate:%s:%s", incident.TenantID, incident.ServiceName)
+	return s.lockIngestion(ctx, key, "service for correlation", logger)
+}
+
+// correlate attaches the incident's alert to a recent incident of its
+// service reported by another provider, as the same outage, when correlation
+// is enabled, and reports whether it did. The alert then starts no
+// remediation or notification of its own.
+func (s *Server) correlate(ctx context.Context, incident *models.Incident, logger *Logger) bool {
+	if s.config == nil || !s.config.Correlation.Enabled {
+		return false
+	}
+
+	repository := s.repository.WithContext(ctx)
+	targetID, err := repository.FindCorrelatedIncident(incident.TenantID, incident.ServiceName, incident.Provider, incident.Fingerprint, s.config.Correlation.WindowSize())
+	if err != nil {
+		logger.Error("failed to find correlated incident", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return false
+	}
+	if targetID == "" {
+		return false
+	}
+
+	logger = logger.With(map[string]interface{}{
+		"correlated_incident_id": targetID,
+	})
+
+	alert := models.NewCorrelatedAlert(targetID, incident)
+	if err := repository.AttachCorrelatedAlert(alert); err != nil {
+		// Losing the alert would be worse than a separate incident
+		logger.Error("failed to attach correlated alert", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return false
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: targetID,
+		EventType:  models.EventAlertCorrelated,
+		EventData: map[string]interface{}{
+			"alert_id":      alert.ID,
+			"provider":      incident.Provider,
+			"provider_ref":  incident.ProviderRef,
+			"error_message": incident.ErrorMessage,
+		},
+	}
+	if err := repository.LogEvent(event); err != nil {
+		logger.Error("failed to log alert correlated event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	logger.Info("alert correlated with an incident of another provider", map[string]interface{}{
+		"provider": incident.Provider,
+	})
+	return true
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestProcessWebhook_CorrelatesProviders(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	service := fmt.Sprintf("checkout-correlation-%d", time.Now().UnixNano())
+	cfg := &config.Config{
+		Server:          config.ServerConfig{Port: 8080},
+		ServiceMappings: []config.ServiceMapping{{ServiceName: service, Repository: "org/checkout", Branch: "main"}},
+		Correlation:     config.CorrelationConfig{Enabled: true},
+	}
+	server := NewServer(cfg, db, nil, nil, NewLogger())
+	defer func() {
+		_, _ = db.Exec("DELETE FROM incidents WHERE service_name = $1", service)
+	}()
+
+	// The same outage as seen by three providers, each with its own message
+	for _, alert := range []struct{ provider, message string }{
+		{"datadog", "High error rate on checkout"},
+		{"sentry", "TypeError: cannot read properties of undefined"},
+		{"pagerduty", "checkout is down"},
+	} {
+		server.processWebhook(ingestJob{
+			ctx:        context.Background(),
+			provider:   alert.provider,
+			receivedAt: time.Now(),
+			incident: &models.Incident{
+				ID:           "inc_" + alert.provider,
+				ServiceName:  service,
+				Repository:   "org/checkout",
+				ErrorMessage: alert.message,
+				Severity:     "high",
+				Status:       models.StatusPending,
+				Provider:     alert.provider,
+			},
+		})
+	}
+
+	repository := database.NewIncidentRepository(db)
+	incidents, err := repository.ListWithFilter(&database.IncidentFilter{ServiceName: &service})
+	if err != nil {
+		t.Fatalf("ListWithFilter() error = %v", err)
+	}
+	if len(incidents) != 1 || incidents[0].Provider != "datadog" {
+		t.Fatalf("expected one incident from datadog, got %d", len(incidents))
+	}
+
+	alerts, err := repository.ListCorrelatedAlerts(incidents[0].ID)
+	if err != nil {
+		t.Fatalf("ListCorrelatedAlerts() error = %v", err)
+	}
+	if len(alerts) != 2 || alerts[0].Provider != "sentry" || alerts[1].Provider != "pagerduty" {
+		t.Errorf("expected the sentry and pagerduty alerts attached, got %+v", alerts)
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/dashboard"
+)
+
+// mountDashboard serves the embedded dashboard under /. The API keeps its
+// routes under /api, so only paths no API route matches reach the dashboard.
+func (s *Server) mountDashboard() {
+	assets, ok := dashboard.Assets()
+	if !ok {
+		s.logger.Error("dashboard is enabled but this binary was built without it, run go generate ./internal/dashboard before building", nil)
+		return
+	}
+	s.serveDashboard(assets)
+}
+
+// serveDashboard routes the paths no other route matches to the dashboard assets
+func (s *Server) serveDashboard(assets fs.FS) {
+	handler := dashboardHandler(assets)
+	s.router.Get("/*", handler.ServeHTTP)
+	s.router.Head("/*", handler.ServeHTTP)
+}
+
+// dashboardHandler serves the dashboard's static files. Paths that are not
+// files are client-side routes, such as /incidents/inc_123, and get
+// index.html so the app can route them.
+func dashboardHandler(assets fs.FS) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Unknown API paths are errors, not pages
+		if r.URL.Path == "/api" || strings.HasPrefix(r.URL.Path, "/api/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		name := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+		if info, err := fs.Stat(assets, name); name == "" || err != nil || info.IsDir() {
+			name = "index.html"
+		}
+
+		file, err := assets.Open(name)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil {
+			http.Error(w, "failed to read dashboard asset", http.StatusInternalServerError)
+			return
+		}
+		content, ok := file.(io.ReadSeeker)
+		if !ok {
+			http.Error(w, "failed to read dashboard asset", http.StatusInternalServerError)
+			return
+		}
+
+		// Vite puts a content hash in the names of built assets, so they never
+		// change; index.html points at the current ones and must be revalidated
+		if strings.HasPrefix(name, "assets/") {
+			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		} else {
+			w.Header().Set("Cache-Control", "no-cache")
+		}
+		http.ServeContent(w, r, name, info.ModTime(), content)
+	})
+}
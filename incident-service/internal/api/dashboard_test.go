@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+)
+
+var testDashboard = fstest.MapFS{
+	"index.html":           {Data: []byte("<html>dashboard</html>")},
+	"assets/index-1a2b.js": {Data: []byte("console.log('app')")},
+	"favicon.svg":          {Data: []byte("<svg/>")},
+}
+
+func TestDashboardHandler(t *testing.T) {
+	handler := dashboardHandler(testDashboard)
+
+	tests := []struct {
+		path         string
+		code         int
+		body         string
+		cacheControl string
+	}{
+		{"/", http.StatusOK, "dashboard", "no-cache"},
+		{"/favicon.svg", http.StatusOK, "<svg/>", "no-cache"},
+		{"/assets/index-1a2b.js", http.StatusOK, "console.log", "public, max-age=31536000, immutable"},
+		// Client-side routes and directories load the app
+		{"/incidents/inc_123", http.StatusOK, "dashboard", "no-cache"},
+		{"/assets/", http.StatusOK, "dashboard", "no-cache"},
+		{"/api/v1/unknown", http.StatusNotFound, "404 page not found", ""},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		if w.Code != tt.code || !strings.Contains(w.Body.String(), tt.body) {
+			t.Errorf("%s: got %d %q, want %d containing %q", tt.path, w.Code, w.Body.String(), tt.code, tt.body)
+		}
+		if got := w.Header().Get("Cache-Control"); got != tt.cacheControl {
+			t.Errorf("%s: Cache-Control = %q, want %q", tt.path, got, tt.cacheControl)
+		}
+	}
+}
+
+func TestDashboard_APIRoutesTakePrecedence(t *testing.T) {
+	s := &Server{
+		config:       &config.Config{},
+		githubClient: github.NewClient("https://api.github.com", "token", "remediate.yml", 1),
+		logger:       NewLogger(),
+		router:       chi.NewRouter(),
+		startedAt:    time.Now(),
+	}
+	s.setupRoutes()
+	s.serveDashboard(testDashboard)
+
+	w := httptest.NewRecorder()
+	s.Router().ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+	if strings.Contains(w.Body.String(), "dashboard") {
+		t.Errorf("/readyz was served by the dashboard")
+	}
+
+	w = httptest.NewRecorder()
+	s.Router().ServeHTTP(w, httptest.NewRequest("GET", "/incidents", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "dashboard") {
+		t.Errorf("expected the dashboard at /incidents, got %d %q", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+)
+
+// DebugStatus is a snapshot of runtime state for diagnosing slowdowns
+type DebugStatus struct {
+	Timestamp  string          `json:"timestamp"`
+	Uptime     string          `json:"uptime"`
+	GoVersion  string          `json:"go_version"`
+	NumCPU     int             `json:"num_cpu"`
+	GOMAXPROCS int             `json:"gomaxprocs"`
+	Goroutines int             `json:"goroutines"`
+	Memory     DebugMemory     `json:"memory"`
+	GC         DebugGC         `json:"gc"`
+	Database   *DebugDatabase  `json:"database,omitempty"`
+	Workflows  *DebugWorkflows `json:"workflows,omitempty"`
+}
+
+// DebugMemory reports heap usage
+type DebugMemory struct {
+	HeapAllocBytes uint64 `json:"heap_alloc_bytes"`
+	HeapInuseBytes uint64 `json:"heap_inuse_bytes"`
+	HeapObjects    uint64 `json:"heap_objects"`
+	SysBytes       uint64 `json:"sys_bytes"`
+}
+
+// DebugGC reports garbage collector activity
+type DebugGC struct {
+	NumGC        uint32  `json:"num_gc"`
+	LastGC       string  `json:"last_gc,omitempty"`
+	LastPauseMs  float64 `json:"last_pause_ms"`
+	PauseTotalMs float64 `json:"pause_total_ms"`
+	NextGCBytes  uint64  `json:"next_gc_bytes"`
+	CPUFraction  float64 `json:"cpu_fraction"`
+}
+
+// DebugDatabase reports connection pool usage
+type DebugDatabase struct {
+	OpenConnections int    `json:"open_connections"`
+	InUse           int    `json:"in_use"`
+	Idle            int    `json:"idle"`
+	WaitCount       int64  `json:"wait_count"`
+	WaitDuration    string `json:"wait_duration"`
+}
+
+// DebugWorkflows reports workflow concurrency and queue sizes
+type DebugWorkflows struct {
+	Active       int                               `json:"active"`
+	Queued       int                               `json:"queued"`
+	Repositories map[string]github.RepositoryStats `json:"repositories"`
+}
+
+// mountDebugRoutes exposes pprof and the runtime status endpoint behind the debug token
+func (s *Server) mountDebugRoutes() {
+	s.router.Group(func(r chi.Router) {
+		r.Use(s.requireDebugToken)
+		r.Mount("/debug", middleware.Profiler())
+		r.Get("/api/v1/debug/status", s.handleDebugStatus)
+	})
+}
+
+// requireDebugToken rejects requests without the configured bearer token
+func (s *Server) requireDebugToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		expected := s.config.Server.Debug.Token
+		if expected == "" || subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleDebugStatus reports goroutines, memory, GC, connection pool and queue state
+func (s *Server) handleDebugStatus(w http.ResponseWriter, r *http.Request) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	status := DebugStatus{
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+		Uptime:     time.Since(s.startedAt).Round(time.Second).String(),
+		GoVersion:  runtime.Version(),
+		NumCPU:     runtime.NumCPU(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		Goroutines: runtime.NumGoroutine(),
+		Memory: DebugMemory{
+			HeapAllocBytes: mem.HeapAlloc,
+			HeapInuseBytes: mem.HeapInuse,
+			HeapObjects:    mem.HeapObjects,
+			SysBytes:       mem.Sys,
+		},
+		GC: DebugGC{
+			NumGC:        mem.NumGC,
+			PauseTotalMs: float64(mem.PauseTotalNs) / float64(time.Millisecond),
+			NextGCBytes:  mem.NextGC,
+			CPUFraction:  mem.GCCPUFraction,
+		},
+	}
+
+	if mem.NumGC > 0 {
+		status.GC.LastGC = time.Unix(0, int64(mem.LastGC)).UTC().Format(time.RFC3339)
+		status.GC.LastPauseMs = float64(mem.PauseNs[(mem.NumGC+255)%256]) / float64(time.Millisecond)
+	}
+
+	if s.db != nil {
+		stats := s.db.Stats()
+		status.Database = &DebugDatabase{
+			OpenConnections: stats.OpenConnections,
+			InUse:           stats.InUse,
+			Idle:            stats.Idle,
+			WaitCount:       stats.WaitCount,
+			WaitDuration:    stats.WaitDuration.String(),
+		}
+	}
+
+	if s.githubClient != nil {
+		workflows := &DebugWorkflows{Repositories: s.githubClient.Stats()}
+		for _, repo := range workflows.Repositories {
+			workflows.Active += repo.Active
+			workflows.Queued += repo.Queued
+		}
+		status.Workflows = workflows
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+)
+
+func newDebugTestServer(debug config.DebugConfig) *Server {
+	s := &Server{
+		config:       &config.Config{Server: config.ServerConfig{Debug: debug}},
+		githubClient: github.NewClient("https://api.github.com", "token", "remediate.yml", 1),
+		logger:       NewLogger(),
+		router:       chi.NewRouter(),
+		startedAt:    time.Now(),
+	}
+	s.setupRoutes()
+	return s
+}
+
+func TestDebugRoutes_Disabled(t *testing.T) {
+	s := newDebugTestServer(config.DebugConfig{})
+
+	for _, path := range []string{"/api/v1/debug/status", "/debug/pprof/"} {
+		w := httptest.NewRecorder()
+		s.Router().ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404 when debug is disabled, got %d", path, w.Code)
+		}
+	}
+}
+
+func TestDebugRoutes_RequireToken(t *testing.T) {
+	s := newDebugTestServer(config.DebugConfig{Enabled: true, Token: "secret"})
+
+	for _, auth := range []string{"", "Bearer wrong"} {
+		req := httptest.NewRequest("GET", "/api/v1/debug/status", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		w := httptest.NewRecorder()
+		s.Router().ServeHTTP(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Authorization %q: expected 401, got %d", auth, w.Code)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/debug/pprof/", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	s.Router().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected pprof index with valid token, got %d", w.Code)
+	}
+}
+
+func TestHandleDebugStatus(t *testing.T) {
+	s := newDebugTestServer(config.DebugConfig{Enabled: true, Token: "secret"})
+
+	req := httptest.NewRequest("GET", "/api/v1/debug/status", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	s.Router().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var status DebugStatus
+	if err := json.NewDecoder(w.Body).Decode(&status); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if status.Goroutines == 0 {
+		t.Error("expected goroutine count")
+	}
+	if status.Memory.HeapAllocBytes == 0 {
+		t.Error("expected heap stats")
+	}
+	if status.Workflows == nil || status.Workflows.Active != 0 || status.Workflows.Queued != 0 {
+		t.Errorf("expected empty workflow queues, got %+v", status.Workflows)
+	}
+	if status.Database != nil {
+		t.Errorf("expected no database stats without a database, got %+v", status.Database)
+	}
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// fingerprint sets the incident's fingerprint as the deduplication strategy
+// of its service wants it, and returns the strategy. Repeats are found by
+// their fingerprint, so the strategy decides what counts as one. Alerts
+// without a provider key get the default fingerprint.
+func (s *Server) fingerprint(incident *models.Incident) string {
+	strategy := config.DedupStrategyFingerprint
+	if s.config != nil {
+		strategy = s.config.Deduplication.StrategyFor(incident.ServiceName)
+	}
+
+	switch strategy {
+	case config.DedupStrategyExactMessage:
+		incident.Fingerprint = models.ExactMessageFingerprint(incident.ErrorMessage)
+	case config.DedupStrategyProviderKey:
+		if key := incident.ProviderKey(); key != "" {
+			incident.Fingerprint = models.ProviderKeyFingerprint(incident.Provider, key)
+			break
+		}
+		fallthrough
+	default:
+		incident.Fingerprint = models.ComputeFingerprint(incident.ErrorMessage, incident.StackTrace)
+	}
+	return strategy
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestFingerprint_Strategies(t *testing.T) {
+	s := &Server{config: &config.Config{Deduplication: config.DeduplicationConfig{
+		Services: map[string]string{
+			"exact":    config.DedupStrategyExactMessage,
+			"keyed":    config.DedupStrategyProviderKey,
+			"disabled": config.DedupStrategyDisabled,
+		},
+	}}}
+	newIncident := func(service, message, issue string) *models.Incident {
+		return &models.Incident{ServiceName: service, ErrorMessage: message, Provider: "sentry", ProviderRef: issue}
+	}
+	sameFingerprint := func(a, b *models.Incident) bool {
+		s.fingerprint(a)
+		s.fingerprint(b)
+		return a.Fingerprint == b.Fingerprint
+	}
+
+	if !sameFingerprint(newIncident("checkout", "timeout after 3012ms", "1"), newIncident("checkout", "timeout after 2001ms", "2")) {
+		t.Error("fingerprint: expected messages differing in numbers to match")
+	}
+	if sameFingerprint(newIncident("exact", "timeout after 3012ms", "1"), newIncident("exact", "timeout after 2001ms", "1")) {
+		t.Error("exact_message: expected messages differing in numbers not to match")
+	}
+	if !sameFingerprint(newIncident("keyed", "timeout", "1"), newIncident("keyed", "connection refused", "1")) {
+		t.Error("provider_key: expected alerts of the same issue to match")
+	}
+	if sameFingerprint(newIncident("keyed", "timeout", "1"), newIncident("keyed", "timeout", "2")) {
+		t.Error("provider_key: expected alerts of different issues not to match")
+	}
+	if !sameFingerprint(newIncident("keyed", "timeout after 3012ms", ""), newIncident("checkout", "timeout after 2001ms", "")) {
+		t.Error("provider_key: expected alerts without a key to get the default fingerprint")
+	}
+
+	if strategy := s.fingerprint(newIncident("disabled", "timeout", "1")); strategy != config.DedupStrategyDisabled {
+		t.Errorf("expected the disabled strategy, got %s", strategy)
+	}
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/markdown"
+)
+
+// DiagnosisResponse is an incident's diagnosis as written by the remediation
+// agent and rendered to HTML that is safe to display as is
+type DiagnosisResponse struct {
+	IncidentID string `json:"incident_id"`
+	Markdown   string `json:"markdown"`
+	HTML       string `json:"html"`
+}
+
+// handleGetDiagnosis returns the diagnosis of an incident, both raw and
+// rendered. Raw HTML in the diagnosis is escaped, and links are kept only
+// for http, https and mailto URLs.
+func (s *Server) handleGetDiagnosis(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	incident, err := s.repository.WithContext(r.Context()).GetByID(id)
+	if err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+	if incident.Diagnosis == nil || *incident.Diagnosis == "" {
+		http.Error(w, "incident has no diagnosis", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(DiagnosisResponse{
+		IncidentID: incident.ID,
+		Markdown:   *incident.Diagnosis,
+		HTML:       markdown.Render(*incident.Diagnosis),
+	})
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/enrichment"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// SetEnricher gathers context from MCP servers with enricher before each
+// incident's first dispatch
+func (s *Server) SetEnricher(enricher *enrichment.Enricher) {
+	s.enricher = enricher
+}
+
+// enrich attaches a context bundle to an incident that has none yet, and
+// stores it on the incident record. When similarity search is enabled, the
+// bundle also lists similar past incidents. Queries that fail are noted in the
+// bundle; the dispatch goes ahead whatever the outcome.
+func (s *Server) enrich(ctx context.Context, inc *models.Incident, logger *Logger) {
+	if s.enricher == nil || inc.Enrichment != nil {
+		return
+	}
+
+	bundle := s.enricher.Enrich(ctx, inc)
+	for _, source := range bundle.Sources {
+		status := "success"
+		if source.Error != "" {
+			status = "error"
+		}
+		s.metrics.RecordEnrichmentQuery(source.Server, status)
+	}
+	if source, ok := s.similarSource(ctx, inc, logger); ok {
+		bundle.Sources = append(bundle.Sources, source)
+	}
+
+	encoded, err := bundle.Encode(s.config.Enrichment.BundleLimit())
+	if err != nil {
+		logger.Error("failed to encode context bundle", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+	inc.Enrichment = &encoded
+
+	repository := s.repository.WithContext(ctx)
+	if err := repository.SetEnrichment(inc.ID, encoded); err != nil {
+		logger.Error("failed to store context bundle", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	failed := bundle.Failed()
+	event := &models.IncidentEvent{
+		IncidentID: inc.ID,
+		EventType:  models.EventContextEnriched,
+		EventData: map[string]interface{}{
+			"sources": len(bundle.Sources),
+			"failed":  failed,
+			"bytes":   len(encoded),
+		},
+	}
+	if err := repository.LogEvent(event); err != nil {
+		logger.Error("failed to log context enriched event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	if len(failed) > 0 {
+		logger.Warn("some context queries failed", map[string]interface{}{
+			"failed": failed,
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// fanOutTargets returns the repositories to remediate when the incident's
+// service mapping spans several repositories, or nil when the incident is
+// remediated in its own repository only
+func (s *Server) fanOutTargets(inc *models.Incident) []config.RepositoryTarget {
+	if s.config == nil || inc.Repository == "" {
+		return nil
+	}
+	mapping := s.config.MappingFor(inc.ServiceName)
+	if mapping == nil || len(mapping.Repositories) == 0 {
+		return nil
+	}
+
+	targets := mapping.Targets()
+	for _, target := range targets {
+		if target.Repository == inc.Repository {
+			return targets
+		}
+	}
+	return nil
+}
+
+// handleListIncidentDispatches lists the per-repository dispatches of an
+// incident whose service spans several repositories
+func (s *Server) handleListIncidentDispatches(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	repository := s.repository.WithContext(r.Context())
+	incident, err := repository.GetByID(id)
+	if err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+
+	dispatches, err := repository.ListDispatches(id)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to list dispatches", map[string]interface{}{
+			"error": err.Error(),
+			"id":    id,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"status":     incident.Status,
+		"dispatches": dispatches,
+		"total":      len(dispatches),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// fanOut dispatches the remediation to each repository of the incident's
+// service and rolls the outcomes up on the incident. Every repository gets a
+// dispatch record. Repositories that already have a fix, or are still queued
+// or running, are not dispatched again, so a retry only re-runs the ones that
+// failed. When a queued repository gets a free slot, the incident comes back
+// with that repository set and only it is dispatched.
+func (s *Server) fanOut(ctx context.Context, inc *models.Incident, targets []config.RepositoryTarget, logger *Logger) error {
+	repository := s.repository.WithContext(ctx)
+
+	existing, err := repository.ListDispatches(inc.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list dispatches: %w", err)
+	}
+	records := make(map[string]*models.IncidentDispatch, len(existing))
+	for _, dispatch := range existing {
+		records[dispatch.Repository] = dispatch
+	}
+
+	if record := records[inc.Repository]; record != nil && record.Status == models.StatusPending {
+		targets = []config.RepositoryTarget{{Repository: record.Repository, Branch: record.Branch}}
+	} else {
+		pending := make([]config.RepositoryTarget, 0, len(targets))
+		for _, target := range targets {
+			if record := records[target.Repository]; record == nil || record.Status == models.StatusFailed {
+				pending = append(pending, target)
+			}
+		}
+		targets = pending
+	}
+
+	failed := 0
+	for _, target := range targets {
+		if !s.dispatchTarget(ctx, inc, target, logger) {
+			failed++
+		}
+	}
+
+	status, err := s.rollUpDispatches(ctx, inc.ID, logger)
+	if err != nil {
+		return err
+	}
+	if status == models.StatusFailed && failed > 0 {
+		return fmt.Errorf("failed to dispatch workflow to %d of %d repositories", failed, len(targets))
+	}
+	return nil
+}
+
+// dispatchTarget dispatches the incident's remediation to one repository and
+// saves the outcome in its dispatch record. It reports whether the workflow was
+// dispatched or queued.
+func (s *Server) dispatchTarget(ctx context.Context, inc *models.Incident, target config.RepositoryTarget, logger *Logger) bool {
+	logger = logger.With(map[string]interface{}{
+		"repository": target.Repository,
+	})
+
+	// The client queues the copy, so a freed slot dispatches this repository
+	child := *inc
+	child.Repository = target.Repository
+
+	record := &models.IncidentDispatch{
+		IncidentID: inc.ID,
+		Repository: target.Repository,
+		Branch:     target.Branch,
+		Status:     models.StatusPending,
+	}
+
+	now := time.Now()
+	err := s.dispatchWorkflow(ctx, &child, target.Branch)
+	switch {
+	case errors.Is(err, github.ErrIncidentQueued):
+		logger.Info("repository at concurrency limit, incident queued", nil)
+		event := &models.IncidentEvent{
+			IncidentID: inc.ID,
+			EventType:  models.EventQueuedForRemediation,
+			EventData: map[string]interface{}{
+				"repository": target.Repository,
+			},
+		}
+		if err := s.repository.WithContext(ctx).LogEvent(event); err != nil {
+			logger.Error("failed to log queue event", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	case err != nil:
+		logger.Error("failed to dispatch workflow", map[string]interface{}{
+			"error": err.Error(),
+		})
+		message := err.Error()
+		record.Status = models.StatusFailed
+		record.Error = &message
+		record.CompletedAt = &now
+	default:
+		record.Status = models.StatusWorkflowTriggered
+		record.TriggeredAt = &now
+	}
+
+	if err := s.repository.WithContext(ctx).SaveDispatch(record); err != nil {
+		logger.Error("failed to save dispatch", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return record.Status != models.StatusFailed
+}
+
+// completeDispatch records a workflow outcome reported for one repository of
+// an incident that was fanned out, and rolls the outcomes up on the incident.
+// It reports false when the incident has no dispatch record for the
+// repository, so the outcome applies to the incident directly.
+func (s *Server) completeDispatch(ctx context.Context, incident *models.Incident, payload *WorkflowStatusPayload, status models.IncidentStatus, logger *Logger) (bool, error) {
+	repository := s.repository.WithContext(ctx)
+
+	dispatches, err := repository.ListDispatches(incident.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list dispatches: %w", err)
+	}
+
+	var record *models.IncidentDispatch
+	for _, dispatch := range dispatches {
+		if dispatch.Repository == payload.Repository {
+			record = dispatch
+			break
+		}
+	}
+	if record == nil {
+		return false, nil
+	}
+
+	now := time.Now()
+	record.Status = status
+	record.CompletedAt = &now
+	if payload.PullRequestURL != "" {
+		record.PullRequestURL = &payload.PullRequestURL
+	}
+	if payload.Diagnosis != "" {
+		record.Diagnosis = &payload.Diagnosis
+	}
+	if err := repository.SaveDispatch(record); err != nil {
+		return true, err
+	}
+
+	eventType := models.EventPRCreated
+	if status == models.StatusFailed {
+		eventType = models.EventIncidentFailed
+	}
+	event := &models.IncidentEvent{
+		IncidentID: incident.ID,
+		EventType:  eventType,
+		EventData: map[string]interface{}{
+			"status":           payload.Status,
+			"repository":       payload.Repository,
+			"pull_request_url": payload.PullRequestURL,
+			"diagnosis":        payload.Diagnosis,
+		},
+	}
+	if err := repository.LogEvent(event); err != nil {
+		logger.Error("failed to log workflow completion event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	if _, err := s.rollUpDispatches(ctx, incident.ID, logger); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// rollUpDispatches sets the incident's status from the outcomes of its
+// dispatches. A finished incident gets the first pull request and the
+// diagnoses of all repositories. It returns the rolled-up status.
+func (s *Server) rollUpDispatches(ctx context.Context, incidentID string, logger *Logger) (models.IncidentStatus, error) {
+	repository := s.repository.WithContext(ctx)
+
+	incident, err := repository.GetByID(incidentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get incident: %w", err)
+	}
+	dispatches, err := repository.ListDispatches(incidentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list dispatches: %w", err)
+	}
+
+	status := models.DispatchStatus(dispatches)
+	if status == incident.Status {
+		return status, nil
+	}
+
+	now := time.Now()
+	incident.Status = status
+	switch status {
+	case models.StatusWorkflowTriggered, models.StatusInProgress:
+		if incident.TriggeredAt == nil {
+			incident.TriggeredAt = &now
+		}
+		incident.CompletedAt = nil
+	case models.StatusPRCreated, models.StatusFailed, models.StatusNoFixNeeded:
+		incident.CompletedAt = &now
+		incident.PullRequestURL = nil
+		var diagnoses []string
+		for _, dispatch := range dispatches {
+			if dispatch.PullRequestURL != nil && incident.PullRequestURL == nil {
+				incident.PullRequestURL = dispatch.PullRequestURL
+			}
+			if dispatch.Diagnosis != nil {
+				diagnoses = append(diagnoses, fmt.Sprintf("%s: %s", dispatch.Repository, *dispatch.Diagnosis))
+			}
+		}
+		if len(diagnoses) > 0 {
+			diagnosis := strings.Join(diagnoses, "\n\n")
+			incident.Diagnosis = &diagnosis
+		}
+	}
+
+	if err := repository.Update(incident); err != nil {
+		return "", fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	logger.Info("incident status rolled up from repository dispatches", map[string]interface{}{
+		"status":       status,
+		"repositories": len(dispatches),
+	})
+
+	switch status {
+	case models.StatusWorkflowTriggered:
+		s.notify(models.EventWorkflowTriggered, incident)
+	case models.StatusPRCreated:
+		s.notify(models.EventPRCreated, incident)
+	case models.StatusFailed:
+		s.notify(models.EventIncidentFailed, incident)
+	case models.StatusNoFixNeeded:
+		s.notify(models.EventStatusChanged, incident)
+	}
+
+	return status, nil
+}
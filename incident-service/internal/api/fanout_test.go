@@ -0,0 +1,112 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestDispatchIncident_FansOutToRepositories(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	var dispatched []string
+	githubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dispatched = append(dispatched, strings.Split(strings.TrimPrefix(r.URL.Path, "/repos/"), "/actions")[0])
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer githubServer.Close()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{Port: 8080},
+		ServiceMappings: []config.ServiceMapping{{
+			ServiceName:  "checkout",
+			Repository:   "org/checkout-api",
+			Branch:       "main",
+			Repositories: []config.RepositoryTarget{{Repository: "org/checkout-infra"}},
+		}},
+	}
+	githubClient := github.NewClient(githubServer.URL, "test-token", "test-workflow.yml", 2)
+	redis, _ := database.ConnectRedis("localhost:6379", "", 0)
+	server := NewServer(cfg, db, redis, githubClient, NewLogger())
+	repository := database.NewIncidentRepository(db)
+
+	incident := &models.Incident{
+		ID:           "test-incident-fanout",
+		ServiceName:  "checkout",
+		Repository:   "org/checkout-api",
+		ErrorMessage: "test error",
+		Status:       models.StatusPending,
+		Provider:     "test",
+		ProviderData: map[string]interface{}{},
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+	if err := repository.Create(incident); err != nil {
+		t.Fatalf("failed to create test incident: %v", err)
+	}
+	defer func() {
+		_, _ = db.Exec("DELETE FROM incidents WHERE id = $1", incident.ID)
+	}()
+
+	if err := server.DispatchIncident(context.Background(), incident); err != nil {
+		t.Fatalf("DispatchIncident() error = %v", err)
+	}
+	if strings.Join(dispatched, ",") != "org/checkout-api,org/checkout-infra" {
+		t.Errorf("expected a dispatch to each repository, got %v", dispatched)
+	}
+
+	stored, err := repository.GetByID(incident.ID)
+	if err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+	if stored.Status != models.StatusWorkflowTriggered {
+		t.Errorf("expected the incident to be triggered, got %s", stored.Status)
+	}
+
+	report := func(repo, status, prURL string) {
+		body, _ := json.Marshal(WorkflowStatusPayload{
+			IncidentID:     incident.ID,
+			Status:         status,
+			PullRequestURL: prURL,
+			Repository:     repo,
+		})
+		w := httptest.NewRecorder()
+		server.handleWorkflowStatus(w, httptest.NewRequest("POST", "/api/v1/webhooks/workflow-status", bytes.NewReader(body)))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	// The incident stays open until every repository has reported
+	report("org/checkout-infra", "success", "https://github.com/org/checkout-infra/pull/9")
+	if stored, _ = repository.GetByID(incident.ID); stored.Status != models.StatusWorkflowTriggered {
+		t.Errorf("expected the incident to wait for the other repository, got %s", stored.Status)
+	}
+
+	report("org/checkout-api", "failed", "")
+	stored, err = repository.GetByID(incident.ID)
+	if err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+	if stored.Status != models.StatusPRCreated || stored.PullRequestURL == nil || !strings.Contains(*stored.PullRequestURL, "checkout-infra") {
+		t.Errorf("expected the infra pull request on the incident, got %s with %v", stored.Status, stored.PullRequestURL)
+	}
+	if stored.CompletedAt == nil {
+		t.Error("expected completed_at to be set")
+	}
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// detailOnlyFields are the incident fields left out of incident lists unless
+// they are asked for with ?fields=, as they can be large. The detail endpoint
+// returns them.
+var detailOnlyFields = map[string]bool{
+	"provider_data": true,
+	"stack_trace":   true,
+	"enrichment":    true,
+}
+
+// incidentFields are the JSON names of every incident field, in field order
+var incidentFields = jsonFieldNames(reflect.TypeOf(models.Incident{}))
+
+// jsonFieldNames returns the JSON names of the fields of a struct type
+func jsonFieldNames(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// parseFields returns the incident fields a list response includes: the
+// comma-separated fields of the fields query parameter, with id always
+// included, or every field but the detail-only ones when it is empty
+func parseFields(param string) (map[string]bool, error) {
+	fields := make(map[string]bool)
+	if strings.TrimSpace(param) == "" {
+		for _, name := range incidentFields {
+			if !detailOnlyFields[name] {
+				fields[name] = true
+			}
+		}
+		return fields, nil
+	}
+
+	known := make(map[string]bool, len(incidentFields))
+	for _, name := range incidentFields {
+		known[name] = true
+	}
+	var unknown []string
+	for _, name := range strings.Split(param, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !known[name] {
+			unknown = append(unknown, name)
+			continue
+		}
+		fields[name] = true
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown fields: %s", strings.Join(unknown, ", "))
+	}
+	fields["id"] = true
+	return fields, nil
+}
+
+// projectIncidents returns the incidents with only the given fields. Fields
+// that are empty and omitted from a full incident are omitted here too.
+func projectIncidents(incidents []*models.Incident, fields map[string]bool) ([]map[string]json.RawMessage, error) {
+	projected := make([]map[string]json.RawMessage, 0, len(incidents))
+	for _, incident := range incidents {
+		data, err := json.Marshal(incident)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal incident: %w", err)
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(data, &all); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal incident: %w", err)
+		}
+		for name := range all {
+			if !fields[name] {
+				delete(all, name)
+			}
+		}
+		projected = append(projected, all)
+	}
+	return projected, nil
+}
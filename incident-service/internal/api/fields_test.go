@@ -0,0 +1,94 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestParseFields(t *testing.T) {
+	summary, err := parseFields("")
+	if err != nil {
+		t.Fatalf("parseFields() error = %v", err)
+	}
+	for name := range detailOnlyFields {
+		if summary[name] {
+			t.Errorf("expected %s left out of the summary", name)
+		}
+	}
+	for _, name := range []string{"id", "status", "severity", "service_name", "created_at", "summary"} {
+		if !summary[name] {
+			t.Errorf("expected %s in the summary", name)
+		}
+	}
+
+	fields, err := parseFields("status, stack_trace,,severity")
+	if err != nil {
+		t.Fatalf("parseFields() error = %v", err)
+	}
+	want := map[string]bool{"id": true, "status": true, "stack_trace": true, "severity": true}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("parseFields() = %v, want %v", fields, want)
+	}
+
+	if _, err := parseFields("status,password,secret"); err == nil || err.Error() != "unknown fields: password, secret" {
+		t.Errorf("expected the unknown fields reported, got %v", err)
+	}
+}
+
+func TestProjectIncidents(t *testing.T) {
+	stackTrace := "at main.go:1"
+	incidents := []*models.Incident{{
+		ID:           "inc_1",
+		ServiceName:  "checkout",
+		Status:       models.StatusPending,
+		StackTrace:   &stackTrace,
+		ProviderData: map[string]interface{}{"level": "error"},
+	}}
+
+	summary, _ := parseFields("")
+	projected, err := projectIncidents(incidents, summary)
+	if err != nil {
+		t.Fatalf("projectIncidents() error = %v", err)
+	}
+	for name := range detailOnlyFields {
+		if _, ok := projected[0][name]; ok {
+			t.Errorf("expected %s left out", name)
+		}
+	}
+	if string(projected[0]["service_name"]) != `"checkout"` {
+		t.Errorf("service_name = %s, want \"checkout\"", projected[0]["service_name"])
+	}
+
+	fields, _ := parseFields("status,stack_trace,pull_request_url")
+	projected, err = projectIncidents(incidents, fields)
+	if err != nil {
+		t.Fatalf("projectIncidents() error = %v", err)
+	}
+	var names []string
+	for name := range projected[0] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	// pull_request_url is empty, so it is omitted as from a full incident
+	if want := []string{"id", "stack_trace", "status"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("projected fields = %v, want %v", names, want)
+	}
+}
+
+func TestHandleListIncidents_UnknownFields(t *testing.T) {
+	// The request is rejected before the repository is used
+	server := &Server{logger: NewLogger()}
+
+	req := httptest.NewRequest("GET", "/api/v1/incidents?fields=status,secret", nil)
+	w := httptest.NewRecorder()
+	server.handleListIncidents(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
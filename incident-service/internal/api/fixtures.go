@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/fixtures"
+)
+
+// FixturesResponse lists fixtures made from captured webhook payloads
+type FixturesResponse struct {
+	Fixtures []fixtures.Fixture `json:"fixtures"`
+	Skipped  int                `json:"skipped"` // captured payloads that are not JSON
+}
+
+// handleListFixtures dumps the latest captured payload of each shape that
+// providers' webhooks have taken, anonymized, as fixtures for the adapters'
+// contract tests. Each fixture expects what the adapter now makes of its
+// payload, so it can be filed under internal/fixtures/testdata as it is.
+func (s *Server) handleListFixtures(w http.ResponseWriter, r *http.Request) {
+	if s.fixtures == nil {
+		http.Error(w, "fixture capture is not enabled", http.StatusNotFound)
+		return
+	}
+
+	provider := r.URL.Query().Get("provider")
+	if provider != "" {
+		if _, ok := s.adapters.Get(provider); !ok {
+			http.Error(w, "unsupported provider", http.StatusBadRequest)
+			return
+		}
+	}
+
+	anonymizer := fixtures.NewAnonymizer(s.config.Fixtures.Keep)
+	response := FixturesResponse{Fixtures: []fixtures.Fixture{}}
+	for _, capture := range s.fixtures.Captures(provider) {
+		adapter, ok := s.adapters.Get(capture.Provider)
+		if !ok {
+			continue
+		}
+		payload, err := anonymizer.Anonymize(capture.Body)
+		if err != nil {
+			response.Skipped++
+			continue
+		}
+
+		capturedAt := capture.LastSeen.UTC()
+		response.Fixtures = append(response.Fixtures, fixtures.Fixture{
+			Name: capture.Shape,
+			Description: fmt.Sprintf("Captured from %d webhooks between %s and %s",
+				capture.Count, capture.FirstSeen.UTC().Format(time.RFC3339), capturedAt.Format(time.RFC3339)),
+			Provider:   capture.Provider,
+			CapturedAt: &capturedAt,
+			Payload:    payload,
+			Expect:     fixtures.Expect(adapter, payload),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
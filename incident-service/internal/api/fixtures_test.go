@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/fixtures"
+)
+
+func TestHandleListFixtures_DumpsAnonymizedCaptures(t *testing.T) {
+	server := &Server{
+		config:   &config.Config{Fixtures: config.FixturesConfig{Capture: true, Keep: []string{"env"}}},
+		adapters: adapters.NewRegistry(),
+		fixtures: fixtures.NewRecorder(10),
+	}
+	now := time.Now()
+	server.fixtures.Record("pagerduty", []byte(`{"event": {"event_type": "incident.triggered", "data": {"id": "Q1SECRET", "title": "Checkout down for bob@example.com", "urgency": "high", "service": {"summary": "checkout"}}}, "env": "production"}`), now)
+	server.fixtures.Record("pagerduty", []byte(`not json`), now)
+	server.fixtures.Record("datadog", []byte(`{"id": "1", "title": "CPU high"}`), now)
+
+	req := httptest.NewRequest("GET", "/api/v1/fixtures?provider=pagerduty", nil)
+	w := httptest.NewRecorder()
+	server.handleListFixtures(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	for _, secret := range []string{"Q1SECRET", "bob", "example.com", "checkout"} {
+		if strings.Contains(w.Body.String(), secret) {
+			t.Errorf("expected %q to be anonymized, got %s", secret, w.Body.String())
+		}
+	}
+
+	var response FixturesResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Fixtures) != 1 || response.Skipped != 1 {
+		t.Fatalf("expected 1 fixture and 1 skipped payload, got %+v", response)
+	}
+	fixture := response.Fixtures[0]
+	if fixture.Provider != "pagerduty" || fixture.Expect.Severity != "critical" || fixture.Expect.Error != "" {
+		t.Errorf("expected the fixture to expect a critical incident, got %+v", fixture)
+	}
+	if !strings.Contains(string(fixture.Payload), `"env":"production"`) {
+		t.Errorf("expected the configured field to be kept, got %s", fixture.Payload)
+	}
+
+	// The dumped fixture passes the contract it sets
+	adapter, _ := server.adapters.Get("pagerduty")
+	if err := fixture.Check(adapter); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+}
+
+func TestHandleListFixtures_CaptureDisabled(t *testing.T) {
+	server := &Server{config: &config.Config{}, adapters: adapters.NewRegistry()}
+
+	req := httptest.NewRequest("GET", "/api/v1/fixtures", nil)
+	w := httptest.NewRecorder()
+	server.handleListFixtures(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/freeze"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// Freezes returns the deploy freeze calendar, or nil when freezes are disabled
+func (s *Server) Freezes() *freeze.Calendar {
+	return s.freezes
+}
+
+// activeFreeze returns the deploy freeze the repository is in, if any
+func (s *Server) activeFreeze(repository string) (freeze.Window, bool) {
+	if s.freezes == nil {
+		return freeze.Window{}, false
+	}
+	return s.freezes.Active(repository, time.Now())
+}
+
+// noteFreeze records on the incident that its remediation was dispatched
+// during a deploy freeze. The workflow still opens its pull request, but
+// withholds auto-merge and rollout until the freeze ends.
+func (s *Server) noteFreeze(ctx context.Context, inc *models.Incident, window freeze.Window) {
+	logger := s.loggerFrom(ctx).With(map[string]interface{}{
+		"incident_id": inc.ID,
+		"repository":  inc.Repository,
+	})
+
+	event := &models.IncidentEvent{
+		IncidentID: inc.ID,
+		EventType:  models.EventDispatchedInFreeze,
+		EventData: map[string]interface{}{
+			"repository":   inc.Repository,
+			"freeze":       window.Name,
+			"freeze_until": window.End,
+			"note":         "auto-merge and rollout are withheld until the freeze ends",
+		},
+	}
+	if err := s.repository.WithContext(ctx).LogEvent(event); err != nil {
+		logger.Error("failed to log freeze event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	logger.Info("remediation dispatched during deploy freeze, auto-merge and rollout withheld", map[string]interface{}{
+		"freeze":       window.Name,
+		"freeze_until": window.End,
+	})
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestDispatchIncident_TagsFreeze(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	var inputs []map[string]interface{}
+	githubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Inputs map[string]interface{} `json:"inputs"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		inputs = append(inputs, request.Inputs)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer githubServer.Close()
+
+	now := time.Now()
+	cfg := &config.Config{
+		Server: config.ServerConfig{Port: 8080},
+		ServiceMappings: []config.ServiceMapping{{
+			ServiceName: "checkout",
+			Repository:  "org/checkout-api",
+			Branch:      "main",
+		}},
+		Freezes: config.FreezeConfig{
+			Enabled: true,
+			Calendars: []config.FreezeCalendar{{
+				Repository: "org/checkout-api",
+				Windows:    []config.FreezeWindow{{Name: "holidays", Start: now.Add(-time.Hour), End: now.Add(time.Hour)}},
+			}},
+		},
+	}
+	githubClient := github.NewClient(githubServer.URL, "test-token", "test-workflow.yml", 2)
+	redis, _ := database.ConnectRedis("localhost:6379", "", 0)
+	server := NewServer(cfg, db, redis, githubClient, NewLogger())
+	repository := database.NewIncidentRepository(db)
+
+	incident := &models.Incident{
+		ID:           "test-incident-freeze",
+		ServiceName:  "checkout",
+		Repository:   "org/checkout-api",
+		ErrorMessage: "test error",
+		Status:       models.StatusPending,
+		Provider:     "test",
+		ProviderData: map[string]interface{}{},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := repository.Create(incident); err != nil {
+		t.Fatalf("failed to create test incident: %v", err)
+	}
+	defer func() {
+		_, _ = db.Exec("DELETE FROM incidents WHERE id = $1", incident.ID)
+	}()
+
+	if err := server.DispatchIncident(context.Background(), incident); err != nil {
+		t.Fatalf("DispatchIncident() error = %v", err)
+	}
+	if len(inputs) != 1 || inputs[0]["freeze"] != "true" {
+		t.Fatalf("expected one dispatch with freeze=true, got %v", inputs)
+	}
+
+	events, err := repository.GetEventsByIncidentID(incident.ID)
+	if err != nil {
+		t.Fatalf("failed to get events: %v", err)
+	}
+	noted := false
+	for _, event := range events {
+		if event.EventType == models.EventDispatchedInFreeze && event.EventData["freeze"] == "holidays" {
+			noted = true
+		}
+	}
+	if !noted {
+		t.Errorf("expected a %s event, got %+v", models.EventDispatchedInFreeze, events)
+	}
+}
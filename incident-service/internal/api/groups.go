@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// Group reasons recorded on incident_grouped events
+const (
+	groupReasonFingerprint = "fingerprint"
+	groupReasonStorm       = "storm"
+)
+
+// findGroupParent sets the incident's parent to the earliest recent incident
+// from another service of its tenant with the same fingerprint, when grouping
+// is enabled.
+// It runs before the incident is stored.
+func (s *Server) findGroupParent(ctx context.Context, incident *models.Incident, logger *Logger) {
+	if s.config == nil || !s.config.Grouping.Enabled || incident.Fingerprint == "" {
+		return
+	}
+
+	parentID, err := s.repository.WithContext(ctx).FindGroupParent(incident.TenantID, incident.ServiceName, incident.Fingerprint, s.config.Grouping.WindowSize())
+	if err != nil {
+		logger.Error("failed to find group parent", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+	if parentID != "" {
+		incident.ParentID = &parentID
+	}
+}
+
+// logGrouped records that a stored incident was grouped under its parent
+func (s *Server) logGrouped(ctx context.Context, incident *models.Incident, reason string, logger *Logger) {
+	if incident.ParentID == nil {
+		return
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: incident.ID,
+		EventType:  models.EventIncidentGrouped,
+		EventData: map[string]interface{}{
+			"parent_id": *incident.ParentID,
+			"reason":    reason,
+		},
+	}
+	if err := s.repository.WithContext(ctx).LogEvent(event); err != nil {
+		logger.Error("failed to log incident grouped event", map[string]interface{}{
+			"error":     err.Error(),
+			"parent_id": *incident.ParentID,
+		})
+	}
+
+	logger.Info("incident grouped under parent incident", map[string]interface{}{
+		"parent_id": *incident.ParentID,
+		"reason":    reason,
+	})
+}
+
+// handleGetIncidentGroup returns the group an incident belongs to: its parent,
+// the parent's children, and the rolled-up status of the group. A child is
+// resolved to its parent, so every member of a group returns the same view.
+func (s *Server) handleGetIncidentGroup(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	logger := s.loggerFrom(r.Context())
+	repository := s.repository.WithContext(r.Context())
+
+	parent, err := repository.GetByID(id)
+	if err != nil {
+		logger.Error("failed to get incident", map[string]interface{}{
+			"error": err.Error(),
+			"id":    id,
+		})
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+
+	// A child whose parent is gone, or belongs to a team the principal cannot
+	// see, is treated as the root of its own group
+	p := principalFrom(r.Context())
+	if parent.ParentID != nil {
+		if root, err := repository.GetByID(*parent.ParentID); err == nil && p.canAccess(root) {
+			parent = root
+		}
+	}
+
+	filter := &database.IncidentFilter{ParentID: &parent.ID}
+	p.scopeIncidents(filter)
+	children, err := repository.ListWithFilter(filter)
+	if err != nil {
+		logger.Error("failed to list group children", map[string]interface{}{
+			"error":     err.Error(),
+			"parent_id": parent.ID,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	members := append([]*models.Incident{parent}, children...)
+	response := map[string]interface{}{
+		"parent":   parent,
+		"children": children,
+		"status":   models.GroupStatus(members),
+		"size":     len(members),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -4,17 +4,37 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/blob"
 	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
 	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/enrichment"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/fixtures"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/freeze"
 	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/ids"
 	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/notifications"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/postmortem"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/severity"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/similarity"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/storm"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/summary"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/validation"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Server represents the HTTP server
@@ -25,13 +45,45 @@ type Server struct {
 	repository   *database.IncidentRepository
 	adapters     *adapters.Registry
 	githubClient *github.Client
+	notifier     *notifications.Dispatcher
 	logger       *Logger
 	metrics      *Metrics
+	ids          *ids.Generator // IDs of new incidents
 	router       *chi.Mux
+	ingestion    *ingestionPool
+	buffer       database.WebhookBuffer // webhooks kept during database outages
+	replay       *replayGuard
+	sources      *sourceFilter
+	proxies      []netip.Prefix // trusted to report the sender in X-Forwarded-For
+	storms       *storm.Detector
+	bursts       *models.BurstGate // holds back non-urgent remediations during dispatch bursts
+	freezes      *freeze.Calendar // deploy freezes of repositories
+	attachments  blob.Store // where files attached to incidents are kept
+	confluence   *postmortem.Confluence // publishes postmortem drafts, when configured
+	fixtures     *fixtures.Recorder // captures webhook payloads for contract test fixtures
+	dropped      unknownFields // payload fields adapters drop, per provider
+	quotas       quotaCounts // provider quota counts when Redis is unavailable
+	remediations remediationCounts // remediation cap counts when Redis is unavailable
+	enricher     *enrichment.Enricher
+	summarizer   *summary.Summarizer
+	embedder     *similarity.Embedder
+	classifier   *severity.Classifier // suggests severities the provider left out
+	rules        models.RuleEvaluator // custom rules applied to new incidents
+	mcpHealth    mcpHealth // latest MCP server health check
+	mappings     mappingHealth // latest check of the service mappings against GitHub
+	slowDrip     slowDrip // dispatch mode while GitHub keeps failing
+	live         liveTails // websockets following incidents
+	background   sync.WaitGroup
+	startedAt    time.Time
 }
 
 // NewServer creates a new HTTP server
-func NewServer(cfg *config.Config, db *database.DB, redis *database.RedisClient, githubClient *github.Client) *Server {
+func NewServer(cfg *config.Config, db *database.DB, redis *database.RedisClient, githubClient *github.Client, logger *Logger) *Server {
+	return newServer(cfg, db, redis, githubClient, logger, NewMetrics())
+}
+
+// newServer creates a server reporting to metrics
+func newServer(cfg *config.Config, db *database.DB, redis *database.RedisClient, githubClient *github.Client, logger *Logger, metrics *Metrics) *Server {
 	s := &Server{
 		config:       cfg,
 		db:           db,
@@ -39,9 +91,49 @@ func NewServer(cfg *config.Config, db *database.DB, redis *database.RedisClient,
 		repository:   database.NewIncidentRepository(db),
 		adapters:     adapters.NewRegistry(),
 		githubClient: githubClient,
-		logger:       NewLogger(),
-		metrics:      NewMetrics(),
+		notifier:     notifications.NewDispatcher(cfg.Notifications),
+		logger:       logger,
+		metrics:      metrics,
+		ids:          ids.New(cfg.IncidentIDs.StrategyName()),
 		router:       chi.NewRouter(),
+		startedAt:    time.Now(),
+	}
+
+	logger.setTap(s.live.publish)
+	s.notifier.SetStore(s.repository)
+	s.notifier.SetTeams(cfg.Teams)
+	s.notifier.SetMetrics(metrics)
+	s.notifier.SetWatchers(s.repository)
+	s.ingestion = newIngestionPool(cfg.Ingestion, s.processWebhook, ingestionGauges{
+		depth:      s.metrics.IncidentQueueDepth,
+		busy:       s.metrics.IngestionWorkersBusy,
+		saturation: s.metrics.IngestionSaturation,
+	})
+	s.sources = newSourceFilter(cfg.Webhooks)
+	if err := s.adapters.AcceptEvents(cfg.Webhooks.AcceptedEvents); err != nil {
+		logger.Error("invalid webhooks.accepted_events, keeping the default events", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	s.proxies = parseRanges(cfg.Webhooks.TrustedProxies)
+	if cfg.Webhooks.ReplayProtection.Enabled {
+		s.replay = newReplayGuard(cfg.Webhooks.ReplayProtection, redisNonceStore{client: redis})
+	}
+	if cfg.Storms.Enabled {
+		s.storms = storm.NewDetector(cfg.Storms)
+		s.storms.SetIDGenerator(s.ids.IncidentID)
+	}
+	if cfg.Concurrency.BurstThreshold > 0 {
+		s.bursts = models.NewBurstGate(cfg.Concurrency.BurstThreshold, cfg.Concurrency.BurstWindowSize())
+	}
+	if cfg.Freezes.Enabled {
+		s.freezes = freeze.NewCalendar(cfg.Freezes)
+	}
+	if cfg.Fixtures.Capture {
+		s.fixtures = fixtures.NewRecorder(cfg.Fixtures.ShapeLimit())
+	}
+	if cfg.Postmortems.Confluence.URL != "" {
+		s.confluence = postmortem.NewConfluence(cfg.Postmortems.Confluence)
 	}
 
 	s.setupRoutes()
@@ -50,8 +142,14 @@ func NewServer(cfg *config.Config, db *database.DB, redis *database.RedisClient,
 
 // setupRoutes configures the HTTP routes
 func (s *Server) setupRoutes() {
+	// Trace every request, tag its log lines with a request ID, and contain handler panics
+	s.router.Use(tracingMiddleware)
+	s.router.Use(requestLoggerMiddleware(s.logger))
+	s.router.Use(s.recoveryMiddleware)
+
 	// Health check endpoint
 	s.router.Get("/api/v1/health", s.handleHealth)
+	s.router.Get("/readyz", s.handleReadyz)
 
 	// Metrics endpoint
 	s.router.Handle("/api/v1/metrics", promhttp.Handler())
@@ -59,60 +157,213 @@ func (s *Server) setupRoutes() {
 	// Webhook endpoint
 	s.router.Post("/api/v1/webhooks/incidents", s.handleWebhook)
 
-	// Incident endpoints (to be implemented in later tasks)
-	s.router.Get("/api/v1/incidents", s.handleListIncidents)
-	s.router.Get("/api/v1/incidents/{id}", s.handleGetIncident)
+	// Management API, behind API keys when any are configured
+	s.router.Group(func(r chi.Router) {
+		r.Use(s.requireAPIKey)
+
+		// Incident endpoints, limited to the incidents of the key's tenant and teams
+		r.Get("/api/v1/incidents", s.handleListIncidents)
+		r.Group(func(r chi.Router) {
+			r.Use(s.requireIncidentAccess)
+
+			r.Get("/api/v1/incidents/{id}", s.handleGetIncident)
+			r.Patch("/api/v1/incidents/{id}", s.handlePatchIncident)
+			r.Get("/api/v1/incidents/{id}/notifications", s.handleListIncidentNotifications)
+			r.Get("/api/v1/incidents/{id}/group", s.handleGetIncidentGroup)
+			r.Get("/api/v1/incidents/{id}/events", s.handleListIncidentEvents)
+			r.Post("/api/v1/incidents/{id}/live/token", s.handleCreateLiveToken)
+			r.Get("/api/v1/incidents/{id}/dispatches", s.handleListIncidentDispatches)
+			r.Get("/api/v1/incidents/{id}/workflow-logs", s.handleListWorkflowLogs)
+			r.Get("/api/v1/incidents/{id}/diagnosis", s.handleGetDiagnosis)
+			r.Get("/api/v1/incidents/{id}/related", s.handleListRelatedIncidents)
+			r.Post("/api/v1/incidents/{id}/related", s.handleLinkIncident)
+			r.Delete("/api/v1/incidents/{id}/related/{relatedID}", s.handleUnlinkIncident)
+			r.Post("/api/v1/incidents/{id}/merge", s.handleMergeIncident)
+			r.Post("/api/v1/incidents/{id}/retry", s.handleRetryIncident)
+			r.Post("/api/v1/incidents/{id}/snooze", s.handleSnoozeIncident)
+			r.Delete("/api/v1/incidents/{id}/snooze", s.handleUnsnoozeIncident)
+			r.Get("/api/v1/incidents/{id}/watchers", s.handleListIncidentWatchers)
+			r.Post("/api/v1/incidents/{id}/watch", s.handleWatchIncident)
+			r.Delete("/api/v1/incidents/{id}/watch", s.handleUnwatchIncident)
+			r.Get("/api/v1/incidents/{id}/attachments", s.handleListIncidentAttachments)
+			r.Post("/api/v1/incidents/{id}/attachments", s.handleUploadAttachment)
+			r.Get("/api/v1/incidents/{id}/attachments/{attachmentID}", s.handleDownloadAttachment)
+			r.Delete("/api/v1/incidents/{id}/attachments/{attachmentID}", s.handleDeleteAttachment)
+			r.Post("/api/v1/incidents/{id}/postmortem", s.handleCreatePostmortem)
+		})
+
+		// Changes made through the management API, across every team
+		r.With(requireUnscoped).Get("/api/v1/audit", s.handleListAudit)
+
+		// Workflow concurrency and dispatch queues
+		r.With(requireAllTenants).Get("/api/v1/queue", s.handleGetQueue)
+		r.With(requireAllTenants, requireAdmin).Post("/api/v1/queue/{owner}/{repo}/reset", s.handleResetQueue)
+
+		// Configuration endpoint
+		r.With(requireAllTenants).Get("/api/v1/config", s.handleGetConfig)
+
+		// Anonymized fixtures from captured webhook payloads
+		r.With(requireAllTenants).Get("/api/v1/fixtures", s.handleListFixtures)
+
+		// Payload fields the adapters drop, per provider
+		r.With(requireAllTenants).Get("/api/v1/providers/unknown-fields", s.handleListUnknownFields)
+
+		// Live health of the configured MCP servers
+		r.With(requireAllTenants).Get("/api/v1/mcp-servers", s.handleListMCPServers)
+
+		// Remediation SLO compliance
+		r.With(requireAllTenants).Get("/api/v1/slos", s.handleListSLOs)
+
+		// Incident statistics, served from rollups
+		r.Get("/api/v1/statistics", s.handleGetStatistics)
+		r.Get("/api/v1/statistics/timeseries", s.handleGetStatisticsTimeSeries)
+		r.Get("/api/v1/statistics/teams", s.handleGetTeamStatistics)
+		r.Get("/api/v1/statistics/tenants", s.handleGetTenantStatistics)
+		r.Get("/api/v1/statistics/heatmap", s.handleGetStatisticsHeatmap)
+
+		// Incident activity per mapped service
+		r.Get("/api/v1/services", s.handleListServices)
+
+		// Services whose incidents wait for a repository mapping
+		r.With(requireAllTenants).Get("/api/v1/services/unmapped", s.handleListUnmappedServices)
+		r.With(requireAllTenants).Get("/api/v1/services/mapping-checks", s.handleCheckServiceMappings)
+		r.With(requireAllTenants, requireAdmin).Post("/api/v1/services/{name}/mapping", s.handleMapService)
+	})
 
 	// Workflow status webhook endpoint
 	s.router.Post("/api/v1/webhooks/workflow-status", s.handleWorkflowStatus)
 
-	// Configuration endpoint
-	s.router.Get("/api/v1/config", s.handleGetConfig)
+	// Full incident context for remediation workflows, signed at dispatch
+	s.router.Get("/api/v1/incidents/{id}/context", s.handleGetIncidentContext)
+
+	// Steps reported by a running remediation workflow, with the token of its
+	// context URL or an API key
+	s.router.With(s.requireWorkflowToken).Post("/api/v1/incidents/{id}/events", s.handleLogWorkflowSteps)
+
+	// Live tail of an incident, with a token from the live token endpoint or
+	// an API key
+	s.router.With(s.requireLiveToken).Get("/api/v1/incidents/{id}/live", s.handleLiveIncident)
+
+	// Profiling and runtime diagnostics, only when explicitly enabled
+	if s.config.Server.Debug.Enabled {
+		s.mountDebugRoutes()
+	}
+
+	// Dashboard, for installs without a separate frontend deployment
+	if s.config.Server.Dashboard.Enabled {
+		s.mountDashboard()
+	}
 }
 
-// handleHealth handles health check requests
+// handleHealth handles health check requests. With slow-drip dispatching
+// enabled, it reports the dispatch mode. With deep=true it also reports
+// the latency of each dependency, including GitHub, when the configuration
+// was loaded and when each background worker last ran.
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
+	logger := s.loggerFrom(ctx)
 
 	health := map[string]interface{}{
 		"status":    "healthy",
 		"timestamp": time.Now().UTC().Format(time.RFC3339),
 	}
+	dependencies := make(map[string]DependencyHealth)
 
 	// Check database health
-	if err := s.db.Health(); err != nil {
-		s.logger.Error("database health check failed", map[string]interface{}{
-			"error": err.Error(),
-		})
-		health["status"] = "unhealthy"
-		health["database"] = "unhealthy"
-		w.WriteHeader(http.StatusServiceUnavailable)
-	} else {
-		health["database"] = "healthy"
+	if s.db != nil {
+		dependencies["database"] = checkDependency(s.db.Health)
+		if err := dependencies["database"].Error; err != "" {
+			logger.Error("database health check failed", map[string]interface{}{
+				"error": err,
+			})
+			health["status"] = "unhealthy"
+		}
+		health["database"] = dependencies["database"].Status
 	}
 
 	// Check Redis health
-	if err := s.redis.Health(ctx); err != nil {
-		s.logger.Error("redis health check failed", map[string]interface{}{
-			"error": err.Error(),
-		})
-		health["status"] = "unhealthy"
-		health["redis"] = "unhealthy"
-		w.WriteHeader(http.StatusServiceUnavailable)
-	} else {
-		health["redis"] = "healthy"
+	if s.redis != nil {
+		dependencies["redis"] = checkDependency(func() error { return s.redis.Health(ctx) })
+		if err := dependencies["redis"].Error; err != "" {
+			logger.Error("redis health check failed", map[string]interface{}{
+				"error": err,
+			})
+			health["status"] = "unhealthy"
+		}
+		health["redis"] = dependencies["redis"].Status
+	}
+
+	// Dispatching in slow-drip mode is degraded, but the service stays
+	// healthy: incidents still get remediated, only later
+	if s.slowDripEnabled() {
+		health["dispatch_mode"] = "normal"
+		if status, ok := s.slowDrip.status(time.Now()); ok {
+			health["dispatch_mode"] = "slow_drip"
+			health["slow_drip"] = status
+		}
+	}
+
+	if r.URL.Query().Get("deep") == "true" {
+		s.deepHealth(ctx, health, dependencies)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	if health["status"] != "healthy" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
 	_ = json.NewEncoder(w).Encode(health)
 }
 
-// handleListIncidents handles listing incidents (placeholder)
+// handleListIncidents handles listing incidents (placeholder). The parent_id
+// query parameter lists the children of a group, and top_level=true leaves out
+// incidents grouped under a parent. team, service_name, status and tenant
+// narrow the list further. Restricted API keys only list the incidents of
+// their tenant and teams. fields selects the incident fields returned; by
+// default the large ones are left out, see detailOnlyFields.
 func (s *Server) handleListIncidents(w http.ResponseWriter, r *http.Request) {
-	incidents, err := s.repository.List()
+	filter := &database.IncidentFilter{}
+	query := r.URL.Query()
+	fields, err := parseFields(query.Get("fields"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if parentID := query.Get("parent_id"); parentID != "" {
+		filter.ParentID = &parentID
+	} else if query.Get("top_level") == "true" {
+		filter.TopLevel = true
+	}
+	if team := query.Get("team"); team != "" {
+		filter.Team = &team
+	}
+	if serviceName := query.Get("service_name"); serviceName != "" {
+		filter.ServiceName = &serviceName
+	}
+	if status := query.Get("status"); status != "" {
+		incidentStatus := models.IncidentStatus(status)
+		filter.Status = &incidentStatus
+	}
+	if tenant := query.Get("tenant"); tenant != "" {
+		filter.TenantID = &tenant
+	}
+	if !principalFrom(r.Context()).scopeIncidents(filter) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	incidents, err := s.repository.WithContext(r.Context()).ListWithFilter(filter)
 	if err != nil {
-		s.logger.Error("failed to list incidents", map[string]interface{}{
+		s.loggerFrom(r.Context()).Error("failed to list incidents", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	projected, err := projectIncidents(incidents, fields)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to project incidents", map[string]interface{}{
 			"error": err.Error(),
 		})
 		http.Error(w, "internal server error", http.StatusInternalServerError)
@@ -121,7 +372,7 @@ func (s *Server) handleListIncidents(w http.ResponseWriter, r *http.Request) {
 
 	// Return response in the format expected by the dashboard
 	response := map[string]interface{}{
-		"incidents": incidents,
+		"incidents": projected,
 		"total":     len(incidents),
 	}
 
@@ -133,9 +384,9 @@ func (s *Server) handleListIncidents(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleGetIncident(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	incident, err := s.repository.GetByID(id)
+	incident, err := s.repository.WithContext(r.Context()).GetByID(id)
 	if err != nil {
-		s.logger.Error("failed to get incident", map[string]interface{}{
+		s.loggerFrom(r.Context()).Error("failed to get incident", map[string]interface{}{
 			"error": err.Error(),
 			"id":    id,
 		})
@@ -143,8 +394,68 @@ func (s *Server) handleGetIncident(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	response := s.newIncidentResponse(incident)
+	related, err := s.repository.WithContext(r.Context()).ListRelatedIncidents(id)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to list related incidents", map[string]interface{}{
+			"error": err.Error(),
+			"id":    id,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	response.Related = principalFrom(r.Context()).visibleRelated(related)
+	response.DispatchAttempts, err = s.repository.WithContext(r.Context()).ListDispatchAttempts(id)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to list dispatch attempts", map[string]interface{}{
+			"error": err.Error(),
+			"id":    id,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	response.CorrelatedAlerts, err = s.repository.WithContext(r.Context()).ListCorrelatedAlerts(id)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to list correlated alerts", map[string]interface{}{
+			"error": err.Error(),
+			"id":    id,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if s.embedder != nil {
+		response.Similar = s.similarIncidents(r.Context(), incident, principalFrom(r.Context()), s.loggerFrom(r.Context()))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// handleListIncidentEvents returns the timeline of an incident, oldest event first
+func (s *Server) handleListIncidentEvents(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	repository := s.repository.WithContext(r.Context())
+	if _, err := repository.GetByID(id); err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+
+	events, err := repository.GetEventsByIncidentID(id)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to list incident events", map[string]interface{}{
+			"error": err.Error(),
+			"id":    id,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if events == nil {
+		events = []*models.IncidentEvent{}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(incident)
+	_ = json.NewEncoder(w).Encode(events)
 }
 
 // Router returns the HTTP router
@@ -157,39 +468,70 @@ func (s *Server) Logger() *Logger {
 	return s.logger
 }
 
+// loggerFrom returns the request-scoped logger in ctx, falling back to the server logger
+func (s *Server) loggerFrom(ctx context.Context) *Logger {
+	return LoggerFromContext(ctx, s.logger)
+}
+
+// Metrics returns the Prometheus metrics
+func (s *Server) Metrics() *Metrics {
+	return s.metrics
+}
+
+// Notifier returns the notification dispatcher
+func (s *Server) Notifier() *notifications.Dispatcher {
+	return s.notifier
+}
+
 // handleWebhook handles incoming webhook requests from observability platforms
 func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
+	ctx := r.Context()
+	span := trace.SpanFromContext(ctx)
 
 	// Get provider from query parameter
 	provider := r.URL.Query().Get("provider")
+	span.SetAttributes(attribute.String("incident.provider", provider))
+	logger := s.loggerFrom(ctx).With(map[string]interface{}{
+		"provider": provider,
+	})
 	if provider == "" {
-		s.logger.Error("missing provider parameter", nil)
+		logger.Error("missing provider parameter", nil)
 		http.Error(w, "missing provider parameter", http.StatusBadRequest)
-		s.metrics.IncidentReceived.WithLabelValues(provider, "error").Inc()
+		s.metrics.RecordIncident(provider, "error")
 		return
 	}
 
 	// Get adapter for provider
 	adapter, ok := s.adapters.Get(provider)
 	if !ok {
-		s.logger.Error("unsupported provider", map[string]interface{}{
-			"provider": provider,
-		})
+		logger.Error("unsupported provider", nil)
 		http.Error(w, "unsupported provider", http.StatusBadRequest)
-		s.metrics.IncidentReceived.WithLabelValues(provider, "error").Inc()
+		s.metrics.RecordIncident(provider, "error")
 		return
 	}
 
+	// Only the provider's own address ranges may send its webhooks
+	if s.sources != nil {
+		if source, ok := s.sources.allows(provider, r); !ok {
+			logger.Error("webhook source not allowed", map[string]interface{}{
+				"source_ip": source,
+			})
+			http.Error(w, "forbidden", http.StatusForbidden)
+			s.metrics.RecordIncident(provider, "forbidden")
+			s.metrics.RecordWebhookFailure(provider, adapters.ReasonSource)
+			return
+		}
+	}
+
 	// Read body
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		s.logger.Error("failed to read request body", map[string]interface{}{
-			"error":    err.Error(),
-			"provider": provider,
+		logger.Error("failed to read request body", map[string]interface{}{
+			"error": err.Error(),
 		})
 		http.Error(w, "failed to read request body", http.StatusBadRequest)
-		s.metrics.IncidentReceived.WithLabelValues(provider, "error").Inc()
+		s.metrics.RecordIncident(provider, "error")
 		return
 	}
 
@@ -197,59 +539,116 @@ func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
 	r.Body = io.NopCloser(bytes.NewBuffer(body))
 
 	// Validate webhook
-	if err := adapter.Validate(r); err != nil {
-		s.logger.Error("webhook validation failed", map[string]interface{}{
-			"error":    err.Error(),
-			"provider": provider,
+	_, validateSpan := tracing.Start(ctx, "adapter.Validate")
+	err = adapter.Validate(r)
+	tracing.End(validateSpan, err)
+	if err != nil {
+		logger.Error("webhook validation failed", map[string]interface{}{
+			"error": err.Error(),
 		})
 		http.Error(w, "validation failed", http.StatusUnauthorized)
-		s.metrics.IncidentReceived.WithLabelValues(provider, "validation_failed").Inc()
+		s.metrics.RecordIncident(provider, "validation_failed")
+		s.metrics.RecordWebhookFailure(provider, adapters.FailureReason(err))
 		return
 	}
 
-	// Parse incident
+	// Reject stale and repeated deliveries of providers that timestamp them
+	var nonce string
+	if s.replay != nil {
+		nonce, err = s.replay.check(ctx, provider, adapter, r, body)
+		if err != nil && adapters.FailureReason(err) == adapters.ReasonOther {
+			logger.Error("failed to check webhook for replay", map[string]interface{}{
+				"error": err.Error(),
+			})
+			http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+			s.metrics.RecordIncident(provider, "rejected")
+			return
+		}
+		if err != nil {
+			logger.Error("webhook delivery rejected", map[string]interface{}{
+				"error": err.Error(),
+			})
+			http.Error(w, "validation failed", http.StatusUnauthorized)
+			s.metrics.RecordIncident(provider, "validation_failed")
+			s.metrics.RecordWebhookFailure(provider, adapters.FailureReason(err))
+			return
+		}
+	}
+
+	// Payloads are captured before parsing, so that fixtures include the
+	// schema versions that fail to parse
+	if s.fixtures != nil {
+		s.fixtures.Record(provider, body, startTime)
+	}
+
+	// Incidents with invalid fields are turned away here, so the provider
+	// learns which fields are at fault. Payloads that do not parse are left to
+	// the ingestion pool, which records why.
+	_, parseSpan := tracing.Start(ctx, "adapter.Parse")
 	incident, err := adapter.Parse(body)
+	tracing.End(parseSpan, err)
 	if err != nil {
-		s.logger.Error("failed to parse webhook payload", map[string]interface{}{
-			"error":    err.Error(),
-			"provider": provider,
+		incident = nil
+	} else if err := validation.Incident(incident); err != nil {
+		var invalid *validation.Error
+		errors.As(err, &invalid)
+		logger.Error("webhook payload has invalid fields", map[string]interface{}{
+			"error": err.Error(),
+		})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"error":  "invalid incident fields",
+			"fields": invalid.Fields,
 		})
-		http.Error(w, "failed to parse payload", http.StatusBadRequest)
-		s.metrics.IncidentReceived.WithLabelValues(provider, "parse_error").Inc()
+		s.metrics.RecordIncident(provider, "invalid")
+		s.metrics.RecordWebhookFailure(provider, adapters.ReasonInvalidField)
 		return
 	}
 
-	// Store incident
-	if err := s.repository.Create(incident); err != nil {
-		s.logger.Error("failed to store incident", map[string]interface{}{
-			"error":       err.Error(),
-			"provider":    provider,
-			"incident_id": incident.ID,
+	job := ingestJob{
+		ctx:        context.WithoutCancel(ctx),
+		provider:   provider,
+		adapter:    adapter,
+		body:       body,
+		incident:   incident,
+		receivedAt: startTime,
+	}
+
+	// Parsing and storage happen on the ingestion pool, so the provider is
+	// acknowledged as soon as the webhook is validated
+	if s.ingestion == nil {
+		s.processWebhook(job)
+	} else if err := s.ingestion.enqueue(job); err != nil {
+		logger.Error("failed to enqueue webhook", map[string]interface{}{
+			"error": err.Error(),
 		})
-		http.Error(w, "internal server error", http.StatusInternalServerError)
-		s.metrics.IncidentReceived.WithLabelValues(provider, "storage_error").Inc()
+		if s.replay != nil {
+			if err := s.replay.release(ctx, nonce); err != nil {
+				logger.Warn("failed to release webhook delivery", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		}
+		// A full queue is temporary, so the provider is told when to retry.
+		// A stopped pool means the replica is shutting down.
+		if errors.Is(err, errIngestionQueueFull) {
+			w.Header().Set("Retry-After", strconv.Itoa(s.ingestion.retryAfter))
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			s.metrics.RecordIncident(provider, "throttled")
+			return
+		}
+		http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+		s.metrics.RecordIncident(provider, "rejected")
 		return
 	}
 
-	// Log success
-	s.logger.Info("incident received and stored", map[string]interface{}{
-		"incident_id":  incident.ID,
-		"provider":     provider,
-		"service_name": incident.ServiceName,
-		"severity":     incident.Severity,
-		"duration_ms":  time.Since(startTime).Milliseconds(),
-	})
-
-	// Update metrics
-	s.metrics.IncidentReceived.WithLabelValues(provider, "success").Inc()
-	s.metrics.WebhookProcessingDuration.WithLabelValues(provider).Observe(time.Since(startTime).Seconds())
-
 	// Return success response
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusAccepted)
 	_ = json.NewEncoder(w).Encode(map[string]interface{}{
-		"status":      "accepted",
-		"incident_id": incident.ID,
+		"status":     "accepted",
+		"request_id": w.Header().Get(requestIDHeader),
 	})
 }
 
@@ -260,16 +659,18 @@ type WorkflowStatusPayload struct {
 	PullRequestURL string `json:"pr_url,omitempty"`
 	Diagnosis      string `json:"diagnosis,omitempty"`
 	Repository     string `json:"repository"`
+	WorkflowRunID  int64  `json:"workflow_run_id,omitempty"`
 }
 
 // handleWorkflowStatus handles workflow completion webhooks from GitHub Actions
 func (s *Server) handleWorkflowStatus(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
+	ctx := r.Context()
 
 	// Parse request body
 	var payload WorkflowStatusPayload
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-		s.logger.Error("failed to parse workflow status payload", map[string]interface{}{
+		s.loggerFrom(ctx).Error("failed to parse workflow status payload", map[string]interface{}{
 			"error": err.Error(),
 		})
 		http.Error(w, "invalid payload", http.StatusBadRequest)
@@ -278,7 +679,7 @@ func (s *Server) handleWorkflowStatus(w http.ResponseWriter, r *http.Request) {
 
 	// Validate required fields
 	if payload.IncidentID == "" || payload.Status == "" || payload.Repository == "" {
-		s.logger.Error("missing required fields in workflow status payload", map[string]interface{}{
+		s.loggerFrom(ctx).Error("missing required fields in workflow status payload", map[string]interface{}{
 			"incident_id": payload.IncidentID,
 			"status":      payload.Status,
 			"repository":  payload.Repository,
@@ -287,57 +688,98 @@ func (s *Server) handleWorkflowStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	logger := s.loggerFrom(ctx).With(map[string]interface{}{
+		"incident_id": payload.IncidentID,
+		"repository":  payload.Repository,
+	})
+
 	// Get the incident
-	incident, err := s.repository.GetByID(payload.IncidentID)
+	repository := s.repository.WithContext(ctx)
+	incident, err := repository.GetByID(payload.IncidentID)
 	if err != nil {
-		s.logger.Error("failed to get incident for workflow status update", map[string]interface{}{
-			"error":       err.Error(),
-			"incident_id": payload.IncidentID,
+		logger.Error("failed to get incident for workflow status update", map[string]interface{}{
+			"error": err.Error(),
 		})
 		http.Error(w, "incident not found", http.StatusNotFound)
 		return
 	}
 
-	// Update incident based on workflow status
-	now := time.Now()
-	incident.CompletedAt = &now
+	status, ok := payload.outcome()
+	if !ok {
+		logger.Error("unknown workflow status", map[string]interface{}{
+			"status": payload.Status,
+		})
+		http.Error(w, "unknown status", http.StatusBadRequest)
+		return
+	}
 
-	switch payload.Status {
-	case "success":
-		if payload.PullRequestURL != "" {
-			incident.Status = models.StatusPRCreated
-			incident.PullRequestURL = &payload.PullRequestURL
-		} else {
-			incident.Status = models.StatusNoFixNeeded
+	// Link the run to the dispatch that started it in the dispatch history
+	if payload.WorkflowRunID != 0 {
+		if err := repository.SetDispatchRunID(payload.IncidentID, payload.Repository, payload.WorkflowRunID); err != nil {
+			logger.Error("failed to record workflow run of dispatch", map[string]interface{}{
+				"error":           err.Error(),
+				"workflow_run_id": payload.WorkflowRunID,
+			})
 		}
-	case "failed":
-		incident.Status = models.StatusFailed
-	case "no_fix_needed":
-		incident.Status = models.StatusNoFixNeeded
-	default:
-		s.logger.Error("unknown workflow status", map[string]interface{}{
-			"status":      payload.Status,
-			"incident_id": payload.IncidentID,
+	}
+
+	// Services that span several repositories record the outcome per
+	// repository and roll the outcomes up on the incident
+	fanned, err := s.completeDispatch(ctx, incident, &payload, status, logger)
+	if err != nil {
+		logger.Error("failed to record repository workflow outcome", map[string]interface{}{
+			"error": err.Error(),
 		})
-		http.Error(w, "unknown status", http.StatusBadRequest)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if fanned {
+		s.ReleaseWorkflowSlot(ctx, payload.Repository, payload.IncidentID)
+		logger.Info("repository workflow status updated", map[string]interface{}{
+			"status":           payload.Status,
+			"pull_request_url": payload.PullRequestURL,
+			"duration_ms":      time.Since(startTime).Milliseconds(),
+		})
+		writeWorkflowStatusUpdated(w)
 		return
 	}
 
+	// Update incident based on workflow status
+	now := time.Now()
+	incident.CompletedAt = &now
+	incident.Status = status
+	if status == models.StatusPRCreated {
+		incident.PullRequestURL = &payload.PullRequestURL
+	}
+
 	// Update diagnosis if provided
 	if payload.Diagnosis != "" {
 		incident.Diagnosis = &payload.Diagnosis
 	}
 
+	// Record the run, so its logs can be fetched if it failed
+	if payload.WorkflowRunID != 0 {
+		incident.WorkflowRunID = &payload.WorkflowRunID
+	}
+
 	// Update the incident in the database
-	if err := s.repository.Update(incident); err != nil {
-		s.logger.Error("failed to update incident after workflow completion", map[string]interface{}{
-			"error":       err.Error(),
-			"incident_id": payload.IncidentID,
+	if err := repository.Update(incident); err != nil {
+		logger.Error("failed to update incident after workflow completion", map[string]interface{}{
+			"error": err.Error(),
 		})
 		http.Error(w, "internal server error", http.StatusInternalServerError)
 		return
 	}
 
+	switch incident.Status {
+	case models.StatusPRCreated:
+		s.notify(models.EventPRCreated, incident)
+	case models.StatusFailed:
+		s.notify(models.EventIncidentFailed, incident)
+	case models.StatusNoFixNeeded:
+		s.notify(models.EventStatusChanged, incident)
+	}
+
 	// Log the workflow completion event
 	eventType := models.EventPRCreated
 	if payload.Status == "failed" {
@@ -354,94 +796,45 @@ func (s *Server) handleWorkflowStatus(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
-	if err := s.repository.LogEvent(event); err != nil {
-		s.logger.Error("failed to log workflow completion event", map[string]interface{}{
-			"error":       err.Error(),
-			"incident_id": payload.IncidentID,
+	if err := repository.LogEvent(event); err != nil {
+		logger.Error("failed to log workflow completion event", map[string]interface{}{
+			"error": err.Error(),
 		})
 		// Don't fail the request if event logging fails
 	}
 
-	// Process queued incidents for this repository
-	nextIncident := s.githubClient.DecrementActive(payload.Repository)
-	if nextIncident != nil {
-		s.logger.Info("processing queued incident", map[string]interface{}{
-			"incident_id": nextIncident.ID,
-			"repository":  nextIncident.Repository,
-		})
-
-		// Log dequeue event
-		dequeueEvent := &models.IncidentEvent{
-			IncidentID: nextIncident.ID,
-			EventType:  models.EventDequeuedForRemediation,
-			EventData: map[string]interface{}{
-				"repository": nextIncident.Repository,
-			},
-		}
-		if err := s.repository.LogEvent(dequeueEvent); err != nil {
-			s.logger.Error("failed to log dequeue event", map[string]interface{}{
-				"error":       err.Error(),
-				"incident_id": nextIncident.ID,
-			})
-		}
-
-		// Trigger workflow for the queued incident
-		go func(inc *models.Incident) {
-			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer cancel()
-
-			// Get the branch from config (default to "main")
-			branch := "main"
-			if s.config != nil && s.config.ServiceMappings != nil {
-				for _, mapping := range s.config.ServiceMappings {
-					if mapping.Repository == inc.Repository {
-						branch = mapping.Branch
-						break
-					}
-				}
-			}
-
-			_, err := s.githubClient.DispatchWorkflow(ctx, inc, branch)
-			if err != nil {
-				s.logger.Error("failed to dispatch workflow for queued incident", map[string]interface{}{
-					"error":       err.Error(),
-					"incident_id": inc.ID,
-					"repository":  inc.Repository,
-				})
-
-				// Update incident status to failed
-				if updateErr := s.repository.UpdateStatus(inc.ID, models.StatusFailed); updateErr != nil {
-					s.logger.Error("failed to update queued incident status", map[string]interface{}{
-						"error":       updateErr.Error(),
-						"incident_id": inc.ID,
-					})
-				}
-				return
-			}
-
-			// Update incident status to workflow_triggered
-			triggerTime := time.Now()
-			inc.TriggeredAt = &triggerTime
-			inc.Status = models.StatusWorkflowTriggered
-			if updateErr := s.repository.Update(inc); updateErr != nil {
-				s.logger.Error("failed to update queued incident after dispatch", map[string]interface{}{
-					"error":       updateErr.Error(),
-					"incident_id": inc.ID,
-				})
-			}
-		}(nextIncident)
-	}
+	// Free the concurrency slot and dispatch the next queued incident for this repository
+	s.ReleaseWorkflowSlot(ctx, payload.Repository, payload.IncidentID)
 
 	// Log success
-	s.logger.Info("workflow status updated", map[string]interface{}{
-		"incident_id":      payload.IncidentID,
+	logger.Info("workflow status updated", map[string]interface{}{
 		"status":           payload.Status,
 		"pull_request_url": payload.PullRequestURL,
-		"repository":       payload.Repository,
 		"duration_ms":      time.Since(startTime).Milliseconds(),
 	})
 
-	// Return success response
+	writeWorkflowStatusUpdated(w)
+}
+
+// outcome maps the reported workflow status to the incident status it leads
+// to. A successful run without a pull request found nothing to fix.
+func (p *WorkflowStatusPayload) outcome() (models.IncidentStatus, bool) {
+	switch p.Status {
+	case "success":
+		if p.PullRequestURL != "" {
+			return models.StatusPRCreated, true
+		}
+		return models.StatusNoFixNeeded, true
+	case "failed":
+		return models.StatusFailed, true
+	case "no_fix_needed":
+		return models.StatusNoFixNeeded, true
+	}
+	return "", false
+}
+
+// writeWorkflowStatusUpdated writes the success response of a workflow status update
+func writeWorkflowStatusUpdated(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(map[string]interface{}{
@@ -450,33 +843,279 @@ func (s *Server) handleWorkflowStatus(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// ReleaseWorkflowSlot frees the workflow concurrency slot the incident holds
+// in the repository once its remediation has finished, and dispatches the
+// next queued incident, if any, in the background
+func (s *Server) ReleaseWorkflowSlot(ctx context.Context, repository, incidentID string) {
+	nextIncident, err := s.githubClient.ReleaseSlot(ctx, repository, incidentID)
+	if err != nil {
+		s.loggerFrom(ctx).Error("failed to release workflow slot", map[string]interface{}{
+			"error":      err.Error(),
+			"repository": repository,
+		})
+		return
+	}
+	s.observeWorkflowSlots(repository)
+	if nextIncident == nil {
+		return
+	}
+	s.dispatchDequeued(ctx, nextIncident)
+}
+
+// dispatchDequeued dispatches an incident popped from its repository's queue
+// in the background
+func (s *Server) dispatchDequeued(ctx context.Context, nextIncident *models.Incident) {
+	queuedLogger := s.loggerFrom(ctx).With(map[string]interface{}{
+		"incident_id": nextIncident.ID,
+		"repository":  nextIncident.Repository,
+	})
+	queuedLogger.Info("processing queued incident", nil)
+
+	// Log dequeue event
+	dequeueEvent := &models.IncidentEvent{
+		IncidentID: nextIncident.ID,
+		EventType:  models.EventDequeuedForRemediation,
+		EventData: map[string]interface{}{
+			"repository": nextIncident.Repository,
+		},
+	}
+	if err := s.repository.WithContext(ctx).LogEvent(dequeueEvent); err != nil {
+		queuedLogger.Error("failed to log dequeue event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	s.notify(models.EventDequeuedForRemediation, nextIncident)
+
+	// Trigger workflow for the queued incident, continuing this request's trace
+	s.dispatchInBackground(ctx, "dispatch queued incident", nextIncident, queuedLogger)
+}
+
+// dispatchInBackground dispatches an incident without waiting for GitHub,
+// continuing the trace of ctx
+func (s *Server) dispatchInBackground(ctx context.Context, task string, inc *models.Incident, logger *Logger) {
+	spanContext := trace.SpanContextFromContext(ctx)
+	s.goBackground(task, map[string]interface{}{
+		"incident_id": inc.ID,
+		"repository":  inc.Repository,
+	}, func() {
+		ctx, cancel := context.WithTimeout(trace.ContextWithSpanContext(context.Background(), spanContext), 30*time.Second)
+		defer cancel()
+
+		_ = s.dispatchIncident(ctx, inc, logger)
+	})
+}
+
+// DispatchIncident triggers the remediation workflow for a pending incident.
+// If the repository is at its concurrency limit, the incident is queued instead.
+// It is dispatched once a slot frees up.
+func (s *Server) DispatchIncident(ctx context.Context, incident *models.Incident) error {
+	logger := s.loggerFrom(ctx).With(map[string]interface{}{
+		"incident_id": incident.ID,
+		"repository":  incident.Repository,
+	})
+	return s.dispatchIncident(ctx, incident, logger)
+}
+
+// dispatchIncident dispatches the workflow and records the outcome on the incident
+func (s *Server) dispatchIncident(ctx context.Context, inc *models.Incident, logger *Logger) error {
+	// Snoozed incidents are known issues that should not be remediated
+	if inc.Snoozed(time.Now()) {
+		logger.Info("incident snoozed, not dispatching remediation", map[string]interface{}{
+			"snoozed_until": inc.SnoozedUntil,
+		})
+		return nil
+	}
+
+	// Incidents whose remediation a custom rule skips are only recorded
+	if inc.Status == models.StatusSuppressed {
+		logger.Info("incident suppressed, not dispatching remediation", nil)
+		return nil
+	}
+
+	// Incidents of a provider past its hourly quota are not remediated automatically
+	if inc.QuotaExceeded {
+		logger.Info("incident over provider quota, not dispatching remediation", map[string]interface{}{
+			"provider": inc.Provider,
+		})
+		return nil
+	}
+
+	// Remediation waits for an open maintenance window to close
+	if s.deferForMaintenance(ctx, inc, logger) {
+		return nil
+	}
+
+	// During a burst of dispatches, only urgent incidents go out right away
+	if s.deferForBurst(ctx, inc, logger) {
+		return nil
+	}
+
+	// A service past its daily remediation cap needs a person instead
+	release, ok := s.reserveRemediation(ctx, inc, logger)
+	if !ok {
+		return nil
+	}
+
+	// The workflow gets what the MCP servers know about the service
+	s.enrich(ctx, inc, logger)
+
+	// Services that span several repositories are remediated in each of them
+	if targets := s.fanOutTargets(inc); targets != nil {
+		return s.fanOut(ctx, inc, targets, logger)
+	}
+
+	// Get the branch from config (default to "main")
+	branch := "main"
+	configured := false
+	if s.config != nil && s.config.ServiceMappings != nil {
+		for _, mapping := range s.config.ServiceMappings {
+			if mapping.Repository == inc.Repository {
+				branch = mapping.Branch
+				configured = true
+				break
+			}
+		}
+	}
+	if !configured {
+		branch = s.runtimeBranch(ctx, inc, branch, logger)
+	}
+
+	err := s.dispatchWorkflow(ctx, inc, branch)
+	s.slowDripTurn(inc.ID)
+	if errors.Is(err, github.ErrIncidentQueued) {
+		release()
+		logger.Info("repository at concurrency limit, incident queued", nil)
+
+		queueEvent := &models.IncidentEvent{
+			IncidentID: inc.ID,
+			EventType:  models.EventQueuedForRemediation,
+			EventData: map[string]interface{}{
+				"repository": inc.Repository,
+			},
+		}
+		if err := s.repository.WithContext(ctx).LogEvent(queueEvent); err != nil {
+			logger.Error("failed to log queue event", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		s.notify(models.EventQueuedForRemediation, inc)
+		return nil
+	}
+	if err != nil {
+		// While GitHub keeps failing, the incident waits for its turn
+		// instead of failing
+		if s.holdForSlowDrip(ctx, inc, err, logger) {
+			return nil
+		}
+
+		logger.Error("failed to dispatch workflow", map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		// Update incident status to failed
+		if updateErr := s.repository.WithContext(ctx).UpdateStatus(inc.ID, models.StatusFailed); updateErr != nil {
+			logger.Error("failed to update incident status after failed dispatch", map[string]interface{}{
+				"error": updateErr.Error(),
+			})
+		}
+		inc.Status = models.StatusFailed
+		s.notify(models.EventIncidentFailed, inc)
+		return fmt.Errorf("failed to dispatch workflow: %w", err)
+	}
+
+	// Update incident status to workflow_triggered
+	triggerTime := time.Now()
+	inc.TriggeredAt = &triggerTime
+	inc.Status = models.StatusWorkflowTriggered
+	if updateErr := s.repository.WithContext(ctx).Update(inc); updateErr != nil {
+		logger.Error("failed to update incident after dispatch", map[string]interface{}{
+			"error": updateErr.Error(),
+		})
+		return fmt.Errorf("failed to update incident after dispatch: %w", updateErr)
+	}
+	s.notify(models.EventWorkflowTriggered, inc)
+
+	return nil
+}
+
 // ConfigResponse represents the configuration data returned to the dashboard
 type ConfigResponse struct {
 	ServiceMappings []ServiceMappingResponse `json:"service_mappings"`
+	Teams           []TeamResponse           `json:"teams"`
+	// Runtime is the rest of the configuration the service runs with, keyed
+	// like the config file, with credentials masked
+	Runtime  map[string]interface{} `json:"runtime"`
+	LoadedAt *time.Time             `json:"loaded_at,omitempty"`
 }
 
 // ServiceMappingResponse represents a service-to-repository mapping
 type ServiceMappingResponse struct {
-	ServiceName string `json:"service_name"`
-	Repository  string `json:"repository"`
-	Branch      string `json:"branch"`
+	ServiceName  string                    `json:"service_name"`
+	Repository   string                    `json:"repository"`
+	Branch       string                    `json:"branch"`
+	Team         string                    `json:"team,omitempty"`
+	Tenant       string                    `json:"tenant,omitempty"`
+	Repositories []config.RepositoryTarget `json:"repositories,omitempty"` // remediated alongside Repository
+}
+
+// TeamResponse represents a team and its contacts. Webhook URLs and routing
+// keys are left out.
+type TeamResponse struct {
+	Name               string   `json:"name"`
+	Owners             []string `json:"owners"`
+	SlackChannel       string   `json:"slack_channel,omitempty"`
+	EscalationContacts []string `json:"escalation_contacts"`
 }
 
 // handleGetConfig handles requests for configuration data
 func (s *Server) handleGetConfig(w http.ResponseWriter, r *http.Request) {
+	// Rule details are for admins only, their patterns can quote customer data
+	includeRules := r.URL.Query().Get("include_rules") == "true"
+	if includeRules && !principalFrom(r.Context()).admin {
+		http.Error(w, "include_rules requires an admin API key", http.StatusForbidden)
+		return
+	}
+
+	runtime, err := runtimeConfig(s.config, includeRules)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to build runtime config", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	// Build response from current configuration
 	response := ConfigResponse{
+		Runtime:         runtime,
 		ServiceMappings: make([]ServiceMappingResponse, 0, len(s.config.ServiceMappings)),
+		Teams:           make([]TeamResponse, 0, len(s.config.Teams)),
 	}
 
 	for _, mapping := range s.config.ServiceMappings {
 		response.ServiceMappings = append(response.ServiceMappings, ServiceMappingResponse{
-			ServiceName: mapping.ServiceName,
-			Repository:  mapping.Repository,
-			Branch:      mapping.Branch,
+			ServiceName:  mapping.ServiceName,
+			Repository:   mapping.Repository,
+			Branch:       mapping.Branch,
+			Team:         mapping.Team,
+			Tenant:       mapping.Tenant,
+			Repositories: mapping.Targets()[1:],
 		})
 	}
 
+	for _, team := range s.config.Teams {
+		response.Teams = append(response.Teams, TeamResponse{
+			Name:               team.Name,
+			Owners:             append([]string{}, team.Owners...),
+			SlackChannel:       team.SlackChannel,
+			EscalationContacts: append([]string{}, team.EscalationContacts...),
+		})
+	}
+	if !s.config.LoadedAt.IsZero() {
+		loadedAt := s.config.LoadedAt.UTC()
+		response.LoadedAt = &loadedAt
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
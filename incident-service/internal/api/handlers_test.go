@@ -50,7 +50,7 @@ func TestHandleWorkflowStatus_Success(t *testing.T) {
 
 	// Create server (without Redis for this test)
 	redis, _ := database.ConnectRedis("localhost:6379", "", 0)
-	server := NewServer(cfg, db, redis, githubClient)
+	server := NewServer(cfg, db, redis, githubClient, NewLogger())
 	repository := database.NewIncidentRepository(db)
 
 	// Create a test incident
@@ -157,7 +157,7 @@ func TestHandleWorkflowStatus_Failed(t *testing.T) {
 
 	// Create server (without Redis for this test)
 	redis, _ := database.ConnectRedis("localhost:6379", "", 0)
-	server := NewServer(cfg, db, redis, githubClient)
+	server := NewServer(cfg, db, redis, githubClient, NewLogger())
 	repository := database.NewIncidentRepository(db)
 
 	// Create a test incident
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/workers"
+)
+
+// DependencyHealth is the result of pinging a dependency in a deep health check
+type DependencyHealth struct {
+	Status    string  `json:"status"` // healthy or unhealthy
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// WorkerHealth is the liveness of a background worker running on this replica.
+// Stale workers missed more than one run.
+type WorkerHealth struct {
+	Interval  string     `json:"interval"`
+	StartedAt time.Time  `json:"started_at"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	Stale     bool       `json:"stale"`
+}
+
+// checkDependency times a ping of a dependency
+func checkDependency(ping func() error) DependencyHealth {
+	start := time.Now()
+	err := ping()
+	health := DependencyHealth{
+		Status:    "healthy",
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		health.Status = "unhealthy"
+		health.Error = err.Error()
+	}
+	return health
+}
+
+// deepHealth adds what ?deep=true asks for to a health response: the latency
+// of each dependency, when the configuration was loaded and the liveness of
+// the background workers. GitHub is only pinged here; it being unreachable
+// delays dispatches but leaves the service healthy.
+func (s *Server) deepHealth(ctx context.Context, health map[string]interface{}, dependencies map[string]DependencyHealth) {
+	if s.githubClient != nil {
+		dependencies["github"] = checkDependency(func() error { return s.githubClient.Ping(ctx) })
+	}
+	health["dependencies"] = dependencies
+
+	if !s.config.LoadedAt.IsZero() {
+		health["config_loaded_at"] = s.config.LoadedAt.UTC().Format(time.RFC3339)
+	}
+
+	now := time.Now()
+	liveness := make(map[string]WorkerHealth)
+	for name, heartbeat := range workers.Heartbeats() {
+		worker := WorkerHealth{
+			Interval:  heartbeat.Interval.String(),
+			StartedAt: heartbeat.StartedAt.UTC(),
+			Stale:     heartbeat.Stale(now),
+		}
+		if !heartbeat.LastRunAt.IsZero() {
+			lastRunAt := heartbeat.LastRunAt.UTC()
+			worker.LastRunAt = &lastRunAt
+		}
+		liveness[name] = worker
+	}
+	health["workers"] = liveness
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/workers"
+)
+
+func TestHandleHealth_Deep(t *testing.T) {
+	gh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rate_limit" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer gh.Close()
+
+	loadedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := &Server{
+		config:       &config.Config{LoadedAt: loadedAt},
+		githubClient: github.NewClient(gh.URL, "token", "remediate.yml", 1),
+		logger:       NewLogger(),
+	}
+
+	watchdog := workers.NewSlotWatchdogWorker(func(ctx context.Context) error { return nil }, NewLogger())
+	go watchdog.Start(time.Millisecond)
+	defer watchdog.Stop()
+	time.Sleep(10 * time.Millisecond)
+
+	w := httptest.NewRecorder()
+	s.handleHealth(w, httptest.NewRequest("GET", "/api/v1/health?deep=true", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Status         string                      `json:"status"`
+		Dependencies   map[string]DependencyHealth `json:"dependencies"`
+		ConfigLoadedAt time.Time                   `json:"config_loaded_at"`
+		Workers        map[string]WorkerHealth     `json:"workers"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Status != "healthy" {
+		t.Errorf("expected healthy status, got %s", response.Status)
+	}
+	if ping := response.Dependencies["github"]; ping.Status != "healthy" || ping.LatencyMs <= 0 {
+		t.Errorf("expected a timed healthy GitHub ping, got %+v", ping)
+	}
+	if !response.ConfigLoadedAt.Equal(loadedAt) {
+		t.Errorf("expected config loaded at %s, got %s", loadedAt, response.ConfigLoadedAt)
+	}
+	if watchdog, ok := response.Workers["slot_watchdog"]; !ok || watchdog.LastRunAt == nil {
+		t.Errorf("expected the slot watchdog to have run, got %+v", response.Workers)
+	}
+}
+
+func TestHandleHealth_GitHubOnlyPingedWhenDeep(t *testing.T) {
+	gh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer gh.Close()
+
+	s := &Server{
+		config:       &config.Config{},
+		githubClient: github.NewClient(gh.URL, "token", "remediate.yml", 1),
+		logger:       NewLogger(),
+	}
+
+	for _, path := range []string{"/api/v1/health", "/api/v1/health?deep=true"} {
+		w := httptest.NewRecorder()
+		s.handleHealth(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status %d when only GitHub fails, got %d", path, http.StatusOK, w.Code)
+		}
+
+		var response struct {
+			Dependencies map[string]DependencyHealth `json:"dependencies"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		ping, pinged := response.Dependencies["github"]
+		if deep := path != "/api/v1/health"; pinged != deep {
+			t.Errorf("%s: expected GitHub pinged %v, got %+v", path, deep, response.Dependencies)
+		} else if deep && (ping.Status != "unhealthy" || ping.Error == "") {
+			t.Errorf("%s: expected GitHub unhealthy with an error, got %+v", path, ping)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+)
+
+// Heatmap holds incident counts by day of week and hour of day. Counts is
+// indexed by weekday, Sunday first, then by hour.
+type Heatmap struct {
+	ServiceName string     `json:"service_name,omitempty"`
+	Total       int        `json:"total"`
+	Counts      [7][24]int `json:"counts"`
+}
+
+// add counts the incidents of an hourly bucket in the cell of its start time
+func (h *Heatmap) add(bucketStart time.Time, count int) {
+	h.Counts[bucketStart.Weekday()][bucketStart.Hour()] += count
+	h.Total += count
+}
+
+// handleGetStatisticsHeatmap returns incident counts by day of week and hour
+// of day, read from the hourly rollups, to show failures that recur at the
+// same time. Hours are those of the timezone parameter, UTC by default.
+// With per_service=true, the response also holds one heatmap per service.
+// It covers the last 4 weeks by default.
+func (s *Server) handleGetStatisticsHeatmap(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	location := time.UTC
+	if name := query.Get("timezone"); name != "" {
+		loaded, err := time.LoadLocation(name)
+		if err != nil {
+			http.Error(w, "invalid timezone, expected an IANA name such as Europe/Berlin", http.StatusBadRequest)
+			return
+		}
+		location = loaded
+	}
+
+	perService := false
+	if value := query.Get("per_service"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, "per_service must be true or false", http.StatusBadRequest)
+			return
+		}
+		perService = parsed
+	}
+
+	filter, err := parseRollupFilter(query, database.RollupHourly, 28*24*time.Hour, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !principalFrom(r.Context()).scopeRollups(filter) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	buckets, err := s.repository.WithContext(r.Context()).GetRollupBucketsByService(filter)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to get statistics heatmap", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	overall, services := buildHeatmaps(buckets, location)
+	response := map[string]interface{}{
+		"start":    filter.Start,
+		"end":      filter.End,
+		"timezone": location.String(),
+		"heatmap":  overall,
+	}
+	if perService {
+		response["services"] = services
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// buildHeatmaps places the hourly buckets in the cells of their local start
+// time, overall and per service. Buckets come ordered by service, so the
+// service heatmaps are ordered by name.
+func buildHeatmaps(buckets []database.ServiceBucket, location *time.Location) (Heatmap, []Heatmap) {
+	var overall Heatmap
+	services := []Heatmap{}
+	for _, bucket := range buckets {
+		start := bucket.BucketStart.In(location)
+		overall.add(start, bucket.Total)
+
+		if len(services) == 0 || services[len(services)-1].ServiceName != bucket.ServiceName {
+			services = append(services, Heatmap{ServiceName: bucket.ServiceName})
+		}
+		services[len(services)-1].add(start, bucket.Total)
+	}
+	return overall, services
+}
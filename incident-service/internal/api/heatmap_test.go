@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+)
+
+func TestBuildHeatmaps(t *testing.T) {
+	// Wednesday 2024-03-06 and Thursday 2024-03-07, in UTC
+	buckets := []database.ServiceBucket{
+		{ServiceName: "api", BucketStart: time.Date(2024, 3, 6, 2, 0, 0, 0, time.UTC), Total: 3},
+		{ServiceName: "api", BucketStart: time.Date(2024, 3, 13, 2, 0, 0, 0, time.UTC), Total: 2},
+		{ServiceName: "worker", BucketStart: time.Date(2024, 3, 7, 23, 0, 0, 0, time.UTC), Total: 1},
+	}
+
+	overall, services := buildHeatmaps(buckets, time.UTC)
+	if overall.Total != 6 || overall.Counts[time.Wednesday][2] != 5 || overall.Counts[time.Thursday][23] != 1 {
+		t.Errorf("unexpected overall heatmap: total %d, %v", overall.Total, overall.Counts)
+	}
+	if len(services) != 2 || services[0].ServiceName != "api" || services[0].Total != 5 || services[1].Counts[time.Thursday][23] != 1 {
+		t.Errorf("unexpected service heatmaps %+v", services)
+	}
+
+	// Buckets move to the local day and hour of the requested timezone
+	tokyo := time.FixedZone("UTC+9", 9*60*60)
+	overall, _ = buildHeatmaps(buckets, tokyo)
+	if overall.Counts[time.Wednesday][11] != 5 || overall.Counts[time.Friday][8] != 1 {
+		t.Errorf("expected buckets in local time, got %v", overall.Counts)
+	}
+}
+
+func TestHandleGetStatisticsHeatmap_InvalidParameters(t *testing.T) {
+	s := &Server{logger: NewLogger()}
+
+	for _, target := range []string{
+		"/api/v1/statistics/heatmap?timezone=Mars/Olympus",
+		"/api/v1/statistics/heatmap?per_service=maybe",
+	} {
+		w := httptest.NewRecorder()
+		s.handleGetStatisticsHeatmap(w, httptest.NewRequest("GET", target, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", target, w.Code)
+		}
+	}
+}
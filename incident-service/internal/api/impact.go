@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// handlePatchIncident updates the impact of an incident: its affected users
+// estimate, error rate and downtime. Fields left out of the body keep their
+// values; no other incident field can be changed this way.
+func (s *Server) handlePatchIncident(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var impact models.Impact
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&impact); err != nil {
+		http.Error(w, "invalid payload, only affected_users, error_rate and downtime_minutes can be updated", http.StatusBadRequest)
+		return
+	}
+	if impact.Empty() {
+		http.Error(w, "at least one of affected_users, error_rate and downtime_minutes is required", http.StatusBadRequest)
+		return
+	}
+	if err := impact.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	repository := s.repository.WithContext(r.Context())
+	incident, err := repository.GetByID(id)
+	if err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+
+	before := impactAuditState(incident)
+	if err := repository.SetImpact(id, impact); err != nil {
+		s.loggerFrom(r.Context()).Error("failed to update incident impact", map[string]interface{}{
+			"error":       err.Error(),
+			"incident_id": id,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	impact.Apply(incident)
+	after := impactAuditState(incident)
+
+	event := &models.IncidentEvent{
+		IncidentID: id,
+		EventType:  models.EventImpactUpdated,
+		EventData:  after,
+	}
+	if err := repository.LogEvent(event); err != nil {
+		s.loggerFrom(r.Context()).Error("failed to log impact event", map[string]interface{}{
+			"error":       err.Error(),
+			"incident_id": id,
+		})
+	}
+	s.recordAudit(r, models.AuditIncidentUpdated, id, before, after)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(incident)
+}
+
+// extractImpact fills in the impact figures that the incident's provider
+// sends, where the impact config says its webhook payloads carry them. The
+// whole payload is searched, as provider data keeps only part of it.
+func (s *Server) extractImpact(incident *models.Incident, body []byte) {
+	fields, ok := s.config.Impact.Providers[incident.Provider]
+	if !ok {
+		return
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return
+	}
+	models.ExtractImpact(payload, models.ImpactPaths{
+		AffectedUsers:   fields.AffectedUsers,
+		ErrorRate:       fields.ErrorRate,
+		DowntimeMinutes: fields.DowntimeMinutes,
+	}).Apply(incident)
+}
+
+// impactAuditState is what the audit log records of an incident's impact
+func impactAuditState(incident *models.Incident) map[string]interface{} {
+	return map[string]interface{}{
+		"affected_users":   incident.AffectedUsers,
+		"error_rate":       incident.ErrorRate,
+		"downtime_minutes": incident.DowntimeMinutes,
+	}
+}
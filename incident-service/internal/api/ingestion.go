@@ -0,0 +1,398 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/shutdown"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/storm"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/validation"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// errIngestionQueueFull is returned when every worker is busy and the queue has no room left
+var errIngestionQueueFull = errors.New("ingestion queue full")
+
+// errIngestionStopped is returned for webhooks that arrive after the pool started draining
+var errIngestionStopped = errors.New("ingestion stopped")
+
+// ingestJob is a validated webhook waiting to be parsed and stored
+type ingestJob struct {
+	ctx        context.Context
+	provider   string
+	adapter    adapters.WebhookAdapter
+	body       []byte
+	incident   *models.Incident // parsed and validated by the handler, nil when still to be parsed
+	receivedAt time.Time
+	replayed   bool // replayed from the webhook buffer, after a first attempt
+}
+
+// ingestionGauges report how loaded the ingestion pool is. Only depth is
+// required.
+type ingestionGauges struct {
+	depth      prometheus.Gauge // queued webhooks
+	busy       prometheus.Gauge // workers processing a webhook
+	saturation prometheus.Gauge // fraction of workers and queue slots in use
+}
+
+// ingestionPool is a bounded queue of webhooks drained by a fixed set of workers
+type ingestionPool struct {
+	jobs       chan ingestJob
+	process    func(job ingestJob)
+	workers    int
+	busy       int64
+	retryAfter int // seconds that webhooks turned away by a full queue are told to wait
+	gauges     ingestionGauges
+	wg         sync.WaitGroup
+	mu         sync.RWMutex
+	stopped    bool
+}
+
+// newIngestionPool creates an ingestion pool and starts its workers
+func newIngestionPool(cfg config.IngestionConfig, process func(job ingestJob), gauges ingestionGauges) *ingestionPool {
+	p := &ingestionPool{
+		jobs:       make(chan ingestJob, cfg.Capacity()),
+		process:    process,
+		workers:    cfg.WorkerCount(),
+		retryAfter: cfg.RetryDelaySeconds(),
+		gauges:     gauges,
+	}
+
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+
+	p.observe()
+	return p
+}
+
+// enqueue hands a webhook to the workers without blocking
+func (p *ingestionPool) enqueue(job ingestJob) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.stopped {
+		return errIngestionStopped
+	}
+
+	select {
+	case p.jobs <- job:
+		p.observe()
+		return nil
+	default:
+		return errIngestionQueueFull
+	}
+}
+
+// work processes queued webhooks until the pool is drained
+func (p *ingestionPool) work() {
+	defer p.wg.Done()
+
+	for job := range p.jobs {
+		atomic.AddInt64(&p.busy, 1)
+		p.observe()
+		p.process(job)
+		atomic.AddInt64(&p.busy, -1)
+		p.observe()
+	}
+}
+
+// observe updates the pool's gauges. Saturation reaches 1 when every worker
+// is busy and the queue is full, at which point webhooks are turned away.
+func (p *ingestionPool) observe() {
+	queued := len(p.jobs)
+	busy := int(atomic.LoadInt64(&p.busy))
+
+	p.gauges.depth.Set(float64(queued))
+	if p.gauges.busy != nil {
+		p.gauges.busy.Set(float64(busy))
+	}
+	if p.gauges.saturation != nil {
+		p.gauges.saturation.Set(float64(queued+busy) / float64(cap(p.jobs)+p.workers))
+	}
+}
+
+// drain stops accepting webhooks and waits for the queued ones to be processed,
+// giving up when ctx is done
+func (p *ingestionPool) drain(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.stopped {
+		p.stopped = true
+		close(p.jobs)
+	}
+	p.mu.Unlock()
+
+	return shutdown.Wait(ctx, &p.wg)
+}
+
+// processWebhook parses and stores an accepted webhook. It runs on an
+// ingestion worker, after the provider has already had its response.
+func (s *Server) processWebhook(job ingestJob) {
+	defer s.recoverBackground("process webhook", map[string]interface{}{
+		"provider": job.provider,
+	})
+
+	ctx, span := tracing.Start(job.ctx, "ingestion.Process")
+	var spanErr error
+	defer func() { tracing.End(span, spanErr) }()
+
+	logger := s.loggerFrom(ctx).With(map[string]interface{}{
+		"provider": job.provider,
+	})
+
+	// A replayed webhook's fields were noted on its first attempt
+	if !job.replayed {
+		s.recordUnknownFields(job.provider, job.adapter, job.body, job.receivedAt)
+	}
+
+	// Parse incident, unless the handler already did. Webhooks replayed from
+	// the buffer, and payloads that did not parse, come here unparsed.
+	incident := job.incident
+	if incident == nil {
+		_, parseSpan := tracing.Start(ctx, "adapter.Parse")
+		parsed, err := job.adapter.Parse(job.body)
+		tracing.End(parseSpan, err)
+		if err != nil {
+			spanErr = err
+			reason := adapters.FailureReason(err)
+			logger.Error("failed to parse webhook payload", map[string]interface{}{
+				"error":  err.Error(),
+				"reason": reason,
+			})
+			s.metrics.RecordIncident(job.provider, "parse_error")
+			s.metrics.RecordWebhookFailure(job.provider, reason)
+			return
+		}
+		if err := validation.Incident(parsed); err != nil {
+			spanErr = err
+			logger.Error("webhook payload has invalid fields", map[string]interface{}{
+				"error": err.Error(),
+			})
+			s.metrics.RecordIncident(job.provider, "invalid")
+			s.metrics.RecordWebhookFailure(job.provider, adapters.ReasonInvalidField)
+			return
+		}
+		incident = parsed
+	}
+
+	// Generated IDs replace the provider-derived one, whose reference the
+	// adapter kept in provider_ref
+	incident.ID = s.ids.IncidentID(incident.ID)
+
+	span.SetAttributes(
+		attribute.String("incident.id", incident.ID),
+		attribute.String("incident.service_name", incident.ServiceName),
+	)
+	logger = logger.With(map[string]interface{}{
+		"incident_id": incident.ID,
+		"repository":  incident.Repository,
+	})
+	if incident.ServiceName == adapters.UnknownService {
+		s.metrics.RecordUnknownService(job.provider)
+	}
+	if s.config != nil {
+		incident.Team = s.config.TeamFor(incident.ServiceName)
+		incident.TenantID = s.config.TenantFor(incident.ServiceName)
+		s.extractImpact(incident, job.body)
+
+		// Incidents of services without a repository are held until the
+		// service is mapped, rather than timing out as failed
+		if !s.serviceMapped(ctx, incident.ServiceName, logger) {
+			incident.Status = models.StatusUnmapped
+		}
+	}
+
+	// Custom rules may set the repository or skip remediation
+	outcome := s.applyRules(incident)
+
+	// Recurrences of a recently resolved incident reopen it, and repeats of a
+	// recent incident only raise its occurrence count, unless the service
+	// turned deduplication off
+	dedup := s.fingerprint(incident) != config.DedupStrategyDisabled
+	unlock := s.lockFingerprint(ctx, incident, logger)
+	defer unlock()
+	if dedup && s.reopen(ctx, incident, logger) {
+		s.metrics.RecordIncident(job.provider, "reopened")
+		return
+	}
+	if dedup && s.deduplicate(ctx, incident, logger) {
+		s.metrics.RecordIncident(job.provider, "duplicate")
+		return
+	}
+
+	// Alerts of other providers about the same outage join its incident
+	unlockService := s.lockCorrelation(ctx, incident, logger)
+	defer unlockService()
+	if s.correlate(ctx, incident, logger) {
+		s.metrics.RecordIncident(job.provider, "correlated")
+		return
+	}
+
+	// A provider past its hourly quota is likely a misconfigured monitor. Its
+	// incidents are stored but not remediated, and do not start storms either.
+	firstOverQuota := s.applyQuota(ctx, incident, logger)
+
+	// Count the incident towards its service's volume before storing it, so a
+	// storm incident exists before the first incident is grouped under it
+	var activeStorm *storm.Storm
+	if !incident.QuotaExceeded {
+		activeStorm = s.observeStorm(ctx, incident, logger)
+	}
+	if activeStorm == nil {
+		s.findGroupParent(ctx, incident, logger)
+	}
+
+	// Suggest a severity when the provider gave none that maps
+	suggestion, suggested := s.suggestSeverity(incident)
+
+	// Store incident
+	if err := s.repository.WithContext(ctx).Create(incident); err != nil {
+		spanErr = err
+		logger.Error("failed to store incident", map[string]interface{}{
+			"error": err.Error(),
+		})
+		// During a database outage the webhook is kept and processed again
+		// once the database recovers, instead of being lost
+		if s.bufferWebhook(ctx, job, logger) {
+			s.metrics.RecordIncident(job.provider, "buffered")
+			return
+		}
+		s.metrics.RecordIncident(job.provider, "storage_error")
+		return
+	}
+
+	// Log success
+	logger.Info("incident received and stored", map[string]interface{}{
+		"service_name": incident.ServiceName,
+		"severity":     incident.Severity,
+		"duration_ms":  time.Since(job.receivedAt).Milliseconds(),
+	})
+
+	// Update metrics
+	s.metrics.RecordIncident(job.provider, "success")
+	s.metrics.ObserveWebhookProcessing(job.provider, time.Since(job.receivedAt))
+
+	if suggested {
+		s.logSeveritySuggestion(ctx, incident, suggestion, logger)
+	}
+	s.logRuleOutcome(ctx, incident, outcome, logger)
+
+	// Incidents grouped under a storm are covered by the storm incident's
+	// remediation and notifications
+	if activeStorm != nil {
+		s.suppressForStorm(ctx, incident, activeStorm, logger)
+		return
+	}
+	s.logGrouped(ctx, incident, groupReasonFingerprint, logger)
+
+	// Incidents over quota only notify the owning team once per window
+	if incident.QuotaExceeded {
+		s.recordQuotaExceeded(ctx, incident, firstOverQuota, logger)
+		return
+	}
+	s.summarize(incident)
+	s.embed(incident)
+	s.notify(models.EventIncidentReceived, incident)
+	if incident.Status == models.StatusUnmapped {
+		s.recordUnmapped(ctx, incident, logger)
+	}
+}
+
+// fingerprintLockTTL bounds how long a fingerprint stays locked if a replica
+// dies while storing its incident
+const fingerprintLockTTL = 30 * time.Second
+
+// lockFingerprint keeps other replicas from storing an incident with the same
+// service and fingerprint until unlock is called. Without it, two replicas
+// receiving the same error at once would both find no duplicate and both
+// create an incident. When Redis is unavailable the incident is stored
+// without the lock, since losing it would be worse than a duplicate.
+func (s *Server) lockFingerprint(ctx context.Context, incident *models.Incident, logger *Logger) (unlock func()) {
+	key := fmt.Sprintf("incident-service:ingest:%s:%s:%s", incident.TenantID, incident.ServiceName, incident.Fingerprint)
+	return s.lockIngestion(ctx, key, "incident fingerprint", logger)
+}
+
+// lockIngestion takes an ingestion lock shared by every replica, or returns
+// a no-op unlock when Redis is unavailable
+func (s *Server) lockIngestion(ctx context.Context, key, what string, logger *Logger) (unlock func()) {
+	if s.redis == nil {
+		return func() {}
+	}
+
+	lockCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	unlock, err := s.redis.Lock(lockCtx, key, fingerprintLockTTL)
+	if err != nil {
+		logger.Warn("failed to lock "+what+", storing without it", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return func() {}
+	}
+	return unlock
+}
+
+// deduplicate counts the incident as another occurrence of a recent or
+// snoozed incident of the same service with the same fingerprint, and reports
+// whether it was one
+func (s *Server) deduplicate(ctx context.Context, incident *models.Incident, logger *Logger) bool {
+	if s.config == nil {
+		return false
+	}
+
+	repository := s.repository.WithContext(ctx)
+	original, err := repository.FindDuplicateIncident(incident.ServiceName, incident.Fingerprint, s.config.Deduplication.TimeWindow)
+	if err != nil {
+		logger.Error("failed to check for duplicate incident", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return false
+	}
+	if original == nil {
+		return false
+	}
+
+	logger = logger.With(map[string]interface{}{
+		"original_incident_id": original.ID,
+	})
+
+	// The original is still counted as a duplicate when recording fails, so
+	// a repeat never starts a second remediation
+	count, err := repository.RecordOccurrence(original.ID)
+	if err != nil {
+		logger.Error("failed to record duplicate occurrence", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return true
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: original.ID,
+		EventType:  models.EventDuplicateDetected,
+		EventData: map[string]interface{}{
+			"duplicate_incident_id": incident.ID,
+			"provider":              incident.Provider,
+			"occurrence_count":      count,
+		},
+	}
+	if err := repository.LogEvent(event); err != nil {
+		logger.Error("failed to log duplicate detected event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	logger.Info("duplicate incident counted as another occurrence", map[string]interface{}{
+		"occurrence_count": count,
+	})
+	return true
+}
@@ -0,0 +1,225 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+func TestIngestionPool_RejectsWhenFull(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 3)
+	depth := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_ingestion_depth"})
+
+	pool := newIngestionPool(config.IngestionConfig{Workers: 1, QueueSize: 2}, func(job ingestJob) {
+		started <- struct{}{}
+		<-release
+	}, ingestionGauges{depth: depth})
+
+	// The single worker picks up the first job and blocks on it
+	if err := pool.enqueue(ingestJob{provider: "datadog"}); err != nil {
+		t.Fatalf("enqueue() error = %v", err)
+	}
+	<-started
+
+	for i := 0; i < 2; i++ {
+		if err := pool.enqueue(ingestJob{provider: "datadog"}); err != nil {
+			t.Fatalf("enqueue() error = %v", err)
+		}
+	}
+	if got := testutil.ToFloat64(depth); got != 2 {
+		t.Errorf("expected queue depth 2, got %v", got)
+	}
+
+	if err := pool.enqueue(ingestJob{provider: "datadog"}); err != errIngestionQueueFull {
+		t.Errorf("expected errIngestionQueueFull, got %v", err)
+	}
+
+	close(release)
+	if err := pool.drain(context.Background()); err != nil {
+		t.Fatalf("drain() error = %v", err)
+	}
+	if got := testutil.ToFloat64(depth); got != 0 {
+		t.Errorf("expected empty queue after drain, got %v", got)
+	}
+}
+
+func TestIngestionPool_DrainProcessesQueuedJobs(t *testing.T) {
+	var mu sync.Mutex
+	processed := 0
+	depth := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_ingestion_depth"})
+
+	pool := newIngestionPool(config.IngestionConfig{Workers: 3}, func(job ingestJob) {
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		processed++
+		mu.Unlock()
+	}, ingestionGauges{depth: depth})
+
+	for i := 0; i < 50; i++ {
+		if err := pool.enqueue(ingestJob{provider: "grafana"}); err != nil {
+			t.Fatalf("enqueue() error = %v", err)
+		}
+	}
+
+	if err := pool.drain(context.Background()); err != nil {
+		t.Fatalf("drain() error = %v", err)
+	}
+	if processed != 50 {
+		t.Errorf("expected all 50 jobs processed before drain returns, got %d", processed)
+	}
+
+	if err := pool.enqueue(ingestJob{provider: "grafana"}); err != errIngestionStopped {
+		t.Errorf("expected errIngestionStopped after drain, got %v", err)
+	}
+	// Draining twice is harmless
+	if err := pool.drain(context.Background()); err != nil {
+		t.Errorf("second drain() error = %v", err)
+	}
+}
+
+func TestIngestionPool_DrainTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	depth := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_ingestion_depth"})
+
+	pool := newIngestionPool(config.IngestionConfig{Workers: 1}, func(job ingestJob) {
+		<-release
+	}, ingestionGauges{depth: depth})
+	if err := pool.enqueue(ingestJob{provider: "sentry"}); err != nil {
+		t.Fatalf("enqueue() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := pool.drain(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected drain to give up at the deadline, got %v", err)
+	}
+}
+
+func TestIngestionPool_Saturation(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	gauges := ingestionGauges{
+		depth:      prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_ingestion_depth"}),
+		busy:       prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_ingestion_busy"}),
+		saturation: prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_ingestion_saturation"}),
+	}
+
+	pool := newIngestionPool(config.IngestionConfig{Workers: 1, QueueSize: 3}, func(job ingestJob) {
+		started <- struct{}{}
+		<-release
+	}, gauges)
+
+	if err := pool.enqueue(ingestJob{provider: "datadog"}); err != nil {
+		t.Fatalf("enqueue() error = %v", err)
+	}
+	<-started
+	if err := pool.enqueue(ingestJob{provider: "datadog"}); err != nil {
+		t.Fatalf("enqueue() error = %v", err)
+	}
+
+	// One busy worker and one queued webhook use half of the four slots
+	if got := testutil.ToFloat64(gauges.busy); got != 1 {
+		t.Errorf("expected 1 busy worker, got %v", got)
+	}
+	if got := testutil.ToFloat64(gauges.saturation); got != 0.5 {
+		t.Errorf("expected saturation 0.5, got %v", got)
+	}
+
+	close(release)
+	if err := pool.drain(context.Background()); err != nil {
+		t.Fatalf("drain() error = %v", err)
+	}
+	if got := testutil.ToFloat64(gauges.saturation); got != 0 {
+		t.Errorf("expected saturation 0 after drain, got %v", got)
+	}
+}
+
+func TestHandleWebhook_ThrottlesWhenSaturated(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{}, 1)
+
+	s := &Server{
+		adapters: adapters.NewRegistry(),
+		logger:   NewLogger(),
+		metrics: &Metrics{
+			IncidentReceived: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_incident_received_total"}, []string{"provider", "status"}),
+		},
+	}
+	s.ingestion = newIngestionPool(config.IngestionConfig{Workers: 1, QueueSize: 1, RetryAfter: 5 * time.Second}, func(job ingestJob) {
+		started <- struct{}{}
+		<-release
+	}, ingestionGauges{depth: prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_ingestion_depth"})})
+
+	send := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		s.handleWebhook(w, httptest.NewRequest("POST", "/api/v1/webhooks/incidents?provider=datadog", strings.NewReader(`{}`)))
+		return w
+	}
+
+	// The worker takes the first webhook and the second fills the queue
+	if w := send(); w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", w.Code)
+	}
+	<-started
+	if w := send(); w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", w.Code)
+	}
+
+	w := send()
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 when saturated, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "5" {
+		t.Errorf("Retry-After = %q, want 5", got)
+	}
+	if got := testutil.ToFloat64(s.metrics.IncidentReceived.WithLabelValues("datadog", "throttled")); got != 1 {
+		t.Errorf("expected 1 throttled webhook, got %v", got)
+	}
+}
+
+func TestHandleWebhook_RejectsInvalidFields(t *testing.T) {
+	processed := make(chan struct{}, 1)
+	s := &Server{
+		adapters: adapters.NewRegistry(),
+		logger:   NewLogger(),
+		metrics: &Metrics{
+			IncidentReceived: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_incident_received_total"}, []string{"provider", "status"}),
+			WebhookFailures:  prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_webhook_failures_total"}, []string{"provider", "reason"}),
+		},
+	}
+	s.ingestion = newIngestionPool(config.IngestionConfig{Workers: 1, QueueSize: 1}, func(job ingestJob) {
+		processed <- struct{}{}
+	}, ingestionGauges{depth: prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_ingestion_depth"})})
+	defer func() { _ = s.ingestion.drain(context.Background()) }()
+
+	body := `{"id":"1","title":"connection refused","tags":["service:` + strings.Repeat("a", 300) + `"]}`
+	w := httptest.NewRecorder()
+	s.handleWebhook(w, httptest.NewRequest("POST", "/api/v1/webhooks/incidents?provider=datadog", strings.NewReader(body)))
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"field":"service_name"`) {
+		t.Errorf("expected the field at fault in the response, got %s", w.Body.String())
+	}
+	if got := testutil.ToFloat64(s.metrics.WebhookFailures.WithLabelValues("datadog", adapters.ReasonInvalidField)); got != 1 {
+		t.Errorf("expected one invalid_field failure, got %v", got)
+	}
+	select {
+	case <-processed:
+		t.Error("expected the invalid incident not to be queued")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
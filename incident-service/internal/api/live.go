@@ -0,0 +1,268 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+const (
+	// livePollInterval is how often a live tail checks for new events and
+	// status changes
+	livePollInterval = time.Second
+	// livePingInterval keeps idle live tails open through proxies
+	livePingInterval = 30 * time.Second
+	// liveWriteTimeout bounds sending a message to a live tail
+	liveWriteTimeout = 10 * time.Second
+	// liveLogBuffer is how many log entries a live tail may fall behind by
+	// before entries are dropped
+	liveLogBuffer = 64
+)
+
+// Live tail message types
+const (
+	liveEvent  = "event"
+	liveLog    = "log"
+	liveStatus = "status"
+)
+
+// LiveMessage is a message sent to a live tail of an incident
+type LiveMessage struct {
+	Type      string                `json:"type"`
+	Event     *models.IncidentEvent `json:"event,omitempty"`
+	Log       *LogEntry             `json:"log,omitempty"`
+	Status    models.IncidentStatus `json:"status,omitempty"`
+	UpdatedAt *time.Time            `json:"updated_at,omitempty"`
+}
+
+// liveUpgrader upgrades live tail requests to websockets. Only pages served
+// from the same host may open one with an API key; a live tail token is bound
+// to one incident and expires quickly, so pages served elsewhere, such as the
+// dashboard, may open one with a token.
+var liveUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool {
+		if tokened, _ := r.Context().Value(liveTokenContextKey{}).(bool); tokened {
+			return true
+		}
+		return sameOrigin(r)
+	},
+}
+
+// sameOrigin reports whether a websocket request comes from a page served by
+// the same host, as gorilla/websocket checks by default
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+// liveTails passes the log entries of incidents to the live tails following
+// them on this replica
+type liveTails struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan LogEntry]struct{}
+	done        chan struct{} // closed when the server shuts down
+}
+
+// subscribe returns the log entries written about an incident from now on,
+// and a function that stops them
+func (t *liveTails) subscribe(incidentID string) (<-chan LogEntry, func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.subscribers == nil {
+		t.subscribers = make(map[string]map[chan LogEntry]struct{})
+	}
+	if t.subscribers[incidentID] == nil {
+		t.subscribers[incidentID] = make(map[chan LogEntry]struct{})
+	}
+	entries := make(chan LogEntry, liveLogBuffer)
+	t.subscribers[incidentID][entries] = struct{}{}
+
+	return entries, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.subscribers[incidentID], entries)
+		if len(t.subscribers[incidentID]) == 0 {
+			delete(t.subscribers, incidentID)
+		}
+	}
+}
+
+// publish passes a log entry to the live tails of the incident it is about.
+// Entries are dropped for tails that have fallen behind rather than holding
+// up the logger.
+func (t *liveTails) publish(entry LogEntry) {
+	incidentID, _ := entry.Fields["incident_id"].(string)
+	if incidentID == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for entries := range t.subscribers[incidentID] {
+		select {
+		case entries <- entry:
+		default:
+		}
+	}
+}
+
+// stopped returns a channel that is closed when the server shuts down
+func (t *liveTails) stopped() <-chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.doneLocked()
+}
+
+// close ends every live tail
+func (t *liveTails) close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	done := t.doneLocked()
+	select {
+	case <-done:
+	default:
+		close(done)
+	}
+}
+
+func (t *liveTails) doneLocked() chan struct{} {
+	if t.done == nil {
+		t.done = make(chan struct{})
+	}
+	return t.done
+}
+
+// handleLiveIncident streams the progress of an incident over a websocket:
+// the events logged so far, then new events, status changes, and the log
+// entries written about the incident, until the client goes away. Events and
+// statuses are read from the database, so they include the work of every
+// replica; log entries only come from the replica serving the stream.
+func (s *Server) handleLiveIncident(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	incident, err := s.repository.WithContext(r.Context()).GetByID(id)
+	if err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+	events, err := s.repository.WithContext(r.Context()).GetEventsByIncidentID(id)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to get incident events", map[string]interface{}{
+			"error": err.Error(),
+			"id":    id,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	logs, unsubscribe := s.live.subscribe(id)
+	defer unsubscribe()
+
+	conn, err := liveUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // the upgrader has answered the request
+	}
+	defer conn.Close()
+
+	// Reading is needed to handle pings and to notice the client leaving
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	send := func(message LiveMessage) bool {
+		_ = conn.SetWriteDeadline(time.Now().Add(liveWriteTimeout))
+		return conn.WriteJSON(message) == nil
+	}
+	sendStatus := func(incident *models.Incident) bool {
+		updatedAt := incident.UpdatedAt
+		return send(LiveMessage{Type: liveStatus, Status: incident.Status, UpdatedAt: &updatedAt})
+	}
+
+	var lastEventID int64
+	for _, event := range events {
+		if !send(LiveMessage{Type: liveEvent, Event: event}) {
+			return
+		}
+		lastEventID = event.ID
+	}
+	if !sendStatus(incident) {
+		return
+	}
+	status := incident.Status
+
+	poll := time.NewTicker(livePollInterval)
+	defer poll.Stop()
+	ping := time.NewTicker(livePingInterval)
+	defer ping.Stop()
+
+	logger := s.loggerFrom(r.Context())
+	for {
+		select {
+		case <-gone:
+			return
+		case <-s.live.stopped():
+			_ = conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+				time.Now().Add(liveWriteTimeout))
+			return
+		case entry := <-logs:
+			if !send(LiveMessage{Type: liveLog, Log: &entry}) {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(liveWriteTimeout)); err != nil {
+				return
+			}
+		case <-poll.C:
+			events, err := s.repository.GetEventsAfter(id, lastEventID)
+			if err != nil {
+				logger.Warn("failed to poll incident events for live tail", map[string]interface{}{
+					"error": err.Error(),
+					"id":    id,
+				})
+				continue
+			}
+			for _, event := range events {
+				if !send(LiveMessage{Type: liveEvent, Event: event}) {
+					return
+				}
+				lastEventID = event.ID
+			}
+
+			incident, err := s.repository.GetByID(id)
+			if err != nil {
+				logger.Warn("failed to poll incident status for live tail", map[string]interface{}{
+					"error": err.Error(),
+					"id":    id,
+				})
+				continue
+			}
+			if incident.Status != status {
+				if !sendStatus(incident) {
+					return
+				}
+				status = incident.Status
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"bytes"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestLiveTails_LogEntries(t *testing.T) {
+	var tails liveTails
+	logger := NewLogger()
+	logger.logger = log.New(&bytes.Buffer{}, "", 0)
+	logger.setTap(tails.publish)
+
+	entries, unsubscribe := tails.subscribe("inc_1")
+	other, unsubscribeOther := tails.subscribe("inc_2")
+	defer unsubscribeOther()
+
+	logger.With(map[string]interface{}{"incident_id": "inc_1"}).Info("workflow dispatched", map[string]interface{}{
+		"token": "ghp_secret",
+	})
+	logger.Info("unrelated", nil)
+
+	select {
+	case entry := <-entries:
+		if entry.Message != "workflow dispatched" || entry.Fields["token"] != redacted {
+			t.Errorf("expected the incident's entry with secrets masked, got %+v", entry)
+		}
+	default:
+		t.Fatal("expected the entry passed to the incident's live tail")
+	}
+	if len(entries) != 0 || len(other) != 0 {
+		t.Error("expected entries of other incidents to be left out")
+	}
+
+	// A tail that falls behind misses entries rather than blocking the logger
+	for i := 0; i < liveLogBuffer+10; i++ {
+		logger.Info("progress", map[string]interface{}{"incident_id": "inc_1"})
+	}
+	if len(entries) != liveLogBuffer {
+		t.Errorf("expected %d buffered entries, got %d", liveLogBuffer, len(entries))
+	}
+
+	unsubscribe()
+	if _, ok := tails.subscribers["inc_1"]; ok {
+		t.Error("expected the incident forgotten once its last tail stopped")
+	}
+
+	tails.close()
+	tails.close()
+	select {
+	case <-tails.stopped():
+	default:
+		t.Error("expected live tails stopped after close")
+	}
+}
+
+func TestHandleLiveIncident(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	server := NewServer(&config.Config{Server: config.ServerConfig{Port: 8080}}, db, nil, nil, NewLogger())
+	repository := database.NewIncidentRepository(db)
+	incident := &models.Incident{
+		ID:           "test-incident-live",
+		ServiceName:  "checkout",
+		Repository:   "org/live-test",
+		ErrorMessage: "test error",
+		Status:       models.StatusPending,
+		Provider:     "test",
+	}
+	if err := repository.Create(incident); err != nil {
+		t.Fatalf("failed to create test incident: %v", err)
+	}
+	defer func() {
+		_, _ = db.Exec("DELETE FROM incidents WHERE id = 'test-incident-live'")
+	}()
+
+	ts := httptest.NewServer(server.Router())
+	defer ts.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/api/v1/incidents/"+incident.ID+"/live", nil)
+	if err != nil {
+		t.Fatalf("failed to open live tail: %v", err)
+	}
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+
+	// The events so far come first, then the current status
+	next := func() LiveMessage {
+		var message LiveMessage
+		if err := conn.ReadJSON(&message); err != nil {
+			t.Fatalf("failed to read live message: %v", err)
+		}
+		return message
+	}
+	message := next()
+	for message.Type == liveEvent {
+		message = next()
+	}
+	if message.Type != liveStatus || message.Status != models.StatusPending {
+		t.Fatalf("expected the current status, got %+v", message)
+	}
+
+	server.logger.Info("dispatching", map[string]interface{}{"incident_id": incident.ID})
+	if message := next(); message.Type != liveLog || message.Log.Message != "dispatching" {
+		t.Errorf("expected the incident's log entry, got %+v", message)
+	}
+
+	if err := repository.UpdateStatus(incident.ID, models.StatusWorkflowTriggered); err != nil {
+		t.Fatalf("failed to update status: %v", err)
+	}
+	message = next()
+	for message.Type == liveEvent {
+		message = next()
+	}
+	if message.Type != liveStatus || message.Status != models.StatusWorkflowTriggered {
+		t.Errorf("expected the status change, got %+v", message)
+	}
+}
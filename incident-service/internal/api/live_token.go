@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+// liveTokenTTL is how long a live tail token may be used to open a stream.
+// Streams opened with it stay open after it expires.
+const liveTokenTTL = time.Minute
+
+// liveTokenContextKey marks requests let in with a live tail token
+type liveTokenContextKey struct{}
+
+// liveToken signs access to the live tail of an incident with an API key
+// until expires. Browsers cannot set headers on websocket requests, so the
+// dashboard passes it in the query string instead of the key itself.
+func liveToken(apiKey *config.APIKey, incidentID string, expires time.Time) string {
+	return fmt.Sprintf("%d.%s.%s", expires.Unix(), liveSignature(apiKey, incidentID, expires.Unix()), apiKey.Name)
+}
+
+// liveSignature is the hex encoded HMAC-SHA256, keyed with the API key, of an
+// incident ID, the expiry of its token and the key's name
+func liveSignature(apiKey *config.APIKey, incidentID string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(apiKey.Key))
+	fmt.Fprintf(mac, "live\n%s\n%d\n%s", incidentID, expires, apiKey.Name)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyLiveToken returns the API key a live tail token of the incident was
+// issued for, if it has not expired at now
+func (s *Server) verifyLiveToken(incidentID, token string, now time.Time) (*config.APIKey, bool) {
+	expiry, rest, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, false
+	}
+	signature, name, ok := strings.Cut(rest, ".")
+	if !ok {
+		return nil, false
+	}
+	expires, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || now.Unix() >= expires {
+		return nil, false
+	}
+	for i, apiKey := range s.config.Server.Auth.APIKeys {
+		if apiKey.Name == name {
+			key := &s.config.Server.Auth.APIKeys[i]
+			return key, hmac.Equal([]byte(signature), []byte(liveSignature(key, incidentID, expires)))
+		}
+	}
+	return nil, false
+}
+
+// requireLiveToken lets a live tail in with a token from
+// handleCreateLiveToken, with the access of the API key it was issued for.
+// Requests without a token need an API key that can access the incident.
+func (s *Server) requireLiveToken(next http.Handler) http.Handler {
+	keyed := s.requireAPIKey(s.requireIncidentAccess(next))
+	tokened := s.requireIncidentAccess(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("token")
+		if token == "" || s.config == nil || !s.config.Server.Auth.Enabled() {
+			keyed.ServeHTTP(w, r)
+			return
+		}
+		apiKey, ok := s.verifyLiveToken(chi.URLParam(r, "id"), token, time.Now())
+		if !ok {
+			http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+			return
+		}
+		ctx := context.WithValue(r.Context(), principalContextKey{}, s.keyPrincipal(apiKey))
+		ctx = context.WithValue(ctx, liveTokenContextKey{}, true)
+		tokened.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// handleCreateLiveToken issues a token that opens the live tail of an
+// incident with the caller's API key. The token is empty when no keys are
+// configured, as none is needed.
+func (s *Server) handleCreateLiveToken(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	expires := time.Now().Add(liveTokenTTL)
+
+	token := ""
+	if s.config != nil && s.config.Server.Auth.Enabled() {
+		apiKey, ok := s.apiKey(requestAPIKey(r))
+		if !ok {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		token = liveToken(apiKey, id, expires)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"token":      token,
+		"expires_at": expires.UTC(),
+	})
+}
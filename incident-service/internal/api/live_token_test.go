@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+func TestVerifyLiveToken(t *testing.T) {
+	server := newAuthTestServer(config.AuthConfig{APIKeys: []config.APIKey{
+		{Name: "dashboard", Key: "dashboard-secret"},
+		{Name: "ops-cli", Key: "cli-secret"},
+	}})
+	dashboard := &server.config.Server.Auth.APIKeys[0]
+	now := time.Now()
+
+	tests := []struct {
+		name  string
+		token string
+		want  bool
+	}{
+		{"token", liveToken(dashboard, "inc_1", now.Add(time.Minute)), true},
+		{"expired token", liveToken(dashboard, "inc_1", now.Add(-time.Second)), false},
+		{"token of another incident", liveToken(dashboard, "inc_2", now.Add(time.Minute)), false},
+		{"token of an unknown key", liveToken(&config.APIKey{Name: "revoked", Key: "old-secret"}, "inc_1", now.Add(time.Minute)), false},
+		{"token signed with another key", liveToken(&config.APIKey{Name: "ops-cli", Key: "dashboard-secret"}, "inc_1", now.Add(time.Minute)), false},
+		{"malformed token", "not-a-token", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKey, ok := server.verifyLiveToken("inc_1", tt.token, now)
+			if ok != tt.want {
+				t.Fatalf("verifyLiveToken() = %v, want %v", ok, tt.want)
+			}
+			if ok && apiKey.Name != "dashboard" {
+				t.Errorf("expected the token's key, got %s", apiKey.Name)
+			}
+		})
+	}
+}
+
+func TestRequireLiveToken(t *testing.T) {
+	server := newAuthTestServer(config.AuthConfig{APIKeys: []config.APIKey{{Name: "dashboard", Key: "dashboard-secret"}}})
+
+	var reached *principal
+	var tokened bool
+	router := chi.NewRouter()
+	router.With(server.requireLiveToken).Get("/api/v1/incidents/{id}/live", func(w http.ResponseWriter, r *http.Request) {
+		reached = principalFrom(r.Context())
+		tokened, _ = r.Context().Value(liveTokenContextKey{}).(bool)
+	})
+
+	// The token endpoint issues tokens to API keys only
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/incidents/inc_1/live/token", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status %d without a key, got %d", http.StatusUnauthorized, w.Code)
+	}
+	req := httptest.NewRequest("POST", "/api/v1/incidents/inc_1/live/token", nil)
+	req.Header.Set("X-API-Key", "dashboard-secret")
+	w = httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Token == "" || time.Until(response.ExpiresAt) > liveTokenTTL {
+		t.Fatalf("expected a short-lived token, got %+v", response)
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		want   int
+	}{
+		{"no credentials", "/api/v1/incidents/inc_1/live", http.StatusUnauthorized},
+		{"token of another incident", "/api/v1/incidents/inc_2/live?token=" + url.QueryEscape(response.Token), http.StatusUnauthorized},
+		{"token", "/api/v1/incidents/inc_1/live?token=" + url.QueryEscape(response.Token), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reached = nil
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", tt.target, nil))
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, w.Code)
+			}
+			if tt.want == http.StatusOK && (reached == nil || reached.name != "dashboard" || !tokened) {
+				t.Errorf("expected the stream opened as the token's key, got %+v", reached)
+			}
+		})
+	}
+
+	// Without keys configured no token is needed, and none is issued
+	open := newAuthTestServer(config.AuthConfig{})
+	w = httptest.NewRecorder()
+	open.Router().ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/incidents/inc_1/live/token", nil))
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil || response.Token != "" {
+		t.Errorf("expected an empty token without keys, got %+v (%v)", response, err)
+	}
+}
+
+func TestLiveUpgrader_CheckOrigin(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://incidents.example.com/api/v1/incidents/inc_1/live", nil)
+	req.Header.Set("Origin", "https://dashboard.example.com")
+	if liveUpgrader.CheckOrigin(req) {
+		t.Error("expected other origins turned away without a token")
+	}
+	req.Header.Set("Origin", "http://incidents.example.com")
+	if !liveUpgrader.CheckOrigin(req) {
+		t.Error("expected the service's own origin let in")
+	}
+	req.Header.Set("Origin", "https://dashboard.example.com")
+	req = req.WithContext(context.WithValue(req.Context(), liveTokenContextKey{}, true))
+	if !liveUpgrader.CheckOrigin(req) {
+		t.Error("expected other origins let in with a token")
+	}
+}
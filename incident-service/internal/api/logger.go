@@ -1,10 +1,20 @@
 package api
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
 )
 
 // LogLevel represents the severity of a log message
@@ -17,18 +27,189 @@ const (
 	LogLevelError LogLevel = "error"
 )
 
+// logLevelRank orders levels so messages below the minimum can be dropped
+var logLevelRank = map[LogLevel]int{
+	LogLevelDebug: 0,
+	LogLevelInfo:  1,
+	LogLevelWarn:  2,
+	LogLevelError: 3,
+}
+
+// LogFormat selects how log entries are written
+type LogFormat string
+
+const (
+	LogFormatJSON    LogFormat = "json"
+	LogFormatConsole LogFormat = "console"
+)
+
 // Logger provides structured logging
 type Logger struct {
-	logger *log.Logger
+	logger   *log.Logger
+	minLevel LogLevel
+	format   LogFormat
+	closer   io.Closer              // set when writing to a file
+	fields   map[string]interface{} // attached to every entry, see With
+	reporter ErrorReporter          // receives error entries, see SetErrorReporter
+	redactor *redactor              // masks secrets in every entry
+	tap      func(entry LogEntry)   // receives every entry written, see setTap
 }
 
-// NewLogger creates a new structured logger
+// ErrorReporter forwards error log entries to an external error tracker
+type ErrorReporter func(message string, fields map[string]interface{})
+
+// NewLogger creates a new structured logger that writes JSON to stdout at info level and above
 func NewLogger() *Logger {
 	return &Logger{
-		logger: log.New(os.Stdout, "", 0),
+		logger:   log.New(os.Stdout, "", 0),
+		minLevel: LogLevelInfo,
+		format:   LogFormatJSON,
+		redactor: newRedactor(nil),
+	}
+}
+
+// NewLoggerFromConfig creates a structured logger with the configured level, format, and output
+func NewLoggerFromConfig(cfg config.LoggingConfig) (*Logger, error) {
+	logger := NewLogger()
+	logger.redactor = newRedactor(cfg.RedactKeys)
+
+	if cfg.Level != "" {
+		logger.minLevel = LogLevel(strings.ToLower(cfg.Level))
+		if _, ok := logLevelRank[logger.minLevel]; !ok {
+			return nil, fmt.Errorf("unknown log level '%s'", cfg.Level)
+		}
+	}
+
+	if cfg.Format != "" {
+		logger.format = LogFormat(strings.ToLower(cfg.Format))
+		if logger.format != LogFormatJSON && logger.format != LogFormatConsole {
+			return nil, fmt.Errorf("unknown log format '%s'", cfg.Format)
+		}
+	}
+
+	switch cfg.Output {
+	case "", "stdout":
+	case "stderr":
+		logger.logger = log.New(os.Stderr, "", 0)
+	default:
+		file, err := os.OpenFile(cfg.Output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		logger.logger = log.New(file, "", 0)
+		logger.closer = file
+	}
+
+	return logger, nil
+}
+
+// Close releases the log file, if the logger writes to one
+func (l *Logger) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
+
+// SetErrorReporter forwards every error entry to reporter. Loggers derived
+// with With afterwards share it.
+func (l *Logger) SetErrorReporter(reporter ErrorReporter) {
+	l.reporter = reporter
+}
+
+// setTap passes every entry written afterwards to tap, secrets masked.
+// Loggers derived with With afterwards share it.
+func (l *Logger) setTap(tap func(entry LogEntry)) {
+	l.tap = tap
+}
+
+// withoutReporter returns a logger that does not forward error entries, for
+// errors that have already been reported another way
+func (l *Logger) withoutReporter() *Logger {
+	child := *l
+	child.reporter = nil
+	child.closer = nil
+	return &child
+}
+
+// With returns a logger that adds fields to every entry it writes.
+// Fields passed to individual calls take precedence over these.
+func (l *Logger) With(fields map[string]interface{}) *Logger {
+	child := *l
+	child.fields = mergeFields(l.fields, fields)
+	child.closer = nil // only the root logger owns the file
+	return &child
+}
+
+// mergeFields returns a new map with the fields of base overridden by extra
+func mergeFields(base, extra map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(extra))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range extra {
+		merged[key] = value
+	}
+	return merged
+}
+
+// loggerContextKey is the context key for request-scoped loggers
+type loggerContextKey struct{}
+
+// ContextWithLogger returns a copy of ctx carrying logger
+func ContextWithLogger(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// LoggerFromContext returns the logger carried by ctx, or fallback if there is none
+func LoggerFromContext(ctx context.Context, fallback *Logger) *Logger {
+	if logger, ok := ctx.Value(loggerContextKey{}).(*Logger); ok {
+		return logger
+	}
+	return fallback
+}
+
+// requestIDHeader carries the request ID to and from callers
+const requestIDHeader = "X-Request-ID"
+
+// requestIDContextKey is the context key for the request ID
+type requestIDContextKey struct{}
+
+// requestIDFrom returns the ID of the request ctx belongs to, or "" outside a request
+func requestIDFrom(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// requestLoggerMiddleware attaches a logger tagged with the request ID to each
+// request context. Callers may supply their own ID in the X-Request-ID header.
+func requestLoggerMiddleware(logger *Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(requestIDHeader)
+			if requestID == "" {
+				requestID = newRequestID()
+			}
+			w.Header().Set(requestIDHeader, requestID)
+
+			ctx := context.WithValue(r.Context(), requestIDContextKey{}, requestID)
+			ctx = ContextWithLogger(ctx, logger.With(map[string]interface{}{
+				"request_id": requestID,
+			}))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
 	}
 }
 
+// newRequestID returns a random 16 character hex ID
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
 // LogEntry represents a structured log entry
 type LogEntry struct {
 	Timestamp string                 `json:"timestamp"`
@@ -37,8 +218,31 @@ type LogEntry struct {
 	Fields    map[string]interface{} `json:"fields,omitempty"`
 }
 
+// enabled reports whether messages at level should be written
+func (l *Logger) enabled(level LogLevel) bool {
+	return logLevelRank[level] >= logLevelRank[l.minLevel]
+}
+
 // log writes a structured log entry
 func (l *Logger) log(level LogLevel, message string, fields map[string]interface{}) {
+	if !l.enabled(level) {
+		return
+	}
+
+	if len(l.fields) > 0 {
+		fields = mergeFields(l.fields, fields)
+	}
+
+	// Secrets are masked before the entry goes anywhere, the error reporter included
+	if l.redactor != nil {
+		message = l.redactor.text(message)
+		fields = l.redactor.fields(fields)
+	}
+
+	if level == LogLevelError && l.reporter != nil {
+		l.reporter(message, fields)
+	}
+
 	entry := LogEntry{
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 		Level:     level,
@@ -46,6 +250,15 @@ func (l *Logger) log(level LogLevel, message string, fields map[string]interface
 		Fields:    fields,
 	}
 
+	if l.tap != nil {
+		l.tap(entry)
+	}
+
+	if l.format == LogFormatConsole {
+		l.logger.Println(formatConsole(entry))
+		return
+	}
+
 	data, err := json.Marshal(entry)
 	if err != nil {
 		l.logger.Printf("failed to marshal log entry: %v", err)
@@ -55,6 +268,28 @@ func (l *Logger) log(level LogLevel, message string, fields map[string]interface
 	l.logger.Println(string(data))
 }
 
+// formatConsole renders an entry as a single human-readable line with sorted key=value fields
+func formatConsole(entry LogEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %-5s %s", entry.Timestamp, strings.ToUpper(string(entry.Level)), entry.Message)
+
+	keys := make([]string, 0, len(entry.Fields))
+	for key := range entry.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := fmt.Sprint(entry.Fields[key])
+		if strings.ContainsAny(value, " \t\n\"") {
+			value = fmt.Sprintf("%q", value)
+		}
+		fmt.Fprintf(&b, " %s=%s", key, value)
+	}
+
+	return b.String()
+}
+
 // Debug logs a debug message
 func (l *Logger) Debug(message string, fields map[string]interface{}) {
 	l.log(LogLevelDebug, message, fields)
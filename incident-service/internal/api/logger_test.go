@@ -0,0 +1,151 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+func TestLogger_MinLevel(t *testing.T) {
+	logger, err := NewLoggerFromConfig(config.LoggingConfig{Level: "WARN"})
+	if err != nil {
+		t.Fatalf("NewLoggerFromConfig() error = %v", err)
+	}
+	var buf bytes.Buffer
+	logger.logger = log.New(&buf, "", 0)
+
+	logger.Debug("debug message", nil)
+	logger.Info("info message", nil)
+	logger.Warn("warn message", nil)
+	logger.Error("error message", nil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d: %q", len(lines), buf.String())
+	}
+
+	var entry LogEntry
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("expected JSON log line: %v", err)
+	}
+	if entry.Level != LogLevelWarn || entry.Message != "warn message" {
+		t.Errorf("unexpected first entry: %+v", entry)
+	}
+}
+
+func TestLogger_ConsoleFormat(t *testing.T) {
+	logger, err := NewLoggerFromConfig(config.LoggingConfig{Format: "console"})
+	if err != nil {
+		t.Fatalf("NewLoggerFromConfig() error = %v", err)
+	}
+	var buf bytes.Buffer
+	logger.logger = log.New(&buf, "", 0)
+
+	logger.Error("dispatch failed", map[string]interface{}{
+		"incident_id": "inc-1",
+		"error":       "connection refused",
+	})
+
+	line := strings.TrimSpace(buf.String())
+	if !strings.Contains(line, `ERROR dispatch failed error="connection refused" incident_id=inc-1`) {
+		t.Errorf("unexpected console line: %s", line)
+	}
+}
+
+func TestLogger_FileOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "service.log")
+
+	logger, err := NewLoggerFromConfig(config.LoggingConfig{Output: path})
+	if err != nil {
+		t.Fatalf("NewLoggerFromConfig() error = %v", err)
+	}
+	logger.Info("written to file", nil)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	if !strings.Contains(string(data), "written to file") {
+		t.Errorf("expected log file to contain message, got %q", string(data))
+	}
+}
+
+func TestNewLoggerFromConfig_Invalid(t *testing.T) {
+	if _, err := NewLoggerFromConfig(config.LoggingConfig{Level: "verbose"}); err == nil {
+		t.Error("expected error for unknown level")
+	}
+	if _, err := NewLoggerFromConfig(config.LoggingConfig{Format: "xml"}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestLogger_With(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger()
+	logger.logger = log.New(&buf, "", 0)
+
+	scoped := logger.With(map[string]interface{}{"incident_id": "inc-1", "provider": "sentry"})
+	scoped.With(map[string]interface{}{"repository": "org/repo"}).Info("stored", map[string]interface{}{
+		"provider": "datadog",
+	})
+	logger.Info("unscoped", nil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d", len(lines))
+	}
+
+	var entry LogEntry
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("expected JSON log line: %v", err)
+	}
+	expected := map[string]interface{}{"incident_id": "inc-1", "provider": "datadog", "repository": "org/repo"}
+	for key, value := range expected {
+		if entry.Fields[key] != value {
+			t.Errorf("expected field %s=%v, got %v", key, value, entry.Fields[key])
+		}
+	}
+
+	if strings.Contains(lines[1], "incident_id") {
+		t.Errorf("expected parent logger to be unaffected by With, got %s", lines[1])
+	}
+}
+
+func TestRequestLoggerMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger()
+	logger.logger = log.New(&buf, "", 0)
+
+	handler := requestLoggerMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		LoggerFromContext(r.Context(), nil).Info("handled", nil)
+	}))
+
+	req := httptest.NewRequest("GET", "/api/v1/incidents", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Header().Get("X-Request-ID"); got != "req-123" {
+		t.Errorf("expected request ID to be echoed, got %q", got)
+	}
+	if !strings.Contains(buf.String(), `"request_id":"req-123"`) {
+		t.Errorf("expected request_id field in log line, got %s", buf.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/incidents", nil))
+	if len(w.Header().Get("X-Request-ID")) != 16 {
+		t.Errorf("expected generated request ID, got %q", w.Header().Get("X-Request-ID"))
+	}
+}
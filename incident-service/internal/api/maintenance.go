@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// deferForMaintenance holds back the incident's remediation while a
+// maintenance window covering its service is open, and reports whether it
+// did. The maintenance worker dispatches it once the window closes.
+func (s *Server) deferForMaintenance(ctx context.Context, inc *models.Incident, logger *Logger) bool {
+	if s.config == nil || !s.config.Maintenance.Enabled {
+		return false
+	}
+
+	window, until := s.config.Maintenance.ActiveWindow(inc.ServiceName, time.Now())
+	if window == nil {
+		return false
+	}
+
+	repository := s.repository.WithContext(ctx)
+	inc.DeferredUntil = &until
+	if err := repository.Update(inc); err != nil {
+		logger.Error("failed to record deferred remediation", map[string]interface{}{
+			"error":  err.Error(),
+			"window": window.Name,
+		})
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: inc.ID,
+		EventType:  models.EventRemediationDeferred,
+		EventData: map[string]interface{}{
+			"window":         window.Name,
+			"deferred_until": until,
+		},
+	}
+	if err := repository.LogEvent(event); err != nil {
+		logger.Error("failed to log remediation deferred event", map[string]interface{}{
+			"error":  err.Error(),
+			"window": window.Name,
+		})
+	}
+
+	logger.Info("remediation deferred by maintenance window", map[string]interface{}{
+		"window":         window.Name,
+		"deferred_until": until,
+	})
+	s.metrics.RecordRemediationDeferred(window.Name)
+
+	return true
+}
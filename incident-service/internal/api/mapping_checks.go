@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// mappingCheckTimeout bounds the check of each repository a service is
+// remediated in
+const mappingCheckTimeout = 10 * time.Second
+
+// Service mapping check statuses
+const (
+	mappingValid     = "valid"
+	mappingInvalid   = "invalid"   // dispatches to the repository would fail
+	mappingUnchecked = "unchecked" // GitHub could not be asked
+)
+
+// ServiceMappingStatus is the outcome of checking that a repository a
+// service is remediated in exists and has the branch and the workflow
+type ServiceMappingStatus struct {
+	ServiceName string    `json:"service_name"`
+	Repository  string    `json:"repository"`
+	Branch      string    `json:"branch"`
+	Status      string    `json:"status"`
+	Problems    []string  `json:"problems,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	CheckedAt   time.Time `json:"checked_at"`
+}
+
+// warning describes an invalid mapping for readiness
+func (s ServiceMappingStatus) warning() string {
+	return fmt.Sprintf("service mapping %s (%s@%s): %s", s.ServiceName, s.Repository, s.Branch, strings.Join(s.Problems, ", "))
+}
+
+// mappingHealth keeps the latest check of the service mappings for readiness
+type mappingHealth struct {
+	mu       sync.RWMutex
+	statuses []ServiceMappingStatus
+}
+
+func (h *mappingHealth) set(statuses []ServiceMappingStatus) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.statuses = statuses
+}
+
+func (h *mappingHealth) get() []ServiceMappingStatus {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.statuses
+}
+
+// CheckServiceMappings checks through the GitHub API that every repository a
+// configured service is remediated in exists, and that its branch and the
+// remediation workflow are present, and logs a warning for each problem. The
+// result is reported by readiness until the next check. Invalid mappings do
+// not stop the service, but their dispatches fail until they are fixed.
+func (s *Server) CheckServiceMappings(ctx context.Context) []ServiceMappingStatus {
+	statuses := s.checkServiceMappings(ctx)
+	for _, status := range statuses {
+		switch status.Status {
+		case mappingInvalid:
+			s.logger.Warn("service mapping cannot be remediated", map[string]interface{}{
+				"service_name": status.ServiceName,
+				"repository":   status.Repository,
+				"branch":       status.Branch,
+				"problems":     status.Problems,
+			})
+		case mappingUnchecked:
+			s.logger.Warn("failed to check service mapping", map[string]interface{}{
+				"service_name": status.ServiceName,
+				"repository":   status.Repository,
+				"error":        status.Error,
+			})
+		}
+	}
+	return statuses
+}
+
+// checkServiceMappings checks the repositories of the configured service
+// mappings and records the result. Each repository and branch is asked about
+// once, however many services share it.
+func (s *Server) checkServiceMappings(ctx context.Context) []ServiceMappingStatus {
+	statuses := []ServiceMappingStatus{}
+	if s.config == nil || s.githubClient == nil {
+		return statuses
+	}
+
+	checked := make(map[string]ServiceMappingStatus)
+	for i := range s.config.ServiceMappings {
+		mapping := &s.config.ServiceMappings[i]
+		for _, target := range mapping.Targets() {
+			key := target.Repository + "@" + target.Branch
+			status, ok := checked[key]
+			if !ok {
+				status = s.checkMappingTarget(ctx, target.Repository, target.Branch)
+				checked[key] = status
+			}
+			status.ServiceName = mapping.ServiceName
+			statuses = append(statuses, status)
+		}
+	}
+
+	s.mappings.set(statuses)
+	return statuses
+}
+
+// checkMappingTarget checks that the remediation workflow can be dispatched
+// on a branch of a repository
+func (s *Server) checkMappingTarget(ctx context.Context, repository, branch string) ServiceMappingStatus {
+	ctx, cancel := context.WithTimeout(ctx, mappingCheckTimeout)
+	defer cancel()
+
+	status := ServiceMappingStatus{Repository: repository, Branch: branch, Status: mappingValid}
+	problems, err := s.githubClient.CheckTarget(ctx, repository, branch)
+	status.CheckedAt = time.Now().UTC()
+	switch {
+	case err != nil:
+		status.Status = mappingUnchecked
+		status.Error = err.Error()
+	case len(problems) > 0:
+		status.Status = mappingInvalid
+		status.Problems = problems
+	}
+	return status
+}
+
+// handleCheckServiceMappings checks the configured service mappings against
+// GitHub and returns the result
+func (s *Server) handleCheckServiceMappings(w http.ResponseWriter, r *http.Request) {
+	statuses := s.checkServiceMappings(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"mappings": statuses,
+	})
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+)
+
+func TestCheckServiceMappings(t *testing.T) {
+	var repositoryRequests atomic.Int32
+	gh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/org/api":
+			repositoryRequests.Add(1)
+			_, _ = w.Write([]byte(`{"full_name": "org/api", "default_branch": "main"}`))
+		case "/repos/org/api/branches/main", "/repos/org/api/contents/.github/workflows/remediate.yml":
+			_, _ = w.Write([]byte(`{}`))
+		case "/repos/org/api/actions/workflows/remediate.yml":
+			_, _ = w.Write([]byte(`{"id": 1, "path": ".github/workflows/remediate.yml", "state": "active"}`))
+		default:
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+		}
+	}))
+	defer gh.Close()
+
+	s := &Server{
+		config: &config.Config{ServiceMappings: []config.ServiceMapping{
+			{ServiceName: "api", Repository: "org/api", Branch: "main"},
+			{ServiceName: "worker", Repository: "org/api", Branch: "main"},
+			{ServiceName: "billing", Repository: "org/api", Branch: "release"},
+		}},
+		githubClient: github.NewClient(gh.URL, "token", "remediate.yml", 1),
+		logger:       NewLogger(),
+	}
+
+	statuses := s.CheckServiceMappings(context.Background())
+	if len(statuses) != 3 {
+		t.Fatalf("expected a status per mapping, got %+v", statuses)
+	}
+	if statuses[0].Status != mappingValid || statuses[1].Status != mappingValid || statuses[1].ServiceName != "worker" {
+		t.Errorf("expected the api and worker mappings valid, got %+v", statuses[:2])
+	}
+	if statuses[2].Status != mappingInvalid || len(statuses[2].Problems) != 1 || statuses[2].Problems[0] != "branch release not found" {
+		t.Errorf("expected the missing branch reported, got %+v", statuses[2])
+	}
+	if n := repositoryRequests.Load(); n != 2 {
+		t.Errorf("expected each repository and branch checked once, got %d repository requests", n)
+	}
+
+	// Readiness keeps reporting the invalid mapping as a warning
+	w := httptest.NewRecorder()
+	s.handleReadyz(w, httptest.NewRequest("GET", "/readyz", nil))
+	var response ReadinessResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Status != "ready" || len(response.Warnings) != 1 || !strings.Contains(response.Warnings[0], "service mapping billing (org/api@release)") {
+		t.Errorf("expected ready with a warning about the billing mapping, got %+v", response)
+	}
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/mcp"
+)
+
+// mcpCheckTimeout bounds the health check of each MCP server
+const mcpCheckTimeout = 5 * time.Second
+
+// MCP server health statuses
+const (
+	mcpHealthy     = "healthy"
+	mcpUnreachable = "unreachable"
+	mcpUnchecked   = "unchecked" // servers the service does not query itself, such as stdio servers run by the workflow
+)
+
+// MCPServerStatus is the health of a configured MCP server
+type MCPServerStatus struct {
+	Name            string     `json:"name"`
+	Type            string     `json:"type"`
+	URL             string     `json:"url,omitempty"`
+	Status          string     `json:"status"`
+	Error           string     `json:"error,omitempty"`
+	ServerName      string     `json:"server_name,omitempty"`
+	ServerVersion   string     `json:"server_version,omitempty"`
+	ProtocolVersion string     `json:"protocol_version,omitempty"`
+	LatencyMs       int64      `json:"latency_ms,omitempty"`
+	CheckedAt       *time.Time `json:"checked_at,omitempty"`
+}
+
+// mcpHealth keeps the latest health check of the MCP servers for readiness
+type mcpHealth struct {
+	mu       sync.RWMutex
+	statuses []MCPServerStatus
+}
+
+func (h *mcpHealth) set(statuses []MCPServerStatus) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.statuses = statuses
+}
+
+func (h *mcpHealth) get() []MCPServerStatus {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.statuses
+}
+
+// CheckMCPServers checks that every http MCP server answers the MCP handshake,
+// and logs a warning for each that does not. The result is reported by
+// readiness until the next check. Unreachable servers do not stop the service,
+// since enrichment goes ahead without them.
+func (s *Server) CheckMCPServers(ctx context.Context) []MCPServerStatus {
+	statuses := s.checkMCPServers(ctx)
+	for _, status := range statuses {
+		if status.Status == mcpUnreachable {
+			s.logger.Warn("mcp server unreachable", map[string]interface{}{
+				"mcp_server": status.Name,
+				"url":        status.URL,
+				"error":      status.Error,
+			})
+		}
+	}
+	return statuses
+}
+
+// checkMCPServers checks the configured servers in parallel and records the result
+func (s *Server) checkMCPServers(ctx context.Context) []MCPServerStatus {
+	statuses := make([]MCPServerStatus, len(s.config.MCPServers))
+	var wg sync.WaitGroup
+	for i, server := range s.config.MCPServers {
+		wg.Add(1)
+		go func(i int, server config.MCPServerConfig) {
+			defer wg.Done()
+			statuses[i] = checkMCPServer(ctx, server)
+		}(i, server)
+	}
+	wg.Wait()
+
+	s.mcpHealth.set(statuses)
+	return statuses
+}
+
+// checkMCPServer runs the MCP handshake against an http server
+func checkMCPServer(ctx context.Context, server config.MCPServerConfig) MCPServerStatus {
+	status := MCPServerStatus{Name: server.Name, Type: server.Type, Status: mcpUnchecked}
+	if server.Type != config.MCPTypeHTTP {
+		return status
+	}
+	status.URL = server.URL()
+
+	ctx, cancel := context.WithTimeout(ctx, mcpCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	info, err := mcp.NewClient(server.URL(), server.Token()).Initialize(ctx)
+	checkedAt := time.Now().UTC()
+	status.CheckedAt = &checkedAt
+	status.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		status.Status = mcpUnreachable
+		status.Error = err.Error()
+		return status
+	}
+
+	status.Status = mcpHealthy
+	status.ServerName = info.Name
+	status.ServerVersion = info.Version
+	status.ProtocolVersion = info.ProtocolVersion
+	return status
+}
+
+// handleListMCPServers checks the configured MCP servers and returns their health
+func (s *Server) handleListMCPServers(w http.ResponseWriter, r *http.Request) {
+	statuses := s.checkMCPServers(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"servers": statuses,
+	})
+}
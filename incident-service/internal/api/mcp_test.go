@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+func TestHandleListMCPServers(t *testing.T) {
+	mcpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg struct {
+			ID     *int64 `json:"id"`
+			Method string `json:"method"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+		if msg.ID == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"protocolVersion":"2025-03-26","serverInfo":{"name":"logs","version":"1.4.0"}}}`, *msg.ID)
+	}))
+	defer mcpServer.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+	defer down.Close()
+
+	server := &Server{
+		config: &config.Config{MCPServers: []config.MCPServerConfig{
+			{Name: "logs", Type: config.MCPTypeHTTP, Config: map[string]string{"url": mcpServer.URL, "token": "secret"}},
+			{Name: "metrics", Type: config.MCPTypeHTTP, Config: map[string]string{"url": down.URL}},
+			{Name: "sentry", Type: "stdio"},
+		}},
+		logger: NewLogger(),
+	}
+
+	w := httptest.NewRecorder()
+	server.handleListMCPServers(w, httptest.NewRequest("GET", "/api/v1/mcp-servers", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if strings.Contains(w.Body.String(), "secret") {
+		t.Error("expected server tokens to stay out of the response")
+	}
+
+	var response struct {
+		Servers []MCPServerStatus `json:"servers"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Servers) != 3 {
+		t.Fatalf("expected 3 servers, got %d", len(response.Servers))
+	}
+	if logs := response.Servers[0]; logs.Status != mcpHealthy || logs.ServerName != "logs" || logs.ServerVersion != "1.4.0" || logs.CheckedAt == nil {
+		t.Errorf("unexpected status of a healthy server: %+v", logs)
+	}
+	if metrics := response.Servers[1]; metrics.Status != mcpUnreachable || !strings.Contains(metrics.Error, "502") {
+		t.Errorf("unexpected status of a failing server: %+v", metrics)
+	}
+	if sentry := response.Servers[2]; sentry.Status != mcpUnchecked || sentry.CheckedAt != nil {
+		t.Errorf("expected stdio servers to be left unchecked, got %+v", sentry)
+	}
+
+	// Readiness reports the last check without failing
+	w = httptest.NewRecorder()
+	server.handleReadyz(w, httptest.NewRequest("GET", "/readyz", nil))
+	var readiness ReadinessResponse
+	if err := json.NewDecoder(w.Body).Decode(&readiness); err != nil {
+		t.Fatalf("failed to decode readiness: %v", err)
+	}
+	if w.Code != http.StatusOK || readiness.Status != "ready" {
+		t.Errorf("expected unreachable MCP servers to leave the service ready, got %d %s", w.Code, readiness.Status)
+	}
+	if len(readiness.MCPServers) != 3 || len(readiness.Warnings) != 1 || !strings.HasPrefix(readiness.Warnings[0], "mcp server metrics:") {
+		t.Errorf("unexpected readiness detail: %+v", readiness)
+	}
+}
+
+func TestCheckMCPServers_NoServers(t *testing.T) {
+	server := &Server{config: &config.Config{}, logger: NewLogger()}
+	if statuses := server.CheckMCPServers(context.Background()); len(statuses) != 0 {
+		t.Errorf("expected no statuses, got %+v", statuses)
+	}
+}
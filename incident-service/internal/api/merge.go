@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// MergeIncidentRequest is the body of a request to merge an incident into another
+type MergeIncidentRequest struct {
+	TargetID string `json:"target_id"`
+	Reason   string `json:"reason,omitempty"` // e.g. "same outage reported by Grafana"
+}
+
+// handleMergeIncident merges an incident into a target incident, for cleaning
+// up split alerts from several providers covering the same outage. The
+// target takes over the incident's events, provider data and occurrences,
+// and the incident is closed as a duplicate of the target. Scoped API keys
+// can only merge into incidents of their own teams.
+func (s *Server) handleMergeIncident(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var payload MergeIncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if payload.TargetID == "" {
+		http.Error(w, "target_id is required", http.StatusBadRequest)
+		return
+	}
+	if payload.TargetID == id {
+		http.Error(w, "an incident cannot be merged into itself", http.StatusBadRequest)
+		return
+	}
+
+	logger := s.loggerFrom(r.Context()).With(map[string]interface{}{
+		"incident_id": id,
+		"target_id":   payload.TargetID,
+	})
+
+	repository := s.repository.WithContext(r.Context())
+	source, err := repository.GetByID(id)
+	if err != nil {
+		http.Error(w, "incident not found: "+id, http.StatusNotFound)
+		return
+	}
+	target, err := repository.GetByID(payload.TargetID)
+	if err != nil || !principalFrom(r.Context()).canAccess(target) {
+		http.Error(w, "incident not found: "+payload.TargetID, http.StatusNotFound)
+		return
+	}
+
+	if source.TenantID != target.TenantID {
+		http.Error(w, "incidents of different tenants cannot be merged", http.StatusBadRequest)
+		return
+	}
+	if source.DuplicateOf != nil {
+		http.Error(w, fmt.Sprintf("incident is already merged into %s", *source.DuplicateOf), http.StatusConflict)
+		return
+	}
+	if target.DuplicateOf != nil {
+		http.Error(w, fmt.Sprintf("target incident is merged into %s; merge into that incident instead", *target.DuplicateOf), http.StatusConflict)
+		return
+	}
+	// A running workflow would report back against the merged incident
+	if source.Status == models.StatusWorkflowTriggered || source.Status == models.StatusInProgress {
+		http.Error(w, "cannot merge an incident while its remediation is running", http.StatusConflict)
+		return
+	}
+
+	before := incidentAuditState(source)
+	if err := repository.MergeIncidents(source.ID, target.ID); err != nil {
+		logger.Error("failed to merge incidents", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// The duplicate must not take a dispatch slot once it is merged
+	if source.Repository != "" && s.githubClient != nil {
+		s.RemoveQueued(source.Repository, source.ID)
+		for _, dispatchTarget := range s.fanOutTargets(source) {
+			s.RemoveQueued(dispatchTarget.Repository, source.ID)
+		}
+	}
+
+	// The source's earlier events now live on the target's timeline
+	events := []*models.IncidentEvent{
+		{
+			IncidentID: target.ID,
+			EventType:  models.EventIncidentMerged,
+			EventData: map[string]interface{}{
+				"merged_incident_id": source.ID,
+				"provider":           source.Provider,
+			},
+		},
+		{
+			IncidentID: source.ID,
+			EventType:  models.EventIncidentMerged,
+			EventData: map[string]interface{}{
+				"merged_into": target.ID,
+			},
+		},
+	}
+	for _, event := range events {
+		if payload.Reason != "" {
+			event.EventData["reason"] = payload.Reason
+		}
+		if err := repository.LogEvent(event); err != nil {
+			logger.Error("failed to log merge event", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	source.Status = models.StatusNoFixNeeded
+	source.DuplicateOf = &target.ID
+	source.NextRetryAt = nil
+	after := incidentAuditState(source)
+	if payload.Reason != "" {
+		after["reason"] = payload.Reason
+	}
+	s.recordAudit(r, models.AuditIncidentMerged, source.ID, before, after)
+
+	logger.Info("incident merged", map[string]interface{}{
+		"reason": payload.Reason,
+	})
+
+	merged, err := repository.GetByID(target.ID)
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.newIncidentResponse(merged))
+}
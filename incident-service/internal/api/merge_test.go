@@ -0,0 +1,106 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestMergeIncident_ClosesSourceAsDuplicate(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	server := NewServer(&config.Config{Server: config.ServerConfig{Port: 8080}}, db, nil, nil, NewLogger())
+	repository := database.NewIncidentRepository(db)
+	source := &models.Incident{ID: "test-incident-merge-source", ServiceName: "checkout", ErrorMessage: "database unreachable", Status: models.StatusPending, Provider: "grafana"}
+	target := &models.Incident{ID: "test-incident-merge-target", ServiceName: "checkout", ErrorMessage: "database unreachable", Status: models.StatusPending, Provider: "datadog"}
+	running := &models.Incident{ID: "test-incident-merge-running", ServiceName: "checkout", ErrorMessage: "database unreachable", Status: models.StatusPending, Provider: "sentry"}
+	for _, incident := range []*models.Incident{source, target, running} {
+		if err := repository.Create(incident); err != nil {
+			t.Fatalf("failed to create test incident: %v", err)
+		}
+		id := incident.ID
+		defer func() {
+			_, _ = db.Exec("DELETE FROM incidents WHERE id = $1", id)
+		}()
+	}
+	if err := repository.LogEvent(&models.IncidentEvent{IncidentID: source.ID, EventType: models.EventIncidentReceived, EventData: map[string]interface{}{}}); err != nil {
+		t.Fatalf("failed to log event: %v", err)
+	}
+	if err := repository.UpdateStatus(running.ID, models.StatusWorkflowTriggered); err != nil {
+		t.Fatalf("failed to trigger workflow: %v", err)
+	}
+	before, err := repository.GetByID(target.ID)
+	if err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+
+	merge := func(id, targetID string) int {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/api/v1/incidents/"+id+"/merge", strings.NewReader(`{"target_id": "`+targetID+`", "reason": "same outage"}`))
+		w := httptest.NewRecorder()
+		server.Router().ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := merge(source.ID, target.ID); code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, code)
+	}
+
+	merged, err := repository.GetByID(source.ID)
+	if err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+	if merged.Status != models.StatusNoFixNeeded || merged.DuplicateOf == nil || *merged.DuplicateOf != target.ID {
+		t.Errorf("expected the source closed as a duplicate of the target, got %s, %v", merged.Status, merged.DuplicateOf)
+	}
+	after, err := repository.GetByID(target.ID)
+	if err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+	if after.OccurrenceCount != before.OccurrenceCount+merged.OccurrenceCount {
+		t.Errorf("expected the source's occurrences added to the target, got %d", after.OccurrenceCount)
+	}
+
+	// The source's timeline moved to the target, which records the merge
+	events, err := repository.GetEventsByIncidentID(target.ID)
+	if err != nil {
+		t.Fatalf("failed to get events: %v", err)
+	}
+	var received, mergedEvents int
+	for _, event := range events {
+		switch event.EventType {
+		case models.EventIncidentReceived:
+			received++
+		case models.EventIncidentMerged:
+			mergedEvents++
+			if event.EventData["merged_incident_id"] != source.ID || event.EventData["reason"] != "same outage" {
+				t.Errorf("expected the merge recorded with its reason, got %v", event.EventData)
+			}
+		}
+	}
+	if received != 1 || mergedEvents != 1 {
+		t.Errorf("expected the source's event and the merge on the target's timeline, got %d and %d", received, mergedEvents)
+	}
+
+	// A duplicate cannot be merged again, nor merged into, nor can a running
+	// remediation be merged away
+	for _, tt := range []struct{ name, id, target string }{
+		{"merged again", source.ID, target.ID},
+		{"into a duplicate", target.ID, source.ID},
+		{"while running", running.ID, target.ID},
+	} {
+		if code := merge(tt.id, tt.target); code != http.StatusConflict {
+			t.Errorf("%s: expected status %d, got %d", tt.name, http.StatusConflict, code)
+		}
+	}
+}
@@ -1,33 +1,96 @@
 package api
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
-// Metrics holds all Prometheus metrics
+// Recorder is the facade the service reports what it does through. Every
+// counter and histogram of Metrics is emitted by one of its methods, so a
+// metric cannot be defined without a path that updates it.
+type Recorder interface {
+	RecordIncident(provider, status string)
+	RecordWebhookFailure(provider, reason string)
+	ObserveWebhookProcessing(provider string, duration time.Duration)
+	RecordUnknownService(provider string)
+	RecordPayloadFields(provider string, unknown int)
+	RecordStormSuppressed(provider string)
+	RecordQuotaExceeded(provider string)
+	RecordRemediationCapped(service string)
+	RecordEnrichmentQuery(server, status string)
+	RecordSummary(status string)
+	RecordEmbedding(status string)
+	RecordSeveritySuggestion(method string)
+	RecordRemediationDeferred(window string)
+	SetSlowDrip(active bool, held int)
+	RecordWorkflowDispatch(repository, status string, duration time.Duration)
+	SetWorkflowSlots(repository string, active, queued int)
+	SetBufferDepth(depth int)
+	RecordNotification(provider, eventType, status string)
+}
+
+var _ Recorder = (*Metrics)(nil)
+
+// Metrics holds all Prometheus metrics. Gauges maintained by background
+// workers are handed to them directly; everything else is updated through
+// the Recorder methods, which skip metrics left nil.
 type Metrics struct {
 	IncidentReceived            *prometheus.CounterVec
 	WebhookProcessingDuration   *prometheus.HistogramVec
-	IncidentIngestionTotal      *prometheus.CounterVec
-	IncidentIngestionLatency    *prometheus.HistogramVec
+	WebhookFailures             *prometheus.CounterVec
+	UnknownServiceIncidents     *prometheus.CounterVec
+	WebhookPayloadsNormalized   *prometheus.CounterVec
+	WebhookUnknownFields        *prometheus.CounterVec
 	WorkflowDispatchTotal       *prometheus.CounterVec
 	WorkflowDispatchLatency     *prometheus.HistogramVec
 	IncidentQueueDepth          prometheus.Gauge
+	IngestionBufferDepth        prometheus.Gauge
+	IngestionWorkersBusy        prometheus.Gauge
+	IngestionSaturation         prometheus.Gauge
 	ActiveWorkflows             *prometheus.GaugeVec
+	QueuedWorkflows             *prometheus.GaugeVec
+	OpenIncidents               *prometheus.GaugeVec
+	IncidentSuccessRate         prometheus.Gauge
+	IncidentMTTR                prometheus.Gauge
+	KPILastRefresh              prometheus.Gauge
+	SLOCompliance               *prometheus.GaugeVec
+	SLOTarget                   *prometheus.GaugeVec
+	SLOErrorBudgetRemaining     *prometheus.GaugeVec
+	SLOEligibleIncidents        *prometheus.GaugeVec
+	StormSuppressedIncidents    *prometheus.CounterVec
+	QuotaExceededIncidents      *prometheus.CounterVec
+	RemediationCappedIncidents  *prometheus.CounterVec
+	EnrichmentQueries           *prometheus.CounterVec
+	IncidentSummaries           *prometheus.CounterVec
+	IncidentEmbeddings          *prometheus.CounterVec
+	SeveritySuggestions         *prometheus.CounterVec
+	RemediationDeferred         *prometheus.CounterVec
+	NotificationDeliveries      *prometheus.CounterVec
+	LeaderElectionIsLeader      prometheus.Gauge
+	DispatchSlowDrip            prometheus.Gauge
+	DispatchSlowDripHeld        prometheus.Gauge
 }
 
 // NewMetrics creates and registers Prometheus metrics
 func NewMetrics() *Metrics {
+	return newMetrics(prometheus.DefaultRegisterer)
+}
+
+// newMetrics creates metrics registered with reg, so that several servers can
+// run in one process with their own registries
+func newMetrics(reg prometheus.Registerer) *Metrics {
+	factory := promauto.With(reg)
 	return &Metrics{
-		IncidentReceived: promauto.NewCounterVec(
+		IncidentReceived: factory.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "incident_received_total",
 				Help: "Total number of incidents received from webhooks",
 			},
 			[]string{"provider", "status"},
 		),
-		WebhookProcessingDuration: promauto.NewHistogramVec(
+		WebhookProcessingDuration: factory.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Name:    "webhook_processing_duration_seconds",
 				Help:    "Duration of webhook processing",
@@ -35,48 +98,350 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"provider"},
 		),
-		IncidentIngestionTotal: promauto.NewCounterVec(
+		WebhookFailures: factory.NewCounterVec(
 			prometheus.CounterOpts{
-				Name: "incident_ingestion_total",
-				Help: "Total number of incidents received",
+				Name: "webhook_failures_total",
+				Help: "Total number of webhooks rejected during validation or parsing, by reason",
 			},
-			[]string{"provider", "status"},
+			[]string{"provider", "reason"},
 		),
-		IncidentIngestionLatency: promauto.NewHistogramVec(
-			prometheus.HistogramOpts{
-				Name:    "incident_ingestion_latency_seconds",
-				Help:    "Latency of incident ingestion",
-				Buckets: prometheus.DefBuckets,
+		UnknownServiceIncidents: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "incident_unknown_service_total",
+				Help: "Total number of incidents ingested with service_name=unknown",
 			},
 			[]string{"provider"},
 		),
-		WorkflowDispatchTotal: promauto.NewCounterVec(
+		WebhookPayloadsNormalized: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "webhook_payloads_normalized_total",
+				Help: "Total number of webhook payloads checked against their adapter's schema, by outcome (complete, or dropped_fields when the adapter does not read some of their fields)",
+			},
+			[]string{"provider", "outcome"},
+		),
+		WebhookUnknownFields: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "webhook_unknown_fields_total",
+				Help: "Total number of webhook payload fields dropped because their adapter does not read them",
+			},
+			[]string{"provider"},
+		),
+		WorkflowDispatchTotal: factory.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "workflow_dispatch_total",
-				Help: "Total number of workflow dispatches",
+				Help: "Total number of workflow dispatch attempts, by outcome (success, queued, circuit_open or error)",
 			},
 			[]string{"repository", "status"},
 		),
-		WorkflowDispatchLatency: promauto.NewHistogramVec(
+		WorkflowDispatchLatency: factory.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Name:    "workflow_dispatch_latency_seconds",
-				Help:    "Latency of workflow dispatch",
+				Help:    "Latency of workflow dispatch requests to GitHub",
 				Buckets: prometheus.DefBuckets,
 			},
 			[]string{"repository"},
 		),
-		IncidentQueueDepth: promauto.NewGauge(
+		IncidentQueueDepth: factory.NewGauge(
 			prometheus.GaugeOpts{
 				Name: "incident_queue_depth",
 				Help: "Number of incidents waiting in queue",
 			},
 		),
-		ActiveWorkflows: promauto.NewGaugeVec(
+		IngestionBufferDepth: factory.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "incident_ingestion_buffer_depth",
+				Help: "Number of webhooks buffered until the database recovers",
+			},
+		),
+		IngestionWorkersBusy: factory.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "incident_ingestion_workers_busy",
+				Help: "Number of ingestion workers processing a webhook",
+			},
+		),
+		IngestionSaturation: factory.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "incident_ingestion_saturation_ratio",
+				Help: "Fraction of ingestion workers and queue slots in use; webhooks are throttled at 1",
+			},
+		),
+		ActiveWorkflows: factory.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "active_workflows",
 				Help: "Number of active workflows per repository",
 			},
 			[]string{"repository"},
 		),
+		QueuedWorkflows: factory.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "queued_workflows",
+				Help: "Number of incidents waiting for a workflow slot per repository",
+			},
+			[]string{"repository"},
+		),
+		OpenIncidents: factory.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "incidents_open",
+				Help: "Number of unresolved incidents by status and severity",
+			},
+			[]string{"status", "severity"},
+		),
+		IncidentSuccessRate: factory.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "incident_success_rate",
+				Help: "Fraction of incidents in the KPI window that were resolved or got a pull request",
+			},
+		),
+		IncidentMTTR: factory.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "incident_mttr_seconds",
+				Help: "Mean time to resolve incidents created in the KPI window",
+			},
+		),
+		KPILastRefresh: factory.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "incident_kpi_last_refresh_timestamp_seconds",
+				Help: "Unix time of the last successful KPI refresh",
+			},
+		),
+		SLOCompliance: factory.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "slo_compliance_ratio",
+				Help: "Fraction of eligible incidents in the SLO window that met the objective",
+			},
+			[]string{"slo"},
+		),
+		SLOTarget: factory.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "slo_target_ratio",
+				Help: "Configured compliance target of the SLO",
+			},
+			[]string{"slo"},
+		),
+		SLOErrorBudgetRemaining: factory.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "slo_error_budget_remaining_ratio",
+				Help: "Fraction of the SLO error budget left in the window; negative once overspent",
+			},
+			[]string{"slo"},
+		),
+		SLOEligibleIncidents: factory.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "slo_eligible_incidents",
+				Help: "Number of incidents in the SLO window whose outcome is known",
+			},
+			[]string{"slo"},
+		),
+		StormSuppressedIncidents: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "incident_storm_suppressed_total",
+				Help: "Total number of incidents grouped under an alert storm instead of being remediated",
+			},
+			[]string{"provider"},
+		),
+		QuotaExceededIncidents: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "incident_quota_exceeded_total",
+				Help: "Total number of incidents received after their provider exceeded its hourly quota",
+			},
+			[]string{"provider"},
+		),
+		RemediationCappedIncidents: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "incident_remediation_capped_total",
+				Help: "Total number of incidents left for manual attention after their service reached its daily remediation cap",
+			},
+			[]string{"service"},
+		),
+		EnrichmentQueries: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "incident_enrichment_queries_total",
+				Help: "Total number of MCP queries run to gather incident context, by server and status",
+			},
+			[]string{"server", "status"},
+		),
+		IncidentSummaries: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "incident_summaries_total",
+				Help: "Total number of incident summaries requested from the language model, by status",
+			},
+			[]string{"status"},
+		),
+		IncidentEmbeddings: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "incident_embeddings_total",
+				Help: "Total number of error message embeddings requested for similarity search, by status",
+			},
+			[]string{"status"},
+		),
+		SeveritySuggestions: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "incident_severity_suggestions_total",
+				Help: "Total number of incidents without a provider severity, by how a severity was suggested (keyword, bayes or none)",
+			},
+			[]string{"method"},
+		),
+		RemediationDeferred: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "incident_remediation_deferred_total",
+				Help: "Total number of remediations deferred by a maintenance window",
+			},
+			[]string{"window"},
+		),
+		NotificationDeliveries: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "notification_deliveries_total",
+				Help: "Total number of notification delivery attempts, by provider, event type and status (success or error)",
+			},
+			[]string{"provider", "event_type", "status"},
+		),
+		LeaderElectionIsLeader: factory.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "leader_election_is_leader",
+				Help: "1 while this replica holds the leader lease and runs the singleton workers",
+			},
+		),
+		DispatchSlowDrip: factory.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "workflow_dispatch_slow_drip",
+				Help: "1 while this replica dispatches in slow-drip mode because GitHub keeps answering with server errors",
+			},
+		),
+		DispatchSlowDripHeld: factory.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "workflow_dispatch_slow_drip_held",
+				Help: "Number of incidents waiting for their turn to be dispatched in slow-drip mode",
+			},
+		),
+	}
+}
+
+// RecordIncident counts a webhook by provider and the status it ended with
+func (m *Metrics) RecordIncident(provider, status string) {
+	incCounter(m.IncidentReceived, provider, status)
+}
+
+// RecordWebhookFailure counts a webhook rejected during validation or parsing
+func (m *Metrics) RecordWebhookFailure(provider, reason string) {
+	incCounter(m.WebhookFailures, provider, reason)
+}
+
+// ObserveWebhookProcessing records the time from receiving a webhook to
+// storing its incident
+func (m *Metrics) ObserveWebhookProcessing(provider string, duration time.Duration) {
+	if m.WebhookProcessingDuration != nil {
+		m.WebhookProcessingDuration.WithLabelValues(provider).Observe(duration.Seconds())
+	}
+}
+
+// RecordUnknownService counts an incident stored without a known service
+func (m *Metrics) RecordUnknownService(provider string) {
+	incCounter(m.UnknownServiceIncidents, provider)
+}
+
+// RecordPayloadFields counts a webhook payload checked against its adapter's
+// schema and the fields the adapter drops from it
+func (m *Metrics) RecordPayloadFields(provider string, unknown int) {
+	if unknown == 0 {
+		incCounter(m.WebhookPayloadsNormalized, provider, "complete")
+		return
+	}
+	incCounter(m.WebhookPayloadsNormalized, provider, "dropped_fields")
+	if m.WebhookUnknownFields != nil {
+		m.WebhookUnknownFields.WithLabelValues(provider).Add(float64(unknown))
+	}
+}
+
+// RecordStormSuppressed counts an incident grouped under an alert storm
+func (m *Metrics) RecordStormSuppressed(provider string) {
+	incCounter(m.StormSuppressedIncidents, provider)
+}
+
+// RecordQuotaExceeded counts an incident received over its provider's quota
+func (m *Metrics) RecordQuotaExceeded(provider string) {
+	incCounter(m.QuotaExceededIncidents, provider)
+}
+
+// RecordRemediationCapped counts an incident left for manual attention over
+// its service's daily remediation cap
+func (m *Metrics) RecordRemediationCapped(service string) {
+	incCounter(m.RemediationCappedIncidents, service)
+}
+
+// RecordEnrichmentQuery counts an MCP query by server and status
+func (m *Metrics) RecordEnrichmentQuery(server, status string) {
+	incCounter(m.EnrichmentQueries, server, status)
+}
+
+// RecordSummary counts a summary request to the language model
+func (m *Metrics) RecordSummary(status string) {
+	incCounter(m.IncidentSummaries, status)
+}
+
+// RecordEmbedding counts an embedding request for similarity search
+func (m *Metrics) RecordEmbedding(status string) {
+	incCounter(m.IncidentEmbeddings, status)
+}
+
+// RecordSeveritySuggestion counts how a severity was suggested for an
+// incident that arrived without one
+func (m *Metrics) RecordSeveritySuggestion(method string) {
+	incCounter(m.SeveritySuggestions, method)
+}
+
+// RecordRemediationDeferred counts a remediation held by a maintenance window
+func (m *Metrics) RecordRemediationDeferred(window string) {
+	incCounter(m.RemediationDeferred, window)
+}
+
+// SetSlowDrip sets whether the replica dispatches in slow-drip mode and how
+// many incidents wait for their turn
+func (m *Metrics) SetSlowDrip(active bool, held int) {
+	if m.DispatchSlowDrip != nil {
+		value := 0.0
+		if active {
+			value = 1
+		}
+		m.DispatchSlowDrip.Set(value)
+	}
+	if m.DispatchSlowDripHeld != nil {
+		m.DispatchSlowDripHeld.Set(float64(held))
+	}
+}
+
+// RecordWorkflowDispatch counts a workflow dispatch attempt by outcome. The
+// latency is only observed for attempts that reached GitHub.
+func (m *Metrics) RecordWorkflowDispatch(repository, status string, duration time.Duration) {
+	incCounter(m.WorkflowDispatchTotal, repository, status)
+	if m.WorkflowDispatchLatency != nil && (status == "success" || status == "error") {
+		m.WorkflowDispatchLatency.WithLabelValues(repository).Observe(duration.Seconds())
+	}
+}
+
+// SetWorkflowSlots sets the active and queued workflow counts of a repository
+func (m *Metrics) SetWorkflowSlots(repository string, active, queued int) {
+	if m.ActiveWorkflows != nil {
+		m.ActiveWorkflows.WithLabelValues(repository).Set(float64(active))
+	}
+	if m.QueuedWorkflows != nil {
+		m.QueuedWorkflows.WithLabelValues(repository).Set(float64(queued))
+	}
+}
+
+// SetBufferDepth sets the number of webhooks buffered until the database recovers
+func (m *Metrics) SetBufferDepth(depth int) {
+	if m.IngestionBufferDepth != nil {
+		m.IngestionBufferDepth.Set(float64(depth))
+	}
+}
+
+// RecordNotification counts a notification delivery attempt
+func (m *Metrics) RecordNotification(provider, eventType, status string) {
+	incCounter(m.NotificationDeliveries, provider, eventType, status)
+}
+
+func incCounter(counter *prometheus.CounterVec, labels ...string) {
+	if counter != nil {
+		counter.WithLabelValues(labels...).Inc()
 	}
 }
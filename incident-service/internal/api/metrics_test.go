@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// workerMetrics are the metric vectors set by the KPI and SLO workers, whose
+// own tests cover them
+var workerMetrics = map[string]bool{
+	"OpenIncidents":           true,
+	"SLOCompliance":           true,
+	"SLOTarget":               true,
+	"SLOErrorBudgetRemaining": true,
+	"SLOEligibleIncidents":    true,
+}
+
+func TestRecorder_EmitsEveryMetric(t *testing.T) {
+	m := newMetrics(prometheus.NewRegistry())
+
+	m.RecordIncident("datadog", "success")
+	m.RecordWebhookFailure("datadog", "malformed_json")
+	m.ObserveWebhookProcessing("datadog", 10*time.Millisecond)
+	m.RecordUnknownService("datadog")
+	m.RecordPayloadFields("datadog", 2)
+	m.RecordStormSuppressed("datadog")
+	m.RecordQuotaExceeded("datadog")
+	m.RecordRemediationCapped("checkout")
+	m.RecordEnrichmentQuery("logs", "success")
+	m.RecordSummary("success")
+	m.RecordEmbedding("success")
+	m.RecordSeveritySuggestion("keyword")
+	m.RecordRemediationDeferred("release-freeze")
+	m.RecordWorkflowDispatch("org/api", "success", time.Second)
+	m.SetWorkflowSlots("org/api", 1, 0)
+	m.SetBufferDepth(0)
+	m.RecordNotification("slack", "incident_received", "success")
+
+	value := reflect.ValueOf(m).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		name := value.Type().Field(i).Name
+		collector, ok := value.Field(i).Interface().(prometheus.Collector)
+		if !ok || value.Field(i).IsNil() {
+			t.Errorf("%s is not registered", name)
+			continue
+		}
+		if workerMetrics[name] {
+			continue
+		}
+		if testutil.CollectAndCount(collector) == 0 {
+			t.Errorf("%s is never emitted through the Recorder", name)
+		}
+	}
+}
+
+func TestRecorder_SkipsUnsetMetrics(t *testing.T) {
+	m := &Metrics{}
+
+	// A partially built Metrics, as in handler tests, must not panic
+	m.RecordIncident("datadog", "success")
+	m.ObserveWebhookProcessing("datadog", time.Millisecond)
+	m.RecordWorkflowDispatch("org/api", "error", time.Second)
+	m.SetWorkflowSlots("org/api", 1, 1)
+	m.SetBufferDepth(3)
+	m.RecordNotification("slack", "incident_received", "error")
+}
+
+func TestDispatchWorkflow_RecordsOutcomeAndSlots(t *testing.T) {
+	githubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer githubServer.Close()
+
+	s := &Server{
+		githubClient: github.NewClient(githubServer.URL, "test-token", "test-workflow.yml", 1),
+		metrics:      newMetrics(prometheus.NewRegistry()),
+	}
+
+	first := &models.Incident{ID: "inc_1", ServiceName: "api", Repository: "org/api"}
+	if err := s.dispatchWorkflow(context.Background(), first, "main"); err != nil {
+		t.Fatalf("dispatchWorkflow() error = %v", err)
+	}
+	second := &models.Incident{ID: "inc_2", ServiceName: "api", Repository: "org/api"}
+	if err := s.dispatchWorkflow(context.Background(), second, "main"); err != github.ErrIncidentQueued {
+		t.Fatalf("expected the second incident to be queued, got %v", err)
+	}
+
+	if got := testutil.ToFloat64(s.metrics.WorkflowDispatchTotal.WithLabelValues("org/api", "success")); got != 1 {
+		t.Errorf("expected 1 successful dispatch, got %v", got)
+	}
+	if got := testutil.ToFloat64(s.metrics.WorkflowDispatchTotal.WithLabelValues("org/api", "queued")); got != 1 {
+		t.Errorf("expected 1 queued dispatch, got %v", got)
+	}
+	if got := testutil.CollectAndCount(s.metrics.WorkflowDispatchLatency); got != 1 {
+		t.Errorf("expected latency for the dispatch that reached GitHub only, got %d series", got)
+	}
+	if got := testutil.ToFloat64(s.metrics.ActiveWorkflows.WithLabelValues("org/api")); got != 1 {
+		t.Errorf("expected 1 active workflow, got %v", got)
+	}
+	if got := testutil.ToFloat64(s.metrics.QueuedWorkflows.WithLabelValues("org/api")); got != 1 {
+		t.Errorf("expected 1 queued workflow, got %v", got)
+	}
+
+	if !s.RemoveQueued("org/api", "inc_2") {
+		t.Fatal("expected the queued incident to be removed")
+	}
+	if got := testutil.ToFloat64(s.metrics.QueuedWorkflows.WithLabelValues("org/api")); got != 0 {
+		t.Errorf("expected no queued workflows after removal, got %v", got)
+	}
+}
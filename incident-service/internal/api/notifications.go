@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// notify sends notifications for an incident event in the background so
+// slow or failing providers never delay the HTTP response
+func (s *Server) notify(eventType models.IncidentEventType, incident *models.Incident) {
+	if !s.notifier.Enabled() {
+		return
+	}
+
+	// Copy the incident so later mutations by the caller don't race with rendering
+	snapshot := *incident
+
+	s.goBackground("send notification", map[string]interface{}{
+		"event_type":  eventType,
+		"incident_id": snapshot.ID,
+	}, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := s.notifier.Notify(ctx, eventType, &snapshot); err != nil {
+			s.logger.Error("failed to send notification", map[string]interface{}{
+				"error":       err.Error(),
+				"event_type":  eventType,
+				"incident_id": snapshot.ID,
+			})
+		}
+	})
+}
+
+// handleListIncidentNotifications returns the notifications sent for an incident
+func (s *Server) handleListIncidentNotifications(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if _, err := s.repository.GetByID(id); err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+
+	deliveries, err := s.repository.GetNotificationDeliveriesByIncidentID(id)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to list notifications", map[string]interface{}{
+			"error": err.Error(),
+			"id":    id,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"notifications": deliveries,
+		"total":         len(deliveries),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/postmortem"
+)
+
+// Postmortem publishing targets
+const (
+	PostmortemTargetRepository = "repository"
+	PostmortemTargetConfluence = "confluence"
+)
+
+// PostmortemRequest is the optional body of a postmortem request
+type PostmortemRequest struct {
+	Publish []string `json:"publish,omitempty"` // repository, confluence, or both
+}
+
+// PostmortemResponse is a postmortem draft, with where it was published
+type PostmortemResponse struct {
+	*postmortem.Draft
+	Published []PublishedPostmortem `json:"published,omitempty"`
+}
+
+// PublishedPostmortem is the outcome of publishing a draft to one target
+type PublishedPostmortem struct {
+	Target string `json:"target"`
+	URL    string `json:"url,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// handleCreatePostmortem drafts the postmortem of an incident from its
+// timeline, diagnosis, pull request and impact window, and returns it as
+// Markdown. The draft is also committed to the configured repository or
+// created as a Confluence page when the request asks to publish it there.
+// When publishing fails, the draft is still returned, with 502.
+func (s *Server) handleCreatePostmortem(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var payload PostmortemRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	for _, target := range payload.Publish {
+		if err := s.checkPostmortemTarget(target); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	logger := s.loggerFrom(r.Context()).With(map[string]interface{}{
+		"incident_id": id,
+	})
+
+	repository := s.repository.WithContext(r.Context())
+	incident, err := repository.GetByID(id)
+	if err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+	events, err := repository.GetEventsByIncidentID(id)
+	if err != nil {
+		logger.Error("failed to get incident events", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := PostmortemResponse{Draft: postmortem.New(incident, events, time.Now())}
+	status := http.StatusOK
+	published := map[string]interface{}{}
+	for _, target := range payload.Publish {
+		url, err := s.publishPostmortem(r, target, response.Draft)
+		if err != nil {
+			logger.Error("failed to publish postmortem", map[string]interface{}{
+				"error":  err.Error(),
+				"target": target,
+			})
+			response.Published = append(response.Published, PublishedPostmortem{Target: target, Error: err.Error()})
+			status = http.StatusBadGateway
+			continue
+		}
+
+		response.Published = append(response.Published, PublishedPostmortem{Target: target, URL: url})
+		published[target] = url
+		event := &models.IncidentEvent{
+			IncidentID: id,
+			EventType:  models.EventPostmortemPublished,
+			EventData:  map[string]interface{}{"target": target, "url": url},
+		}
+		if err := repository.LogEvent(event); err != nil {
+			logger.Error("failed to log postmortem published event", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		logger.Info("postmortem published", map[string]interface{}{
+			"target": target,
+			"url":    url,
+		})
+	}
+	if len(published) > 0 {
+		s.recordAudit(r, models.AuditIncidentPostmortem, id, nil, published)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// checkPostmortemTarget reports whether drafts can be published to target
+func (s *Server) checkPostmortemTarget(target string) error {
+	switch target {
+	case PostmortemTargetRepository:
+		if s.config.Postmortems.Repository.Name == "" || s.githubClient == nil {
+			return fmt.Errorf("publishing postmortems to a repository is not configured")
+		}
+	case PostmortemTargetConfluence:
+		if s.confluence == nil {
+			return fmt.Errorf("publishing postmortems to Confluence is not configured")
+		}
+	default:
+		return fmt.Errorf("publish targets must be %s or %s", PostmortemTargetRepository, PostmortemTargetConfluence)
+	}
+	return nil
+}
+
+// publishPostmortem publishes a draft to a checked target and returns where
+// it can be read
+func (s *Server) publishPostmortem(r *http.Request, target string, draft *postmortem.Draft) (string, error) {
+	if target == PostmortemTargetConfluence {
+		return s.confluence.Publish(r.Context(), draft)
+	}
+
+	cfg := s.config.Postmortems.Repository
+	message := fmt.Sprintf("Add postmortem draft for incident %s", draft.IncidentID)
+	return s.githubClient.CommitFile(r.Context(), cfg.Name, cfg.Branch, cfg.Directory()+"/"+draft.Filename, message, []byte(draft.Markdown))
+}
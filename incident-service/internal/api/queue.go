@@ -0,0 +1,288 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// QueueStatus reports workflow concurrency and the dispatch queue of every
+// repository with workflows running or waiting
+type QueueStatus struct {
+	Active       int                        `json:"active"`
+	Queued       int                        `json:"queued"`
+	MaxPerRepo   int                        `json:"max_workflows_per_repo"`
+	Repositories map[string]RepositoryQueue `json:"repositories"`
+}
+
+// RepositoryQueue is the workflow concurrency state of one repository
+type RepositoryQueue struct {
+	github.RepositoryStats
+	Incidents []string           `json:"incidents"` // queued incident IDs, next first
+	Leases    []github.SlotLease `json:"leases"`    // the running workflows' slots, oldest first
+}
+
+// QueueResetResponse is the response of resetting a repository's workflow slots
+type QueueResetResponse struct {
+	Repository string             `json:"repository"`
+	Released   []github.SlotLease `json:"released"`   // the slots that were held
+	Dispatched []string           `json:"dispatched"` // queued incident IDs dispatched into the freed slots
+}
+
+// handleGetQueue reports running workflows and queued incidents per repository
+func (s *Server) handleGetQueue(w http.ResponseWriter, r *http.Request) {
+	status := QueueStatus{Repositories: map[string]RepositoryQueue{}}
+	if s.config != nil {
+		status.MaxPerRepo = s.config.Concurrency.MaxWorkflowsPerRepo
+	}
+
+	if s.githubClient != nil {
+		for repository, stats := range s.githubClient.Stats() {
+			status.Active += stats.Active
+			status.Queued += stats.Queued
+			leases, err := s.githubClient.Leases(r.Context(), repository)
+			if err != nil || leases == nil {
+				leases = []github.SlotLease{}
+			}
+			status.Repositories[repository] = RepositoryQueue{
+				RepositoryStats: stats,
+				Incidents:       s.githubClient.QueuedIncidents(repository),
+				Leases:          leases,
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// handleResetQueue frees every workflow slot of a repository, for when its
+// counters leaked, and dispatches queued incidents into the freed slots. The
+// queue itself is kept.
+func (s *Server) handleResetQueue(w http.ResponseWriter, r *http.Request) {
+	repository := chi.URLParam(r, "owner") + "/" + chi.URLParam(r, "repo")
+	logger := s.loggerFrom(r.Context()).With(map[string]interface{}{
+		"repository": repository,
+	})
+	if s.githubClient == nil {
+		http.Error(w, "workflow dispatch is not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	leases, err := s.githubClient.Leases(r.Context(), repository)
+	if err != nil {
+		logger.Error("failed to get workflow slot leases", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	active := s.githubClient.GetActiveCount(repository)
+
+	next, err := s.githubClient.ResetSlots(r.Context(), repository)
+	if err != nil {
+		logger.Error("failed to reset workflow slots", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	s.observeWorkflowSlots(repository)
+
+	response := QueueResetResponse{Repository: repository, Released: leases, Dispatched: []string{}}
+	if response.Released == nil {
+		response.Released = []github.SlotLease{}
+	}
+	for _, incident := range next {
+		response.Dispatched = append(response.Dispatched, incident.ID)
+		s.dispatchDequeued(r.Context(), incident)
+	}
+
+	s.recordResourceAudit(r, models.AuditQueueReset, "repository", repository,
+		map[string]interface{}{"active": active, "leases": leases},
+		map[string]interface{}{"active": 0, "dispatched": response.Dispatched})
+	logger.Warn("workflow slots reset", map[string]interface{}{
+		"released":   active,
+		"dispatched": len(response.Dispatched),
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// ExpireWorkflowSlots releases the workflow slots held longer than the slot
+// TTL, as their workflows never reported back, recording a warning on each
+// incident's timeline and dispatching queued incidents into the freed slots
+func (s *Server) ExpireWorkflowSlots(ctx context.Context) error {
+	if s.githubClient == nil || s.config == nil {
+		return nil
+	}
+
+	ttl := s.config.Concurrency.SlotExpiry()
+	expired, err := s.githubClient.ExpireSlots(ctx, ttl)
+	for _, slot := range expired {
+		s.loggerFrom(ctx).Warn("workflow slot expired", map[string]interface{}{
+			"repository":  slot.Repository,
+			"incident_id": slot.IncidentID,
+			"acquired_at": slot.AcquiredAt,
+			"ttl":         ttl.String(),
+		})
+		s.observeWorkflowSlots(slot.Repository)
+		if slot.IncidentID != "" {
+			event := &models.IncidentEvent{
+				IncidentID: slot.IncidentID,
+				EventType:  models.EventSlotExpired,
+				EventData: map[string]interface{}{
+					"repository":  slot.Repository,
+					"acquired_at": slot.AcquiredAt,
+					"ttl":         ttl.String(),
+				},
+			}
+			if err := s.repository.WithContext(ctx).LogEvent(event); err != nil {
+				s.loggerFrom(ctx).Error("failed to log slot expired event", map[string]interface{}{
+					"error":       err.Error(),
+					"incident_id": slot.IncidentID,
+				})
+			}
+		}
+		if slot.Next != nil {
+			s.dispatchDequeued(ctx, slot.Next)
+		}
+	}
+	return err
+}
+
+// dispatchWorkflow dispatches the remediation workflow of an incident,
+// recording the outcome and the repository's workflow slots. During a deploy
+// freeze of the repository the workflow is dispatched with freeze=true.
+// Inputs over the configured size are truncated, and the workflow gets a
+// signed URL to fetch them whole from when one is configured. Every request
+// to GitHub is recorded in the incident's dispatch history.
+func (s *Server) dispatchWorkflow(ctx context.Context, inc *models.Incident, branch string) error {
+	window, frozen := s.activeFreeze(inc.Repository)
+
+	var attempts []github.DispatchAttempt
+	opts := github.DispatchOptions{
+		Runbooks:   s.runbookInput(inc.ServiceName),
+		Freeze:     frozen,
+		ContextURL: s.contextURL(inc),
+		OnAttempt:  func(attempt github.DispatchAttempt) { attempts = append(attempts, attempt) },
+	}
+	if s.config != nil {
+		opts.MaxInputBytes = s.config.WorkflowInputs.InputLimit()
+	}
+
+	start := time.Now()
+	_, err := s.githubClient.DispatchWorkflow(ctx, inc, branch, opts)
+
+	status := "success"
+	switch {
+	case errors.Is(err, github.ErrIncidentQueued):
+		status = "queued"
+	case errors.Is(err, github.ErrCircuitOpen):
+		status = "circuit_open"
+	case err != nil:
+		status = "error"
+	}
+	s.metrics.RecordWorkflowDispatch(inc.Repository, status, time.Since(start))
+	s.observeWorkflowSlots(inc.Repository)
+	s.observeSlowDrip(ctx)
+	if errors.Is(err, github.ErrCircuitOpen) {
+		attempts = append(attempts, github.DispatchAttempt{Attempt: 1, StartedAt: start, Err: err})
+	}
+	s.recordDispatchAttempts(ctx, inc, attempts)
+
+	if err == nil && frozen {
+		s.noteFreeze(ctx, inc, window)
+	}
+	if err == nil {
+		s.noteTruncatedInputs(ctx, inc, opts)
+	}
+	return err
+}
+
+// recordDispatchAttempts adds the requests made to dispatch the incident's
+// workflow to its dispatch history
+func (s *Server) recordDispatchAttempts(ctx context.Context, inc *models.Incident, attempts []github.DispatchAttempt) {
+	// Servers built for handler tests have no database
+	if s.repository == nil {
+		return
+	}
+
+	for _, attempt := range attempts {
+		record := &models.DispatchAttempt{
+			IncidentID:  inc.ID,
+			Repository:  inc.Repository,
+			Attempt:     attempt.Attempt,
+			Outcome:     models.DispatchAttemptSucceeded,
+			AttemptedAt: attempt.StartedAt,
+		}
+		if attempt.StatusCode != 0 {
+			statusCode := attempt.StatusCode
+			record.HTTPStatus = &statusCode
+		}
+		if attempt.Err != nil {
+			message := attempt.Err.Error()
+			record.Error = &message
+			record.Outcome = models.DispatchAttemptFailed
+			if errors.Is(attempt.Err, github.ErrCircuitOpen) {
+				record.Outcome = models.DispatchAttemptCircuitOpen
+			}
+		}
+		if err := s.repository.WithContext(ctx).RecordDispatchAttempt(record); err != nil {
+			s.loggerFrom(ctx).Error("failed to record dispatch attempt", map[string]interface{}{
+				"error":       err.Error(),
+				"incident_id": inc.ID,
+				"attempt":     attempt.Attempt,
+			})
+		}
+	}
+}
+
+// noteTruncatedInputs records on the incident's timeline which inputs of its
+// workflow were cut short or left out, with the full values in the database
+func (s *Server) noteTruncatedInputs(ctx context.Context, inc *models.Incident, opts github.DispatchOptions) {
+	_, truncated := github.NewWorkflowInputs(inc, opts)
+	if len(truncated) == 0 {
+		return
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: inc.ID,
+		EventType:  models.EventInputsTruncated,
+		EventData: map[string]interface{}{
+			"repository":  inc.Repository,
+			"inputs":      truncated,
+			"limit":       opts.MaxInputBytes,
+			"context_url": opts.ContextURL != "",
+		},
+	}
+	if err := s.repository.WithContext(ctx).LogEvent(event); err != nil {
+		s.loggerFrom(ctx).Error("failed to log inputs truncated event", map[string]interface{}{
+			"error":       err.Error(),
+			"incident_id": inc.ID,
+		})
+	}
+}
+
+// RemoveQueued drops an incident from a repository's dispatch queue,
+// reporting whether it was queued
+func (s *Server) RemoveQueued(repository, incidentID string) bool {
+	removed := s.githubClient.RemoveQueued(repository, incidentID)
+	if removed {
+		s.observeWorkflowSlots(repository)
+	}
+	return removed
+}
+
+// observeWorkflowSlots updates the active and queued workflow gauges of a
+// repository after its slots changed
+func (s *Server) observeWorkflowSlots(repository string) {
+	s.metrics.SetWorkflowSlots(repository, s.githubClient.GetActiveCount(repository), s.githubClient.GetQueuedCount(repository))
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// newQueueTestServer creates a server dispatching to a fake GitHub API, with a
+// repository limited to one workflow at a time and two pending incidents
+func newQueueTestServer(t *testing.T, concurrency config.ConcurrencyConfig) (*Server, *database.DB, []*models.Incident) {
+	t.Helper()
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(gh.Close)
+
+	concurrency.MaxWorkflowsPerRepo = 1
+	cfg := &config.Config{
+		Server:      config.ServerConfig{Port: 8080, Auth: config.AuthConfig{APIKeys: []config.APIKey{{Name: "ops-cli", Key: "cli-secret", Admin: true}}}},
+		Concurrency: concurrency,
+	}
+	server := NewServer(cfg, db, nil, github.NewClient(gh.URL, "token", "remediate.yml", 1), NewLogger())
+
+	repository := database.NewIncidentRepository(db)
+	var incidents []*models.Incident
+	for _, id := range []string{"test-incident-queue-0", "test-incident-queue-1"} {
+		incident := &models.Incident{
+			ID:           id,
+			ServiceName:  "checkout",
+			Repository:   "org/queue-test",
+			ErrorMessage: "test error",
+			Status:       models.StatusPending,
+			Provider:     "test",
+		}
+		if err := repository.Create(incident); err != nil {
+			t.Fatalf("failed to create test incident: %v", err)
+		}
+		incidents = append(incidents, incident)
+	}
+	t.Cleanup(func() {
+		_, _ = db.Exec("DELETE FROM incidents WHERE id LIKE 'test-incident-queue-%'")
+		_, _ = db.Exec("DELETE FROM audit_log WHERE resource_id = 'org/queue-test'")
+	})
+
+	// The first incident takes the only slot, the second waits for it
+	for _, incident := range incidents {
+		_ = server.dispatchWorkflow(context.Background(), incident, "main")
+	}
+	return server, db, incidents
+}
+
+func TestHandleResetQueue(t *testing.T) {
+	server, db, incidents := newQueueTestServer(t, config.ConcurrencyConfig{})
+
+	req := httptest.NewRequest("POST", "/api/v1/queue/org/queue-test/reset", nil)
+	req.Header.Set("X-API-Key", "cli-secret")
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	server.background.Wait()
+
+	var response QueueResetResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Released) != 1 || response.Released[0].IncidentID != incidents[0].ID {
+		t.Errorf("expected the slot of %s released, got %+v", incidents[0].ID, response.Released)
+	}
+	if len(response.Dispatched) != 1 || response.Dispatched[0] != incidents[1].ID {
+		t.Errorf("expected %s dispatched, got %+v", incidents[1].ID, response.Dispatched)
+	}
+	if queued := server.githubClient.GetQueuedCount("org/queue-test"); queued != 0 {
+		t.Errorf("expected an empty queue, got %d", queued)
+	}
+
+	entries, err := database.NewIncidentRepository(db).ListAudit(database.AuditFilter{Action: models.AuditQueueReset, ResourceID: "org/queue-test"})
+	if err != nil {
+		t.Fatalf("ListAudit() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Actor != "ops-cli" || entries[0].ResourceType != "repository" {
+		t.Errorf("expected the reset audited, got %+v", entries)
+	}
+}
+
+func TestExpireWorkflowSlots(t *testing.T) {
+	server, db, incidents := newQueueTestServer(t, config.ConcurrencyConfig{SlotTTL: time.Millisecond})
+	time.Sleep(5 * time.Millisecond)
+
+	if err := server.ExpireWorkflowSlots(context.Background()); err != nil {
+		t.Fatalf("ExpireWorkflowSlots() error = %v", err)
+	}
+	server.background.Wait()
+
+	events, err := database.NewIncidentRepository(db).GetEventsByIncidentID(incidents[0].ID)
+	if err != nil {
+		t.Fatalf("failed to get events: %v", err)
+	}
+	expired := false
+	for _, event := range events {
+		expired = expired || event.EventType == models.EventSlotExpired
+	}
+	if !expired {
+		t.Errorf("expected a %s event, got %+v", models.EventSlotExpired, events)
+	}
+	if queued := server.githubClient.GetQueuedCount("org/queue-test"); queued != 0 {
+		t.Errorf("expected the queued incident dispatched, got %d queued", queued)
+	}
+}
+
+func TestHandleGetIncident_DispatchAttempts(t *testing.T) {
+	server, _, incidents := newQueueTestServer(t, config.ConcurrencyConfig{})
+
+	req := httptest.NewRequest("GET", "/api/v1/incidents/"+incidents[0].ID, nil)
+	req.Header.Set("X-API-Key", "cli-secret")
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response struct {
+		DispatchAttempts []models.DispatchAttempt `json:"dispatch_attempts"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	attempts := response.DispatchAttempts
+	if len(attempts) != 1 || attempts[0].Outcome != models.DispatchAttemptSucceeded || attempts[0].HTTPStatus == nil || *attempts[0].HTTPStatus != http.StatusNoContent {
+		t.Errorf("expected one successful attempt, got %+v", attempts)
+	}
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// quotaWindow is the period provider quotas are counted over
+const quotaWindow = time.Hour
+
+// quotaCounts counts the incidents of each provider in the current window,
+// for replicas without Redis
+type quotaCounts struct {
+	mu     sync.Mutex
+	window time.Time
+	counts map[string]int64
+}
+
+// add counts an incident of provider received at now, and returns the
+// provider's count in now's window
+func (q *quotaCounts) add(provider string, now time.Time) int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	window := now.Truncate(quotaWindow)
+	if !window.Equal(q.window) || q.counts == nil {
+		q.window = window
+		q.counts = make(map[string]int64)
+	}
+	q.counts[provider]++
+	return q.counts[provider]
+}
+
+// applyQuota counts the incident towards its provider's hourly quota and flags
+// it once the quota is exceeded, so that it is stored but never remediated
+// automatically. It reports whether the incident is the first one over quota
+// in the current window.
+func (s *Server) applyQuota(ctx context.Context, incident *models.Incident, logger *Logger) (first bool) {
+	if s.config == nil {
+		return false
+	}
+	quota := s.config.Ingestion.HourlyQuota(incident.Provider)
+	if quota <= 0 {
+		return false
+	}
+
+	count := s.countForQuota(ctx, incident.Provider, time.Now(), logger)
+	if count <= int64(quota) {
+		return false
+	}
+
+	incident.QuotaExceeded = true
+	return count == int64(quota)+1
+}
+
+// countForQuota counts an incident of provider and returns the provider's count
+// in the current window. Counts are kept in Redis so that every replica shares
+// them, and locally when Redis is unavailable.
+func (s *Server) countForQuota(ctx context.Context, provider string, now time.Time, logger *Logger) int64 {
+	if s.redis != nil {
+		window := now.Truncate(quotaWindow)
+		key := fmt.Sprintf("incident-service:quota:%s:%d", provider, window.Unix())
+		count, err := s.redis.Incr(ctx, key).Result()
+		if err == nil {
+			if count == 1 {
+				s.redis.Expire(ctx, key, 2*quotaWindow)
+			}
+			return count
+		}
+		logger.Warn("failed to count incident towards provider quota, counting locally", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	return s.quotas.add(provider, now)
+}
+
+// recordQuotaExceeded logs that a stored incident is over its provider's quota,
+// and notifies the owning team of the first one in the window
+func (s *Server) recordQuotaExceeded(ctx context.Context, incident *models.Incident, first bool, logger *Logger) {
+	quota := s.config.Ingestion.HourlyQuota(incident.Provider)
+	event := &models.IncidentEvent{
+		IncidentID: incident.ID,
+		EventType:  models.EventQuotaExceeded,
+		EventData: map[string]interface{}{
+			"provider":     incident.Provider,
+			"hourly_quota": quota,
+		},
+	}
+	if err := s.repository.WithContext(ctx).LogEvent(event); err != nil {
+		logger.Error("failed to log quota exceeded event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	s.metrics.RecordQuotaExceeded(incident.Provider)
+
+	if !first {
+		return
+	}
+	logger.Warn("provider exceeded its hourly quota, not remediating its incidents", map[string]interface{}{
+		"hourly_quota": quota,
+	})
+	s.notify(models.EventQuotaExceeded, incident)
+}
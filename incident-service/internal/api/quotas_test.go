@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestQuotaCounts_ResetEachWindow(t *testing.T) {
+	var counts quotaCounts
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	counts.add("datadog", start)
+	if n := counts.add("datadog", start.Add(59*time.Minute)); n != 2 {
+		t.Errorf("expected 2 incidents in the window, got %d", n)
+	}
+	if n := counts.add("sentry", start); n != 1 {
+		t.Errorf("expected providers to be counted apart, got %d", n)
+	}
+	if n := counts.add("datadog", start.Add(time.Hour)); n != 1 {
+		t.Errorf("expected the count to restart in the next window, got %d", n)
+	}
+}
+
+func TestApplyQuota_FlagsIncidentsOverQuota(t *testing.T) {
+	server := &Server{
+		config: &config.Config{
+			Ingestion: config.IngestionConfig{Quotas: map[string]int{"datadog": 2}},
+		},
+		logger: NewLogger(),
+	}
+	ctx := context.Background()
+
+	var flagged, first int
+	for i := 0; i < 5; i++ {
+		incident := &models.Incident{Provider: "datadog"}
+		if server.applyQuota(ctx, incident, server.logger) {
+			first++
+		}
+		if incident.QuotaExceeded {
+			flagged++
+		}
+	}
+	if flagged != 3 {
+		t.Errorf("expected the 3 incidents over quota to be flagged, got %d", flagged)
+	}
+	if first != 1 {
+		t.Errorf("expected one incident to be reported first over quota, got %d", first)
+	}
+
+	unlimited := &models.Incident{Provider: "sentry"}
+	if server.applyQuota(ctx, unlimited, server.logger) || unlimited.QuotaExceeded {
+		t.Error("expected providers without a quota never to be flagged")
+	}
+}
+
+func TestDispatchIncident_SkipsIncidentsOverQuota(t *testing.T) {
+	// The GitHub client is never reached, so none is configured
+	server := &Server{config: &config.Config{}, logger: NewLogger()}
+	incident := &models.Incident{ID: "inc_1", Provider: "datadog", Repository: "org/api", QuotaExceeded: true}
+
+	if err := server.DispatchIncident(context.Background(), incident); err != nil {
+		t.Errorf("DispatchIncident() error = %v", err)
+	}
+}
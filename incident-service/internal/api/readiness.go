@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+)
+
+// ReadinessResponse reports whether the service can take traffic. Dependency
+// failures make it not ready; a degraded GitHub client only adds warnings,
+// since incidents are still ingested and queued while GitHub recovers.
+type ReadinessResponse struct {
+	Status     string               `json:"status"` // ready or not_ready
+	Checks     map[string]string    `json:"checks"`
+	GitHub     *github.HealthStatus `json:"github,omitempty"`
+	MCPServers []MCPServerStatus    `json:"mcp_servers,omitempty"` // as of the last check, at startup or through /api/v1/mcp-servers
+	Warnings   []string             `json:"warnings,omitempty"`
+}
+
+// handleReadyz reports readiness for load balancers and orchestrators
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	logger := s.loggerFrom(ctx)
+
+	response := ReadinessResponse{
+		Status: "ready",
+		Checks: make(map[string]string),
+	}
+
+	if s.db != nil {
+		if err := s.db.Health(); err != nil {
+			logger.Error("database readiness check failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+			response.Status = "not_ready"
+			response.Checks["database"] = "unhealthy"
+		} else {
+			response.Checks["database"] = "healthy"
+		}
+	}
+
+	if s.redis != nil {
+		if err := s.redis.Health(ctx); err != nil {
+			logger.Error("redis readiness check failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+			response.Status = "not_ready"
+			response.Checks["redis"] = "unhealthy"
+		} else {
+			response.Checks["redis"] = "healthy"
+		}
+	}
+
+	if s.githubClient != nil {
+		health := s.githubClient.Health()
+		response.GitHub = &health
+		response.Checks["github"] = health.Status
+		for _, warning := range health.Warnings {
+			response.Warnings = append(response.Warnings, "github: "+warning)
+		}
+	}
+	if status, ok := s.slowDrip.status(time.Now()); ok {
+		response.Warnings = append(response.Warnings, fmt.Sprintf("github: dispatching in slow-drip mode since %s, %d incidents held", status.Since.Format(time.RFC3339), status.Held))
+	}
+
+	// Invalid mappings only fail the dispatches of their services
+	for _, mapping := range s.mappings.get() {
+		if mapping.Status == mappingInvalid {
+			response.Warnings = append(response.Warnings, mapping.warning())
+		}
+	}
+
+	// Unreachable MCP servers only leave incidents with less context
+	response.MCPServers = s.mcpHealth.get()
+	for _, server := range response.MCPServers {
+		if server.Status == mcpUnreachable {
+			response.Warnings = append(response.Warnings, "mcp server "+server.Name+": "+server.Error)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if response.Status != "ready" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestHandleReadyz_GitHubDegradedIsWarning(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "3")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	githubClient := github.NewClient(server.URL, "token", "remediate.yml", 1)
+	incident := &models.Incident{ID: "inc-1", Repository: "org/repo", CreatedAt: time.Now()}
+	if _, err := githubClient.DispatchWorkflow(context.Background(), incident, "main", github.DispatchOptions{}); err != nil {
+		t.Fatalf("DispatchWorkflow() error = %v", err)
+	}
+
+	s := &Server{
+		githubClient: githubClient,
+		logger:       NewLogger(),
+	}
+
+	w := httptest.NewRecorder()
+	s.handleReadyz(w, httptest.NewRequest("GET", "/readyz", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 when only GitHub is degraded, got %d", w.Code)
+	}
+
+	var response ReadinessResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Status != "ready" {
+		t.Errorf("expected ready status, got %s", response.Status)
+	}
+	if response.Checks["github"] != "degraded" || len(response.Warnings) != 1 {
+		t.Errorf("expected degraded GitHub check with one warning, got %v %v", response.Checks, response.Warnings)
+	}
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/errorreporting"
+)
+
+// recoveryMiddleware turns a panicking handler into a 500 response, so one bad
+// payload fails its own request instead of crashing the service. The panic is
+// logged with its stack and sent to error reporting.
+func (s *Server) recoveryMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// net/http uses this panic to abort a response deliberately
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			fields := map[string]interface{}{
+				"panic":  fmt.Sprint(recovered),
+				"method": r.Method,
+				"path":   r.URL.Path,
+			}
+			errorreporting.CapturePanic(recovered, r, fields)
+
+			fields["stack"] = string(debug.Stack())
+			s.loggerFrom(r.Context()).withoutReporter().Error("recovered from panic in http handler", fields)
+
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// recoverBackground reports a panic in a background goroutine started by a
+// handler instead of letting it crash the service. It must be deferred.
+func (s *Server) recoverBackground(task string, fields map[string]interface{}) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	fields = mergeFields(fields, map[string]interface{}{
+		"panic": fmt.Sprint(recovered),
+		"task":  task,
+	})
+	errorreporting.CapturePanic(recovered, nil, fields)
+
+	fields["stack"] = string(debug.Stack())
+	s.logger.withoutReporter().Error("recovered from panic in background task", fields)
+}
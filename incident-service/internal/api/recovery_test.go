@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	s := &Server{logger: NewLogger()}
+
+	handler := s.recoveryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		payload["service"] = "checkout" // nil map write
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/webhooks/incidents", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 after a handler panic, got %d", w.Code)
+	}
+}
+
+func TestRecoveryMiddleware_AbortHandler(t *testing.T) {
+	s := &Server{logger: NewLogger()}
+
+	handler := s.recoveryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if recovered := recover(); recovered != http.ErrAbortHandler {
+			t.Errorf("expected http.ErrAbortHandler to propagate, got %v", recovered)
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+}
+
+func TestRecoverBackground(t *testing.T) {
+	s := &Server{logger: NewLogger()}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer s.recoverBackground("send notification", map[string]interface{}{"incident_id": "inc-1"})
+		panic("boom")
+	}()
+	<-done
+}
+
+func TestLogger_ErrorReporter(t *testing.T) {
+	var reported []string
+	logger := NewLogger()
+	logger.SetErrorReporter(func(message string, fields map[string]interface{}) {
+		reported = append(reported, message)
+	})
+
+	child := logger.With(map[string]interface{}{"request_id": "abc"})
+	child.Info("ingested", nil)
+	child.Warn("slow", nil)
+	child.Error("failed", nil)
+	logger.withoutReporter().Error("already reported", nil)
+
+	if len(reported) != 1 || reported[0] != "failed" {
+		t.Errorf("expected only the error entry to be reported, got %v", reported)
+	}
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// LinkIncidentRequest is the body of a request to link a related incident
+type LinkIncidentRequest struct {
+	IncidentID string `json:"incident_id"`
+	Reason     string `json:"reason,omitempty"` // e.g. "same database outage"
+}
+
+// handleListRelatedIncidents lists the incidents linked to an incident that
+// the principal can access
+func (s *Server) handleListRelatedIncidents(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	repository := s.repository.WithContext(r.Context())
+	if _, err := repository.GetByID(id); err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+
+	related, err := repository.ListRelatedIncidents(id)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to list related incidents", map[string]interface{}{
+			"error": err.Error(),
+			"id":    id,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	related = principalFrom(r.Context()).visibleRelated(related)
+	response := map[string]interface{}{
+		"related": related,
+		"total":   len(related),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// handleLinkIncident links an incident to another one as related. Links go
+// both ways, and linking incidents that are already related changes nothing.
+func (s *Server) handleLinkIncident(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var payload LinkIncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if payload.IncidentID == "" {
+		http.Error(w, "incident_id is required", http.StatusBadRequest)
+		return
+	}
+	if payload.IncidentID == id {
+		http.Error(w, "an incident cannot be related to itself", http.StatusBadRequest)
+		return
+	}
+
+	s.changeLink(w, r, id, payload.IncidentID, payload.Reason, true)
+}
+
+// handleUnlinkIncident removes the link between two related incidents
+func (s *Server) handleUnlinkIncident(w http.ResponseWriter, r *http.Request) {
+	s.changeLink(w, r, chi.URLParam(r, "id"), chi.URLParam(r, "relatedID"), "", false)
+}
+
+// changeLink links or unlinks two incidents, logs the change on both of their
+// timelines, and responds with the incident's related incidents. Both
+// incidents must be accessible to the principal, and only incidents of the
+// same tenant can be linked.
+func (s *Server) changeLink(w http.ResponseWriter, r *http.Request, id, relatedID, reason string, link bool) {
+	logger := s.loggerFrom(r.Context()).With(map[string]interface{}{
+		"incident_id":         id,
+		"related_incident_id": relatedID,
+	})
+
+	repository := s.repository.WithContext(r.Context())
+	p := principalFrom(r.Context())
+	tenants := map[string]bool{}
+	for _, incidentID := range []string{id, relatedID} {
+		incident, err := repository.GetByID(incidentID)
+		if err != nil || !p.canAccess(incident) {
+			http.Error(w, "incident not found: "+incidentID, http.StatusNotFound)
+			return
+		}
+		tenants[incident.TenantID] = true
+	}
+	if link && len(tenants) > 1 {
+		http.Error(w, "incidents of different tenants cannot be related", http.StatusBadRequest)
+		return
+	}
+
+	var changed bool
+	var err error
+	eventType := models.EventIncidentLinked
+	if link {
+		changed, err = repository.LinkIncidents(id, relatedID, reason)
+	} else {
+		eventType = models.EventIncidentUnlinked
+		changed, err = repository.UnlinkIncidents(id, relatedID)
+	}
+	if err != nil {
+		logger.Error("failed to change incident link", map[string]interface{}{
+			"error": err.Error(),
+			"link":  link,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if changed {
+		for _, pair := range [][2]string{{id, relatedID}, {relatedID, id}} {
+			data := map[string]interface{}{
+				"related_incident_id": pair[1],
+			}
+			if reason != "" {
+				data["reason"] = reason
+			}
+			event := &models.IncidentEvent{
+				IncidentID: pair[0],
+				EventType:  eventType,
+				EventData:  data,
+			}
+			if err := repository.LogEvent(event); err != nil {
+				logger.Error("failed to log link event", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		}
+		state := map[string]interface{}{"related_incident_id": relatedID}
+		if link {
+			if reason != "" {
+				state["reason"] = reason
+			}
+			s.recordAudit(r, models.AuditIncidentLinked, id, nil, state)
+		} else {
+			s.recordAudit(r, models.AuditIncidentUnlinked, id, state, nil)
+		}
+		logger.Info("incident link changed", map[string]interface{}{
+			"event_type": eventType,
+			"reason":     reason,
+		})
+	}
+
+	s.handleListRelatedIncidents(w, r)
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestLinkIncident_LinksBothWays(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	server := NewServer(&config.Config{Server: config.ServerConfig{Port: 8080}}, db, nil, nil, NewLogger())
+	repository := database.NewIncidentRepository(db)
+	for _, incident := range []*models.Incident{
+		{ID: "test-incident-link-a", ServiceName: "checkout", ErrorMessage: "database unreachable", Status: models.StatusPending, Provider: "test"},
+		{ID: "test-incident-link-b", ServiceName: "payments", ErrorMessage: "connection refused", Status: models.StatusPending, Provider: "test"},
+		{ID: "test-incident-link-c", ServiceName: "payments", ErrorMessage: "connection refused", Status: models.StatusPending, Provider: "test", TenantID: "acme"},
+	} {
+		if err := repository.Create(incident); err != nil {
+			t.Fatalf("failed to create test incident: %v", err)
+		}
+		id := incident.ID
+		defer func() {
+			_, _ = db.Exec("DELETE FROM incidents WHERE id = $1", id)
+		}()
+	}
+
+	send := func(method, target, body string) (int, []models.RelatedIncident) {
+		t.Helper()
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		server.Router().ServeHTTP(w, req)
+		var response struct {
+			Related []models.RelatedIncident `json:"related"`
+		}
+		if w.Code == http.StatusOK {
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+		}
+		return w.Code, response.Related
+	}
+	linkEvents := func(id string, eventType models.IncidentEventType) int {
+		t.Helper()
+		events, err := repository.GetEventsByIncidentID(id)
+		if err != nil {
+			t.Fatalf("failed to get events: %v", err)
+		}
+		n := 0
+		for _, event := range events {
+			if event.EventType == eventType {
+				n++
+			}
+		}
+		return n
+	}
+
+	code, related := send("POST", "/api/v1/incidents/test-incident-link-a/related", `{"incident_id": "test-incident-link-b", "reason": "same database outage"}`)
+	if code != http.StatusOK || len(related) != 1 || related[0].IncidentID != "test-incident-link-b" || related[0].Reason != "same database outage" {
+		t.Fatalf("expected the incidents linked, got %d %+v", code, related)
+	}
+	if _, related := send("GET", "/api/v1/incidents/test-incident-link-b/related", ""); len(related) != 1 || related[0].IncidentID != "test-incident-link-a" {
+		t.Errorf("expected the link seen from the other incident, got %+v", related)
+	}
+
+	// Linking again changes nothing
+	if code, related := send("POST", "/api/v1/incidents/test-incident-link-b/related", `{"incident_id": "test-incident-link-a"}`); code != http.StatusOK || len(related) != 1 {
+		t.Errorf("expected the existing link kept, got %d %+v", code, related)
+	}
+	for _, id := range []string{"test-incident-link-a", "test-incident-link-b"} {
+		if n := linkEvents(id, models.EventIncidentLinked); n != 1 {
+			t.Errorf("expected one incident_linked event on %s, got %d", id, n)
+		}
+	}
+
+	// Incidents of different tenants stay apart
+	if code, _ := send("POST", "/api/v1/incidents/test-incident-link-a/related", `{"incident_id": "test-incident-link-c"}`); code != http.StatusBadRequest {
+		t.Errorf("expected status %d linking across tenants, got %d", http.StatusBadRequest, code)
+	}
+
+	code, related = send("DELETE", "/api/v1/incidents/test-incident-link-b/related/test-incident-link-a", "")
+	if code != http.StatusOK || len(related) != 0 {
+		t.Fatalf("expected the link removed, got %d %+v", code, related)
+	}
+	if _, related := send("GET", "/api/v1/incidents/test-incident-link-a/related", ""); len(related) != 0 {
+		t.Errorf("expected the link removed from both incidents, got %+v", related)
+	}
+	for _, id := range []string{"test-incident-link-a", "test-incident-link-b"} {
+		if n := linkEvents(id, models.EventIncidentUnlinked); n != 1 {
+			t.Errorf("expected one incident_unlinked event on %s, got %d", id, n)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// remediationCapWindow is the period remediation caps are counted over
+const remediationCapWindow = 24 * time.Hour
+
+// remediationCounts counts the automated remediation attempts of each
+// service in the current day, for replicas without Redis
+type remediationCounts struct {
+	mu     sync.Mutex
+	day    time.Time
+	counts map[string]int64
+}
+
+// add adds delta to the attempts of service in day, and returns the
+// service's count
+func (c *remediationCounts) add(service string, day time.Time, delta int64) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !day.Equal(c.day) || c.counts == nil {
+		if day.Before(c.day) {
+			// An attempt given back after the day turned
+			return 0
+		}
+		c.day = day
+		c.counts = make(map[string]int64)
+	}
+	c.counts[service] += delta
+	return c.counts[service]
+}
+
+// manualDispatchKey marks the context of a dispatch a person asked for
+type manualDispatchKey struct{}
+
+// withManualDispatch marks ctx as a dispatch a person asked for, which
+// remediation caps do not apply to
+func withManualDispatch(ctx context.Context) context.Context {
+	return context.WithValue(ctx, manualDispatchKey{}, true)
+}
+
+// manualDispatch reports whether a person asked for the dispatch of ctx
+func manualDispatch(ctx context.Context) bool {
+	manual, _ := ctx.Value(manualDispatchKey{}).(bool)
+	return manual
+}
+
+// reserveRemediation counts an automated remediation attempt towards the
+// daily cap of the incident's service. Once the cap is reached, the incident
+// is recorded as needing manual attention and ok is false. Otherwise release
+// gives the attempt back, for incidents that were queued rather than
+// dispatched and are counted again once dequeued.
+func (s *Server) reserveRemediation(ctx context.Context, inc *models.Incident, logger *Logger) (release func(), ok bool) {
+	release = func() {}
+	if s.config == nil || manualDispatch(ctx) {
+		return release, true
+	}
+	limit := s.config.RemediationCaps.DailyCap(inc.ServiceName)
+	if limit <= 0 {
+		return release, true
+	}
+
+	day := time.Now().Truncate(remediationCapWindow)
+	count := s.countRemediation(ctx, inc.ServiceName, day, 1, logger)
+	if count <= int64(limit) {
+		return func() { s.countRemediation(ctx, inc.ServiceName, day, -1, logger) }, true
+	}
+
+	s.recordRemediationCapped(ctx, inc, limit, count == int64(limit)+1, logger)
+	return release, false
+}
+
+// countRemediation adds delta to the remediation attempts of service in day
+// and returns the service's count. Counts are kept in Redis so that every
+// replica shares them, and locally when Redis is unavailable.
+func (s *Server) countRemediation(ctx context.Context, service string, day time.Time, delta int64, logger *Logger) int64 {
+	if s.redis != nil {
+		key := fmt.Sprintf("incident-service:remediation-cap:%s:%d", service, day.Unix())
+		count, err := s.redis.IncrBy(ctx, key, delta).Result()
+		if err == nil {
+			if count == 1 && delta > 0 {
+				s.redis.Expire(ctx, key, 2*remediationCapWindow)
+			}
+			return count
+		}
+		logger.Warn("failed to count remediation attempt towards service cap, counting locally", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	return s.remediations.add(service, day, delta)
+}
+
+// recordRemediationCapped marks an incident over its service's daily cap as
+// needing manual attention, and notifies the owning team of the first one
+// in the day
+func (s *Server) recordRemediationCapped(ctx context.Context, inc *models.Incident, limit int, first bool, logger *Logger) {
+	repository := s.repository.WithContext(ctx)
+	if err := repository.UpdateStatus(inc.ID, models.StatusManualAttention); err != nil {
+		logger.Error("failed to mark incident as needing manual attention", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	inc.Status = models.StatusManualAttention
+
+	event := &models.IncidentEvent{
+		IncidentID: inc.ID,
+		EventType:  models.EventRemediationCapped,
+		EventData: map[string]interface{}{
+			"service_name": inc.ServiceName,
+			"daily_cap":    limit,
+		},
+	}
+	if err := repository.LogEvent(event); err != nil {
+		logger.Error("failed to log remediation capped event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	s.metrics.RecordRemediationCapped(inc.ServiceName)
+
+	if !first {
+		return
+	}
+	logger.Warn("service reached its daily remediation cap, leaving its incidents for manual attention", map[string]interface{}{
+		"service_name": inc.ServiceName,
+		"daily_cap":    limit,
+	})
+	s.notify(models.EventRemediationCapped, inc)
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestRemediationCounts_ResetEachDay(t *testing.T) {
+	var counts remediationCounts
+	today := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	counts.add("checkout", today, 1)
+	if n := counts.add("checkout", today, 1); n != 2 {
+		t.Errorf("expected 2 attempts today, got %d", n)
+	}
+	if n := counts.add("checkout", today, -1); n != 1 {
+		t.Errorf("expected a released attempt to be given back, got %d", n)
+	}
+	if n := counts.add("billing", today, 1); n != 1 {
+		t.Errorf("expected services to be counted apart, got %d", n)
+	}
+
+	tomorrow := today.Add(remediationCapWindow)
+	if n := counts.add("checkout", tomorrow, 1); n != 1 {
+		t.Errorf("expected the count to restart the next day, got %d", n)
+	}
+	if n := counts.add("checkout", today, -1); n != 0 {
+		t.Errorf("expected an attempt of the previous day not to count, got %d", n)
+	}
+}
+
+func TestReserveRemediation_UnderCap(t *testing.T) {
+	server := &Server{
+		config: &config.Config{
+			RemediationCaps: config.RemediationCapsConfig{Services: map[string]int{"checkout": 2}},
+		},
+		logger: NewLogger(),
+	}
+	ctx := context.Background()
+	incident := &models.Incident{ID: "inc_1", ServiceName: "checkout"}
+
+	// Queued incidents give their attempt back, so only two count
+	for i := 0; i < 3; i++ {
+		release, ok := server.reserveRemediation(ctx, incident, server.logger)
+		if !ok {
+			t.Fatalf("expected attempt %d under the cap", i+1)
+		}
+		if i == 0 {
+			release()
+		}
+	}
+
+	// People retrying by hand and uncapped services are never counted
+	if _, ok := server.reserveRemediation(withManualDispatch(ctx), incident, server.logger); !ok {
+		t.Error("expected a manual dispatch to skip the cap")
+	}
+	if _, ok := server.reserveRemediation(ctx, &models.Incident{ServiceName: "billing"}, server.logger); !ok {
+		t.Error("expected a service without a cap to be unlimited")
+	}
+}
+
+func TestDispatchIncident_RemediationCap(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	gh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer gh.Close()
+
+	cfg := &config.Config{
+		Server:          config.ServerConfig{Port: 8080},
+		Concurrency:     config.ConcurrencyConfig{MaxWorkflowsPerRepo: 5},
+		RemediationCaps: config.RemediationCapsConfig{Daily: 1},
+	}
+	server := NewServer(cfg, db, nil, github.NewClient(gh.URL, "token", "remediate.yml", 5), NewLogger())
+
+	repository := database.NewIncidentRepository(db)
+	var incidents []*models.Incident
+	for _, id := range []string{"test-incident-cap-0", "test-incident-cap-1"} {
+		incident := &models.Incident{
+			ID:           id,
+			ServiceName:  "checkout",
+			Repository:   "org/cap-test",
+			ErrorMessage: "test error",
+			Status:       models.StatusPending,
+			Provider:     "test",
+		}
+		if err := repository.Create(incident); err != nil {
+			t.Fatalf("failed to create test incident: %v", err)
+		}
+		incidents = append(incidents, incident)
+	}
+	defer func() {
+		_, _ = db.Exec("DELETE FROM incidents WHERE id LIKE 'test-incident-cap-%'")
+	}()
+
+	ctx := context.Background()
+	for _, incident := range incidents {
+		if err := server.dispatchIncident(ctx, incident, server.logger); err != nil {
+			t.Fatalf("dispatchIncident() error = %v", err)
+		}
+	}
+
+	capped, err := repository.GetByID(incidents[1].ID)
+	if err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+	if capped.Status != models.StatusManualAttention {
+		t.Fatalf("expected the incident over the cap to need manual attention, got %s", capped.Status)
+	}
+	events, err := repository.GetEventsByIncidentID(capped.ID)
+	if err != nil {
+		t.Fatalf("failed to get events: %v", err)
+	}
+	found := false
+	for _, event := range events {
+		if event.EventType == models.EventRemediationCapped {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected a remediation capped event")
+	}
+
+	// Retrying it by hand dispatches it anyway
+	if err := server.dispatchIncident(withManualDispatch(ctx), capped, server.logger); err != nil {
+		t.Fatalf("dispatchIncident() error = %v", err)
+	}
+	if capped.Status != models.StatusWorkflowTriggered {
+		t.Errorf("expected a manual retry to dispatch the incident, got %s", capped.Status)
+	}
+}
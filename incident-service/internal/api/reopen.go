@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// reopen reopens a recently resolved incident of the same service with the
+// same fingerprint, instead of storing the recurrence as a new incident, and
+// reports whether the recurrence was taken over by an earlier incident
+func (s *Server) reopen(ctx context.Context, incident *models.Incident, logger *Logger) bool {
+	if s.config == nil || s.config.Deduplication.ReopenWindow <= 0 {
+		return false
+	}
+
+	repository := s.repository.WithContext(ctx)
+	original, err := repository.FindResolvedIncident(incident.ServiceName, incident.Fingerprint, s.config.Deduplication.ReopenWindow)
+	if err != nil {
+		logger.Error("failed to check for resolved incident", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return false
+	}
+	if original == nil {
+		return false
+	}
+
+	logger = logger.With(map[string]interface{}{
+		"original_incident_id": original.ID,
+	})
+
+	count, reopened, err := repository.Reopen(original.ID)
+	if err != nil {
+		logger.Error("failed to reopen incident", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return false
+	}
+
+	// A concurrent recurrence reopened it first, so this one is only another
+	// occurrence of the reopened incident
+	if !reopened {
+		if _, err := repository.RecordOccurrence(original.ID); err != nil {
+			logger.Error("failed to record recurrence occurrence", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		return true
+	}
+
+	eventData := map[string]interface{}{
+		"recurrence_incident_id": incident.ID,
+		"provider":               incident.Provider,
+		"occurrence_count":       count,
+	}
+	if original.CompletedAt != nil {
+		eventData["resolved_at"] = original.CompletedAt
+	}
+	if original.PullRequestURL != nil {
+		eventData["previous_pull_request_url"] = *original.PullRequestURL
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: original.ID,
+		EventType:  models.EventIncidentReopened,
+		EventData:  eventData,
+	}
+	if err := repository.LogEvent(event); err != nil {
+		logger.Error("failed to log incident reopened event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	// Mirror what Reopen stored, for the notification and the dispatch
+	reopenedAt := time.Now()
+	original.Status = models.StatusPending
+	original.CompletedAt = nil
+	original.TriggeredAt = nil
+	original.WorkflowRunID = nil
+	original.PullRequestURL = nil
+	original.RetryCount = 0
+	original.NextRetryAt = nil
+	original.OccurrenceCount = count
+	original.ReopenedAt = &reopenedAt
+
+	logger.Warn("resolved incident recurred and was reopened", map[string]interface{}{
+		"occurrence_count": count,
+	})
+	s.notify(models.EventIncidentReopened, original)
+
+	// The fix did not hold, so the incident is remediated again like a new
+	// one, subject to snoozes, maintenance windows and remediation caps
+	if original.Repository != "" {
+		s.dispatchInBackground(ctx, "dispatch reopened incident", original, logger)
+	}
+
+	return true
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestReopen_DispatchesReopenedIncident(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	var dispatches int32
+	gh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/dispatches") {
+			atomic.AddInt32(&dispatches, 1)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer gh.Close()
+
+	cfg := &config.Config{
+		Server:        config.ServerConfig{Port: 8080},
+		Concurrency:   config.ConcurrencyConfig{MaxWorkflowsPerRepo: 1},
+		Deduplication: config.DeduplicationConfig{ReopenWindow: 24 * time.Hour},
+	}
+	server := NewServer(cfg, db, nil, github.NewClient(gh.URL, "token", "remediate.yml", 1), NewLogger())
+
+	repository := database.NewIncidentRepository(db)
+	fingerprint := models.ComputeFingerprint("connection pool exhausted", nil)
+	resolvedAt := time.Now().Add(-time.Hour)
+	original := &models.Incident{
+		ID:           "test-incident-reopen",
+		ServiceName:  "checkout",
+		Repository:   "org/reopen-test",
+		ErrorMessage: "connection pool exhausted",
+		Severity:     "high",
+		Status:       models.StatusPending,
+		Provider:     "test",
+		Fingerprint:  fingerprint,
+	}
+	if err := repository.Create(original); err != nil {
+		t.Fatalf("failed to create test incident: %v", err)
+	}
+	defer func() {
+		_, _ = db.Exec("DELETE FROM incidents WHERE id = $1", original.ID)
+	}()
+	original.Status = models.StatusResolved
+	original.CompletedAt = &resolvedAt
+	if err := repository.Update(original); err != nil {
+		t.Fatalf("failed to resolve test incident: %v", err)
+	}
+
+	recurrence := &models.Incident{
+		ID:           "test-incident-recurrence",
+		ServiceName:  "checkout",
+		Repository:   "org/reopen-test",
+		ErrorMessage: "connection pool exhausted",
+		Provider:     "test",
+		Fingerprint:  fingerprint,
+	}
+	if !server.reopen(context.Background(), recurrence, server.logger) {
+		t.Fatal("expected the recurrence to reopen the resolved incident")
+	}
+	server.background.Wait()
+
+	if n := atomic.LoadInt32(&dispatches); n != 1 {
+		t.Errorf("expected the reopened incident dispatched once, got %d dispatches", n)
+	}
+	stored, err := repository.GetByID(original.ID)
+	if err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+	if stored.Status != models.StatusWorkflowTriggered {
+		t.Errorf("expected workflow_triggered, got %s", stored.Status)
+	}
+	if stored.ReopenedAt == nil || time.Since(*stored.ReopenedAt) > time.Minute {
+		t.Errorf("expected reopened_at set to the reopen, got %v", stored.ReopenedAt)
+	}
+}
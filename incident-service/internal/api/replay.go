@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+)
+
+// nonceStore remembers the webhook deliveries that were accepted
+type nonceStore interface {
+	// claim records key for ttl, and reports false when it is already recorded
+	claim(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// release forgets key, so that the delivery can be accepted again
+	release(ctx context.Context, key string) error
+}
+
+// redisNonceStore shares accepted deliveries between replicas
+type redisNonceStore struct {
+	client *database.RedisClient
+}
+
+func (s redisNonceStore) claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, key, 1, ttl).Result()
+}
+
+func (s redisNonceStore) release(ctx context.Context, key string) error {
+	return s.client.Del(ctx, key).Err()
+}
+
+// replayGuard rejects webhook deliveries that were sent too long ago, or that
+// were accepted before, for adapters that implement adapters.ReplayProtected
+type replayGuard struct {
+	store  nonceStore
+	window time.Duration
+	now    func() time.Time
+}
+
+func newReplayGuard(cfg config.ReplayProtectionConfig, store nonceStore) *replayGuard {
+	return &replayGuard{store: store, window: cfg.Window(), now: time.Now}
+}
+
+// check claims the nonce of a validated delivery. It returns the claimed key,
+// empty when the adapter's deliveries carry no timestamp. Stale and repeated
+// deliveries fail with adapters.ReasonReplay; other errors mean the nonce
+// store is unavailable.
+func (g *replayGuard) check(ctx context.Context, provider string, adapter adapters.WebhookAdapter, r *http.Request, body []byte) (string, error) {
+	protected, ok := adapter.(adapters.ReplayProtected)
+	if !ok {
+		return "", nil
+	}
+	delivery, ok, err := protected.Delivery(r, body)
+	if err != nil || !ok {
+		return "", err
+	}
+
+	age := g.now().Sub(delivery.SentAt)
+	if age > g.window || age < -g.window {
+		return "", &adapters.FailureError{
+			Reason: adapters.ReasonReplay,
+			Err:    fmt.Errorf("delivery sent at %s is outside the %s replay window", delivery.SentAt.UTC().Format(time.RFC3339), g.window),
+		}
+	}
+
+	// A delivery is accepted for up to a window after it was sent, and its
+	// timestamp can be up to a window ahead of this clock
+	key := "webhook:nonce:" + provider + ":" + delivery.Nonce
+	claimed, err := g.store.claim(ctx, key, 2*g.window)
+	if err != nil {
+		return "", fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+	if !claimed {
+		return "", &adapters.FailureError{
+			Reason: adapters.ReasonReplay,
+			Err:    fmt.Errorf("delivery was already accepted"),
+		}
+	}
+	return key, nil
+}
+
+// release forgets a claimed delivery that was not accepted after all, so that
+// the provider's retry is not taken for a replay
+func (g *replayGuard) release(ctx context.Context, key string) error {
+	if key == "" {
+		return nil
+	}
+	return g.store.release(ctx, key)
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+// memoryNonceStore is a nonceStore that ignores expiry
+type memoryNonceStore struct {
+	keys map[string]bool
+	err  error
+}
+
+func (s *memoryNonceStore) claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	if s.keys[key] {
+		return false, nil
+	}
+	s.keys[key] = true
+	return true, nil
+}
+
+func (s *memoryNonceStore) release(ctx context.Context, key string) error {
+	delete(s.keys, key)
+	return nil
+}
+
+func TestReplayGuard_Check(t *testing.T) {
+	t.Setenv("SENTRY_WEBHOOK_SECRET", "s3cret")
+	adapter := adapters.NewSentryAdapter()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	store := &memoryNonceStore{keys: map[string]bool{}}
+	guard := newReplayGuard(config.ReplayProtectionConfig{Enabled: true, MaxAge: 5 * time.Minute}, store)
+	guard.now = func() time.Time { return now }
+
+	send := func(sentAt time.Time, body string) (string, error) {
+		req := httptest.NewRequest("POST", "/api/v1/webhooks/incidents", bytes.NewReader([]byte(body)))
+		if err := adapters.Sign(req.Header, "sentry", "s3cret", []byte(body)); err != nil {
+			t.Fatalf("Sign failed: %v", err)
+		}
+		req.Header.Set("Sentry-Hook-Timestamp", strconv.FormatInt(sentAt.Unix(), 10))
+		return guard.check(context.Background(), "sentry", adapter, req, []byte(body))
+	}
+
+	key, err := send(now.Add(-time.Minute), `{"action":"created","id":1}`)
+	if err != nil || key == "" {
+		t.Fatalf("expected a fresh delivery to be accepted, got %q, %v", key, err)
+	}
+	if _, err := send(now.Add(-time.Minute), `{"action":"created","id":1}`); adapters.FailureReason(err) != adapters.ReasonReplay {
+		t.Errorf("expected a repeated delivery to be rejected, got %v", err)
+	}
+	if _, err := send(now.Add(-10*time.Minute), `{"action":"created","id":2}`); adapters.FailureReason(err) != adapters.ReasonReplay {
+		t.Errorf("expected a stale delivery to be rejected, got %v", err)
+	}
+	if _, err := send(now.Add(10*time.Minute), `{"action":"created","id":3}`); adapters.FailureReason(err) != adapters.ReasonReplay {
+		t.Errorf("expected a delivery from the future to be rejected, got %v", err)
+	}
+
+	// A released delivery, such as one the ingestion queue had no room for,
+	// is accepted when the provider retries it
+	if err := guard.release(context.Background(), key); err != nil {
+		t.Fatalf("release failed: %v", err)
+	}
+	if _, err := send(now.Add(-time.Minute), `{"action":"created","id":1}`); err != nil {
+		t.Errorf("expected the retried delivery to be accepted, got %v", err)
+	}
+
+	// Store failures are not replays
+	store.err = errors.New("connection refused")
+	if _, err := send(now, `{"action":"created","id":4}`); err == nil || adapters.FailureReason(err) != adapters.ReasonOther {
+		t.Errorf("expected a store error, got %v", err)
+	}
+}
+
+func TestReplayGuard_UnprotectedAdapters(t *testing.T) {
+	guard := newReplayGuard(config.ReplayProtectionConfig{Enabled: true}, &memoryNonceStore{err: errors.New("unused")})
+	req := httptest.NewRequest("POST", "/api/v1/webhooks/incidents", nil)
+
+	// Datadog deliveries carry no timestamp, and unsigned Sentry deliveries
+	// carry no trustworthy one
+	for _, adapter := range []adapters.WebhookAdapter{adapters.NewDatadogAdapter(), &adapters.SentryAdapter{}} {
+		if key, err := guard.check(context.Background(), adapter.ProviderName(), adapter, req, nil); key != "" || err != nil {
+			t.Errorf("%s: expected no check, got %q, %v", adapter.ProviderName(), key, err)
+		}
+	}
+}
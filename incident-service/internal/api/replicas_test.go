@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// replicaHarness runs two servers against one Postgres database and one Redis
+// instance, the way two replicas of the service are deployed
+type replicaHarness struct {
+	db         *database.DB
+	replicas   [2]*Server
+	dispatches int64
+}
+
+// newReplicaHarness starts the replicas, or skips the test when Postgres or
+// Redis is unavailable
+func newReplicaHarness(t *testing.T, maxWorkflows int) *replicaHarness {
+	t.Helper()
+
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	redis, err := database.ConnectRedis("localhost:6379", "", 0)
+	if err != nil {
+		t.Skipf("redis not available: %v", err)
+	}
+	t.Cleanup(func() { redis.Close() })
+
+	h := &replicaHarness{db: db}
+	githubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&h.dispatches, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(githubServer.Close)
+
+	// Each run gets its own slots, so an interrupted run leaves nothing behind
+	prefix := fmt.Sprintf("incident-service:test:%d:", time.Now().UnixNano())
+	cfg := &config.Config{
+		Server:        config.ServerConfig{Port: 8080},
+		Deduplication: config.DeduplicationConfig{TimeWindow: time.Hour},
+		Concurrency: config.ConcurrencyConfig{
+			MaxWorkflowsPerRepo: maxWorkflows,
+			Store:               config.ConcurrencyStoreRedis,
+		},
+	}
+	for i := range h.replicas {
+		githubClient := github.NewClient(githubServer.URL, "test-token", "test-workflow.yml", maxWorkflows)
+		githubClient.SetSlotStore(database.NewRedisSlotStore(redis, prefix))
+		// Each replica reports to its own registry, as separate processes would
+		h.replicas[i] = newServer(cfg, db, redis, githubClient, NewLogger(), newMetrics(prometheus.NewRegistry()))
+	}
+	return h
+}
+
+// createIncident stores a pending incident for the repository
+func (h *replicaHarness) createIncident(t *testing.T, id, repository string) *models.Incident {
+	t.Helper()
+
+	incident := &models.Incident{
+		ID:           id,
+		ServiceName:  "replica-test",
+		Repository:   repository,
+		ErrorMessage: "test error",
+		Status:       models.StatusPending,
+		Provider:     "test",
+		ProviderData: map[string]interface{}{},
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+	if err := database.NewIncidentRepository(h.db).Create(incident); err != nil {
+		t.Fatalf("failed to create test incident: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = h.db.Exec("DELETE FROM incident_events WHERE incident_id = $1", id)
+		_, _ = h.db.Exec("DELETE FROM incidents WHERE id = $1", id)
+	})
+	return incident
+}
+
+func TestReplicas_DeduplicateConcurrentWebhooks(t *testing.T) {
+	h := newReplicaHarness(t, 2)
+	service := fmt.Sprintf("replica-dedup-%d", time.Now().UnixNano())
+	t.Cleanup(func() {
+		_, _ = h.db.Exec("DELETE FROM incident_events WHERE incident_id IN (SELECT id FROM incidents WHERE service_name = $1)", service)
+		_, _ = h.db.Exec("DELETE FROM incidents WHERE service_name = $1", service)
+	})
+
+	// The same alert fires twice, and each delivery lands on another replica
+	var wg sync.WaitGroup
+	for i, replica := range h.replicas {
+		body := fmt.Sprintf(`{"id":"%s-%d","title":"connection refused","tags":["service:%s"]}`, service, i, service)
+		wg.Add(1)
+		go func(replica *Server) {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			replica.Router().ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/webhooks/incidents?provider=datadog", strings.NewReader(body)))
+			if w.Code != http.StatusAccepted {
+				t.Errorf("expected status 202, got %d: %s", w.Code, w.Body.String())
+			}
+		}(replica)
+	}
+	wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, replica := range h.replicas {
+		if err := replica.ingestion.drain(ctx); err != nil {
+			t.Fatalf("failed to drain ingestion: %v", err)
+		}
+	}
+
+	var count int
+	if err := h.db.QueryRow("SELECT COUNT(*) FROM incidents WHERE service_name = $1", service).Scan(&count); err != nil {
+		t.Fatalf("failed to count incidents: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected one incident across replicas, got %d", count)
+	}
+}
+
+func TestReplicas_ShareConcurrencyLimit(t *testing.T) {
+	h := newReplicaHarness(t, 1)
+	repository := fmt.Sprintf("org/replica-limit-%d", time.Now().UnixNano())
+
+	// Both replicas dispatch at once, but the repository allows one workflow
+	var wg sync.WaitGroup
+	for i, replica := range h.replicas {
+		incident := h.createIncident(t, fmt.Sprintf("test-incident-replica-limit-%d", i), repository)
+		wg.Add(1)
+		go func(replica *Server) {
+			defer wg.Done()
+			if err := replica.DispatchIncident(context.Background(), incident); err != nil {
+				t.Errorf("DispatchIncident() error = %v", err)
+			}
+		}(replica)
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt64(&h.dispatches); got != 1 {
+		t.Errorf("expected 1 dispatch across replicas, got %d", got)
+	}
+	for i, replica := range h.replicas {
+		if active := replica.githubClient.GetActiveCount(repository); active != 1 {
+			t.Errorf("replica %d: expected 1 active workflow, got %d", i, active)
+		}
+		if queued := replica.githubClient.GetQueuedCount(repository); queued != 1 {
+			t.Errorf("replica %d: expected 1 queued incident, got %d", i, queued)
+		}
+	}
+}
+
+func TestReplicas_ProcessQueueFromAnyReplica(t *testing.T) {
+	h := newReplicaHarness(t, 1)
+	repository := fmt.Sprintf("org/replica-queue-%d", time.Now().UnixNano())
+
+	first := h.createIncident(t, "test-incident-replica-queue-0", repository)
+	second := h.createIncident(t, "test-incident-replica-queue-1", repository)
+	ctx := context.Background()
+	if err := h.replicas[0].DispatchIncident(ctx, first); err != nil {
+		t.Fatalf("DispatchIncident() error = %v", err)
+	}
+	if err := h.replicas[0].DispatchIncident(ctx, second); err != nil {
+		t.Fatalf("DispatchIncident() error = %v", err)
+	}
+	if queued := h.replicas[1].githubClient.GetQueuedCount(repository); queued != 1 {
+		t.Fatalf("expected the second incident queued on both replicas, got %d", queued)
+	}
+
+	// The first workflow finishes on the other replica, which dispatches the
+	// incident queued by the first one
+	h.replicas[1].ReleaseWorkflowSlot(ctx, repository, first.ID)
+
+	repo := database.NewIncidentRepository(h.db)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		stored, err := repo.GetByID(second.ID)
+		if err != nil {
+			t.Fatalf("failed to get incident: %v", err)
+		}
+		if stored.Status == models.StatusWorkflowTriggered {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the queued incident to be dispatched, got %s", stored.Status)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if got := atomic.LoadInt64(&h.dispatches); got != 2 {
+		t.Errorf("expected 2 dispatches, got %d", got)
+	}
+	if queued := h.replicas[0].githubClient.GetQueuedCount(repository); queued != 0 {
+		t.Errorf("expected an empty queue, got %d", queued)
+	}
+}
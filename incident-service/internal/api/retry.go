@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// handleRetryIncident resets a failed incident, or one left for manual
+// attention, to pending and dispatches its remediation again, whether or not
+// automatic retries are exhausted or its service's remediation cap is reached
+func (s *Server) handleRetryIncident(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	logger := s.loggerFrom(r.Context()).With(map[string]interface{}{
+		"incident_id": id,
+	})
+
+	repository := s.repository.WithContext(r.Context())
+	incident, err := repository.GetByID(id)
+	if err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+
+	if incident.Status != models.StatusFailed && incident.Status != models.StatusManualAttention {
+		http.Error(w, fmt.Sprintf("only failed incidents and incidents needing manual attention can be retried, incident is %s", incident.Status), http.StatusConflict)
+		return
+	}
+	if incident.Repository == "" {
+		http.Error(w, "incident has no repository to remediate", http.StatusConflict)
+		return
+	}
+
+	before := incidentAuditState(incident)
+	previousStatus := incident.Status
+	incident.Status = models.StatusPending
+	incident.NextRetryAt = nil
+	incident.TriggeredAt = nil
+	incident.CompletedAt = nil
+	incident.WorkflowRunID = nil
+	incident.PullRequestURL = nil
+
+	if err := repository.Update(incident); err != nil {
+		logger.Error("failed to reset incident for retry", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: incident.ID,
+		EventType:  models.EventManualTrigger,
+		EventData: map[string]interface{}{
+			"previous_status": previousStatus,
+			"retry_count":     incident.RetryCount,
+		},
+	}
+	if err := repository.LogEvent(event); err != nil {
+		logger.Error("failed to log manual trigger event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	s.recordAudit(r, models.AuditIncidentRetried, incident.ID, before, incidentAuditState(incident))
+
+	logger.Info("manual remediation retry requested", map[string]interface{}{
+		"repository": incident.Repository,
+	})
+
+	if s.githubClient != nil {
+		snapshot := *incident
+		spanContext := trace.SpanContextFromContext(r.Context())
+		s.goBackground("dispatch retried incident", map[string]interface{}{
+			"incident_id": snapshot.ID,
+			"repository":  snapshot.Repository,
+		}, func() {
+			ctx, cancel := context.WithTimeout(trace.ContextWithSpanContext(context.Background(), spanContext), 30*time.Second)
+			defer cancel()
+
+			// A person asked for it, so the service's remediation cap does not apply
+			_ = s.dispatchIncident(withManualDispatch(ctx), &snapshot, logger)
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(s.newIncidentResponse(incident))
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// SetRules applies the outcome of custom rules to new incidents: a
+// repository set by a rule overrides the service mapping, and an incident
+// whose remediation a rule skips is stored as suppressed and not dispatched
+func (s *Server) SetRules(rules models.RuleEvaluator) {
+	s.rules = rules
+}
+
+// applyRules evaluates the custom rules against a new incident before it is
+// stored, and sets the repository and status they decide on
+func (s *Server) applyRules(incident *models.Incident) models.RuleOutcome {
+	if s.rules == nil {
+		return models.RuleOutcome{}
+	}
+	outcome := s.rules.Outcome(incident)
+	if outcome.Repository != "" {
+		incident.Repository = outcome.Repository
+		if incident.Status == models.StatusUnmapped {
+			incident.Status = models.StatusPending
+		}
+	}
+	if outcome.SkipRemediation {
+		incident.Status = models.StatusSuppressed
+	}
+	return outcome
+}
+
+// logRuleOutcome adds the decisions of custom rules to the timeline of the
+// stored incident
+func (s *Server) logRuleOutcome(ctx context.Context, incident *models.Incident, outcome models.RuleOutcome, logger *Logger) {
+	var events []*models.IncidentEvent
+	if outcome.Repository != "" {
+		data := map[string]interface{}{
+			"rule":       outcome.RepositorySetBy,
+			"repository": outcome.Repository,
+		}
+		if s.config != nil {
+			if mapping := s.config.MappingFor(incident.ServiceName); mapping != nil {
+				data["mapped_repository"] = mapping.Repository
+			}
+		}
+		events = append(events, &models.IncidentEvent{
+			IncidentID: incident.ID,
+			EventType:  models.EventRepositoryOverridden,
+			EventData:  data,
+		})
+	}
+	if outcome.SkipRemediation {
+		events = append(events, &models.IncidentEvent{
+			IncidentID: incident.ID,
+			EventType:  models.EventRemediationSuppressed,
+			EventData: map[string]interface{}{
+				"rule": outcome.SkippedBy,
+			},
+		})
+		logger.Info("remediation suppressed by custom rule", map[string]interface{}{
+			"rule": outcome.SkippedBy,
+		})
+	}
+	for _, event := range events {
+		if err := s.repository.WithContext(ctx).LogEvent(event); err != nil {
+			logger.Error("failed to log custom rule event", map[string]interface{}{
+				"error":      err.Error(),
+				"event_type": event.EventType,
+			})
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestApplyRules(t *testing.T) {
+	batch, override := "batch", "org/batch-jobs"
+	s := &Server{}
+	s.SetRules(config.NewRuleEngine([]config.CustomRule{
+		{Name: "batch-repo", Enabled: true, Conditions: config.RuleConditions{ServiceName: &batch}, Actions: config.RuleActions{SetRepository: &override}},
+		{Name: "known-flake", Enabled: true, Conditions: config.RuleConditions{ErrorPattern: strPtr("flaky")}, Actions: config.RuleActions{SkipRemediation: true}},
+	}))
+
+	tests := []struct {
+		name           string
+		incident       models.Incident
+		wantRepository string
+		wantStatus     models.IncidentStatus
+	}{
+		{"no rule", models.Incident{ServiceName: "checkout", ErrorMessage: "boom", Repository: "org/checkout", Status: models.StatusPending}, "org/checkout", models.StatusPending},
+		{"repository set for an unmapped service", models.Incident{ServiceName: "batch", ErrorMessage: "boom", Status: models.StatusUnmapped}, override, models.StatusPending},
+		{"remediation skipped", models.Incident{ServiceName: "checkout", ErrorMessage: "flaky test", Status: models.StatusPending}, "", models.StatusSuppressed},
+		{"both", models.Incident{ServiceName: "batch", ErrorMessage: "flaky job", Status: models.StatusUnmapped}, override, models.StatusSuppressed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incident := tt.incident
+			s.applyRules(&incident)
+			if incident.Repository != tt.wantRepository || incident.Status != tt.wantStatus {
+				t.Errorf("expected %s and %s, got %s and %s", tt.wantRepository, tt.wantStatus, incident.Repository, incident.Status)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestProcessWebhook_SkipRemediationRule(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	var dispatches int32
+	githubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/dispatches") {
+			atomic.AddInt32(&dispatches, 1)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer githubServer.Close()
+
+	service := fmt.Sprintf("rules-test-%d", time.Now().UnixNano())
+	override := "org/rules-override"
+	cfg := &config.Config{
+		Server:      config.ServerConfig{Port: 8080},
+		Concurrency: config.ConcurrencyConfig{MaxWorkflowsPerRepo: 10},
+		CustomRules: []config.CustomRule{{
+			Name:       "known-flake",
+			Enabled:    true,
+			Conditions: config.RuleConditions{ServiceName: &service},
+			Actions:    config.RuleActions{SetRepository: &override, SkipRemediation: true},
+		}},
+	}
+	server := NewServer(cfg, db, nil, github.NewClient(githubServer.URL, "test-token", "test-workflow.yml", 10), NewLogger())
+	server.SetRules(config.NewRuleEngine(cfg.CustomRules))
+	t.Cleanup(func() {
+		_, _ = db.Exec("DELETE FROM incident_events WHERE incident_id IN (SELECT id FROM incidents WHERE service_name = $1)", service)
+		_, _ = db.Exec("DELETE FROM incidents WHERE service_name = $1", service)
+	})
+
+	body := fmt.Sprintf(`{"id":"%s","title":"flaky integration test","tags":["service:%s"]}`, service, service)
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/webhooks/incidents?provider=datadog", strings.NewReader(body)))
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected status 202, got %d: %s", w.Code, w.Body.String())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.ingestion.drain(ctx); err != nil {
+		t.Fatalf("failed to drain ingestion: %v", err)
+	}
+
+	var id string
+	if err := db.QueryRow("SELECT id FROM incidents WHERE service_name = $1", service).Scan(&id); err != nil {
+		t.Fatalf("expected the incident stored: %v", err)
+	}
+	repository := database.NewIncidentRepository(db)
+	stored, err := repository.GetByID(id)
+	if err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+	if stored.Status != models.StatusSuppressed || stored.Repository != override {
+		t.Errorf("expected the incident suppressed with the rule's repository, got %s and %q", stored.Status, stored.Repository)
+	}
+
+	events, err := repository.GetEventsByIncidentID(id)
+	if err != nil {
+		t.Fatalf("failed to get events: %v", err)
+	}
+	logged := map[models.IncidentEventType]string{}
+	for _, event := range events {
+		if rule, ok := event.EventData["rule"].(string); ok {
+			logged[event.EventType] = rule
+		}
+	}
+	if logged[models.EventRepositoryOverridden] != "known-flake" || logged[models.EventRemediationSuppressed] != "known-flake" {
+		t.Errorf("expected both rule decisions on the timeline, got %v", logged)
+	}
+
+	// The suppressed incident is recorded but never dispatched
+	if err := server.DispatchIncident(context.Background(), stored); err != nil {
+		t.Fatalf("DispatchIncident() error = %v", err)
+	}
+	server.background.Wait()
+	if n := atomic.LoadInt32(&dispatches); n != 0 {
+		t.Errorf("expected no dispatch, got %d", n)
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/json"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// IncidentResponse is an incident with the runbook and known-issue links of
+// its service and the incidents linked to it
+type IncidentResponse struct {
+	*models.Incident
+	Runbooks    []config.Link            `json:"runbooks"`
+	KnownIssues []config.Link            `json:"known_issues"`
+	Related     []models.RelatedIncident `json:"related"`
+	Similar     []models.SimilarIncident `json:"similar,omitempty"` // past incidents with a similar error, when similarity search is enabled
+
+	DispatchAttempts []*models.DispatchAttempt `json:"dispatch_attempts,omitempty"` // every request made to dispatch the workflow, on the detail endpoint
+	CorrelatedAlerts []*models.CorrelatedAlert `json:"correlated_alerts,omitempty"` // alerts of other providers about the same outage, on the detail and context endpoints
+}
+
+// RunbookContext holds the links passed to the remediation workflow
+type RunbookContext struct {
+	Runbooks    []config.Link `json:"runbooks,omitempty"`
+	KnownIssues []config.Link `json:"known_issues,omitempty"`
+}
+
+// runbooksFor returns the runbook and known-issue links configured for the
+// service. Both are empty, not nil, when the service has none.
+func (s *Server) runbooksFor(serviceName string) RunbookContext {
+	links := RunbookContext{Runbooks: []config.Link{}, KnownIssues: []config.Link{}}
+	if s.config == nil {
+		return links
+	}
+	if mapping := s.config.MappingFor(serviceName); mapping != nil {
+		links.Runbooks = append(links.Runbooks, mapping.Runbooks...)
+		links.KnownIssues = append(links.KnownIssues, mapping.KnownIssues...)
+	}
+	return links
+}
+
+// runbookInput returns the service's links as the JSON runbooks input of the
+// remediation workflow, or an empty string when the service has none, so that
+// workflows that do not declare the input keep working
+func (s *Server) runbookInput(serviceName string) string {
+	links := s.runbooksFor(serviceName)
+	if len(links.Runbooks) == 0 && len(links.KnownIssues) == 0 {
+		return ""
+	}
+	encoded, err := json.Marshal(links)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
+
+// newIncidentResponse attaches the links of the incident's service
+func (s *Server) newIncidentResponse(incident *models.Incident) IncidentResponse {
+	links := s.runbooksFor(incident.ServiceName)
+	return IncidentResponse{
+		Incident:    incident,
+		Runbooks:    links.Runbooks,
+		KnownIssues: links.KnownIssues,
+		Related:     []models.RelatedIncident{},
+	}
+}
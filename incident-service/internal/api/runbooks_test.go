@@ -0,0 +1,54 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestServer_RunbookLinks(t *testing.T) {
+	s := &Server{logger: NewLogger(), config: &config.Config{ServiceMappings: []config.ServiceMapping{
+		{
+			ServiceName: "checkout",
+			Repository:  "org/checkout",
+			Runbooks:    []config.Link{{Title: "Checkout runbook", URL: "https://wiki.example.com/checkout"}},
+			KnownIssues: []config.Link{{URL: "https://jira.example.com/browse/OPS-12"}},
+		},
+		{ServiceName: "search", Repository: "org/search"},
+	}}}
+
+	input := s.runbookInput("checkout")
+	var decoded RunbookContext
+	if err := json.Unmarshal([]byte(input), &decoded); err != nil {
+		t.Fatalf("expected a JSON runbooks input, got %q: %v", input, err)
+	}
+	if len(decoded.Runbooks) != 1 || decoded.Runbooks[0].Title != "Checkout runbook" || len(decoded.KnownIssues) != 1 {
+		t.Errorf("unexpected runbooks input: %+v", decoded)
+	}
+	if input := s.runbookInput("search"); input != "" {
+		t.Errorf("expected no runbooks input for a service without links, got %q", input)
+	}
+
+	body, err := json.Marshal(s.newIncidentResponse(&models.Incident{ID: "inc_1", ServiceName: "checkout"}))
+	if err != nil {
+		t.Fatalf("failed to encode response: %v", err)
+	}
+	var response map[string]interface{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response["id"] != "inc_1" {
+		t.Errorf("expected the incident fields at the top level, got %s", body)
+	}
+	if runbooks, ok := response["runbooks"].([]interface{}); !ok || len(runbooks) != 1 {
+		t.Errorf("expected one runbook in the response, got %s", body)
+	}
+
+	// Without configuration, the links are empty lists rather than null
+	empty := (&Server{logger: NewLogger()}).newIncidentResponse(&models.Incident{ID: "inc_2"})
+	if empty.Runbooks == nil || empty.KnownIssues == nil {
+		t.Error("expected empty link lists without configuration")
+	}
+}
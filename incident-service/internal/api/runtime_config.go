@@ -0,0 +1,97 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// configSecretKeys are configuration fields holding credentials that the
+// log redactor does not recognise by name
+var configSecretKeys = []string{
+	"key",
+	"previous_keys",
+	"signing_key",
+	"access_key_id",
+	"routing_key",
+	"pagerduty_routing_key",
+	"default_webhook_url",
+	"teams_webhook_url",
+	"headers",
+}
+
+// runtimeConfig returns the configuration the service runs with, as its
+// YAML keys, with credentials masked. Service mappings and teams are left
+// out, the config endpoint returns them on their own. Custom rules keep only
+// their name, description and whether they are enabled unless includeRules
+// is set, since their patterns can quote customer data.
+func runtimeConfig(cfg *config.Config, includeRules bool) (map[string]interface{}, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	var runtime map[string]interface{}
+	if err := yaml.Unmarshal(data, &runtime); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	delete(runtime, "service_mappings")
+	delete(runtime, "teams")
+	if !includeRules {
+		rules := make([]interface{}, 0, len(cfg.CustomRules))
+		for _, rule := range cfg.CustomRules {
+			rules = append(rules, map[string]interface{}{
+				"name":        rule.Name,
+				"description": rule.Description,
+				"enabled":     rule.Enabled,
+			})
+		}
+		runtime["custom_rules"] = rules
+	}
+
+	return redactConfig(newRedactor(configSecretKeys), runtime).(map[string]interface{}), nil
+}
+
+// redactConfig masks the sensitive values of a configuration tree. Unlike
+// log redaction, settings left empty stay empty, so that it shows which
+// credentials are configured.
+func redactConfig(r *redactor, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redactedMap := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if r.sensitive(key) && !emptyConfigValue(item) {
+				redactedMap[key] = redacted
+				continue
+			}
+			redactedMap[key] = redactConfig(r, item)
+		}
+		return redactedMap
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = redactConfig(r, item)
+		}
+		return items
+	case string:
+		return r.text(v)
+	default:
+		return value
+	}
+}
+
+// emptyConfigValue reports whether a configuration value was left unset
+func emptyConfigValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+func TestRuntimeConfig_RedactsSecrets(t *testing.T) {
+	pattern := "card number \\d+"
+	cfg := &config.Config{
+		Database:      config.DatabaseConfig{Host: "db", Password: "s3cret"},
+		GitHub:        config.GitHubConfig{Token: "ghp_token"},
+		Deduplication: config.DeduplicationConfig{TimeWindow: 5 * time.Minute},
+		Concurrency:   config.ConcurrencyConfig{MaxWorkflowsPerRepo: 2},
+		MCPServers: []config.MCPServerConfig{
+			{Name: "grafana", Type: config.MCPTypeHTTP, Config: map[string]string{"url": "http://grafana-mcp", "token": "mcp-token"}},
+		},
+		CustomRules: []config.CustomRule{
+			{Name: "pci", Enabled: true, Conditions: config.RuleConditions{ErrorPattern: &pattern}},
+		},
+		Notifications: config.NotificationsConfig{
+			Webhooks: []config.WebhookConfig{{Name: "audit", URL: "https://hooks.example.com", Headers: map[string]string{"Authorization": "Basic abc"}}},
+		},
+		ServiceMappings: []config.ServiceMapping{{ServiceName: "checkout", Repository: "org/checkout"}},
+	}
+
+	runtime, err := runtimeConfig(cfg, false)
+	if err != nil {
+		t.Fatalf("runtimeConfig() error = %v", err)
+	}
+	data, err := json.Marshal(runtime)
+	if err != nil {
+		t.Fatalf("failed to marshal runtime config: %v", err)
+	}
+	var got struct {
+		Database struct {
+			Host     string `json:"host"`
+			Password string `json:"password"`
+		} `json:"database"`
+		Redis struct {
+			Password string `json:"password"`
+		} `json:"redis"`
+		GitHub struct {
+			Token string `json:"token"`
+		} `json:"github"`
+		Deduplication struct {
+			TimeWindow string `json:"time_window"`
+		} `json:"deduplication"`
+		Concurrency struct {
+			MaxWorkflowsPerRepo int `json:"max_workflows_per_repo"`
+		} `json:"concurrency"`
+		MCPServers []struct {
+			Config map[string]string `json:"config"`
+		} `json:"mcp_servers"`
+		CustomRules   []map[string]interface{} `json:"custom_rules"`
+		Notifications struct {
+			Webhooks []struct {
+				URL     string      `json:"url"`
+				Headers interface{} `json:"headers"`
+			} `json:"webhooks"`
+		} `json:"notifications"`
+		ServiceMappings interface{} `json:"service_mappings"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("failed to decode runtime config: %v", err)
+	}
+
+	if got.Database.Host != "db" || got.Database.Password != redacted || got.GitHub.Token != redacted {
+		t.Errorf("expected credentials masked and the rest kept, got %+v %+v", got.Database, got.GitHub)
+	}
+	if got.Redis.Password != "" {
+		t.Errorf("expected an unset password to stay empty, got %q", got.Redis.Password)
+	}
+	if got.Deduplication.TimeWindow != "5m0s" || got.Concurrency.MaxWorkflowsPerRepo != 2 {
+		t.Errorf("expected the dedup window and concurrency, got %+v %+v", got.Deduplication, got.Concurrency)
+	}
+	if len(got.MCPServers) != 1 || got.MCPServers[0].Config["token"] != redacted || got.MCPServers[0].Config["url"] != "http://grafana-mcp" {
+		t.Errorf("expected the MCP server token masked, got %+v", got.MCPServers)
+	}
+	if len(got.Notifications.Webhooks) != 1 || got.Notifications.Webhooks[0].Headers != redacted {
+		t.Errorf("expected webhook headers masked, got %+v", got.Notifications.Webhooks)
+	}
+	if len(got.CustomRules) != 1 || got.CustomRules[0]["name"] != "pci" || got.CustomRules[0]["conditions"] != nil {
+		t.Errorf("expected rules without their details, got %v", got.CustomRules)
+	}
+	if got.ServiceMappings != nil {
+		t.Error("expected service mappings left to their own section")
+	}
+
+	detailed, err := runtimeConfig(cfg, true)
+	if err != nil {
+		t.Fatalf("runtimeConfig() error = %v", err)
+	}
+	rules := detailed["custom_rules"].([]interface{})
+	conditions := rules[0].(map[string]interface{})["conditions"].(map[string]interface{})
+	if conditions["error_pattern"] != pattern {
+		t.Errorf("expected rule details when asked for, got %v", rules[0])
+	}
+}
+
+func TestHandleGetConfig_IncludeRulesRequiresAdmin(t *testing.T) {
+	s := newAuthTestServer(config.AuthConfig{APIKeys: []config.APIKey{
+		{Name: "ops-cli", Key: "cli-secret"},
+		{Name: "sre", Key: "admin-secret", Admin: true},
+	}})
+
+	tests := []struct {
+		name string
+		key  string
+		want int
+	}{
+		{"admin key", "admin-secret", http.StatusOK},
+		{"other key", "cli-secret", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/config?include_rules=true", nil)
+			req.Header.Set("X-API-Key", tt.key)
+			w := httptest.NewRecorder()
+			s.Router().ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+
+	// Without the flag any key may read the configuration
+	req := httptest.NewRequest("GET", "/api/v1/config", nil)
+	req.Header.Set("X-API-Key", "cli-secret")
+	w := httptest.NewRecorder()
+	s.Router().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d", http.StatusOK, w.Code)
+	}
+	var response ConfigResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	server := response.Runtime["server"].(map[string]interface{})
+	keys := server["auth"].(map[string]interface{})["api_keys"].([]interface{})
+	if key := keys[0].(map[string]interface{})["key"]; key != redacted {
+		t.Errorf("expected API keys masked, got %v", key)
+	}
+}
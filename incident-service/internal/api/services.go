@@ -0,0 +1,153 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// openServiceStatuses are the statuses of incidents still awaiting an outcome
+var openServiceStatuses = map[models.IncidentStatus]bool{
+	models.StatusPending:           true,
+	models.StatusWorkflowTriggered: true,
+	models.StatusInProgress:        true,
+}
+
+// ServiceOverview is the incident activity of a mapped service. Open counts
+// are current; the totals, success rate and mean time to resolve cover the
+// requested time range.
+type ServiceOverview struct {
+	ServiceName    string         `json:"service_name"`
+	Repository     string         `json:"repository"`
+	Team           string         `json:"team,omitempty"`
+	Tenant         string         `json:"tenant"`
+	OpenIncidents  int            `json:"open_incidents"`
+	OpenByStatus   map[string]int `json:"open_by_status"`
+	OpenBySeverity map[string]int `json:"open_by_severity"`
+	LastIncidentAt *time.Time     `json:"last_incident_at,omitempty"`
+	*database.IncidentStatistics
+}
+
+// handleListServices returns the incident activity of every mapped service
+// the caller can see, ordered as in the service mappings. It covers the last
+// 7 days by default.
+func (s *Server) handleListServices(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseRollupFilter(r.URL.Query(), database.RollupHourly, 7*24*time.Hour, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Open counts span every severity and provider, so the range statistics do too
+	filter.Severity = nil
+	filter.Provider = nil
+
+	p := principalFrom(r.Context())
+	incidentFilter := &database.IncidentFilter{
+		ServiceName: filter.ServiceName,
+		Team:        filter.Team,
+		TenantID:    filter.TenantID,
+	}
+	if !p.scopeRollups(filter) || !p.scopeIncidents(incidentFilter) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	repository := s.repository.WithContext(r.Context())
+	counts, err := repository.CountIncidentsByService(incidentFilter)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to count incidents by service", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	stats, err := repository.GetRollupStatisticsByService(filter)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to get service statistics", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var mappings []config.ServiceMapping
+	if s.config != nil {
+		for _, mapping := range s.config.ServiceMappings {
+			tenant := s.config.TenantFor(mapping.ServiceName)
+			if !p.allows(tenant, mapping.Team) || !matchesServiceFilter(filter, mapping, tenant) {
+				continue
+			}
+			mappings = append(mappings, mapping)
+		}
+	}
+	services := buildServiceOverviews(mappings, s.config, counts, stats)
+
+	response := map[string]interface{}{
+		"start":    filter.Start,
+		"end":      filter.End,
+		"services": services,
+		"total":    len(services),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// matchesServiceFilter reports whether a mapping passes the service, team
+// and tenant filters of the request
+func matchesServiceFilter(filter *database.RollupFilter, mapping config.ServiceMapping, tenant string) bool {
+	if filter.ServiceName != nil && *filter.ServiceName != mapping.ServiceName {
+		return false
+	}
+	if filter.Team != nil && *filter.Team != mapping.Team {
+		return false
+	}
+	return filter.TenantID == nil || *filter.TenantID == tenant
+}
+
+// buildServiceOverviews combines the incident counts and range statistics of
+// each mapped service. Services without incidents are listed with zeros.
+func buildServiceOverviews(mappings []config.ServiceMapping, cfg *config.Config, counts []database.ServiceIncidentCount, stats []database.ServiceStatistics) []ServiceOverview {
+	byService := make(map[string]*ServiceOverview, len(mappings))
+	services := make([]ServiceOverview, len(mappings))
+	for i, mapping := range mappings {
+		services[i] = ServiceOverview{
+			ServiceName:        mapping.ServiceName,
+			Repository:         mapping.Repository,
+			Team:               mapping.Team,
+			Tenant:             cfg.TenantFor(mapping.ServiceName),
+			OpenByStatus:       map[string]int{},
+			OpenBySeverity:     map[string]int{},
+			IncidentStatistics: &database.IncidentStatistics{},
+		}
+		byService[mapping.ServiceName] = &services[i]
+	}
+
+	for _, count := range counts {
+		service, ok := byService[count.ServiceName]
+		if !ok {
+			continue
+		}
+		if service.LastIncidentAt == nil || count.LastCreatedAt.After(*service.LastIncidentAt) {
+			last := count.LastCreatedAt
+			service.LastIncidentAt = &last
+		}
+		if openServiceStatuses[count.Status] {
+			service.OpenIncidents += count.Count
+			service.OpenByStatus[string(count.Status)] += count.Count
+			service.OpenBySeverity[count.Severity] += count.Count
+		}
+	}
+
+	for _, stat := range stats {
+		if service, ok := byService[stat.ServiceName]; ok {
+			service.IncidentStatistics = stat.IncidentStatistics
+		}
+	}
+
+	return services
+}
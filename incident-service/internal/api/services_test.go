@@ -0,0 +1,59 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestBuildServiceOverviews(t *testing.T) {
+	cfg := &config.Config{
+		ServiceMappings: []config.ServiceMapping{
+			{ServiceName: "api", Repository: "org/api", Team: "payments", Tenant: "retail"},
+			{ServiceName: "worker", Repository: "org/worker"},
+		},
+	}
+	earlier := time.Date(2024, 3, 6, 10, 0, 0, 0, time.UTC)
+	later := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+
+	counts := []database.ServiceIncidentCount{
+		{ServiceName: "api", Status: models.StatusPending, Severity: "high", Count: 2, LastCreatedAt: earlier},
+		{ServiceName: "api", Status: models.StatusInProgress, Severity: "critical", Count: 1, LastCreatedAt: earlier},
+		{ServiceName: "api", Status: models.StatusResolved, Severity: "high", Count: 5, LastCreatedAt: later},
+		// Incidents of unmapped services are left out
+		{ServiceName: "legacy", Status: models.StatusPending, Severity: "low", Count: 3, LastCreatedAt: later},
+	}
+	stats := []database.ServiceStatistics{
+		{ServiceName: "api", IncidentStatistics: &database.IncidentStatistics{TotalIncidents: 8, ResolvedIncidents: 5, SuccessRate: 0.625, MeanTimeToResolve: 600}},
+	}
+
+	services := buildServiceOverviews(cfg.ServiceMappings, cfg, counts, stats)
+	if len(services) != 2 {
+		t.Fatalf("expected both mapped services, got %+v", services)
+	}
+
+	api := services[0]
+	if api.ServiceName != "api" || api.Tenant != "retail" || api.Team != "payments" {
+		t.Errorf("unexpected service %+v", api)
+	}
+	if api.OpenIncidents != 3 || api.OpenByStatus["pending"] != 2 || api.OpenByStatus["in_progress"] != 1 {
+		t.Errorf("unexpected open counts %d %v", api.OpenIncidents, api.OpenByStatus)
+	}
+	if api.OpenBySeverity["high"] != 2 || api.OpenBySeverity["critical"] != 1 {
+		t.Errorf("expected resolved incidents not to count as open, got %v", api.OpenBySeverity)
+	}
+	if api.LastIncidentAt == nil || !api.LastIncidentAt.Equal(later) {
+		t.Errorf("expected the latest incident of any status, got %v", api.LastIncidentAt)
+	}
+	if api.SuccessRate != 0.625 || api.MeanTimeToResolve != 600 {
+		t.Errorf("unexpected statistics %+v", api.IncidentStatistics)
+	}
+
+	worker := services[1]
+	if worker.Tenant != config.DefaultTenant || worker.OpenIncidents != 0 || worker.LastIncidentAt != nil || worker.TotalIncidents != 0 {
+		t.Errorf("expected a quiet service with zeros, got %+v", worker)
+	}
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/severity"
+)
+
+// SetSeverityClassifier suggests a severity with classifier for incidents
+// whose provider gave none that maps
+func (s *Server) SetSeverityClassifier(classifier *severity.Classifier) {
+	s.classifier = classifier
+}
+
+// suggestSeverity records on the incident the severity the classifier
+// suggests, when its provider gave none that maps. The incident's severity is
+// left as the provider's, so the suggestion changes nothing on its own.
+func (s *Server) suggestSeverity(inc *models.Incident) (severity.Suggestion, bool) {
+	if s.classifier == nil || !inc.SeverityDefaulted {
+		return severity.Suggestion{}, false
+	}
+	suggestion, ok := s.classifier.Suggest(inc.ServiceName, inc.ErrorMessage)
+	if !ok {
+		s.metrics.RecordSeveritySuggestion("none")
+		return severity.Suggestion{}, false
+	}
+	s.metrics.RecordSeveritySuggestion(suggestion.Method)
+	inc.SuggestedSeverity = suggestion.Severity
+	return suggestion, true
+}
+
+// logSeveritySuggestion adds the suggestion to the timeline of the stored incident
+func (s *Server) logSeveritySuggestion(ctx context.Context, inc *models.Incident, suggestion severity.Suggestion, logger *Logger) {
+	data := map[string]interface{}{
+		"provider_severity":  inc.Severity,
+		"suggested_severity": suggestion.Severity,
+		"method":             suggestion.Method,
+		"confidence":         suggestion.Confidence,
+	}
+	if suggestion.Keyword != "" {
+		data["keyword"] = suggestion.Keyword
+	}
+	event := &models.IncidentEvent{
+		IncidentID: inc.ID,
+		EventType:  models.EventSeveritySuggested,
+		EventData:  data,
+	}
+	if err := s.repository.WithContext(ctx).LogEvent(event); err != nil {
+		logger.Error("failed to log severity suggested event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/shutdown"
+)
+
+// goBackground runs task in a goroutine that Shutdown waits for
+func (s *Server) goBackground(task string, fields map[string]interface{}, fn func()) {
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		defer s.recoverBackground(task, fields)
+		fn()
+	}()
+}
+
+// Shutdown finishes the work the server accepted before it stopped serving
+// requests. It ends live tails, processes queued webhooks, waits for
+// background dispatches and notifications, then resends notifications that
+// are due for a retry. It gives up when ctx is done. Call it after the HTTP
+// server and the background workers have stopped.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.live.close()
+
+	if s.ingestion != nil {
+		if err := s.ingestion.drain(ctx); err != nil {
+			return fmt.Errorf("failed to drain ingestion queue: %w", err)
+		}
+	}
+
+	if err := shutdown.Wait(ctx, &s.background); err != nil {
+		return fmt.Errorf("failed to wait for background tasks: %w", err)
+	}
+
+	if s.notifier != nil && s.notifier.Enabled() {
+		if err := s.notifier.RetryDue(ctx); err != nil {
+			return fmt.Errorf("failed to flush notification retries: %w", err)
+		}
+	}
+
+	return nil
+}
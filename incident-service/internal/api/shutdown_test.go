@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+func TestServerShutdown_WaitsForBackgroundTasks(t *testing.T) {
+	s := &Server{logger: NewLogger()}
+
+	var finished int32
+	for i := 0; i < 3; i++ {
+		s.goBackground("dispatch queued incident", nil, func() {
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&finished, 1)
+		})
+	}
+	// A panicking task must not keep shutdown waiting
+	s.goBackground("send notification", nil, func() { panic("boom") })
+
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&finished); got != 3 {
+		t.Errorf("expected all background tasks to finish before Shutdown returns, got %d", got)
+	}
+}
+
+func TestServerShutdown_DrainsIngestion(t *testing.T) {
+	s := &Server{logger: NewLogger()}
+
+	var processed int32
+	s.ingestion = newIngestionPool(config.IngestionConfig{Workers: 1}, func(job ingestJob) {
+		// Processing a webhook can start background work of its own
+		s.goBackground("send notification", nil, func() {
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&processed, 1)
+		})
+	}, ingestionGauges{depth: prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_ingestion_depth"})})
+
+	for i := 0; i < 5; i++ {
+		if err := s.ingestion.enqueue(ingestJob{provider: "datadog"}); err != nil {
+			t.Fatalf("enqueue() error = %v", err)
+		}
+	}
+
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&processed); got != 5 {
+		t.Errorf("expected queued webhooks and their notifications to finish, got %d", got)
+	}
+}
+
+func TestServerShutdown_Timeout(t *testing.T) {
+	s := &Server{logger: NewLogger()}
+
+	release := make(chan struct{})
+	defer close(release)
+	s.goBackground("dispatch queued incident", nil, func() { <-release })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.Shutdown(ctx); err == nil {
+		t.Error("expected Shutdown to give up at the deadline")
+	}
+}
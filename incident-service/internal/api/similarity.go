@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/enrichment"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/similarity"
+)
+
+// similarSourceName names the similar incidents in a context bundle
+const similarSourceName = "similar_incidents"
+
+// SetEmbedder embeds the error message of each incident once it is stored,
+// and lists similar past incidents on the incident detail endpoint
+func (s *Server) SetEmbedder(embedder *similarity.Embedder) {
+	s.embedder = embedder
+}
+
+// embed stores the embedding of an incident in the background. Incidents
+// that fail are embedded again the first time their similar incidents are
+// looked up.
+func (s *Server) embed(inc *models.Incident) {
+	if s.embedder == nil {
+		return
+	}
+
+	snapshot := *inc
+	fields := map[string]interface{}{"incident_id": snapshot.ID}
+	s.goBackground("embed incident", fields, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if _, err := s.embedIncident(ctx, &snapshot); err != nil {
+			s.logger.With(fields).Warn("failed to embed incident", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	})
+}
+
+// embedIncident embeds the incident's error message and stores the result
+func (s *Server) embedIncident(ctx context.Context, inc *models.Incident) ([]float64, error) {
+	vector, err := s.embedder.Embed(ctx, inc)
+	if err != nil {
+		s.metrics.RecordEmbedding("error")
+		return nil, err
+	}
+	s.metrics.RecordEmbedding("success")
+
+	if err := s.repository.WithContext(ctx).SetEmbedding(inc.ID, s.embedder.Model(), vector); err != nil {
+		return nil, err
+	}
+	return vector, nil
+}
+
+// similarIncidents returns the past incidents of the incident's tenant whose
+// error messages resemble its own and that the principal may see, most
+// similar first. Failures are logged and return no incidents, so that they
+// never fail the caller.
+func (s *Server) similarIncidents(ctx context.Context, inc *models.Incident, p *principal, logger *Logger) []models.SimilarIncident {
+	similar := []models.SimilarIncident{}
+	if s.embedder == nil {
+		return similar
+	}
+	cfg := s.config.Similarity
+	repository := s.repository.WithContext(ctx)
+
+	vector, err := repository.GetEmbedding(inc.ID, s.embedder.Model())
+	if err == nil && vector == nil {
+		// Incidents stored before similarity search was enabled, or whose
+		// embedding failed, are embedded on first lookup
+		vector, err = s.embedIncident(ctx, inc)
+	}
+	if err != nil {
+		logger.Warn("failed to embed incident", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return similar
+	}
+
+	candidates, err := repository.ListEmbeddings(database.EmbeddingFilter{
+		TenantID: inc.TenantID,
+		Model:    s.embedder.Model(),
+		Since:    time.Now().Add(-cfg.LookbackWindow()),
+		Exclude:  inc.ID,
+		Limit:    cfg.CandidateLimit(),
+	})
+	if err != nil {
+		logger.Error("failed to list embeddings", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return similar
+	}
+
+	// Rank every match, since some may be hidden from the principal
+	for _, match := range similarity.Rank(vector, candidates, cfg.Threshold(), len(candidates)) {
+		if len(similar) == cfg.ResultLimit() {
+			break
+		}
+		past, err := repository.GetByID(match.IncidentID)
+		if err != nil {
+			logger.Error("failed to get similar incident", map[string]interface{}{
+				"error":       err.Error(),
+				"incident_id": match.IncidentID,
+			})
+			continue
+		}
+		if !p.canAccess(past) {
+			continue
+		}
+		similar = append(similar, models.SimilarIncident{
+			IncidentID:     past.ID,
+			ServiceName:    past.ServiceName,
+			Status:         past.Status,
+			Severity:       past.Severity,
+			Team:           past.Team,
+			TenantID:       past.TenantID,
+			ErrorMessage:   past.ErrorMessage,
+			Diagnosis:      past.Diagnosis,
+			PullRequestURL: past.PullRequestURL,
+			Score:          match.Score,
+			CreatedAt:      past.CreatedAt,
+		})
+	}
+	return similar
+}
+
+// similarSource returns the similar incidents as a source of the context
+// bundle, so that the remediation workflow can learn from past fixes. It
+// returns false when there are none.
+func (s *Server) similarSource(ctx context.Context, inc *models.Incident, logger *Logger) (enrichment.Source, bool) {
+	similar := s.similarIncidents(ctx, inc, principalFrom(ctx), logger)
+	if len(similar) == 0 {
+		return enrichment.Source{}, false
+	}
+	content, err := json.Marshal(similar)
+	if err != nil {
+		logger.Error("failed to marshal similar incidents", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return enrichment.Source{}, false
+	}
+	return enrichment.Source{Name: similarSourceName, Server: "incident-service", Content: string(content)}, true
+}
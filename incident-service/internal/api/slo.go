@@ -0,0 +1,35 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/slo"
+)
+
+// handleListSLOs returns the current compliance of every configured SLO
+func (s *Server) handleListSLOs(w http.ResponseWriter, r *http.Request) {
+	results := []slo.Result{}
+
+	if len(s.config.SLOs.Objectives) > 0 {
+		evaluator := slo.NewEvaluator(s.config.SLOs.Objectives, s.repository.WithContext(r.Context()))
+
+		var err error
+		results, err = evaluator.Evaluate()
+		if err != nil {
+			s.loggerFrom(r.Context()).Error("failed to evaluate slos", map[string]interface{}{
+				"error": err.Error(),
+			})
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	response := map[string]interface{}{
+		"slos":  results,
+		"total": len(results),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+func TestHandleListSLOs_NoObjectives(t *testing.T) {
+	s := &Server{
+		config: &config.Config{},
+		logger: NewLogger(),
+	}
+
+	w := httptest.NewRecorder()
+	s.handleListSLOs(w, httptest.NewRequest("GET", "/api/v1/slos", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var response struct {
+		SLOs  []json.RawMessage `json:"slos"`
+		Total int               `json:"total"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.SLOs == nil || len(response.SLOs) != 0 || response.Total != 0 {
+		t.Errorf("expected an empty slo list, got %+v", response)
+	}
+}
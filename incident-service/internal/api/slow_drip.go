@@ -0,0 +1,211 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// slowDripReason is the reason recorded on remediations deferred in
+// slow-drip mode
+const slowDripReason = "github_slow_drip"
+
+// slowDrip is the dispatch mode a replica enters while GitHub keeps
+// answering dispatches with server errors. Like the circuit breaker whose
+// failures it follows, it is kept per replica.
+type slowDrip struct {
+	mu     sync.Mutex
+	active bool
+	since  time.Time
+	next   time.Time           // when the last incident held in the mode is due
+	held   map[string]struct{} // incidents waiting for their turn
+}
+
+// SlowDripStatus is the slow-drip mode as reported by the health checks
+type SlowDripStatus struct {
+	Since          time.Time  `json:"since"`
+	Held           int        `json:"held"` // incidents waiting for their turn
+	NextDispatchAt *time.Time `json:"next_dispatch_at,omitempty"`
+}
+
+// enter starts the mode, reporting whether it was not active yet
+func (d *slowDrip) enter(now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.active {
+		return false
+	}
+	d.active = true
+	d.since = now
+	d.next = time.Time{}
+	d.held = make(map[string]struct{})
+	return true
+}
+
+// leave ends the mode, returning the incidents still waiting for their
+// turn, and reports whether it was active
+func (d *slowDrip) leave() (held []string, left bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.active {
+		return nil, false
+	}
+	for id := range d.held {
+		held = append(held, id)
+	}
+	d.active = false
+	d.held = nil
+	return held, true
+}
+
+// hold gives the incident the next turn, one interval after the last one,
+// and returns when it is due. ok is false outside of the mode.
+func (d *slowDrip) hold(id string, now time.Time, interval time.Duration) (at time.Time, held int, ok bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.active {
+		return time.Time{}, 0, false
+	}
+	if d.next.Before(now) {
+		d.next = now
+	}
+	d.next = d.next.Add(interval)
+	d.held[id] = struct{}{}
+	return d.next, len(d.held), true
+}
+
+// done stops counting the incident as held, once its turn came, and returns
+// how many incidents are still held
+func (d *slowDrip) done(id string) (held int, active bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.held, id)
+	return len(d.held), d.active
+}
+
+// status returns the mode, or false outside of it
+func (d *slowDrip) status(now time.Time) (SlowDripStatus, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.active {
+		return SlowDripStatus{}, false
+	}
+	status := SlowDripStatus{Since: d.since.UTC(), Held: len(d.held)}
+	if d.next.After(now) {
+		next := d.next.UTC()
+		status.NextDispatchAt = &next
+	}
+	return status, true
+}
+
+// slowDripTurn stops counting the incident as held in slow-drip mode,
+// whether or not it was
+func (s *Server) slowDripTurn(id string) {
+	if held, active := s.slowDrip.done(id); active {
+		s.metrics.SetSlowDrip(true, held)
+	}
+}
+
+// slowDripEnabled reports whether failing dispatches may switch to
+// slow-drip mode
+func (s *Server) slowDripEnabled() bool {
+	return s.config != nil && s.config.SlowDrip.Enabled && s.githubClient != nil
+}
+
+// observeSlowDrip enters slow-drip mode once GitHub answered enough dispatch
+// requests in a row with a server error, and leaves it as soon as GitHub
+// answers one properly. The incidents still waiting for their turn are then
+// dispatched by the next maintenance check rather than one by one.
+func (s *Server) observeSlowDrip(ctx context.Context) {
+	if !s.slowDripEnabled() {
+		return
+	}
+	logger := s.loggerFrom(ctx)
+	serverErrors := s.githubClient.Health().ConsecutiveServerErrors
+
+	if serverErrors >= s.config.SlowDrip.ServerErrorThreshold() {
+		if s.slowDrip.enter(time.Now()) {
+			logger.Warn("github keeps failing with server errors, dispatching in slow-drip mode", map[string]interface{}{
+				"server_errors": serverErrors,
+				"interval":      s.config.SlowDrip.DispatchInterval().String(),
+			})
+			s.metrics.SetSlowDrip(true, 0)
+		}
+		return
+	}
+	if serverErrors > 0 {
+		return
+	}
+
+	held, left := s.slowDrip.leave()
+	if !left {
+		return
+	}
+	s.metrics.SetSlowDrip(false, 0)
+	logger.Info("github recovered, leaving slow-drip mode", map[string]interface{}{
+		"held": len(held),
+	})
+	if len(held) == 0 {
+		return
+	}
+	if _, err := s.repository.WithContext(ctx).ResumeDeferred(held, time.Now()); err != nil {
+		logger.Error("failed to resume remediations held in slow-drip mode", map[string]interface{}{
+			"error": err.Error(),
+			"held":  len(held),
+		})
+	}
+}
+
+// holdForSlowDrip defers the remediation of an incident whose dispatch
+// failed in slow-drip mode to its turn, instead of failing it, and reports
+// whether it did. The maintenance worker dispatches it when it is due.
+func (s *Server) holdForSlowDrip(ctx context.Context, inc *models.Incident, dispatchErr error, logger *Logger) bool {
+	if !s.slowDripEnabled() || ctx.Err() != nil {
+		return false
+	}
+	at, held, ok := s.slowDrip.hold(inc.ID, time.Now(), s.config.SlowDrip.DispatchInterval())
+	if !ok {
+		return false
+	}
+
+	repository := s.repository.WithContext(ctx)
+	inc.DeferredUntil = &at
+	if err := repository.Update(inc); err != nil {
+		// Without its deferral stored, nothing would dispatch it later
+		s.slowDrip.done(inc.ID)
+		inc.DeferredUntil = nil
+		logger.Error("failed to hold remediation for slow-drip dispatch", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return false
+	}
+	s.metrics.SetSlowDrip(true, held)
+
+	event := &models.IncidentEvent{
+		IncidentID: inc.ID,
+		EventType:  models.EventRemediationDeferred,
+		EventData: map[string]interface{}{
+			"reason":         slowDripReason,
+			"deferred_until": at,
+			"error":          dispatchErr.Error(),
+		},
+	}
+	if err := repository.LogEvent(event); err != nil {
+		logger.Error("failed to log remediation deferred event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	logger.Warn("github failing, remediation waits for its slow-drip turn", map[string]interface{}{
+		"error":          dispatchErr.Error(),
+		"deferred_until": at,
+	})
+	return true
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestSlowDrip_HoldsOneTurnPerInterval(t *testing.T) {
+	var drip slowDrip
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	if _, _, ok := drip.hold("inc_1", now, time.Minute); ok {
+		t.Fatal("expected no turns outside of slow-drip mode")
+	}
+
+	drip.enter(now)
+	first, _, _ := drip.hold("inc_1", now, time.Minute)
+	second, held, _ := drip.hold("inc_2", now.Add(10*time.Second), time.Minute)
+	if !first.Equal(now.Add(time.Minute)) || !second.Equal(now.Add(2*time.Minute)) {
+		t.Errorf("expected turns one minute apart, got %v and %v", first, second)
+	}
+	if held != 2 {
+		t.Errorf("expected 2 incidents held, got %d", held)
+	}
+
+	// Once the last turn has passed, the next one is an interval from now
+	later := now.Add(time.Hour)
+	if third, _, _ := drip.hold("inc_3", later, time.Minute); !third.Equal(later.Add(time.Minute)) {
+		t.Errorf("expected the next turn an interval from now, got %v", third)
+	}
+
+	drip.done("inc_1")
+	remaining, left := drip.leave()
+	if !left || len(remaining) != 2 {
+		t.Errorf("expected the 2 incidents still held returned on leaving, got %v", remaining)
+	}
+}
+
+func TestHandleHealth_SlowDrip(t *testing.T) {
+	s := &Server{
+		config:       &config.Config{SlowDrip: config.SlowDripConfig{Enabled: true}},
+		githubClient: github.NewClient("https://api.github.com", "token", "remediate.yml", 1),
+		logger:       NewLogger(),
+	}
+	s.slowDrip.enter(time.Now())
+	s.slowDrip.hold("inc_1", time.Now(), time.Minute)
+
+	w := httptest.NewRecorder()
+	s.handleHealth(w, httptest.NewRequest("GET", "/api/v1/health", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d in slow-drip mode, got %d", http.StatusOK, w.Code)
+	}
+
+	var response struct {
+		DispatchMode string         `json:"dispatch_mode"`
+		SlowDrip     SlowDripStatus `json:"slow_drip"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.DispatchMode != "slow_drip" || response.SlowDrip.Held != 1 || response.SlowDrip.NextDispatchAt == nil {
+		t.Errorf("expected slow-drip mode with one held incident, got %+v", response)
+	}
+}
+
+func TestDispatchIncident_SlowDrip(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	var failing atomic.Bool
+	failing.Store(true)
+	gh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer gh.Close()
+
+	cfg := &config.Config{
+		Server:   config.ServerConfig{Port: 8080},
+		SlowDrip: config.SlowDripConfig{Enabled: true, Threshold: 3, Interval: time.Minute},
+	}
+	server := NewServer(cfg, db, nil, github.NewClient(gh.URL, "token", "remediate.yml", 5), NewLogger())
+
+	repository := database.NewIncidentRepository(db)
+	var incidents []*models.Incident
+	for _, id := range []string{"test-incident-drip-0", "test-incident-drip-1"} {
+		incident := &models.Incident{
+			ID:           id,
+			ServiceName:  "checkout",
+			Repository:   "org/drip-test",
+			ErrorMessage: "test error",
+			Status:       models.StatusPending,
+			Provider:     "test",
+		}
+		if err := repository.Create(incident); err != nil {
+			t.Fatalf("failed to create test incident: %v", err)
+		}
+		incidents = append(incidents, incident)
+	}
+	defer func() {
+		_, _ = db.Exec("DELETE FROM incidents WHERE id LIKE 'test-incident-drip-%'")
+	}()
+
+	// Three 502s in a row switch to slow-drip mode, so the incident waits
+	// for its turn instead of failing
+	ctx := context.Background()
+	if err := server.dispatchIncident(ctx, incidents[0], server.logger); err != nil {
+		t.Fatalf("expected the incident held, got %v", err)
+	}
+	held, err := repository.GetByID(incidents[0].ID)
+	if err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+	if held.Status != models.StatusPending || held.DeferredUntil == nil || !held.DeferredUntil.After(time.Now()) {
+		t.Fatalf("expected the incident pending until its turn, got status %s deferred until %v", held.Status, held.DeferredUntil)
+	}
+
+	// The first dispatch GitHub accepts ends the mode, and the held incident
+	// is due at once
+	failing.Store(false)
+	if err := server.dispatchIncident(ctx, incidents[1], server.logger); err != nil {
+		t.Fatalf("dispatchIncident() error = %v", err)
+	}
+	if _, active := server.slowDrip.status(time.Now()); active {
+		t.Error("expected slow-drip mode to end once GitHub recovered")
+	}
+	resumed, err := repository.GetByID(incidents[0].ID)
+	if err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+	if resumed.DeferredUntil == nil || resumed.DeferredUntil.After(time.Now()) {
+		t.Errorf("expected the held incident due, got deferred until %v", resumed.DeferredUntil)
+	}
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// maxSnoozeDuration bounds how long an incident can be snoozed at once
+const maxSnoozeDuration = 30 * 24 * time.Hour
+
+// SnoozeRequest is the body of a snooze request
+type SnoozeRequest struct {
+	Duration string `json:"duration"` // e.g. "4h", at most 720h
+	Reason   string `json:"reason,omitempty"`
+}
+
+// handleSnoozeIncident snoozes an incident for a duration. Until the snooze
+// expires, the incident triggers no remediation and sends no notifications,
+// and repeats of it are counted as occurrences however old it is.
+func (s *Server) handleSnoozeIncident(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	logger := s.loggerFrom(r.Context()).With(map[string]interface{}{
+		"incident_id": id,
+	})
+
+	var payload SnoozeRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	duration, err := time.ParseDuration(payload.Duration)
+	if err != nil || duration <= 0 || duration > maxSnoozeDuration {
+		http.Error(w, fmt.Sprintf("duration must be a positive duration of at most %s", maxSnoozeDuration), http.StatusBadRequest)
+		return
+	}
+
+	repository := s.repository.WithContext(r.Context())
+	incident, err := repository.GetByID(id)
+	if err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+
+	before := incidentAuditState(incident)
+	until := time.Now().Add(duration)
+	if err := repository.SetSnooze(id, &until); err != nil {
+		logger.Error("failed to snooze incident", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	incident.SnoozedUntil = &until
+
+	eventData := map[string]interface{}{
+		"snoozed_until": until,
+		"duration":      duration.String(),
+	}
+	if payload.Reason != "" {
+		eventData["reason"] = payload.Reason
+	}
+	s.logSnoozeEvent(r, incident.ID, models.EventIncidentSnoozed, eventData)
+	s.recordAudit(r, models.AuditIncidentSnoozed, incident.ID, before, incidentAuditState(incident))
+
+	// Drop it from the dispatch queues, so it does not take a slot while snoozed
+	if incident.Repository != "" && s.githubClient != nil {
+		s.RemoveQueued(incident.Repository, incident.ID)
+		for _, target := range s.fanOutTargets(incident) {
+			s.RemoveQueued(target.Repository, incident.ID)
+		}
+	}
+
+	logger.Info("incident snoozed", map[string]interface{}{
+		"snoozed_until": until,
+		"reason":        payload.Reason,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(incident)
+}
+
+// handleUnsnoozeIncident ends an incident's snooze early
+func (s *Server) handleUnsnoozeIncident(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	repository := s.repository.WithContext(r.Context())
+	incident, err := repository.GetByID(id)
+	if err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+
+	if incident.SnoozedUntil != nil {
+		if err := repository.SetSnooze(id, nil); err != nil {
+			s.loggerFrom(r.Context()).Error("failed to end incident snooze", map[string]interface{}{
+				"error":       err.Error(),
+				"incident_id": id,
+			})
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		s.logSnoozeEvent(r, incident.ID, models.EventIncidentUnsnoozed, map[string]interface{}{
+			"snoozed_until": *incident.SnoozedUntil,
+		})
+		before := incidentAuditState(incident)
+		incident.SnoozedUntil = nil
+		s.recordAudit(r, models.AuditIncidentUnsnoozed, incident.ID, before, incidentAuditState(incident))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(incident)
+}
+
+// logSnoozeEvent records a snooze change, logging any failure
+func (s *Server) logSnoozeEvent(r *http.Request, incidentID string, eventType models.IncidentEventType, data map[string]interface{}) {
+	event := &models.IncidentEvent{
+		IncidentID: incidentID,
+		EventType:  eventType,
+		EventData:  data,
+	}
+	if err := s.repository.WithContext(r.Context()).LogEvent(event); err != nil {
+		s.loggerFrom(r.Context()).Error("failed to log snooze event", map[string]interface{}{
+			"error":       err.Error(),
+			"incident_id": incidentID,
+			"event_type":  eventType,
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestSnooze_SuppressesNotificationsAndAbsorbsRepeats(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	var notifications int32
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&notifications, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer webhook.Close()
+
+	// Without a dedup window, only a snoozed incident absorbs repeats
+	cfg := &config.Config{
+		Server:        config.ServerConfig{Port: 8080},
+		Notifications: config.NotificationsConfig{Webhooks: []config.WebhookConfig{{Name: "cmdb", URL: webhook.URL}}},
+	}
+	server := NewServer(cfg, db, nil, nil, NewLogger())
+
+	repository := database.NewIncidentRepository(db)
+	incident := &models.Incident{
+		ID:           "test-incident-snooze",
+		ServiceName:  "checkout",
+		Repository:   "org/snooze-test",
+		ErrorMessage: "disk almost full",
+		Severity:     "low",
+		Status:       models.StatusPending,
+		Provider:     "test",
+		Fingerprint:  models.ComputeFingerprint("disk almost full", nil),
+	}
+	if err := repository.Create(incident); err != nil {
+		t.Fatalf("failed to create test incident: %v", err)
+	}
+	defer func() {
+		_, _ = db.Exec("DELETE FROM incidents WHERE id = $1", incident.ID)
+	}()
+
+	send := func(method, body string) {
+		t.Helper()
+		req := httptest.NewRequest(method, "/api/v1/incidents/"+incident.ID+"/snooze", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		server.Router().ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s snooze: expected status %d, got %d: %s", method, http.StatusOK, w.Code, w.Body.String())
+		}
+	}
+	repeat := func() bool {
+		return server.deduplicate(context.Background(), &models.Incident{
+			ID:           "test-incident-snooze-repeat",
+			ServiceName:  incident.ServiceName,
+			ErrorMessage: incident.ErrorMessage,
+			Provider:     "test",
+			Fingerprint:  incident.Fingerprint,
+		}, server.logger)
+	}
+	notify := func() int32 {
+		stored, err := repository.GetByID(incident.ID)
+		if err != nil {
+			t.Fatalf("failed to get incident: %v", err)
+		}
+		before := atomic.LoadInt32(&notifications)
+		server.notify(models.EventIncidentFailed, stored)
+		server.background.Wait()
+		return atomic.LoadInt32(&notifications) - before
+	}
+
+	if repeat() {
+		t.Fatal("expected no repeat absorbed before snoozing")
+	}
+
+	send("POST", `{"duration": "2h", "reason": "known issue"}`)
+	stored, err := repository.GetByID(incident.ID)
+	if err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+	occurrences := stored.OccurrenceCount
+	if stored.SnoozedUntil == nil || time.Until(*stored.SnoozedUntil) < time.Hour {
+		t.Fatalf("expected the incident snoozed for 2h, got %v", stored.SnoozedUntil)
+	}
+
+	// While snoozed, repeats count as occurrences and nothing is sent
+	if !repeat() {
+		t.Error("expected the repeat absorbed by the snoozed incident")
+	}
+	if stored, err = repository.GetByID(incident.ID); err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+	if stored.OccurrenceCount != occurrences+1 {
+		t.Errorf("expected the repeat counted as an occurrence, got %d", stored.OccurrenceCount)
+	}
+	if n := notify(); n != 0 {
+		t.Errorf("expected no notifications while snoozed, got %d", n)
+	}
+
+	// Ending the snooze restores both
+	send("DELETE", "")
+	if repeat() {
+		t.Error("expected no repeat absorbed once the snooze ended")
+	}
+	if n := notify(); n != 1 {
+		t.Errorf("expected the notification sent once the snooze ended, got %d", n)
+	}
+
+	events, err := repository.GetEventsByIncidentID(incident.ID)
+	if err != nil {
+		t.Fatalf("failed to get events: %v", err)
+	}
+	var changes []string
+	for _, event := range events {
+		if event.EventType == models.EventIncidentSnoozed || event.EventType == models.EventIncidentUnsnoozed {
+			changes = append(changes, string(event.EventType))
+		}
+	}
+	if strings.Join(changes, ",") != "incident_snoozed,incident_unsnoozed" {
+		t.Errorf("expected the snooze and its end on the timeline, got %v", changes)
+	}
+}
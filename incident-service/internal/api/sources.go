@@ -0,0 +1,97 @@
+package api
+
+import (
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+// sourceFilter limits where each provider's webhooks may be sent from
+type sourceFilter struct {
+	allowed map[string][]netip.Prefix // by provider
+	proxies []netip.Prefix
+}
+
+// newSourceFilter returns the filter of the webhook settings, or nil when no
+// provider is limited. Ranges are checked when the configuration is loaded;
+// any that fail to parse here are left out, which only narrows the allowlist.
+func newSourceFilter(cfg config.WebhooksConfig) *sourceFilter {
+	if len(cfg.AllowedSources) == 0 {
+		return nil
+	}
+
+	f := &sourceFilter{allowed: make(map[string][]netip.Prefix, len(cfg.AllowedSources))}
+	for provider, ranges := range cfg.AllowedSources {
+		f.allowed[provider] = parseRanges(ranges)
+	}
+	f.proxies = parseRanges(cfg.TrustedProxies)
+	return f
+}
+
+func parseRanges(ranges []string) []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(ranges))
+	for _, r := range ranges {
+		if prefix, err := config.ParseAddressRange(r); err == nil {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+// allows reports whether the provider's webhook was sent from an allowed
+// address, and returns the address it was sent from
+func (f *sourceFilter) allows(provider string, r *http.Request) (string, bool) {
+	ranges, limited := f.allowed[provider]
+	source, ok := f.source(r)
+	if !limited {
+		return source.String(), true
+	}
+	return source.String(), ok && contains(ranges, source)
+}
+
+// source returns the address of the sender
+func (f *sourceFilter) source(r *http.Request) (netip.Addr, bool) {
+	return sourceAddr(r, f.proxies)
+}
+
+// sourceAddr returns the address a request was sent from. Behind trusted
+// proxies, that is the last X-Forwarded-For entry not added by one of them;
+// entries further left can be set by anyone.
+func sourceAddr(r *http.Request, proxies []netip.Prefix) (netip.Addr, bool) {
+	remote, err := netip.ParseAddrPort(r.RemoteAddr)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	addr := remote.Addr().Unmap()
+	if !contains(proxies, addr) {
+		return addr, true
+	}
+
+	var forwarded []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(header, ",")...)
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(forwarded[i]))
+		if err != nil {
+			return netip.Addr{}, false
+		}
+		addr = hop.Unmap()
+		if !contains(proxies, addr) {
+			return addr, true
+		}
+	}
+	// Every hop is a trusted proxy, so the request started inside the network
+	return addr, true
+}
+
+func contains(ranges []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range ranges {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+func TestSourceFilter_Allows(t *testing.T) {
+	f := newSourceFilter(config.WebhooksConfig{
+		AllowedSources: map[string][]string{
+			"datadog":   {"3.233.144.0/20", "2600:1f18::/32"},
+			"pagerduty": {"44.242.69.192"},
+		},
+		TrustedProxies: []string{"10.0.0.0/8"},
+	})
+
+	tests := []struct {
+		name      string
+		provider  string
+		remote    string
+		forwarded []string
+		want      bool
+	}{
+		{"direct from range", "datadog", "3.233.150.7:443", nil, true},
+		{"direct from outside", "datadog", "203.0.113.9:443", nil, false},
+		{"IPv6 range", "datadog", "[2600:1f18::1]:443", nil, true},
+		{"IPv4-mapped address", "pagerduty", "[::ffff:44.242.69.192]:443", nil, true},
+		{"unlisted provider", "grafana", "203.0.113.9:443", nil, true},
+		{"through a trusted proxy", "datadog", "10.1.2.3:443", []string{"3.233.150.7"}, true},
+		{"through chained trusted proxies", "datadog", "10.1.2.3:443", []string{"3.233.150.7, 10.4.5.6"}, true},
+		{"spoofed entry left of the sender", "datadog", "10.1.2.3:443", []string{"3.233.150.7, 203.0.113.9"}, false},
+		{"forwarded header from an untrusted peer", "datadog", "203.0.113.9:443", []string{"3.233.150.7"}, false},
+		{"several forwarded headers", "pagerduty", "10.1.2.3:443", []string{"198.51.100.1", "44.242.69.192"}, true},
+		{"malformed forwarded entry", "datadog", "10.1.2.3:443", []string{"not-an-ip"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v1/webhooks/incidents?provider="+tt.provider, nil)
+			req.RemoteAddr = tt.remote
+			for _, value := range tt.forwarded {
+				req.Header.Add("X-Forwarded-For", value)
+			}
+			if _, got := f.allows(tt.provider, req); got != tt.want {
+				t.Errorf("allows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSourceFilter_Disabled(t *testing.T) {
+	if f := newSourceFilter(config.WebhooksConfig{TrustedProxies: []string{"10.0.0.0/8"}}); f != nil {
+		t.Error("expected no filter without allowed sources")
+	}
+}
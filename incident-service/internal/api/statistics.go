@@ -0,0 +1,202 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+)
+
+// handleGetStatistics returns aggregated incident statistics for a time range,
+// read from the hourly rollups
+func (s *Server) handleGetStatistics(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseRollupFilter(r.URL.Query(), database.RollupHourly, 7*24*time.Hour, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !principalFrom(r.Context()).scopeRollups(filter) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	stats, err := s.repository.WithContext(r.Context()).GetRollupStatistics(filter)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to get statistics", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"start":      filter.Start,
+		"end":        filter.End,
+		"statistics": stats,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// handleGetTeamStatistics returns aggregated incident statistics per owning
+// team for a time range, read from the hourly rollups
+func (s *Server) handleGetTeamStatistics(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseRollupFilter(r.URL.Query(), database.RollupHourly, 7*24*time.Hour, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !principalFrom(r.Context()).scopeRollups(filter) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	teams, err := s.repository.WithContext(r.Context()).GetRollupStatisticsByTeam(filter)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to get team statistics", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"start": filter.Start,
+		"end":   filter.End,
+		"teams": teams,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// handleGetTenantStatistics returns aggregated incident statistics per tenant
+// for a time range, read from the hourly rollups
+func (s *Server) handleGetTenantStatistics(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseRollupFilter(r.URL.Query(), database.RollupHourly, 7*24*time.Hour, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !principalFrom(r.Context()).scopeRollups(filter) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	tenants, err := s.repository.WithContext(r.Context()).GetRollupStatisticsByTenant(filter)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to get tenant statistics", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"start":   filter.Start,
+		"end":     filter.End,
+		"tenants": tenants,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// handleGetStatisticsTimeSeries returns incident statistics per hourly or daily bucket
+func (s *Server) handleGetStatisticsTimeSeries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	granularity := database.RollupGranularity(query.Get("granularity"))
+	if granularity == "" {
+		granularity = database.RollupHourly
+	}
+	if !granularity.Valid() {
+		http.Error(w, "granularity must be hour or day", http.StatusBadRequest)
+		return
+	}
+
+	window := 24 * time.Hour
+	if granularity == database.RollupDaily {
+		window = 30 * 24 * time.Hour
+	}
+
+	filter, err := parseRollupFilter(query, granularity, window, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !principalFrom(r.Context()).scopeRollups(filter) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	points, err := s.repository.WithContext(r.Context()).GetRollupTimeSeries(filter)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to get statistics time series", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"granularity": granularity,
+		"start":       filter.Start,
+		"end":         filter.End,
+		"points":      points,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// parseRollupFilter reads the time range and dimension filters from query
+// parameters. The range defaults to the window ending now and is widened to
+// whole buckets.
+func parseRollupFilter(query url.Values, granularity database.RollupGranularity, window time.Duration, now time.Time) (*database.RollupFilter, error) {
+	end := now
+	if value := query.Get("end"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end time, expected RFC3339")
+		}
+		end = parsed
+	}
+
+	start := end.Add(-window)
+	if value := query.Get("start"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start time, expected RFC3339")
+		}
+		start = parsed
+	}
+
+	if !start.Before(end) {
+		return nil, fmt.Errorf("start must be before end")
+	}
+
+	filter := &database.RollupFilter{
+		Granularity: granularity,
+		Start:       granularity.Truncate(start),
+		End:         granularity.Next(granularity.Truncate(end)),
+	}
+
+	for param, field := range map[string]**string{
+		"service_name": &filter.ServiceName,
+		"severity":     &filter.Severity,
+		"provider":     &filter.Provider,
+		"team":         &filter.Team,
+		"tenant":       &filter.TenantID,
+	} {
+		if value := query.Get(param); value != "" {
+			*field = &value
+		}
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+)
+
+func TestParseRollupFilter(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 34, 0, 0, time.UTC)
+
+	filter, err := parseRollupFilter(url.Values{}, database.RollupHourly, 24*time.Hour, now)
+	if err != nil {
+		t.Fatalf("parseRollupFilter() error = %v", err)
+	}
+	// The window is widened to whole buckets, including the current one
+	if !filter.Start.Equal(time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)) || !filter.End.Equal(time.Date(2024, 3, 6, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected default range %v - %v", filter.Start, filter.End)
+	}
+	if filter.ServiceName != nil || filter.Severity != nil || filter.Provider != nil {
+		t.Error("expected no dimension filters by default")
+	}
+
+	query := url.Values{
+		"start":        {"2024-03-01T00:00:00Z"},
+		"end":          {"2024-03-03T10:00:00Z"},
+		"service_name": {"checkout"},
+		"provider":     {"sentry"},
+		"team":         {"payments"},
+	}
+	filter, err = parseRollupFilter(query, database.RollupDaily, 24*time.Hour, now)
+	if err != nil {
+		t.Fatalf("parseRollupFilter() error = %v", err)
+	}
+	if !filter.Start.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) || !filter.End.Equal(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected range %v - %v", filter.Start, filter.End)
+	}
+	if filter.ServiceName == nil || *filter.ServiceName != "checkout" || filter.Provider == nil || *filter.Provider != "sentry" || filter.Severity != nil {
+		t.Errorf("unexpected dimension filters: %+v", filter)
+	}
+	if filter.Team == nil || *filter.Team != "payments" {
+		t.Errorf("expected a team filter, got %v", filter.Team)
+	}
+
+	for name, query := range map[string]url.Values{
+		"bad start":      {"start": {"yesterday"}},
+		"bad end":        {"end": {"1709700000"}},
+		"start past end": {"start": {"2024-03-05T00:00:00Z"}, "end": {"2024-03-04T00:00:00Z"}},
+	} {
+		if _, err := parseRollupFilter(query, database.RollupHourly, time.Hour, now); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestHandleGetStatisticsTimeSeries_InvalidGranularity(t *testing.T) {
+	s := &Server{logger: NewLogger()}
+
+	w := httptest.NewRecorder()
+	s.handleGetStatisticsTimeSeries(w, httptest.NewRequest("GET", "/api/v1/statistics/timeseries?granularity=week", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unsupported granularity, got %d", w.Code)
+	}
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/storm"
+)
+
+// stormProvider is the provider recorded on storm incidents
+const stormProvider = "storm"
+
+// Storms returns the alert storm detector, or nil when storm detection is disabled
+func (s *Server) Storms() *storm.Detector {
+	return s.storms
+}
+
+// observeStorm counts the incident towards its service's volume and returns
+// the storm it belongs to, if any. The incident that starts a storm creates
+// the storm incident and dispatches its remediation.
+func (s *Server) observeStorm(ctx context.Context, incident *models.Incident, logger *Logger) *storm.Storm {
+	if s.storms == nil {
+		return nil
+	}
+
+	active, started := s.storms.Observe(incident.ServiceName, time.Now())
+	if active == nil || !started {
+		return active
+	}
+
+	if err := s.startStorm(ctx, active, incident, logger); err != nil {
+		logger.Error("failed to start alert storm", map[string]interface{}{
+			"error":    err.Error(),
+			"storm_id": active.ID,
+		})
+		s.storms.Cancel(active.ServiceName, active.ID)
+		return nil
+	}
+
+	return active
+}
+
+// startStorm stores the incident that stands in for the whole storm and
+// dispatches a single remediation for it
+func (s *Server) startStorm(ctx context.Context, active *storm.Storm, trigger *models.Incident, logger *Logger) error {
+	window := s.config.Storms.WindowSize()
+	threshold := s.config.Storms.StartThreshold()
+
+	stormIncident := &models.Incident{
+		ID:           active.ID,
+		ServiceName:  active.ServiceName,
+		Repository:   s.repositoryFor(trigger),
+		ErrorMessage: fmt.Sprintf("%d incidents from %s within %s, first: %s", threshold, active.ServiceName, window, trigger.ErrorMessage),
+		StackTrace:   trigger.StackTrace,
+		Severity:     trigger.Severity,
+		Team:         trigger.Team,
+		TenantID:     trigger.TenantID,
+		Status:       models.StatusPending,
+		Provider:     stormProvider,
+		ProviderData: map[string]interface{}{
+			"window":              window.String(),
+			"threshold":           threshold,
+			"trigger_incident_id": trigger.ID,
+			"trigger_provider":    trigger.Provider,
+		},
+	}
+
+	repository := s.repository.WithContext(ctx)
+	if err := repository.Create(stormIncident); err != nil {
+		return fmt.Errorf("failed to store storm incident: %w", err)
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: stormIncident.ID,
+		EventType:  models.EventStormDetected,
+		EventData: map[string]interface{}{
+			"service_name": stormIncident.ServiceName,
+			"window":       window.String(),
+			"threshold":    threshold,
+		},
+	}
+	if err := repository.LogEvent(event); err != nil {
+		logger.Error("failed to log storm detected event", map[string]interface{}{
+			"error":    err.Error(),
+			"storm_id": stormIncident.ID,
+		})
+	}
+
+	logger.Warn("alert storm detected, grouping further incidents", map[string]interface{}{
+		"storm_id":     stormIncident.ID,
+		"service_name": stormIncident.ServiceName,
+		"threshold":    threshold,
+		"window":       window.String(),
+	})
+	s.notify(models.EventStormDetected, stormIncident)
+
+	if stormIncident.Repository == "" {
+		logger.Warn("no repository mapped for storm service, not dispatching remediation", map[string]interface{}{
+			"storm_id":     stormIncident.ID,
+			"service_name": stormIncident.ServiceName,
+		})
+		return nil
+	}
+
+	dispatchLogger := logger.With(map[string]interface{}{
+		"incident_id": stormIncident.ID,
+		"repository":  stormIncident.Repository,
+	})
+	s.dispatchInBackground(ctx, "dispatch storm incident", stormIncident, dispatchLogger)
+
+	return nil
+}
+
+// suppressForStorm closes a stored incident as part of its storm, so it is
+// never remediated on its own
+func (s *Server) suppressForStorm(ctx context.Context, incident *models.Incident, active *storm.Storm, logger *Logger) {
+	completedAt := time.Now()
+	diagnosis := fmt.Sprintf("Grouped under alert storm %s", active.ID)
+	incident.Status = models.StatusNoFixNeeded
+	incident.CompletedAt = &completedAt
+	incident.Diagnosis = &diagnosis
+	incident.ParentID = &active.ID
+
+	repository := s.repository.WithContext(ctx)
+	if err := repository.Update(incident); err != nil {
+		logger.Error("failed to suppress incident for alert storm", map[string]interface{}{
+			"error":    err.Error(),
+			"storm_id": active.ID,
+		})
+		return
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: incident.ID,
+		EventType:  models.EventStormSuppressed,
+		EventData: map[string]interface{}{
+			"storm_id": active.ID,
+		},
+	}
+	if err := repository.LogEvent(event); err != nil {
+		logger.Error("failed to log storm suppressed event", map[string]interface{}{
+			"error":    err.Error(),
+			"storm_id": active.ID,
+		})
+	}
+
+	s.metrics.RecordStormSuppressed(incident.Provider)
+	s.logGrouped(ctx, incident, groupReasonStorm, logger)
+}
+
+// repositoryFor returns the incident's repository, falling back to the
+// configured mapping for its service
+func (s *Server) repositoryFor(incident *models.Incident) string {
+	if incident.Repository != "" {
+		return incident.Repository
+	}
+	for _, mapping := range s.config.ServiceMappings {
+		if mapping.ServiceName == incident.ServiceName {
+			return mapping.Repository
+		}
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/summary"
+)
+
+// summaryTimeout bounds summarizing an incident, storing the result included
+const summaryTimeout = 2 * time.Minute
+
+// SetSummarizer has a language model summarize each incident once it is stored
+func (s *Server) SetSummarizer(summarizer *summary.Summarizer) {
+	s.summarizer = summarizer
+}
+
+// summarize writes a summary of the incident in the background and stores it
+// on the incident record. Failures are logged and leave the incident without
+// a summary.
+func (s *Server) summarize(inc *models.Incident) {
+	if s.summarizer == nil || inc.Summary != nil {
+		return
+	}
+
+	snapshot := *inc
+	fields := map[string]interface{}{"incident_id": snapshot.ID}
+	s.goBackground("summarize incident", fields, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), summaryTimeout)
+		defer cancel()
+		logger := s.logger.With(fields)
+
+		result, err := s.summarizer.Summarize(ctx, &snapshot)
+		if err != nil {
+			s.metrics.RecordSummary("error")
+			logger.Warn("failed to summarize incident", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return
+		}
+		s.metrics.RecordSummary("success")
+
+		repository := s.repository.WithContext(ctx)
+		if err := repository.SetSummary(snapshot.ID, result); err != nil {
+			logger.Error("failed to store summary", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		event := &models.IncidentEvent{
+			IncidentID: snapshot.ID,
+			EventType:  models.EventIncidentSummarized,
+			EventData: map[string]interface{}{
+				"root_cause_category": result.RootCauseCategory,
+				"model":               result.Model,
+			},
+		}
+		if err := repository.LogEvent(event); err != nil {
+			logger.Error("failed to log incident summarized event", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	})
+}
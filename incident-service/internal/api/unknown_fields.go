@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+)
+
+// unknownFieldLimit bounds the unknown fields remembered per provider. Once
+// it is reached, new fields are counted but not listed.
+const unknownFieldLimit = 200
+
+// UnknownFieldStats describes a field that a provider's payloads carry and
+// its adapter drops
+type UnknownFieldStats struct {
+	Path      string    `json:"path"`
+	Type      string    `json:"type"`
+	Count     int       `json:"count"` // payloads that carried the field
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// ProviderFieldReport is how much of a provider's payloads its adapter reads
+type ProviderFieldReport struct {
+	Provider            string              `json:"provider"`
+	Payloads            int                 `json:"payloads"`                     // payloads checked
+	PayloadsWithUnknown int                 `json:"payloads_with_unknown_fields"` // payloads that lost data
+	Unlisted            int                 `json:"unlisted_fields,omitempty"`    // fields seen past the limit, per payload
+	UnknownFields       []UnknownFieldStats `json:"unknown_fields"`
+}
+
+// unknownFields keeps, per provider, the fields of received payloads that
+// their adapter does not read
+type unknownFields struct {
+	mu        sync.Mutex
+	providers map[string]*ProviderFieldReport
+	fields    map[string]map[string]*UnknownFieldStats // by provider, then path
+}
+
+// record notes the unknown fields of a payload received from provider at t
+func (u *unknownFields) record(provider string, unknown []adapters.UnknownField, t time.Time) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.providers == nil {
+		u.providers = make(map[string]*ProviderFieldReport)
+		u.fields = make(map[string]map[string]*UnknownFieldStats)
+	}
+	report, ok := u.providers[provider]
+	if !ok {
+		report = &ProviderFieldReport{Provider: provider}
+		u.providers[provider] = report
+		u.fields[provider] = make(map[string]*UnknownFieldStats)
+	}
+	report.Payloads++
+	if len(unknown) > 0 {
+		report.PayloadsWithUnknown++
+	}
+
+	fields := u.fields[provider]
+	for _, field := range unknown {
+		stats, ok := fields[field.Path]
+		if !ok {
+			if len(fields) >= unknownFieldLimit {
+				report.Unlisted++
+				continue
+			}
+			stats = &UnknownFieldStats{Path: field.Path, Type: field.Type, FirstSeen: t}
+			fields[field.Path] = stats
+		}
+		stats.Type = field.Type
+		stats.Count++
+		stats.LastSeen = t
+	}
+}
+
+// reports returns the report of provider, or of every provider when it is
+// empty, by provider with the most common fields first
+func (u *unknownFields) reports(provider string) []ProviderFieldReport {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	reports := []ProviderFieldReport{}
+	for name, report := range u.providers {
+		if provider != "" && name != provider {
+			continue
+		}
+		r := *report
+		r.UnknownFields = make([]UnknownFieldStats, 0, len(u.fields[name]))
+		for _, stats := range u.fields[name] {
+			r.UnknownFields = append(r.UnknownFields, *stats)
+		}
+		sort.Slice(r.UnknownFields, func(i, j int) bool {
+			if r.UnknownFields[i].Count != r.UnknownFields[j].Count {
+				return r.UnknownFields[i].Count > r.UnknownFields[j].Count
+			}
+			return r.UnknownFields[i].Path < r.UnknownFields[j].Path
+		})
+		reports = append(reports, r)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Provider < reports[j].Provider })
+	return reports
+}
+
+// recordUnknownFields checks a payload against its adapter's schema and
+// notes the fields the adapter drops
+func (s *Server) recordUnknownFields(provider string, adapter adapters.WebhookAdapter, body []byte, t time.Time) {
+	unknown := adapters.UnknownFields(adapter, body)
+	if unknown == nil {
+		return // no schema, or not JSON, which parsing reports
+	}
+
+	s.dropped.record(provider, unknown, t)
+	s.metrics.RecordPayloadFields(provider, len(unknown))
+}
+
+// handleListUnknownFields reports, per provider, the fields of received
+// payloads that the adapter drops, so that data providers have started to
+// send can be put to use
+func (s *Server) handleListUnknownFields(w http.ResponseWriter, r *http.Request) {
+	provider := r.URL.Query().Get("provider")
+	if provider != "" {
+		if _, ok := s.adapters.Get(provider); !ok {
+			http.Error(w, "unsupported provider", http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"providers": s.dropped.reports(provider),
+	})
+}
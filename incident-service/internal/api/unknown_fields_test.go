@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+)
+
+func TestHandleListUnknownFields(t *testing.T) {
+	s := &Server{adapters: adapters.NewRegistry(), metrics: newMetrics(prometheus.NewRegistry()), logger: NewLogger()}
+	datadog, _ := s.adapters.Get("datadog")
+	sentry, _ := s.adapters.Get("sentry")
+
+	now := time.Now().UTC()
+	s.recordUnknownFields("datadog", datadog, []byte(`{"id": "1", "title": "boom", "incident": {"public_id": 42}, "org": {"id": 1}}`), now)
+	s.recordUnknownFields("datadog", datadog, []byte(`{"id": "2", "title": "boom", "incident": {"public_id": 43}}`), now.Add(time.Minute))
+	s.recordUnknownFields("sentry", sentry, []byte(`{"action": "created", "data": {"issue": {"id": "1"}}}`), now)
+	s.recordUnknownFields("sentry", sentry, []byte(`not json`), now)
+
+	if got := testutil.ToFloat64(s.metrics.WebhookUnknownFields.WithLabelValues("datadog")); got != 3 {
+		t.Errorf("expected 3 dropped datadog fields counted, got %v", got)
+	}
+	if got := testutil.ToFloat64(s.metrics.WebhookPayloadsNormalized.WithLabelValues("sentry", "complete")); got != 1 {
+		t.Errorf("expected 1 complete sentry payload counted, got %v", got)
+	}
+
+	w := httptest.NewRecorder()
+	s.handleListUnknownFields(w, httptest.NewRequest("GET", "/api/v1/providers/unknown-fields?provider=datadog", nil))
+	var response struct {
+		Providers []ProviderFieldReport `json:"providers"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Providers) != 1 {
+		t.Fatalf("expected the datadog report only, got %+v", response.Providers)
+	}
+	report := response.Providers[0]
+	if report.Payloads != 2 || report.PayloadsWithUnknown != 2 || len(report.UnknownFields) != 2 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	incident := report.UnknownFields[0]
+	if incident.Path != "incident" || incident.Type != "object" || incident.Count != 2 || !incident.LastSeen.Equal(now.Add(time.Minute)) {
+		t.Errorf("expected the most common field first, got %+v", incident)
+	}
+
+	w = httptest.NewRecorder()
+	s.handleListUnknownFields(w, httptest.NewRequest("GET", "/api/v1/providers/unknown-fields?provider=nagios", nil))
+	if w.Code != 400 {
+		t.Errorf("expected 400 for an unknown provider, got %d", w.Code)
+	}
+}
+
+func TestUnknownFields_Limit(t *testing.T) {
+	var u unknownFields
+	now := time.Now()
+	for i := 0; i < unknownFieldLimit+5; i++ {
+		u.record("datadog", []adapters.UnknownField{{Path: fmt.Sprintf("field%d", i), Type: "string"}}, now)
+	}
+
+	reports := u.reports("")
+	if len(reports) != 1 || len(reports[0].UnknownFields) != unknownFieldLimit || reports[0].Unlisted != 5 {
+		t.Errorf("expected %d fields listed and 5 unlisted, got %d and %d", unknownFieldLimit, len(reports[0].UnknownFields), reports[0].Unlisted)
+	}
+}
+
+func TestProcessBuffered_CountsUnknownFieldsOnce(t *testing.T) {
+	// The payload does not parse, so neither attempt reaches the database
+	s := &Server{adapters: adapters.NewRegistry(), metrics: newMetrics(prometheus.NewRegistry()), logger: NewLogger()}
+	datadog, _ := s.adapters.Get("datadog")
+	body := []byte(`{"id": "1", "org": {"id": 1}}`)
+	now := time.Now().UTC()
+
+	s.processWebhook(ingestJob{ctx: context.Background(), provider: "datadog", adapter: datadog, body: body, receivedAt: now})
+	s.ProcessBuffered(context.Background(), &database.BufferedWebhook{Provider: "datadog", Body: body, ReceivedAt: now})
+
+	if got := testutil.ToFloat64(s.metrics.WebhookUnknownFields.WithLabelValues("datadog")); got != 1 {
+		t.Errorf("expected the dropped field counted once, got %v", got)
+	}
+	if reports := s.dropped.reports("datadog"); len(reports) != 1 || reports[0].Payloads != 1 {
+		t.Errorf("expected one payload reported, got %+v", reports)
+	}
+}
@@ -0,0 +1,269 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// serviceMapped reports whether the service is mapped to a repository, in
+// the configuration or through the API. When the lookup fails the service is
+// taken to be mapped, so its incidents are handled as they were before.
+func (s *Server) serviceMapped(ctx context.Context, serviceName string, logger *Logger) bool {
+	if s.config.MappingFor(serviceName) != nil {
+		return true
+	}
+	mapping, err := s.repository.WithContext(ctx).GetServiceMapping(serviceName)
+	if err != nil {
+		logger.Warn("failed to look up service mapping", map[string]interface{}{
+			"error":        err.Error(),
+			"service_name": serviceName,
+		})
+		return true
+	}
+	return mapping != nil
+}
+
+// recordUnmapped puts a stored incident of an unmapped service on record and
+// tells whoever is notified of unmapped services to map it
+func (s *Server) recordUnmapped(ctx context.Context, incident *models.Incident, logger *Logger) {
+	event := &models.IncidentEvent{
+		IncidentID: incident.ID,
+		EventType:  models.EventServiceUnmapped,
+		EventData: map[string]interface{}{
+			"service_name": incident.ServiceName,
+		},
+	}
+	if err := s.repository.WithContext(ctx).LogEvent(event); err != nil {
+		logger.Error("failed to log service unmapped event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	s.notify(models.EventServiceUnmapped, incident)
+}
+
+// runtimeBranch returns the branch of a service mapped through the API, or
+// fallback when the service has no such mapping
+func (s *Server) runtimeBranch(ctx context.Context, inc *models.Incident, fallback string, logger *Logger) string {
+	if inc.Repository == "" {
+		return fallback
+	}
+	mapping, err := s.repository.WithContext(ctx).GetServiceMapping(inc.ServiceName)
+	if err != nil {
+		logger.Warn("failed to look up service mapping", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return fallback
+	}
+	if mapping == nil || mapping.Repository != inc.Repository {
+		return fallback
+	}
+	return mapping.Branch
+}
+
+// UnmappedService is a service that incidents arrived from without a
+// repository to remediate them in
+type UnmappedService struct {
+	ServiceName    string         `json:"service_name"`
+	Incidents      int            `json:"incidents"`
+	BySeverity     map[string]int `json:"by_severity"`
+	LastIncidentAt time.Time      `json:"last_incident_at"`
+}
+
+// handleListUnmappedServices returns the services whose incidents are held
+// as unmapped, those with the most recent incident first
+func (s *Server) handleListUnmappedServices(w http.ResponseWriter, r *http.Request) {
+	counts, err := s.repository.WithContext(r.Context()).CountIncidentsByService(&database.IncidentFilter{})
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to count incidents by service", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	byService := make(map[string]*UnmappedService)
+	for _, count := range counts {
+		if count.Status != models.StatusUnmapped {
+			continue
+		}
+		service, ok := byService[count.ServiceName]
+		if !ok {
+			service = &UnmappedService{ServiceName: count.ServiceName, BySeverity: map[string]int{}}
+			byService[count.ServiceName] = service
+		}
+		service.Incidents += count.Count
+		service.BySeverity[count.Severity] += count.Count
+		if count.LastCreatedAt.After(service.LastIncidentAt) {
+			service.LastIncidentAt = count.LastCreatedAt
+		}
+	}
+
+	services := make([]UnmappedService, 0, len(byService))
+	for _, service := range byService {
+		services = append(services, *service)
+	}
+	sort.Slice(services, func(i, j int) bool {
+		if !services[i].LastIncidentAt.Equal(services[j].LastIncidentAt) {
+			return services[i].LastIncidentAt.After(services[j].LastIncidentAt)
+		}
+		return services[i].ServiceName < services[j].ServiceName
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"services": services,
+		"total":    len(services),
+	})
+}
+
+// MapServiceRequest maps a service to the repository its incidents are
+// remediated in
+type MapServiceRequest struct {
+	Repository string `json:"repository"` // owner/repo
+	Branch     string `json:"branch,omitempty"`
+}
+
+// MapServiceResponse is a new service mapping and the held incidents it
+// released for remediation
+type MapServiceResponse struct {
+	ServiceName string   `json:"service_name"`
+	Repository  string   `json:"repository"`
+	Branch      string   `json:"branch"`
+	Incidents   []string `json:"incidents"`          // dispatched in this order, oldest first
+	Warnings    []string `json:"warnings,omitempty"` // why dispatches to the repository would fail
+}
+
+// handleMapService maps a service that is missing from the configuration to
+// a repository and dispatches remediation for the incidents of the service
+// held as unmapped. Later incidents of the service are remediated in the
+// repository too. The mapping is stored in the database; copying it into the
+// configuration gives the service its team, runbooks and other settings.
+func (s *Server) handleMapService(w http.ResponseWriter, r *http.Request) {
+	serviceName := chi.URLParam(r, "name")
+	logger := s.loggerFrom(r.Context()).With(map[string]interface{}{
+		"service_name": serviceName,
+	})
+
+	var payload MapServiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	parts := strings.Split(payload.Repository, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		http.Error(w, "repository must be owner/repo", http.StatusBadRequest)
+		return
+	}
+	if payload.Branch == "" {
+		payload.Branch = "main"
+	}
+	if s.config != nil && s.config.MappingFor(serviceName) != nil {
+		http.Error(w, "service is mapped in the configuration", http.StatusConflict)
+		return
+	}
+
+	repository := s.repository.WithContext(r.Context())
+	mapping := &models.ServiceMapping{ServiceName: serviceName, Repository: payload.Repository, Branch: payload.Branch}
+	if err := repository.SaveServiceMapping(mapping, principalFrom(r.Context()).name); err != nil {
+		logger.Error("failed to save service mapping", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	status := models.StatusUnmapped
+	incidents, err := repository.ListWithFilter(&database.IncidentFilter{ServiceName: &serviceName, Status: &status})
+	if err != nil {
+		logger.Error("failed to list unmapped incidents", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	sort.SliceStable(incidents, func(i, j int) bool {
+		return incidents[i].CreatedAt.Before(incidents[j].CreatedAt)
+	})
+
+	response := MapServiceResponse{ServiceName: serviceName, Repository: mapping.Repository, Branch: mapping.Branch, Incidents: []string{}}
+	if s.githubClient != nil {
+		// The mapping is kept either way, a repository can be set up after
+		// its service is mapped
+		check := s.checkMappingTarget(r.Context(), mapping.Repository, mapping.Branch)
+		response.Warnings = check.Problems
+		if check.Status == mappingInvalid {
+			logger.Warn("mapped service cannot be remediated", map[string]interface{}{
+				"repository": mapping.Repository,
+				"branch":     mapping.Branch,
+				"problems":   check.Problems,
+			})
+		}
+	}
+	var mapped []models.Incident
+	for _, incident := range incidents {
+		before := incidentAuditState(incident)
+		incident.Repository = mapping.Repository
+		incident.Status = models.StatusPending
+		if err := repository.Update(incident); err != nil {
+			logger.Error("failed to map incident", map[string]interface{}{
+				"error":       err.Error(),
+				"incident_id": incident.ID,
+			})
+			continue
+		}
+
+		event := &models.IncidentEvent{
+			IncidentID: incident.ID,
+			EventType:  models.EventServiceMapped,
+			EventData: map[string]interface{}{
+				"repository": mapping.Repository,
+				"branch":     mapping.Branch,
+			},
+		}
+		if err := repository.LogEvent(event); err != nil {
+			logger.Error("failed to log service mapped event", map[string]interface{}{
+				"error":       err.Error(),
+				"incident_id": incident.ID,
+			})
+		}
+		s.recordAudit(r, models.AuditIncidentMapped, incident.ID, before, incidentAuditState(incident))
+
+		response.Incidents = append(response.Incidents, incident.ID)
+		mapped = append(mapped, *incident)
+	}
+
+	logger.Info("service mapped", map[string]interface{}{
+		"repository": mapping.Repository,
+		"branch":     mapping.Branch,
+		"incidents":  len(mapped),
+	})
+
+	if s.githubClient != nil && len(mapped) > 0 {
+		spanContext := trace.SpanContextFromContext(r.Context())
+		s.goBackground("dispatch mapped incidents", map[string]interface{}{
+			"service_name": serviceName,
+			"repository":   mapping.Repository,
+		}, func() {
+			for i := range mapped {
+				ctx, cancel := context.WithTimeout(trace.ContextWithSpanContext(context.Background(), spanContext), 30*time.Second)
+				_ = s.dispatchIncident(ctx, &mapped[i], logger.With(map[string]interface{}{
+					"incident_id": mapped[i].ID,
+				}))
+				cancel()
+			}
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(response)
+}
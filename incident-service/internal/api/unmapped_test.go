@@ -0,0 +1,163 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestHandleMapService_InvalidRequest(t *testing.T) {
+	// The request is rejected before the repository is used
+	server := &Server{
+		logger: NewLogger(),
+		config: &config.Config{ServiceMappings: []config.ServiceMapping{{ServiceName: "checkout", Repository: "org/checkout", Branch: "main"}}},
+	}
+
+	tests := []struct {
+		name       string
+		service    string
+		payload    string
+		wantStatus int
+	}{
+		{"invalid JSON", "batch", "{invalid json", http.StatusBadRequest},
+		{"missing repository", "batch", `{"branch": "main"}`, http.StatusBadRequest},
+		{"repository without owner", "batch", `{"repository": "batch"}`, http.StatusBadRequest},
+		{"repository with a path", "batch", `{"repository": "org/batch/jobs"}`, http.StatusBadRequest},
+		{"service mapped in the configuration", "checkout", `{"repository": "org/checkout-v2"}`, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v1/services/"+tt.service+"/mapping", bytes.NewReader([]byte(tt.payload)))
+			routeCtx := chi.NewRouteContext()
+			routeCtx.URLParams.Add("name", tt.service)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+			w := httptest.NewRecorder()
+
+			server.handleMapService(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestHandleMapService_DispatchesUnmappedIncidents(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	var dispatches int32
+	githubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/dispatches") {
+			atomic.AddInt32(&dispatches, 1)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer githubServer.Close()
+
+	cfg := &config.Config{
+		Server:      config.ServerConfig{Port: 8080},
+		Concurrency: config.ConcurrencyConfig{MaxWorkflowsPerRepo: 10},
+	}
+	server := NewServer(cfg, db, nil, github.NewClient(githubServer.URL, "test-token", "test-workflow.yml", 10), NewLogger())
+	repository := database.NewIncidentRepository(db)
+	defer func() {
+		_, _ = db.Exec("DELETE FROM service_mappings WHERE service_name = 'test-map-batch'")
+	}()
+
+	base := time.Now().Add(-time.Hour)
+	for i, tt := range []struct{ id, service string }{
+		{"test-incident-map-new", "test-map-batch"},
+		{"test-incident-map-old", "test-map-batch"},
+		{"test-incident-map-other", "test-map-reports"},
+	} {
+		incident := &models.Incident{
+			ID:           tt.id,
+			ServiceName:  tt.service,
+			ErrorMessage: "test error " + tt.id,
+			Severity:     "high",
+			Status:       models.StatusUnmapped,
+			Provider:     "test",
+		}
+		if err := repository.Create(incident); err != nil {
+			t.Fatalf("failed to create test incident: %v", err)
+		}
+		defer func(id string) {
+			_, _ = db.Exec("DELETE FROM incidents WHERE id = $1", id)
+		}(tt.id)
+		// The first incident is the newest
+		if _, err := db.Exec("UPDATE incidents SET created_at = $2 WHERE id = $1", tt.id, base.Add(-time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("failed to age test incident: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest("POST", "/api/v1/services/test-map-batch/mapping", strings.NewReader(`{"repository": "org/batch"}`))
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, w.Code, w.Body.String())
+	}
+	var response MapServiceResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if fmt.Sprint(response.Incidents) != "[test-incident-map-old test-incident-map-new]" || response.Branch != "main" {
+		t.Errorf("expected both held incidents released oldest first on main, got %+v", response)
+	}
+	server.background.Wait()
+
+	if n := atomic.LoadInt32(&dispatches); n != 2 {
+		t.Errorf("expected the released incidents dispatched, got %d dispatches", n)
+	}
+	for _, id := range response.Incidents {
+		incident, err := repository.GetByID(id)
+		if err != nil {
+			t.Fatalf("failed to get incident: %v", err)
+		}
+		if incident.Repository != "org/batch" || incident.Status == models.StatusUnmapped {
+			t.Errorf("expected %s mapped to org/batch, got %s in %q", id, incident.Status, incident.Repository)
+		}
+		events, err := repository.GetEventsByIncidentID(id)
+		if err != nil {
+			t.Fatalf("failed to get events: %v", err)
+		}
+		mapped := 0
+		for _, event := range events {
+			if event.EventType == models.EventServiceMapped {
+				mapped++
+			}
+		}
+		if mapped != 1 {
+			t.Errorf("expected one service_mapped event on %s, got %d", id, mapped)
+		}
+	}
+
+	other, err := repository.GetByID("test-incident-map-other")
+	if err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+	if other.Status != models.StatusUnmapped {
+		t.Errorf("expected incidents of other services to stay unmapped, got %s", other.Status)
+	}
+	if mapping, err := repository.GetServiceMapping("test-map-batch"); err != nil || mapping == nil || mapping.Repository != "org/batch" {
+		t.Errorf("expected the mapping stored, got %+v, %v", mapping, err)
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// WatchRequest is the body of a request to watch or stop watching an incident
+type WatchRequest struct {
+	Email     string `json:"email,omitempty"`
+	SlackUser string `json:"slack_user,omitempty"` // Slack member ID, messaged directly
+}
+
+// handleListIncidentWatchers lists the watchers of an incident
+func (s *Server) handleListIncidentWatchers(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	repository := s.repository.WithContext(r.Context())
+	if _, err := repository.GetByID(id); err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+
+	watchers, err := repository.ListIncidentWatchers(id)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to list incident watchers", map[string]interface{}{
+			"error": err.Error(),
+			"id":    id,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"watchers": watchers,
+		"total":    len(watchers),
+	})
+}
+
+// handleWatchIncident subscribes someone to an incident. From then on, the
+// incident's notifications are also sent to them by email, as a Slack direct
+// message, or both. Watching an incident again changes nothing.
+func (s *Server) handleWatchIncident(w http.ResponseWriter, r *http.Request) {
+	s.changeWatch(w, r, true)
+}
+
+// handleUnwatchIncident unsubscribes someone from an incident
+func (s *Server) handleUnwatchIncident(w http.ResponseWriter, r *http.Request) {
+	s.changeWatch(w, r, false)
+}
+
+// changeWatch adds or removes the watcher in the request body, and responds
+// with the incident's watchers
+func (s *Server) changeWatch(w http.ResponseWriter, r *http.Request, watch bool) {
+	id := chi.URLParam(r, "id")
+
+	var payload WatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	watcher := &models.IncidentWatcher{IncidentID: id, Email: payload.Email, SlackUser: payload.SlackUser}
+	if err := watcher.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	logger := s.loggerFrom(r.Context()).With(map[string]interface{}{
+		"incident_id": id,
+	})
+
+	repository := s.repository.WithContext(r.Context())
+	if _, err := repository.GetByID(id); err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+
+	var changed bool
+	var err error
+	if watch {
+		changed, err = repository.WatchIncident(watcher)
+	} else {
+		changed, err = repository.UnwatchIncident(watcher)
+	}
+	if err != nil {
+		logger.Error("failed to change incident watchers", map[string]interface{}{
+			"error": err.Error(),
+			"watch": watch,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if changed {
+		state := map[string]interface{}{}
+		if watcher.Email != "" {
+			state["email"] = watcher.Email
+		}
+		if watcher.SlackUser != "" {
+			state["slack_user"] = watcher.SlackUser
+		}
+		if watch {
+			s.recordAudit(r, models.AuditIncidentWatched, id, nil, state)
+		} else {
+			s.recordAudit(r, models.AuditIncidentUnwatched, id, state, nil)
+		}
+		logger.Info("incident watchers changed", map[string]interface{}{
+			"watch": watch,
+		})
+	}
+
+	s.handleListIncidentWatchers(w, r)
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestWatchIncident_NotifiesWatchers(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	var mu sync.Mutex
+	var channels []string
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg struct {
+			Channel string `json:"channel"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+		mu.Lock()
+		channels = append(channels, msg.Channel)
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer slack.Close()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{Port: 8080},
+		Notifications: config.NotificationsConfig{Slack: config.SlackConfig{
+			Enabled:        true,
+			APIURL:         slack.URL,
+			Token:          "xoxb-test",
+			DefaultChannel: "#incidents",
+		}},
+	}
+	server := NewServer(cfg, db, nil, nil, NewLogger())
+	repository := database.NewIncidentRepository(db)
+	incident := &models.Incident{
+		ID:           "test-incident-watch",
+		ServiceName:  "checkout",
+		ErrorMessage: "database unreachable",
+		Severity:     "high",
+		Status:       models.StatusPending,
+		Provider:     "test",
+	}
+	if err := repository.Create(incident); err != nil {
+		t.Fatalf("failed to create test incident: %v", err)
+	}
+	defer func() {
+		_, _ = db.Exec("DELETE FROM incidents WHERE id = $1", incident.ID)
+	}()
+
+	send := func(method, body string) []*models.IncidentWatcher {
+		t.Helper()
+		req := httptest.NewRequest(method, "/api/v1/incidents/"+incident.ID+"/watch", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		server.Router().ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s watch: expected status %d, got %d: %s", method, http.StatusOK, w.Code, w.Body.String())
+		}
+		var response struct {
+			Watchers []*models.IncidentWatcher `json:"watchers"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return response.Watchers
+	}
+	notified := func() []string {
+		mu.Lock()
+		channels = nil
+		mu.Unlock()
+		server.notify(models.EventPRCreated, incident)
+		server.background.Wait()
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), channels...)
+	}
+
+	if watchers := send("POST", `{"slack_user": "U024BE7LH"}`); len(watchers) != 1 || watchers[0].SlackUser != "U024BE7LH" {
+		t.Fatalf("expected the watcher added, got %+v", watchers)
+	}
+	// Watching again changes nothing
+	if watchers := send("POST", `{"slack_user": "U024BE7LH"}`); len(watchers) != 1 {
+		t.Fatalf("expected the watcher kept once, got %+v", watchers)
+	}
+	if got := strings.Join(notified(), ","); !strings.Contains(got, "#incidents") || strings.Count(got, "U024BE7LH") != 1 {
+		t.Errorf("expected the channel and the watcher notified once, got %s", got)
+	}
+
+	if watchers := send("DELETE", `{"slack_user": "U024BE7LH"}`); len(watchers) != 0 {
+		t.Fatalf("expected the watcher removed, got %+v", watchers)
+	}
+	if got := strings.Join(notified(), ","); strings.Contains(got, "U024BE7LH") {
+		t.Errorf("expected the former watcher left out, got %s", got)
+	}
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// handleListWorkflowLogs lists the log excerpts of the failed workflow runs
+// of an incident, latest run first
+func (s *Server) handleListWorkflowLogs(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	repository := s.repository.WithContext(r.Context())
+	if _, err := repository.GetByID(id); err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+
+	logs, err := repository.ListWorkflowLogs(id)
+	if err != nil {
+		s.loggerFrom(r.Context()).Error("failed to list workflow logs", map[string]interface{}{
+			"error": err.Error(),
+			"id":    id,
+		})
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"workflow_logs": logs,
+		"total":         len(logs),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
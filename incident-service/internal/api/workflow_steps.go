@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+const (
+	// maxWorkflowSteps bounds the steps reported in one request
+	maxWorkflowSteps = 100
+
+	// maxWorkflowStepName and maxWorkflowStepMessage bound the length of a
+	// step's name and message, in bytes
+	maxWorkflowStepName    = 200
+	maxWorkflowStepMessage = 4096
+
+	// maxWorkflowStepsBytes bounds the body of a request, details included
+	maxWorkflowStepsBytes = 1 << 20
+)
+
+// workflowPrincipal is the principal of requests made with the token of a
+// context URL rather than an API key
+const workflowPrincipal = "remediation-workflow"
+
+// WorkflowStep is a step a remediation workflow reports while it runs, such
+// as "reproduced error" or "running tests"
+type WorkflowStep struct {
+	Step    string                 `json:"step"`
+	Message string                 `json:"message,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+	At      *time.Time             `json:"at,omitempty"` // when the step was taken, if earlier than reported
+}
+
+// WorkflowStepsRequest is a batch of steps, in the order they were taken
+type WorkflowStepsRequest struct {
+	Events []WorkflowStep `json:"events"`
+}
+
+// validate checks that the batch holds between one and maxWorkflowSteps
+// named steps of bounded length
+func (req *WorkflowStepsRequest) validate() error {
+	if len(req.Events) == 0 {
+		return errors.New("events must not be empty")
+	}
+	if len(req.Events) > maxWorkflowSteps {
+		return fmt.Errorf("at most %d events may be sent at once", maxWorkflowSteps)
+	}
+	for i, step := range req.Events {
+		if step.Step == "" {
+			return fmt.Errorf("events[%d]: step is required", i)
+		}
+		if len(step.Step) > maxWorkflowStepName {
+			return fmt.Errorf("events[%d]: step must be at most %d bytes", i, maxWorkflowStepName)
+		}
+		if len(step.Message) > maxWorkflowStepMessage {
+			return fmt.Errorf("events[%d]: message must be at most %d bytes", i, maxWorkflowStepMessage)
+		}
+	}
+	return nil
+}
+
+// requireWorkflowToken lets a remediation workflow in with the token of the
+// context URL it was dispatched with, which only opens its own incident.
+// Requests without a token need an API key that can access the incident.
+func (s *Server) requireWorkflowToken(next http.Handler) http.Handler {
+	keyed := s.requireAPIKey(s.requireIncidentAccess(next))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("token")
+		if token == "" {
+			keyed.ServeHTTP(w, r)
+			return
+		}
+		if s.config == nil || s.config.WorkflowInputs.ContextURL == "" ||
+			!verifyContextToken(s.config.WorkflowInputs.SigningKey, chi.URLParam(r, "id"), token, time.Now()) {
+			http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+			return
+		}
+		p := &principal{name: workflowPrincipal}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalContextKey{}, p)))
+	})
+}
+
+// handleLogWorkflowSteps adds the steps a remediation workflow reports to the
+// incident's timeline. The first report moves an incident whose workflow was
+// triggered to in progress.
+func (s *Server) handleLogWorkflowSteps(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	logger := s.loggerFrom(r.Context()).With(map[string]interface{}{
+		"incident_id": id,
+	})
+
+	var payload WorkflowStepsRequest
+	r.Body = http.MaxBytesReader(w, r.Body, maxWorkflowStepsBytes)
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if err := payload.validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	repository := s.repository.WithContext(r.Context())
+	incident, err := repository.GetByID(id)
+	if err != nil {
+		http.Error(w, "incident not found", http.StatusNotFound)
+		return
+	}
+
+	for i, step := range payload.Events {
+		eventData := map[string]interface{}{
+			"step":        step.Step,
+			"reported_by": principalFrom(r.Context()).name,
+		}
+		if step.Message != "" {
+			eventData["message"] = step.Message
+		}
+		if len(step.Details) > 0 {
+			eventData["details"] = step.Details
+		}
+		if step.At != nil {
+			eventData["at"] = step.At.UTC()
+		}
+		event := &models.IncidentEvent{
+			IncidentID: id,
+			EventType:  models.EventWorkflowStep,
+			EventData:  eventData,
+		}
+		if err := repository.LogEvent(event); err != nil {
+			logger.Error("failed to log workflow step", map[string]interface{}{
+				"error":  err.Error(),
+				"step":   step.Step,
+				"logged": i,
+			})
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// A workflow reporting steps has started, whether or not it reports its
+	// outcome later
+	if incident.Status == models.StatusWorkflowTriggered {
+		started, err := repository.StartProgress(id)
+		if err != nil {
+			logger.Error("failed to mark incident in progress", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		if started {
+			event := &models.IncidentEvent{
+				IncidentID: id,
+				EventType:  models.EventWorkflowInProgress,
+				EventData: map[string]interface{}{
+					"old_status": incident.Status,
+					"new_status": models.StatusInProgress,
+				},
+			}
+			if err := repository.LogEvent(event); err != nil {
+				logger.Error("failed to log workflow in progress event", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+			incident.Status = models.StatusInProgress
+		}
+	}
+
+	logger.Info("workflow steps logged", map[string]interface{}{
+		"steps":  len(payload.Events),
+		"status": incident.Status,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"incident_id": id,
+		"logged":      len(payload.Events),
+		"status":      incident.Status,
+	})
+}
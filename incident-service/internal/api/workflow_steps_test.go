@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestWorkflowStepsRequest_Validate(t *testing.T) {
+	tooMany := make([]WorkflowStep, maxWorkflowSteps+1)
+	for i := range tooMany {
+		tooMany[i].Step = "running tests"
+	}
+
+	tests := []struct {
+		name    string
+		events  []WorkflowStep
+		wantErr bool
+	}{
+		{"steps", []WorkflowStep{{Step: "reproduced error"}, {Step: "running tests", Message: "npm test"}}, false},
+		{"no steps", nil, true},
+		{"too many steps", tooMany, true},
+		{"unnamed step", []WorkflowStep{{Step: "reproduced error"}, {Message: "npm test"}}, true},
+		{"long step name", []WorkflowStep{{Step: strings.Repeat("s", maxWorkflowStepName+1)}}, true},
+		{"long message", []WorkflowStep{{Step: "running tests", Message: strings.Repeat("m", maxWorkflowStepMessage+1)}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &WorkflowStepsRequest{Events: tt.events}
+			if err := req.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandleLogWorkflowSteps_Auth(t *testing.T) {
+	// Requests are turned away, or their empty batch rejected, before the
+	// repository is used
+	key := strings.Repeat("k", 32)
+	server := newAuthTestServer(config.AuthConfig{APIKeys: []config.APIKey{{Name: "ops-cli", Key: "cli-secret"}}})
+	server.config.WorkflowInputs = config.WorkflowInputsConfig{ContextURL: "https://incidents.example.com", SigningKey: key}
+
+	tests := []struct {
+		name   string
+		token  string
+		apiKey string
+		want   int
+	}{
+		{"no credentials", "", "", http.StatusUnauthorized},
+		{"wrong api key", "", "other-secret", http.StatusUnauthorized},
+		{"expired token", contextToken(key, "inc_1", time.Now().Add(-time.Second)), "", http.StatusUnauthorized},
+		{"token of another incident", contextToken(key, "inc_2", time.Now().Add(time.Minute)), "", http.StatusUnauthorized},
+		{"token", contextToken(key, "inc_1", time.Now().Add(time.Minute)), "", http.StatusBadRequest},
+		{"api key", "", "cli-secret", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := "/api/v1/incidents/inc_1/events"
+			if tt.token != "" {
+				target += "?token=" + url.QueryEscape(tt.token)
+			}
+			req := httptest.NewRequest("POST", target, strings.NewReader(`{"events": []}`))
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+			w := httptest.NewRecorder()
+			server.Router().ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+
+	// Tokens only work while the context URL is configured
+	server.config.WorkflowInputs.ContextURL = ""
+	req := httptest.NewRequest("POST", "/api/v1/incidents/inc_1/events?token="+url.QueryEscape(contextToken(key, "inc_1", time.Now().Add(time.Minute))), strings.NewReader(`{"events": []}`))
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d without a context URL, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestHandleLogWorkflowSteps(t *testing.T) {
+	// Skip if no test database is configured
+	db, err := database.Connect("postgres://localhost/ai_sre_test?sslmode=disable")
+	if err != nil {
+		t.Skipf("test database not configured: %v", err)
+	}
+	defer db.Close()
+
+	key := strings.Repeat("k", 32)
+	cfg := &config.Config{
+		Server:         config.ServerConfig{Port: 8080},
+		WorkflowInputs: config.WorkflowInputsConfig{ContextURL: "https://incidents.example.com", SigningKey: key},
+	}
+	server := NewServer(cfg, db, nil, nil, NewLogger())
+	repository := database.NewIncidentRepository(db)
+	incident := &models.Incident{
+		ID:           "test-incident-steps",
+		ServiceName:  "checkout",
+		Repository:   "org/steps-test",
+		ErrorMessage: "test error",
+		Status:       models.StatusPending,
+		Provider:     "test",
+	}
+	if err := repository.Create(incident); err != nil {
+		t.Fatalf("failed to create test incident: %v", err)
+	}
+	defer func() {
+		_, _ = db.Exec("DELETE FROM incidents WHERE id = $1", incident.ID)
+	}()
+	if err := repository.UpdateStatus(incident.ID, models.StatusWorkflowTriggered); err != nil {
+		t.Fatalf("failed to trigger workflow: %v", err)
+	}
+
+	post := func(body string) *httptest.ResponseRecorder {
+		token := contextToken(key, incident.ID, time.Now().Add(time.Minute))
+		req := httptest.NewRequest("POST", "/api/v1/incidents/"+incident.ID+"/events?token="+url.QueryEscape(token), strings.NewReader(body))
+		w := httptest.NewRecorder()
+		server.Router().ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"events": [{"step": "reproduced error"}, {"step": "running tests", "message": "npm test", "details": {"suites": 12}}]}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response["logged"] != float64(2) || response["status"] != string(models.StatusInProgress) {
+		t.Errorf("expected 2 steps logged and the incident in progress, got %v", response)
+	}
+
+	// Later batches add to the timeline without changing the status again
+	if w := post(`{"events": [{"step": "opening pull request"}]}`); w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+
+	events, err := repository.GetEventsByIncidentID(incident.ID)
+	if err != nil {
+		t.Fatalf("failed to get events: %v", err)
+	}
+	var steps []string
+	inProgress := 0
+	for _, event := range events {
+		switch event.EventType {
+		case models.EventWorkflowStep:
+			steps = append(steps, event.EventData["step"].(string))
+		case models.EventWorkflowInProgress:
+			inProgress++
+		}
+	}
+	if strings.Join(steps, ", ") != "reproduced error, running tests, opening pull request" {
+		t.Errorf("expected the steps in order, got %v", steps)
+	}
+	if inProgress != 1 {
+		t.Errorf("expected one workflow_in_progress event, got %d", inProgress)
+	}
+}
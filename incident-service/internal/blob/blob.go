@@ -0,0 +1,54 @@
+// Package blob stores the files attached to incidents. The bytes live in a
+// pluggable backend, a local directory or an S3 bucket, under keys chosen by
+// the caller; what the files are is recorded in the database.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+// ErrNotFound is returned when no blob is stored under a key
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps blobs under slash-separated keys
+type Store interface {
+	// Put stores data under key, replacing any blob already there
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	// Get opens the blob stored under key. The caller closes it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob stored under key. Deleting a missing blob is
+	// not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// New creates the store of the configured backend
+func New(cfg config.AttachmentsConfig) (Store, error) {
+	switch cfg.StorageBackend() {
+	case config.AttachmentBackendDisk:
+		return NewDiskStore(cfg.Disk.Path)
+	case config.AttachmentBackendS3:
+		return NewS3Store(cfg.S3), nil
+	default:
+		return nil, fmt.Errorf("unknown attachment backend: %s", cfg.Backend)
+	}
+}
+
+// checkKey rejects keys that are empty, absolute, or could step out of the
+// store with . or .. segments
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("invalid blob key: %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("invalid blob key: %q", key)
+		}
+	}
+	return nil
+}
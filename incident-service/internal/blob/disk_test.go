@@ -0,0 +1,54 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+func TestDiskStore_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store, err := New(config.AttachmentsConfig{Disk: config.AttachmentsDiskConfig{Path: filepath.Join(t.TempDir(), "attachments")}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := store.Put(ctx, "inc_1/abc", "text/plain", []byte("panic: nil map")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	body, err := store.Get(ctx, "inc_1/abc")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "panic: nil map" {
+		t.Errorf("Get() = %q", data)
+	}
+
+	if err := store.Delete(ctx, "inc_1/abc"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get(ctx, "inc_1/abc"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after Delete, got %v", err)
+	}
+	if err := store.Delete(ctx, "inc_1/abc"); err != nil {
+		t.Errorf("expected deleting a missing blob to succeed, got %v", err)
+	}
+}
+
+func TestDiskStore_RejectsEscapingKeys(t *testing.T) {
+	store, err := NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskStore() error = %v", err)
+	}
+	for _, key := range []string{"", "/etc/passwd", "../secret", "inc_1/../../secret", "inc_1//abc", `inc_1\abc`} {
+		if err := store.Put(context.Background(), key, "", []byte("x")); err == nil {
+			t.Errorf("expected key %q to be rejected", key)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+// maxErrorBytes bounds how much of an S3 error response is read
+const maxErrorBytes = 4 << 10
+
+// S3Store keeps blobs as objects of an S3 bucket. Requests are signed with
+// AWS Signature Version 4, so S3-compatible stores such as MinIO work too.
+type S3Store struct {
+	httpClient *http.Client
+	config     config.AttachmentsS3Config
+	region     string
+	now        func() time.Time
+}
+
+// NewS3Store creates a store for the configured bucket
+func NewS3Store(cfg config.AttachmentsS3Config) *S3Store {
+	return &S3Store{
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+		config:     cfg,
+		region:     cfg.RegionName(),
+		now:        time.Now,
+	}
+}
+
+// Put uploads the blob as an object
+func (s *S3Store) Put(ctx context.Context, key, contentType string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, key, contentType, data)
+	if err != nil {
+		return fmt.Errorf("failed to store blob: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to store blob: %w", s3Error(resp))
+	}
+	return nil
+}
+
+// Get downloads the blob's object
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("failed to open blob: %w", s3Error(resp))
+	}
+}
+
+// Delete removes the blob's object
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, "", nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete blob: %w", s3Error(resp))
+	}
+	return nil
+}
+
+// do sends a signed request for the object of key
+func (s *S3Store) do(ctx context.Context, method, key, contentType string, data []byte) (*http.Response, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	objectURL := s.objectURL(s.config.Prefix + key)
+	req, err := http.NewRequestWithContext(ctx, method, objectURL.String(), bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	sum := sha256.Sum256(data)
+	s.sign(req, hex.EncodeToString(sum[:]), s.now())
+
+	return s.httpClient.Do(req)
+}
+
+// objectURL returns the URL of an object. Buckets on AWS are addressed by
+// host name; custom endpoints and force_path_style address them in the path.
+func (s *S3Store) objectURL(key string) *url.URL {
+	objectURL := &url.URL{Scheme: "https", Host: fmt.Sprintf("%s.s3.%s.amazonaws.com", s.config.Bucket, s.region)}
+	path := "/" + key
+	if s.config.Endpoint != "" || s.config.ForcePathStyle {
+		if s.config.Endpoint != "" {
+			endpoint, _ := url.Parse(s.config.Endpoint)
+			objectURL.Scheme, objectURL.Host = endpoint.Scheme, endpoint.Host
+		} else {
+			objectURL.Host = fmt.Sprintf("s3.%s.amazonaws.com", s.region)
+		}
+		path = "/" + s.config.Bucket + path
+	}
+	objectURL.Path = path
+	objectURL.RawPath = uriEncode(path, false)
+	return objectURL
+}
+
+// sign adds the headers and Authorization of AWS Signature Version 4 to req.
+// Every header already set on req is signed, along with its host.
+func (s *S3Store) sign(req *http.Request, payloadHash string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.config.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.config.SessionToken)
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQuery returns the query string sorted and encoded as SigV4 expects
+func canonicalQuery(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes everything but the unreserved characters of
+// RFC 3986, and slashes unless encodeSlash is set
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data under key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Error describes an unexpected S3 response
+func s3Error(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBytes))
+	return fmt.Errorf("s3 returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,171 @@
+// Package chaos injects controlled failures into the service's dependencies
+// for integration testing: GitHub API calls that fail or time out, and
+// webhooks that fail signature validation or parsing. Failures are drawn at
+// the configured rates, from a seeded source so that runs can be repeated.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// Injector decides which calls fail
+type Injector struct {
+	config config.ChaosConfig
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// New creates an injector for the configured failures
+func New(cfg config.ChaosConfig) *Injector {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Injector{config: cfg, rand: rand.New(rand.NewSource(seed))}
+}
+
+// roll returns a random number in [0, 1)
+func (i *Injector) roll() float64 {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.rand.Float64()
+}
+
+// Transport wraps a GitHub client's transport so that calls fail at the
+// configured rates, answered with the error status or hanging until they
+// time out, and are delayed by the configured latency
+func (i *Injector) Transport(next http.RoundTripper) http.RoundTripper {
+	return &transport{injector: i, next: next}
+}
+
+// transport is the RoundTripper returned by Transport
+type transport struct {
+	injector *Injector
+	next     http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cfg := t.injector.config.GitHub
+	if cfg.Latency > 0 {
+		if err := sleep(req.Context(), cfg.Latency); err != nil {
+			closeRequest(req)
+			return nil, err
+		}
+	}
+
+	roll := t.injector.roll()
+	switch {
+	case roll < cfg.ErrorRate:
+		closeRequest(req)
+		status := cfg.Status()
+		return &http.Response{
+			Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			StatusCode: status,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"message":"injected by chaos mode"}`)),
+			Request:    req,
+		}, nil
+	case roll < cfg.ErrorRate+cfg.TimeoutRate:
+		closeRequest(req)
+		if err := sleep(req.Context(), cfg.TimeoutAfter()); err != nil {
+			return nil, err
+		}
+		return nil, &TimeoutError{URL: req.URL.String()}
+	}
+	return t.next.RoundTrip(req)
+}
+
+// TimeoutError is returned for GitHub calls made to time out. Like the
+// errors of a real timeout, it is a net.Error whose Timeout is true.
+type TimeoutError struct {
+	URL string
+}
+
+// Error describes the timeout
+func (e *TimeoutError) Error() string {
+	return "chaos: injected timeout calling " + e.URL
+}
+
+// Timeout reports that the error is a timeout
+func (e *TimeoutError) Timeout() bool { return true }
+
+// Temporary reports that the error is temporary
+func (e *TimeoutError) Temporary() bool { return true }
+
+// Adapter wraps a webhook adapter so that webhooks of the affected providers
+// fail signature validation and parsing at the configured rates
+func (i *Injector) Adapter(next adapters.WebhookAdapter) adapters.WebhookAdapter {
+	if !i.config.Webhooks.Affects(next.ProviderName()) {
+		return next
+	}
+	wrapped := &adapter{injector: i, WebhookAdapter: next}
+	if protected, ok := next.(adapters.ReplayProtected); ok {
+		return &replayProtectedAdapter{adapter: wrapped, ReplayProtected: protected}
+	}
+	return wrapped
+}
+
+// adapter is the WebhookAdapter returned by Adapter
+type adapter struct {
+	injector *Injector
+	adapters.WebhookAdapter
+}
+
+// replayProtectedAdapter keeps the replay protection of adapters that have it
+type replayProtectedAdapter struct {
+	*adapter
+	adapters.ReplayProtected
+}
+
+// Validate implements adapters.WebhookAdapter
+func (a *adapter) Validate(r *http.Request) error {
+	if a.injector.roll() < a.injector.config.Webhooks.SignatureErrorRate {
+		return &adapters.FailureError{Reason: adapters.ReasonSignature, Err: errors.New("chaos: injected signature error")}
+	}
+	return a.WebhookAdapter.Validate(r)
+}
+
+// Parse implements adapters.WebhookAdapter
+func (a *adapter) Parse(body []byte) (*models.Incident, error) {
+	if a.injector.roll() < a.injector.config.Webhooks.ParseErrorRate {
+		return nil, &adapters.FailureError{Reason: adapters.ReasonSchema, Err: errors.New("chaos: injected parse error")}
+	}
+	return a.WebhookAdapter.Parse(body)
+}
+
+// sleep waits for d, or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// closeRequest closes the body of a request that is not sent, as a
+// RoundTripper must
+func closeRequest(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
@@ -0,0 +1,130 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+)
+
+// newGitHub returns a GitHub API stub that counts the requests reaching it
+func newGitHub(t *testing.T) (*httptest.Server, *int64) {
+	var calls int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1, "name": "remediate", "path": ".github/workflows/remediate.yml", "state": "active"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestTransport_InjectsErrors(t *testing.T) {
+	server, calls := newGitHub(t)
+	client := github.NewClient(server.URL, "token", "remediate.yml", 3)
+	client.WrapTransport(New(config.ChaosConfig{GitHub: config.ChaosGitHubConfig{ErrorRate: 1, ErrorStatus: 502}}).Transport)
+
+	_, err := client.GetWorkflow(context.Background(), "org/checkout")
+	var apiErr *github.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected an injected 502, got %v", err)
+	}
+	if atomic.LoadInt64(calls) != 0 {
+		t.Errorf("expected no request to reach GitHub, got %d", *calls)
+	}
+}
+
+func TestTransport_InjectsTimeouts(t *testing.T) {
+	server, calls := newGitHub(t)
+	client := github.NewClient(server.URL, "token", "remediate.yml", 3)
+	client.WrapTransport(New(config.ChaosConfig{GitHub: config.ChaosGitHubConfig{TimeoutRate: 1, Timeout: 10 * time.Millisecond}}).Transport)
+
+	_, err := client.GetWorkflow(context.Background(), "org/checkout")
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+	if atomic.LoadInt64(calls) != 0 {
+		t.Errorf("expected no request to reach GitHub, got %d", *calls)
+	}
+}
+
+func TestTransport_PassesThroughWithoutFailures(t *testing.T) {
+	server, calls := newGitHub(t)
+	client := github.NewClient(server.URL, "token", "remediate.yml", 3)
+	client.WrapTransport(New(config.ChaosConfig{GitHub: config.ChaosGitHubConfig{Latency: time.Millisecond}}).Transport)
+
+	workflow, err := client.GetWorkflow(context.Background(), "org/checkout")
+	if err != nil || workflow == nil || workflow.State != "active" {
+		t.Fatalf("GetWorkflow() = %+v, %v", workflow, err)
+	}
+	if atomic.LoadInt64(calls) != 1 {
+		t.Errorf("expected the request to reach GitHub, got %d calls", *calls)
+	}
+}
+
+func TestInjector_SeedRepeatsFailures(t *testing.T) {
+	sequence := func() string {
+		injector := New(config.ChaosConfig{Seed: 42, GitHub: config.ChaosGitHubConfig{ErrorRate: 0.5}})
+		var b strings.Builder
+		for i := 0; i < 32; i++ {
+			if injector.roll() < 0.5 {
+				b.WriteByte('x')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		return b.String()
+	}
+
+	first, second := sequence(), sequence()
+	if first != second {
+		t.Errorf("expected the same failures for the same seed, got %s and %s", first, second)
+	}
+	if !strings.Contains(first, "x") || !strings.Contains(first, ".") {
+		t.Errorf("expected a mix of failures and successes, got %s", first)
+	}
+}
+
+func TestAdapter_InjectsWebhookFailures(t *testing.T) {
+	req := httptest.NewRequest("POST", "/api/v1/webhooks/incidents?provider=datadog", strings.NewReader("{}"))
+
+	injector := New(config.ChaosConfig{Webhooks: config.ChaosWebhooksConfig{SignatureErrorRate: 1}})
+	err := injector.Adapter(adapters.NewDatadogAdapter()).Validate(req)
+	if adapters.FailureReason(err) != adapters.ReasonSignature {
+		t.Errorf("expected an injected signature error, got %v", err)
+	}
+
+	injector = New(config.ChaosConfig{Webhooks: config.ChaosWebhooksConfig{ParseErrorRate: 1}})
+	_, err = injector.Adapter(adapters.NewDatadogAdapter()).Parse([]byte(`{"id": "1"}`))
+	if adapters.FailureReason(err) != adapters.ReasonSchema {
+		t.Errorf("expected an injected parse error, got %v", err)
+	}
+}
+
+func TestAdapter_LimitedToProviders(t *testing.T) {
+	injector := New(config.ChaosConfig{Webhooks: config.ChaosWebhooksConfig{Providers: []string{"sentry"}, ParseErrorRate: 1}})
+
+	datadog := adapters.NewDatadogAdapter()
+	if injector.Adapter(datadog) != adapters.WebhookAdapter(datadog) {
+		t.Error("expected adapters of other providers to be left as they are")
+	}
+
+	// Replay protection still applies to the wrapped adapter
+	sentry := injector.Adapter(adapters.NewSentryAdapter())
+	if _, ok := sentry.(adapters.ReplayProtected); !ok {
+		t.Error("expected the wrapped adapter to keep its replay protection")
+	}
+	if sentry.ProviderName() != "sentry" {
+		t.Errorf("ProviderName() = %q", sentry.ProviderName())
+	}
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Attachment storage backends
+const (
+	AttachmentBackendDisk = "disk"
+	AttachmentBackendS3   = "s3"
+)
+
+// AttachmentsConfig controls files attached to incidents, such as
+// screenshots, log excerpts or flame graphs. The files are kept in a blob
+// store; their metadata is kept in the database.
+type AttachmentsConfig struct {
+	Enabled        bool                  `yaml:"enabled"`
+	Backend        string                `yaml:"backend"`          // disk or s3, defaults to disk
+	MaxSize        int64                 `yaml:"max_size"`         // bytes per file, defaults to 10 MiB
+	MaxPerIncident int                   `yaml:"max_per_incident"` // defaults to 20
+	Disk           AttachmentsDiskConfig `yaml:"disk"`
+	S3             AttachmentsS3Config   `yaml:"s3"`
+}
+
+// AttachmentsDiskConfig keeps attachments in a local directory
+type AttachmentsDiskConfig struct {
+	Path string `yaml:"path"`
+}
+
+// AttachmentsS3Config keeps attachments in an S3 bucket, or in a store
+// speaking the S3 API
+type AttachmentsS3Config struct {
+	Bucket          string `yaml:"bucket"`
+	Region          string `yaml:"region"`   // defaults to us-east-1
+	Endpoint        string `yaml:"endpoint"` // S3-compatible endpoint such as MinIO, defaults to AWS
+	Prefix          string `yaml:"prefix"`   // prepended to every object key
+	AccessKeyID     string `yaml:"access_key_id"`
+	SecretAccessKey string `yaml:"secret_access_key"`
+	SessionToken    string `yaml:"session_token"`    // temporary credentials, optional
+	ForcePathStyle  bool   `yaml:"force_path_style"` // address the bucket in the path rather than the host name, implied by endpoint
+}
+
+// StorageBackend returns where attachments are kept
+func (c *AttachmentsConfig) StorageBackend() string {
+	if c.Backend == "" {
+		return AttachmentBackendDisk
+	}
+	return c.Backend
+}
+
+// SizeLimit returns the largest attachment accepted, in bytes
+func (c *AttachmentsConfig) SizeLimit() int64 {
+	if c.MaxSize <= 0 {
+		return 10 << 20
+	}
+	return c.MaxSize
+}
+
+// IncidentLimit returns how many attachments an incident may have
+func (c *AttachmentsConfig) IncidentLimit() int {
+	if c.MaxPerIncident <= 0 {
+		return 20
+	}
+	return c.MaxPerIncident
+}
+
+// RegionName returns the region requests are signed for
+func (c *AttachmentsS3Config) RegionName() string {
+	if c.Region == "" {
+		return "us-east-1"
+	}
+	return c.Region
+}
+
+// Validate checks the limits and the settings of the chosen backend
+func (c *AttachmentsConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.MaxSize < 0 {
+		return fmt.Errorf("max_size must not be negative")
+	}
+	if c.MaxPerIncident < 0 {
+		return fmt.Errorf("max_per_incident must not be negative")
+	}
+
+	switch c.StorageBackend() {
+	case AttachmentBackendDisk:
+		if c.Disk.Path == "" {
+			return fmt.Errorf("disk.path is required for the disk backend")
+		}
+	case AttachmentBackendS3:
+		if err := c.S3.Validate(); err != nil {
+			return fmt.Errorf("s3: %w", err)
+		}
+	default:
+		return fmt.Errorf("backend must be %s or %s", AttachmentBackendDisk, AttachmentBackendS3)
+	}
+	return nil
+}
+
+// Validate checks that the bucket and credentials are set
+func (c *AttachmentsS3Config) Validate() error {
+	if c.Bucket == "" {
+		return fmt.Errorf("bucket is required")
+	}
+	if c.AccessKeyID == "" || c.SecretAccessKey == "" {
+		return fmt.Errorf("access_key_id and secret_access_key are required")
+	}
+	if c.Endpoint != "" {
+		parsed, err := url.Parse(c.Endpoint)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("endpoint must be an http or https URL")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAttachmentsConfig_Validate(t *testing.T) {
+	s3 := AttachmentsS3Config{Bucket: "incidents", AccessKeyID: "AKID", SecretAccessKey: "secret"}
+
+	tests := []struct {
+		name    string
+		cfg     AttachmentsConfig
+		wantErr string
+	}{
+		{"disabled", AttachmentsConfig{Backend: "gcs"}, ""},
+		{"disk", AttachmentsConfig{Enabled: true, Disk: AttachmentsDiskConfig{Path: "/var/lib/attachments"}}, ""},
+		{"disk without path", AttachmentsConfig{Enabled: true}, "disk.path is required"},
+		{"s3", AttachmentsConfig{Enabled: true, Backend: AttachmentBackendS3, S3: s3}, ""},
+		{"s3 without bucket", AttachmentsConfig{Enabled: true, Backend: AttachmentBackendS3, S3: AttachmentsS3Config{AccessKeyID: "AKID", SecretAccessKey: "secret"}}, "bucket is required"},
+		{"s3 without credentials", AttachmentsConfig{Enabled: true, Backend: AttachmentBackendS3, S3: AttachmentsS3Config{Bucket: "incidents"}}, "access_key_id"},
+		{"s3 bad endpoint", AttachmentsConfig{Enabled: true, Backend: AttachmentBackendS3, S3: AttachmentsS3Config{
+			Bucket: "incidents", AccessKeyID: "AKID", SecretAccessKey: "secret", Endpoint: "minio:9000",
+		}}, "endpoint"},
+		{"unknown backend", AttachmentsConfig{Enabled: true, Backend: "gcs"}, "backend must be"},
+		{"negative size", AttachmentsConfig{Enabled: true, MaxSize: -1, Disk: AttachmentsDiskConfig{Path: "/tmp"}}, "max_size"},
+		{"negative count", AttachmentsConfig{Enabled: true, MaxPerIncident: -1, Disk: AttachmentsDiskConfig{Path: "/tmp"}}, "max_per_incident"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAttachmentsConfig_Defaults(t *testing.T) {
+	cfg := AttachmentsConfig{}
+	if got := cfg.StorageBackend(); got != AttachmentBackendDisk {
+		t.Errorf("StorageBackend() = %s, want disk", got)
+	}
+	if got := cfg.SizeLimit(); got != 10<<20 {
+		t.Errorf("SizeLimit() = %d, want 10 MiB", got)
+	}
+	if got := cfg.IncidentLimit(); got != 20 {
+		t.Errorf("IncidentLimit() = %d, want 20", got)
+	}
+	if got := cfg.S3.RegionName(); got != "us-east-1" {
+		t.Errorf("RegionName() = %s, want us-east-1", got)
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+)
+
+// AuthConfig controls API key authentication of the management API. With no
+// keys configured the API is open, as it is behind a trusted network.
+type AuthConfig struct {
+	APIKeys []APIKey `yaml:"api_keys"`
+	// ScopeByTeam limits each key to the incidents of its teams, unless the
+	// key is an admin key
+	ScopeByTeam bool `yaml:"scope_by_team"`
+}
+
+// APIKey is a named key accepted as a bearer token or X-API-Key header
+type APIKey struct {
+	Name  string   `yaml:"name"` // identifies the caller in logs
+	Key   string   `yaml:"key"`
+	Teams []string `yaml:"teams"` // teams whose incidents the key can see and act on
+	Admin bool     `yaml:"admin"` // sees and acts on every incident of its tenant, and may reset dispatch queues and map services
+	// Tenant limits the key to the data of one tenant. Keys without one
+	// serve every tenant.
+	Tenant string `yaml:"tenant"`
+}
+
+// Enabled reports whether requests must carry an API key
+func (c *AuthConfig) Enabled() bool {
+	return len(c.APIKeys) > 0
+}
+
+// Validate checks that every API key is named and set
+func (c *AuthConfig) Validate() error {
+	names := make(map[string]bool, len(c.APIKeys))
+	for i, key := range c.APIKeys {
+		if key.Name == "" {
+			return fmt.Errorf("api_keys[%d]: name is required", i)
+		}
+		if names[key.Name] {
+			return fmt.Errorf("api_keys[%d]: duplicate name %q", i, key.Name)
+		}
+		names[key.Name] = true
+		if key.Key == "" {
+			return fmt.Errorf("api_keys[%d] (%s): key is required", i, key.Name)
+		}
+		if c.ScopeByTeam && len(key.Teams) == 0 && !key.Admin {
+			return fmt.Errorf("api_keys[%d] (%s): teams or admin is required when scope_by_team is set", i, key.Name)
+		}
+	}
+	if c.ScopeByTeam && len(c.APIKeys) == 0 {
+		return fmt.Errorf("scope_by_team requires api_keys")
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package config
+
+import "testing"
+
+func TestAuthConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     AuthConfig
+		wantErr bool
+	}{
+		{"open", AuthConfig{}, false},
+		{"keys", AuthConfig{APIKeys: []APIKey{{Name: "ops-cli", Key: "secret"}}}, false},
+		{"missing key", AuthConfig{APIKeys: []APIKey{{Name: "ops-cli"}}}, true},
+		{
+			"scoped keys",
+			AuthConfig{ScopeByTeam: true, APIKeys: []APIKey{
+				{Name: "payments", Key: "a", Teams: []string{"payments"}},
+				{Name: "sre", Key: "b", Admin: true},
+			}},
+			false,
+		},
+		{
+			"scoped key without teams",
+			AuthConfig{ScopeByTeam: true, APIKeys: []APIKey{{Name: "payments", Key: "a"}}},
+			true,
+		},
+		{"scoping without keys", AuthConfig{ScopeByTeam: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// ChaosConfig injects failures into GitHub API calls and webhook handling,
+// so that integration tests exercise retries, the circuit breaker and
+// failure metrics against otherwise healthy dependencies. It is for test
+// environments only and must never be enabled in production.
+type ChaosConfig struct {
+	Enabled  bool                `yaml:"enabled"`
+	Seed     int64               `yaml:"seed"` // makes the injected failures repeatable, random when 0
+	GitHub   ChaosGitHubConfig   `yaml:"github"`
+	Webhooks ChaosWebhooksConfig `yaml:"webhooks"`
+}
+
+// ChaosGitHubConfig controls the failures injected into GitHub API calls
+type ChaosGitHubConfig struct {
+	ErrorRate   float64       `yaml:"error_rate"`   // fraction of calls answered with error_status
+	ErrorStatus int           `yaml:"error_status"` // defaults to 500
+	TimeoutRate float64       `yaml:"timeout_rate"` // fraction of calls that time out
+	Timeout     time.Duration `yaml:"timeout"`      // how long a call hangs before timing out, defaults to 1s
+	Latency     time.Duration `yaml:"latency"`      // added to every call
+}
+
+// ChaosWebhooksConfig controls the failures injected into provider webhooks
+type ChaosWebhooksConfig struct {
+	Providers          []string `yaml:"providers"`            // providers affected, all when empty
+	SignatureErrorRate float64  `yaml:"signature_error_rate"` // fraction of webhooks failing signature validation
+	ParseErrorRate     float64  `yaml:"parse_error_rate"`     // fraction of webhooks failing to parse
+}
+
+// Status returns the status code of injected GitHub errors
+func (c *ChaosGitHubConfig) Status() int {
+	if c.ErrorStatus == 0 {
+		return 500
+	}
+	return c.ErrorStatus
+}
+
+// TimeoutAfter returns how long a timed out GitHub call hangs
+func (c *ChaosGitHubConfig) TimeoutAfter() time.Duration {
+	if c.Timeout <= 0 {
+		return time.Second
+	}
+	return c.Timeout
+}
+
+// Affects reports whether failures are injected into the provider's webhooks
+func (c *ChaosWebhooksConfig) Affects(provider string) bool {
+	if len(c.Providers) == 0 {
+		return true
+	}
+	for _, p := range c.Providers {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that rates are fractions and the error status is an error
+func (c *ChaosConfig) Validate() error {
+	for name, rate := range map[string]float64{
+		"github.error_rate":             c.GitHub.ErrorRate,
+		"github.timeout_rate":           c.GitHub.TimeoutRate,
+		"webhooks.signature_error_rate": c.Webhooks.SignatureErrorRate,
+		"webhooks.parse_error_rate":     c.Webhooks.ParseErrorRate,
+	} {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("%s must be between 0 and 1", name)
+		}
+	}
+	if c.GitHub.ErrorRate+c.GitHub.TimeoutRate > 1 {
+		return fmt.Errorf("github.error_rate and github.timeout_rate must add up to at most 1")
+	}
+	if status := c.GitHub.Status(); status < 400 || status > 599 {
+		return fmt.Errorf("github.error_status must be a 4xx or 5xx status code")
+	}
+	if c.GitHub.Timeout < 0 || c.GitHub.Latency < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChaosConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ChaosConfig
+		wantErr bool
+	}{
+		{"disabled", ChaosConfig{}, false},
+		{"github failures", ChaosConfig{Enabled: true, GitHub: ChaosGitHubConfig{ErrorRate: 0.3, TimeoutRate: 0.2, ErrorStatus: 429}}, false},
+		{"webhook failures", ChaosConfig{Enabled: true, Webhooks: ChaosWebhooksConfig{SignatureErrorRate: 1, ParseErrorRate: 1}}, false},
+		{"rate above 1", ChaosConfig{GitHub: ChaosGitHubConfig{ErrorRate: 1.5}}, true},
+		{"negative rate", ChaosConfig{Webhooks: ChaosWebhooksConfig{ParseErrorRate: -0.1}}, true},
+		{"github rates above 1", ChaosConfig{GitHub: ChaosGitHubConfig{ErrorRate: 0.6, TimeoutRate: 0.6}}, true},
+		{"success status", ChaosConfig{GitHub: ChaosGitHubConfig{ErrorStatus: 200}}, true},
+		{"negative latency", ChaosConfig{GitHub: ChaosGitHubConfig{Latency: -time.Second}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
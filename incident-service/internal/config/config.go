@@ -12,32 +12,75 @@ import (
 
 // Config represents the application configuration
 type Config struct {
-	Server          ServerConfig        `yaml:"server"`
-	Database        DatabaseConfig      `yaml:"database"`
-	Redis           RedisConfig         `yaml:"redis"`
-	GitHub          GitHubConfig        `yaml:"github"`
-	ServiceMappings []ServiceMapping    `yaml:"service_mappings"`
-	Deduplication   DeduplicationConfig `yaml:"deduplication"`
-	Concurrency     ConcurrencyConfig   `yaml:"concurrency"`
-	MCPServers      []MCPServerConfig   `yaml:"mcp_servers"`
-	CustomRules     []CustomRule        `yaml:"custom_rules"`
+	Server          ServerConfig           `yaml:"server"`
+	Database        DatabaseConfig         `yaml:"database"`
+	Redis           RedisConfig            `yaml:"redis"`
+	GitHub          GitHubConfig           `yaml:"github"`
+	ServiceMappings []ServiceMapping       `yaml:"service_mappings"`
+	Teams           []Team                 `yaml:"teams"`
+	Tenants         []Tenant               `yaml:"tenants"`
+	IncidentIDs     IncidentIDsConfig      `yaml:"incident_ids"`
+	Deduplication   DeduplicationConfig    `yaml:"deduplication"`
+	Concurrency     ConcurrencyConfig      `yaml:"concurrency"`
+	Ingestion       IngestionConfig        `yaml:"ingestion"`
+	Webhooks        WebhooksConfig         `yaml:"webhooks"`
+	MCPServers      []MCPServerConfig      `yaml:"mcp_servers"`
+	Enrichment      EnrichmentConfig       `yaml:"enrichment"`
+	Summaries       SummaryConfig          `yaml:"summaries"`
+	Similarity      SimilarityConfig       `yaml:"similarity"`
+	Severity        SeverityConfig         `yaml:"severity_classifier"`
+	CustomRules     []CustomRule           `yaml:"custom_rules"`
+	Notifications   NotificationsConfig    `yaml:"notifications"`
+	Escalation      EscalationConfig       `yaml:"escalation"`
+	Digests         DigestConfig           `yaml:"digests"`
+	Reconciliation  ReconciliationConfig   `yaml:"reconciliation"`
+	WorkflowLogs    WorkflowLogsConfig     `yaml:"workflow_logs"`
+	StaleIncidents  StaleIncidentsConfig   `yaml:"stale_incidents"`
+	Retries         RemediationRetryConfig `yaml:"remediation_retries"`
+	RemediationCaps RemediationCapsConfig  `yaml:"remediation_caps"`
+	Tracing         TracingConfig          `yaml:"tracing"`
+	ErrorReporting  ErrorReportingConfig   `yaml:"error_reporting"`
+	Logging         LoggingConfig          `yaml:"logging"`
+	KPIs            KPIConfig              `yaml:"kpis"`
+	Rollups         RollupConfig           `yaml:"rollups"`
+	SLOs            SLOConfig              `yaml:"slos"`
+	SLAs            SLAConfig              `yaml:"slas"`
+	Storms          StormConfig            `yaml:"storms"`
+	Grouping        GroupingConfig         `yaml:"grouping"`
+	Correlation     CorrelationConfig      `yaml:"correlation"`
+	Maintenance     MaintenanceConfig      `yaml:"maintenance"`
+	Freezes         FreezeConfig           `yaml:"freezes"`
+	Attachments     AttachmentsConfig      `yaml:"attachments"`
+	Postmortems     PostmortemConfig       `yaml:"postmortems"`
+	Impact          ImpactConfig           `yaml:"impact"`
+	Chaos           ChaosConfig            `yaml:"chaos"`
+	Fixtures        FixturesConfig         `yaml:"fixtures"`
+	WorkflowInputs  WorkflowInputsConfig   `yaml:"workflow_inputs"`
+	LeaderElection  LeaderElectionConfig   `yaml:"leader_election"`
+	SlowDrip        SlowDripConfig         `yaml:"slow_drip"`
+
+	LoadedAt time.Time `yaml:"-"` // when the file was last read, set by Load
 }
 
 // ServerConfig contains HTTP server settings
 type ServerConfig struct {
-	Port         int           `yaml:"port"`
-	ReadTimeout  time.Duration `yaml:"read_timeout"`
-	WriteTimeout time.Duration `yaml:"write_timeout"`
+	Port         int             `yaml:"port"`
+	ReadTimeout  time.Duration   `yaml:"read_timeout"`
+	WriteTimeout time.Duration   `yaml:"write_timeout"`
+	Debug        DebugConfig     `yaml:"debug"`
+	Auth         AuthConfig      `yaml:"auth"`
+	Dashboard    DashboardConfig `yaml:"dashboard"`
 }
 
 // DatabaseConfig contains PostgreSQL connection settings
 type DatabaseConfig struct {
-	Host     string `yaml:"host"`
-	Port     int    `yaml:"port"`
-	Database string `yaml:"database"`
-	User     string `yaml:"user"`
-	Password string `yaml:"password"`
-	SSLMode  string `yaml:"ssl_mode"`
+	Host       string           `yaml:"host"`
+	Port       int              `yaml:"port"`
+	Database   string           `yaml:"database"`
+	User       string           `yaml:"user"`
+	Password   string           `yaml:"password"`
+	SSLMode    string           `yaml:"ssl_mode"`
+	Encryption EncryptionConfig `yaml:"encryption"`
 }
 
 // RedisConfig contains Redis connection settings
@@ -53,30 +96,100 @@ type GitHubConfig struct {
 	APIURL       string `yaml:"api_url"`
 	Token        string `yaml:"token"`
 	WorkflowName string `yaml:"workflow_name"`
+	MaxIdleConns int    `yaml:"max_idle_conns"` // idle API connections kept open for reuse, defaults to 32
 }
 
 // DeduplicationConfig contains incident deduplication settings
 type DeduplicationConfig struct {
-	TimeWindow time.Duration `yaml:"time_window"`
+	TimeWindow   time.Duration `yaml:"time_window"`
+	ReopenWindow time.Duration `yaml:"reopen_window"` // a recurrence within this long of resolution reopens the incident, 0 disables
+	Strategy     string        `yaml:"strategy"`      // what makes incidents repeats of each other, see DedupStrategyFingerprint
+	// Services overrides the strategy per service name, for teams whose
+	// alerts need other semantics
+	Services map[string]string `yaml:"services"`
 }
 
 // ConcurrencyConfig contains workflow concurrency settings
 type ConcurrencyConfig struct {
-	MaxWorkflowsPerRepo int `yaml:"max_workflows_per_repo"`
+	MaxWorkflowsPerRepo int    `yaml:"max_workflows_per_repo"`
+	DispatchShards      int    `yaml:"dispatch_shards"` // workers serializing dispatches by repository, defaults to 16
+	Store               string `yaml:"store"`           // memory, or redis to share slots and queues between replicas
+	// SnapshotInterval is how often the in-memory slots and queues are saved
+	// to the database, to be restored when the service starts. Defaults to
+	// 30s; the Redis store keeps them across restarts itself.
+	SnapshotInterval time.Duration `yaml:"snapshot_interval"`
+	// SlotTTL is how long a workflow may hold its slot without reporting
+	// back before the slot is released, so that a lost callback does not
+	// stall the repository's queue. Defaults to 6h, the longest a GitHub
+	// Actions job runs.
+	SlotTTL time.Duration `yaml:"slot_ttl"`
+	// BurstThreshold is how many remediations may be dispatched within
+	// BurstWindow before only critical and high severity incidents are
+	// dispatched right away. The rest wait for the burst to end. Zero, the
+	// default, never holds an incident back.
+	BurstThreshold int           `yaml:"burst_threshold"`
+	BurstWindow    time.Duration `yaml:"burst_window"` // defaults to 1m
+}
+
+// DefaultQueueSnapshotInterval is how often the in-memory slots and queues
+// are saved when concurrency.snapshot_interval is not set
+const DefaultQueueSnapshotInterval = 30 * time.Second
+
+// SnapshotEvery returns how often the in-memory slots and queues are saved
+func (c *ConcurrencyConfig) SnapshotEvery() time.Duration {
+	if c.SnapshotInterval <= 0 {
+		return DefaultQueueSnapshotInterval
+	}
+	return c.SnapshotInterval
+}
+
+// DefaultSlotTTL is how long a workflow may hold its slot when
+// concurrency.slot_ttl is not set
+const DefaultSlotTTL = 6 * time.Hour
+
+// SlotExpiry returns how long a workflow may hold its slot without reporting back
+func (c *ConcurrencyConfig) SlotExpiry() time.Duration {
+	if c.SlotTTL <= 0 {
+		return DefaultSlotTTL
+	}
+	return c.SlotTTL
+}
+
+// DefaultBurstWindow is the window of concurrency.burst_threshold when
+// concurrency.burst_window is not set
+const DefaultBurstWindow = time.Minute
+
+// BurstWindowSize returns the window in which dispatches count towards a burst
+func (c *ConcurrencyConfig) BurstWindowSize() time.Duration {
+	if c.BurstWindow <= 0 {
+		return DefaultBurstWindow
+	}
+	return c.BurstWindow
 }
 
+// Concurrency stores
+const (
+	ConcurrencyStoreMemory = "memory"
+	ConcurrencyStoreRedis  = "redis"
+)
+
 // ServiceMapping maps a service name to a repository
 type ServiceMapping struct {
-	ServiceName string `yaml:"service_name"`
-	Repository  string `yaml:"repository"`
-	Branch      string `yaml:"branch"`
+	ServiceName  string             `yaml:"service_name"`
+	Repository   string             `yaml:"repository"`
+	Branch       string             `yaml:"branch"`
+	Team         string             `yaml:"team"`   // name of the owning team, optional
+	Tenant       string             `yaml:"tenant"` // name of the owning tenant, DefaultTenant when empty
+	Runbooks     []Link             `yaml:"runbooks"`
+	KnownIssues  []Link             `yaml:"known_issues"`
+	Repositories []RepositoryTarget `yaml:"repositories"` // remediated alongside Repository, optional
 }
 
 // MCPServerConfig contains MCP server configuration
 type MCPServerConfig struct {
 	Name   string            `yaml:"name"`
-	Type   string            `yaml:"type"`
-	Config map[string]string `yaml:"config"`
+	Type   string            `yaml:"type"`   // http servers are queried by the service itself
+	Config map[string]string `yaml:"config"` // url and token of http servers
 }
 
 // CustomRule represents a custom incident detection rule
@@ -94,6 +207,7 @@ type RuleConditions struct {
 	ErrorPattern *string            `yaml:"error_pattern"`
 	Severity     *string            `yaml:"severity"`
 	Provider     *string            `yaml:"provider"`
+	Tenant       *string            `yaml:"tenant"`
 	Metadata     map[string]string  `yaml:"metadata"`
 }
 
@@ -152,6 +266,7 @@ func Load(path string) (*Config, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
+	cfg.LoadedAt = time.Now()
 
 	return &cfg, nil
 }
@@ -170,6 +285,54 @@ func (c *Config) Validate() error {
 	if c.GitHub.Token == "" {
 		return fmt.Errorf("github.token is required")
 	}
+	if c.GitHub.MaxIdleConns < 0 {
+		return fmt.Errorf("github.max_idle_conns must not be negative")
+	}
+	if err := c.Database.Encryption.Validate(); err != nil {
+		return fmt.Errorf("invalid database.encryption config: %w", err)
+	}
+
+	switch c.Concurrency.Store {
+	case "", ConcurrencyStoreMemory, ConcurrencyStoreRedis:
+	default:
+		return fmt.Errorf("concurrency.store must be %s or %s", ConcurrencyStoreMemory, ConcurrencyStoreRedis)
+	}
+	if c.Concurrency.SnapshotInterval < 0 {
+		return fmt.Errorf("concurrency.snapshot_interval must not be negative")
+	}
+	if c.Concurrency.SlotTTL < 0 {
+		return fmt.Errorf("concurrency.slot_ttl must not be negative")
+	}
+	if c.Concurrency.BurstThreshold < 0 {
+		return fmt.Errorf("concurrency.burst_threshold must not be negative")
+	}
+	if c.Concurrency.BurstWindow < 0 {
+		return fmt.Errorf("concurrency.burst_window must not be negative")
+	}
+
+	if err := c.IncidentIDs.Validate(); err != nil {
+		return fmt.Errorf("invalid incident_ids config: %w", err)
+	}
+
+	if err := c.Deduplication.Validate(); err != nil {
+		return fmt.Errorf("invalid deduplication config: %w", err)
+	}
+
+	if err := c.validateTeams(); err != nil {
+		return fmt.Errorf("invalid teams config: %w", err)
+	}
+
+	if err := c.validateTenants(); err != nil {
+		return fmt.Errorf("invalid tenants config: %w", err)
+	}
+
+	if err := c.validateLinks(); err != nil {
+		return fmt.Errorf("invalid service mappings: %w", err)
+	}
+
+	if err := c.validateRepositories(); err != nil {
+		return fmt.Errorf("invalid service mappings: %w", err)
+	}
 
 	// Validate custom rules
 	for i, rule := range c.CustomRules {
@@ -178,6 +341,152 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if err := c.Notifications.Validate(); err != nil {
+		return fmt.Errorf("invalid notifications config: %w", err)
+	}
+
+	if err := c.Escalation.Validate(); err != nil {
+		return fmt.Errorf("invalid escalation config: %w", err)
+	}
+
+	if err := c.Digests.Validate(); err != nil {
+		return fmt.Errorf("invalid digests config: %w", err)
+	}
+
+	if err := c.Reconciliation.Validate(); err != nil {
+		return fmt.Errorf("invalid reconciliation config: %w", err)
+	}
+
+	if err := c.WorkflowLogs.Validate(); err != nil {
+		return fmt.Errorf("invalid workflow_logs config: %w", err)
+	}
+
+	if err := c.StaleIncidents.Validate(); err != nil {
+		return fmt.Errorf("invalid stale_incidents config: %w", err)
+	}
+
+	if err := c.Ingestion.Validate(); err != nil {
+		return fmt.Errorf("invalid ingestion config: %w", err)
+	}
+
+	if err := c.Webhooks.Validate(); err != nil {
+		return fmt.Errorf("invalid webhooks config: %w", err)
+	}
+
+	if err := c.Retries.Validate(); err != nil {
+		return fmt.Errorf("invalid remediation_retries config: %w", err)
+	}
+
+	if err := c.Tracing.Validate(); err != nil {
+		return fmt.Errorf("invalid tracing config: %w", err)
+	}
+
+	if err := c.ErrorReporting.Validate(); err != nil {
+		return fmt.Errorf("invalid error_reporting config: %w", err)
+	}
+
+	if err := c.Server.Debug.Validate(); err != nil {
+		return fmt.Errorf("invalid server.debug config: %w", err)
+	}
+
+	if err := c.Server.Auth.Validate(); err != nil {
+		return fmt.Errorf("invalid server.auth config: %w", err)
+	}
+
+	if err := c.Logging.Validate(); err != nil {
+		return fmt.Errorf("invalid logging config: %w", err)
+	}
+
+	if err := c.KPIs.Validate(); err != nil {
+		return fmt.Errorf("invalid kpis config: %w", err)
+	}
+
+	if err := c.Rollups.Validate(); err != nil {
+		return fmt.Errorf("invalid rollups config: %w", err)
+	}
+
+	if err := c.SLAs.Validate(); err != nil {
+		return fmt.Errorf("invalid slas config: %w", err)
+	}
+
+	if err := c.SLOs.Validate(); err != nil {
+		return fmt.Errorf("invalid slos config: %w", err)
+	}
+
+	if err := c.Storms.Validate(); err != nil {
+		return fmt.Errorf("invalid storms config: %w", err)
+	}
+
+	if err := c.Grouping.Validate(); err != nil {
+		return fmt.Errorf("invalid grouping config: %w", err)
+	}
+
+	if err := c.Correlation.Validate(); err != nil {
+		return fmt.Errorf("invalid correlation config: %w", err)
+	}
+
+	if err := c.SlowDrip.Validate(); err != nil {
+		return fmt.Errorf("invalid slow_drip config: %w", err)
+	}
+
+	if err := c.RemediationCaps.Validate(); err != nil {
+		return fmt.Errorf("invalid remediation_caps config: %w", err)
+	}
+
+	if err := c.Maintenance.Validate(); err != nil {
+		return fmt.Errorf("invalid maintenance config: %w", err)
+	}
+
+	if err := c.Freezes.Validate(); err != nil {
+		return fmt.Errorf("invalid freezes config: %w", err)
+	}
+
+	if err := c.Attachments.Validate(); err != nil {
+		return fmt.Errorf("invalid attachments config: %w", err)
+	}
+
+	if err := c.Postmortems.Validate(); err != nil {
+		return fmt.Errorf("invalid postmortems config: %w", err)
+	}
+
+	if err := c.Impact.Validate(); err != nil {
+		return fmt.Errorf("invalid impact config: %w", err)
+	}
+
+	if err := c.Chaos.Validate(); err != nil {
+		return fmt.Errorf("invalid chaos config: %w", err)
+	}
+
+	if err := c.Fixtures.Validate(); err != nil {
+		return fmt.Errorf("invalid fixtures config: %w", err)
+	}
+
+	if err := c.WorkflowInputs.Validate(); err != nil {
+		return fmt.Errorf("invalid workflow_inputs config: %w", err)
+	}
+
+	if err := c.LeaderElection.Validate(); err != nil {
+		return fmt.Errorf("invalid leader_election config: %w", err)
+	}
+
+	if c.Enrichment.Enabled {
+		if err := c.Enrichment.Validate(c.MCPServers); err != nil {
+			return fmt.Errorf("invalid enrichment config: %w", err)
+		}
+	}
+
+	if err := c.Summaries.Validate(); err != nil {
+		return fmt.Errorf("invalid summaries config: %w", err)
+	}
+
+	if err := c.Similarity.Validate(); err != nil {
+		return fmt.Errorf("invalid similarity config: %w", err)
+	}
+
+	if err := c.Severity.Validate(); err != nil {
+		return fmt.Errorf("invalid severity_classifier config: %w", err)
+	}
+
 	return nil
 }
 
@@ -228,6 +537,7 @@ func ValidateRule(rule *CustomRule) error {
 		rule.Conditions.ErrorPattern == nil &&
 		rule.Conditions.Severity == nil &&
 		rule.Conditions.Provider == nil &&
+		rule.Conditions.Tenant == nil &&
 		len(rule.Conditions.Metadata) == 0 {
 		return fmt.Errorf("rule '%s' must have at least one condition", rule.Name)
 	}
@@ -303,6 +303,16 @@ func TestConfigValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "negative reopen window",
+			config: Config{
+				Server:        ServerConfig{Port: 8080},
+				Database:      DatabaseConfig{Host: "localhost", Database: "test"},
+				GitHub:        GitHubConfig{Token: "token"},
+				Deduplication: DeduplicationConfig{ReopenWindow: -time.Hour},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
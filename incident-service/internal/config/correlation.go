@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// CorrelationConfig controls correlating alerts that different providers send
+// about the same outage. An alert from another provider for a service with a
+// recent incident is attached to that incident instead of starting a
+// remediation of its own.
+type CorrelationConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	Window  time.Duration `yaml:"window"` // how soon after an incident alerts are correlated with it, defaults to 2m
+}
+
+// DefaultCorrelationWindow is how soon after an incident alerts of other
+// providers are correlated with it when correlation.window is not set
+const DefaultCorrelationWindow = 2 * time.Minute
+
+// WindowSize returns how soon after an incident alerts are correlated with it
+func (c *CorrelationConfig) WindowSize() time.Duration {
+	if c.Window <= 0 {
+		return DefaultCorrelationWindow
+	}
+	return c.Window
+}
+
+// Validate checks that the correlation window is usable
+func (c *CorrelationConfig) Validate() error {
+	if c.Window < 0 {
+		return fmt.Errorf("window must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCorrelationConfig_WindowSize(t *testing.T) {
+	if got := (&CorrelationConfig{}).WindowSize(); got != DefaultCorrelationWindow {
+		t.Errorf("expected default window of %v, got %v", DefaultCorrelationWindow, got)
+	}
+	if got := (&CorrelationConfig{Window: 30 * time.Second}).WindowSize(); got != 30*time.Second {
+		t.Errorf("expected configured window of 30s, got %v", got)
+	}
+}
+
+func TestCorrelationConfig_Validate(t *testing.T) {
+	if err := (&CorrelationConfig{Enabled: true, Window: time.Minute}).Validate(); err != nil {
+		t.Errorf("expected valid config, got %v", err)
+	}
+	if err := (&CorrelationConfig{Window: -time.Minute}).Validate(); err == nil {
+		t.Error("expected error for negative window")
+	}
+}
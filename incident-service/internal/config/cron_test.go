@@ -0,0 +1,84 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{"every minute", "* * * * *", false},
+		{"weeknights", "0 22 * * 1-5", false},
+		{"steps and lists", "*/15 2,3 * * *", false},
+		{"names", "30 1 * jan-mar sun", false},
+		{"sunday as seven", "0 0 * * 7", false},
+		{"too few fields", "0 22 * *", true},
+		{"minute out of range", "60 * * * *", true},
+		{"reversed range", "0 5-1 * * *", true},
+		{"zero step", "*/0 * * * *", true},
+		{"unknown name", "0 0 * * funday", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCron(tt.expr); (err != nil) != tt.wantErr {
+				t.Errorf("ParseCron(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronSchedule_Matches(t *testing.T) {
+	// 2024-03-10 is a Sunday
+	sunday := time.Date(2024, 3, 10, 2, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		at   time.Time
+		want bool
+	}{
+		{"30 2 * * *", sunday, true},
+		{"*/15 * * * *", sunday, true},
+		{"*/20 * * * *", sunday, false},
+		{"30 2 * * sun", sunday, true},
+		{"30 2 * * 7", sunday, true},
+		{"30 2 * * 1-5", sunday, false},
+		{"30 2 * feb *", sunday, false},
+		// Either the day of month or the day of week matching is enough
+		{"30 2 1 * sun", sunday, true},
+		{"30 2 10 * mon", sunday, true},
+		{"30 2 1 * mon", sunday, false},
+	}
+
+	for _, tt := range tests {
+		schedule, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) error = %v", tt.expr, err)
+		}
+		if got := schedule.Matches(tt.at); got != tt.want {
+			t.Errorf("%q matches %v = %v, want %v", tt.expr, tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestCronSchedule_Latest(t *testing.T) {
+	schedule, err := ParseCron("0 2 * * sun")
+	if err != nil {
+		t.Fatalf("ParseCron() error = %v", err)
+	}
+
+	// Tuesday 2024-03-12, so the latest run was Sunday at 02:00
+	now := time.Date(2024, 3, 12, 9, 15, 0, 0, time.UTC)
+	got, ok := schedule.Latest(now.Add(-7*24*time.Hour), now)
+	if want := time.Date(2024, 3, 10, 2, 0, 0, 0, time.UTC); !ok || !got.Equal(want) {
+		t.Errorf("Latest() = %v, %v, want %v", got, ok, want)
+	}
+
+	if _, ok := schedule.Latest(now.Add(-24*time.Hour), now); ok {
+		t.Error("expected no run within the last day")
+	}
+}
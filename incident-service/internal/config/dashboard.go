@@ -0,0 +1,7 @@
+package config
+
+// DashboardConfig controls serving the dashboard from the incident service
+// binary, for installs without a separate frontend deployment
+type DashboardConfig struct {
+	Enabled bool `yaml:"enabled"` // serve the embedded dashboard under /
+}
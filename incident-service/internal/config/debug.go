@@ -0,0 +1,19 @@
+package config
+
+import (
+	"fmt"
+)
+
+// DebugConfig controls the pprof and runtime diagnostics endpoints
+type DebugConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Token   string `yaml:"token"` // required as a bearer token on every debug request
+}
+
+// Validate checks that debug endpoints are not exposed without authentication
+func (c *DebugConfig) Validate() error {
+	if c.Enabled && c.Token == "" {
+		return fmt.Errorf("token is required when debug endpoints are enabled")
+	}
+	return nil
+}
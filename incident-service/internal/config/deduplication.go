@@ -0,0 +1,55 @@
+package config
+
+import "fmt"
+
+// Deduplication strategies, deciding which incidents of a service are
+// repeats of each other
+const (
+	// DedupStrategyFingerprint matches errors whose messages and top stack
+	// frames are the same once IDs, timestamps and numbers are stripped. It
+	// is the default.
+	DedupStrategyFingerprint = "fingerprint"
+	// DedupStrategyExactMessage matches errors with exactly the same message
+	DedupStrategyExactMessage = "exact_message"
+	// DedupStrategyProviderKey matches alerts the provider itself groups
+	// together, such as a Datadog aggregation key or a Sentry issue
+	DedupStrategyProviderKey = "provider_key"
+	// DedupStrategyDisabled stores every incident and never reopens one
+	DedupStrategyDisabled = "disabled"
+)
+
+// StrategyFor returns the deduplication strategy of a service
+func (c *DeduplicationConfig) StrategyFor(service string) string {
+	if strategy, ok := c.Services[service]; ok {
+		return strategy
+	}
+	if c.Strategy == "" {
+		return DedupStrategyFingerprint
+	}
+	return c.Strategy
+}
+
+// Validate checks the deduplication windows and strategies
+func (c *DeduplicationConfig) Validate() error {
+	if c.TimeWindow < 0 || c.ReopenWindow < 0 {
+		return fmt.Errorf("deduplication windows must not be negative")
+	}
+	if c.Strategy != "" && !validDedupStrategy(c.Strategy) {
+		return fmt.Errorf("unknown strategy %q", c.Strategy)
+	}
+	for service, strategy := range c.Services {
+		if !validDedupStrategy(strategy) {
+			return fmt.Errorf("unknown strategy %q for service %s", strategy, service)
+		}
+	}
+	return nil
+}
+
+// validDedupStrategy reports whether strategy is a known deduplication strategy
+func validDedupStrategy(strategy string) bool {
+	switch strategy {
+	case DedupStrategyFingerprint, DedupStrategyExactMessage, DedupStrategyProviderKey, DedupStrategyDisabled:
+		return true
+	}
+	return false
+}
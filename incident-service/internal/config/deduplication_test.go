@@ -0,0 +1,39 @@
+package config
+
+import "testing"
+
+func TestDeduplicationConfig_StrategyFor(t *testing.T) {
+	cfg := DeduplicationConfig{
+		Strategy: DedupStrategyExactMessage,
+		Services: map[string]string{"checkout": DedupStrategyProviderKey},
+	}
+	if got := cfg.StrategyFor("checkout"); got != DedupStrategyProviderKey {
+		t.Errorf("StrategyFor(checkout) = %s, want %s", got, DedupStrategyProviderKey)
+	}
+	if got := cfg.StrategyFor("billing"); got != DedupStrategyExactMessage {
+		t.Errorf("StrategyFor(billing) = %s, want %s", got, DedupStrategyExactMessage)
+	}
+	if got := (&DeduplicationConfig{}).StrategyFor("billing"); got != DedupStrategyFingerprint {
+		t.Errorf("expected the fingerprint strategy by default, got %s", got)
+	}
+}
+
+func TestDeduplicationConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     DeduplicationConfig
+		wantErr bool
+	}{
+		{"defaults", DeduplicationConfig{}, false},
+		{"known strategies", DeduplicationConfig{Strategy: DedupStrategyDisabled, Services: map[string]string{"checkout": DedupStrategyFingerprint}}, false},
+		{"unknown strategy", DeduplicationConfig{Strategy: "message"}, true},
+		{"unknown service strategy", DeduplicationConfig{Services: map[string]string{"checkout": ""}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DigestConfig contains scheduled incident digest settings
+type DigestConfig struct {
+	Enabled       bool             `yaml:"enabled"`
+	CheckInterval time.Duration    `yaml:"check_interval"`
+	TopServices   int              `yaml:"top_services"` // number of failing services to list, defaults to 5
+	Schedules     []DigestSchedule `yaml:"schedules"`
+}
+
+// DigestSchedule defines when a digest is sent and where it is delivered.
+// Times are in UTC. Daily digests cover the previous 24 hours and weekly
+// digests cover the previous 7 days.
+type DigestSchedule struct {
+	Name            string   `yaml:"name"`
+	Frequency       string   `yaml:"frequency"` // daily or weekly
+	Weekday         string   `yaml:"weekday"`   // weekly only, e.g. monday
+	Time            string   `yaml:"time"`      // HH:MM, defaults to 09:00
+	SlackChannel    string   `yaml:"slack_channel"`
+	TeamsWebhookURL string   `yaml:"teams_webhook_url"`
+	EmailRecipients []string `yaml:"email_recipients"`
+}
+
+// Route returns the notification destinations for the digest
+func (s *DigestSchedule) Route() *NotificationRoute {
+	return &NotificationRoute{
+		SlackChannel:    s.SlackChannel,
+		TeamsWebhookURL: s.TeamsWebhookURL,
+		EmailRecipients: s.EmailRecipients,
+	}
+}
+
+// Period returns the length of time the digest covers
+func (s *DigestSchedule) Period() time.Duration {
+	if s.Frequency == "weekly" {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// LastRun returns the most recent scheduled send time at or before now
+func (s *DigestSchedule) LastRun(now time.Time) (time.Time, error) {
+	hour, minute, err := s.clock()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	now = now.UTC()
+	run := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, time.UTC)
+
+	if s.Frequency == "weekly" {
+		weekday, err := parseWeekday(s.Weekday)
+		if err != nil {
+			return time.Time{}, err
+		}
+		run = run.AddDate(0, 0, -((int(run.Weekday()) - int(weekday) + 7) % 7))
+	}
+
+	if run.After(now) {
+		run = run.Add(-s.Period())
+	}
+
+	return run, nil
+}
+
+// clock parses the HH:MM send time
+func (s *DigestSchedule) clock() (int, int, error) {
+	value := s.Time
+	if value == "" {
+		value = "09:00"
+	}
+
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time '%s': expected HH:MM", s.Time)
+	}
+
+	return t.Hour(), t.Minute(), nil
+}
+
+// parseWeekday converts a weekday name such as "monday" to a time.Weekday
+func parseWeekday(name string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday '%s'", name)
+}
+
+// Validate checks that the digest schedules are usable
+func (c *DigestConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if len(c.Schedules) == 0 {
+		return fmt.Errorf("at least one schedule is required when digests are enabled")
+	}
+
+	for i, schedule := range c.Schedules {
+		if schedule.Name == "" {
+			return fmt.Errorf("schedule at index %d: name is required", i)
+		}
+
+		switch schedule.Frequency {
+		case "daily":
+		case "weekly":
+			if _, err := parseWeekday(schedule.Weekday); err != nil {
+				return fmt.Errorf("schedule '%s': %w", schedule.Name, err)
+			}
+		default:
+			return fmt.Errorf("schedule '%s': frequency must be daily or weekly", schedule.Name)
+		}
+
+		if _, _, err := schedule.clock(); err != nil {
+			return fmt.Errorf("schedule '%s': %w", schedule.Name, err)
+		}
+
+		if schedule.SlackChannel == "" && schedule.TeamsWebhookURL == "" && len(schedule.EmailRecipients) == 0 {
+			return fmt.Errorf("schedule '%s': at least one destination is required", schedule.Name)
+		}
+	}
+
+	return nil
+}
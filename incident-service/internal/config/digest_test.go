@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDigestSchedule_LastRun(t *testing.T) {
+	// 2024-03-06 is a Wednesday
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule DigestSchedule
+		expected time.Time
+	}{
+		{"daily earlier today", DigestSchedule{Frequency: "daily", Time: "09:30"}, time.Date(2024, 3, 6, 9, 30, 0, 0, time.UTC)},
+		{"daily later today", DigestSchedule{Frequency: "daily", Time: "18:00"}, time.Date(2024, 3, 5, 18, 0, 0, 0, time.UTC)},
+		{"daily default time", DigestSchedule{Frequency: "daily"}, time.Date(2024, 3, 6, 9, 0, 0, 0, time.UTC)},
+		{"weekly earlier this week", DigestSchedule{Frequency: "weekly", Weekday: "Monday"}, time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)},
+		{"weekly later today", DigestSchedule{Frequency: "weekly", Weekday: "wednesday", Time: "13:00"}, time.Date(2024, 2, 28, 13, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run, err := tt.schedule.LastRun(now)
+			if err != nil {
+				t.Fatalf("LastRun() error = %v", err)
+			}
+			if !run.Equal(tt.expected) {
+				t.Errorf("LastRun() = %v, want %v", run, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDigestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  DigestConfig
+		wantErr bool
+	}{
+		{"disabled", DigestConfig{}, false},
+		{"no schedules", DigestConfig{Enabled: true}, true},
+		{"valid", DigestConfig{Enabled: true, Schedules: []DigestSchedule{{Name: "d", Frequency: "daily", SlackChannel: "#sre"}}}, false},
+		{"bad frequency", DigestConfig{Enabled: true, Schedules: []DigestSchedule{{Name: "d", Frequency: "hourly", SlackChannel: "#sre"}}}, true},
+		{"weekly without weekday", DigestConfig{Enabled: true, Schedules: []DigestSchedule{{Name: "w", Frequency: "weekly", SlackChannel: "#sre"}}}, true},
+		{"bad time", DigestConfig{Enabled: true, Schedules: []DigestSchedule{{Name: "d", Frequency: "daily", Time: "9am", SlackChannel: "#sre"}}}, true},
+		{"no destination", DigestConfig{Enabled: true, Schedules: []DigestSchedule{{Name: "d", Frequency: "daily"}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EncryptionConfig controls application-level encryption of the incident
+// columns that can hold sensitive context: provider data, stack traces and
+// diagnoses. Keys are 32 bytes, base64 encoded.
+type EncryptionConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Key is the key new values are encrypted with
+	Key string `yaml:"key"`
+	// KeyFile reads the key from a file instead, such as a secret that a KMS
+	// or secrets manager integration mounts into the container
+	KeyFile string `yaml:"key_file"`
+	// PreviousKeys still decrypt values written before a key rotation
+	PreviousKeys []string `yaml:"previous_keys"`
+}
+
+// Validate checks that a usable key is configured when encryption is enabled
+func (c *EncryptionConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Key != "" && c.KeyFile != "" {
+		return fmt.Errorf("key and key_file are mutually exclusive")
+	}
+	if c.Key == "" && c.KeyFile == "" {
+		return fmt.Errorf("key or key_file is required when encryption is enabled")
+	}
+	if c.Key != "" {
+		if _, err := decodeKey(c.Key); err != nil {
+			return fmt.Errorf("invalid key: %w", err)
+		}
+	}
+	for i, key := range c.PreviousKeys {
+		if _, err := decodeKey(key); err != nil {
+			return fmt.Errorf("invalid previous_keys[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Keys returns the current key and the previous keys, reading the key file
+// if one is configured
+func (c *EncryptionConfig) Keys() ([]byte, [][]byte, error) {
+	encoded := c.Key
+	if c.KeyFile != "" {
+		data, err := os.ReadFile(c.KeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read key file: %w", err)
+		}
+		encoded = strings.TrimSpace(string(data))
+	}
+	key, err := decodeKey(encoded)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid key: %w", err)
+	}
+
+	previous := make([][]byte, 0, len(c.PreviousKeys))
+	for i, encoded := range c.PreviousKeys {
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid previous_keys[%d]: %w", i, err)
+		}
+		previous = append(previous, key)
+	}
+	return key, previous, nil
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("key must be base64 encoded: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("key must be 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
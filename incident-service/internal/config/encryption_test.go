@@ -0,0 +1,57 @@
+package config
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncryptionConfig_Validate(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(make([]byte, 32))
+
+	tests := []struct {
+		name string
+		cfg  EncryptionConfig
+		err  string
+	}{
+		{"disabled", EncryptionConfig{}, ""},
+		{"key", EncryptionConfig{Enabled: true, Key: key}, ""},
+		{"key file", EncryptionConfig{Enabled: true, KeyFile: "/run/secrets/key"}, ""},
+		{"no key", EncryptionConfig{Enabled: true}, "key or key_file is required"},
+		{"both", EncryptionConfig{Enabled: true, Key: key, KeyFile: "/run/secrets/key"}, "mutually exclusive"},
+		{"short key", EncryptionConfig{Enabled: true, Key: "c2hvcnQ="}, "must be 32 bytes"},
+		{"bad previous key", EncryptionConfig{Enabled: true, Key: key, PreviousKeys: []string{"not base64!"}}, "previous_keys[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestEncryptionConfig_KeyFile(t *testing.T) {
+	key := make([]byte, 32)
+	key[0] = 7
+	path := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600); err != nil {
+		t.Fatalf("failed to write key file: %v", err)
+	}
+
+	cfg := EncryptionConfig{Enabled: true, KeyFile: path}
+	got, previous, err := cfg.Keys()
+	if err != nil {
+		t.Fatalf("Keys() error = %v", err)
+	}
+	if got[0] != 7 || len(previous) != 0 {
+		t.Errorf("unexpected keys %v, %v", got, previous)
+	}
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"text/template"
+	"time"
+)
+
+// MCP server transports
+const (
+	MCPTypeHTTP = "http"
+)
+
+// URL returns the endpoint of an http MCP server
+func (c *MCPServerConfig) URL() string {
+	return c.Config["url"]
+}
+
+// Token returns the bearer token sent to an http MCP server, if any
+func (c *MCPServerConfig) Token() string {
+	return c.Config["token"]
+}
+
+// EnrichmentConfig controls the context gathered from MCP servers before an
+// incident's workflow is dispatched
+type EnrichmentConfig struct {
+	Enabled  bool              `yaml:"enabled"`
+	Timeout  time.Duration     `yaml:"timeout"`   // bounds all queries of one incident, defaults to 10s
+	Lookback time.Duration     `yaml:"lookback"`  // how far before the incident queries look, defaults to 1h
+	MaxBytes int               `yaml:"max_bytes"` // size of the context bundle, defaults to 8000
+	Queries  []EnrichmentQuery `yaml:"queries"`
+}
+
+// EnrichmentQuery is an MCP tool call whose result is added to the context.
+// Argument values are templates over the incident: {{.IncidentID}},
+// {{.ServiceName}}, {{.Repository}}, {{.Severity}}, and {{.Since}} and
+// {{.Until}} in RFC3339.
+type EnrichmentQuery struct {
+	Name      string            `yaml:"name"`   // names the result in the bundle, such as logs or deploys
+	Server    string            `yaml:"server"` // name of an mcp_servers entry
+	Tool      string            `yaml:"tool"`
+	Arguments map[string]string `yaml:"arguments"`
+}
+
+// QueryTimeout returns how long the queries of one incident may take
+func (c *EnrichmentConfig) QueryTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 10 * time.Second
+	}
+	return c.Timeout
+}
+
+// LookbackWindow returns how far before the incident queries look
+func (c *EnrichmentConfig) LookbackWindow() time.Duration {
+	if c.Lookback <= 0 {
+		return time.Hour
+	}
+	return c.Lookback
+}
+
+// BundleLimit returns the size of the context bundle in bytes
+func (c *EnrichmentConfig) BundleLimit() int {
+	if c.MaxBytes <= 0 {
+		return 8000
+	}
+	return c.MaxBytes
+}
+
+// Validate checks that every query names a usable MCP server and tool, and
+// that its arguments are valid templates
+func (c *EnrichmentConfig) Validate(servers []MCPServerConfig) error {
+	if c.Timeout < 0 || c.Lookback < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+	if c.MaxBytes < 0 {
+		return fmt.Errorf("max_bytes must not be negative")
+	}
+	// GitHub limits the total size of a workflow's inputs to 65535 characters
+	if c.MaxBytes > 60000 {
+		return fmt.Errorf("max_bytes must be at most 60000")
+	}
+
+	byName := make(map[string]MCPServerConfig, len(servers))
+	for _, server := range servers {
+		byName[server.Name] = server
+	}
+
+	names := make(map[string]bool)
+	for i, query := range c.Queries {
+		if query.Name == "" {
+			return fmt.Errorf("queries[%d]: name is required", i)
+		}
+		if names[query.Name] {
+			return fmt.Errorf("queries[%d]: duplicate name %q", i, query.Name)
+		}
+		names[query.Name] = true
+
+		server, ok := byName[query.Server]
+		if !ok {
+			return fmt.Errorf("queries[%d]: unknown mcp server %q", i, query.Server)
+		}
+		if server.Type != MCPTypeHTTP || server.URL() == "" {
+			return fmt.Errorf("queries[%d]: mcp server %q must be of type %s with a url", i, query.Server, MCPTypeHTTP)
+		}
+		if query.Tool == "" {
+			return fmt.Errorf("queries[%d]: tool is required", i)
+		}
+		for name, value := range query.Arguments {
+			if _, err := template.New(name).Parse(value); err != nil {
+				return fmt.Errorf("queries[%d]: invalid argument %s: %w", i, name, err)
+			}
+		}
+	}
+	return nil
+}
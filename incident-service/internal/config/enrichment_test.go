@@ -0,0 +1,39 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEnrichmentConfig_Validate(t *testing.T) {
+	servers := []MCPServerConfig{
+		{Name: "datadog", Type: MCPTypeHTTP, Config: map[string]string{"url": "https://mcp.example.com/mcp"}},
+		{Name: "local", Type: "stdio"},
+	}
+	logs := EnrichmentQuery{Name: "logs", Server: "datadog", Tool: "search_logs", Arguments: map[string]string{"query": "service:{{.ServiceName}}"}}
+
+	tests := []struct {
+		name    string
+		config  EnrichmentConfig
+		wantErr bool
+	}{
+		{"no queries", EnrichmentConfig{}, false},
+		{"valid", EnrichmentConfig{Timeout: 5 * time.Second, Queries: []EnrichmentQuery{logs}}, false},
+		{"negative timeout", EnrichmentConfig{Timeout: -time.Second}, true},
+		{"bundle too large", EnrichmentConfig{MaxBytes: 100000}, true},
+		{"missing name", EnrichmentConfig{Queries: []EnrichmentQuery{{Server: "datadog", Tool: "search_logs"}}}, true},
+		{"duplicate name", EnrichmentConfig{Queries: []EnrichmentQuery{logs, logs}}, true},
+		{"unknown server", EnrichmentConfig{Queries: []EnrichmentQuery{{Name: "logs", Server: "splunk", Tool: "search"}}}, true},
+		{"server without http", EnrichmentConfig{Queries: []EnrichmentQuery{{Name: "logs", Server: "local", Tool: "search"}}}, true},
+		{"missing tool", EnrichmentConfig{Queries: []EnrichmentQuery{{Name: "logs", Server: "datadog"}}}, true},
+		{"invalid template", EnrichmentConfig{Queries: []EnrichmentQuery{{Name: "logs", Server: "datadog", Tool: "search", Arguments: map[string]string{"query": "{{.ServiceName"}}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(servers); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
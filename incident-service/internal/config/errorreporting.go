@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+)
+
+// ErrorReportingConfig contains settings for reporting the service's own
+// errors and panics to Sentry or a Sentry-compatible backend
+type ErrorReportingConfig struct {
+	Enabled     bool     `yaml:"enabled"`
+	DSN         string   `yaml:"dsn"`
+	Environment string   `yaml:"environment"`
+	Release     string   `yaml:"release"`
+	SampleRate  *float64 `yaml:"sample_rate"` // fraction of error events to send, defaults to 1
+}
+
+// Validate checks that the error reporting settings are usable
+func (c *ErrorReportingConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.DSN == "" {
+		return fmt.Errorf("dsn is required when error reporting is enabled")
+	}
+
+	if c.SampleRate != nil && (*c.SampleRate < 0 || *c.SampleRate > 1) {
+		return fmt.Errorf("sample_rate must be between 0 and 1")
+	}
+
+	return nil
+}
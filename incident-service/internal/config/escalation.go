@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// EscalationConfig contains the escalation policy for stuck or repeatedly failing incidents
+type EscalationConfig struct {
+	Enabled       bool              `yaml:"enabled"`
+	CheckInterval time.Duration     `yaml:"check_interval"`
+	Levels        []EscalationLevel `yaml:"levels"`
+}
+
+// EscalationLevel defines when an incident escalates to a level and who is notified.
+// A level is reached once the incident has been stuck in workflow_triggered or
+// in_progress for After, or has failed AfterFailures times, whichever comes first.
+type EscalationLevel struct {
+	Name                string        `yaml:"name"`
+	After               time.Duration `yaml:"after"`
+	AfterFailures       int           `yaml:"after_failures"`
+	SlackChannel        string        `yaml:"slack_channel"`
+	TeamsWebhookURL     string        `yaml:"teams_webhook_url"`
+	EmailRecipients     []string      `yaml:"email_recipients"`
+	PagerDutyRoutingKey string        `yaml:"pagerduty_routing_key"`
+}
+
+// Route returns the notification destinations for the level
+func (l *EscalationLevel) Route() *NotificationRoute {
+	return &NotificationRoute{
+		SlackChannel:        l.SlackChannel,
+		TeamsWebhookURL:     l.TeamsWebhookURL,
+		EmailRecipients:     l.EmailRecipients,
+		PagerDutyRoutingKey: l.PagerDutyRoutingKey,
+	}
+}
+
+// Validate checks that the escalation policy is usable
+func (c *EscalationConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if len(c.Levels) == 0 {
+		return fmt.Errorf("at least one level is required when escalation is enabled")
+	}
+
+	for i, level := range c.Levels {
+		if level.Name == "" {
+			return fmt.Errorf("level at index %d: name is required", i)
+		}
+		if level.After <= 0 && level.AfterFailures <= 0 {
+			return fmt.Errorf("level '%s': after or after_failures is required", level.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Event bus drivers
+const (
+	EventBusNATS  = "nats"
+	EventBusKafka = "kafka"
+)
+
+// DefaultEventBusEvents are the lifecycle events published when no events
+// are configured
+var DefaultEventBusEvents = []string{
+	"incident_received",
+	"workflow_triggered",
+	"pr_created",
+	"incident_resolved",
+	"incident_failed",
+}
+
+// EventBusConfig contains settings for publishing incident lifecycle events
+// to a message bus, for other platform services to consume
+type EventBusConfig struct {
+	Enabled bool        `yaml:"enabled"`
+	Driver  string      `yaml:"driver"` // nats or kafka
+	Events  []string    `yaml:"events"` // defaults to DefaultEventBusEvents
+	NATS    NATSConfig  `yaml:"nats"`
+	Kafka   KafkaConfig `yaml:"kafka"`
+}
+
+// NATSConfig contains NATS connection settings
+type NATSConfig struct {
+	URL             string `yaml:"url"`     // nats://host:4222, or tls://host:4222
+	Subject         string `yaml:"subject"` // prefix of the event subjects, defaults to "incidents"
+	Token           string `yaml:"token"`
+	Username        string `yaml:"username"`
+	Password        string `yaml:"password"`
+	CredentialsFile string `yaml:"credentials_file"` // .creds file with a user JWT and NKey seed
+}
+
+// SubjectPrefix returns the prefix of the event subjects
+func (c *NATSConfig) SubjectPrefix() string {
+	if c.Subject == "" {
+		return "incidents"
+	}
+	return c.Subject
+}
+
+// KafkaConfig contains settings for producing to Kafka through a Kafka REST
+// Proxy (v2 API)
+type KafkaConfig struct {
+	RESTProxyURL string            `yaml:"rest_proxy_url"`
+	Topic        string            `yaml:"topic"`
+	Username     string            `yaml:"username"`
+	Password     string            `yaml:"password"`
+	Headers      map[string]string `yaml:"headers"`
+}
+
+// EventTypes returns the event types to publish
+func (c *EventBusConfig) EventTypes() []string {
+	if len(c.Events) == 0 {
+		return DefaultEventBusEvents
+	}
+	return c.Events
+}
+
+// Validate checks the event bus settings of the configured driver
+func (c *EventBusConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	switch c.Driver {
+	case EventBusNATS:
+		u, err := url.Parse(c.NATS.URL)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("nats.url must be a nats:// or tls:// URL")
+		}
+		if u.Scheme != "nats" && u.Scheme != "tls" {
+			return fmt.Errorf("nats.url must be a nats:// or tls:// URL")
+		}
+	case EventBusKafka:
+		if c.Kafka.RESTProxyURL == "" {
+			return fmt.Errorf("kafka.rest_proxy_url is required")
+		}
+		if c.Kafka.Topic == "" {
+			return fmt.Errorf("kafka.topic is required")
+		}
+	default:
+		return fmt.Errorf("driver must be %s or %s", EventBusNATS, EventBusKafka)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEventBusConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  EventBusConfig
+		err  string
+	}{
+		{"disabled", EventBusConfig{Driver: "rabbitmq"}, ""},
+		{"nats", EventBusConfig{Enabled: true, Driver: EventBusNATS, NATS: NATSConfig{URL: "nats://nats:4222"}}, ""},
+		{"nats tls", EventBusConfig{Enabled: true, Driver: EventBusNATS, NATS: NATSConfig{URL: "tls://nats:4222"}}, ""},
+		{"nats http url", EventBusConfig{Enabled: true, Driver: EventBusNATS, NATS: NATSConfig{URL: "http://nats:4222"}}, "nats.url"},
+		{"nats missing url", EventBusConfig{Enabled: true, Driver: EventBusNATS}, "nats.url"},
+		{"kafka", EventBusConfig{Enabled: true, Driver: EventBusKafka, Kafka: KafkaConfig{RESTProxyURL: "http://kafka-rest:8082", Topic: "incidents"}}, ""},
+		{"kafka missing topic", EventBusConfig{Enabled: true, Driver: EventBusKafka, Kafka: KafkaConfig{RESTProxyURL: "http://kafka-rest:8082"}}, "kafka.topic"},
+		{"kafka missing proxy", EventBusConfig{Enabled: true, Driver: EventBusKafka, Kafka: KafkaConfig{Topic: "incidents"}}, "kafka.rest_proxy_url"},
+		{"unknown driver", EventBusConfig{Enabled: true, Driver: "rabbitmq"}, "driver"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestEventBusConfig_EventTypes(t *testing.T) {
+	cfg := EventBusConfig{}
+	if got := cfg.EventTypes(); len(got) != len(DefaultEventBusEvents) {
+		t.Errorf("EventTypes() = %v, want the defaults", got)
+	}
+
+	cfg.Events = []string{"pr_created"}
+	if got := cfg.EventTypes(); len(got) != 1 || got[0] != "pr_created" {
+		t.Errorf("EventTypes() = %v, want [pr_created]", got)
+	}
+}
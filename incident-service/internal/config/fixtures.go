@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FixturesConfig controls capturing provider webhook payloads, from which
+// anonymized fixtures for the adapters' contract tests can be dumped
+type FixturesConfig struct {
+	Capture bool     `yaml:"capture"`
+	Shapes  int      `yaml:"shapes"` // payload shapes kept per provider, defaults to 20
+	Keep    []string `yaml:"keep"`   // fields whose values are kept when anonymizing, in addition to states, priorities and the like
+}
+
+// ShapeLimit returns how many payload shapes are kept per provider
+func (c *FixturesConfig) ShapeLimit() int {
+	if c.Shapes <= 0 {
+		return 20
+	}
+	return c.Shapes
+}
+
+// Validate checks that the shape limit and kept fields are usable
+func (c *FixturesConfig) Validate() error {
+	if c.Shapes < 0 {
+		return fmt.Errorf("shapes must not be negative")
+	}
+	for _, field := range c.Keep {
+		if strings.TrimSpace(field) == "" {
+			return fmt.Errorf("keep must not contain empty field names")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package config
+
+import "testing"
+
+func TestFixturesConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     FixturesConfig
+		wantErr bool
+	}{
+		{"defaults", FixturesConfig{}, false},
+		{"capturing", FixturesConfig{Capture: true, Shapes: 5, Keep: []string{"env"}}, false},
+		{"negative shapes", FixturesConfig{Shapes: -1}, true},
+		{"empty kept field", FixturesConfig{Keep: []string{"env", " "}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if limit := (&FixturesConfig{}).ShapeLimit(); limit != 20 {
+		t.Errorf("ShapeLimit() = %d, want 20", limit)
+	}
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// FreezeConfig controls deploy freeze calendars. Unlike maintenance windows,
+// a freeze does not hold remediation back: the workflow still runs and opens
+// its pull request, but it is dispatched with freeze=true so that it
+// withholds auto-merge and rollout.
+type FreezeConfig struct {
+	Enabled       bool             `yaml:"enabled"`
+	FetchInterval time.Duration    `yaml:"fetch_interval"` // how often iCal calendars are fetched, defaults to 15m
+	Calendars     []FreezeCalendar `yaml:"calendars"`
+}
+
+// FreezeCalendar is the freeze windows of one repository, listed as dates,
+// read from an iCal feed, or both
+type FreezeCalendar struct {
+	Repository string         `yaml:"repository"`
+	URL        string         `yaml:"url"` // iCal feed whose events are freeze windows, optional
+	Windows    []FreezeWindow `yaml:"windows"`
+}
+
+// FreezeWindow is a freeze between start and end
+type FreezeWindow struct {
+	Name  string    `yaml:"name"`
+	Start time.Time `yaml:"start"` // RFC 3339
+	End   time.Time `yaml:"end"`
+}
+
+// RefreshInterval returns how often iCal calendars are fetched
+func (c *FreezeConfig) RefreshInterval() time.Duration {
+	if c.FetchInterval <= 0 {
+		return 15 * time.Minute
+	}
+	return c.FetchInterval
+}
+
+// Validate checks that every calendar is usable and that each repository has
+// one calendar at most
+func (c *FreezeConfig) Validate() error {
+	if c.FetchInterval < 0 {
+		return fmt.Errorf("fetch_interval must not be negative")
+	}
+
+	repositories := make(map[string]bool, len(c.Calendars))
+	for i := range c.Calendars {
+		calendar := &c.Calendars[i]
+		if err := calendar.Validate(); err != nil {
+			return fmt.Errorf("invalid calendar at index %d: %w", i, err)
+		}
+		if repositories[calendar.Repository] {
+			return fmt.Errorf("duplicate calendar for repository %q", calendar.Repository)
+		}
+		repositories[calendar.Repository] = true
+	}
+
+	return nil
+}
+
+// Validate checks that the calendar names a repository and has windows or
+// an http(s) iCal URL
+func (c *FreezeCalendar) Validate() error {
+	if c.Repository == "" {
+		return fmt.Errorf("repository is required")
+	}
+	if c.URL == "" && len(c.Windows) == 0 {
+		return fmt.Errorf("url or windows are required")
+	}
+	if c.URL != "" {
+		parsed, err := url.Parse(c.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("url must be an http or https URL")
+		}
+	}
+
+	for i, window := range c.Windows {
+		if window.Start.IsZero() || window.End.IsZero() {
+			return fmt.Errorf("window at index %d: start and end are required", i)
+		}
+		if !window.End.After(window.Start) {
+			return fmt.Errorf("window at index %d: end must be after start", i)
+		}
+	}
+
+	return nil
+}
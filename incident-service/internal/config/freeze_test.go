@@ -0,0 +1,45 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFreezeConfig_Validate(t *testing.T) {
+	start := time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC)
+	window := FreezeWindow{Name: "holidays", Start: start, End: start.Add(14 * 24 * time.Hour)}
+
+	tests := []struct {
+		name    string
+		cfg     FreezeConfig
+		wantErr string
+	}{
+		{"windows", FreezeConfig{Calendars: []FreezeCalendar{{Repository: "org/api", Windows: []FreezeWindow{window}}}}, ""},
+		{"ical", FreezeConfig{Calendars: []FreezeCalendar{{Repository: "org/api", URL: "https://calendar.example.com/freeze.ics"}}}, ""},
+		{"no repository", FreezeConfig{Calendars: []FreezeCalendar{{Windows: []FreezeWindow{window}}}}, "repository is required"},
+		{"empty calendar", FreezeConfig{Calendars: []FreezeCalendar{{Repository: "org/api"}}}, "url or windows are required"},
+		{"webcal url", FreezeConfig{Calendars: []FreezeCalendar{{Repository: "org/api", URL: "webcal://calendar.example.com/freeze.ics"}}}, "http or https"},
+		{"reversed window", FreezeConfig{Calendars: []FreezeCalendar{{Repository: "org/api", Windows: []FreezeWindow{{Start: window.End, End: window.Start}}}}}, "end must be after start"},
+		{"duplicate repository", FreezeConfig{Calendars: []FreezeCalendar{
+			{Repository: "org/api", Windows: []FreezeWindow{window}},
+			{Repository: "org/api", URL: "https://calendar.example.com/freeze.ics"},
+		}}, "duplicate calendar"},
+		{"negative interval", FreezeConfig{FetchInterval: -time.Minute}, "fetch_interval"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// GroupingConfig controls grouping of related incidents. An incident whose
+// fingerprint matches a recent incident from another service is grouped under
+// that incident as its parent.
+type GroupingConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	Window  time.Duration `yaml:"window"` // how far back a parent is searched for, defaults to 15m
+}
+
+// WindowSize returns how far back a parent incident is searched for
+func (c *GroupingConfig) WindowSize() time.Duration {
+	if c.Window <= 0 {
+		return 15 * time.Minute
+	}
+	return c.Window
+}
+
+// Validate checks that the grouping window is usable
+func (c *GroupingConfig) Validate() error {
+	if c.Window < 0 {
+		return fmt.Errorf("window must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGroupingConfig_WindowSize(t *testing.T) {
+	if got := (&GroupingConfig{}).WindowSize(); got != 15*time.Minute {
+		t.Errorf("expected default window of 15m, got %v", got)
+	}
+	if got := (&GroupingConfig{Window: time.Hour}).WindowSize(); got != time.Hour {
+		t.Errorf("expected configured window of 1h, got %v", got)
+	}
+}
+
+func TestGroupingConfig_Validate(t *testing.T) {
+	if err := (&GroupingConfig{Enabled: true, Window: time.Minute}).Validate(); err != nil {
+		t.Errorf("expected valid config, got %v", err)
+	}
+	if err := (&GroupingConfig{Window: -time.Minute}).Validate(); err == nil {
+		t.Error("expected error for negative window")
+	}
+}
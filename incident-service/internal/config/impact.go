@@ -0,0 +1,43 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ImpactConfig controls extracting the impact of incidents from the webhook
+// payloads of their providers. Impact can also be set through the API.
+type ImpactConfig struct {
+	// Providers maps a provider, such as datadog, to where its payloads
+	// carry impact figures. Providers that are not listed carry none.
+	Providers map[string]ImpactFieldsConfig `yaml:"providers"`
+}
+
+// ImpactFieldsConfig locates impact figures in a provider's webhook payloads,
+// as dotted paths into the payload's JSON
+type ImpactFieldsConfig struct {
+	AffectedUsers   string `yaml:"affected_users"`   // e.g. "impact.affected_users"
+	ErrorRate       string `yaml:"error_rate"`       // from 0 to 1, or a percentage above 1
+	DowntimeMinutes string `yaml:"downtime_minutes"` // e.g. "labels.downtime_minutes"
+}
+
+// Validate checks that every path is well formed
+func (c *ImpactConfig) Validate() error {
+	for provider, fields := range c.Providers {
+		for name, path := range map[string]string{
+			"affected_users":   fields.AffectedUsers,
+			"error_rate":       fields.ErrorRate,
+			"downtime_minutes": fields.DowntimeMinutes,
+		} {
+			if path == "" {
+				continue
+			}
+			for _, key := range strings.Split(path, ".") {
+				if strings.TrimSpace(key) == "" {
+					return fmt.Errorf("providers.%s.%s: invalid path %q", provider, name, path)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package config
+
+import "testing"
+
+func TestImpactConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  ImpactFieldsConfig
+		wantErr bool
+	}{
+		{"empty", ImpactFieldsConfig{}, false},
+		{"nested paths", ImpactFieldsConfig{AffectedUsers: "attributes.users_affected", ErrorRate: "error_rate"}, false},
+		{"empty segment", ImpactFieldsConfig{ErrorRate: "attributes..error_rate"}, true},
+		{"trailing dot", ImpactFieldsConfig{DowntimeMinutes: "custom_details."}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := ImpactConfig{Providers: map[string]ImpactFieldsConfig{"datadog": tt.fields}}
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package config
+
+import "fmt"
+
+// Incident ID strategies
+const (
+	IncidentIDLegacy = "legacy" // provider-derived, such as inc_dd_<alert ID>
+	IncidentIDULID   = "ulid"
+	IncidentIDUUIDv7 = "uuidv7"
+)
+
+// IncidentIDsConfig selects how the IDs of new incidents are generated.
+// Existing incidents keep their IDs whatever the strategy.
+type IncidentIDsConfig struct {
+	Strategy string `yaml:"strategy"` // legacy, ulid or uuidv7, defaults to legacy
+}
+
+// StrategyName returns the configured strategy, legacy when unset
+func (c *IncidentIDsConfig) StrategyName() string {
+	if c.Strategy == "" {
+		return IncidentIDLegacy
+	}
+	return c.Strategy
+}
+
+// Validate checks that the strategy is known
+func (c *IncidentIDsConfig) Validate() error {
+	switch c.StrategyName() {
+	case IncidentIDLegacy, IncidentIDULID, IncidentIDUUIDv7:
+		return nil
+	}
+	return fmt.Errorf("strategy must be %s, %s or %s", IncidentIDLegacy, IncidentIDULID, IncidentIDUUIDv7)
+}
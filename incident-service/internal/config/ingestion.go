@@ -0,0 +1,143 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Ingestion buffer backends
+const (
+	BufferBackendRedis = "redis"
+	BufferBackendDisk  = "disk"
+)
+
+// IngestionConfig controls the worker pool that processes accepted webhooks
+// outside the request path
+type IngestionConfig struct {
+	Workers    int                   `yaml:"workers"`     // defaults to 4
+	QueueSize  int                   `yaml:"queue_size"`  // webhooks waiting for a worker, defaults to 1000
+	RetryAfter time.Duration         `yaml:"retry_after"` // sent to providers turned away by a full queue, defaults to 30s
+	Buffer     IngestionBufferConfig `yaml:"buffer"`
+	Quotas     map[string]int        `yaml:"quotas"` // incidents per provider per hour that are remediated automatically, unlisted providers are unlimited
+}
+
+// IngestionBufferConfig controls where accepted webhooks are kept while the
+// database is unavailable, until they can be stored
+type IngestionBufferConfig struct {
+	Enabled       bool          `yaml:"enabled"`
+	Backend       string        `yaml:"backend"`        // redis or disk, defaults to redis
+	Key           string        `yaml:"key"`            // Redis list holding the webhooks, defaults to incident-service:ingestion-buffer
+	Path          string        `yaml:"path"`           // spool directory of the disk backend
+	MaxSize       int           `yaml:"max_size"`       // webhooks kept before new ones are dropped, defaults to 10000
+	DrainInterval time.Duration `yaml:"drain_interval"` // how often the database is checked to drain the buffer, defaults to 10s
+}
+
+// WorkerCount returns the number of ingestion workers
+func (c *IngestionConfig) WorkerCount() int {
+	if c.Workers <= 0 {
+		return 4
+	}
+	return c.Workers
+}
+
+// Capacity returns how many webhooks can wait for a worker before new ones are rejected
+func (c *IngestionConfig) Capacity() int {
+	if c.QueueSize <= 0 {
+		return 1000
+	}
+	return c.QueueSize
+}
+
+// RetryDelaySeconds returns the Retry-After of webhooks turned away by a full
+// queue, in whole seconds
+func (c *IngestionConfig) RetryDelaySeconds() int {
+	if c.RetryAfter <= 0 {
+		return 30
+	}
+	return int(math.Ceil(c.RetryAfter.Seconds()))
+}
+
+// HourlyQuota returns how many incidents of provider are remediated
+// automatically per hour, or 0 when the provider has no quota
+func (c *IngestionConfig) HourlyQuota(provider string) int {
+	return c.Quotas[provider]
+}
+
+// BufferBackend returns where buffered webhooks are kept
+func (c *IngestionBufferConfig) BufferBackend() string {
+	if c.Backend == "" {
+		return BufferBackendRedis
+	}
+	return c.Backend
+}
+
+// BufferKey returns the Redis list holding buffered webhooks
+func (c *IngestionBufferConfig) BufferKey() string {
+	if c.Key == "" {
+		return "incident-service:ingestion-buffer"
+	}
+	return c.Key
+}
+
+// Limit returns how many webhooks can be buffered
+func (c *IngestionBufferConfig) Limit() int {
+	if c.MaxSize <= 0 {
+		return 10000
+	}
+	return c.MaxSize
+}
+
+// Interval returns how often the buffer is drained
+func (c *IngestionBufferConfig) Interval() time.Duration {
+	if c.DrainInterval <= 0 {
+		return 10 * time.Second
+	}
+	return c.DrainInterval
+}
+
+// Validate checks that the ingestion pool settings are usable
+func (c *IngestionConfig) Validate() error {
+	if c.Workers < 0 {
+		return fmt.Errorf("workers must not be negative")
+	}
+	if c.QueueSize < 0 {
+		return fmt.Errorf("queue_size must not be negative")
+	}
+	if c.RetryAfter < 0 {
+		return fmt.Errorf("retry_after must not be negative")
+	}
+	for provider, quota := range c.Quotas {
+		if quota < 0 {
+			return fmt.Errorf("quotas.%s must not be negative", provider)
+		}
+	}
+	if err := c.Buffer.Validate(); err != nil {
+		return fmt.Errorf("buffer: %w", err)
+	}
+	return nil
+}
+
+// Validate checks the buffer backend settings
+func (c *IngestionBufferConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.MaxSize < 0 {
+		return fmt.Errorf("max_size must not be negative")
+	}
+	if c.DrainInterval < 0 {
+		return fmt.Errorf("drain_interval must not be negative")
+	}
+
+	switch c.BufferBackend() {
+	case BufferBackendRedis:
+	case BufferBackendDisk:
+		if c.Path == "" {
+			return fmt.Errorf("path is required for the disk backend")
+		}
+	default:
+		return fmt.Errorf("backend must be %s or %s", BufferBackendRedis, BufferBackendDisk)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIngestionConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  IngestionConfig
+		wantErr bool
+	}{
+		{"defaults", IngestionConfig{}, false},
+		{"quotas", IngestionConfig{Quotas: map[string]int{"datadog": 500, "sentry": 0}}, false},
+		{"negative workers", IngestionConfig{Workers: -1}, true},
+		{"negative retry after", IngestionConfig{RetryAfter: -time.Second}, true},
+		{"negative quota", IngestionConfig{Quotas: map[string]int{"datadog": -1}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIngestionConfig_HourlyQuota(t *testing.T) {
+	cfg := IngestionConfig{Quotas: map[string]int{"datadog": 500}}
+	if quota := cfg.HourlyQuota("datadog"); quota != 500 {
+		t.Errorf("expected a quota of 500 for datadog, got %d", quota)
+	}
+	if quota := cfg.HourlyQuota("sentry"); quota != 0 {
+		t.Errorf("expected unlisted providers to be unlimited, got %d", quota)
+	}
+}
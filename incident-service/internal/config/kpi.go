@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// KPIConfig controls the background job that refreshes business KPI gauges
+type KPIConfig struct {
+	Enabled         bool          `yaml:"enabled"`
+	RefreshInterval time.Duration `yaml:"refresh_interval"` // defaults to 1m
+	Window          time.Duration `yaml:"window"`           // rolling window for success rate and MTTR, defaults to 24h
+}
+
+// RollingWindow returns the window used for success rate and MTTR
+func (c *KPIConfig) RollingWindow() time.Duration {
+	if c.Window <= 0 {
+		return 24 * time.Hour
+	}
+	return c.Window
+}
+
+// Validate checks that the KPI settings are usable
+func (c *KPIConfig) Validate() error {
+	if c.RefreshInterval < 0 {
+		return fmt.Errorf("refresh_interval must not be negative")
+	}
+	if c.Window < 0 {
+		return fmt.Errorf("window must not be negative")
+	}
+	return nil
+}
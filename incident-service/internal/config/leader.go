@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// LeaderElectionConfig controls the Redis lease that lets only one replica
+// run the singleton background workers
+type LeaderElectionConfig struct {
+	Enabled       bool          `yaml:"enabled"`
+	Key           string        `yaml:"key"`            // Redis key holding the lease, defaults to incident-service:leader
+	LeaseDuration time.Duration `yaml:"lease_duration"` // how long a lease lasts without renewal, defaults to 15s
+	RenewInterval time.Duration `yaml:"renew_interval"` // how often the lease is renewed or campaigned for, defaults to 5s
+}
+
+// LeaseKey returns the Redis key holding the lease
+func (c *LeaderElectionConfig) LeaseKey() string {
+	if c.Key == "" {
+		return "incident-service:leader"
+	}
+	return c.Key
+}
+
+// LeaseTTL returns how long a lease lasts without renewal
+func (c *LeaderElectionConfig) LeaseTTL() time.Duration {
+	if c.LeaseDuration <= 0 {
+		return 15 * time.Second
+	}
+	return c.LeaseDuration
+}
+
+// Renewal returns how often the lease is renewed or campaigned for
+func (c *LeaderElectionConfig) Renewal() time.Duration {
+	if c.RenewInterval <= 0 {
+		return 5 * time.Second
+	}
+	return c.RenewInterval
+}
+
+// Validate checks that the lease can be renewed before it expires
+func (c *LeaderElectionConfig) Validate() error {
+	if c.LeaseDuration < 0 || c.RenewInterval < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+
+	if c.Renewal() >= c.LeaseTTL() {
+		return fmt.Errorf("renew_interval must be shorter than lease_duration")
+	}
+
+	return nil
+}
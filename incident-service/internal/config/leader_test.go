@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLeaderElectionConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  LeaderElectionConfig
+		wantErr bool
+	}{
+		{"defaults", LeaderElectionConfig{}, false},
+		{"valid", LeaderElectionConfig{Enabled: true, LeaseDuration: 30 * time.Second, RenewInterval: 10 * time.Second}, false},
+		{"negative duration", LeaderElectionConfig{LeaseDuration: -time.Second}, true},
+		{"renewal not before expiry", LeaderElectionConfig{LeaseDuration: 10 * time.Second, RenewInterval: 10 * time.Second}, true},
+		{"renewal default above lease", LeaderElectionConfig{LeaseDuration: 3 * time.Second}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
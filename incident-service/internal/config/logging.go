@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LoggingConfig contains log output settings shared by all components
+type LoggingConfig struct {
+	Level      string   `yaml:"level"`       // debug, info, warn, or error; defaults to info
+	Format     string   `yaml:"format"`      // json or console; defaults to json
+	Output     string   `yaml:"output"`      // stdout, stderr, or a file path; defaults to stdout
+	RedactKeys []string `yaml:"redact_keys"` // field names masked in addition to the built-in sensitive ones
+}
+
+// Validate checks that the logging settings are supported
+func (c *LoggingConfig) Validate() error {
+	switch strings.ToLower(c.Level) {
+	case "", "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("level must be one of debug, info, warn, error, got '%s'", c.Level)
+	}
+
+	switch strings.ToLower(c.Format) {
+	case "", "json", "console":
+	default:
+		return fmt.Errorf("format must be json or console, got '%s'", c.Format)
+	}
+
+	return nil
+}
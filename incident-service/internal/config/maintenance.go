@@ -0,0 +1,148 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxMaintenanceDuration bounds recurring windows, so finding the occurrence
+// that is open never looks back further than a week
+const maxMaintenanceDuration = 7 * 24 * time.Hour
+
+// MaintenanceConfig controls maintenance windows. Incidents are still
+// recorded during a window, but their remediation is deferred until it closes.
+type MaintenanceConfig struct {
+	Enabled       bool                `yaml:"enabled"`
+	CheckInterval time.Duration       `yaml:"check_interval"` // how often deferred remediations are checked for dispatch, defaults to 1m
+	Windows       []MaintenanceWindow `yaml:"windows"`
+}
+
+// MaintenanceWindow is a recurring window opened by a cron schedule, or a
+// one-off window between start and end
+type MaintenanceWindow struct {
+	Name     string        `yaml:"name"`
+	Services []string      `yaml:"services"` // services the window applies to, all services if empty
+	Schedule string        `yaml:"schedule"` // cron expression for when a recurring window opens
+	Duration time.Duration `yaml:"duration"` // how long a recurring window stays open
+	Timezone string        `yaml:"timezone"` // IANA time zone the schedule is read in, defaults to UTC
+	Start    time.Time     `yaml:"start"`    // one-off window, RFC 3339
+	End      time.Time     `yaml:"end"`
+}
+
+// ActiveWindow returns the window open for the service at now that closes
+// last, and when it closes, or nil if no window is open
+func (c *MaintenanceConfig) ActiveWindow(serviceName string, now time.Time) (*MaintenanceWindow, time.Time) {
+	var active *MaintenanceWindow
+	var until time.Time
+	for i := range c.Windows {
+		window := &c.Windows[i]
+		if !window.AppliesTo(serviceName) {
+			continue
+		}
+		if end, open := window.OpenUntil(now); open && end.After(until) {
+			active, until = window, end
+		}
+	}
+	return active, until
+}
+
+// Validate checks that every window is usable
+func (c *MaintenanceConfig) Validate() error {
+	if c.CheckInterval < 0 {
+		return fmt.Errorf("check_interval must not be negative")
+	}
+
+	names := make(map[string]bool, len(c.Windows))
+	for i := range c.Windows {
+		window := &c.Windows[i]
+		if err := window.Validate(); err != nil {
+			return fmt.Errorf("invalid window at index %d: %w", i, err)
+		}
+		if names[window.Name] {
+			return fmt.Errorf("duplicate window name %q", window.Name)
+		}
+		names[window.Name] = true
+	}
+
+	return nil
+}
+
+// AppliesTo reports whether the window covers the service
+func (w *MaintenanceWindow) AppliesTo(serviceName string) bool {
+	if len(w.Services) == 0 {
+		return true
+	}
+	for _, service := range w.Services {
+		if service == serviceName {
+			return true
+		}
+	}
+	return false
+}
+
+// OpenUntil reports whether the window is open at now, and when it closes
+func (w *MaintenanceWindow) OpenUntil(now time.Time) (time.Time, bool) {
+	if w.Schedule == "" {
+		if now.Before(w.Start) || !now.Before(w.End) {
+			return time.Time{}, false
+		}
+		return w.End, true
+	}
+
+	schedule, err := ParseCron(w.Schedule)
+	if err != nil {
+		return time.Time{}, false
+	}
+	location, err := w.location()
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	local := now.In(location)
+	opened, ok := schedule.Latest(local.Add(-w.Duration), local)
+	if !ok {
+		return time.Time{}, false
+	}
+	return opened.Add(w.Duration), true
+}
+
+// location returns the time zone the schedule is read in
+func (w *MaintenanceWindow) location() (*time.Location, error) {
+	if w.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(w.Timezone)
+}
+
+// Validate checks that the window is either recurring or one-off, and that
+// its schedule and times are usable
+func (w *MaintenanceWindow) Validate() error {
+	if w.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if w.Schedule == "" {
+		if w.Start.IsZero() || w.End.IsZero() {
+			return fmt.Errorf("schedule and duration, or start and end, are required")
+		}
+		if !w.End.After(w.Start) {
+			return fmt.Errorf("end must be after start")
+		}
+		return nil
+	}
+
+	if !w.Start.IsZero() || !w.End.IsZero() {
+		return fmt.Errorf("start and end cannot be combined with schedule")
+	}
+	if _, err := ParseCron(w.Schedule); err != nil {
+		return fmt.Errorf("invalid schedule: %w", err)
+	}
+	if w.Duration <= 0 || w.Duration > maxMaintenanceDuration {
+		return fmt.Errorf("duration must be positive and at most %s", maxMaintenanceDuration)
+	}
+	if _, err := w.location(); err != nil {
+		return fmt.Errorf("invalid timezone: %w", err)
+	}
+
+	return nil
+}
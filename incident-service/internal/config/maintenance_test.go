@@ -0,0 +1,128 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMaintenanceWindow_OpenUntil(t *testing.T) {
+	weekly := MaintenanceWindow{Name: "weekly", Schedule: "0 2 * * sun", Duration: 2 * time.Hour, Timezone: "Europe/Berlin"}
+	oneOff := MaintenanceWindow{
+		Name:  "migration",
+		Start: time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 6, 2, 4, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name      string
+		window    MaintenanceWindow
+		now       time.Time
+		wantOpen  bool
+		wantUntil time.Time
+	}{
+		// 02:00 in Berlin on 2024-03-10 is 01:00 UTC
+		{"recurring open", weekly, time.Date(2024, 3, 10, 2, 30, 0, 0, time.UTC), true, time.Date(2024, 3, 10, 3, 0, 0, 0, time.UTC)},
+		{"recurring before opening", weekly, time.Date(2024, 3, 10, 0, 59, 0, 0, time.UTC), false, time.Time{}},
+		{"recurring after closing", weekly, time.Date(2024, 3, 10, 3, 0, 0, 0, time.UTC), false, time.Time{}},
+		{"one-off open", oneOff, time.Date(2024, 6, 1, 23, 0, 0, 0, time.UTC), true, oneOff.End},
+		{"one-off closed", oneOff, oneOff.End, false, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			until, open := tt.window.OpenUntil(tt.now)
+			if open != tt.wantOpen || !until.Equal(tt.wantUntil) {
+				t.Errorf("OpenUntil() = %v, %v, want %v, %v", until, open, tt.wantUntil, tt.wantOpen)
+			}
+		})
+	}
+}
+
+func TestMaintenanceConfig_ActiveWindow(t *testing.T) {
+	now := time.Date(2024, 3, 10, 2, 30, 0, 0, time.UTC)
+	cfg := MaintenanceConfig{
+		Windows: []MaintenanceWindow{
+			{Name: "global", Schedule: "0 2 * * *", Duration: time.Hour},
+			{Name: "payments", Services: []string{"payments-api"}, Schedule: "0 2 * * *", Duration: 3 * time.Hour},
+		},
+	}
+
+	window, until := cfg.ActiveWindow("payments-api", now)
+	if window == nil || window.Name != "payments" || !until.Equal(time.Date(2024, 3, 10, 5, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the payments window to close last, got %v until %v", window, until)
+	}
+
+	window, _ = cfg.ActiveWindow("checkout", now)
+	if window == nil || window.Name != "global" {
+		t.Errorf("expected the global window for other services, got %v", window)
+	}
+
+	if window, _ := cfg.ActiveWindow("checkout", now.Add(2*time.Hour)); window != nil {
+		t.Errorf("expected no open window, got %s", window.Name)
+	}
+}
+
+func TestMaintenanceConfig_Validate(t *testing.T) {
+	start := time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		window  MaintenanceWindow
+		wantErr bool
+	}{
+		{"recurring", MaintenanceWindow{Name: "w", Schedule: "0 2 * * sun", Duration: time.Hour}, false},
+		{"one-off", MaintenanceWindow{Name: "w", Start: start, End: start.Add(time.Hour)}, false},
+		{"missing name", MaintenanceWindow{Schedule: "0 2 * * sun", Duration: time.Hour}, true},
+		{"neither schedule nor times", MaintenanceWindow{Name: "w"}, true},
+		{"end before start", MaintenanceWindow{Name: "w", Start: start, End: start.Add(-time.Hour)}, true},
+		{"schedule and times", MaintenanceWindow{Name: "w", Schedule: "0 2 * * sun", Duration: time.Hour, Start: start, End: start.Add(time.Hour)}, true},
+		{"invalid schedule", MaintenanceWindow{Name: "w", Schedule: "0 25 * * *", Duration: time.Hour}, true},
+		{"missing duration", MaintenanceWindow{Name: "w", Schedule: "0 2 * * sun"}, true},
+		{"duration over a week", MaintenanceWindow{Name: "w", Schedule: "0 2 * * sun", Duration: 8 * 24 * time.Hour}, true},
+		{"unknown timezone", MaintenanceWindow{Name: "w", Schedule: "0 2 * * sun", Duration: time.Hour, Timezone: "Mars/Olympus"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := MaintenanceConfig{Windows: []MaintenanceWindow{tt.window}}
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	duplicate := MaintenanceConfig{Windows: []MaintenanceWindow{
+		{Name: "w", Schedule: "0 2 * * sun", Duration: time.Hour},
+		{Name: "w", Start: start, End: start.Add(time.Hour)},
+	}}
+	if err := duplicate.Validate(); err == nil {
+		t.Error("expected error for duplicate window names")
+	}
+}
+
+func TestMaintenanceConfig_YAML(t *testing.T) {
+	data := []byte(`
+enabled: true
+windows:
+  - name: weekly
+    services: [payments-api]
+    schedule: "0 2 * * sun"
+    duration: 2h
+  - name: migration
+    start: 2024-06-01T20:00:00Z
+    end: 2024-06-02T04:00:00Z
+`)
+
+	var cfg MaintenanceConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("failed to parse maintenance config: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(cfg.Windows) != 2 || cfg.Windows[0].Duration != 2*time.Hour || !cfg.Windows[1].End.Equal(time.Date(2024, 6, 2, 4, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected windows: %+v", cfg.Windows)
+	}
+}
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// NotificationsConfig contains outbound notification settings
+type NotificationsConfig struct {
+	DashboardURL   string              `yaml:"dashboard_url"`
+	Slack          SlackConfig         `yaml:"slack"`
+	Teams          TeamsConfig         `yaml:"teams"`
+	Email          EmailConfig         `yaml:"email"`
+	Webhooks       []WebhookConfig     `yaml:"webhooks"`
+	EventBus       EventBusConfig      `yaml:"event_bus"`
+	PagerDuty      PagerDutySyncConfig `yaml:"pagerduty"`
+	Routes         []NotificationRoute `yaml:"routes"`
+	Retry          RetryConfig         `yaml:"retry"`
+	Templates      map[string]string   `yaml:"templates"`       // plain text, keyed by event type
+	SlackTemplates map[string]string   `yaml:"slack_templates"` // Block Kit JSON, keyed by event type or "default"
+	TeamsTemplates map[string]string   `yaml:"teams_templates"` // MessageCard JSON, keyed by event type or "default"
+}
+
+// SlackConfig contains Slack notification settings
+type SlackConfig struct {
+	Enabled        bool   `yaml:"enabled"`
+	APIURL         string `yaml:"api_url"`
+	Token          string `yaml:"token"`
+	DefaultChannel string `yaml:"default_channel"`
+}
+
+// TeamsConfig contains Microsoft Teams notification settings
+type TeamsConfig struct {
+	Enabled           bool   `yaml:"enabled"`
+	DefaultWebhookURL string `yaml:"default_webhook_url"`
+}
+
+// EmailConfig contains SMTP email notification settings
+type EmailConfig struct {
+	Enabled            bool                `yaml:"enabled"`
+	SMTPHost           string              `yaml:"smtp_host"`
+	SMTPPort           int                 `yaml:"smtp_port"`
+	Username           string              `yaml:"username"`
+	Password           string              `yaml:"password"`
+	From               string              `yaml:"from"`
+	DefaultRecipients  []string            `yaml:"default_recipients"`
+	SeverityRecipients map[string][]string `yaml:"severity_recipients"`
+}
+
+// SMTPAddr returns the SMTP server address
+func (c *EmailConfig) SMTPAddr() string {
+	port := c.SMTPPort
+	if port == 0 {
+		port = 587
+	}
+	return fmt.Sprintf("%s:%d", c.SMTPHost, port)
+}
+
+// WebhookConfig contains settings for an outbound webhook subscriber
+type WebhookConfig struct {
+	Name    string            `yaml:"name"`
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+	Secret  string            `yaml:"secret"`
+	Events  []string          `yaml:"events"` // empty means all events
+}
+
+// PagerDutySyncConfig contains settings for mirroring incidents into PagerDuty
+type PagerDutySyncConfig struct {
+	Enabled      bool   `yaml:"enabled"`
+	EventsAPIURL string `yaml:"events_api_url"`
+	RoutingKey   string `yaml:"routing_key"`
+}
+
+// RetryConfig controls how failed notification deliveries are retried
+type RetryConfig struct {
+	MaxAttempts    int           `yaml:"max_attempts"`    // total attempts including the first, defaults to 5
+	InitialBackoff time.Duration `yaml:"initial_backoff"` // defaults to 30s, doubled after each failure
+	MaxBackoff     time.Duration `yaml:"max_backoff"`     // defaults to 30m
+	CheckInterval  time.Duration `yaml:"check_interval"`  // defaults to 30s
+}
+
+// Backoff returns the delay before the next attempt after the given number of failed attempts
+func (c *RetryConfig) Backoff(attempts int) time.Duration {
+	backoff := c.InitialBackoff
+	if backoff <= 0 {
+		backoff = 30 * time.Second
+	}
+	maxBackoff := c.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 30 * time.Minute
+	}
+
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+
+	return backoff
+}
+
+// Attempts returns the maximum number of delivery attempts
+func (c *RetryConfig) Attempts() int {
+	if c.MaxAttempts <= 0 {
+		return 5
+	}
+	return c.MaxAttempts
+}
+
+// NotificationRoute routes notifications for a service to specific destinations
+type NotificationRoute struct {
+	ServiceName         string   `yaml:"service_name"`
+	SlackChannel        string   `yaml:"slack_channel"`
+	TeamsWebhookURL     string   `yaml:"teams_webhook_url"`
+	EmailRecipients     []string `yaml:"email_recipients"`
+	PagerDutyRoutingKey string   `yaml:"pagerduty_routing_key"`
+}
+
+// Validate checks that notification settings are consistent
+func (c *NotificationsConfig) Validate() error {
+	if c.Slack.Enabled {
+		if c.Slack.Token == "" {
+			return fmt.Errorf("slack.token is required when slack is enabled")
+		}
+		if c.Slack.DefaultChannel == "" && len(c.Routes) == 0 {
+			return fmt.Errorf("slack.default_channel or at least one route is required when slack is enabled")
+		}
+	}
+
+	if c.Teams.Enabled && c.Teams.DefaultWebhookURL == "" {
+		hasRoute := false
+		for _, route := range c.Routes {
+			if route.TeamsWebhookURL != "" {
+				hasRoute = true
+				break
+			}
+		}
+		if !hasRoute {
+			return fmt.Errorf("teams.default_webhook_url or a route with teams_webhook_url is required when teams is enabled")
+		}
+	}
+
+	if c.Email.Enabled {
+		if c.Email.SMTPHost == "" {
+			return fmt.Errorf("email.smtp_host is required when email is enabled")
+		}
+		if c.Email.From == "" {
+			return fmt.Errorf("email.from is required when email is enabled")
+		}
+	}
+
+	if c.PagerDuty.Enabled && c.PagerDuty.RoutingKey == "" {
+		hasRoute := false
+		for _, route := range c.Routes {
+			if route.PagerDutyRoutingKey != "" {
+				hasRoute = true
+				break
+			}
+		}
+		if !hasRoute {
+			return fmt.Errorf("pagerduty.routing_key or a route with pagerduty_routing_key is required when pagerduty sync is enabled")
+		}
+	}
+
+	for i, webhook := range c.Webhooks {
+		if webhook.Name == "" {
+			return fmt.Errorf("webhook at index %d: name is required", i)
+		}
+		if webhook.URL == "" {
+			return fmt.Errorf("webhook '%s': url is required", webhook.Name)
+		}
+	}
+
+	if err := c.EventBus.Validate(); err != nil {
+		return fmt.Errorf("invalid event_bus config: %w", err)
+	}
+
+	for i, route := range c.Routes {
+		if route.ServiceName == "" {
+			return fmt.Errorf("route at index %d: service_name is required", i)
+		}
+	}
+
+	// Templates are rendered with text/template, so reject syntax errors at load time
+	if err := validateTemplates("text", c.Templates); err != nil {
+		return err
+	}
+	if err := validateTemplates("slack", c.SlackTemplates); err != nil {
+		return err
+	}
+	if err := validateTemplates("teams", c.TeamsTemplates); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RouteFor returns the notification route for a service, if one is configured
+func (c *NotificationsConfig) RouteFor(serviceName string) *NotificationRoute {
+	for i := range c.Routes {
+		if c.Routes[i].ServiceName == serviceName {
+			return &c.Routes[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// PostmortemConfig controls where postmortem drafts can be published. Drafts
+// are always returned by the API; publishing them is optional.
+type PostmortemConfig struct {
+	Repository PostmortemRepositoryConfig `yaml:"repository"`
+	Confluence ConfluenceConfig           `yaml:"confluence"`
+}
+
+// PostmortemRepositoryConfig commits drafts as Markdown files to a GitHub
+// repository, with the service's GitHub token
+type PostmortemRepositoryConfig struct {
+	Name   string `yaml:"name"`   // owner/repo, publishing to a repository is disabled when empty
+	Branch string `yaml:"branch"` // defaults to the repository's default branch
+	Path   string `yaml:"path"`   // directory of the drafts, defaults to postmortems
+}
+
+// ConfluenceConfig creates drafts as Confluence pages
+type ConfluenceConfig struct {
+	URL      string `yaml:"url"` // e.g. https://your-org.atlassian.net/wiki, publishing to Confluence is disabled when empty
+	SpaceKey string `yaml:"space_key"`
+	ParentID string `yaml:"parent_id"` // page the drafts are created under, optional
+	Username string `yaml:"username"`
+	APIToken string `yaml:"api_token"`
+}
+
+// Directory returns the repository directory drafts are committed to
+func (c *PostmortemRepositoryConfig) Directory() string {
+	if c.Path == "" {
+		return "postmortems"
+	}
+	return strings.Trim(c.Path, "/")
+}
+
+// Validate checks the settings of the configured publishing targets
+func (c *PostmortemConfig) Validate() error {
+	if name := c.Repository.Name; name != "" {
+		parts := strings.Split(name, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("repository.name must be owner/repo")
+		}
+		for _, segment := range strings.Split(c.Repository.Directory(), "/") {
+			if segment == "" || segment == "." || segment == ".." {
+				return fmt.Errorf("repository.path must be a relative directory")
+			}
+		}
+	}
+
+	if c.Confluence.URL != "" {
+		parsed, err := url.Parse(c.Confluence.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("confluence.url must be an http or https URL")
+		}
+		if c.Confluence.SpaceKey == "" {
+			return fmt.Errorf("confluence.space_key is required")
+		}
+		if c.Confluence.Username == "" || c.Confluence.APIToken == "" {
+			return fmt.Errorf("confluence.username and confluence.api_token are required")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPostmortemConfig_Validate(t *testing.T) {
+	confluence := ConfluenceConfig{URL: "https://example.atlassian.net/wiki", SpaceKey: "SRE", Username: "bot@example.com", APIToken: "token"}
+
+	tests := []struct {
+		name    string
+		cfg     PostmortemConfig
+		wantErr string
+	}{
+		{"disabled", PostmortemConfig{}, ""},
+		{"repository", PostmortemConfig{Repository: PostmortemRepositoryConfig{Name: "org/postmortems", Path: "/docs/incidents/"}}, ""},
+		{"confluence", PostmortemConfig{Confluence: confluence}, ""},
+		{"bad repository", PostmortemConfig{Repository: PostmortemRepositoryConfig{Name: "postmortems"}}, "owner/repo"},
+		{"escaping path", PostmortemConfig{Repository: PostmortemRepositoryConfig{Name: "org/postmortems", Path: "../secrets"}}, "relative directory"},
+		{"bad confluence url", PostmortemConfig{Confluence: ConfluenceConfig{URL: "example.atlassian.net", SpaceKey: "SRE"}}, "http or https"},
+		{"no space", PostmortemConfig{Confluence: ConfluenceConfig{URL: confluence.URL, Username: "bot", APIToken: "token"}}, "space_key"},
+		{"no credentials", PostmortemConfig{Confluence: ConfluenceConfig{URL: confluence.URL, SpaceKey: "SRE"}}, "api_token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPostmortemRepositoryConfig_Directory(t *testing.T) {
+	for path, want := range map[string]string{"": "postmortems", "/docs/incidents/": "docs/incidents"} {
+		cfg := PostmortemRepositoryConfig{Path: path}
+		if got := cfg.Directory(); got != want {
+			t.Errorf("Directory(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
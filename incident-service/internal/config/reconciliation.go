@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReconciliationConfig controls the worker that repairs drift between
+// incidents and their GitHub workflow runs and pull requests
+type ReconciliationConfig struct {
+	Enabled       bool          `yaml:"enabled"`
+	CheckInterval time.Duration `yaml:"check_interval"` // defaults to 5m
+	MinAge        time.Duration `yaml:"min_age"`        // time since dispatch before an incident is checked, defaults to 15m
+}
+
+// GracePeriod returns how long a dispatched incident is left to its callback before it is reconciled
+func (c *ReconciliationConfig) GracePeriod() time.Duration {
+	if c.MinAge <= 0 {
+		return 15 * time.Minute
+	}
+	return c.MinAge
+}
+
+// Validate checks that the reconciliation settings are usable
+func (c *ReconciliationConfig) Validate() error {
+	if c.CheckInterval < 0 {
+		return fmt.Errorf("check_interval must not be negative")
+	}
+	if c.MinAge < 0 {
+		return fmt.Errorf("min_age must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package config
+
+import "fmt"
+
+// RemediationCapsConfig caps how many automated remediation attempts run
+// for a service per day, so that a noisy service cannot flood its
+// repository with pull requests. Incidents over the cap are recorded as
+// needing manual attention instead.
+type RemediationCapsConfig struct {
+	Daily int `yaml:"daily"` // attempts per service per UTC day, 0 is unlimited
+	// Services overrides the cap per service name, 0 lifting it
+	Services map[string]int `yaml:"services"`
+}
+
+// DailyCap returns how many automated remediation attempts may run for the
+// service per day, or 0 when they are unlimited
+func (c *RemediationCapsConfig) DailyCap(service string) int {
+	if limit, ok := c.Services[service]; ok {
+		return limit
+	}
+	return c.Daily
+}
+
+// Validate checks that the remediation caps are usable
+func (c *RemediationCapsConfig) Validate() error {
+	if c.Daily < 0 {
+		return fmt.Errorf("daily must not be negative")
+	}
+	for service, limit := range c.Services {
+		if limit < 0 {
+			return fmt.Errorf("services.%s must not be negative", service)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package config
+
+import "testing"
+
+func TestRemediationCapsConfig_DailyCap(t *testing.T) {
+	cfg := RemediationCapsConfig{
+		Daily:    10,
+		Services: map[string]int{"checkout": 3, "billing": 0},
+	}
+	tests := map[string]int{
+		"checkout": 3,
+		"billing":  0,
+		"search":   10,
+	}
+	for service, want := range tests {
+		if got := cfg.DailyCap(service); got != want {
+			t.Errorf("DailyCap(%s) = %d, want %d", service, got, want)
+		}
+	}
+}
+
+func TestRemediationCapsConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RemediationCapsConfig
+		wantErr bool
+	}{
+		{"defaults", RemediationCapsConfig{}, false},
+		{"caps", RemediationCapsConfig{Daily: 5, Services: map[string]int{"checkout": 0}}, false},
+		{"negative cap", RemediationCapsConfig{Daily: -1}, true},
+		{"negative service cap", RemediationCapsConfig{Services: map[string]int{"checkout": -1}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// RemediationRetryConfig controls automatic re-dispatch of failed incidents
+type RemediationRetryConfig struct {
+	Enabled        bool          `yaml:"enabled"`
+	MaxRetries     int           `yaml:"max_retries"`     // retries after the first attempt, defaults to 3
+	InitialBackoff time.Duration `yaml:"initial_backoff"` // defaults to 5m, doubled after each retry
+	MaxBackoff     time.Duration `yaml:"max_backoff"`     // defaults to 1h
+	MaxAge         time.Duration `yaml:"max_age"`         // failures older than this are not retried, defaults to 24h
+	CheckInterval  time.Duration `yaml:"check_interval"`  // defaults to 1m
+}
+
+// Backoff returns the delay between a failure and the next retry, given the retries already made
+func (c *RemediationRetryConfig) Backoff(retries int) time.Duration {
+	backoff := c.InitialBackoff
+	if backoff <= 0 {
+		backoff = 5 * time.Minute
+	}
+	maxBackoff := c.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = time.Hour
+	}
+
+	for i := 0; i < retries && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+
+	return backoff
+}
+
+// Retries returns the maximum number of retries per incident
+func (c *RemediationRetryConfig) Retries() int {
+	if c.MaxRetries <= 0 {
+		return 3
+	}
+	return c.MaxRetries
+}
+
+// RetryWindow returns how long after a failure an incident is still eligible for a retry
+func (c *RemediationRetryConfig) RetryWindow() time.Duration {
+	if c.MaxAge <= 0 {
+		return 24 * time.Hour
+	}
+	return c.MaxAge
+}
+
+// Validate checks that the retry policy is usable
+func (c *RemediationRetryConfig) Validate() error {
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("max_retries must not be negative")
+	}
+	if c.InitialBackoff < 0 || c.MaxBackoff < 0 || c.MaxAge < 0 || c.CheckInterval < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+	if c.InitialBackoff > 0 && c.MaxBackoff > 0 && c.InitialBackoff > c.MaxBackoff {
+		return fmt.Errorf("initial_backoff must not exceed max_backoff")
+	}
+	return nil
+}
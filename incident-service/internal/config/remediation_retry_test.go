@@ -0,0 +1,51 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRemediationRetryConfig_Backoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   RemediationRetryConfig
+		retries  int
+		expected time.Duration
+	}{
+		{"first retry", RemediationRetryConfig{InitialBackoff: time.Minute, MaxBackoff: time.Hour}, 0, time.Minute},
+		{"doubles", RemediationRetryConfig{InitialBackoff: time.Minute, MaxBackoff: time.Hour}, 3, 8 * time.Minute},
+		{"capped", RemediationRetryConfig{InitialBackoff: time.Minute, MaxBackoff: 10 * time.Minute}, 5, 10 * time.Minute},
+		{"defaults", RemediationRetryConfig{}, 1, 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.Backoff(tt.retries); got != tt.expected {
+				t.Errorf("Backoff(%d) = %v, want %v", tt.retries, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRemediationRetryConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  RemediationRetryConfig
+		wantErr bool
+	}{
+		{"defaults", RemediationRetryConfig{}, false},
+		{"valid", RemediationRetryConfig{Enabled: true, MaxRetries: 5, InitialBackoff: time.Minute, MaxBackoff: time.Hour}, false},
+		{"negative retries", RemediationRetryConfig{MaxRetries: -1}, true},
+		{"negative duration", RemediationRetryConfig{MaxAge: -time.Hour}, true},
+		{"initial above max", RemediationRetryConfig{InitialBackoff: 2 * time.Hour, MaxBackoff: time.Hour}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
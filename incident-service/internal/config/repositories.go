@@ -0,0 +1,43 @@
+package config
+
+import "fmt"
+
+// RepositoryTarget is a repository remediated for a service, and the branch
+// the remediation workflow runs on
+type RepositoryTarget struct {
+	Repository string `yaml:"repository" json:"repository"`
+	Branch     string `yaml:"branch" json:"branch"`
+}
+
+// Targets returns the repositories remediated for the service: the mapped
+// repository first, followed by the additional ones. Additional repositories
+// without a branch use the mapping's branch.
+func (m *ServiceMapping) Targets() []RepositoryTarget {
+	targets := make([]RepositoryTarget, 0, 1+len(m.Repositories))
+	targets = append(targets, RepositoryTarget{Repository: m.Repository, Branch: m.Branch})
+	for _, target := range m.Repositories {
+		if target.Branch == "" {
+			target.Branch = m.Branch
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// validateRepositories checks that the additional repositories of each service
+// mapping are named and listed once
+func (c *Config) validateRepositories() error {
+	for _, mapping := range c.ServiceMappings {
+		seen := map[string]bool{mapping.Repository: true}
+		for i, target := range mapping.Repositories {
+			if target.Repository == "" {
+				return fmt.Errorf("service mapping for %q: repository at index %d: repository is required", mapping.ServiceName, i)
+			}
+			if seen[target.Repository] {
+				return fmt.Errorf("service mapping for %q: repository %q is listed more than once", mapping.ServiceName, target.Repository)
+			}
+			seen[target.Repository] = true
+		}
+	}
+	return nil
+}
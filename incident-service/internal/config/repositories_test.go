@@ -0,0 +1,63 @@
+package config
+
+import "testing"
+
+func TestServiceMapping_Targets(t *testing.T) {
+	mapping := ServiceMapping{
+		ServiceName: "checkout",
+		Repository:  "org/checkout-api",
+		Branch:      "main",
+		Repositories: []RepositoryTarget{
+			{Repository: "org/checkout-infra"},
+			{Repository: "org/checkout-web", Branch: "develop"},
+		},
+	}
+
+	targets := mapping.Targets()
+	expected := []RepositoryTarget{
+		{Repository: "org/checkout-api", Branch: "main"},
+		{Repository: "org/checkout-infra", Branch: "main"},
+		{Repository: "org/checkout-web", Branch: "develop"},
+	}
+	if len(targets) != len(expected) {
+		t.Fatalf("Targets() = %+v, want %+v", targets, expected)
+	}
+	for i := range expected {
+		if targets[i] != expected[i] {
+			t.Errorf("target %d = %+v, want %+v", i, targets[i], expected[i])
+		}
+	}
+
+	// The mapping itself keeps its unset branches
+	if mapping.Repositories[0].Branch != "" {
+		t.Errorf("expected Targets() to leave the mapping untouched, got %+v", mapping.Repositories[0])
+	}
+}
+
+func TestConfig_ValidateRepositories(t *testing.T) {
+	tests := []struct {
+		name         string
+		repositories []RepositoryTarget
+		wantErr      bool
+	}{
+		{"none", nil, false},
+		{"additional repository", []RepositoryTarget{{Repository: "org/checkout-infra"}}, false},
+		{"missing repository", []RepositoryTarget{{Branch: "main"}}, true},
+		{"repeats the mapped repository", []RepositoryTarget{{Repository: "org/checkout-api"}}, true},
+		{"listed twice", []RepositoryTarget{{Repository: "org/checkout-infra"}, {Repository: "org/checkout-infra"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{ServiceMappings: []ServiceMapping{{
+				ServiceName:  "checkout",
+				Repository:   "org/checkout-api",
+				Branch:       "main",
+				Repositories: tt.repositories,
+			}}}
+			if err := cfg.validateRepositories(); (err != nil) != tt.wantErr {
+				t.Errorf("validateRepositories() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// RollupConfig controls the worker that pre-aggregates incident statistics
+// into hourly and daily summary buckets
+type RollupConfig struct {
+	Enabled         bool          `yaml:"enabled"`
+	RefreshInterval time.Duration `yaml:"refresh_interval"` // defaults to 5m
+	HourlyLookback  time.Duration `yaml:"hourly_lookback"`  // hourly buckets recomputed on each refresh, defaults to 48h
+	DailyLookback   time.Duration `yaml:"daily_lookback"`   // daily buckets recomputed on each refresh, defaults to 7 days
+}
+
+// HourlyWindow returns how far back hourly buckets are recomputed
+func (c *RollupConfig) HourlyWindow() time.Duration {
+	if c.HourlyLookback <= 0 {
+		return 48 * time.Hour
+	}
+	return c.HourlyLookback
+}
+
+// DailyWindow returns how far back daily buckets are recomputed
+func (c *RollupConfig) DailyWindow() time.Duration {
+	if c.DailyLookback <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return c.DailyLookback
+}
+
+// Validate checks that the rollup settings are usable
+func (c *RollupConfig) Validate() error {
+	if c.RefreshInterval < 0 || c.HourlyLookback < 0 || c.DailyLookback < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+	return nil
+}
@@ -1,7 +1,12 @@
 package config
 
 import (
+	"context"
 	"regexp"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // RuleEngine evaluates custom rules against incidents
@@ -30,6 +35,7 @@ type IncidentData struct {
 	ErrorMessage string
 	Severity     string
 	Provider     string
+	Tenant       string
 	Metadata     map[string]string
 }
 
@@ -55,6 +61,22 @@ func (e *RuleEngine) Evaluate(incident *IncidentData) []RuleMatch {
 	return matches
 }
 
+// EvaluateContext evaluates all rules like Evaluate, recording the evaluation as a span
+func (e *RuleEngine) EvaluateContext(ctx context.Context, incident *IncidentData) []RuleMatch {
+	_, span := otel.Tracer("github.com/your-org/ai-sre-platform/incident-service/internal/config").Start(ctx, "rules.Evaluate")
+	defer span.End()
+
+	matches := e.Evaluate(incident)
+
+	span.SetAttributes(
+		attribute.String("service.name", incident.ServiceName),
+		attribute.Int("rules.evaluated", len(e.rules)),
+		attribute.Int("rules.matched", len(matches)),
+	)
+
+	return matches
+}
+
 // matchesRule checks if an incident matches a rule's conditions
 func (e *RuleEngine) matchesRule(incident *IncidentData, rule *CustomRule) bool {
 	conditions := &rule.Conditions
@@ -88,6 +110,13 @@ func (e *RuleEngine) matchesRule(incident *IncidentData, rule *CustomRule) bool
 		}
 	}
 
+	// Check tenant
+	if conditions.Tenant != nil {
+		if incident.Tenant != *conditions.Tenant {
+			return false
+		}
+	}
+
 	// Check metadata
 	for key, value := range conditions.Metadata {
 		incidentValue, exists := incident.Metadata[key]
@@ -138,3 +167,35 @@ func GetRepositoryOverride(matches []RuleMatch) *string {
 	}
 	return nil
 }
+
+// Outcome evaluates the rules against a new incident and returns what they
+// decide for its remediation, implementing models.RuleEvaluator. String
+// values of the incident's provider data are matched as metadata.
+func (e *RuleEngine) Outcome(incident *models.Incident) models.RuleOutcome {
+	data := &IncidentData{
+		ServiceName:  incident.ServiceName,
+		ErrorMessage: incident.ErrorMessage,
+		Severity:     incident.Severity,
+		Provider:     incident.Provider,
+		Tenant:       incident.TenantID,
+		Metadata:     make(map[string]string),
+	}
+	for key, value := range incident.ProviderData {
+		if s, ok := value.(string); ok {
+			data.Metadata[key] = s
+		}
+	}
+
+	var outcome models.RuleOutcome
+	for _, match := range e.Evaluate(data) {
+		if match.Actions.SkipRemediation && !outcome.SkipRemediation {
+			outcome.SkipRemediation = true
+			outcome.SkippedBy = match.Rule.Name
+		}
+		if match.Actions.SetRepository != nil && outcome.Repository == "" {
+			outcome.Repository = *match.Actions.SetRepository
+			outcome.RepositorySetBy = match.Rule.Name
+		}
+	}
+	return outcome
+}
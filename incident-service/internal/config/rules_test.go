@@ -2,6 +2,8 @@ package config
 
 import (
 	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
 )
 
 func TestRuleEngine_Evaluate(t *testing.T) {
@@ -74,6 +76,29 @@ func TestRuleEngine_Evaluate(t *testing.T) {
 			},
 			expectMatches: 0,
 		},
+		{
+			name: "no match - other tenant",
+			rules: []CustomRule{
+				{
+					Name:    "tenant-rule",
+					Enabled: true,
+					Conditions: RuleConditions{
+						ServiceName: stringPtr("payment-service"),
+						Tenant:      stringPtr("retail"),
+					},
+					Actions: RuleActions{
+						SetSeverity: stringPtr("critical"),
+					},
+				},
+			},
+			incident: IncidentData{
+				ServiceName:  "payment-service",
+				ErrorMessage: "payment failed",
+				Severity:     "high",
+				Tenant:       "wholesale",
+			},
+			expectMatches: 0,
+		},
 		{
 			name: "disabled rule should not match",
 			rules: []CustomRule{
@@ -391,3 +416,43 @@ func TestGetRepositoryOverride(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleEngine_Outcome(t *testing.T) {
+	engine := NewRuleEngine([]CustomRule{
+		{
+			Name:       "legacy-checkout",
+			Conditions: RuleConditions{ServiceName: stringPtr("checkout"), Metadata: map[string]string{"env": "legacy"}},
+			Actions:    RuleActions{SetRepository: stringPtr("org/checkout-legacy")},
+			Enabled:    true,
+		},
+		{
+			Name:       "ignore-low",
+			Conditions: RuleConditions{Severity: stringPtr("low")},
+			Actions:    RuleActions{SkipRemediation: true},
+			Enabled:    true,
+		},
+		{
+			Name:       "later-override",
+			Conditions: RuleConditions{ServiceName: stringPtr("checkout")},
+			Actions:    RuleActions{SetRepository: stringPtr("org/other")},
+			Enabled:    true,
+		},
+	})
+
+	outcome := engine.Outcome(&models.Incident{
+		ServiceName:  "checkout",
+		Severity:     "low",
+		ProviderData: map[string]interface{}{"env": "legacy", "count": 3},
+	})
+	if !outcome.SkipRemediation || outcome.SkippedBy != "ignore-low" {
+		t.Errorf("expected remediation skipped by ignore-low, got %+v", outcome)
+	}
+	if outcome.Repository != "org/checkout-legacy" || outcome.RepositorySetBy != "legacy-checkout" {
+		t.Errorf("expected the first matching rule's repository, got %+v", outcome)
+	}
+
+	outcome = engine.Outcome(&models.Incident{ServiceName: "search", Severity: "high"})
+	if outcome != (models.RuleOutcome{}) {
+		t.Errorf("expected no outcome without matching rules, got %+v", outcome)
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Link is a titled URL, such as a runbook or a known-issue ticket
+type Link struct {
+	Title string `yaml:"title" json:"title,omitempty"`
+	URL   string `yaml:"url" json:"url"`
+}
+
+// Validate checks that the link has an absolute http or https URL
+func (l *Link) Validate() error {
+	parsed, err := url.Parse(l.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("url %q must be an absolute http or https URL", l.URL)
+	}
+	return nil
+}
+
+// MappingFor returns the service mapping of the service, if one is configured
+func (c *Config) MappingFor(serviceName string) *ServiceMapping {
+	for i := range c.ServiceMappings {
+		if c.ServiceMappings[i].ServiceName == serviceName {
+			return &c.ServiceMappings[i]
+		}
+	}
+	return nil
+}
+
+// validateLinks checks the runbook and known-issue links of the service mappings
+func (c *Config) validateLinks() error {
+	for _, mapping := range c.ServiceMappings {
+		for i, link := range mapping.Runbooks {
+			if err := link.Validate(); err != nil {
+				return fmt.Errorf("service mapping for %q: runbook at index %d: %w", mapping.ServiceName, i, err)
+			}
+		}
+		for i, link := range mapping.KnownIssues {
+			if err := link.Validate(); err != nil {
+				return fmt.Errorf("service mapping for %q: known issue at index %d: %w", mapping.ServiceName, i, err)
+			}
+		}
+	}
+	return nil
+}
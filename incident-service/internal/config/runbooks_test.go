@@ -0,0 +1,46 @@
+package config
+
+import "testing"
+
+func TestLink_Validate(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"https://wiki.example.com/runbooks/checkout", false},
+		{"http://jira.example.com/browse/OPS-12", false},
+		{"", true},
+		{"wiki.example.com/runbooks", true},
+		{"ftp://files.example.com/runbook.txt", true},
+		{"https://", true},
+	}
+
+	for _, tt := range tests {
+		link := Link{Title: "runbook", URL: tt.url}
+		if err := link.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
+
+func TestConfig_ValidateLinks(t *testing.T) {
+	cfg := &Config{ServiceMappings: []ServiceMapping{{
+		ServiceName: "checkout",
+		Runbooks:    []Link{{Title: "Checkout runbook", URL: "https://wiki.example.com/checkout"}},
+		KnownIssues: []Link{{Title: "Flaky payment provider", URL: "https://jira.example.com/browse/OPS-12"}},
+	}}}
+	if err := cfg.validateLinks(); err != nil {
+		t.Errorf("expected valid links, got %v", err)
+	}
+	if mapping := cfg.MappingFor("checkout"); mapping == nil || len(mapping.Runbooks) != 1 {
+		t.Errorf("expected the checkout mapping, got %+v", mapping)
+	}
+	if cfg.MappingFor("search") != nil {
+		t.Error("expected no mapping for an unmapped service")
+	}
+
+	cfg.ServiceMappings[0].KnownIssues[0].URL = "OPS-12"
+	if err := cfg.validateLinks(); err == nil {
+		t.Error("expected an error for a relative known-issue URL")
+	}
+}
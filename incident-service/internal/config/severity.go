@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SeverityConfig controls the severity suggested for incidents
+// whose provider gave no severity that maps. Keywords in the error decide
+// first; otherwise a naive Bayes model trained on past incidents of known
+// severity predicts from the error text and the service.
+type SeverityConfig struct {
+	Enabled       bool                `yaml:"enabled"`
+	Keywords      map[string][]string `yaml:"keywords"`       // phrases that suggest a severity, such as critical: ["out of memory"]
+	TrainInterval time.Duration       `yaml:"train_interval"` // how often the model is retrained, defaults to 1h
+	Lookback      time.Duration       `yaml:"lookback"`       // how far back training incidents go, defaults to 90 days
+	MaxSamples    int                 `yaml:"max_samples"`    // most recent incidents trained on, defaults to 5000
+	MinSamples    int                 `yaml:"min_samples"`    // incidents needed before the model predicts, defaults to 50
+	MinConfidence float64             `yaml:"min_confidence"` // probability a prediction needs, defaults to 0.5
+}
+
+// severities lists the severities incidents can have, most urgent first
+var severities = []string{"critical", "high", "medium", "low"}
+
+// RetrainInterval returns how often the model is retrained
+func (c *SeverityConfig) RetrainInterval() time.Duration {
+	if c.TrainInterval <= 0 {
+		return time.Hour
+	}
+	return c.TrainInterval
+}
+
+// TrainingWindow returns how far back training incidents go
+func (c *SeverityConfig) TrainingWindow() time.Duration {
+	if c.Lookback <= 0 {
+		return 90 * 24 * time.Hour
+	}
+	return c.Lookback
+}
+
+// SampleLimit returns how many incidents the model is trained on at most
+func (c *SeverityConfig) SampleLimit() int {
+	if c.MaxSamples <= 0 {
+		return 5000
+	}
+	return c.MaxSamples
+}
+
+// SampleMinimum returns how many incidents the model needs before it predicts
+func (c *SeverityConfig) SampleMinimum() int {
+	if c.MinSamples <= 0 {
+		return 50
+	}
+	return c.MinSamples
+}
+
+// ConfidenceThreshold returns the probability a prediction needs
+func (c *SeverityConfig) ConfidenceThreshold() float64 {
+	if c.MinConfidence <= 0 {
+		return 0.5
+	}
+	return c.MinConfidence
+}
+
+// Validate checks that keywords name known severities and the bounds make sense
+func (c *SeverityConfig) Validate() error {
+	for severity, phrases := range c.Keywords {
+		if !knownSeverity(severity) {
+			return fmt.Errorf("keywords: unknown severity %q, expected one of %s", severity, strings.Join(severities, ", "))
+		}
+		for _, phrase := range phrases {
+			if strings.TrimSpace(phrase) == "" {
+				return fmt.Errorf("keywords: empty phrase for severity %s", severity)
+			}
+		}
+	}
+	if c.TrainInterval < 0 || c.Lookback < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+	if c.MaxSamples < 0 || c.MinSamples < 0 {
+		return fmt.Errorf("max_samples and min_samples must not be negative")
+	}
+	if c.MinConfidence < 0 || c.MinConfidence > 1 {
+		return fmt.Errorf("min_confidence must be between 0 and 1")
+	}
+	return nil
+}
+
+func knownSeverity(severity string) bool {
+	for _, known := range severities {
+		if severity == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSeverityConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  SeverityConfig
+		wantErr bool
+	}{
+		{"defaults", SeverityConfig{}, false},
+		{"keywords", SeverityConfig{Keywords: map[string][]string{"critical": {"out of memory"}}}, false},
+		{"unknown severity", SeverityConfig{Keywords: map[string][]string{"urgent": {"out of memory"}}}, true},
+		{"empty phrase", SeverityConfig{Keywords: map[string][]string{"low": {" "}}}, true},
+		{"negative interval", SeverityConfig{TrainInterval: -time.Hour}, true},
+		{"negative samples", SeverityConfig{MinSamples: -1}, true},
+		{"confidence above 1", SeverityConfig{MinConfidence: 2}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
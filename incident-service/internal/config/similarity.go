@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// SimilarityConfig controls the search for past incidents similar to an
+// incident, by the embeddings of their error messages. Embeddings come from
+// an OpenAI-compatible embeddings API, hosted or local.
+type SimilarityConfig struct {
+	Enabled       bool          `yaml:"enabled"`
+	BaseURL       string        `yaml:"base_url"` // such as https://api.openai.com/v1 or http://localhost:11434/v1
+	APIKey        string        `yaml:"api_key"`  // sent as a bearer token, optional for local endpoints
+	Model         string        `yaml:"model"`
+	Timeout       time.Duration `yaml:"timeout"`        // per embedding request, defaults to 10s
+	Limit         int           `yaml:"limit"`          // similar incidents returned, defaults to 5
+	MinScore      float64       `yaml:"min_score"`      // cosine similarity a match needs, defaults to 0.8
+	Lookback      time.Duration `yaml:"lookback"`       // how far back matches are looked for, defaults to 90 days
+	MaxCandidates int           `yaml:"max_candidates"` // most recent incidents compared, defaults to 5000
+}
+
+// RequestTimeout returns how long an embedding request may take
+func (c *SimilarityConfig) RequestTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 10 * time.Second
+	}
+	return c.Timeout
+}
+
+// ResultLimit returns how many similar incidents are returned
+func (c *SimilarityConfig) ResultLimit() int {
+	if c.Limit <= 0 {
+		return 5
+	}
+	return c.Limit
+}
+
+// Threshold returns the cosine similarity a match needs
+func (c *SimilarityConfig) Threshold() float64 {
+	if c.MinScore <= 0 {
+		return 0.8
+	}
+	return c.MinScore
+}
+
+// LookbackWindow returns how far back matches are looked for
+func (c *SimilarityConfig) LookbackWindow() time.Duration {
+	if c.Lookback <= 0 {
+		return 90 * 24 * time.Hour
+	}
+	return c.Lookback
+}
+
+// CandidateLimit returns how many recent incidents are compared at most
+func (c *SimilarityConfig) CandidateLimit() int {
+	if c.MaxCandidates <= 0 {
+		return 5000
+	}
+	return c.MaxCandidates
+}
+
+// Validate checks that the endpoint and model are set and the search bounds
+// make sense
+func (c *SimilarityConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Model == "" {
+		return fmt.Errorf("model is required")
+	}
+	u, err := url.Parse(c.BaseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("base_url must be an absolute http or https URL")
+	}
+	if c.Timeout < 0 || c.Lookback < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+	if c.Limit < 0 || c.MaxCandidates < 0 {
+		return fmt.Errorf("limit and max_candidates must not be negative")
+	}
+	if c.MinScore < 0 || c.MinScore > 1 {
+		return fmt.Errorf("min_score must be between 0 and 1")
+	}
+	return nil
+}
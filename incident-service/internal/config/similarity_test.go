@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimilarityConfig_Validate(t *testing.T) {
+	valid := SimilarityConfig{Enabled: true, BaseURL: "https://api.openai.com/v1", Model: "text-embedding-3-small"}
+
+	withoutModel := valid
+	withoutModel.Model = ""
+	badURL := valid
+	badURL.BaseURL = "api.openai.com"
+	badScore := valid
+	badScore.MinScore = 1.5
+	negativeLookback := valid
+	negativeLookback.Lookback = -time.Hour
+
+	tests := []struct {
+		name    string
+		config  SimilarityConfig
+		wantErr bool
+	}{
+		{"disabled", SimilarityConfig{}, false},
+		{"valid", valid, false},
+		{"missing model", withoutModel, true},
+		{"bad base url", badURL, true},
+		{"min score above 1", badScore, true},
+		{"negative lookback", negativeLookback, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSimilarityConfig_Defaults(t *testing.T) {
+	var cfg SimilarityConfig
+	if cfg.ResultLimit() != 5 || cfg.Threshold() != 0.8 || cfg.CandidateLimit() != 5000 {
+		t.Errorf("unexpected defaults: limit %d, threshold %v, candidates %d", cfg.ResultLimit(), cfg.Threshold(), cfg.CandidateLimit())
+	}
+	if cfg.LookbackWindow() != 90*24*time.Hour {
+		t.Errorf("expected a default lookback of 90 days, got %v", cfg.LookbackWindow())
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// SLAConfig contains per-severity remediation SLA targets. Unlike SLOs, which
+// are measured over many incidents, an SLA applies to every single incident
+// and is reported as soon as it is breached.
+type SLAConfig struct {
+	Enabled       bool          `yaml:"enabled"`
+	CheckInterval time.Duration `yaml:"check_interval"` // defaults to 1m
+	Targets       []SLATarget   `yaml:"targets"`
+}
+
+// SLATarget sets the time limits, measured from incident creation, for
+// incidents of one severity. A zero limit is not tracked.
+type SLATarget struct {
+	Severity      string        `yaml:"severity"`        // empty applies to severities without their own target
+	TimeToTrigger time.Duration `yaml:"time_to_trigger"` // until the workflow is dispatched
+	TimeToPR      time.Duration `yaml:"time_to_pr"`      // until a pull request is created
+	TimeToResolve time.Duration `yaml:"time_to_resolve"` // until the incident is resolved, has a pull request or needs no fix
+}
+
+// TargetFor returns the target for the severity, falling back to the target
+// without a severity, or nil if neither exists
+func (c *SLAConfig) TargetFor(severity string) *SLATarget {
+	var fallback *SLATarget
+	for i := range c.Targets {
+		target := &c.Targets[i]
+		if target.Severity == severity {
+			return target
+		}
+		if target.Severity == "" {
+			fallback = target
+		}
+	}
+	return fallback
+}
+
+// Longest returns the largest time limit across all targets
+func (c *SLAConfig) Longest() time.Duration {
+	var longest time.Duration
+	for _, target := range c.Targets {
+		for _, limit := range []time.Duration{target.TimeToTrigger, target.TimeToPR, target.TimeToResolve} {
+			if limit > longest {
+				longest = limit
+			}
+		}
+	}
+	return longest
+}
+
+// Validate checks that the SLA targets are usable
+func (c *SLAConfig) Validate() error {
+	if c.CheckInterval < 0 {
+		return fmt.Errorf("check_interval must not be negative")
+	}
+
+	if c.Enabled && len(c.Targets) == 0 {
+		return fmt.Errorf("at least one target is required when slas are enabled")
+	}
+
+	seen := make(map[string]bool)
+	for i, target := range c.Targets {
+		if seen[target.Severity] {
+			return fmt.Errorf("target at index %d: duplicate severity '%s'", i, target.Severity)
+		}
+		seen[target.Severity] = true
+
+		if target.TimeToTrigger < 0 || target.TimeToPR < 0 || target.TimeToResolve < 0 {
+			return fmt.Errorf("target at index %d: time limits must not be negative", i)
+		}
+		if target.TimeToTrigger == 0 && target.TimeToPR == 0 && target.TimeToResolve == 0 {
+			return fmt.Errorf("target at index %d: at least one time limit is required", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSLAConfig_TargetFor(t *testing.T) {
+	cfg := SLAConfig{Targets: []SLATarget{
+		{TimeToResolve: 72 * time.Hour},
+		{Severity: "critical", TimeToPR: 30 * time.Minute},
+	}}
+
+	if target := cfg.TargetFor("critical"); target == nil || target.TimeToPR != 30*time.Minute {
+		t.Errorf("expected the critical target, got %+v", target)
+	}
+	if target := cfg.TargetFor("low"); target == nil || target.TimeToResolve != 72*time.Hour {
+		t.Errorf("expected the default target for low, got %+v", target)
+	}
+	if target := (&SLAConfig{Targets: cfg.Targets[1:]}).TargetFor("low"); target != nil {
+		t.Errorf("expected no target without a default, got %+v", target)
+	}
+	if got := cfg.Longest(); got != 72*time.Hour {
+		t.Errorf("Longest() = %v, want 72h", got)
+	}
+}
+
+func TestSLAConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  SLAConfig
+		wantErr bool
+	}{
+		{"disabled", SLAConfig{}, false},
+		{"valid", SLAConfig{Enabled: true, Targets: []SLATarget{{Severity: "critical", TimeToPR: time.Hour}, {TimeToResolve: time.Hour}}}, false},
+		{"enabled without targets", SLAConfig{Enabled: true}, true},
+		{"duplicate severity", SLAConfig{Targets: []SLATarget{{Severity: "high", TimeToPR: time.Hour}, {Severity: "high", TimeToResolve: time.Hour}}}, true},
+		{"negative limit", SLAConfig{Targets: []SLATarget{{Severity: "high", TimeToPR: -time.Hour}}}, true},
+		{"no limits", SLAConfig{Targets: []SLATarget{{Severity: "high"}}}, true},
+		{"negative interval", SLAConfig{CheckInterval: -time.Minute}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
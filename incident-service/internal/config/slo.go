@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// SLOConfig contains remediation service level objectives
+type SLOConfig struct {
+	RefreshInterval time.Duration `yaml:"refresh_interval"` // how often SLO gauges are recomputed, defaults to 5m
+	Objectives      []SLO         `yaml:"objectives"`
+}
+
+// SLO is a remediation objective such as "90% of critical incidents get a
+// pull request within 30 minutes", measured over a rolling window
+type SLO struct {
+	Name        string        `yaml:"name"`
+	Description string        `yaml:"description"`
+	Severity    string        `yaml:"severity"`     // empty matches all severities
+	ServiceName string        `yaml:"service_name"` // empty matches all services
+	Target      float64       `yaml:"target"`       // fraction of incidents that must meet the objective, e.g. 0.9
+	Within      time.Duration `yaml:"within"`       // time from creation to pull request
+	Window      time.Duration `yaml:"window"`       // rolling window, defaults to 30 days
+}
+
+// RollingWindow returns the window the SLO is measured over
+func (s *SLO) RollingWindow() time.Duration {
+	if s.Window <= 0 {
+		return 30 * 24 * time.Hour
+	}
+	return s.Window
+}
+
+// Validate checks that the objectives are well formed
+func (c *SLOConfig) Validate() error {
+	seen := make(map[string]bool)
+	for i, slo := range c.Objectives {
+		if slo.Name == "" {
+			return fmt.Errorf("objective at index %d: name is required", i)
+		}
+		if seen[slo.Name] {
+			return fmt.Errorf("objective '%s': duplicate name", slo.Name)
+		}
+		seen[slo.Name] = true
+
+		if slo.Target <= 0 || slo.Target > 1 {
+			return fmt.Errorf("objective '%s': target must be greater than 0 and at most 1", slo.Name)
+		}
+		if slo.Within <= 0 {
+			return fmt.Errorf("objective '%s': within must be positive", slo.Name)
+		}
+		if slo.Window < 0 {
+			return fmt.Errorf("objective '%s': window must not be negative", slo.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// SlowDripConfig controls dispatching at a slow, fixed rate while GitHub
+// keeps answering with server errors. Instead of failing, incidents whose
+// dispatch fails in that mode wait for their turn and are dispatched one
+// per interval until GitHub recovers.
+type SlowDripConfig struct {
+	Enabled   bool          `yaml:"enabled"`
+	Threshold int           `yaml:"threshold"` // dispatch requests answered with a 5xx in a row that start slow-drip mode, defaults to 5
+	Interval  time.Duration `yaml:"interval"`  // time between dispatches in slow-drip mode, defaults to 1m
+}
+
+// DefaultSlowDripThreshold is how many dispatch requests in a row must get
+// a server error before slow-drip mode starts when slow_drip.threshold is
+// not set
+const DefaultSlowDripThreshold = 5
+
+// DefaultSlowDripInterval is the time between dispatches in slow-drip mode
+// when slow_drip.interval is not set
+const DefaultSlowDripInterval = time.Minute
+
+// ServerErrorThreshold returns how many dispatch requests in a row must get
+// a server error before slow-drip mode starts
+func (c *SlowDripConfig) ServerErrorThreshold() int {
+	if c.Threshold <= 0 {
+		return DefaultSlowDripThreshold
+	}
+	return c.Threshold
+}
+
+// DispatchInterval returns the time between dispatches in slow-drip mode
+func (c *SlowDripConfig) DispatchInterval() time.Duration {
+	if c.Interval <= 0 {
+		return DefaultSlowDripInterval
+	}
+	return c.Interval
+}
+
+// Validate checks that the slow-drip settings are usable
+func (c *SlowDripConfig) Validate() error {
+	if c.Threshold < 0 {
+		return fmt.Errorf("threshold must not be negative")
+	}
+	if c.Interval < 0 {
+		return fmt.Errorf("interval must not be negative")
+	}
+	return nil
+}
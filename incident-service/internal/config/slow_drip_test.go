@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSlowDripConfig_Defaults(t *testing.T) {
+	cfg := &SlowDripConfig{}
+	if got := cfg.ServerErrorThreshold(); got != DefaultSlowDripThreshold {
+		t.Errorf("expected default threshold of %d, got %d", DefaultSlowDripThreshold, got)
+	}
+	if got := cfg.DispatchInterval(); got != DefaultSlowDripInterval {
+		t.Errorf("expected default interval of %v, got %v", DefaultSlowDripInterval, got)
+	}
+}
+
+func TestSlowDripConfig_Validate(t *testing.T) {
+	if err := (&SlowDripConfig{Enabled: true, Threshold: 3, Interval: 30 * time.Second}).Validate(); err != nil {
+		t.Errorf("expected valid config, got %v", err)
+	}
+	if err := (&SlowDripConfig{Threshold: -1}).Validate(); err == nil {
+		t.Error("expected error for negative threshold")
+	}
+	if err := (&SlowDripConfig{Interval: -time.Minute}).Validate(); err == nil {
+		t.Error("expected error for negative interval")
+	}
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// StaleIncidentsConfig controls the worker that fails incidents stuck in a
+// non-terminal status for too long
+type StaleIncidentsConfig struct {
+	Enabled           bool          `yaml:"enabled"`
+	CheckInterval     time.Duration `yaml:"check_interval"`      // defaults to 1m
+	PendingTimeout    time.Duration `yaml:"pending_timeout"`     // time since creation, 0 disables
+	InProgressTimeout time.Duration `yaml:"in_progress_timeout"` // time since dispatch for workflow_triggered and in_progress, 0 disables
+}
+
+// Validate checks that the stale incident thresholds are usable
+func (c *StaleIncidentsConfig) Validate() error {
+	if c.CheckInterval < 0 || c.PendingTimeout < 0 || c.InProgressTimeout < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+
+	if c.Enabled && c.PendingTimeout == 0 && c.InProgressTimeout == 0 {
+		return fmt.Errorf("pending_timeout or in_progress_timeout is required when enabled")
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// StormConfig controls alert storm detection. When one service produces a
+// burst of incidents, the burst is collapsed into a single storm incident and
+// the individual incidents are recorded without being remediated.
+type StormConfig struct {
+	Enabled       bool          `yaml:"enabled"`
+	Window        time.Duration `yaml:"window"`         // sliding window incidents are counted over, defaults to 5m
+	Threshold     int           `yaml:"threshold"`      // incidents within the window that start a storm, defaults to 20
+	ReleaseBelow  int           `yaml:"release_below"`  // a storm ends once the window holds fewer incidents, defaults to a quarter of threshold
+	CheckInterval time.Duration `yaml:"check_interval"` // how often storms are checked for release, defaults to 1m
+}
+
+// WindowSize returns the sliding window incidents are counted over
+func (c *StormConfig) WindowSize() time.Duration {
+	if c.Window <= 0 {
+		return 5 * time.Minute
+	}
+	return c.Window
+}
+
+// StartThreshold returns how many incidents within the window start a storm
+func (c *StormConfig) StartThreshold() int {
+	if c.Threshold <= 0 {
+		return 20
+	}
+	return c.Threshold
+}
+
+// ReleaseThreshold returns the incident count within the window below which a storm ends
+func (c *StormConfig) ReleaseThreshold() int {
+	if c.ReleaseBelow <= 0 {
+		if release := c.StartThreshold() / 4; release > 0 {
+			return release
+		}
+		return 1
+	}
+	return c.ReleaseBelow
+}
+
+// Validate checks that the storm thresholds are usable
+func (c *StormConfig) Validate() error {
+	if c.Window < 0 || c.CheckInterval < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+
+	if c.Threshold < 0 || c.ReleaseBelow < 0 {
+		return fmt.Errorf("thresholds must not be negative")
+	}
+
+	if c.Threshold == 1 {
+		return fmt.Errorf("threshold must be at least 2")
+	}
+
+	if c.ReleaseThreshold() > c.StartThreshold() {
+		return fmt.Errorf("release_below must not be above threshold")
+	}
+
+	return nil
+}
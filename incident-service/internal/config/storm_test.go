@@ -0,0 +1,41 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStormConfig_Defaults(t *testing.T) {
+	cfg := StormConfig{}
+	if cfg.WindowSize() != 5*time.Minute || cfg.StartThreshold() != 20 || cfg.ReleaseThreshold() != 5 {
+		t.Errorf("unexpected defaults: window %v, threshold %d, release %d", cfg.WindowSize(), cfg.StartThreshold(), cfg.ReleaseThreshold())
+	}
+
+	small := StormConfig{Threshold: 3}
+	if small.ReleaseThreshold() != 1 {
+		t.Errorf("expected release threshold of at least 1, got %d", small.ReleaseThreshold())
+	}
+}
+
+func TestStormConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  StormConfig
+		wantErr bool
+	}{
+		{"defaults", StormConfig{}, false},
+		{"valid", StormConfig{Enabled: true, Window: time.Minute, Threshold: 10, ReleaseBelow: 3}, false},
+		{"negative window", StormConfig{Window: -time.Minute}, true},
+		{"negative threshold", StormConfig{Threshold: -1}, true},
+		{"threshold of one", StormConfig{Threshold: 1}, true},
+		{"release above threshold", StormConfig{Threshold: 5, ReleaseBelow: 6}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// SummaryConfig controls the summaries generated for incidents by a language
+// model served behind an OpenAI-compatible chat completions API, hosted or local
+type SummaryConfig struct {
+	Enabled   bool          `yaml:"enabled"`
+	BaseURL   string        `yaml:"base_url"` // such as https://api.openai.com/v1 or http://localhost:11434/v1
+	APIKey    string        `yaml:"api_key"`  // sent as a bearer token, optional for local endpoints
+	Model     string        `yaml:"model"`
+	Timeout   time.Duration `yaml:"timeout"`    // per request, defaults to 30s
+	MaxTokens int           `yaml:"max_tokens"` // of the model's answer, defaults to 300
+}
+
+// RequestTimeout returns how long a summary request may take
+func (c *SummaryConfig) RequestTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 30 * time.Second
+	}
+	return c.Timeout
+}
+
+// AnswerTokens returns the most tokens the model may answer with
+func (c *SummaryConfig) AnswerTokens() int {
+	if c.MaxTokens <= 0 {
+		return 300
+	}
+	return c.MaxTokens
+}
+
+// Validate checks that the endpoint and model are set
+func (c *SummaryConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Model == "" {
+		return fmt.Errorf("model is required")
+	}
+	u, err := url.Parse(c.BaseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("base_url must be an absolute http or https URL")
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+	if c.MaxTokens < 0 {
+		return fmt.Errorf("max_tokens must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummaryConfig_Validate(t *testing.T) {
+	valid := SummaryConfig{Enabled: true, BaseURL: "http://localhost:11434/v1", Model: "llama3"}
+
+	withTimeout := valid
+	withTimeout.Timeout = -time.Second
+	withoutModel := valid
+	withoutModel.Model = ""
+	relativeURL := valid
+	relativeURL.BaseURL = "/v1"
+
+	tests := []struct {
+		name    string
+		config  SummaryConfig
+		wantErr bool
+	}{
+		{"disabled", SummaryConfig{}, false},
+		{"valid", valid, false},
+		{"missing model", withoutModel, true},
+		{"relative base url", relativeURL, true},
+		{"negative timeout", withTimeout, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSummaryConfig_Defaults(t *testing.T) {
+	var cfg SummaryConfig
+	if timeout := cfg.RequestTimeout(); timeout != 30*time.Second {
+		t.Errorf("expected a default timeout of 30s, got %v", timeout)
+	}
+	if tokens := cfg.AnswerTokens(); tokens != 300 {
+		t.Errorf("expected a default of 300 answer tokens, got %d", tokens)
+	}
+}
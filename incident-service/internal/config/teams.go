@@ -0,0 +1,80 @@
+package config
+
+import "fmt"
+
+// Team is a group of people that owns services. Incidents of the services
+// mapped to a team carry its name, and the team's destinations receive their
+// notifications when no route is configured for the service itself.
+type Team struct {
+	Name                string   `yaml:"name"`
+	Owners              []string `yaml:"owners"` // email addresses of the owners, notified by email
+	SlackChannel        string   `yaml:"slack_channel"`
+	TeamsWebhookURL     string   `yaml:"teams_webhook_url"` // Microsoft Teams channel
+	PagerDutyRoutingKey string   `yaml:"pagerduty_routing_key"`
+	EscalationContacts  []string `yaml:"escalation_contacts"` // email addresses added to escalation notifications
+}
+
+// Route returns the notification destinations of the team
+func (t *Team) Route() *NotificationRoute {
+	return &NotificationRoute{
+		SlackChannel:        t.SlackChannel,
+		TeamsWebhookURL:     t.TeamsWebhookURL,
+		EmailRecipients:     t.Owners,
+		PagerDutyRoutingKey: t.PagerDutyRoutingKey,
+	}
+}
+
+// TeamNamed returns the team with the given name, if one is configured
+func (c *Config) TeamNamed(name string) *Team {
+	if name == "" {
+		return nil
+	}
+	for i := range c.Teams {
+		if c.Teams[i].Name == name {
+			return &c.Teams[i]
+		}
+	}
+	return nil
+}
+
+// TeamFor returns the name of the team that owns the service, or an empty
+// string if the service is not mapped to a team
+func (c *Config) TeamFor(serviceName string) string {
+	for _, mapping := range c.ServiceMappings {
+		if mapping.ServiceName == serviceName {
+			return mapping.Team
+		}
+	}
+	return ""
+}
+
+// validateTeams checks that team names are unique and that service mappings
+// only refer to configured teams
+func (c *Config) validateTeams() error {
+	names := make(map[string]bool, len(c.Teams))
+	for i, team := range c.Teams {
+		if team.Name == "" {
+			return fmt.Errorf("team at index %d: name is required", i)
+		}
+		if names[team.Name] {
+			return fmt.Errorf("duplicate team name %q", team.Name)
+		}
+		names[team.Name] = true
+	}
+
+	for _, mapping := range c.ServiceMappings {
+		if mapping.Team != "" && !names[mapping.Team] {
+			return fmt.Errorf("service mapping for %q refers to unknown team %q", mapping.ServiceName, mapping.Team)
+		}
+	}
+
+	for _, key := range c.Server.Auth.APIKeys {
+		for _, team := range key.Teams {
+			if !names[team] {
+				return fmt.Errorf("api key %q refers to unknown team %q", key.Name, team)
+			}
+		}
+	}
+
+	return nil
+}
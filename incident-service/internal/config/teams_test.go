@@ -0,0 +1,82 @@
+package config
+
+import "testing"
+
+func TestConfig_TeamFor(t *testing.T) {
+	cfg := &Config{
+		Teams: []Team{{Name: "payments", Owners: []string{"payments@example.com"}, SlackChannel: "#payments"}},
+		ServiceMappings: []ServiceMapping{
+			{ServiceName: "checkout", Repository: "org/checkout", Branch: "main", Team: "payments"},
+			{ServiceName: "search", Repository: "org/search", Branch: "main"},
+		},
+	}
+
+	if got := cfg.TeamFor("checkout"); got != "payments" {
+		t.Errorf("TeamFor(checkout) = %q, want payments", got)
+	}
+	if got := cfg.TeamFor("search"); got != "" {
+		t.Errorf("TeamFor(search) = %q, want no team", got)
+	}
+	if got := cfg.TeamFor("unmapped"); got != "" {
+		t.Errorf("TeamFor(unmapped) = %q, want no team", got)
+	}
+
+	team := cfg.TeamNamed("payments")
+	if team == nil {
+		t.Fatal("expected the payments team")
+	}
+	route := team.Route()
+	if route.SlackChannel != "#payments" || len(route.EmailRecipients) != 1 || route.EmailRecipients[0] != "payments@example.com" {
+		t.Errorf("unexpected team route: %+v", route)
+	}
+	if cfg.TeamNamed("") != nil || cfg.TeamNamed("infra") != nil {
+		t.Error("expected no team for unknown names")
+	}
+}
+
+func TestConfig_ValidateTeams(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"no teams", Config{}, false},
+		{
+			"mapped team",
+			Config{
+				Teams:           []Team{{Name: "payments"}},
+				ServiceMappings: []ServiceMapping{{ServiceName: "checkout", Team: "payments"}},
+			},
+			false,
+		},
+		{"missing name", Config{Teams: []Team{{SlackChannel: "#payments"}}}, true},
+		{"duplicate name", Config{Teams: []Team{{Name: "payments"}, {Name: "payments"}}}, true},
+		{
+			"unknown team",
+			Config{ServiceMappings: []ServiceMapping{{ServiceName: "checkout", Team: "payments"}}},
+			true,
+		},
+		{
+			"api key of a known team",
+			Config{
+				Teams:  []Team{{Name: "payments"}},
+				Server: ServerConfig{Auth: AuthConfig{APIKeys: []APIKey{{Name: "payments", Key: "k", Teams: []string{"payments"}}}}},
+			},
+			false,
+		},
+		{
+			"api key of an unknown team",
+			Config{Server: ServerConfig{Auth: AuthConfig{APIKeys: []APIKey{{Name: "payments", Key: "k", Teams: []string{"payments"}}}}}},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.validateTeams()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateTeams() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// TemplateFuncs are the functions available to notification templates. They are
+// shared with config validation so that overrides are checked at load time.
+var TemplateFuncs = template.FuncMap{
+	"json":    templateJSON,
+	"upper":   strings.ToUpper,
+	"lower":   strings.ToLower,
+	"replace": strings.ReplaceAll,
+	"truncate": func(n int, s string) string {
+		if len(s) <= n {
+			return s
+		}
+		return s[:n] + "..."
+	},
+}
+
+// templateJSON encodes a value as JSON so it can be embedded in JSON templates
+func templateJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// validateTemplates checks the syntax of a set of templates keyed by event type
+func validateTemplates(field string, templates map[string]string) error {
+	for event, text := range templates {
+		if _, err := template.New(event).Funcs(TemplateFuncs).Parse(text); err != nil {
+			return fmt.Errorf("invalid %s template for event '%s': %w", field, event, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import "fmt"
+
+// DefaultTenant is the tenant of services mapped to no tenant, and of the
+// incidents stored before tenants were configured
+const DefaultTenant = "default"
+
+// Tenant is a business unit served by the deployment. Its incidents, events
+// and statistics are kept apart from those of other tenants.
+type Tenant struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+// TenantFor returns the name of the tenant that owns the service
+func (c *Config) TenantFor(serviceName string) string {
+	for _, mapping := range c.ServiceMappings {
+		if mapping.ServiceName == serviceName && mapping.Tenant != "" {
+			return mapping.Tenant
+		}
+	}
+	return DefaultTenant
+}
+
+// validateTenants checks that tenant names are unique and that service
+// mappings, API keys and rules only refer to configured tenants
+func (c *Config) validateTenants() error {
+	names := map[string]bool{DefaultTenant: true}
+	for i, tenant := range c.Tenants {
+		if tenant.Name == "" {
+			return fmt.Errorf("tenant at index %d: name is required", i)
+		}
+		if names[tenant.Name] {
+			return fmt.Errorf("duplicate tenant name %q", tenant.Name)
+		}
+		names[tenant.Name] = true
+	}
+
+	known := func(tenant string) bool {
+		return tenant == "" || names[tenant]
+	}
+	for _, mapping := range c.ServiceMappings {
+		if !known(mapping.Tenant) {
+			return fmt.Errorf("service mapping for %q refers to unknown tenant %q", mapping.ServiceName, mapping.Tenant)
+		}
+	}
+	for _, key := range c.Server.Auth.APIKeys {
+		if !known(key.Tenant) {
+			return fmt.Errorf("api key %q refers to unknown tenant %q", key.Name, key.Tenant)
+		}
+	}
+	for _, rule := range c.CustomRules {
+		if rule.Conditions.Tenant != nil && !known(*rule.Conditions.Tenant) {
+			return fmt.Errorf("custom rule %q refers to unknown tenant %q", rule.Name, *rule.Conditions.Tenant)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package config
+
+import "testing"
+
+func TestConfig_TenantFor(t *testing.T) {
+	cfg := &Config{
+		Tenants: []Tenant{{Name: "retail"}},
+		ServiceMappings: []ServiceMapping{
+			{ServiceName: "checkout", Tenant: "retail"},
+			{ServiceName: "search"},
+		},
+	}
+
+	tests := map[string]string{
+		"checkout": "retail",
+		"search":   DefaultTenant,
+		"unmapped": DefaultTenant,
+	}
+	for service, want := range tests {
+		if got := cfg.TenantFor(service); got != want {
+			t.Errorf("TenantFor(%q) = %q, want %q", service, got, want)
+		}
+	}
+}
+
+func TestConfig_ValidateTenants(t *testing.T) {
+	retail := "retail"
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"no tenants", Config{}, false},
+		{
+			"configured tenant",
+			Config{
+				Tenants:         []Tenant{{Name: "retail"}},
+				ServiceMappings: []ServiceMapping{{ServiceName: "checkout", Tenant: "retail"}},
+				Server:          ServerConfig{Auth: AuthConfig{APIKeys: []APIKey{{Name: "retail", Key: "k", Tenant: "retail"}}}},
+				CustomRules:     []CustomRule{{Name: "retail-rule", Conditions: RuleConditions{Tenant: &retail}}},
+			},
+			false,
+		},
+		{"default tenant", Config{ServiceMappings: []ServiceMapping{{ServiceName: "checkout", Tenant: DefaultTenant}}}, false},
+		{"missing name", Config{Tenants: []Tenant{{Description: "Retail"}}}, true},
+		{"duplicate name", Config{Tenants: []Tenant{{Name: "retail"}, {Name: "retail"}}}, true},
+		{"redefined default", Config{Tenants: []Tenant{{Name: DefaultTenant}}}, true},
+		{"unknown mapping tenant", Config{ServiceMappings: []ServiceMapping{{ServiceName: "checkout", Tenant: "retail"}}}, true},
+		{
+			"unknown api key tenant",
+			Config{Server: ServerConfig{Auth: AuthConfig{APIKeys: []APIKey{{Name: "retail", Key: "k", Tenant: "retail"}}}}},
+			true,
+		},
+		{"unknown rule tenant", Config{CustomRules: []CustomRule{{Name: "retail-rule", Conditions: RuleConditions{Tenant: &retail}}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.validateTenants()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateTenants() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
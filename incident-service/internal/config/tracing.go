@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+)
+
+// TracingConfig contains OpenTelemetry tracing settings
+type TracingConfig struct {
+	Enabled     bool              `yaml:"enabled"`
+	ServiceName string            `yaml:"service_name"` // defaults to incident-service
+	Endpoint    string            `yaml:"endpoint"`     // OTLP/HTTP collector host:port, e.g. otel-collector:4318
+	URLPath     string            `yaml:"url_path"`     // defaults to /v1/traces
+	Insecure    bool              `yaml:"insecure"`
+	Headers     map[string]string `yaml:"headers"`
+	SampleRatio *float64          `yaml:"sample_ratio"` // fraction of new traces to sample, defaults to 1
+}
+
+// Validate checks that the tracing settings are usable
+func (c *TracingConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Endpoint == "" {
+		return fmt.Errorf("endpoint is required when tracing is enabled")
+	}
+
+	if c.SampleRatio != nil && (*c.SampleRatio < 0 || *c.SampleRatio > 1) {
+		return fmt.Errorf("sample_ratio must be between 0 and 1")
+	}
+
+	return nil
+}
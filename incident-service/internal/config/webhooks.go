@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// WebhooksConfig contains the protections applied to provider webhooks
+// before they are accepted
+type WebhooksConfig struct {
+	ReplayProtection ReplayProtectionConfig `yaml:"replay_protection"`
+	// AllowedSources limits each provider's webhooks to address ranges, such
+	// as the provider's published egress ranges. Providers that are not
+	// listed can send from anywhere.
+	AllowedSources map[string][]string `yaml:"allowed_sources"`
+	// TrustedProxies are the address ranges of the load balancers and proxies
+	// in front of the service. Only their X-Forwarded-For headers are used to
+	// find the sender.
+	TrustedProxies []string `yaml:"trusted_proxies"`
+	// AcceptedEvents replaces, per provider, the event types or states that
+	// create incidents: Grafana alert states, Sentry issue actions, PagerDuty
+	// event types, Datadog alert types and Splunk detector statuses. Events
+	// of other types are acknowledged and ignored.
+	AcceptedEvents map[string][]string `yaml:"accepted_events"`
+}
+
+// ReplayProtectionConfig controls rejecting stale and repeated deliveries
+// from providers that say when a webhook was sent (PagerDuty and Sentry)
+type ReplayProtectionConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	MaxAge  time.Duration `yaml:"max_age"` // oldest delivery accepted, defaults to 5m
+}
+
+// Window returns how far a delivery's timestamp may be from the current time
+func (c *ReplayProtectionConfig) Window() time.Duration {
+	if c.MaxAge <= 0 {
+		return 5 * time.Minute
+	}
+	return c.MaxAge
+}
+
+// Validate checks that the webhook protections are usable
+func (c *WebhooksConfig) Validate() error {
+	if c.ReplayProtection.MaxAge < 0 {
+		return fmt.Errorf("replay_protection.max_age must not be negative")
+	}
+	for provider, ranges := range c.AllowedSources {
+		if len(ranges) == 0 {
+			return fmt.Errorf("allowed_sources.%s must list at least one range", provider)
+		}
+		for _, r := range ranges {
+			if _, err := ParseAddressRange(r); err != nil {
+				return fmt.Errorf("invalid allowed_sources.%s: %w", provider, err)
+			}
+		}
+	}
+	for _, r := range c.TrustedProxies {
+		if _, err := ParseAddressRange(r); err != nil {
+			return fmt.Errorf("invalid trusted_proxies: %w", err)
+		}
+	}
+	for provider, events := range c.AcceptedEvents {
+		if len(events) == 0 {
+			return fmt.Errorf("accepted_events.%s must list at least one event", provider)
+		}
+		for _, event := range events {
+			if strings.TrimSpace(event) == "" {
+				return fmt.Errorf("accepted_events.%s must not list an empty event", provider)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseAddressRange parses a CIDR range, or a single address as a range
+// holding only that address
+func ParseAddressRange(s string) (netip.Prefix, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid address range %q", s)
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid address range %q", s)
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
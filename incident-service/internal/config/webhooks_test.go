@@ -0,0 +1,52 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWebhooksConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  WebhooksConfig
+		err  string
+	}{
+		{"empty", WebhooksConfig{}, ""},
+		{"ranges", WebhooksConfig{
+			AllowedSources: map[string][]string{"datadog": {"3.233.144.0/20", "44.242.69.192", "2600:1f18::/32"}},
+			TrustedProxies: []string{"10.0.0.0/8"},
+		}, ""},
+		{"negative max age", WebhooksConfig{ReplayProtection: ReplayProtectionConfig{MaxAge: -1}}, "max_age"},
+		{"empty allowlist", WebhooksConfig{AllowedSources: map[string][]string{"datadog": {}}}, "at least one range"},
+		{"invalid range", WebhooksConfig{AllowedSources: map[string][]string{"datadog": {"3.233.144.0/40"}}}, "allowed_sources.datadog"},
+		{"invalid proxy", WebhooksConfig{TrustedProxies: []string{"proxy.internal"}}, "trusted_proxies"},
+		{"accepted events", WebhooksConfig{AcceptedEvents: map[string][]string{"grafana": {"firing", "pending"}}}, ""},
+		{"no accepted events", WebhooksConfig{AcceptedEvents: map[string][]string{"sentry": {}}}, "accepted_events.sentry"},
+		{"empty accepted event", WebhooksConfig{AcceptedEvents: map[string][]string{"sentry": {"created", " "}}}, "empty event"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestParseAddressRange(t *testing.T) {
+	for input, want := range map[string]string{
+		"44.242.69.192":  "44.242.69.192/32",
+		"3.233.150.7/20": "3.233.144.0/20",
+		"2600:1f18::1":   "2600:1f18::1/128",
+	} {
+		prefix, err := ParseAddressRange(input)
+		if err != nil || prefix.String() != want {
+			t.Errorf("ParseAddressRange(%q) = %s, %v, want %s", input, prefix, err, want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// DefaultWorkflowInputBytes leaves room under GitHub's limit of 65535
+// characters for the inputs of a workflow dispatch
+const DefaultWorkflowInputBytes = 60000
+
+// WorkflowInputsConfig limits the size of remediation workflow inputs, and
+// gives the workflow a signed URL to fetch the incident's full context from
+type WorkflowInputsConfig struct {
+	MaxBytes   int           `yaml:"max_bytes"`   // all inputs together, defaults to 60000
+	ContextURL string        `yaml:"context_url"` // base URL of this API as the workflow reaches it, no context_url input when empty
+	SigningKey string        `yaml:"signing_key"` // HMAC key of context tokens, required with context_url
+	TokenTTL   time.Duration `yaml:"token_ttl"`   // how long a context token is valid, defaults to 1h
+}
+
+// InputLimit returns how many bytes the inputs may take together
+func (c *WorkflowInputsConfig) InputLimit() int {
+	if c.MaxBytes <= 0 {
+		return DefaultWorkflowInputBytes
+	}
+	return c.MaxBytes
+}
+
+// TTL returns how long a context token is valid
+func (c *WorkflowInputsConfig) TTL() time.Duration {
+	if c.TokenTTL <= 0 {
+		return time.Hour
+	}
+	return c.TokenTTL
+}
+
+// Validate checks the input limit, and the context URL and its signing key
+func (c *WorkflowInputsConfig) Validate() error {
+	if c.MaxBytes < 0 || c.MaxBytes > 65535 {
+		return fmt.Errorf("max_bytes must be between 0 and 65535")
+	}
+	if c.MaxBytes > 0 && c.MaxBytes < 1024 {
+		return fmt.Errorf("max_bytes must be at least 1024")
+	}
+	if c.TokenTTL < 0 {
+		return fmt.Errorf("token_ttl must not be negative")
+	}
+	if c.ContextURL == "" {
+		return nil
+	}
+	parsed, err := url.Parse(c.ContextURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("context_url %q must be an absolute http or https URL", c.ContextURL)
+	}
+	if len(c.SigningKey) < 32 {
+		return fmt.Errorf("signing_key must be at least 32 characters with context_url")
+	}
+	return nil
+}
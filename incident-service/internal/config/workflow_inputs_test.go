@@ -0,0 +1,44 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWorkflowInputsConfig_Defaults(t *testing.T) {
+	cfg := WorkflowInputsConfig{}
+	if got := cfg.InputLimit(); got != DefaultWorkflowInputBytes {
+		t.Errorf("InputLimit() = %d, want %d", got, DefaultWorkflowInputBytes)
+	}
+	if got := cfg.TTL(); got != time.Hour {
+		t.Errorf("TTL() = %v, want 1h", got)
+	}
+}
+
+func TestWorkflowInputsConfig_Validate(t *testing.T) {
+	key := strings.Repeat("k", 32)
+	tests := []struct {
+		name    string
+		cfg     WorkflowInputsConfig
+		wantErr bool
+	}{
+		{"defaults", WorkflowInputsConfig{}, false},
+		{"limit", WorkflowInputsConfig{MaxBytes: 30000}, false},
+		{"limit over GitHub's", WorkflowInputsConfig{MaxBytes: 70000}, true},
+		{"limit too small", WorkflowInputsConfig{MaxBytes: 100}, true},
+		{"negative TTL", WorkflowInputsConfig{TokenTTL: -time.Minute}, true},
+		{"context URL", WorkflowInputsConfig{ContextURL: "https://incidents.example.com", SigningKey: key}, false},
+		{"relative context URL", WorkflowInputsConfig{ContextURL: "/api", SigningKey: key}, true},
+		{"context URL without key", WorkflowInputsConfig{ContextURL: "https://incidents.example.com"}, true},
+		{"short key", WorkflowInputsConfig{ContextURL: "https://incidents.example.com", SigningKey: "secret"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// WorkflowLogsConfig controls the worker that downloads the logs of failed
+// remediation workflow runs and keeps an excerpt of the failing step
+type WorkflowLogsConfig struct {
+	Enabled       bool          `yaml:"enabled"`
+	CheckInterval time.Duration `yaml:"check_interval"` // defaults to 2m
+	Lookback      time.Duration `yaml:"lookback"`       // how far back failed incidents are checked, defaults to 24h
+	MaxBytes      int           `yaml:"max_bytes"`      // size of the stored excerpt, defaults to 8192
+}
+
+// LookbackWindow returns how old an incident may be for its logs to be fetched
+func (c *WorkflowLogsConfig) LookbackWindow() time.Duration {
+	if c.Lookback <= 0 {
+		return 24 * time.Hour
+	}
+	return c.Lookback
+}
+
+// ExcerptLimit returns the most bytes of log output stored per run
+func (c *WorkflowLogsConfig) ExcerptLimit() int {
+	if c.MaxBytes <= 0 {
+		return 8192
+	}
+	return c.MaxBytes
+}
+
+// Validate checks that the workflow log settings are usable
+func (c *WorkflowLogsConfig) Validate() error {
+	if c.CheckInterval < 0 {
+		return fmt.Errorf("check_interval must not be negative")
+	}
+	if c.Lookback < 0 {
+		return fmt.Errorf("lookback must not be negative")
+	}
+	if c.MaxBytes < 0 {
+		return fmt.Errorf("max_bytes must not be negative")
+	}
+	return nil
+}
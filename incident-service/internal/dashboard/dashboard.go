@@ -0,0 +1,28 @@
+// Package dashboard embeds the built dashboard, so that the incident service
+// can serve it from a single binary. The assets are copied into dist by
+// go generate; binaries built without running it carry no dashboard.
+package dashboard
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:generate sh -c "cd ../../../dashboard && npm ci && npm run build"
+//go:generate sh -c "rm -rf dist/* && cp -R ../../../dashboard/dist/. dist/"
+
+//go:embed all:dist
+var assets embed.FS
+
+// Assets returns the built dashboard, and false when the binary was built
+// without it
+func Assets() (fs.FS, bool) {
+	dist, err := fs.Sub(assets, "dist")
+	if err != nil {
+		return nil, false
+	}
+	if _, err := fs.Stat(dist, "index.html"); err != nil {
+		return nil, false
+	}
+	return dist, true
+}
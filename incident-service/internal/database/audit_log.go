@@ -0,0 +1,164 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// defaultAuditLimit bounds how many audit entries a query returns when it sets no limit
+const defaultAuditLimit = 100
+
+// AuditFilter represents filtering options for audit log queries
+type AuditFilter struct {
+	Actor        string
+	Action       models.AuditAction
+	ResourceType string
+	ResourceID   string
+	Since        *time.Time
+	Until        *time.Time
+	Limit        int // defaults to 100
+}
+
+// RecordAudit appends an entry to the audit log
+func (r *IncidentRepository) RecordAudit(entry *models.AuditEntry) (err error) {
+	ctx, span := r.startSpan("RecordAudit")
+	defer func() { tracing.End(span, err) }()
+
+	before, err := marshalAuditValue(entry.Before)
+	if err != nil {
+		return err
+	}
+	after, err := marshalAuditValue(entry.After)
+	if err != nil {
+		return err
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO audit_log (
+			actor, action, resource_type, resource_id, before_value,
+			after_value, source_ip, request_id, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`,
+		entry.Actor,
+		entry.Action,
+		entry.ResourceType,
+		entry.ResourceID,
+		before,
+		after,
+		entry.SourceIP,
+		entry.RequestID,
+		entry.CreatedAt,
+	).Scan(&entry.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// marshalAuditValue marshals a before or after value, keeping absent values NULL
+func marshalAuditValue(value map[string]interface{}) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit value: %w", err)
+	}
+	return data, nil
+}
+
+// ListAudit returns audit entries matching the filter, newest first
+func (r *IncidentRepository) ListAudit(filter AuditFilter) (_ []*models.AuditEntry, err error) {
+	ctx, span := r.startSpan("ListAudit")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT id, actor, action, resource_type, resource_id, before_value,
+			after_value, source_ip, request_id, created_at
+		FROM audit_log
+		WHERE 1=1
+	`
+
+	args := []interface{}{}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		query += fmt.Sprintf(" AND "+condition, len(args))
+	}
+	if filter.Actor != "" {
+		addCondition("actor = $%d", filter.Actor)
+	}
+	if filter.Action != "" {
+		addCondition("action = $%d", filter.Action)
+	}
+	if filter.ResourceType != "" {
+		addCondition("resource_type = $%d", filter.ResourceType)
+	}
+	if filter.ResourceID != "" {
+		addCondition("resource_id = $%d", filter.ResourceID)
+	}
+	if filter.Since != nil {
+		addCondition("created_at >= $%d", *filter.Since)
+	}
+	if filter.Until != nil {
+		addCondition("created_at < $%d", *filter.Until)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*models.AuditEntry{}
+	for rows.Next() {
+		var entry models.AuditEntry
+		var before, after []byte
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.Actor,
+			&entry.Action,
+			&entry.ResourceType,
+			&entry.ResourceID,
+			&before,
+			&after,
+			&entry.SourceIP,
+			&entry.RequestID,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		if before != nil {
+			if err := json.Unmarshal(before, &entry.Before); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal audit value: %w", err)
+			}
+		}
+		if after != nil {
+			if err := json.Unmarshal(after, &entry.After); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal audit value: %w", err)
+			}
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating audit entries: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestIncidentRepository_AuditLog(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+	if _, err := db.Exec("DELETE FROM audit_log"); err != nil {
+		t.Fatalf("failed to clean up audit log: %v", err)
+	}
+
+	repo := NewIncidentRepository(db)
+	start := time.Now().Add(-time.Minute)
+
+	entries := []*models.AuditEntry{
+		{
+			Actor:        "ops-cli",
+			Action:       models.AuditIncidentRetried,
+			ResourceType: "incident",
+			ResourceID:   "inc_test_audit_1",
+			Before:       map[string]interface{}{"status": "failed"},
+			After:        map[string]interface{}{"status": "pending"},
+			SourceIP:     "10.0.0.7",
+			RequestID:    "req-1",
+		},
+		{
+			Actor:        "dashboard",
+			Action:       models.AuditIncidentLinked,
+			ResourceType: "incident",
+			ResourceID:   "inc_test_audit_2",
+			After:        map[string]interface{}{"related_incident_id": "inc_test_audit_1"},
+		},
+	}
+	for _, entry := range entries {
+		if err := repo.RecordAudit(entry); err != nil {
+			t.Fatalf("RecordAudit() error = %v", err)
+		}
+		if entry.ID == 0 {
+			t.Error("expected the entry ID to be set")
+		}
+	}
+
+	all, err := repo.ListAudit(AuditFilter{Since: &start})
+	if err != nil {
+		t.Fatalf("ListAudit() error = %v", err)
+	}
+	if len(all) != 2 || all[0].Actor != "dashboard" {
+		t.Fatalf("expected both entries, newest first, got %+v", all)
+	}
+	if all[0].Before != nil {
+		t.Errorf("expected no before value for a new link, got %v", all[0].Before)
+	}
+
+	byActor, err := repo.ListAudit(AuditFilter{Actor: "ops-cli", ResourceID: "inc_test_audit_1"})
+	if err != nil {
+		t.Fatalf("ListAudit() error = %v", err)
+	}
+	if len(byActor) != 1 || byActor[0].Before["status"] != "failed" || byActor[0].SourceIP != "10.0.0.7" {
+		t.Errorf("unexpected entries %+v", byActor)
+	}
+
+	limited, err := repo.ListAudit(AuditFilter{Limit: 1})
+	if err != nil {
+		t.Fatalf("ListAudit() error = %v", err)
+	}
+	if len(limited) != 1 {
+		t.Errorf("expected the limit to apply, got %d entries", len(limited))
+	}
+}
@@ -11,6 +11,7 @@ import (
 // DB wraps the database connection
 type DB struct {
 	*sql.DB
+	cipher *Cipher // encrypts sensitive incident columns, nil when encryption is disabled
 }
 
 // Connect establishes a connection to PostgreSQL
@@ -30,7 +31,13 @@ func Connect(dsn string) (*DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &DB{db}, nil
+	return &DB{DB: db}, nil
+}
+
+// SetCipher enables encryption of the sensitive incident columns. Values
+// written before are still read as plaintext.
+func (db *DB) SetCipher(cipher *Cipher) {
+	db.cipher = cipher
 }
 
 // Close closes the database connection
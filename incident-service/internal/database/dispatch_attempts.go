@@ -0,0 +1,108 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// RecordDispatchAttempt appends an attempt to dispatch an incident's
+// remediation workflow to its dispatch history
+func (r *IncidentRepository) RecordDispatchAttempt(attempt *models.DispatchAttempt) (err error) {
+	ctx, span := r.startSpan("RecordDispatchAttempt")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		INSERT INTO dispatch_attempts (
+			incident_id, repository, attempt, outcome, http_status, run_id, error, attempted_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+
+	err = r.db.QueryRowContext(ctx,
+		query,
+		attempt.IncidentID,
+		attempt.Repository,
+		attempt.Attempt,
+		attempt.Outcome,
+		attempt.HTTPStatus,
+		attempt.RunID,
+		attempt.Error,
+		attempt.AttemptedAt,
+	).Scan(&attempt.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record dispatch attempt: %w", err)
+	}
+
+	return nil
+}
+
+// SetDispatchRunID records the workflow run of an incident's latest
+// successful dispatch to a repository, once the workflow reports it. It does
+// nothing when that dispatch already has a run.
+func (r *IncidentRepository) SetDispatchRunID(incidentID, repository string, runID int64) (err error) {
+	ctx, span := r.startSpan("SetDispatchRunID")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		UPDATE dispatch_attempts SET run_id = $3
+		WHERE id = (
+			SELECT id FROM dispatch_attempts
+			WHERE incident_id = $1 AND repository = $2 AND outcome = $4
+			ORDER BY id DESC
+			LIMIT 1
+		) AND run_id IS NULL
+	`
+
+	if _, err = r.db.ExecContext(ctx, query, incidentID, repository, runID, models.DispatchAttemptSucceeded); err != nil {
+		return fmt.Errorf("failed to set dispatch run ID: %w", err)
+	}
+
+	return nil
+}
+
+// ListDispatchAttempts returns the dispatch history of an incident, oldest
+// attempt first
+func (r *IncidentRepository) ListDispatchAttempts(incidentID string) (_ []*models.DispatchAttempt, err error) {
+	ctx, span := r.startSpan("ListDispatchAttempts")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT id, incident_id, repository, attempt, outcome, http_status, run_id, error, attempted_at
+		FROM dispatch_attempts
+		WHERE incident_id = $1
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dispatch attempts: %w", err)
+	}
+	defer rows.Close()
+
+	attempts := []*models.DispatchAttempt{}
+	for rows.Next() {
+		var attempt models.DispatchAttempt
+		if err := rows.Scan(
+			&attempt.ID,
+			&attempt.IncidentID,
+			&attempt.Repository,
+			&attempt.Attempt,
+			&attempt.Outcome,
+			&attempt.HTTPStatus,
+			&attempt.RunID,
+			&attempt.Error,
+			&attempt.AttemptedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan dispatch attempt: %w", err)
+		}
+		attempts = append(attempts, &attempt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating dispatch attempts: %w", err)
+	}
+
+	return attempts, nil
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestIncidentRepository_DispatchAttempts(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+	if err := cleanupTestData(db.DB); err != nil {
+		t.Fatalf("failed to clean up test data: %v", err)
+	}
+
+	repo := NewIncidentRepository(db)
+
+	incident := &models.Incident{
+		ID:           "inc_test_dispatch_attempts",
+		ServiceName:  "checkout",
+		Repository:   "org/checkout-api",
+		ErrorMessage: "test error",
+		Severity:     "high",
+		Status:       models.StatusPending,
+		Provider:     "datadog",
+	}
+	if err := repo.Create(incident); err != nil {
+		t.Fatalf("failed to create incident: %v", err)
+	}
+
+	unavailable, created := 503, 204
+	message := "unexpected status code 503"
+	for _, attempt := range []*models.DispatchAttempt{
+		{Attempt: 1, Outcome: models.DispatchAttemptFailed, HTTPStatus: &unavailable, Error: &message},
+		{Attempt: 2, Outcome: models.DispatchAttemptSucceeded, HTTPStatus: &created},
+	} {
+		attempt.IncidentID = incident.ID
+		attempt.Repository = incident.Repository
+		attempt.AttemptedAt = time.Now()
+		if err := repo.RecordDispatchAttempt(attempt); err != nil {
+			t.Fatalf("RecordDispatchAttempt() error = %v", err)
+		}
+	}
+
+	// The run is linked to the successful attempt, and only once
+	if err := repo.SetDispatchRunID(incident.ID, incident.Repository, 42); err != nil {
+		t.Fatalf("SetDispatchRunID() error = %v", err)
+	}
+	if err := repo.SetDispatchRunID(incident.ID, incident.Repository, 43); err != nil {
+		t.Fatalf("SetDispatchRunID() error = %v", err)
+	}
+
+	attempts, err := repo.ListDispatchAttempts(incident.ID)
+	if err != nil {
+		t.Fatalf("ListDispatchAttempts() error = %v", err)
+	}
+	if len(attempts) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(attempts))
+	}
+	first, second := attempts[0], attempts[1]
+	if first.Attempt != 1 || first.Outcome != models.DispatchAttemptFailed || first.HTTPStatus == nil || *first.HTTPStatus != 503 || first.Error == nil || first.RunID != nil {
+		t.Errorf("unexpected first attempt: %+v", first)
+	}
+	if second.Attempt != 2 || second.Outcome != models.DispatchAttemptSucceeded || second.RunID == nil || *second.RunID != 42 {
+		t.Errorf("unexpected second attempt: %+v", second)
+	}
+}
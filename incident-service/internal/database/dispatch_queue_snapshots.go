@@ -0,0 +1,81 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// SaveQueueSnapshot replaces the stored snapshot of the workflow slots and
+// queues with snapshot
+func (r *IncidentRepository) SaveQueueSnapshot(snapshot *github.QueueSnapshot) (err error) {
+	ctx, span := r.startSpan("SaveQueueSnapshot")
+	defer func() { tracing.End(span, err) }()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin queue snapshot transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM dispatch_queue_snapshots`); err != nil {
+		return fmt.Errorf("failed to clear queue snapshot: %w", err)
+	}
+	for repository, entry := range snapshot.Repositories {
+		queued, err := json.Marshal(entry.Queued)
+		if err != nil {
+			return fmt.Errorf("failed to marshal queued incidents: %w", err)
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO dispatch_queue_snapshots (repository, active, queued, taken_at)
+			VALUES ($1, $2, $3, $4)
+		`, repository, entry.Active, queued, snapshot.TakenAt)
+		if err != nil {
+			return fmt.Errorf("failed to save queue snapshot of %s: %w", repository, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit queue snapshot: %w", err)
+	}
+	return nil
+}
+
+// GetQueueSnapshot returns the stored snapshot of the workflow slots and
+// queues, or nil when no repository had running or queued workflows
+func (r *IncidentRepository) GetQueueSnapshot() (_ *github.QueueSnapshot, err error) {
+	ctx, span := r.startSpan("GetQueueSnapshot")
+	defer func() { tracing.End(span, err) }()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT repository, active, queued, taken_at
+		FROM dispatch_queue_snapshots
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queue snapshot: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshot *github.QueueSnapshot
+	for rows.Next() {
+		var repository string
+		var entry github.RepositorySnapshot
+		var queued []byte
+		if snapshot == nil {
+			snapshot = &github.QueueSnapshot{Repositories: make(map[string]github.RepositorySnapshot)}
+		}
+		if err := rows.Scan(&repository, &entry.Active, &queued, &snapshot.TakenAt); err != nil {
+			return nil, fmt.Errorf("failed to scan queue snapshot: %w", err)
+		}
+		if err := json.Unmarshal(queued, &entry.Queued); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal queued incidents: %w", err)
+		}
+		snapshot.Repositories[repository] = entry
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read queue snapshot: %w", err)
+	}
+	return snapshot, nil
+}
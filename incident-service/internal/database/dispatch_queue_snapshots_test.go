@@ -0,0 +1,49 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+)
+
+func TestIncidentRepository_QueueSnapshots(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+
+	repo := NewIncidentRepository(db)
+	takenAt := time.Now().UTC().Truncate(time.Second)
+
+	if err := repo.SaveQueueSnapshot(&github.QueueSnapshot{TakenAt: takenAt, Repositories: map[string]github.RepositorySnapshot{
+		"org/checkout": {Active: 2, Queued: []string{"inc_1", "inc_2"}},
+		"org/billing":  {Active: 1, Queued: []string{}},
+	}}); err != nil {
+		t.Fatalf("SaveQueueSnapshot() error = %v", err)
+	}
+	want := map[string]github.RepositorySnapshot{"org/checkout": {Active: 1, Queued: []string{"inc_2"}}}
+	if err := repo.SaveQueueSnapshot(&github.QueueSnapshot{TakenAt: takenAt, Repositories: want}); err != nil {
+		t.Fatalf("SaveQueueSnapshot() error = %v", err)
+	}
+
+	snapshot, err := repo.GetQueueSnapshot()
+	if err != nil {
+		t.Fatalf("GetQueueSnapshot() error = %v", err)
+	}
+	if snapshot == nil || !reflect.DeepEqual(snapshot.Repositories, want) {
+		t.Fatalf("expected the snapshot replaced, got %+v", snapshot)
+	}
+	if !snapshot.TakenAt.Equal(takenAt) {
+		t.Errorf("TakenAt = %v, want %v", snapshot.TakenAt, takenAt)
+	}
+
+	if err := repo.SaveQueueSnapshot(&github.QueueSnapshot{TakenAt: takenAt}); err != nil {
+		t.Fatalf("SaveQueueSnapshot() error = %v", err)
+	}
+	if snapshot, err := repo.GetQueueSnapshot(); err != nil || snapshot != nil {
+		t.Errorf("GetQueueSnapshot() = %+v, %v, want none", snapshot, err)
+	}
+}
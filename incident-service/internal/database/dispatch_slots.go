@@ -0,0 +1,236 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// slotLockTTL bounds how long a repository's slots stay locked if a replica
+// dies while dispatching. A dispatch with retries takes at most about 100s.
+const slotLockTTL = 2 * time.Minute
+
+// releaseSlotScript frees a slot without going below zero, drops the lease of
+// the incident or else the oldest one, and pops the next queued incident, in
+// one step. Leases are "<acquired nanos>:<incident ID>" members of a sorted
+// set scored by acquisition time.
+var releaseSlotScript = redis.NewScript(`
+local active = tonumber(redis.call("HGET", KEYS[1], ARGV[1]) or "0")
+if active > 1 then
+	redis.call("HINCRBY", KEYS[1], ARGV[1], -1)
+else
+	redis.call("HDEL", KEYS[1], ARGV[1])
+end
+local leases = redis.call("ZRANGE", KEYS[4], 0, -1)
+local lease = leases[1]
+for _, member in ipairs(leases) do
+	if string.sub(member, string.find(member, ":", 1, true) + 1) == ARGV[2] then
+		lease = member
+		break
+	end
+end
+if lease then
+	redis.call("ZREM", KEYS[4], lease)
+end
+local next = redis.call("LPOP", KEYS[2])
+if redis.call("LLEN", KEYS[2]) == 0 then
+	redis.call("SREM", KEYS[3], ARGV[1])
+end
+return next
+`)
+
+// RedisSlotStore keeps workflow concurrency state in Redis, so that every
+// replica enforces the same limit per repository and dispatches from the same
+// queue
+type RedisSlotStore struct {
+	client *RedisClient
+	prefix string
+}
+
+// NewRedisSlotStore creates a slot store under keys starting with prefix
+func NewRedisSlotStore(client *RedisClient, prefix string) *RedisSlotStore {
+	return &RedisSlotStore{client: client, prefix: prefix}
+}
+
+func (s *RedisSlotStore) activeKey() string           { return s.prefix + "active" }
+func (s *RedisSlotStore) queuedKey() string           { return s.prefix + "queued" }
+func (s *RedisSlotStore) queueKey(repo string) string { return s.prefix + "queue:" + repo }
+func (s *RedisSlotStore) lockKey(repo string) string  { return s.prefix + "lock:" + repo }
+func (s *RedisSlotStore) leaseKey(repo string) string { return s.prefix + "lease:" + repo }
+
+// Lock takes the repository's lock, shared by every replica
+func (s *RedisSlotStore) Lock(ctx context.Context, repository string) (func(), error) {
+	return s.client.Lock(ctx, s.lockKey(repository), slotLockTTL)
+}
+
+// Active returns the number of running workflows
+func (s *RedisSlotStore) Active(ctx context.Context, repository string) (int, error) {
+	active, err := s.client.HGet(ctx, s.activeKey(), repository).Int()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get active workflows: %w", err)
+	}
+	return active, nil
+}
+
+// Acquire takes a slot for the incident's workflow, leased from now
+func (s *RedisSlotStore) Acquire(ctx context.Context, repository, incidentID string) error {
+	now := time.Now()
+	pipe := s.client.TxPipeline()
+	pipe.HIncrBy(ctx, s.activeKey(), repository, 1)
+	pipe.ZAdd(ctx, s.leaseKey(repository), redis.Z{
+		Score:  float64(now.UnixMilli()),
+		Member: strconv.FormatInt(now.UnixNano(), 10) + ":" + incidentID,
+	})
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to acquire workflow slot: %w", err)
+	}
+	return nil
+}
+
+// Release frees the incident's slot, or the oldest one, and pops the next
+// queued incident
+func (s *RedisSlotStore) Release(ctx context.Context, repository, incidentID string) (*models.Incident, error) {
+	keys := []string{s.activeKey(), s.queueKey(repository), s.queuedKey(), s.leaseKey(repository)}
+	data, err := releaseSlotScript.Run(ctx, s.client, keys, repository, incidentID).Text()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to release workflow slot: %w", err)
+	}
+	return decodeQueuedIncident(data)
+}
+
+// Leases returns the leases of the repository's slots, oldest first
+func (s *RedisSlotStore) Leases(ctx context.Context, repository string) ([]github.SlotLease, error) {
+	members, err := s.client.ZRange(ctx, s.leaseKey(repository), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow slot leases: %w", err)
+	}
+
+	leases := make([]github.SlotLease, 0, len(members))
+	for _, member := range members {
+		acquired, incidentID, _ := strings.Cut(member, ":")
+		nanos, err := strconv.ParseInt(acquired, 10, 64)
+		if err != nil {
+			continue
+		}
+		leases = append(leases, github.SlotLease{IncidentID: incidentID, AcquiredAt: time.Unix(0, nanos)})
+	}
+	return leases, nil
+}
+
+// Reset frees every slot of the repository, leaving its queue
+func (s *RedisSlotStore) Reset(ctx context.Context, repository string) error {
+	pipe := s.client.TxPipeline()
+	pipe.HDel(ctx, s.activeKey(), repository)
+	pipe.Del(ctx, s.leaseKey(repository))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to reset workflow slots: %w", err)
+	}
+	return nil
+}
+
+// Enqueue appends an incident to its repository's queue
+func (s *RedisSlotStore) Enqueue(ctx context.Context, incident *models.Incident) error {
+	data, err := json.Marshal(incident)
+	if err != nil {
+		return fmt.Errorf("failed to marshal queued incident: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.RPush(ctx, s.queueKey(incident.Repository), data)
+	pipe.SAdd(ctx, s.queuedKey(), incident.Repository)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to queue incident: %w", err)
+	}
+	return nil
+}
+
+// Remove drops an incident from the queue
+func (s *RedisSlotStore) Remove(ctx context.Context, repository, incidentID string) (bool, error) {
+	entries, err := s.client.LRange(ctx, s.queueKey(repository), 0, -1).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to list queued incidents: %w", err)
+	}
+
+	for _, entry := range entries {
+		incident, err := decodeQueuedIncident(entry)
+		if err != nil || incident.ID != incidentID {
+			continue
+		}
+		if err := s.client.LRem(ctx, s.queueKey(repository), 1, entry).Err(); err != nil {
+			return false, fmt.Errorf("failed to remove queued incident: %w", err)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// Queued returns the incidents queued for a repository, next first
+func (s *RedisSlotStore) Queued(ctx context.Context, repository string) ([]*models.Incident, error) {
+	entries, err := s.client.LRange(ctx, s.queueKey(repository), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list queued incidents: %w", err)
+	}
+
+	incidents := make([]*models.Incident, 0, len(entries))
+	for _, entry := range entries {
+		incident, err := decodeQueuedIncident(entry)
+		if err != nil {
+			return nil, err
+		}
+		incidents = append(incidents, incident)
+	}
+	return incidents, nil
+}
+
+// Stats returns the state of every repository with running or queued workflows
+func (s *RedisSlotStore) Stats(ctx context.Context) (map[string]github.RepositoryStats, error) {
+	active, err := s.client.HGetAll(ctx, s.activeKey()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active workflows: %w", err)
+	}
+	queued, err := s.client.SMembers(ctx, s.queuedKey()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list queued repositories: %w", err)
+	}
+
+	stats := make(map[string]github.RepositoryStats)
+	for repository, value := range active {
+		var n int
+		if _, err := fmt.Sscan(value, &n); err == nil && n > 0 {
+			stats[repository] = github.RepositoryStats{Active: n}
+		}
+	}
+	for _, repository := range queued {
+		n, err := s.client.LLen(ctx, s.queueKey(repository)).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to count queued incidents: %w", err)
+		}
+		if n > 0 {
+			entry := stats[repository]
+			entry.Queued = int(n)
+			stats[repository] = entry
+		}
+	}
+	return stats, nil
+}
+
+func decodeQueuedIncident(data string) (*models.Incident, error) {
+	var incident models.Incident
+	if err := json.Unmarshal([]byte(data), &incident); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal queued incident: %w", err)
+	}
+	return &incident, nil
+}
@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+var _ github.SlotStore = (*RedisSlotStore)(nil)
+
+func TestRedisSlotStore(t *testing.T) {
+	ctx := context.Background()
+	client, err := ConnectRedis("localhost:6379", "", 0)
+	if err != nil {
+		t.Skipf("test redis not configured: %v", err)
+	}
+	defer client.Close()
+
+	prefix := "test:dispatch-slots:" + time.Now().Format(time.RFC3339Nano) + ":"
+	store := NewRedisSlotStore(client, prefix)
+	repository := "org/api"
+	defer client.Del(ctx, store.activeKey(), store.queuedKey(), store.queueKey(repository), store.leaseKey(repository))
+
+	if err := store.Acquire(ctx, repository, "inc-0"); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	for _, id := range []string{"inc-1", "inc-2", "inc-3"} {
+		if err := store.Enqueue(ctx, &models.Incident{ID: id, Repository: repository}); err != nil {
+			t.Fatalf("Enqueue() error = %v", err)
+		}
+	}
+	if active, err := store.Active(ctx, repository); err != nil || active != 1 {
+		t.Errorf("Active() = %d, %v, want 1", active, err)
+	}
+
+	removed, err := store.Remove(ctx, repository, "inc-2")
+	if err != nil || !removed {
+		t.Errorf("Remove() = %v, %v, want true", removed, err)
+	}
+	stats, err := store.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats[repository] != (github.RepositoryStats{Active: 1, Queued: 2}) {
+		t.Errorf("Stats() = %+v", stats[repository])
+	}
+
+	// Each release frees the slot and hands back the next incident in order
+	running := "inc-0"
+	for _, want := range []string{"inc-1", "inc-3"} {
+		next, err := store.Release(ctx, repository, running)
+		if err != nil || next == nil || next.ID != want {
+			t.Fatalf("Release() = %+v, %v, want %s", next, err, want)
+		}
+		if err := store.Acquire(ctx, repository, next.ID); err != nil {
+			t.Fatalf("Acquire() error = %v", err)
+		}
+		running = next.ID
+	}
+	if next, err := store.Release(ctx, repository, running); err != nil || next != nil {
+		t.Errorf("Release() on an empty queue = %+v, %v", next, err)
+	}
+	if active, _ := store.Active(ctx, repository); active != 0 {
+		t.Errorf("expected no active workflows, got %d", active)
+	}
+	if stats, _ := store.Stats(ctx); len(stats) != 0 {
+		t.Errorf("expected no repositories in Stats(), got %+v", stats)
+	}
+	if leases, _ := store.Leases(ctx, repository); len(leases) != 0 {
+		t.Errorf("expected no leases, got %+v", leases)
+	}
+}
+
+func TestRedisSlotStore_Leases(t *testing.T) {
+	ctx := context.Background()
+	client, err := ConnectRedis("localhost:6379", "", 0)
+	if err != nil {
+		t.Skipf("test redis not configured: %v", err)
+	}
+	defer client.Close()
+
+	prefix := "test:dispatch-slots:" + time.Now().Format(time.RFC3339Nano) + ":"
+	store := NewRedisSlotStore(client, prefix)
+	repository := "org/api"
+	defer client.Del(ctx, store.activeKey(), store.queuedKey(), store.queueKey(repository), store.leaseKey(repository))
+
+	for _, id := range []string{"inc-1", "inc-2", "inc-3"} {
+		if err := store.Acquire(ctx, repository, id); err != nil {
+			t.Fatalf("Acquire() error = %v", err)
+		}
+	}
+
+	// Releasing an incident drops its own lease; an unknown one drops the oldest
+	if _, err := store.Release(ctx, repository, "inc-2"); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if _, err := store.Release(ctx, repository, "inc-9"); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	leases, err := store.Leases(ctx, repository)
+	if err != nil {
+		t.Fatalf("Leases() error = %v", err)
+	}
+	if len(leases) != 1 || leases[0].IncidentID != "inc-3" || leases[0].AcquiredAt.IsZero() {
+		t.Errorf("Leases() = %+v, want the lease of inc-3", leases)
+	}
+
+	if err := store.Reset(ctx, repository); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if active, _ := store.Active(ctx, repository); active != 0 {
+		t.Errorf("expected no active workflows after Reset(), got %d", active)
+	}
+	if leases, _ := store.Leases(ctx, repository); len(leases) != 0 {
+		t.Errorf("expected no leases after Reset(), got %+v", leases)
+	}
+}
+
+func TestRedisClient_Lock(t *testing.T) {
+	ctx := context.Background()
+	client, err := ConnectRedis("localhost:6379", "", 0)
+	if err != nil {
+		t.Skipf("test redis not configured: %v", err)
+	}
+	defer client.Close()
+
+	key := "test:lock:" + time.Now().Format(time.RFC3339Nano)
+	defer client.Del(ctx, key)
+
+	unlock, err := client.Lock(ctx, key, time.Minute)
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if _, err := client.Lock(waitCtx, key, time.Minute); err == nil {
+		t.Fatal("expected a held lock to time out")
+	}
+
+	unlock()
+	unlockAgain, err := client.Lock(ctx, key, time.Minute)
+	if err != nil {
+		t.Fatalf("Lock() after unlock error = %v", err)
+	}
+	unlockAgain()
+}
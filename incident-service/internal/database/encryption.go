@@ -0,0 +1,242 @@
+package database
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// encryptedPrefix marks values encrypted by a Cipher. Values without it are
+// plaintext, written before encryption was enabled, and are read as they are.
+const encryptedPrefix = "enc:v1:"
+
+// Cipher encrypts incident columns that can hold sensitive context from the
+// monitored services, with AES-256-GCM. Values name the key they were
+// encrypted with, so that keys can be rotated: new values use the current key
+// and values written with a previous key stay readable.
+type Cipher struct {
+	current string                 // ID of the key used to encrypt
+	keys    map[string]cipher.AEAD // by key ID
+}
+
+// NewCipher creates a cipher that encrypts with key and decrypts with key or
+// any of the previous keys. Keys must be 32 bytes.
+func NewCipher(key []byte, previous ...[]byte) (*Cipher, error) {
+	c := &Cipher{keys: make(map[string]cipher.AEAD, len(previous)+1)}
+	for i, k := range append([][]byte{key}, previous...) {
+		if len(k) != 32 {
+			return nil, fmt.Errorf("encryption key must be 32 bytes, got %d", len(k))
+		}
+		block, err := aes.NewCipher(k)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cipher: %w", err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cipher: %w", err)
+		}
+		id := keyID(k)
+		if i == 0 {
+			c.current = id
+		}
+		c.keys[id] = aead
+	}
+	return c, nil
+}
+
+// NewCipherFromConfig creates the cipher of the encryption settings, or
+// returns nil when encryption is disabled
+func NewCipherFromConfig(cfg config.EncryptionConfig) (*Cipher, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	key, previous, err := cfg.Keys()
+	if err != nil {
+		return nil, err
+	}
+	return NewCipher(key, previous...)
+}
+
+// keyID identifies a key without revealing it
+func keyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:4])
+}
+
+// Encrypt encrypts plaintext with the current key
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	aead := c.keys[c.current]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedPrefix + c.current + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a value returned by Encrypt. Plaintext values are
+// returned unchanged.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !encrypted(value) {
+		return value, nil
+	}
+	if c == nil {
+		return "", fmt.Errorf("value is encrypted but no encryption key is configured")
+	}
+
+	id, data, ok := strings.Cut(strings.TrimPrefix(value, encryptedPrefix), ":")
+	if !ok {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	aead, ok := c.keys[id]
+	if !ok {
+		return "", fmt.Errorf("value is encrypted with unknown key %s", id)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value with key %s: %w", id, err)
+	}
+	return string(plaintext), nil
+}
+
+func encrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// sealText encrypts an optional text column. Without a cipher, values are
+// stored as they are.
+func (c *Cipher) sealText(value *string) (*string, error) {
+	if c == nil || value == nil {
+		return value, nil
+	}
+	sealed, err := c.Encrypt(*value)
+	if err != nil {
+		return nil, err
+	}
+	return &sealed, nil
+}
+
+// openText decrypts an optional text column read from the database
+func (c *Cipher) openText(value *string) (*string, error) {
+	if value == nil || !encrypted(*value) {
+		return value, nil
+	}
+	opened, err := c.Decrypt(*value)
+	if err != nil {
+		return nil, err
+	}
+	return &opened, nil
+}
+
+// sealJSON marshals a JSONB column. Encrypted, the column holds the
+// ciphertext as a JSON string, so that it stays valid JSONB.
+func (c *Cipher) sealJSON(value interface{}) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return c.sealRawJSON(data)
+}
+
+// sealRawJSON encrypts an already marshaled JSONB column like sealJSON
+func (c *Cipher) sealRawJSON(data []byte) ([]byte, error) {
+	if c == nil {
+		return data, nil
+	}
+	sealed, err := c.Encrypt(string(data))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(sealed)
+}
+
+// openJSON unmarshals a JSONB column written by sealJSON into out
+func (c *Cipher) openJSON(data []byte, out interface{}) error {
+	data, err := c.openRawJSON(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// openRawJSON returns the JSON of a JSONB column written by sealRawJSON
+func (c *Cipher) openRawJSON(data []byte) ([]byte, error) {
+	var sealed string
+	if len(data) == 0 || data[0] != '"' || json.Unmarshal(data, &sealed) != nil || !encrypted(sealed) {
+		return data, nil
+	}
+	opened, err := c.Decrypt(sealed)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(opened), nil
+}
+
+// sealedIncident holds the encrypted columns of an incident for writing
+type sealedIncident struct {
+	stackTrace   *string
+	diagnosis    *string
+	enrichment   *string
+	providerData []byte
+}
+
+// sealIncident encrypts the sensitive columns of incident for writing
+func (c *Cipher) sealIncident(incident *models.Incident) (*sealedIncident, error) {
+	providerData, err := c.sealJSON(incident.ProviderData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal provider data: %w", err)
+	}
+	stackTrace, err := c.sealText(incident.StackTrace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt stack trace: %w", err)
+	}
+	diagnosis, err := c.sealText(incident.Diagnosis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt diagnosis: %w", err)
+	}
+	enrichment, err := c.sealText(incident.Enrichment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt enrichment: %w", err)
+	}
+	return &sealedIncident{stackTrace: stackTrace, diagnosis: diagnosis, enrichment: enrichment, providerData: providerData}, nil
+}
+
+// openIncident decrypts the sensitive columns of an incident read from the
+// database, and unmarshals its provider data
+func (c *Cipher) openIncident(incident *models.Incident, providerData []byte) error {
+	if err := c.openJSON(providerData, &incident.ProviderData); err != nil {
+		return fmt.Errorf("failed to unmarshal provider data: %w", err)
+	}
+	stackTrace, err := c.openText(incident.StackTrace)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt stack trace: %w", err)
+	}
+	diagnosis, err := c.openText(incident.Diagnosis)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt diagnosis: %w", err)
+	}
+	enrichment, err := c.openText(incident.Enrichment)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt enrichment: %w", err)
+	}
+	incident.StackTrace = stackTrace
+	incident.Diagnosis = diagnosis
+	incident.Enrichment = enrichment
+	return nil
+}
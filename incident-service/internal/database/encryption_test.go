@@ -0,0 +1,152 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func TestCipher_SealOpenIncident(t *testing.T) {
+	c, err := NewCipher(testKey(1))
+	if err != nil {
+		t.Fatalf("NewCipher() error = %v", err)
+	}
+
+	stackTrace := "at db.Connect (pool.go:42)"
+	diagnosis := "connection pool exhausted"
+	incident := &models.Incident{
+		StackTrace:   &stackTrace,
+		Diagnosis:    &diagnosis,
+		ProviderData: map[string]interface{}{"monitor_id": "123", "tags": []interface{}{"env:prod"}},
+	}
+
+	sealed, err := c.sealIncident(incident)
+	if err != nil {
+		t.Fatalf("sealIncident() error = %v", err)
+	}
+	if !strings.HasPrefix(*sealed.stackTrace, encryptedPrefix) || !strings.HasPrefix(*sealed.diagnosis, encryptedPrefix) {
+		t.Errorf("expected encrypted text columns, got %q and %q", *sealed.stackTrace, *sealed.diagnosis)
+	}
+	if bytes.Contains(sealed.providerData, []byte("monitor_id")) || !json.Valid(sealed.providerData) {
+		t.Errorf("expected provider data encrypted as valid JSON, got %s", sealed.providerData)
+	}
+
+	read := &models.Incident{StackTrace: sealed.stackTrace, Diagnosis: sealed.diagnosis}
+	if err := c.openIncident(read, sealed.providerData); err != nil {
+		t.Fatalf("openIncident() error = %v", err)
+	}
+	if *read.StackTrace != stackTrace || *read.Diagnosis != diagnosis || !reflect.DeepEqual(read.ProviderData, incident.ProviderData) {
+		t.Errorf("round trip changed the incident: %+v", read)
+	}
+}
+
+func TestCipher_Plaintext(t *testing.T) {
+	c, err := NewCipher(testKey(1))
+	if err != nil {
+		t.Fatalf("NewCipher() error = %v", err)
+	}
+
+	// Rows written before encryption was enabled are read as they are, with
+	// or without a cipher
+	for _, cipher := range []*Cipher{c, nil} {
+		diagnosis := "plain diagnosis"
+		incident := &models.Incident{Diagnosis: &diagnosis}
+		if err := cipher.openIncident(incident, []byte(`{"monitor_id":"123"}`)); err != nil {
+			t.Fatalf("openIncident() error = %v", err)
+		}
+		if *incident.Diagnosis != diagnosis || incident.ProviderData["monitor_id"] != "123" {
+			t.Errorf("unexpected incident %+v", incident)
+		}
+	}
+
+	// Without a cipher, values are written as they are
+	var disabled *Cipher
+	data, err := disabled.sealJSON(map[string]interface{}{"monitor_id": "123"})
+	if err != nil || string(data) != `{"monitor_id":"123"}` {
+		t.Errorf("sealJSON() = %s, %v", data, err)
+	}
+}
+
+func TestCipher_SealOpenRawJSON(t *testing.T) {
+	c, err := NewCipher(testKey(1))
+	if err != nil {
+		t.Fatalf("NewCipher() error = %v", err)
+	}
+
+	// Notification messages carry the incident, stack trace included
+	message := []byte(`{"EventType":"incident_received","Incident":{"stack_trace":"at db.Connect (pool.go:42)"}}`)
+	sealed, err := c.sealRawJSON(message)
+	if err != nil {
+		t.Fatalf("sealRawJSON() error = %v", err)
+	}
+	if bytes.Contains(sealed, []byte("pool.go")) || !json.Valid(sealed) {
+		t.Errorf("expected the message encrypted as valid JSON, got %s", sealed)
+	}
+	opened, err := c.openRawJSON(sealed)
+	if err != nil || !bytes.Equal(opened, message) {
+		t.Errorf("openRawJSON() = %s, %v, want %s", opened, err, message)
+	}
+
+	// Messages written before encryption was enabled are read as they are
+	if opened, err := c.openRawJSON(message); err != nil || !bytes.Equal(opened, message) {
+		t.Errorf("openRawJSON() of plaintext = %s, %v", opened, err)
+	}
+}
+
+func TestCipher_KeyRotation(t *testing.T) {
+	old, err := NewCipher(testKey(1))
+	if err != nil {
+		t.Fatalf("NewCipher() error = %v", err)
+	}
+	value, err := old.Encrypt("secret")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	rotated, err := NewCipher(testKey(2), testKey(1))
+	if err != nil {
+		t.Fatalf("NewCipher() error = %v", err)
+	}
+	if got, err := rotated.Decrypt(value); err != nil || got != "secret" {
+		t.Errorf("Decrypt() with a previous key = %q, %v", got, err)
+	}
+	newValue, err := rotated.Encrypt("secret")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if _, err := old.Decrypt(newValue); err == nil || !strings.Contains(err.Error(), "unknown key") {
+		t.Errorf("expected the old cipher not to know the new key, got %v", err)
+	}
+
+	var disabled *Cipher
+	if _, err := disabled.Decrypt(value); err == nil {
+		t.Error("expected decrypting without a key to fail")
+	}
+}
+
+func TestCipher_Tampered(t *testing.T) {
+	c, err := NewCipher(testKey(1))
+	if err != nil {
+		t.Fatalf("NewCipher() error = %v", err)
+	}
+	value, err := c.Encrypt("secret")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	tampered := value[:len(value)-4] + "AAAA"
+	if _, err := c.Decrypt(tampered); err == nil {
+		t.Error("expected a tampered value to fail to decrypt")
+	}
+	if _, err := NewCipher([]byte("short")); err == nil {
+		t.Error("expected a short key to be rejected")
+	}
+}
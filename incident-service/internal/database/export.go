@@ -0,0 +1,177 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// ExportIncidents returns the incidents created since the given time, along
+// with the older incidents they reference as parent or duplicate, so that the
+// export can be imported on its own. Incidents come after the incidents they
+// reference, which is the order ImportIncident needs them in.
+func (r *IncidentRepository) ExportIncidents(since time.Time) (_ []*models.Incident, err error) {
+	ctx, span := r.startSpan("ExportIncidents")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		WITH RECURSIVE exported AS (
+			SELECT id, parent_id, duplicate_of
+			FROM incidents
+			WHERE created_at >= $1
+			UNION
+			SELECT i.id, i.parent_id, i.duplicate_of
+			FROM incidents i
+			JOIN exported e ON i.id = e.parent_id OR i.id = e.duplicate_of
+		)
+		SELECT
+			id, service_name, repository, error_message, stack_trace,
+			severity, status, provider, provider_data, workflow_run_id,
+			pull_request_url, diagnosis, created_at, updated_at,
+			triggered_at, completed_at, retry_count, next_retry_at,
+			fingerprint, occurrence_count, parent_id, deferred_until,
+			snoozed_until, team, duplicate_of, tenant_id, quota_exceeded,
+			enrichment, summary, severity_defaulted, suggested_severity,
+			provider_ref, affected_users, error_rate, downtime_minutes,
+			reopened_at
+		FROM incidents
+		WHERE id IN (SELECT id FROM exported)
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export incidents: %w", err)
+	}
+	defer rows.Close()
+
+	var incidents []*models.Incident
+	for rows.Next() {
+		var incident models.Incident
+		var providerDataJSON []byte
+
+		err := rows.Scan(
+			&incident.ID,
+			&incident.ServiceName,
+			&incident.Repository,
+			&incident.ErrorMessage,
+			&incident.StackTrace,
+			&incident.Severity,
+			&incident.Status,
+			&incident.Provider,
+			&providerDataJSON,
+			&incident.WorkflowRunID,
+			&incident.PullRequestURL,
+			&incident.Diagnosis,
+			&incident.CreatedAt,
+			&incident.UpdatedAt,
+			&incident.TriggeredAt,
+			&incident.CompletedAt,
+			&incident.RetryCount,
+			&incident.NextRetryAt,
+			&incident.Fingerprint,
+			&incident.OccurrenceCount,
+			&incident.ParentID,
+			&incident.DeferredUntil,
+			&incident.SnoozedUntil,
+			&incident.Team,
+			&incident.DuplicateOf,
+			&incident.TenantID,
+			&incident.QuotaExceeded,
+			&incident.Enrichment,
+			&incident.Summary,
+			&incident.SeverityDefaulted,
+			&incident.SuggestedSeverity,
+			&incident.ProviderRef,
+			&incident.AffectedUsers,
+			&incident.ErrorRate,
+			&incident.DowntimeMinutes,
+			&incident.ReopenedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incident: %w", err)
+		}
+
+		if err := r.db.cipher.openIncident(&incident, providerDataJSON); err != nil {
+			return nil, err
+		}
+
+		incidents = append(incidents, &incident)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating incidents: %w", err)
+	}
+
+	return ImportOrder(incidents), nil
+}
+
+// ImportOrder orders incidents so that every incident comes after the
+// incidents it references as parent or duplicate. References to incidents
+// that are not in the list are ignored; otherwise the given order is kept.
+func ImportOrder(incidents []*models.Incident) []*models.Incident {
+	byID := make(map[string]*models.Incident, len(incidents))
+	for _, incident := range incidents {
+		byID[incident.ID] = incident
+	}
+
+	ordered := make([]*models.Incident, 0, len(incidents))
+	visited := make(map[string]bool, len(incidents))
+	var visit func(incident *models.Incident)
+	visit = func(incident *models.Incident) {
+		if visited[incident.ID] {
+			return
+		}
+		// Marked before the references are visited, so a cycle ends here
+		visited[incident.ID] = true
+		for _, ref := range []*string{incident.ParentID, incident.DuplicateOf} {
+			if ref != nil {
+				if referenced, ok := byID[*ref]; ok {
+					visit(referenced)
+				}
+			}
+		}
+		ordered = append(ordered, incident)
+	}
+
+	for _, incident := range incidents {
+		visit(incident)
+	}
+
+	return ordered
+}
+
+// ListIncidentLinks returns the links between the given incidents, oldest first
+func (r *IncidentRepository) ListIncidentLinks(ids []string) (_ []models.IncidentLink, err error) {
+	ctx, span := r.startSpan("ListIncidentLinks")
+	defer func() { tracing.End(span, err) }()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT incident_id, related_id, reason, created_at
+		FROM incident_links
+		WHERE incident_id = ANY($1) AND related_id = ANY($1)
+		ORDER BY created_at, incident_id, related_id
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incident links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []models.IncidentLink
+	for rows.Next() {
+		var link models.IncidentLink
+		if err := rows.Scan(&link.IncidentID, &link.RelatedID, &link.Reason, &link.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan incident link: %w", err)
+		}
+		links = append(links, link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating incident links: %w", err)
+	}
+
+	return links, nil
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestImportOrder(t *testing.T) {
+	ref := func(id string) *string { return &id }
+	incidents := []*models.Incident{
+		{ID: "merged", DuplicateOf: ref("target")},
+		{ID: "child", ParentID: ref("parent")},
+		{ID: "parent"},
+		{ID: "target", ParentID: ref("elsewhere")},
+		{ID: "loop_a", ParentID: ref("loop_b")},
+		{ID: "loop_b", DuplicateOf: ref("loop_a")},
+	}
+
+	var ids []string
+	for _, incident := range ImportOrder(incidents) {
+		ids = append(ids, incident.ID)
+	}
+
+	want := []string{"target", "merged", "parent", "child", "loop_b", "loop_a"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("ImportOrder() = %v, want %v", ids, want)
+	}
+}
+
+func TestIncidentRepository_ExportIncidents(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+	if err := cleanupTestData(db.DB); err != nil {
+		t.Fatalf("failed to clean up test data: %v", err)
+	}
+
+	repo := NewIncidentRepository(db)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	newIncident := func(id string, createdAt time.Time) *models.Incident {
+		return &models.Incident{
+			ID:           id,
+			ServiceName:  "test-service",
+			ErrorMessage: "connection refused",
+			Severity:     "high",
+			Status:       models.StatusResolved,
+			Provider:     "sentry",
+			CreatedAt:    createdAt,
+			UpdatedAt:    createdAt,
+		}
+	}
+
+	// An old parent referenced by a recent child is exported along with it
+	parent := newIncident("inc_test_export_parent", now.Add(-30*24*time.Hour))
+	child := newIncident("inc_test_export_child", now.Add(-time.Hour))
+	child.ParentID = &parent.ID
+	unrelated := newIncident("inc_test_export_old", now.Add(-30*24*time.Hour))
+	for _, incident := range []*models.Incident{parent, child, unrelated} {
+		if _, err := repo.ImportIncident(incident, nil); err != nil {
+			t.Fatalf("ImportIncident() error = %v", err)
+		}
+	}
+	if _, err := repo.ImportIncidentLink(models.IncidentLink{IncidentID: child.ID, RelatedID: parent.ID, Reason: "same root cause", CreatedAt: now}); err != nil {
+		t.Fatalf("ImportIncidentLink() error = %v", err)
+	}
+
+	exported, err := repo.ExportIncidents(now.Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatalf("ExportIncidents() error = %v", err)
+	}
+	var ids []string
+	for _, incident := range exported {
+		ids = append(ids, incident.ID)
+	}
+	if want := []string{parent.ID, child.ID}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ExportIncidents() = %v, want %v", ids, want)
+	}
+
+	links, err := repo.ListIncidentLinks(ids)
+	if err != nil {
+		t.Fatalf("ListIncidentLinks() error = %v", err)
+	}
+	if len(links) != 1 || links[0].Reason != "same root cause" || !links[0].CreatedAt.Equal(now) {
+		t.Errorf("unexpected links %+v", links)
+	}
+}
@@ -0,0 +1,150 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// ImportIncident stores an incident and its timeline exactly as given,
+// timestamps included, for seeding and restoring data. Unlike Create, it
+// logs no events of its own. It reports whether the incident was imported;
+// an incident whose ID already exists is left untouched.
+func (r *IncidentRepository) ImportIncident(incident *models.Incident, events []*models.IncidentEvent) (_ bool, err error) {
+	ctx, span := r.startSpan("ImportIncident")
+	defer func() { tracing.End(span, err) }()
+
+	stored := *incident
+	if stored.ProviderData == nil {
+		stored.ProviderData = map[string]interface{}{}
+	}
+	sealed, err := r.db.cipher.sealIncident(&stored)
+	if err != nil {
+		return false, err
+	}
+	if incident.OccurrenceCount < 1 {
+		incident.OccurrenceCount = 1
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin import transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO incidents (
+			id, service_name, repository, error_message, stack_trace,
+			severity, status, provider, provider_data, workflow_run_id,
+			pull_request_url, diagnosis, created_at, updated_at,
+			triggered_at, completed_at, retry_count, next_retry_at,
+			fingerprint, occurrence_count, parent_id, deferred_until,
+			snoozed_until, team, duplicate_of, tenant_id, quota_exceeded,
+			enrichment, summary, severity_defaulted, suggested_severity,
+			provider_ref, affected_users, error_rate, downtime_minutes,
+			reopened_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13,
+			$14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29,
+			$30, $31, $32, $33, $34, $35, $36
+		)
+		ON CONFLICT (id) DO NOTHING
+	`,
+		incident.ID,
+		incident.ServiceName,
+		incident.Repository,
+		incident.ErrorMessage,
+		sealed.stackTrace,
+		incident.Severity,
+		incident.Status,
+		incident.Provider,
+		sealed.providerData,
+		incident.WorkflowRunID,
+		incident.PullRequestURL,
+		sealed.diagnosis,
+		incident.CreatedAt,
+		incident.UpdatedAt,
+		incident.TriggeredAt,
+		incident.CompletedAt,
+		incident.RetryCount,
+		incident.NextRetryAt,
+		incident.Fingerprint,
+		incident.OccurrenceCount,
+		incident.ParentID,
+		incident.DeferredUntil,
+		incident.SnoozedUntil,
+		incident.Team,
+		incident.DuplicateOf,
+		tenantOf(incident),
+		incident.QuotaExceeded,
+		sealed.enrichment,
+		incident.Summary,
+		incident.SeverityDefaulted,
+		incident.SuggestedSeverity,
+		incident.ProviderRef,
+		incident.AffectedUsers,
+		incident.ErrorRate,
+		incident.DowntimeMinutes,
+		incident.ReopenedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to import incident %s: %w", incident.ID, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	for _, event := range events {
+		eventDataJSON, err := json.Marshal(event.EventData)
+		if err != nil {
+			return false, fmt.Errorf("failed to marshal event data: %w", err)
+		}
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO incident_events (incident_id, event_type, event_data, created_at, tenant_id)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id
+		`, incident.ID, event.EventType, eventDataJSON, event.CreatedAt, tenantOf(incident)).Scan(&event.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to import event of incident %s: %w", incident.ID, err)
+		}
+		event.IncidentID = incident.ID
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit import: %w", err)
+	}
+
+	return true, nil
+}
+
+// ImportIncidentLink stores a link between two imported incidents, keeping
+// its creation time. It reports whether the link was imported; existing links
+// are left untouched.
+func (r *IncidentRepository) ImportIncidentLink(link models.IncidentLink) (_ bool, err error) {
+	ctx, span := r.startSpan("ImportIncidentLink")
+	defer func() { tracing.End(span, err) }()
+
+	incidentID, relatedID := models.LinkKey(link.IncidentID, link.RelatedID)
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO incident_links (incident_id, related_id, reason, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (incident_id, related_id) DO NOTHING
+	`, incidentID, relatedID, link.Reason, link.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to import link between %s and %s: %w", incidentID, relatedID, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestIncidentRepository_ImportIncident(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+	if err := cleanupTestData(db.DB); err != nil {
+		t.Fatalf("failed to clean up test data: %v", err)
+	}
+
+	repo := NewIncidentRepository(db)
+
+	createdAt := time.Now().Add(-72 * time.Hour).UTC().Truncate(time.Second)
+	completedAt := createdAt.Add(40 * time.Minute)
+	prURL := "https://github.com/org/api/pull/7"
+	incident := &models.Incident{
+		ID:             "inc_test_import",
+		ServiceName:    "test-service",
+		ErrorMessage:   "connection refused",
+		Severity:       "high",
+		Status:         models.StatusResolved,
+		Provider:       "sentry",
+		PullRequestURL: &prURL,
+		CreatedAt:      createdAt,
+		UpdatedAt:      completedAt,
+		CompletedAt:    &completedAt,
+	}
+	events := []*models.IncidentEvent{
+		{EventType: models.EventIncidentReceived, EventData: map[string]interface{}{}, CreatedAt: createdAt},
+		{EventType: models.EventIncidentResolved, EventData: map[string]interface{}{}, CreatedAt: completedAt},
+	}
+
+	imported, err := repo.ImportIncident(incident, events)
+	if err != nil || !imported {
+		t.Fatalf("ImportIncident() = %v, %v, want imported", imported, err)
+	}
+
+	stored, err := repo.GetByID("inc_test_import")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !stored.CreatedAt.Equal(createdAt) || stored.Status != models.StatusResolved {
+		t.Errorf("expected the incident as imported, got created_at %s and status %s", stored.CreatedAt, stored.Status)
+	}
+
+	// Only the imported events are on the timeline, with their own timestamps
+	timeline, err := repo.GetEventsByIncidentID("inc_test_import")
+	if err != nil {
+		t.Fatalf("GetEventsByIncidentID() error = %v", err)
+	}
+	if len(timeline) != 2 || !timeline[1].CreatedAt.Equal(completedAt) {
+		t.Errorf("unexpected timeline: %+v", timeline)
+	}
+
+	// Importing the same incident again leaves it untouched
+	if imported, err := repo.ImportIncident(incident, events); err != nil || imported {
+		t.Errorf("ImportIncident() = %v, %v, want the existing incident skipped", imported, err)
+	}
+}
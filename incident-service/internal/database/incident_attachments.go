@@ -0,0 +1,154 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// ErrAttachmentLimit is returned when an incident already has as many
+// attachments as it may
+var ErrAttachmentLimit = errors.New("attachment limit reached")
+
+// CreateIncidentAttachment records an attachment, unless its incident
+// already has limit attachments. The incident is locked meanwhile, so that
+// concurrent uploads cannot exceed the limit.
+func (r *IncidentRepository) CreateIncidentAttachment(attachment *models.IncidentAttachment, limit int) (err error) {
+	ctx, span := r.startSpan("CreateIncidentAttachment")
+	defer func() { tracing.End(span, err) }()
+
+	if attachment.CreatedAt.IsZero() {
+		attachment.CreatedAt = time.Now()
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin attachment transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var incidentID string
+	err = tx.QueryRowContext(ctx, `
+		SELECT id FROM incidents WHERE id = $1 FOR UPDATE
+	`, attachment.IncidentID).Scan(&incidentID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("incident not found: %s", attachment.IncidentID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock incident: %w", err)
+	}
+
+	var count int
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM incident_attachments WHERE incident_id = $1
+	`, attachment.IncidentID).Scan(&count); err != nil {
+		return fmt.Errorf("failed to count incident attachments: %w", err)
+	}
+	if count >= limit {
+		return ErrAttachmentLimit
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO incident_attachments (
+			id, incident_id, filename, content_type, size, sha256,
+			storage_key, uploaded_by, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, attachment.ID, attachment.IncidentID, attachment.Filename, attachment.ContentType, attachment.Size,
+		attachment.SHA256, attachment.StorageKey, attachment.UploadedBy, attachment.CreatedAt); err != nil {
+		return fmt.Errorf("failed to create incident attachment: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit incident attachment: %w", err)
+	}
+
+	return nil
+}
+
+// ListIncidentAttachments returns the attachments of an incident, oldest first
+func (r *IncidentRepository) ListIncidentAttachments(incidentID string) (_ []*models.IncidentAttachment, err error) {
+	ctx, span := r.startSpan("ListIncidentAttachments")
+	defer func() { tracing.End(span, err) }()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, incident_id, filename, content_type, size, sha256, storage_key, uploaded_by, created_at
+		FROM incident_attachments
+		WHERE incident_id = $1
+		ORDER BY created_at, id
+	`, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incident attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []*models.IncidentAttachment{}
+	for rows.Next() {
+		attachment, err := scanIncidentAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating incident attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// GetIncidentAttachment returns an attachment of an incident
+func (r *IncidentRepository) GetIncidentAttachment(incidentID, id string) (_ *models.IncidentAttachment, err error) {
+	ctx, span := r.startSpan("GetIncidentAttachment")
+	defer func() { tracing.End(span, err) }()
+
+	row := r.db.QueryRowContext(ctx, `
+		SELECT id, incident_id, filename, content_type, size, sha256, storage_key, uploaded_by, created_at
+		FROM incident_attachments
+		WHERE incident_id = $1 AND id = $2
+	`, incidentID, id)
+	attachment, err := scanIncidentAttachment(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("attachment not found: %s", id)
+	}
+	return attachment, err
+}
+
+// DeleteIncidentAttachment removes the record of an attachment. It reports
+// whether there was one.
+func (r *IncidentRepository) DeleteIncidentAttachment(incidentID, id string) (_ bool, err error) {
+	ctx, span := r.startSpan("DeleteIncidentAttachment")
+	defer func() { tracing.End(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM incident_attachments WHERE incident_id = $1 AND id = $2
+	`, incidentID, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete incident attachment: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// scanIncidentAttachment reads an attachment from a row
+func scanIncidentAttachment(row interface{ Scan(...interface{}) error }) (*models.IncidentAttachment, error) {
+	var attachment models.IncidentAttachment
+	err := row.Scan(&attachment.ID, &attachment.IncidentID, &attachment.Filename, &attachment.ContentType, &attachment.Size,
+		&attachment.SHA256, &attachment.StorageKey, &attachment.UploadedBy, &attachment.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan incident attachment: %w", err)
+	}
+	return &attachment, nil
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestIncidentRepository_IncidentAttachments(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+	if err := cleanupTestData(db.DB); err != nil {
+		t.Fatalf("failed to clean up test data: %v", err)
+	}
+
+	repo := NewIncidentRepository(db)
+
+	for _, id := range []string{"inc_test_attach_a", "inc_test_attach_b"} {
+		incident := &models.Incident{
+			ID:           id,
+			ServiceName:  "test-service",
+			ErrorMessage: "connection refused",
+			Severity:     "high",
+			Status:       models.StatusPending,
+			Provider:     "datadog",
+		}
+		if err := repo.Create(incident); err != nil {
+			t.Fatalf("failed to create incident: %v", err)
+		}
+	}
+
+	for _, id := range []string{"att_1", "att_2"} {
+		attachment := &models.IncidentAttachment{
+			ID:          id,
+			IncidentID:  "inc_test_attach_a",
+			Filename:    id + ".png",
+			ContentType: "image/png",
+			Size:        3,
+			SHA256:      "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			StorageKey:  "inc_test_attach_a/" + id,
+			UploadedBy:  "oncall",
+		}
+		if err := repo.CreateIncidentAttachment(attachment, 2); err != nil {
+			t.Fatalf("CreateIncidentAttachment() error = %v", err)
+		}
+	}
+	err := repo.CreateIncidentAttachment(&models.IncidentAttachment{ID: "att_3", IncidentID: "inc_test_attach_a", Filename: "att_3.png"}, 2)
+	if !errors.Is(err, ErrAttachmentLimit) {
+		t.Fatalf("expected ErrAttachmentLimit, got %v", err)
+	}
+
+	attachments, err := repo.ListIncidentAttachments("inc_test_attach_a")
+	if err != nil {
+		t.Fatalf("ListIncidentAttachments() error = %v", err)
+	}
+	if len(attachments) != 2 || attachments[0].ID != "att_1" || attachments[0].StorageKey != "inc_test_attach_a/att_1" {
+		t.Errorf("unexpected attachments: %+v", attachments)
+	}
+
+	if deleted, err := repo.DeleteIncidentAttachment("inc_test_attach_a", "att_2"); err != nil || !deleted {
+		t.Fatalf("DeleteIncidentAttachment() = %v, %v, want the attachment removed", deleted, err)
+	}
+	if _, err := repo.GetIncidentAttachment("inc_test_attach_a", "att_2"); err == nil {
+		t.Error("expected the deleted attachment to be gone")
+	}
+
+	// Merging moves the attachments to the target
+	if err := repo.MergeIncidents("inc_test_attach_a", "inc_test_attach_b"); err != nil {
+		t.Fatalf("MergeIncidents() error = %v", err)
+	}
+	attachment, err := repo.GetIncidentAttachment("inc_test_attach_b", "att_1")
+	if err != nil || attachment.Filename != "att_1.png" {
+		t.Errorf("expected att_1 on the merge target, got %+v, %v", attachment, err)
+	}
+}
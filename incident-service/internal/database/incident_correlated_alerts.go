@@ -0,0 +1,141 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// FindCorrelatedIncident finds the open incident of the service that an alert
+// from provider is most likely about: one reported by another provider within
+// the time window, preferring one with the same fingerprint and then the
+// earliest. It returns the incident's ID, or an empty string if there is none.
+func (r *IncidentRepository) FindCorrelatedIncident(tenantID, serviceName, provider, fingerprint string, timeWindow time.Duration) (_ string, err error) {
+	ctx, span := r.startSpan("FindCorrelatedIncident")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT id
+		FROM incidents
+		WHERE service_name = $1
+		  AND tenant_id = $2
+		  AND provider <> $3
+		  AND created_at > $4
+		  AND duplicate_of IS NULL
+		  AND status <> $5
+		ORDER BY fingerprint = $6 DESC, created_at ASC
+		LIMIT 1
+	`
+
+	var id string
+	err = r.db.QueryRowContext(ctx, query, serviceName, tenantID, provider, time.Now().Add(-timeWindow), models.StatusResolved, fingerprint).Scan(&id)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find correlated incident: %w", err)
+	}
+
+	return id, nil
+}
+
+// AttachCorrelatedAlert attaches an alert of another provider to the incident
+// it was correlated with
+func (r *IncidentRepository) AttachCorrelatedAlert(alert *models.CorrelatedAlert) (err error) {
+	ctx, span := r.startSpan("AttachCorrelatedAlert")
+	defer func() { tracing.End(span, err) }()
+
+	providerData, err := r.db.cipher.sealJSON(alert.ProviderData)
+	if err != nil {
+		return fmt.Errorf("failed to marshal provider data: %w", err)
+	}
+	stackTrace, err := r.db.cipher.sealText(alert.StackTrace)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt stack trace: %w", err)
+	}
+
+	query := `
+		INSERT INTO incident_correlated_alerts (
+			incident_id, provider, provider_ref, error_message, stack_trace,
+			severity, fingerprint, provider_data, received_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+
+	err = r.db.QueryRowContext(ctx,
+		query,
+		alert.IncidentID,
+		alert.Provider,
+		alert.ProviderRef,
+		alert.ErrorMessage,
+		stackTrace,
+		alert.Severity,
+		alert.Fingerprint,
+		providerData,
+		alert.ReceivedAt,
+	).Scan(&alert.ID)
+	if err != nil {
+		return fmt.Errorf("failed to attach correlated alert: %w", err)
+	}
+
+	return nil
+}
+
+// ListCorrelatedAlerts returns the alerts of other providers attached to an
+// incident, in the order they were received
+func (r *IncidentRepository) ListCorrelatedAlerts(incidentID string) (_ []*models.CorrelatedAlert, err error) {
+	ctx, span := r.startSpan("ListCorrelatedAlerts")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT id, incident_id, provider, provider_ref, error_message, stack_trace,
+			severity, fingerprint, provider_data, received_at
+		FROM incident_correlated_alerts
+		WHERE incident_id = $1
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list correlated alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []*models.CorrelatedAlert{}
+	for rows.Next() {
+		var alert models.CorrelatedAlert
+		var providerData []byte
+		if err := rows.Scan(
+			&alert.ID,
+			&alert.IncidentID,
+			&alert.Provider,
+			&alert.ProviderRef,
+			&alert.ErrorMessage,
+			&alert.StackTrace,
+			&alert.Severity,
+			&alert.Fingerprint,
+			&providerData,
+			&alert.ReceivedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan correlated alert: %w", err)
+		}
+		if providerData != nil {
+			if err := r.db.cipher.openJSON(providerData, &alert.ProviderData); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal provider data: %w", err)
+			}
+		}
+		if alert.StackTrace, err = r.db.cipher.openText(alert.StackTrace); err != nil {
+			return nil, fmt.Errorf("failed to decrypt stack trace: %w", err)
+		}
+		alerts = append(alerts, &alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating correlated alerts: %w", err)
+	}
+
+	return alerts, nil
+}
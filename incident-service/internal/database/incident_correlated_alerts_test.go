@@ -0,0 +1,87 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestIncidentRepository_CorrelatedAlerts(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+	if err := cleanupTestData(db.DB); err != nil {
+		t.Fatalf("failed to clean up test data: %v", err)
+	}
+
+	repo := NewIncidentRepository(db)
+
+	for _, incident := range []*models.Incident{
+		{ID: "inc_test_correlate_dd", Provider: "datadog", Fingerprint: "fp-latency"},
+		{ID: "inc_test_correlate_pd", Provider: "pagerduty", Fingerprint: "fp-panic"},
+		{ID: "inc_test_correlate_other", Provider: "datadog", Fingerprint: "fp-panic", ServiceName: "billing"},
+	} {
+		if incident.ServiceName == "" {
+			incident.ServiceName = "checkout"
+		}
+		incident.Repository = "org/checkout"
+		incident.ErrorMessage = "test error"
+		incident.Severity = "high"
+		incident.Status = models.StatusPending
+		if err := repo.Create(incident); err != nil {
+			t.Fatalf("failed to create incident: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		service     string
+		provider    string
+		fingerprint string
+		want        string
+	}{
+		{"earliest of another provider", "checkout", "sentry", "fp-other", "inc_test_correlate_dd"},
+		{"same fingerprint first", "checkout", "sentry", "fp-panic", "inc_test_correlate_pd"},
+		{"never the same provider", "checkout", "datadog", "fp-panic", "inc_test_correlate_pd"},
+		{"another service", "search", "sentry", "fp-panic", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.FindCorrelatedIncident("", tt.service, tt.provider, tt.fingerprint, time.Minute)
+			if err != nil {
+				t.Fatalf("FindCorrelatedIncident() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FindCorrelatedIncident() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	stackTrace := "panic: nil map\n\tcheckout/cart.go:42"
+	alert := models.NewCorrelatedAlert("inc_test_correlate_dd", &models.Incident{
+		Provider:     "sentry",
+		ProviderRef:  "4711",
+		ErrorMessage: "nil map write",
+		StackTrace:   &stackTrace,
+		Severity:     "critical",
+		ProviderData: map[string]interface{}{"level": "fatal"},
+	})
+	if err := repo.AttachCorrelatedAlert(alert); err != nil {
+		t.Fatalf("AttachCorrelatedAlert() error = %v", err)
+	}
+
+	alerts, err := repo.ListCorrelatedAlerts("inc_test_correlate_dd")
+	if err != nil {
+		t.Fatalf("ListCorrelatedAlerts() error = %v", err)
+	}
+	if len(alerts) != 1 {
+		t.Fatalf("expected 1 correlated alert, got %d", len(alerts))
+	}
+	got := alerts[0]
+	if got.Provider != "sentry" || got.ProviderRef != "4711" || got.StackTrace == nil || *got.StackTrace != stackTrace || got.ProviderData["level"] != "fatal" {
+		t.Errorf("unexpected correlated alert: %+v", got)
+	}
+}
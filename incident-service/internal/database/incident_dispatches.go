@@ -0,0 +1,111 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// SaveDispatch creates or replaces the dispatch record of an incident in a
+// repository. There is one record per incident and repository, so a retried
+// remediation overwrites the outcome of the previous attempt.
+func (r *IncidentRepository) SaveDispatch(dispatch *models.IncidentDispatch) (err error) {
+	ctx, span := r.startSpan("SaveDispatch")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		INSERT INTO incident_dispatches (
+			incident_id, repository, branch, status, pull_request_url, diagnosis,
+			error, created_at, updated_at, triggered_at, completed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8, $9, $10)
+		ON CONFLICT (incident_id, repository) DO UPDATE SET
+			branch = EXCLUDED.branch,
+			status = EXCLUDED.status,
+			pull_request_url = EXCLUDED.pull_request_url,
+			diagnosis = EXCLUDED.diagnosis,
+			error = EXCLUDED.error,
+			updated_at = EXCLUDED.updated_at,
+			triggered_at = EXCLUDED.triggered_at,
+			completed_at = EXCLUDED.completed_at
+		RETURNING id, created_at
+	`
+
+	diagnosis, err := r.db.cipher.sealText(dispatch.Diagnosis)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt diagnosis: %w", err)
+	}
+
+	dispatch.UpdatedAt = time.Now()
+	err = r.db.QueryRowContext(ctx,
+		query,
+		dispatch.IncidentID,
+		dispatch.Repository,
+		dispatch.Branch,
+		dispatch.Status,
+		dispatch.PullRequestURL,
+		diagnosis,
+		dispatch.Error,
+		dispatch.UpdatedAt,
+		dispatch.TriggeredAt,
+		dispatch.CompletedAt,
+	).Scan(&dispatch.ID, &dispatch.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save dispatch: %w", err)
+	}
+
+	return nil
+}
+
+// ListDispatches returns the dispatch records of an incident in the order
+// they were first created
+func (r *IncidentRepository) ListDispatches(incidentID string) (_ []*models.IncidentDispatch, err error) {
+	ctx, span := r.startSpan("ListDispatches")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT id, incident_id, repository, branch, status, pull_request_url, diagnosis,
+			error, created_at, updated_at, triggered_at, completed_at
+		FROM incident_dispatches
+		WHERE incident_id = $1
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dispatches: %w", err)
+	}
+	defer rows.Close()
+
+	dispatches := []*models.IncidentDispatch{}
+	for rows.Next() {
+		var dispatch models.IncidentDispatch
+		if err := rows.Scan(
+			&dispatch.ID,
+			&dispatch.IncidentID,
+			&dispatch.Repository,
+			&dispatch.Branch,
+			&dispatch.Status,
+			&dispatch.PullRequestURL,
+			&dispatch.Diagnosis,
+			&dispatch.Error,
+			&dispatch.CreatedAt,
+			&dispatch.UpdatedAt,
+			&dispatch.TriggeredAt,
+			&dispatch.CompletedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan dispatch: %w", err)
+		}
+		if dispatch.Diagnosis, err = r.db.cipher.openText(dispatch.Diagnosis); err != nil {
+			return nil, fmt.Errorf("failed to decrypt diagnosis: %w", err)
+		}
+		dispatches = append(dispatches, &dispatch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating dispatches: %w", err)
+	}
+
+	return dispatches, nil
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestIncidentRepository_Dispatches(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+	if err := cleanupTestData(db.DB); err != nil {
+		t.Fatalf("failed to clean up test data: %v", err)
+	}
+
+	repo := NewIncidentRepository(db)
+
+	incident := &models.Incident{
+		ID:           "inc_test_dispatch",
+		ServiceName:  "checkout",
+		Repository:   "org/checkout-api",
+		ErrorMessage: "test error",
+		Severity:     "high",
+		Status:       models.StatusPending,
+		Provider:     "datadog",
+	}
+	if err := repo.Create(incident); err != nil {
+		t.Fatalf("failed to create incident: %v", err)
+	}
+
+	for _, repository := range []string{"org/checkout-api", "org/checkout-infra"} {
+		dispatch := &models.IncidentDispatch{
+			IncidentID: incident.ID,
+			Repository: repository,
+			Branch:     "main",
+			Status:     models.StatusWorkflowTriggered,
+		}
+		if err := repo.SaveDispatch(dispatch); err != nil {
+			t.Fatalf("SaveDispatch() error = %v", err)
+		}
+	}
+
+	// Saving again replaces the repository's record
+	prURL := "https://github.com/org/checkout-infra/pull/3"
+	if err := repo.SaveDispatch(&models.IncidentDispatch{
+		IncidentID:     incident.ID,
+		Repository:     "org/checkout-infra",
+		Branch:         "main",
+		Status:         models.StatusPRCreated,
+		PullRequestURL: &prURL,
+	}); err != nil {
+		t.Fatalf("SaveDispatch() error = %v", err)
+	}
+
+	dispatches, err := repo.ListDispatches(incident.ID)
+	if err != nil {
+		t.Fatalf("ListDispatches() error = %v", err)
+	}
+	if len(dispatches) != 2 {
+		t.Fatalf("expected 2 dispatches, got %d", len(dispatches))
+	}
+	if dispatches[0].Repository != "org/checkout-api" || dispatches[0].Status != models.StatusWorkflowTriggered {
+		t.Errorf("unexpected first dispatch: %+v", dispatches[0])
+	}
+	if dispatches[1].Status != models.StatusPRCreated || dispatches[1].PullRequestURL == nil || *dispatches[1].PullRequestURL != prURL {
+		t.Errorf("unexpected second dispatch: %+v", dispatches[1])
+	}
+	if status := models.DispatchStatus(dispatches); status != models.StatusWorkflowTriggered {
+		t.Errorf("expected the incident to still be triggered, got %s", status)
+	}
+}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// SetEmbedding stores the embedding of an incident's error message,
+// replacing any embedding it had
+func (r *IncidentRepository) SetEmbedding(id, model string, vector []float64) (err error) {
+	ctx, span := r.startSpan("SetEmbedding")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		INSERT INTO incident_embeddings (incident_id, model, embedding, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (incident_id) DO UPDATE
+		SET model = EXCLUDED.model, embedding = EXCLUDED.embedding, created_at = EXCLUDED.created_at
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, id, model, pq.Array(vector), time.Now()); err != nil {
+		return fmt.Errorf("failed to store embedding: %w", err)
+	}
+	return nil
+}
+
+// GetEmbedding returns the embedding of an incident made by model, or nil
+// when it has none
+func (r *IncidentRepository) GetEmbedding(id, model string) (_ []float64, err error) {
+	ctx, span := r.startSpan("GetEmbedding")
+	defer func() { tracing.End(span, err) }()
+
+	var vector []float64
+	err = r.db.QueryRowContext(ctx, `
+		SELECT embedding FROM incident_embeddings
+		WHERE incident_id = $1 AND model = $2
+	`, id, model).Scan(pq.Array(&vector))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get embedding: %w", err)
+	}
+	return vector, nil
+}
+
+// EmbeddingFilter selects the incidents whose embeddings are compared
+type EmbeddingFilter struct {
+	TenantID string
+	Model    string
+	Since    time.Time
+	Exclude  string // the incident looked up
+	Limit    int
+}
+
+// ListEmbeddings returns the embeddings made by the filter's model of the
+// tenant's incidents created since the given time, most recent first.
+// Incidents merged into another are left out.
+func (r *IncidentRepository) ListEmbeddings(filter EmbeddingFilter) (_ []models.IncidentEmbedding, err error) {
+	ctx, span := r.startSpan("ListEmbeddings")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT e.incident_id, e.embedding
+		FROM incident_embeddings e
+		JOIN incidents i ON i.id = e.incident_id
+		WHERE e.model = $1 AND i.tenant_id = $2 AND i.created_at >= $3
+		  AND i.id <> $4 AND i.duplicate_of IS NULL
+		ORDER BY i.created_at DESC
+		LIMIT $5
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, filter.Model, filter.TenantID, filter.Since, filter.Exclude, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list embeddings: %w", err)
+	}
+	defer rows.Close()
+
+	var embeddings []models.IncidentEmbedding
+	for rows.Next() {
+		var embedding models.IncidentEmbedding
+		if err := rows.Scan(&embedding.IncidentID, pq.Array(&embedding.Vector)); err != nil {
+			return nil, fmt.Errorf("failed to scan embedding: %w", err)
+		}
+		embeddings = append(embeddings, embedding)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating embeddings: %w", err)
+	}
+
+	return embeddings, nil
+}
@@ -0,0 +1,76 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestIncidentRepository_Embeddings(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+	if err := cleanupTestData(db.DB); err != nil {
+		t.Fatalf("failed to clean up test data: %v", err)
+	}
+
+	repo := NewIncidentRepository(db)
+
+	for _, id := range []string{"inc_test_embed_a", "inc_test_embed_b", "inc_test_embed_c"} {
+		incident := &models.Incident{
+			ID:           id,
+			ServiceName:  "test-service",
+			ErrorMessage: "connection refused",
+			Severity:     "high",
+			Status:       models.StatusPending,
+			Provider:     "datadog",
+		}
+		if err := repo.Create(incident); err != nil {
+			t.Fatalf("failed to create incident: %v", err)
+		}
+	}
+
+	if vector, err := repo.GetEmbedding("inc_test_embed_a", "test-model"); err != nil || vector != nil {
+		t.Fatalf("GetEmbedding() = %v, %v, want no embedding", vector, err)
+	}
+
+	if err := repo.SetEmbedding("inc_test_embed_a", "test-model", []float64{1, 0}); err != nil {
+		t.Fatalf("SetEmbedding() error = %v", err)
+	}
+	// Storing again replaces the embedding
+	if err := repo.SetEmbedding("inc_test_embed_a", "test-model", []float64{0.5, 0.5}); err != nil {
+		t.Fatalf("SetEmbedding() error = %v", err)
+	}
+	if err := repo.SetEmbedding("inc_test_embed_b", "test-model", []float64{0, 1}); err != nil {
+		t.Fatalf("SetEmbedding() error = %v", err)
+	}
+	if err := repo.SetEmbedding("inc_test_embed_c", "other-model", []float64{1, 1}); err != nil {
+		t.Fatalf("SetEmbedding() error = %v", err)
+	}
+
+	vector, err := repo.GetEmbedding("inc_test_embed_a", "test-model")
+	if err != nil || len(vector) != 2 || vector[0] != 0.5 {
+		t.Fatalf("GetEmbedding() = %v, %v, want the replaced embedding", vector, err)
+	}
+	if vector, err := repo.GetEmbedding("inc_test_embed_c", "test-model"); err != nil || vector != nil {
+		t.Errorf("expected embeddings of other models to be ignored, got %v, %v", vector, err)
+	}
+
+	embeddings, err := repo.ListEmbeddings(EmbeddingFilter{
+		TenantID: config.DefaultTenant,
+		Model:    "test-model",
+		Since:    time.Now().Add(-time.Hour),
+		Exclude:  "inc_test_embed_a",
+		Limit:    10,
+	})
+	if err != nil {
+		t.Fatalf("ListEmbeddings() error = %v", err)
+	}
+	if len(embeddings) != 1 || embeddings[0].IncidentID != "inc_test_embed_b" {
+		t.Errorf("expected only the other incident of the model, got %+v", embeddings)
+	}
+}
@@ -0,0 +1,105 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// LinkIncidents records two incidents as related. It reports whether a new
+// link was created; linking incidents that are already related is a no-op.
+func (r *IncidentRepository) LinkIncidents(a, b, reason string) (_ bool, err error) {
+	ctx, span := r.startSpan("LinkIncidents")
+	defer func() { tracing.End(span, err) }()
+
+	incidentID, relatedID := models.LinkKey(a, b)
+
+	query := `
+		INSERT INTO incident_links (incident_id, related_id, reason, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (incident_id, related_id) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, incidentID, relatedID, reason, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("failed to link incidents: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// UnlinkIncidents removes the link between two incidents. It reports whether
+// the incidents were linked.
+func (r *IncidentRepository) UnlinkIncidents(a, b string) (_ bool, err error) {
+	ctx, span := r.startSpan("UnlinkIncidents")
+	defer func() { tracing.End(span, err) }()
+
+	incidentID, relatedID := models.LinkKey(a, b)
+
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM incident_links
+		WHERE incident_id = $1 AND related_id = $2
+	`, incidentID, relatedID)
+	if err != nil {
+		return false, fmt.Errorf("failed to unlink incidents: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// ListRelatedIncidents returns the incidents linked to the incident, most
+// recently linked first
+func (r *IncidentRepository) ListRelatedIncidents(id string) (_ []models.RelatedIncident, err error) {
+	ctx, span := r.startSpan("ListRelatedIncidents")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT i.id, i.service_name, i.status, i.severity, i.team, i.tenant_id, l.reason, l.created_at
+		FROM incident_links l
+		JOIN incidents i ON i.id = CASE WHEN l.incident_id = $1 THEN l.related_id ELSE l.incident_id END
+		WHERE l.incident_id = $1 OR l.related_id = $1
+		ORDER BY l.created_at DESC, i.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list related incidents: %w", err)
+	}
+	defer rows.Close()
+
+	related := []models.RelatedIncident{}
+	for rows.Next() {
+		var incident models.RelatedIncident
+		if err := rows.Scan(
+			&incident.IncidentID,
+			&incident.ServiceName,
+			&incident.Status,
+			&incident.Severity,
+			&incident.Team,
+			&incident.TenantID,
+			&incident.Reason,
+			&incident.LinkedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan related incident: %w", err)
+		}
+		related = append(related, incident)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating related incidents: %w", err)
+	}
+
+	return related, nil
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestIncidentRepository_IncidentLinks(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+	if err := cleanupTestData(db.DB); err != nil {
+		t.Fatalf("failed to clean up test data: %v", err)
+	}
+
+	repo := NewIncidentRepository(db)
+
+	for _, id := range []string{"inc_test_link_a", "inc_test_link_b", "inc_test_link_c"} {
+		incident := &models.Incident{
+			ID:           id,
+			ServiceName:  "test-service",
+			ErrorMessage: "connection refused",
+			Severity:     "high",
+			Status:       models.StatusPending,
+			Provider:     "datadog",
+		}
+		if err := repo.Create(incident); err != nil {
+			t.Fatalf("failed to create incident: %v", err)
+		}
+	}
+
+	// Links are symmetric, so linking in the other direction is a no-op
+	if linked, err := repo.LinkIncidents("inc_test_link_b", "inc_test_link_a", "same database outage"); err != nil || !linked {
+		t.Fatalf("LinkIncidents() = %v, %v, want a new link", linked, err)
+	}
+	if linked, err := repo.LinkIncidents("inc_test_link_a", "inc_test_link_b", ""); err != nil || linked {
+		t.Fatalf("LinkIncidents() = %v, %v, want no new link", linked, err)
+	}
+	if _, err := repo.LinkIncidents("inc_test_link_a", "inc_test_link_c", ""); err != nil {
+		t.Fatalf("LinkIncidents() error = %v", err)
+	}
+
+	related, err := repo.ListRelatedIncidents("inc_test_link_a")
+	if err != nil {
+		t.Fatalf("ListRelatedIncidents() error = %v", err)
+	}
+	if len(related) != 2 {
+		t.Fatalf("expected 2 related incidents, got %+v", related)
+	}
+
+	related, err = repo.ListRelatedIncidents("inc_test_link_b")
+	if err != nil {
+		t.Fatalf("ListRelatedIncidents() error = %v", err)
+	}
+	if len(related) != 1 || related[0].IncidentID != "inc_test_link_a" || related[0].Reason != "same database outage" {
+		t.Errorf("unexpected related incidents: %+v", related)
+	}
+
+	if unlinked, err := repo.UnlinkIncidents("inc_test_link_a", "inc_test_link_b"); err != nil || !unlinked {
+		t.Fatalf("UnlinkIncidents() = %v, %v, want the link removed", unlinked, err)
+	}
+	if unlinked, err := repo.UnlinkIncidents("inc_test_link_b", "inc_test_link_a"); err != nil || unlinked {
+		t.Fatalf("UnlinkIncidents() = %v, %v, want nothing to remove", unlinked, err)
+	}
+	related, err = repo.ListRelatedIncidents("inc_test_link_b")
+	if err != nil || len(related) != 0 {
+		t.Errorf("expected no related incidents after unlinking, got %+v, %v", related, err)
+	}
+}
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// MergeIncidents folds the source incident into the target. In one
+// transaction, the source's events move to the target's timeline, its
+// provider data is kept on the target under merged_incidents.<source ID>, its
+// occurrences are added to the target's, and incidents grouped under it are
+// regrouped under the target. Its watchers start watching the target, and
+// its attachments move to it. The source is then closed as no_fix_needed and
+// marked as a duplicate of the target. Neither incident may already be a
+// duplicate.
+func (r *IncidentRepository) MergeIncidents(sourceID, targetID string) (err error) {
+	ctx, span := r.startSpan("MergeIncidents")
+	defer func() { tracing.End(span, err) }()
+
+	if sourceID == targetID {
+		return fmt.Errorf("cannot merge incident %s into itself", sourceID)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin merge transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now()
+	message := fmt.Sprintf("Merged into incident %s", targetID)
+	diagnosis, err := r.db.cipher.sealText(&message)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt diagnosis: %w", err)
+	}
+
+	var provider string
+	var providerDataJSON []byte
+	var occurrences int
+	err = tx.QueryRowContext(ctx, `
+		UPDATE incidents
+		SET status = $3, duplicate_of = $2, diagnosis = COALESCE(diagnosis, $4),
+		    completed_at = COALESCE(completed_at, $5), next_retry_at = NULL,
+		    deferred_until = NULL, updated_at = $5
+		WHERE id = $1 AND duplicate_of IS NULL
+		RETURNING provider, provider_data, occurrence_count
+	`, sourceID, targetID, models.StatusNoFixNeeded, diagnosis, now).Scan(&provider, &providerDataJSON, &occurrences)
+	if err != nil {
+		return fmt.Errorf("failed to mark incident %s as duplicate: %w", sourceID, err)
+	}
+	var sourceData map[string]interface{}
+	if err := r.db.cipher.openJSON(providerDataJSON, &sourceData); err != nil {
+		return fmt.Errorf("failed to read provider data of incident %s: %w", sourceID, err)
+	}
+
+	// The provider data is merged here rather than with JSONB operators, as
+	// it may be encrypted
+	var targetDataJSON []byte
+	err = tx.QueryRowContext(ctx, `
+		SELECT provider_data FROM incidents
+		WHERE id = $1 AND duplicate_of IS NULL
+		FOR UPDATE
+	`, targetID).Scan(&targetDataJSON)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("incident not found or already merged: %s", targetID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to merge into incident %s: %w", targetID, err)
+	}
+	var targetData map[string]interface{}
+	if err := r.db.cipher.openJSON(targetDataJSON, &targetData); err != nil {
+		return fmt.Errorf("failed to read provider data of incident %s: %w", targetID, err)
+	}
+	if targetData == nil {
+		targetData = map[string]interface{}{}
+	}
+	merged, _ := targetData["merged_incidents"].(map[string]interface{})
+	if merged == nil {
+		merged = map[string]interface{}{}
+	}
+	merged[sourceID] = map[string]interface{}{"provider": provider, "provider_data": sourceData}
+	targetData["merged_incidents"] = merged
+
+	targetDataJSON, err = r.db.cipher.sealJSON(targetData)
+	if err != nil {
+		return fmt.Errorf("failed to marshal provider data: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE incidents
+		SET occurrence_count = occurrence_count + $2, provider_data = $3, updated_at = $4
+		WHERE id = $1
+	`, targetID, occurrences, targetDataJSON, now); err != nil {
+		return fmt.Errorf("failed to merge into incident %s: %w", targetID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE incident_events SET incident_id = $2 WHERE incident_id = $1
+	`, sourceID, targetID); err != nil {
+		return fmt.Errorf("failed to move incident events: %w", err)
+	}
+
+	// Watchers of the source keep following the outage on the target
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO incident_watchers (incident_id, email, slack_user, created_at)
+		SELECT $2, email, slack_user, created_at FROM incident_watchers WHERE incident_id = $1
+		ON CONFLICT (incident_id, email, slack_user) DO NOTHING
+	`, sourceID, targetID); err != nil {
+		return fmt.Errorf("failed to move incident watchers: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM incident_watchers WHERE incident_id = $1
+	`, sourceID); err != nil {
+		return fmt.Errorf("failed to move incident watchers: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE incident_attachments SET incident_id = $2 WHERE incident_id = $1
+	`, sourceID, targetID); err != nil {
+		return fmt.Errorf("failed to move incident attachments: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE incidents SET parent_id = $2, updated_at = $3
+		WHERE parent_id = $1 AND id <> $2
+	`, sourceID, targetID, now); err != nil {
+		return fmt.Errorf("failed to regroup incidents: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit merge: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestIncidentRepository_MergeIncidents(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+	if err := cleanupTestData(db.DB); err != nil {
+		t.Fatalf("failed to clean up test data: %v", err)
+	}
+
+	repo := NewIncidentRepository(db)
+
+	for _, incident := range []*models.Incident{
+		{ID: "inc_test_merge_target", Provider: "datadog", ProviderData: map[string]interface{}{"monitor_id": "123"}},
+		{ID: "inc_test_merge_source", Provider: "grafana", ProviderData: map[string]interface{}{"rule_uid": "abc"}, OccurrenceCount: 3},
+	} {
+		incident.ServiceName = "test-service"
+		incident.ErrorMessage = "database connection refused"
+		incident.Severity = "high"
+		incident.Status = models.StatusPending
+		if err := repo.Create(incident); err != nil {
+			t.Fatalf("failed to create incident: %v", err)
+		}
+		if err := repo.LogEvent(&models.IncidentEvent{
+			IncidentID: incident.ID,
+			EventType:  models.EventIncidentReceived,
+			EventData:  map[string]interface{}{},
+		}); err != nil {
+			t.Fatalf("failed to log event: %v", err)
+		}
+	}
+
+	if err := repo.MergeIncidents("inc_test_merge_source", "inc_test_merge_target"); err != nil {
+		t.Fatalf("MergeIncidents() error = %v", err)
+	}
+
+	source, err := repo.GetByID("inc_test_merge_source")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if source.DuplicateOf == nil || *source.DuplicateOf != "inc_test_merge_target" {
+		t.Errorf("expected the source to be a duplicate of the target, got %v", source.DuplicateOf)
+	}
+	if source.Status != models.StatusNoFixNeeded || source.CompletedAt == nil {
+		t.Errorf("expected the source to be closed, got status %s", source.Status)
+	}
+
+	target, err := repo.GetByID("inc_test_merge_target")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if target.OccurrenceCount != 4 {
+		t.Errorf("expected 4 occurrences on the target, got %d", target.OccurrenceCount)
+	}
+	merged, _ := target.ProviderData["merged_incidents"].(map[string]interface{})
+	entry, _ := merged["inc_test_merge_source"].(map[string]interface{})
+	if entry["provider"] != "grafana" || target.ProviderData["monitor_id"] != "123" {
+		t.Errorf("expected the source's provider data on the target, got %+v", target.ProviderData)
+	}
+
+	events, err := repo.GetEventsByIncidentID("inc_test_merge_target")
+	if err != nil {
+		t.Fatalf("GetEventsByIncidentID() error = %v", err)
+	}
+	if len(events) != 2 {
+		t.Errorf("expected both timelines on the target, got %d events", len(events))
+	}
+
+	// A duplicate can be neither merged again nor merged into
+	if err := repo.MergeIncidents("inc_test_merge_source", "inc_test_merge_target"); err == nil {
+		t.Error("expected merging a duplicate again to fail")
+	}
+	if err := repo.MergeIncidents("inc_test_merge_target", "inc_test_merge_source"); err == nil {
+		t.Error("expected merging into a duplicate to fail")
+	}
+}
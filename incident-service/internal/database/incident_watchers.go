@@ -0,0 +1,90 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// WatchIncident subscribes a watcher to an incident. It reports whether the
+// watcher is new; watching an incident again is a no-op.
+func (r *IncidentRepository) WatchIncident(watcher *models.IncidentWatcher) (_ bool, err error) {
+	ctx, span := r.startSpan("WatchIncident")
+	defer func() { tracing.End(span, err) }()
+
+	if watcher.CreatedAt.IsZero() {
+		watcher.CreatedAt = time.Now()
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO incident_watchers (incident_id, email, slack_user, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (incident_id, email, slack_user) DO NOTHING
+	`, watcher.IncidentID, watcher.Email, watcher.SlackUser, watcher.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to watch incident: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// UnwatchIncident removes a watcher from an incident. It reports whether the
+// watcher was subscribed.
+func (r *IncidentRepository) UnwatchIncident(watcher *models.IncidentWatcher) (_ bool, err error) {
+	ctx, span := r.startSpan("UnwatchIncident")
+	defer func() { tracing.End(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM incident_watchers
+		WHERE incident_id = $1 AND email = $2 AND slack_user = $3
+	`, watcher.IncidentID, watcher.Email, watcher.SlackUser)
+	if err != nil {
+		return false, fmt.Errorf("failed to unwatch incident: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// ListIncidentWatchers returns the watchers of an incident, oldest first
+func (r *IncidentRepository) ListIncidentWatchers(incidentID string) (_ []*models.IncidentWatcher, err error) {
+	ctx, span := r.startSpan("ListIncidentWatchers")
+	defer func() { tracing.End(span, err) }()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT incident_id, email, slack_user, created_at
+		FROM incident_watchers
+		WHERE incident_id = $1
+		ORDER BY created_at, email, slack_user
+	`, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incident watchers: %w", err)
+	}
+	defer rows.Close()
+
+	watchers := []*models.IncidentWatcher{}
+	for rows.Next() {
+		var watcher models.IncidentWatcher
+		if err := rows.Scan(&watcher.IncidentID, &watcher.Email, &watcher.SlackUser, &watcher.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan incident watcher: %w", err)
+		}
+		watchers = append(watchers, &watcher)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating incident watchers: %w", err)
+	}
+
+	return watchers, nil
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestIncidentRepository_IncidentWatchers(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+	if err := cleanupTestData(db.DB); err != nil {
+		t.Fatalf("failed to clean up test data: %v", err)
+	}
+
+	repo := NewIncidentRepository(db)
+
+	for _, id := range []string{"inc_test_watch_a", "inc_test_watch_b"} {
+		incident := &models.Incident{
+			ID:           id,
+			ServiceName:  "test-service",
+			ErrorMessage: "connection refused",
+			Severity:     "high",
+			Status:       models.StatusPending,
+			Provider:     "datadog",
+		}
+		if err := repo.Create(incident); err != nil {
+			t.Fatalf("failed to create incident: %v", err)
+		}
+	}
+
+	alice := &models.IncidentWatcher{IncidentID: "inc_test_watch_a", Email: "alice@example.com"}
+	if watched, err := repo.WatchIncident(alice); err != nil || !watched {
+		t.Fatalf("WatchIncident() = %v, %v, want a new watcher", watched, err)
+	}
+	if watched, err := repo.WatchIncident(&models.IncidentWatcher{IncidentID: "inc_test_watch_a", Email: "alice@example.com"}); err != nil || watched {
+		t.Fatalf("WatchIncident() = %v, %v, want no new watcher", watched, err)
+	}
+	bob := &models.IncidentWatcher{IncidentID: "inc_test_watch_a", SlackUser: "U024BE7LH"}
+	if _, err := repo.WatchIncident(bob); err != nil {
+		t.Fatalf("WatchIncident() error = %v", err)
+	}
+
+	watchers, err := repo.ListIncidentWatchers("inc_test_watch_a")
+	if err != nil {
+		t.Fatalf("ListIncidentWatchers() error = %v", err)
+	}
+	if len(watchers) != 2 || watchers[0].Email != "alice@example.com" || watchers[1].SlackUser != "U024BE7LH" {
+		t.Errorf("unexpected watchers: %+v", watchers)
+	}
+
+	if unwatched, err := repo.UnwatchIncident(bob); err != nil || !unwatched {
+		t.Fatalf("UnwatchIncident() = %v, %v, want the watcher removed", unwatched, err)
+	}
+	if unwatched, err := repo.UnwatchIncident(bob); err != nil || unwatched {
+		t.Fatalf("UnwatchIncident() = %v, %v, want nothing to remove", unwatched, err)
+	}
+
+	// Merging moves the watchers to the target
+	if err := repo.MergeIncidents("inc_test_watch_a", "inc_test_watch_b"); err != nil {
+		t.Fatalf("MergeIncidents() error = %v", err)
+	}
+	watchers, err = repo.ListIncidentWatchers("inc_test_watch_b")
+	if err != nil || len(watchers) != 1 || watchers[0].Email != "alice@example.com" {
+		t.Errorf("expected alice to watch the merge target, got %+v, %v", watchers, err)
+	}
+	watchers, err = repo.ListIncidentWatchers("inc_test_watch_a")
+	if err != nil || len(watchers) != 0 {
+		t.Errorf("expected no watchers left on the merged incident, got %+v, %v", watchers, err)
+	}
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// SaveWorkflowLog stores the log excerpt of an incident's workflow run,
+// replacing any excerpt stored for the same run
+func (r *IncidentRepository) SaveWorkflowLog(log *models.WorkflowLog) (err error) {
+	ctx, span := r.startSpan("SaveWorkflowLog")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		INSERT INTO incident_workflow_logs (
+			incident_id, workflow_run_id, repository, job_name, step_name,
+			conclusion, excerpt, truncated, error, fetched_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (incident_id, workflow_run_id) DO UPDATE SET
+			repository = EXCLUDED.repository,
+			job_name = EXCLUDED.job_name,
+			step_name = EXCLUDED.step_name,
+			conclusion = EXCLUDED.conclusion,
+			excerpt = EXCLUDED.excerpt,
+			truncated = EXCLUDED.truncated,
+			error = EXCLUDED.error,
+			fetched_at = EXCLUDED.fetched_at
+	`
+
+	// Step output can echo secrets and customer data, like a diagnosis
+	excerpt, err := r.db.cipher.sealText(log.Excerpt)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt workflow log excerpt: %w", err)
+	}
+
+	if log.FetchedAt.IsZero() {
+		log.FetchedAt = time.Now()
+	}
+	_, err = r.db.ExecContext(ctx,
+		query,
+		log.IncidentID,
+		log.WorkflowRunID,
+		log.Repository,
+		log.JobName,
+		log.StepName,
+		log.Conclusion,
+		excerpt,
+		log.Truncated,
+		log.Error,
+		log.FetchedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save workflow log: %w", err)
+	}
+
+	return nil
+}
+
+// HasWorkflowLog reports whether the logs of an incident's workflow run have
+// been fetched, or given up on
+func (r *IncidentRepository) HasWorkflowLog(incidentID string, runID int64) (_ bool, err error) {
+	ctx, span := r.startSpan("HasWorkflowLog")
+	defer func() { tracing.End(span, err) }()
+
+	var one int
+	err = r.db.QueryRowContext(ctx, `
+		SELECT 1 FROM incident_workflow_logs
+		WHERE incident_id = $1 AND workflow_run_id = $2
+	`, incidentID, runID).Scan(&one)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check workflow log: %w", err)
+	}
+	return true, nil
+}
+
+// ListWorkflowLogs returns the log excerpts of an incident's workflow runs,
+// latest run first
+func (r *IncidentRepository) ListWorkflowLogs(incidentID string) (_ []*models.WorkflowLog, err error) {
+	ctx, span := r.startSpan("ListWorkflowLogs")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT incident_id, workflow_run_id, repository, job_name, step_name,
+			conclusion, excerpt, truncated, error, fetched_at
+		FROM incident_workflow_logs
+		WHERE incident_id = $1
+		ORDER BY workflow_run_id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow logs: %w", err)
+	}
+	defer rows.Close()
+
+	logs := []*models.WorkflowLog{}
+	for rows.Next() {
+		var log models.WorkflowLog
+		if err := rows.Scan(
+			&log.IncidentID,
+			&log.WorkflowRunID,
+			&log.Repository,
+			&log.JobName,
+			&log.StepName,
+			&log.Conclusion,
+			&log.Excerpt,
+			&log.Truncated,
+			&log.Error,
+			&log.FetchedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan workflow log: %w", err)
+		}
+		if log.Excerpt, err = r.db.cipher.openText(log.Excerpt); err != nil {
+			return nil, fmt.Errorf("failed to decrypt workflow log excerpt: %w", err)
+		}
+		logs = append(logs, &log)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating workflow logs: %w", err)
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestIncidentRepository_WorkflowLogs(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+	if err := cleanupTestData(db.DB); err != nil {
+		t.Fatalf("failed to clean up test data: %v", err)
+	}
+
+	repo := NewIncidentRepository(db)
+
+	incident := &models.Incident{
+		ID:           "inc_test_logs",
+		ServiceName:  "test-service",
+		Repository:   "org/test-repo",
+		ErrorMessage: "connection refused",
+		Severity:     "high",
+		Status:       models.StatusFailed,
+		Provider:     "datadog",
+	}
+	if err := repo.Create(incident); err != nil {
+		t.Fatalf("failed to create incident: %v", err)
+	}
+
+	if found, err := repo.HasWorkflowLog(incident.ID, 100); err != nil || found {
+		t.Fatalf("HasWorkflowLog() = %v, %v, want no log", found, err)
+	}
+
+	excerpt := "npm test\nError: expected 200, got 500"
+	if err := repo.SaveWorkflowLog(&models.WorkflowLog{
+		IncidentID:    incident.ID,
+		WorkflowRunID: 100,
+		Repository:    incident.Repository,
+		JobName:       "remediate",
+		StepName:      "Run tests",
+		Conclusion:    "failure",
+		Excerpt:       &excerpt,
+	}); err != nil {
+		t.Fatalf("SaveWorkflowLog() error = %v", err)
+	}
+	unavailable := "logs have expired"
+	if err := repo.SaveWorkflowLog(&models.WorkflowLog{
+		IncidentID:    incident.ID,
+		WorkflowRunID: 200,
+		Repository:    incident.Repository,
+		Error:         &unavailable,
+	}); err != nil {
+		t.Fatalf("SaveWorkflowLog() error = %v", err)
+	}
+
+	if found, err := repo.HasWorkflowLog(incident.ID, 100); err != nil || !found {
+		t.Fatalf("HasWorkflowLog() = %v, %v, want the stored log", found, err)
+	}
+
+	logs, err := repo.ListWorkflowLogs(incident.ID)
+	if err != nil {
+		t.Fatalf("ListWorkflowLogs() error = %v", err)
+	}
+	if len(logs) != 2 || logs[0].WorkflowRunID != 200 || logs[1].WorkflowRunID != 100 {
+		t.Fatalf("expected both runs, latest first, got %+v", logs)
+	}
+	if logs[1].Excerpt == nil || *logs[1].Excerpt != excerpt || logs[1].StepName != "Run tests" {
+		t.Errorf("unexpected excerpt %+v", logs[1])
+	}
+	if logs[0].Error == nil || logs[0].Excerpt != nil {
+		t.Errorf("expected the unavailable run to have an error and no excerpt, got %+v", logs[0])
+	}
+}
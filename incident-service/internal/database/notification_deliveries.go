@@ -0,0 +1,206 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// notificationDeliveryColumns lists the columns selected for notification deliveries
+const notificationDeliveryColumns = `
+	id, incident_id, event_type, provider, status, attempts, last_error,
+	message, next_attempt_at, delivered_at, created_at, updated_at
+`
+
+// CreateNotificationDelivery records a notification delivery attempt
+func (r *IncidentRepository) CreateNotificationDelivery(delivery *models.NotificationDelivery) (err error) {
+	ctx, span := r.startSpan("CreateNotificationDelivery")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		INSERT INTO notification_deliveries (
+			incident_id, event_type, provider, status, attempts, last_error,
+			message, next_attempt_at, delivered_at, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+
+	now := time.Now()
+	delivery.CreatedAt = now
+	delivery.UpdatedAt = now
+
+	// The message holds the incident, stack trace and diagnosis included
+	message := delivery.Message
+	if len(message) == 0 {
+		message = []byte("{}")
+	}
+	message, err = r.db.cipher.sealRawJSON(message)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt notification message: %w", err)
+	}
+
+	err = r.db.QueryRowContext(ctx,
+		query,
+		delivery.IncidentID,
+		delivery.EventType,
+		delivery.Provider,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.LastError,
+		[]byte(message),
+		delivery.NextAttemptAt,
+		delivery.DeliveredAt,
+		delivery.CreatedAt,
+		delivery.UpdatedAt,
+	).Scan(&delivery.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create notification delivery: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateNotificationDelivery updates the outcome of a notification delivery
+func (r *IncidentRepository) UpdateNotificationDelivery(delivery *models.NotificationDelivery) (err error) {
+	ctx, span := r.startSpan("UpdateNotificationDelivery")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		UPDATE notification_deliveries SET
+			status = $2,
+			attempts = $3,
+			last_error = $4,
+			next_attempt_at = $5,
+			delivered_at = $6,
+			updated_at = $7
+		WHERE id = $1
+	`
+
+	delivery.UpdatedAt = time.Now()
+
+	result, err := r.db.ExecContext(ctx,
+		query,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.DeliveredAt,
+		delivery.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update notification delivery: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("notification delivery not found: %d", delivery.ID)
+	}
+
+	return nil
+}
+
+// ListDueNotificationDeliveries retrieves deliveries awaiting a retry at or before the given time
+func (r *IncidentRepository) ListDueNotificationDeliveries(before time.Time, limit int) (_ []*models.NotificationDelivery, err error) {
+	ctx, span := r.startSpan("ListDueNotificationDeliveries")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT ` + notificationDeliveryColumns + `
+		FROM notification_deliveries
+		WHERE status = $1 AND next_attempt_at <= $2
+		ORDER BY next_attempt_at ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, models.DeliveryRetrying, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due notification deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	return scanNotificationDeliveries(rows, r.db.cipher)
+}
+
+// GetNotificationDeliveriesByIncidentID retrieves all notification deliveries for an incident
+func (r *IncidentRepository) GetNotificationDeliveriesByIncidentID(incidentID string) (_ []*models.NotificationDelivery, err error) {
+	ctx, span := r.startSpan("GetNotificationDeliveriesByIncidentID")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT ` + notificationDeliveryColumns + `
+		FROM notification_deliveries
+		WHERE incident_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	return scanNotificationDeliveries(rows, r.db.cipher)
+}
+
+// scanNotificationDeliveries reads notification deliveries from query rows,
+// decrypting their messages
+func scanNotificationDeliveries(rows *sql.Rows, cipher *Cipher) ([]*models.NotificationDelivery, error) {
+	deliveries := make([]*models.NotificationDelivery, 0)
+	for rows.Next() {
+		var delivery models.NotificationDelivery
+		var incidentID, lastError sql.NullString
+		var nextAttemptAt, deliveredAt sql.NullTime
+		var message []byte
+
+		err := rows.Scan(
+			&delivery.ID,
+			&incidentID,
+			&delivery.EventType,
+			&delivery.Provider,
+			&delivery.Status,
+			&delivery.Attempts,
+			&lastError,
+			&message,
+			&nextAttemptAt,
+			&deliveredAt,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification delivery: %w", err)
+		}
+
+		if incidentID.Valid {
+			delivery.IncidentID = &incidentID.String
+		}
+		if lastError.Valid {
+			delivery.LastError = &lastError.String
+		}
+		if nextAttemptAt.Valid {
+			delivery.NextAttemptAt = &nextAttemptAt.Time
+		}
+		if deliveredAt.Valid {
+			delivery.DeliveredAt = &deliveredAt.Time
+		}
+		delivery.Message, err = cipher.openRawJSON(message)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt notification message: %w", err)
+		}
+
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating notification deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestIncidentRepository_NotificationDeliveries(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+
+	repo := NewIncidentRepository(db)
+
+	incident := &models.Incident{
+		ID:           "inc_test_notify",
+		ServiceName:  "test-service",
+		ErrorMessage: "test error",
+		Severity:     "high",
+		Status:       models.StatusPending,
+		Provider:     "datadog",
+	}
+	if err := repo.Create(incident); err != nil {
+		t.Fatalf("failed to create incident: %v", err)
+	}
+
+	lastError := "unexpected status code 502"
+	due := time.Now().Add(-time.Minute)
+	delivery := &models.NotificationDelivery{
+		IncidentID:    &incident.ID,
+		EventType:     models.EventIncidentReceived,
+		Provider:      "slack",
+		Status:        models.DeliveryRetrying,
+		Attempts:      1,
+		LastError:     &lastError,
+		Message:       []byte(`{"Text":"hello"}`),
+		NextAttemptAt: &due,
+	}
+	if err := repo.CreateNotificationDelivery(delivery); err != nil {
+		t.Fatalf("failed to create delivery: %v", err)
+	}
+
+	dueDeliveries, err := repo.ListDueNotificationDeliveries(time.Now(), 10)
+	if err != nil {
+		t.Fatalf("failed to list due deliveries: %v", err)
+	}
+	if len(dueDeliveries) != 1 || dueDeliveries[0].ID != delivery.ID {
+		t.Fatalf("expected delivery to be due, got %+v", dueDeliveries)
+	}
+
+	delivered := time.Now()
+	delivery.Status = models.DeliverySent
+	delivery.Attempts = 2
+	delivery.LastError = nil
+	delivery.NextAttemptAt = nil
+	delivery.DeliveredAt = &delivered
+	if err := repo.UpdateNotificationDelivery(delivery); err != nil {
+		t.Fatalf("failed to update delivery: %v", err)
+	}
+
+	deliveries, err := repo.GetNotificationDeliveriesByIncidentID(incident.ID)
+	if err != nil {
+		t.Fatalf("failed to get deliveries: %v", err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("expected 1 delivery, got %d", len(deliveries))
+	}
+	if deliveries[0].Status != models.DeliverySent || deliveries[0].Attempts != 2 || deliveries[0].DeliveredAt == nil || deliveries[0].LastError != nil {
+		t.Errorf("unexpected delivery: %+v", deliveries[0])
+	}
+}
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// lockPollInterval is how often a held lock is retried
+const lockPollInterval = 25 * time.Millisecond
+
+// unlockScript deletes the lock only if the token still holds it
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Lock takes the lock under key, waiting while another holder has it, until
+// ctx is done. The lock expires after ttl in case its holder dies, so ttl
+// must exceed the longest time it is held. Call unlock to release it.
+func (r *RedisClient) Lock(ctx context.Context, key string, ttl time.Duration) (unlock func(), err error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to generate lock token: %w", err)
+	}
+	token := hex.EncodeToString(b)
+
+	for {
+		ok, err := r.SetNX(ctx, key, token, ttl).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to take lock %s: %w", key, err)
+		}
+		if ok {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to take lock %s: %w", key, ctx.Err())
+		case <-time.After(lockPollInterval):
+		}
+	}
+
+	return func() {
+		// Released even when the caller's context is done
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = unlockScript.Run(ctx, r.Client, []string{key}, token).Err()
+	}, nil
+}
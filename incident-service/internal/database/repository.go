@@ -1,55 +1,107 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/lib/pq"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
 	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // IncidentRepository handles incident database operations
 type IncidentRepository struct {
-	db *DB
+	db  *DB
+	ctx context.Context // operations run under it, traced as children of its span
 }
 
 // NewIncidentRepository creates a new incident repository
 func NewIncidentRepository(db *DB) *IncidentRepository {
-	return &IncidentRepository{db: db}
+	return &IncidentRepository{db: db, ctx: context.Background()}
+}
+
+// WithContext returns a repository whose operations run under ctx: they are
+// traced as children of its span, and their queries are cancelled with it
+func (r *IncidentRepository) WithContext(ctx context.Context) *IncidentRepository {
+	return &IncidentRepository{db: r.db, ctx: ctx}
+}
+
+// startSpan starts a client span for a repository operation. Queries run
+// under the returned context.
+func (r *IncidentRepository) startSpan(operation string) (context.Context, trace.Span) {
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return tracing.Start(ctx, "repository."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", operation),
+		),
+	)
 }
 
 // Create inserts a new incident into the database and logs the creation event
-func (r *IncidentRepository) Create(incident *models.Incident) error {
-	providerDataJSON, err := json.Marshal(incident.ProviderData)
+func (r *IncidentRepository) Create(incident *models.Incident) (err error) {
+	ctx, span := r.startSpan("Create")
+	defer func() { tracing.End(span, err) }()
+
+	sealed, err := r.db.cipher.sealIncident(incident)
 	if err != nil {
-		return fmt.Errorf("failed to marshal provider data: %w", err)
+		return err
 	}
 
 	query := `
 		INSERT INTO incidents (
 			id, service_name, repository, error_message, stack_trace,
-			severity, status, provider, provider_data, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			severity, status, provider, provider_data, created_at, updated_at,
+			fingerprint, occurrence_count, parent_id, team, tenant_id,
+			quota_exceeded, severity_defaulted, suggested_severity,
+			provider_ref, affected_users, error_rate, downtime_minutes
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
 	`
 
 	now := time.Now()
 	incident.CreatedAt = now
 	incident.UpdatedAt = now
+	if incident.OccurrenceCount < 1 {
+		incident.OccurrenceCount = 1
+	}
+	incident.TenantID = tenantOf(incident)
 
-	_, err = r.db.Exec(
+	_, err = r.db.ExecContext(ctx,
 		query,
 		incident.ID,
 		incident.ServiceName,
 		incident.Repository,
 		incident.ErrorMessage,
-		incident.StackTrace,
+		sealed.stackTrace,
 		incident.Severity,
 		incident.Status,
 		incident.Provider,
-		providerDataJSON,
+		sealed.providerData,
 		incident.CreatedAt,
 		incident.UpdatedAt,
+		incident.Fingerprint,
+		incident.OccurrenceCount,
+		incident.ParentID,
+		incident.Team,
+		incident.TenantID,
+		incident.QuotaExceeded,
+		incident.SeverityDefaulted,
+		incident.SuggestedSeverity,
+		incident.ProviderRef,
+		incident.AffectedUsers,
+		incident.ErrorRate,
+		incident.DowntimeMinutes,
 	)
 
 	if err != nil {
@@ -67,7 +119,7 @@ func (r *IncidentRepository) Create(incident *models.Incident) error {
 		},
 	}
 
-	if err := r.LogEvent(event); err != nil {
+	if err := r.WithContext(ctx).LogEvent(event); err != nil {
 		// Log error but don't fail the incident creation
 		return fmt.Errorf("failed to log incident creation event: %w", err)
 	}
@@ -76,13 +128,21 @@ func (r *IncidentRepository) Create(incident *models.Incident) error {
 }
 
 // GetByID retrieves an incident by its ID
-func (r *IncidentRepository) GetByID(id string) (*models.Incident, error) {
+func (r *IncidentRepository) GetByID(id string) (_ *models.Incident, err error) {
+	ctx, span := r.startSpan("GetByID")
+	defer func() { tracing.End(span, err) }()
+
 	query := `
 		SELECT 
 			id, service_name, repository, error_message, stack_trace,
 			severity, status, provider, provider_data, workflow_run_id,
 			pull_request_url, diagnosis, created_at, updated_at,
-			triggered_at, completed_at
+			triggered_at, completed_at, retry_count, next_retry_at,
+			fingerprint, occurrence_count, parent_id, deferred_until,
+			snoozed_until, team, duplicate_of, tenant_id, quota_exceeded,
+			enrichment, summary, severity_defaulted, suggested_severity,
+			provider_ref, affected_users, error_rate, downtime_minutes,
+			reopened_at
 		FROM incidents
 		WHERE id = $1
 	`
@@ -90,7 +150,7 @@ func (r *IncidentRepository) GetByID(id string) (*models.Incident, error) {
 	var incident models.Incident
 	var providerDataJSON []byte
 
-	err := r.db.QueryRow(query, id).Scan(
+	err = r.db.QueryRowContext(ctx, query, id).Scan(
 		&incident.ID,
 		&incident.ServiceName,
 		&incident.Repository,
@@ -107,6 +167,26 @@ func (r *IncidentRepository) GetByID(id string) (*models.Incident, error) {
 		&incident.UpdatedAt,
 		&incident.TriggeredAt,
 		&incident.CompletedAt,
+		&incident.RetryCount,
+		&incident.NextRetryAt,
+		&incident.Fingerprint,
+		&incident.OccurrenceCount,
+		&incident.ParentID,
+		&incident.DeferredUntil,
+		&incident.SnoozedUntil,
+		&incident.Team,
+		&incident.DuplicateOf,
+		&incident.TenantID,
+		&incident.QuotaExceeded,
+		&incident.Enrichment,
+		&incident.Summary,
+		&incident.SeverityDefaulted,
+		&incident.SuggestedSeverity,
+		&incident.ProviderRef,
+		&incident.AffectedUsers,
+		&incident.ErrorRate,
+		&incident.DowntimeMinutes,
+		&incident.ReopenedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -116,18 +196,30 @@ func (r *IncidentRepository) GetByID(id string) (*models.Incident, error) {
 		return nil, fmt.Errorf("failed to get incident: %w", err)
 	}
 
-	if err := json.Unmarshal(providerDataJSON, &incident.ProviderData); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal provider data: %w", err)
+	if err := r.db.cipher.openIncident(&incident, providerDataJSON); err != nil {
+		return nil, err
 	}
 
 	return &incident, nil
 }
 
+// tenantOf returns the tenant of an incident, which is the default tenant for
+// incidents received before tenants were configured
+func tenantOf(incident *models.Incident) string {
+	if incident.TenantID == "" {
+		return config.DefaultTenant
+	}
+	return incident.TenantID
+}
+
 // Update updates an existing incident
-func (r *IncidentRepository) Update(incident *models.Incident) error {
-	providerDataJSON, err := json.Marshal(incident.ProviderData)
+func (r *IncidentRepository) Update(incident *models.Incident) (err error) {
+	ctx, span := r.startSpan("Update")
+	defer func() { tracing.End(span, err) }()
+
+	sealed, err := r.db.cipher.sealIncident(incident)
 	if err != nil {
-		return fmt.Errorf("failed to marshal provider data: %w", err)
+		return err
 	}
 
 	query := `
@@ -135,29 +227,35 @@ func (r *IncidentRepository) Update(incident *models.Incident) error {
 		SET service_name = $2, repository = $3, error_message = $4,
 		    stack_trace = $5, severity = $6, status = $7, provider = $8,
 		    provider_data = $9, workflow_run_id = $10, pull_request_url = $11,
-		    diagnosis = $12, updated_at = $13, triggered_at = $14, completed_at = $15
+		    diagnosis = $12, updated_at = $13, triggered_at = $14, completed_at = $15,
+		    retry_count = $16, next_retry_at = $17, parent_id = $18,
+		    deferred_until = $19
 		WHERE id = $1
 	`
 
 	incident.UpdatedAt = time.Now()
 
-	_, err = r.db.Exec(
+	_, err = r.db.ExecContext(ctx,
 		query,
 		incident.ID,
 		incident.ServiceName,
 		incident.Repository,
 		incident.ErrorMessage,
-		incident.StackTrace,
+		sealed.stackTrace,
 		incident.Severity,
 		incident.Status,
 		incident.Provider,
-		providerDataJSON,
+		sealed.providerData,
 		incident.WorkflowRunID,
 		incident.PullRequestURL,
-		incident.Diagnosis,
+		sealed.diagnosis,
 		incident.UpdatedAt,
 		incident.TriggeredAt,
 		incident.CompletedAt,
+		incident.RetryCount,
+		incident.NextRetryAt,
+		incident.ParentID,
+		incident.DeferredUntil,
 	)
 
 	if err != nil {
@@ -174,6 +272,11 @@ type IncidentFilter struct {
 	Repository  *string
 	StartTime   *time.Time
 	EndTime     *time.Time
+	Team        *string
+	Teams       []string // only incidents of these teams, when not nil
+	TenantID    *string
+	ParentID    *string  // only incidents grouped under this parent
+	TopLevel    bool     // only incidents without a parent
 }
 
 // List retrieves all incidents with optional filtering
@@ -182,13 +285,21 @@ func (r *IncidentRepository) List() ([]*models.Incident, error) {
 }
 
 // ListWithFilter retrieves incidents with optional filtering
-func (r *IncidentRepository) ListWithFilter(filter *IncidentFilter) ([]*models.Incident, error) {
+func (r *IncidentRepository) ListWithFilter(filter *IncidentFilter) (_ []*models.Incident, err error) {
+	ctx, span := r.startSpan("ListWithFilter")
+	defer func() { tracing.End(span, err) }()
+
 	query := `
 		SELECT 
 			id, service_name, repository, error_message, stack_trace,
 			severity, status, provider, provider_data, workflow_run_id,
 			pull_request_url, diagnosis, created_at, updated_at,
-			triggered_at, completed_at
+			triggered_at, completed_at, retry_count, next_retry_at,
+			fingerprint, occurrence_count, parent_id, deferred_until,
+			snoozed_until, team, duplicate_of, tenant_id, quota_exceeded,
+			enrichment, summary, severity_defaulted, suggested_severity,
+			provider_ref, affected_users, error_rate, downtime_minutes,
+			reopened_at
 		FROM incidents
 		WHERE 1=1
 	`
@@ -220,13 +331,36 @@ func (r *IncidentRepository) ListWithFilter(filter *IncidentFilter) ([]*models.I
 		if filter.EndTime != nil {
 			query += fmt.Sprintf(" AND created_at <= $%d", argCount)
 			args = append(args, *filter.EndTime)
+			argCount++
+		}
+		if filter.Team != nil {
+			query += fmt.Sprintf(" AND team = $%d", argCount)
+			args = append(args, *filter.Team)
+			argCount++
+		}
+		if filter.Teams != nil {
+			query += fmt.Sprintf(" AND team = ANY($%d)", argCount)
+			args = append(args, pq.Array(filter.Teams))
+			argCount++
+		}
+		if filter.TenantID != nil {
+			query += fmt.Sprintf(" AND tenant_id = $%d", argCount)
+			args = append(args, *filter.TenantID)
+			argCount++
+		}
+		if filter.ParentID != nil {
+			query += fmt.Sprintf(" AND parent_id = $%d", argCount)
+			args = append(args, *filter.ParentID)
 			// argCount++ not needed after last parameter
 		}
+		if filter.TopLevel {
+			query += " AND parent_id IS NULL"
+		}
 	}
 
 	query += " ORDER BY created_at DESC"
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list incidents: %w", err)
 	}
@@ -254,13 +388,33 @@ func (r *IncidentRepository) ListWithFilter(filter *IncidentFilter) ([]*models.I
 			&incident.UpdatedAt,
 			&incident.TriggeredAt,
 			&incident.CompletedAt,
+			&incident.RetryCount,
+			&incident.NextRetryAt,
+			&incident.Fingerprint,
+			&incident.OccurrenceCount,
+			&incident.ParentID,
+			&incident.DeferredUntil,
+			&incident.SnoozedUntil,
+			&incident.Team,
+			&incident.DuplicateOf,
+			&incident.TenantID,
+			&incident.QuotaExceeded,
+			&incident.Enrichment,
+			&incident.Summary,
+			&incident.SeverityDefaulted,
+			&incident.SuggestedSeverity,
+			&incident.ProviderRef,
+			&incident.AffectedUsers,
+			&incident.ErrorRate,
+			&incident.DowntimeMinutes,
+			&incident.ReopenedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan incident: %w", err)
 		}
 
-		if err := json.Unmarshal(providerDataJSON, &incident.ProviderData); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal provider data: %w", err)
+		if err := r.db.cipher.openIncident(&incident, providerDataJSON); err != nil {
+			return nil, err
 		}
 
 		incidents = append(incidents, &incident)
@@ -273,27 +427,38 @@ func (r *IncidentRepository) ListWithFilter(filter *IncidentFilter) ([]*models.I
 	return incidents, nil
 }
 
-// FindDuplicateIncident finds an existing incident with the same service and error within the time window
-func (r *IncidentRepository) FindDuplicateIncident(serviceName, errorMessage string, timeWindow time.Duration) (*models.Incident, error) {
+// FindDuplicateIncident finds the latest incident of the service with the same
+// fingerprint created within the time window, or snoozed
+func (r *IncidentRepository) FindDuplicateIncident(serviceName, fingerprint string, timeWindow time.Duration) (_ *models.Incident, err error) {
+	ctx, span := r.startSpan("FindDuplicateIncident")
+	defer func() { tracing.End(span, err) }()
+
 	query := `
 		SELECT 
 			id, service_name, repository, error_message, stack_trace,
 			severity, status, provider, provider_data, workflow_run_id,
 			pull_request_url, diagnosis, created_at, updated_at,
-			triggered_at, completed_at
+			triggered_at, completed_at, retry_count, next_retry_at,
+			fingerprint, occurrence_count, parent_id, deferred_until,
+			snoozed_until, team, duplicate_of, tenant_id, quota_exceeded,
+			enrichment, summary, severity_defaulted, suggested_severity,
+			provider_ref, affected_users, error_rate, downtime_minutes,
+			reopened_at
 		FROM incidents
 		WHERE service_name = $1 
-		  AND error_message = $2
-		  AND created_at > $3
+		  AND fingerprint = $2
+		  AND (created_at > $3 OR snoozed_until > $4)
+		  AND duplicate_of IS NULL
 		ORDER BY created_at DESC
 		LIMIT 1
 	`
 
-	cutoffTime := time.Now().Add(-timeWindow)
+	now := time.Now()
+	cutoffTime := now.Add(-timeWindow)
 	var incident models.Incident
 	var providerDataJSON []byte
 
-	err := r.db.QueryRow(query, serviceName, errorMessage, cutoffTime).Scan(
+	err = r.db.QueryRowContext(ctx, query, serviceName, fingerprint, cutoffTime, now).Scan(
 		&incident.ID,
 		&incident.ServiceName,
 		&incident.Repository,
@@ -310,6 +475,26 @@ func (r *IncidentRepository) FindDuplicateIncident(serviceName, errorMessage str
 		&incident.UpdatedAt,
 		&incident.TriggeredAt,
 		&incident.CompletedAt,
+		&incident.RetryCount,
+		&incident.NextRetryAt,
+		&incident.Fingerprint,
+		&incident.OccurrenceCount,
+		&incident.ParentID,
+		&incident.DeferredUntil,
+		&incident.SnoozedUntil,
+		&incident.Team,
+		&incident.DuplicateOf,
+		&incident.TenantID,
+		&incident.QuotaExceeded,
+		&incident.Enrichment,
+		&incident.Summary,
+		&incident.SeverityDefaulted,
+		&incident.SuggestedSeverity,
+		&incident.ProviderRef,
+		&incident.AffectedUsers,
+		&incident.ErrorRate,
+		&incident.DowntimeMinutes,
+		&incident.ReopenedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -319,17 +504,333 @@ func (r *IncidentRepository) FindDuplicateIncident(serviceName, errorMessage str
 		return nil, fmt.Errorf("failed to find duplicate incident: %w", err)
 	}
 
-	if err := json.Unmarshal(providerDataJSON, &incident.ProviderData); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal provider data: %w", err)
+	if err := r.db.cipher.openIncident(&incident, providerDataJSON); err != nil {
+		return nil, err
+	}
+
+	return &incident, nil
+}
+
+// FindResolvedIncident finds the most recently resolved incident for the
+// same service with the same fingerprint that was resolved within the time window
+func (r *IncidentRepository) FindResolvedIncident(serviceName, fingerprint string, timeWindow time.Duration) (_ *models.Incident, err error) {
+	ctx, span := r.startSpan("FindResolvedIncident")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT 
+			id, service_name, repository, error_message, stack_trace,
+			severity, status, provider, provider_data, workflow_run_id,
+			pull_request_url, diagnosis, created_at, updated_at,
+			triggered_at, completed_at, retry_count, next_retry_at,
+			fingerprint, occurrence_count, parent_id, deferred_until,
+			snoozed_until, team, duplicate_of, tenant_id, quota_exceeded,
+			enrichment, summary, severity_defaulted, suggested_severity,
+			provider_ref, affected_users, error_rate, downtime_minutes,
+			reopened_at
+		FROM incidents
+		WHERE service_name = $1 
+		  AND fingerprint = $2
+		  AND status = $3
+		  AND completed_at > $4
+		ORDER BY completed_at DESC
+		LIMIT 1
+	`
+
+	cutoffTime := time.Now().Add(-timeWindow)
+	var incident models.Incident
+	var providerDataJSON []byte
+
+	err = r.db.QueryRowContext(ctx, query, serviceName, fingerprint, models.StatusResolved, cutoffTime).Scan(
+		&incident.ID,
+		&incident.ServiceName,
+		&incident.Repository,
+		&incident.ErrorMessage,
+		&incident.StackTrace,
+		&incident.Severity,
+		&incident.Status,
+		&incident.Provider,
+		&providerDataJSON,
+		&incident.WorkflowRunID,
+		&incident.PullRequestURL,
+		&incident.Diagnosis,
+		&incident.CreatedAt,
+		&incident.UpdatedAt,
+		&incident.TriggeredAt,
+		&incident.CompletedAt,
+		&incident.RetryCount,
+		&incident.NextRetryAt,
+		&incident.Fingerprint,
+		&incident.OccurrenceCount,
+		&incident.ParentID,
+		&incident.DeferredUntil,
+		&incident.SnoozedUntil,
+		&incident.Team,
+		&incident.DuplicateOf,
+		&incident.TenantID,
+		&incident.QuotaExceeded,
+		&incident.Enrichment,
+		&incident.Summary,
+		&incident.SeverityDefaulted,
+		&incident.SuggestedSeverity,
+		&incident.ProviderRef,
+		&incident.AffectedUsers,
+		&incident.ErrorRate,
+		&incident.DowntimeMinutes,
+		&incident.ReopenedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find resolved incident: %w", err)
+	}
+
+	if err := r.db.cipher.openIncident(&incident, providerDataJSON); err != nil {
+		return nil, err
 	}
 
 	return &incident, nil
 }
 
+// Reopen moves a resolved incident back to pending so it can be remediated
+// again, and counts the recurrence as another occurrence. The resolution is
+// revoked: the completion time, workflow run, pull request and retries of the
+// fix that did not hold are cleared. It returns the new occurrence count, and
+// false if the incident is no longer resolved, such as when a concurrent
+// recurrence already reopened it.
+func (r *IncidentRepository) Reopen(id string) (_ int, _ bool, err error) {
+	ctx, span := r.startSpan("Reopen")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		UPDATE incidents
+		SET status = $2, completed_at = NULL, triggered_at = NULL,
+		    workflow_run_id = NULL, pull_request_url = NULL,
+		    retry_count = 0, next_retry_at = NULL,
+		    occurrence_count = occurrence_count + 1, reopened_at = $3, updated_at = $3
+		WHERE id = $1 AND status = $4
+		RETURNING occurrence_count
+	`
+
+	var count int
+	err = r.db.QueryRowContext(ctx, query, id, models.StatusPending, time.Now(), models.StatusResolved).Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to reopen incident: %w", err)
+	}
+
+	return count, true, nil
+}
+
+// FindGroupParent finds the earliest top-level incident of another service
+// of the tenant with the same fingerprint created within the time window, and
+// returns its ID, or an empty string if there is none
+func (r *IncidentRepository) FindGroupParent(tenantID, serviceName, fingerprint string, timeWindow time.Duration) (_ string, err error) {
+	ctx, span := r.startSpan("FindGroupParent")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT id
+		FROM incidents
+		WHERE fingerprint = $1
+		  AND service_name <> $2
+		  AND parent_id IS NULL
+		  AND created_at > $3
+		  AND tenant_id = $4
+		ORDER BY created_at ASC
+		LIMIT 1
+	`
+
+	var id string
+	err = r.db.QueryRowContext(ctx, query, fingerprint, serviceName, time.Now().Add(-timeWindow), tenantID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find group parent: %w", err)
+	}
+
+	return id, nil
+}
+
+// RecordOccurrence counts another occurrence of an incident that was seen
+// again, and returns the new occurrence count
+func (r *IncidentRepository) RecordOccurrence(id string) (_ int, err error) {
+	ctx, span := r.startSpan("RecordOccurrence")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		UPDATE incidents
+		SET occurrence_count = occurrence_count + 1, updated_at = $2
+		WHERE id = $1
+		RETURNING occurrence_count
+	`
+
+	var count int
+	err = r.db.QueryRowContext(ctx, query, id, time.Now()).Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("incident not found: %s", id)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to record occurrence: %w", err)
+	}
+
+	return count, nil
+}
+
+// ResumeDeferred brings forward to at the deferral of the given incidents
+// that are still pending and deferred past at, returning how many were
+func (r *IncidentRepository) ResumeDeferred(ids []string, at time.Time) (_ int64, err error) {
+	ctx, span := r.startSpan("ResumeDeferred")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		UPDATE incidents
+		SET deferred_until = $2, updated_at = $3
+		WHERE id = ANY($1) AND status = 'pending' AND deferred_until > $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, pq.Array(ids), at, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to resume deferred incidents: %w", err)
+	}
+	resumed, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to resume deferred incidents: %w", err)
+	}
+
+	return resumed, nil
+}
+
+// SetSnooze snoozes an incident until the given time, or ends its snooze when
+// until is nil
+func (r *IncidentRepository) SetSnooze(id string, until *time.Time) (err error) {
+	ctx, span := r.startSpan("SetSnooze")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		UPDATE incidents
+		SET snoozed_until = $2, updated_at = $3
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, until, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to snooze incident: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("incident not found: %s", id)
+	}
+
+	return nil
+}
+
+// SetEnrichment stores the context gathered for an incident before its dispatch
+func (r *IncidentRepository) SetEnrichment(id, enrichment string) (err error) {
+	ctx, span := r.startSpan("SetEnrichment")
+	defer func() { tracing.End(span, err) }()
+
+	sealed, err := r.db.cipher.sealText(&enrichment)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt enrichment: %w", err)
+	}
+
+	query := `
+		UPDATE incidents
+		SET enrichment = $2, updated_at = $3
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, sealed, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to store enrichment: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("incident not found: %s", id)
+	}
+
+	return nil
+}
+
+// SetSummary stores the summary written for an incident
+func (r *IncidentRepository) SetSummary(id string, summary *models.IncidentSummary) (err error) {
+	ctx, span := r.startSpan("SetSummary")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		UPDATE incidents
+		SET summary = $2, updated_at = $3
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, summary, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to store summary: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("incident not found: %s", id)
+	}
+
+	return nil
+}
+
+// SetImpact stores the impact fields that impact sets, leaving the others as
+// they are
+func (r *IncidentRepository) SetImpact(id string, impact models.Impact) (err error) {
+	ctx, span := r.startSpan("SetImpact")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		UPDATE incidents
+		SET affected_users = COALESCE($2, affected_users),
+			error_rate = COALESCE($3, error_rate),
+			downtime_minutes = COALESCE($4, downtime_minutes),
+			updated_at = $5
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, impact.AffectedUsers, impact.ErrorRate, impact.DowntimeMinutes, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to store impact: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("incident not found: %s", id)
+	}
+
+	return nil
+}
+
 // UpdateStatus updates the status of an incident and logs the status change event
-func (r *IncidentRepository) UpdateStatus(id string, status models.IncidentStatus) error {
+func (r *IncidentRepository) UpdateStatus(id string, status models.IncidentStatus) (err error) {
+	ctx, span := r.startSpan("UpdateStatus")
+	defer func() { tracing.End(span, err) }()
+
 	// Get the current incident to check the old status
-	incident, err := r.GetByID(id)
+	incident, err := r.WithContext(ctx).GetByID(id)
 	if err != nil {
 		return fmt.Errorf("failed to get incident for status update: %w", err)
 	}
@@ -342,7 +843,7 @@ func (r *IncidentRepository) UpdateStatus(id string, status models.IncidentStatu
 		WHERE id = $1
 	`
 
-	_, err = r.db.Exec(query, id, status, time.Now())
+	_, err = r.db.ExecContext(ctx, query, id, status, time.Now())
 	if err != nil {
 		return fmt.Errorf("failed to update incident status: %w", err)
 	}
@@ -373,7 +874,7 @@ func (r *IncidentRepository) UpdateStatus(id string, status models.IncidentStatu
 		},
 	}
 
-	if err := r.LogEvent(event); err != nil {
+	if err := r.WithContext(ctx).LogEvent(event); err != nil {
 		// Log error but don't fail the status update
 		return fmt.Errorf("failed to log status change event: %w", err)
 	}
@@ -381,23 +882,53 @@ func (r *IncidentRepository) UpdateStatus(id string, status models.IncidentStatu
 	return nil
 }
 
+// StartProgress moves an incident whose workflow was triggered to in
+// progress. It returns false if the incident is in another status, such as
+// when its workflow already reported the outcome.
+func (r *IncidentRepository) StartProgress(id string) (_ bool, err error) {
+	ctx, span := r.startSpan("StartProgress")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		UPDATE incidents
+		SET status = $2, updated_at = $3
+		WHERE id = $1 AND status = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, models.StatusInProgress, time.Now(), models.StatusWorkflowTriggered)
+	if err != nil {
+		return false, fmt.Errorf("failed to start incident progress: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rows == 1, nil
+}
+
 // LogEvent logs an event in the incident lifecycle for audit trail
-func (r *IncidentRepository) LogEvent(event *models.IncidentEvent) error {
+func (r *IncidentRepository) LogEvent(event *models.IncidentEvent) (err error) {
+	ctx, span := r.startSpan("LogEvent")
+	defer func() { tracing.End(span, err) }()
+
 	eventDataJSON, err := json.Marshal(event.EventData)
 	if err != nil {
 		return fmt.Errorf("failed to marshal event data: %w", err)
 	}
 
+	// Events carry the tenant of their incident
 	query := `
-		INSERT INTO incident_events (incident_id, event_type, event_data, created_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO incident_events (incident_id, event_type, event_data, created_at, tenant_id)
+		VALUES ($1, $2, $3, $4, COALESCE((SELECT tenant_id FROM incidents WHERE id = $1), $5))
 		RETURNING id
 	`
 
 	now := time.Now()
 	event.CreatedAt = now
 
-	err = r.db.QueryRow(query, event.IncidentID, event.EventType, eventDataJSON, event.CreatedAt).Scan(&event.ID)
+	err = r.db.QueryRowContext(ctx, query, event.IncidentID, event.EventType, eventDataJSON, event.CreatedAt, config.DefaultTenant).Scan(&event.ID)
 	if err != nil {
 		return fmt.Errorf("failed to log event: %w", err)
 	}
@@ -406,7 +937,10 @@ func (r *IncidentRepository) LogEvent(event *models.IncidentEvent) error {
 }
 
 // GetEventsByIncidentID retrieves all events for a specific incident
-func (r *IncidentRepository) GetEventsByIncidentID(incidentID string) ([]*models.IncidentEvent, error) {
+func (r *IncidentRepository) GetEventsByIncidentID(incidentID string) (_ []*models.IncidentEvent, err error) {
+	ctx, span := r.startSpan("GetEventsByIncidentID")
+	defer func() { tracing.End(span, err) }()
+
 	query := `
 		SELECT id, incident_id, event_type, event_data, created_at
 		FROM incident_events
@@ -414,7 +948,28 @@ func (r *IncidentRepository) GetEventsByIncidentID(incidentID string) ([]*models
 		ORDER BY created_at ASC
 	`
 
-	rows, err := r.db.Query(query, incidentID)
+	return r.queryEvents(ctx, query, incidentID)
+}
+
+// GetEventsAfter retrieves the events of an incident logged after the event
+// with the given ID, oldest first
+func (r *IncidentRepository) GetEventsAfter(incidentID string, afterID int64) (_ []*models.IncidentEvent, err error) {
+	ctx, span := r.startSpan("GetEventsAfter")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT id, incident_id, event_type, event_data, created_at
+		FROM incident_events
+		WHERE incident_id = $1 AND id > $2
+		ORDER BY id ASC
+	`
+
+	return r.queryEvents(ctx, query, incidentID, afterID)
+}
+
+// queryEvents runs a query selecting incident events
+func (r *IncidentRepository) queryEvents(ctx context.Context, query string, args ...interface{}) ([]*models.IncidentEvent, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get events: %w", err)
 	}
@@ -457,16 +1012,25 @@ type IncidentStatistics struct {
 	FailedIncidents   int     `json:"failed_incidents"`
 	SuccessRate       float64 `json:"success_rate"`
 	MeanTimeToResolve float64 `json:"mean_time_to_resolve_seconds"`
+	AffectedUsers     int64   `json:"affected_users"`   // summed over incidents with an estimate
+	DowntimeMinutes   int64   `json:"downtime_minutes"` // summed over incidents with an estimate
+	PeakErrorRate     float64 `json:"peak_error_rate"`  // highest error rate of any incident
 }
 
 // GetStatistics computes aggregated statistics for incidents
-func (r *IncidentRepository) GetStatistics(filter *IncidentFilter) (*IncidentStatistics, error) {
+func (r *IncidentRepository) GetStatistics(filter *IncidentFilter) (_ *IncidentStatistics, err error) {
+	ctx, span := r.startSpan("GetStatistics")
+	defer func() { tracing.End(span, err) }()
+
 	query := `
 		SELECT 
 			COUNT(*) as total,
 			COUNT(CASE WHEN status = 'resolved' OR status = 'pr_created' THEN 1 END) as resolved,
 			COUNT(CASE WHEN status = 'failed' THEN 1 END) as failed,
-			AVG(EXTRACT(EPOCH FROM (completed_at - created_at))) as avg_resolution_time
+			AVG(EXTRACT(EPOCH FROM (completed_at - created_at))) as avg_resolution_time,
+			COALESCE(SUM(affected_users), 0) as affected_users,
+			COALESCE(SUM(downtime_minutes), 0) as downtime_minutes,
+			COALESCE(MAX(error_rate), 0) as peak_error_rate
 		FROM incidents
 		WHERE 1=1
 	`
@@ -498,18 +1062,44 @@ func (r *IncidentRepository) GetStatistics(filter *IncidentFilter) (*IncidentSta
 		if filter.EndTime != nil {
 			query += fmt.Sprintf(" AND created_at <= $%d", argCount)
 			args = append(args, *filter.EndTime)
+			argCount++
+		}
+		if filter.Team != nil {
+			query += fmt.Sprintf(" AND team = $%d", argCount)
+			args = append(args, *filter.Team)
+			argCount++
+		}
+		if filter.Teams != nil {
+			query += fmt.Sprintf(" AND team = ANY($%d)", argCount)
+			args = append(args, pq.Array(filter.Teams))
+			argCount++
+		}
+		if filter.TenantID != nil {
+			query += fmt.Sprintf(" AND tenant_id = $%d", argCount)
+			args = append(args, *filter.TenantID)
+			argCount++
+		}
+		if filter.ParentID != nil {
+			query += fmt.Sprintf(" AND parent_id = $%d", argCount)
+			args = append(args, *filter.ParentID)
 			// argCount++ not needed after last parameter
 		}
+		if filter.TopLevel {
+			query += " AND parent_id IS NULL"
+		}
 	}
 
 	var stats IncidentStatistics
 	var avgResolutionTime sql.NullFloat64
 
-	err := r.db.QueryRow(query, args...).Scan(
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(
 		&stats.TotalIncidents,
 		&stats.ResolvedIncidents,
 		&stats.FailedIncidents,
 		&avgResolutionTime,
+		&stats.AffectedUsers,
+		&stats.DowntimeMinutes,
+		&stats.PeakErrorRate,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get statistics: %w", err)
@@ -528,15 +1118,60 @@ func (r *IncidentRepository) GetStatistics(filter *IncidentFilter) (*IncidentSta
 	return &stats, nil
 }
 
+// ServiceFailureCount represents the number of failed incidents for a service
+type ServiceFailureCount struct {
+	ServiceName string `json:"service_name"`
+	Failures    int    `json:"failures"`
+}
+
+// GetTopFailingServices returns the services with the most failed incidents created in the time range
+func (r *IncidentRepository) GetTopFailingServices(start, end time.Time, limit int) (_ []ServiceFailureCount, err error) {
+	ctx, span := r.startSpan("GetTopFailingServices")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT service_name, COUNT(*) as failures
+		FROM incidents
+		WHERE status = 'failed' AND created_at >= $1 AND created_at <= $2
+		GROUP BY service_name
+		ORDER BY failures DESC, service_name
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, start, end, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top failing services: %w", err)
+	}
+	defer rows.Close()
+
+	var services []ServiceFailureCount
+	for rows.Next() {
+		var service ServiceFailureCount
+		if err := rows.Scan(&service.ServiceName, &service.Failures); err != nil {
+			return nil, fmt.Errorf("failed to scan service failure count: %w", err)
+		}
+		services = append(services, service)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating service failure counts: %w", err)
+	}
+
+	return services, nil
+}
+
 // DeleteOldIncidents deletes incidents older than the retention period
-func (r *IncidentRepository) DeleteOldIncidents(retentionPeriod time.Duration) (int64, error) {
+func (r *IncidentRepository) DeleteOldIncidents(retentionPeriod time.Duration) (_ int64, err error) {
+	ctx, span := r.startSpan("DeleteOldIncidents")
+	defer func() { tracing.End(span, err) }()
+
 	query := `
 		DELETE FROM incidents
 		WHERE created_at < $1
 	`
 
 	cutoffTime := time.Now().Add(-retentionPeriod)
-	result, err := r.db.Exec(query, cutoffTime)
+	result, err := r.db.ExecContext(ctx, query, cutoffTime)
 	if err != nil {
 		return 0, fmt.Errorf("failed to delete old incidents: %w", err)
 	}
@@ -548,3 +1183,45 @@ func (r *IncidentRepository) DeleteOldIncidents(retentionPeriod time.Duration) (
 
 	return rowsAffected, nil
 }
+
+// OpenIncidentCount represents the number of open incidents with a status and severity
+type OpenIncidentCount struct {
+	Status   models.IncidentStatus `json:"status"`
+	Severity string                `json:"severity"`
+	Count    int                   `json:"count"`
+}
+
+// CountOpenIncidents returns the number of unresolved incidents grouped by status and severity
+func (r *IncidentRepository) CountOpenIncidents() (_ []OpenIncidentCount, err error) {
+	ctx, span := r.startSpan("CountOpenIncidents")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT status, severity, COUNT(*) as count
+		FROM incidents
+		WHERE status IN ('pending', 'workflow_triggered', 'in_progress')
+		GROUP BY status, severity
+		ORDER BY status, severity
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count open incidents: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []OpenIncidentCount
+	for rows.Next() {
+		var count OpenIncidentCount
+		if err := rows.Scan(&count.Status, &count.Severity, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan open incident count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating open incident counts: %w", err)
+	}
+
+	return counts, nil
+}
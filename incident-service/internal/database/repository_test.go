@@ -1,7 +1,9 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -10,6 +12,7 @@ import (
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
 	"github.com/leanovate/gopter/prop"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
 	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
 )
 
@@ -179,7 +182,7 @@ func setupTestDB(t *testing.T) *DB {
 		_ = db.Close()
 	})
 
-	return &DB{db}
+	return &DB{DB: db}
 }
 
 // getTestDatabaseDSN returns the test database connection string
@@ -233,6 +236,16 @@ func setupTestSchema(db *sql.DB) error {
 			completed_at TIMESTAMP
 		);
 
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS retry_count INTEGER NOT NULL DEFAULT 0;
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS next_retry_at TIMESTAMP;
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS fingerprint VARCHAR(64) NOT NULL DEFAULT '';
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS occurrence_count INTEGER NOT NULL DEFAULT 1;
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS parent_id VARCHAR(255);
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS deferred_until TIMESTAMP;
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS snoozed_until TIMESTAMP;
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS team VARCHAR(255) NOT NULL DEFAULT '';
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS duplicate_of VARCHAR(255);
+
 		CREATE TABLE IF NOT EXISTS incident_events (
 			id SERIAL PRIMARY KEY,
 			incident_id VARCHAR(255) NOT NULL,
@@ -241,6 +254,109 @@ func setupTestSchema(db *sql.DB) error {
 			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
 			FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE
 		);
+
+		CREATE TABLE IF NOT EXISTS incident_links (
+			incident_id VARCHAR(255) NOT NULL,
+			related_id VARCHAR(255) NOT NULL,
+			reason TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (incident_id, related_id),
+			CHECK (incident_id < related_id),
+			FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE,
+			FOREIGN KEY (related_id) REFERENCES incidents(id) ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS incident_dispatches (
+			id SERIAL PRIMARY KEY,
+			incident_id VARCHAR(255) NOT NULL,
+			repository VARCHAR(255) NOT NULL,
+			branch VARCHAR(255) NOT NULL,
+			status VARCHAR(50) NOT NULL,
+			pull_request_url TEXT,
+			diagnosis TEXT,
+			error TEXT,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			triggered_at TIMESTAMP,
+			completed_at TIMESTAMP,
+			UNIQUE (incident_id, repository),
+			FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS notification_deliveries (
+			id SERIAL PRIMARY KEY,
+			incident_id VARCHAR(255),
+			event_type VARCHAR(100) NOT NULL,
+			provider VARCHAR(255) NOT NULL,
+			status VARCHAR(50) NOT NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT,
+			message JSONB NOT NULL DEFAULT '{}',
+			next_attempt_at TIMESTAMP,
+			delivered_at TIMESTAMP,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS incident_rollups (
+			granularity VARCHAR(10) NOT NULL,
+			bucket_start TIMESTAMP NOT NULL,
+			service_name VARCHAR(255) NOT NULL,
+			severity VARCHAR(50) NOT NULL,
+			provider VARCHAR(50) NOT NULL,
+			team VARCHAR(255) NOT NULL DEFAULT '',
+			total INTEGER NOT NULL DEFAULT 0,
+			resolved INTEGER NOT NULL DEFAULT 0,
+			failed INTEGER NOT NULL DEFAULT 0,
+			completed INTEGER NOT NULL DEFAULT 0,
+			resolution_seconds DOUBLE PRECISION NOT NULL DEFAULT 0,
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (granularity, bucket_start, service_name, severity, provider, team)
+		);
+
+		CREATE TABLE IF NOT EXISTS service_mappings (
+			service_name VARCHAR(255) PRIMARY KEY,
+			repository VARCHAR(255) NOT NULL,
+			branch VARCHAR(255) NOT NULL DEFAULT 'main',
+			created_by VARCHAR(255) NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+		);
+
+		CREATE TABLE IF NOT EXISTS dispatch_queue_snapshots (
+			repository VARCHAR(255) PRIMARY KEY,
+			active INTEGER NOT NULL DEFAULT 0,
+			queued JSONB NOT NULL DEFAULT '[]',
+			taken_at TIMESTAMP NOT NULL DEFAULT NOW()
+		);
+
+		CREATE TABLE IF NOT EXISTS dispatch_attempts (
+			id SERIAL PRIMARY KEY,
+			incident_id VARCHAR(255) NOT NULL,
+			repository VARCHAR(255) NOT NULL,
+			attempt INTEGER NOT NULL,
+			outcome VARCHAR(50) NOT NULL,
+			http_status INTEGER,
+			run_id BIGINT,
+			error TEXT,
+			attempted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS incident_correlated_alerts (
+			id SERIAL PRIMARY KEY,
+			incident_id VARCHAR(255) NOT NULL,
+			provider VARCHAR(50) NOT NULL,
+			provider_ref VARCHAR(255) NOT NULL DEFAULT '',
+			error_message TEXT NOT NULL,
+			stack_trace TEXT,
+			severity VARCHAR(50) NOT NULL DEFAULT '',
+			fingerprint VARCHAR(64) NOT NULL DEFAULT '',
+			provider_data JSONB,
+			received_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE
+		);
 	`
 
 	_, err := db.Exec(schema)
@@ -354,6 +470,26 @@ func TestIncidentRepository_GetByID(t *testing.T) {
 	}
 }
 
+// Unit test for queries running under the repository's context
+func TestIncidentRepository_WithContext(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	repo := NewIncidentRepository(db).WithContext(ctx)
+
+	if _, err := repo.GetByID("inc_test_002"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancelled context to cancel the query, got %v", err)
+	}
+	if err := repo.LogEvent(&models.IncidentEvent{IncidentID: "inc_test_002", EventType: models.EventWorkflowStep}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancelled context to cancel the insert, got %v", err)
+	}
+}
+
 // Unit test for incident update
 func TestIncidentRepository_Update(t *testing.T) {
 	db := setupTestDB(t)
@@ -405,3 +541,403 @@ func TestIncidentRepository_Update(t *testing.T) {
 		t.Error("expected TriggeredAt to be set")
 	}
 }
+
+func TestIncidentRepository_FindDuplicateIncident(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+
+	repo := NewIncidentRepository(db)
+
+	incident := &models.Incident{
+		ID:           "inc_test_004",
+		ServiceName:  "test-service",
+		Repository:   "org/test-repo",
+		ErrorMessage: "request 123 failed",
+		Severity:     "high",
+		Status:       models.StatusPending,
+		Provider:     "datadog",
+		ProviderData: map[string]interface{}{},
+		Fingerprint:  models.ComputeFingerprint("request 123 failed", nil),
+	}
+	if err := repo.Create(incident); err != nil {
+		t.Fatalf("failed to create incident: %v", err)
+	}
+
+	duplicate, err := repo.FindDuplicateIncident("test-service", models.ComputeFingerprint("request 456 failed", nil), time.Hour)
+	if err != nil {
+		t.Fatalf("FindDuplicateIncident() error = %v", err)
+	}
+	if duplicate == nil || duplicate.ID != incident.ID || duplicate.OccurrenceCount != 1 {
+		t.Fatalf("expected inc_test_004 with one occurrence, got %+v", duplicate)
+	}
+
+	count, err := repo.RecordOccurrence(incident.ID)
+	if err != nil {
+		t.Fatalf("RecordOccurrence() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected occurrence count 2, got %d", count)
+	}
+
+	other, err := repo.FindDuplicateIncident("other-service", incident.Fingerprint, time.Hour)
+	if err != nil {
+		t.Fatalf("FindDuplicateIncident() error = %v", err)
+	}
+	if other != nil {
+		t.Errorf("expected no duplicate for another service, got %s", other.ID)
+	}
+}
+
+func TestIncidentRepository_Groups(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+
+	repo := NewIncidentRepository(db)
+	fingerprint := models.ComputeFingerprint("upstream timeout", nil)
+
+	parent := &models.Incident{
+		ID:           "inc_test_005",
+		ServiceName:  "api-gateway",
+		Repository:   "org/api-gateway",
+		ErrorMessage: "upstream timeout",
+		Severity:     "high",
+		Status:       models.StatusPending,
+		Provider:     "datadog",
+		ProviderData: map[string]interface{}{},
+		Fingerprint:  fingerprint,
+	}
+	if err := repo.Create(parent); err != nil {
+		t.Fatalf("failed to create parent incident: %v", err)
+	}
+
+	parentID, err := repo.FindGroupParent(config.DefaultTenant, "checkout", fingerprint, time.Hour)
+	if err != nil {
+		t.Fatalf("FindGroupParent() error = %v", err)
+	}
+	if parentID != parent.ID {
+		t.Fatalf("expected parent %s, got %q", parent.ID, parentID)
+	}
+
+	// Incidents never group across tenants
+	other, err := repo.FindGroupParent("retail", "checkout", fingerprint, time.Hour)
+	if err != nil {
+		t.Fatalf("FindGroupParent() error = %v", err)
+	}
+	if other != "" {
+		t.Errorf("expected no parent in another tenant, got %s", other)
+	}
+
+	child := &models.Incident{
+		ID:           "inc_test_006",
+		ServiceName:  "checkout",
+		Repository:   "org/checkout",
+		ErrorMessage: "upstream timeout",
+		Severity:     "high",
+		Status:       models.StatusPending,
+		Provider:     "datadog",
+		ProviderData: map[string]interface{}{},
+		Fingerprint:  fingerprint,
+		ParentID:     &parentID,
+	}
+	if err := repo.Create(child); err != nil {
+		t.Fatalf("failed to create child incident: %v", err)
+	}
+
+	// The parent's own service never groups under it
+	same, err := repo.FindGroupParent(config.DefaultTenant, "api-gateway", fingerprint, time.Hour)
+	if err != nil {
+		t.Fatalf("FindGroupParent() error = %v", err)
+	}
+	if same != "" {
+		t.Errorf("expected no parent for the same service, got %s", same)
+	}
+
+	children, err := repo.ListWithFilter(&IncidentFilter{ParentID: &parent.ID})
+	if err != nil {
+		t.Fatalf("ListWithFilter() error = %v", err)
+	}
+	if len(children) != 1 || children[0].ID != child.ID || children[0].ParentID == nil || *children[0].ParentID != parent.ID {
+		t.Errorf("expected %s as the only child, got %+v", child.ID, children)
+	}
+
+	topLevel, err := repo.ListWithFilter(&IncidentFilter{TopLevel: true})
+	if err != nil {
+		t.Fatalf("ListWithFilter() error = %v", err)
+	}
+	if len(topLevel) != 1 || topLevel[0].ID != parent.ID {
+		t.Errorf("expected only %s at the top level, got %+v", parent.ID, topLevel)
+	}
+}
+
+func TestIncidentRepository_Reopen(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+
+	repo := NewIncidentRepository(db)
+
+	completedAt := time.Now().Add(-2 * time.Hour)
+	prURL := "https://github.com/org/test-repo/pull/7"
+	incident := &models.Incident{
+		ID:             "inc_test_007",
+		ServiceName:    "test-service",
+		Repository:     "org/test-repo",
+		ErrorMessage:   "connection pool exhausted",
+		Severity:       "high",
+		Status:         models.StatusPending,
+		Provider:       "datadog",
+		ProviderData:   map[string]interface{}{},
+		Fingerprint:    models.ComputeFingerprint("connection pool exhausted", nil),
+		PullRequestURL: &prURL,
+	}
+	if err := repo.Create(incident); err != nil {
+		t.Fatalf("failed to create incident: %v", err)
+	}
+	incident.Status = models.StatusResolved
+	incident.CompletedAt = &completedAt
+	if err := repo.Update(incident); err != nil {
+		t.Fatalf("failed to resolve incident: %v", err)
+	}
+
+	if stale, err := repo.FindResolvedIncident("test-service", incident.Fingerprint, time.Hour); err != nil || stale != nil {
+		t.Fatalf("expected no incident resolved within the last hour, got %v, %v", stale, err)
+	}
+
+	resolved, err := repo.FindResolvedIncident("test-service", incident.Fingerprint, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("FindResolvedIncident() error = %v", err)
+	}
+	if resolved == nil || resolved.ID != incident.ID {
+		t.Fatalf("expected inc_test_007, got %+v", resolved)
+	}
+
+	count, reopened, err := repo.Reopen(incident.ID)
+	if err != nil {
+		t.Fatalf("Reopen() error = %v", err)
+	}
+	if !reopened || count != 2 {
+		t.Errorf("expected reopen with occurrence count 2, got %v, %d", reopened, count)
+	}
+
+	stored, err := repo.GetByID(incident.ID)
+	if err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+	if stored.Status != models.StatusPending || stored.CompletedAt != nil || stored.PullRequestURL != nil {
+		t.Errorf("expected the resolution to be revoked, got %+v", stored)
+	}
+
+	// Only resolved incidents can be reopened
+	if _, reopened, err := repo.Reopen(incident.ID); err != nil || reopened {
+		t.Errorf("expected a pending incident not to be reopened, got %v, %v", reopened, err)
+	}
+}
+
+func TestIncidentRepository_StartProgress(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+
+	repo := NewIncidentRepository(db)
+
+	incident := &models.Incident{
+		ID:           "inc_test_progress",
+		ServiceName:  "test-service",
+		Repository:   "org/test-repo",
+		ErrorMessage: "bad gateway",
+		Severity:     "high",
+		Status:       models.StatusPending,
+		Provider:     "datadog",
+		ProviderData: map[string]interface{}{},
+	}
+	if err := repo.Create(incident); err != nil {
+		t.Fatalf("failed to create incident: %v", err)
+	}
+
+	// A pending incident has no workflow running yet
+	if started, err := repo.StartProgress(incident.ID); err != nil || started {
+		t.Errorf("expected a pending incident not to start progress, got %v, %v", started, err)
+	}
+
+	if err := repo.UpdateStatus(incident.ID, models.StatusWorkflowTriggered); err != nil {
+		t.Fatalf("failed to trigger workflow: %v", err)
+	}
+	if started, err := repo.StartProgress(incident.ID); err != nil || !started {
+		t.Fatalf("expected progress started, got %v, %v", started, err)
+	}
+	stored, err := repo.GetByID(incident.ID)
+	if err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+	if stored.Status != models.StatusInProgress {
+		t.Errorf("expected in_progress, got %s", stored.Status)
+	}
+
+	// Only the first report starts it
+	if started, err := repo.StartProgress(incident.ID); err != nil || started {
+		t.Errorf("expected an incident in progress not to start again, got %v, %v", started, err)
+	}
+}
+
+func TestIncidentRepository_ResumeDeferred(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+
+	repo := NewIncidentRepository(db)
+
+	later := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	for _, id := range []string{"inc_test_resume_1", "inc_test_resume_2"} {
+		incident := &models.Incident{
+			ID:           id,
+			ServiceName:  "test-service",
+			Repository:   "org/test-repo",
+			ErrorMessage: "bad gateway",
+			Severity:     "high",
+			Status:       models.StatusPending,
+			Provider:     "datadog",
+			ProviderData: map[string]interface{}{},
+		}
+		if err := repo.Create(incident); err != nil {
+			t.Fatalf("failed to create incident: %v", err)
+		}
+		incident.DeferredUntil = &later
+		if err := repo.Update(incident); err != nil {
+			t.Fatalf("failed to defer incident: %v", err)
+		}
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	resumed, err := repo.ResumeDeferred([]string{"inc_test_resume_1", "missing"}, now)
+	if err != nil {
+		t.Fatalf("ResumeDeferred() error = %v", err)
+	}
+	if resumed != 1 {
+		t.Errorf("expected 1 incident resumed, got %d", resumed)
+	}
+
+	for id, want := range map[string]time.Time{"inc_test_resume_1": now, "inc_test_resume_2": later} {
+		stored, err := repo.GetByID(id)
+		if err != nil {
+			t.Fatalf("failed to get incident: %v", err)
+		}
+		if stored.DeferredUntil == nil || !stored.DeferredUntil.Equal(want) {
+			t.Errorf("%s: expected deferral until %v, got %v", id, want, stored.DeferredUntil)
+		}
+	}
+}
+
+func TestIncidentRepository_SetSnooze(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+
+	repo := NewIncidentRepository(db)
+
+	incident := &models.Incident{
+		ID:           "inc_test_008",
+		ServiceName:  "test-service",
+		Repository:   "org/test-repo",
+		ErrorMessage: "disk almost full",
+		Severity:     "low",
+		Status:       models.StatusPending,
+		Provider:     "datadog",
+		ProviderData: map[string]interface{}{},
+		Fingerprint:  models.ComputeFingerprint("disk almost full", nil),
+	}
+	if err := repo.Create(incident); err != nil {
+		t.Fatalf("failed to create incident: %v", err)
+	}
+
+	// Outside the dedup window, only a snoozed incident still absorbs repeats
+	if duplicate, err := repo.FindDuplicateIncident("test-service", incident.Fingerprint, 0); err != nil || duplicate != nil {
+		t.Fatalf("expected no duplicate before snoozing, got %v, %v", duplicate, err)
+	}
+
+	until := time.Now().Add(time.Hour)
+	if err := repo.SetSnooze(incident.ID, &until); err != nil {
+		t.Fatalf("SetSnooze() error = %v", err)
+	}
+
+	duplicate, err := repo.FindDuplicateIncident("test-service", incident.Fingerprint, 0)
+	if err != nil {
+		t.Fatalf("FindDuplicateIncident() error = %v", err)
+	}
+	if duplicate == nil || duplicate.ID != incident.ID || duplicate.SnoozedUntil == nil {
+		t.Fatalf("expected the snoozed incident as duplicate, got %+v", duplicate)
+	}
+
+	if err := repo.SetSnooze(incident.ID, nil); err != nil {
+		t.Fatalf("SetSnooze(nil) error = %v", err)
+	}
+	stored, err := repo.GetByID(incident.ID)
+	if err != nil {
+		t.Fatalf("failed to get incident: %v", err)
+	}
+	if stored.SnoozedUntil != nil {
+		t.Errorf("expected the snooze to be cleared, got %v", stored.SnoozedUntil)
+	}
+
+	if err := repo.SetSnooze("missing", &until); err == nil {
+		t.Error("expected error for missing incident")
+	}
+}
+
+func TestIncidentRepository_GetEventsAfter(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+
+	repo := NewIncidentRepository(db)
+
+	incident := &models.Incident{
+		ID:           "inc_test_events_after",
+		ServiceName:  "test-service",
+		Repository:   "org/test-repo",
+		ErrorMessage: "bad gateway",
+		Severity:     "high",
+		Status:       models.StatusPending,
+		Provider:     "datadog",
+		ProviderData: map[string]interface{}{},
+	}
+	if err := repo.Create(incident); err != nil {
+		t.Fatalf("failed to create incident: %v", err)
+	}
+	for _, eventType := range []models.IncidentEventType{models.EventWorkflowTriggered, models.EventWorkflowInProgress} {
+		if err := repo.LogEvent(&models.IncidentEvent{IncidentID: incident.ID, EventType: eventType, EventData: map[string]interface{}{}}); err != nil {
+			t.Fatalf("failed to log event: %v", err)
+		}
+	}
+
+	all, err := repo.GetEventsAfter(incident.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEventsAfter() error = %v", err)
+	}
+	if len(all) < 2 {
+		t.Fatalf("expected the logged events, got %d", len(all))
+	}
+
+	newer, err := repo.GetEventsAfter(incident.ID, all[len(all)-2].ID)
+	if err != nil {
+		t.Fatalf("GetEventsAfter() error = %v", err)
+	}
+	if len(newer) != 1 || newer[0].EventType != models.EventWorkflowInProgress {
+		t.Errorf("expected only the last event, got %+v", newer)
+	}
+}
@@ -0,0 +1,441 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// RollupGranularity is the size of a rollup time bucket
+type RollupGranularity string
+
+const (
+	RollupHourly RollupGranularity = "hour"
+	RollupDaily  RollupGranularity = "day"
+)
+
+// Valid reports whether g is a supported granularity
+func (g RollupGranularity) Valid() bool {
+	return g == RollupHourly || g == RollupDaily
+}
+
+// Truncate returns the start of the bucket containing t
+func (g RollupGranularity) Truncate(t time.Time) time.Time {
+	if g == RollupDaily {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+	return t.Truncate(time.Hour)
+}
+
+// Next returns the start of the bucket after the one starting at bucketStart
+func (g RollupGranularity) Next(bucketStart time.Time) time.Time {
+	if g == RollupDaily {
+		return bucketStart.AddDate(0, 0, 1)
+	}
+	return bucketStart.Add(time.Hour)
+}
+
+// RollupFilter selects rollup buckets by time range and dimensions
+type RollupFilter struct {
+	Granularity RollupGranularity
+	Start       time.Time // inclusive, matched against bucket starts
+	End         time.Time // exclusive
+	ServiceName *string
+	Severity    *string
+	Provider    *string
+	Team        *string
+	Teams       []string // only rollups of these teams, when not nil
+	TenantID    *string
+}
+
+// RollupPoint holds the aggregated incident counts of one time bucket
+type RollupPoint struct {
+	BucketStart       time.Time `json:"bucket_start"`
+	TotalIncidents    int       `json:"total_incidents"`
+	ResolvedIncidents int       `json:"resolved_incidents"`
+	FailedIncidents   int       `json:"failed_incidents"`
+	SuccessRate       float64   `json:"success_rate"`
+	MeanTimeToResolve float64   `json:"mean_time_to_resolve_seconds"`
+	AffectedUsers     int64     `json:"affected_users"`
+	DowntimeMinutes   int64     `json:"downtime_minutes"`
+	PeakErrorRate     float64   `json:"peak_error_rate"`
+
+	completed         int
+	resolutionSeconds float64
+}
+
+// RefreshRollups recomputes the rollup buckets of the given granularity for
+// incidents created in [start, end). Buckets are replaced rather than
+// incremented, so a range can be refreshed repeatedly as incidents change
+// status. It returns the number of buckets written.
+func (r *IncidentRepository) RefreshRollups(granularity RollupGranularity, start, end time.Time) (_ int64, err error) {
+	ctx, span := r.startSpan("RefreshRollups")
+	defer func() { tracing.End(span, err) }()
+
+	if !granularity.Valid() {
+		return 0, fmt.Errorf("unsupported rollup granularity %q", granularity)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin rollup transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM incident_rollups
+		WHERE granularity = $1 AND bucket_start >= $2 AND bucket_start < $3
+	`, string(granularity), start, end)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear rollups: %w", err)
+	}
+
+	// granularity is validated above, so it is safe to inline for date_trunc
+	query := fmt.Sprintf(`
+		INSERT INTO incident_rollups (
+			granularity, bucket_start, service_name, severity, provider, team, tenant_id,
+			total, resolved, failed, completed, resolution_seconds,
+			affected_users, downtime_minutes, peak_error_rate, updated_at
+		)
+		SELECT
+			$1,
+			date_trunc('%[1]s', created_at) as bucket_start,
+			service_name,
+			severity,
+			provider,
+			team,
+			tenant_id,
+			COUNT(*) as total,
+			COUNT(CASE WHEN status = 'resolved' OR status = 'pr_created' THEN 1 END) as resolved,
+			COUNT(CASE WHEN status = 'failed' THEN 1 END) as failed,
+			COUNT(completed_at) as completed,
+			COALESCE(SUM(EXTRACT(EPOCH FROM (completed_at - created_at))), 0) as resolution_seconds,
+			COALESCE(SUM(affected_users), 0) as affected_users,
+			COALESCE(SUM(downtime_minutes), 0) as downtime_minutes,
+			COALESCE(MAX(error_rate), 0) as peak_error_rate,
+			NOW()
+		FROM incidents
+		WHERE created_at >= $2 AND created_at < $3
+		GROUP BY date_trunc('%[1]s', created_at), service_name, severity, provider, team, tenant_id
+	`, granularity)
+
+	result, err := tx.ExecContext(ctx, query, string(granularity), start, end)
+	if err != nil {
+		return 0, fmt.Errorf("failed to write rollups: %w", err)
+	}
+
+	written, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit rollups: %w", err)
+	}
+
+	return written, nil
+}
+
+// GetRollupTimeSeries returns one point per bucket in the filter's range that
+// has incidents, oldest first, summed across the matching dimensions
+func (r *IncidentRepository) GetRollupTimeSeries(filter *RollupFilter) (_ []RollupPoint, err error) {
+	ctx, span := r.startSpan("GetRollupTimeSeries")
+	defer func() { tracing.End(span, err) }()
+
+	if !filter.Granularity.Valid() {
+		return nil, fmt.Errorf("unsupported rollup granularity %q", filter.Granularity)
+	}
+
+	query := `
+		SELECT
+			bucket_start,
+			SUM(total), SUM(resolved), SUM(failed), SUM(completed), SUM(resolution_seconds),
+			SUM(affected_users), SUM(downtime_minutes), MAX(peak_error_rate)
+		FROM incident_rollups
+		WHERE granularity = $1 AND bucket_start >= $2 AND bucket_start < $3
+	`
+
+	where, args := filter.conditions()
+	query += where + " GROUP BY bucket_start ORDER BY bucket_start"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rollup time series: %w", err)
+	}
+	defer rows.Close()
+
+	points := []RollupPoint{}
+	for rows.Next() {
+		var point RollupPoint
+		if err := rows.Scan(
+			&point.BucketStart,
+			&point.TotalIncidents,
+			&point.ResolvedIncidents,
+			&point.FailedIncidents,
+			&point.completed,
+			&point.resolutionSeconds,
+			&point.AffectedUsers,
+			&point.DowntimeMinutes,
+			&point.PeakErrorRate,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan rollup: %w", err)
+		}
+		point.summarize()
+		points = append(points, point)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rollups: %w", err)
+	}
+
+	return points, nil
+}
+
+// ServiceBucket is the number of incidents of a service created in one rollup bucket
+type ServiceBucket struct {
+	ServiceName string
+	BucketStart time.Time
+	Total       int
+}
+
+// GetRollupBucketsByService returns the incident count of each service in
+// each bucket of the filter's range that has incidents, summed across the
+// other dimensions
+func (r *IncidentRepository) GetRollupBucketsByService(filter *RollupFilter) (_ []ServiceBucket, err error) {
+	ctx, span := r.startSpan("GetRollupBucketsByService")
+	defer func() { tracing.End(span, err) }()
+
+	if !filter.Granularity.Valid() {
+		return nil, fmt.Errorf("unsupported rollup granularity %q", filter.Granularity)
+	}
+
+	query := `
+		SELECT service_name, bucket_start, SUM(total)
+		FROM incident_rollups
+		WHERE granularity = $1 AND bucket_start >= $2 AND bucket_start < $3
+	`
+
+	where, args := filter.conditions()
+	query += where + " GROUP BY service_name, bucket_start ORDER BY service_name, bucket_start"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rollup buckets: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := []ServiceBucket{}
+	for rows.Next() {
+		var bucket ServiceBucket
+		if err := rows.Scan(&bucket.ServiceName, &bucket.BucketStart, &bucket.Total); err != nil {
+			return nil, fmt.Errorf("failed to scan rollup: %w", err)
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rollups: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// TeamStatistics holds the aggregated statistics of the incidents owned by one team
+type TeamStatistics struct {
+	Team string `json:"team"`
+	*IncidentStatistics
+}
+
+// GetRollupStatisticsByTeam computes aggregated statistics for the filter's
+// range per owning team, ordered by team name. Incidents of services without
+// a team are reported under the empty team name.
+func (r *IncidentRepository) GetRollupStatisticsByTeam(filter *RollupFilter) (_ []TeamStatistics, err error) {
+	ctx, span := r.startSpan("GetRollupStatisticsByTeam")
+	defer func() { tracing.End(span, err) }()
+
+	teams := []TeamStatistics{}
+	err = r.groupRollupStatistics(ctx, filter, "team", func(team string, stats *IncidentStatistics) {
+		teams = append(teams, TeamStatistics{Team: team, IncidentStatistics: stats})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team statistics: %w", err)
+	}
+	return teams, nil
+}
+
+// TenantStatistics holds the aggregated statistics of the incidents of one tenant
+type TenantStatistics struct {
+	TenantID string `json:"tenant_id"`
+	*IncidentStatistics
+}
+
+// GetRollupStatisticsByTenant computes aggregated statistics for the filter's
+// range per tenant, ordered by tenant name
+func (r *IncidentRepository) GetRollupStatisticsByTenant(filter *RollupFilter) (_ []TenantStatistics, err error) {
+	ctx, span := r.startSpan("GetRollupStatisticsByTenant")
+	defer func() { tracing.End(span, err) }()
+
+	tenants := []TenantStatistics{}
+	err = r.groupRollupStatistics(ctx, filter, "tenant_id", func(tenant string, stats *IncidentStatistics) {
+		tenants = append(tenants, TenantStatistics{TenantID: tenant, IncidentStatistics: stats})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant statistics: %w", err)
+	}
+	return tenants, nil
+}
+
+// ServiceStatistics holds the aggregated statistics of the incidents of one service
+type ServiceStatistics struct {
+	ServiceName string `json:"service_name"`
+	*IncidentStatistics
+}
+
+// GetRollupStatisticsByService computes aggregated statistics for the
+// filter's range per service, ordered by service name
+func (r *IncidentRepository) GetRollupStatisticsByService(filter *RollupFilter) (_ []ServiceStatistics, err error) {
+	ctx, span := r.startSpan("GetRollupStatisticsByService")
+	defer func() { tracing.End(span, err) }()
+
+	services := []ServiceStatistics{}
+	err = r.groupRollupStatistics(ctx, filter, "service_name", func(service string, stats *IncidentStatistics) {
+		services = append(services, ServiceStatistics{ServiceName: service, IncidentStatistics: stats})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service statistics: %w", err)
+	}
+	return services, nil
+}
+
+// groupRollupStatistics sums the rollups of the filter's range per value of
+// column, and passes each group's statistics to add, ordered by value
+func (r *IncidentRepository) groupRollupStatistics(ctx context.Context, filter *RollupFilter, column string, add func(string, *IncidentStatistics)) error {
+	if !filter.Granularity.Valid() {
+		return fmt.Errorf("unsupported rollup granularity %q", filter.Granularity)
+	}
+
+	// column is one of the callers' constants, so it is safe to inline
+	query := fmt.Sprintf(`
+		SELECT
+			%[1]s,
+			SUM(total), SUM(resolved), SUM(failed), SUM(completed), SUM(resolution_seconds),
+			SUM(affected_users), SUM(downtime_minutes), MAX(peak_error_rate)
+		FROM incident_rollups
+		WHERE granularity = $1 AND bucket_start >= $2 AND bucket_start < $3
+	`, column)
+
+	where, args := filter.conditions()
+	query += where + fmt.Sprintf(" GROUP BY %[1]s ORDER BY %[1]s", column)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var value string
+		var point RollupPoint
+		if err := rows.Scan(
+			&value,
+			&point.TotalIncidents,
+			&point.ResolvedIncidents,
+			&point.FailedIncidents,
+			&point.completed,
+			&point.resolutionSeconds,
+			&point.AffectedUsers,
+			&point.DowntimeMinutes,
+			&point.PeakErrorRate,
+		); err != nil {
+			return fmt.Errorf("failed to scan rollup: %w", err)
+		}
+		add(value, SummarizeRollups([]RollupPoint{point}))
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating rollups: %w", err)
+	}
+
+	return nil
+}
+
+// conditions returns the dimension filters as SQL conditions following the
+// granularity and time range placeholders ($1-$3), with all query arguments
+func (f *RollupFilter) conditions() (string, []interface{}) {
+	args := []interface{}{string(f.Granularity), f.Start, f.End}
+
+	var where string
+	for _, dimension := range []struct {
+		column string
+		value  *string
+	}{
+		{"service_name", f.ServiceName},
+		{"severity", f.Severity},
+		{"provider", f.Provider},
+		{"team", f.Team},
+		{"tenant_id", f.TenantID},
+	} {
+		if dimension.value == nil {
+			continue
+		}
+		args = append(args, *dimension.value)
+		where += fmt.Sprintf(" AND %s = $%d", dimension.column, len(args))
+	}
+	if f.Teams != nil {
+		args = append(args, pq.Array(f.Teams))
+		where += fmt.Sprintf(" AND team = ANY($%d)", len(args))
+	}
+
+	return where, args
+}
+
+// GetRollupStatistics computes aggregated statistics for the filter's range from rollups
+func (r *IncidentRepository) GetRollupStatistics(filter *RollupFilter) (*IncidentStatistics, error) {
+	points, err := r.GetRollupTimeSeries(filter)
+	if err != nil {
+		return nil, err
+	}
+	return SummarizeRollups(points), nil
+}
+
+// SummarizeRollups combines rollup points into overall statistics
+func SummarizeRollups(points []RollupPoint) *IncidentStatistics {
+	var total RollupPoint
+	for _, point := range points {
+		total.TotalIncidents += point.TotalIncidents
+		total.ResolvedIncidents += point.ResolvedIncidents
+		total.FailedIncidents += point.FailedIncidents
+		total.completed += point.completed
+		total.resolutionSeconds += point.resolutionSeconds
+		total.AffectedUsers += point.AffectedUsers
+		total.DowntimeMinutes += point.DowntimeMinutes
+		total.PeakErrorRate = max(total.PeakErrorRate, point.PeakErrorRate)
+	}
+	total.summarize()
+
+	return &IncidentStatistics{
+		TotalIncidents:    total.TotalIncidents,
+		ResolvedIncidents: total.ResolvedIncidents,
+		FailedIncidents:   total.FailedIncidents,
+		SuccessRate:       total.SuccessRate,
+		MeanTimeToResolve: total.MeanTimeToResolve,
+		AffectedUsers:     total.AffectedUsers,
+		DowntimeMinutes:   total.DowntimeMinutes,
+		PeakErrorRate:     total.PeakErrorRate,
+	}
+}
+
+// summarize derives the success rate and mean time to resolve from the counts
+func (p *RollupPoint) summarize() {
+	p.SuccessRate = 0
+	if p.TotalIncidents > 0 {
+		p.SuccessRate = float64(p.ResolvedIncidents) / float64(p.TotalIncidents)
+	}
+	p.MeanTimeToResolve = 0
+	if p.completed > 0 {
+		p.MeanTimeToResolve = p.resolutionSeconds / float64(p.completed)
+	}
+}
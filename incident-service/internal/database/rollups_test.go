@@ -0,0 +1,177 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestRollupGranularity_Buckets(t *testing.T) {
+	at := time.Date(2024, 3, 6, 14, 37, 12, 0, time.UTC)
+
+	tests := []struct {
+		granularity RollupGranularity
+		start       time.Time
+		next        time.Time
+	}{
+		{RollupHourly, time.Date(2024, 3, 6, 14, 0, 0, 0, time.UTC), time.Date(2024, 3, 6, 15, 0, 0, 0, time.UTC)},
+		{RollupDaily, time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.granularity), func(t *testing.T) {
+			start := tt.granularity.Truncate(at)
+			if !start.Equal(tt.start) {
+				t.Errorf("Truncate() = %v, want %v", start, tt.start)
+			}
+			if next := tt.granularity.Next(start); !next.Equal(tt.next) {
+				t.Errorf("Next() = %v, want %v", next, tt.next)
+			}
+		})
+	}
+
+	if RollupGranularity("week").Valid() {
+		t.Error("expected week to be an unsupported granularity")
+	}
+}
+
+func TestRollupFilter_Conditions(t *testing.T) {
+	team, tenant := "payments", "retail"
+	filter := &RollupFilter{Granularity: RollupHourly, Team: &team, TenantID: &tenant, Teams: []string{"payments", "search"}}
+
+	where, args := filter.conditions()
+	if want := " AND team = $4 AND tenant_id = $5 AND team = ANY($6)"; where != want {
+		t.Errorf("conditions() = %q, want %q", where, want)
+	}
+	if len(args) != 6 {
+		t.Errorf("expected 6 arguments, got %d", len(args))
+	}
+}
+
+func TestSummarizeRollups(t *testing.T) {
+	points := []RollupPoint{
+		{TotalIncidents: 3, ResolvedIncidents: 2, FailedIncidents: 1, completed: 3, resolutionSeconds: 900,
+			AffectedUsers: 1200, DowntimeMinutes: 30, PeakErrorRate: 0.2},
+		{TotalIncidents: 1, ResolvedIncidents: 1, completed: 1, resolutionSeconds: 300,
+			AffectedUsers: 300, DowntimeMinutes: 15, PeakErrorRate: 0.05},
+	}
+
+	stats := SummarizeRollups(points)
+	if stats.TotalIncidents != 4 || stats.ResolvedIncidents != 3 || stats.FailedIncidents != 1 {
+		t.Errorf("unexpected counts: %+v", stats)
+	}
+	if stats.SuccessRate != 0.75 {
+		t.Errorf("expected success rate 0.75, got %v", stats.SuccessRate)
+	}
+	// MTTR is averaged over incidents, not over buckets
+	if stats.MeanTimeToResolve != 300 {
+		t.Errorf("expected mean time to resolve 300s, got %v", stats.MeanTimeToResolve)
+	}
+	// Impact is summed, except for the error rate, which peaks
+	if stats.AffectedUsers != 1500 || stats.DowntimeMinutes != 45 || stats.PeakErrorRate != 0.2 {
+		t.Errorf("unexpected impact: %+v", stats)
+	}
+
+	empty := SummarizeRollups(nil)
+	if empty.TotalIncidents != 0 || empty.SuccessRate != 0 || empty.MeanTimeToResolve != 0 {
+		t.Errorf("expected zero statistics without rollups, got %+v", empty)
+	}
+}
+
+func TestIncidentRepository_RefreshRollups(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+	if err := cleanupTestData(db.DB); err != nil {
+		t.Fatalf("failed to clean up test data: %v", err)
+	}
+	defer func() { _, _ = db.Exec("TRUNCATE TABLE incident_rollups") }()
+
+	repo := NewIncidentRepository(db)
+
+	for i, status := range []models.IncidentStatus{models.StatusPRCreated, models.StatusFailed, models.StatusPending} {
+		incident := &models.Incident{
+			ID:           "inc_test_rollup_" + string(rune('a'+i)),
+			ServiceName:  "checkout",
+			ErrorMessage: "test error",
+			Severity:     "high",
+			Status:       models.StatusPending,
+			Provider:     "datadog",
+			Team:         "storefront",
+		}
+		if err := repo.Create(incident); err != nil {
+			t.Fatalf("failed to create incident: %v", err)
+		}
+		if status != models.StatusPending {
+			completed := incident.CreatedAt.Add(10 * time.Minute)
+			incident.Status = status
+			incident.CompletedAt = &completed
+			if err := repo.Update(incident); err != nil {
+				t.Fatalf("failed to update incident: %v", err)
+			}
+		}
+	}
+
+	users, rate, minutes := int64(1200), 0.3, 25
+	if err := repo.SetImpact("inc_test_rollup_a", models.Impact{AffectedUsers: &users, ErrorRate: &rate}); err != nil {
+		t.Fatalf("SetImpact() error = %v", err)
+	}
+	if err := repo.SetImpact("inc_test_rollup_a", models.Impact{DowntimeMinutes: &minutes}); err != nil {
+		t.Fatalf("SetImpact() error = %v", err)
+	}
+	impacted, err := repo.GetByID("inc_test_rollup_a")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	// Fields left out of an update keep their values
+	if impacted.AffectedUsers == nil || *impacted.AffectedUsers != users || impacted.ErrorRate == nil || *impacted.ErrorRate != rate ||
+		impacted.DowntimeMinutes == nil || *impacted.DowntimeMinutes != minutes {
+		t.Errorf("unexpected impact: %v %v %v", impacted.AffectedUsers, impacted.ErrorRate, impacted.DowntimeMinutes)
+	}
+
+	now := time.Now()
+	start := RollupHourly.Truncate(now.Add(-time.Hour))
+	end := RollupHourly.Next(RollupHourly.Truncate(now))
+
+	// Refreshing twice must not double count
+	for i := 0; i < 2; i++ {
+		if _, err := repo.RefreshRollups(RollupHourly, start, end); err != nil {
+			t.Fatalf("RefreshRollups() error = %v", err)
+		}
+	}
+
+	service := "checkout"
+	stats, err := repo.GetRollupStatistics(&RollupFilter{Granularity: RollupHourly, Start: start, End: end, ServiceName: &service})
+	if err != nil {
+		t.Fatalf("GetRollupStatistics() error = %v", err)
+	}
+	if stats.TotalIncidents != 3 || stats.ResolvedIncidents != 1 || stats.FailedIncidents != 1 {
+		t.Errorf("unexpected rollup statistics: %+v", stats)
+	}
+	if stats.MeanTimeToResolve < 599 || stats.MeanTimeToResolve > 601 {
+		t.Errorf("expected mean time to resolve of about 600s, got %v", stats.MeanTimeToResolve)
+	}
+	if stats.AffectedUsers != users || stats.DowntimeMinutes != int64(minutes) || stats.PeakErrorRate != rate {
+		t.Errorf("unexpected rollup impact: %+v", stats)
+	}
+
+	other := "payments"
+	points, err := repo.GetRollupTimeSeries(&RollupFilter{Granularity: RollupHourly, Start: start, End: end, ServiceName: &other})
+	if err != nil {
+		t.Fatalf("GetRollupTimeSeries() error = %v", err)
+	}
+	if len(points) != 0 {
+		t.Errorf("expected no buckets for another service, got %+v", points)
+	}
+
+	teams, err := repo.GetRollupStatisticsByTeam(&RollupFilter{Granularity: RollupHourly, Start: start, End: end})
+	if err != nil {
+		t.Fatalf("GetRollupStatisticsByTeam() error = %v", err)
+	}
+	if len(teams) != 1 || teams[0].Team != "storefront" || teams[0].TotalIncidents != 3 || teams[0].ResolvedIncidents != 1 {
+		t.Errorf("unexpected team statistics: %+v", teams)
+	}
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// ServiceIncidentCount is the number of incidents of a service with a status
+// and severity, and when the latest of them was created
+type ServiceIncidentCount struct {
+	ServiceName   string
+	Status        models.IncidentStatus
+	Severity      string
+	Count         int
+	LastCreatedAt time.Time
+}
+
+// CountIncidentsByService returns the number of incidents per service, status
+// and severity across all time, honouring the filter's service, team and
+// tenant. The counts come from the incidents themselves rather than rollups,
+// so open incidents are current.
+func (r *IncidentRepository) CountIncidentsByService(filter *IncidentFilter) (_ []ServiceIncidentCount, err error) {
+	ctx, span := r.startSpan("CountIncidentsByService")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT service_name, status, severity, COUNT(*), MAX(created_at)
+		FROM incidents
+		WHERE 1=1
+	`
+
+	args := []interface{}{}
+	if filter != nil {
+		if filter.ServiceName != nil {
+			args = append(args, *filter.ServiceName)
+			query += fmt.Sprintf(" AND service_name = $%d", len(args))
+		}
+		if filter.Team != nil {
+			args = append(args, *filter.Team)
+			query += fmt.Sprintf(" AND team = $%d", len(args))
+		}
+		if filter.Teams != nil {
+			args = append(args, pq.Array(filter.Teams))
+			query += fmt.Sprintf(" AND team = ANY($%d)", len(args))
+		}
+		if filter.TenantID != nil {
+			args = append(args, *filter.TenantID)
+			query += fmt.Sprintf(" AND tenant_id = $%d", len(args))
+		}
+	}
+	query += " GROUP BY service_name, status, severity ORDER BY service_name, status, severity"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count incidents by service: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []ServiceIncidentCount{}
+	for rows.Next() {
+		var count ServiceIncidentCount
+		if err := rows.Scan(&count.ServiceName, &count.Status, &count.Severity, &count.Count, &count.LastCreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan service incident count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating service incident counts: %w", err)
+	}
+
+	return counts, nil
+}
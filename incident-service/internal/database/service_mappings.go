@@ -0,0 +1,52 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// SaveServiceMapping maps a service to a repository, replacing any mapping
+// the service already has. createdBy names who mapped it.
+func (r *IncidentRepository) SaveServiceMapping(mapping *models.ServiceMapping, createdBy string) (err error) {
+	ctx, span := r.startSpan("SaveServiceMapping")
+	defer func() { tracing.End(span, err) }()
+
+	now := time.Now()
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO service_mappings (service_name, repository, branch, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (service_name) DO UPDATE
+		SET repository = EXCLUDED.repository, branch = EXCLUDED.branch,
+			created_by = EXCLUDED.created_by, updated_at = EXCLUDED.updated_at
+	`, mapping.ServiceName, mapping.Repository, mapping.Branch, createdBy, now)
+	if err != nil {
+		return fmt.Errorf("failed to save service mapping: %w", err)
+	}
+	return nil
+}
+
+// GetServiceMapping returns the mapping made through the API for a service,
+// or nil when it has none
+func (r *IncidentRepository) GetServiceMapping(serviceName string) (_ *models.ServiceMapping, err error) {
+	ctx, span := r.startSpan("GetServiceMapping")
+	defer func() { tracing.End(span, err) }()
+
+	var mapping models.ServiceMapping
+	err = r.db.QueryRowContext(ctx, `
+		SELECT service_name, repository, branch
+		FROM service_mappings
+		WHERE service_name = $1
+	`, serviceName).Scan(&mapping.ServiceName, &mapping.Repository, &mapping.Branch)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service mapping: %w", err)
+	}
+	return &mapping, nil
+}
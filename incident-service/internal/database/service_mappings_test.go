@@ -0,0 +1,39 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestIncidentRepository_ServiceMappings(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		t.Skip("test database not configured")
+	}
+	defer db.Close()
+	if _, err := db.Exec("DELETE FROM service_mappings WHERE service_name = 'test-batch'"); err != nil {
+		t.Fatalf("failed to clean up service mappings: %v", err)
+	}
+
+	repo := NewIncidentRepository(db)
+
+	if mapping, err := repo.GetServiceMapping("test-batch"); err != nil || mapping != nil {
+		t.Fatalf("GetServiceMapping() = %v, %v, want no mapping", mapping, err)
+	}
+
+	if err := repo.SaveServiceMapping(&models.ServiceMapping{ServiceName: "test-batch", Repository: "org/batch", Branch: "main"}, "admin"); err != nil {
+		t.Fatalf("SaveServiceMapping() error = %v", err)
+	}
+	if err := repo.SaveServiceMapping(&models.ServiceMapping{ServiceName: "test-batch", Repository: "org/jobs", Branch: "develop"}, "admin"); err != nil {
+		t.Fatalf("SaveServiceMapping() error = %v", err)
+	}
+
+	mapping, err := repo.GetServiceMapping("test-batch")
+	if err != nil {
+		t.Fatalf("GetServiceMapping() error = %v", err)
+	}
+	if mapping == nil || mapping.Repository != "org/jobs" || mapping.Branch != "develop" {
+		t.Errorf("expected the mapping replaced, got %+v", mapping)
+	}
+}
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/severity"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+)
+
+// ListSeveritySamples returns the incidents created since the given time
+// whose severity came from their provider, most recent first, for training
+// the severity classifier. Incidents merged into another are left out.
+func (r *IncidentRepository) ListSeveritySamples(since time.Time, limit int) (_ []severity.Sample, err error) {
+	ctx, span := r.startSpan("ListSeveritySamples")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT service_name, error_message, severity
+		FROM incidents
+		WHERE created_at >= $1 AND NOT severity_defaulted AND duplicate_of IS NULL
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list severity samples: %w", err)
+	}
+	defer rows.Close()
+
+	var samples []severity.Sample
+	for rows.Next() {
+		var sample severity.Sample
+		if err := rows.Scan(&sample.ServiceName, &sample.ErrorMessage, &sample.Severity); err != nil {
+			return nil, fmt.Errorf("failed to scan severity sample: %w", err)
+		}
+		samples = append(samples, sample)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating severity samples: %w", err)
+	}
+
+	return samples, nil
+}
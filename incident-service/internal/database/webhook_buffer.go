@@ -0,0 +1,244 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrBufferFull is returned when the webhook buffer has no room left
+var ErrBufferFull = errors.New("webhook buffer full")
+
+// BufferedWebhook is an accepted webhook kept while the database is
+// unavailable, to be processed once it recovers
+type BufferedWebhook struct {
+	Provider   string    `json:"provider"`
+	Body       []byte    `json:"body"`
+	ReceivedAt time.Time `json:"received_at"`
+	RequestID  string    `json:"request_id,omitempty"`
+}
+
+// WebhookBuffer holds accepted webhooks in arrival order
+type WebhookBuffer interface {
+	// Push appends a webhook, or returns ErrBufferFull
+	Push(ctx context.Context, webhook *BufferedWebhook) error
+	// Pop removes and returns the oldest webhook, or nil when the buffer is empty
+	Pop(ctx context.Context) (*BufferedWebhook, error)
+	// Len returns the number of buffered webhooks
+	Len(ctx context.Context) (int, error)
+}
+
+// RedisWebhookBuffer keeps buffered webhooks in a Redis list shared by every
+// replica, so any replica can drain them
+type RedisWebhookBuffer struct {
+	client  redis.Cmdable
+	key     string
+	maxSize int
+	cipher  *Cipher // encrypts buffered webhooks, nil when encryption is disabled
+}
+
+// NewRedisWebhookBuffer creates a buffer on the Redis list at key
+func NewRedisWebhookBuffer(client redis.Cmdable, key string, maxSize int) *RedisWebhookBuffer {
+	return &RedisWebhookBuffer{client: client, key: key, maxSize: maxSize}
+}
+
+// SetCipher encrypts the webhooks buffered from now on. Webhooks buffered in
+// plaintext stay readable.
+func (b *RedisWebhookBuffer) SetCipher(cipher *Cipher) {
+	b.cipher = cipher
+}
+
+// Push appends a webhook to the list. The size limit is checked before the
+// push, so replicas buffering at the same time can overshoot it slightly.
+func (b *RedisWebhookBuffer) Push(ctx context.Context, webhook *BufferedWebhook) error {
+	n, err := b.Len(ctx)
+	if err != nil {
+		return err
+	}
+	if n >= b.maxSize {
+		return ErrBufferFull
+	}
+
+	data, err := sealWebhook(b.cipher, webhook)
+	if err != nil {
+		return err
+	}
+	if err := b.client.RPush(ctx, b.key, data).Err(); err != nil {
+		return fmt.Errorf("failed to buffer webhook: %w", err)
+	}
+	return nil
+}
+
+// Pop removes and returns the oldest webhook
+func (b *RedisWebhookBuffer) Pop(ctx context.Context) (*BufferedWebhook, error) {
+	data, err := b.client.LPop(ctx, b.key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to pop buffered webhook: %w", err)
+	}
+	return openWebhook(b.cipher, data)
+}
+
+// Len returns the length of the list
+func (b *RedisWebhookBuffer) Len(ctx context.Context) (int, error) {
+	n, err := b.client.LLen(ctx, b.key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get webhook buffer length: %w", err)
+	}
+	return int(n), nil
+}
+
+// DiskWebhookBuffer spools buffered webhooks to files in a local directory.
+// It keeps working when Redis is down too, but only the replica that wrote
+// the files can drain them.
+type DiskWebhookBuffer struct {
+	dir     string
+	maxSize int
+	cipher  *Cipher // encrypts spooled webhooks, nil when encryption is disabled
+	mu      sync.Mutex
+	seq     uint64
+}
+
+// NewDiskWebhookBuffer creates a buffer spooling to dir, creating it if needed
+func NewDiskWebhookBuffer(dir string, maxSize int) (*DiskWebhookBuffer, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create spool directory: %w", err)
+	}
+	return &DiskWebhookBuffer{dir: dir, maxSize: maxSize}, nil
+}
+
+// SetCipher encrypts the webhooks spooled from now on. Webhooks spooled in
+// plaintext stay readable.
+func (b *DiskWebhookBuffer) SetCipher(cipher *Cipher) {
+	b.cipher = cipher
+}
+
+// Push writes a webhook to its own file. Files are named by arrival time, so
+// that listing the directory returns them in order, and renamed into place
+// once written, so that a crash never leaves a partial webhook behind.
+func (b *DiskWebhookBuffer) Push(ctx context.Context, webhook *BufferedWebhook) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	files, err := b.files()
+	if err != nil {
+		return err
+	}
+	if len(files) >= b.maxSize {
+		return ErrBufferFull
+	}
+
+	data, err := sealWebhook(b.cipher, webhook)
+	if err != nil {
+		return err
+	}
+
+	b.seq++
+	name := fmt.Sprintf("%020d-%06d.json", time.Now().UnixNano(), b.seq%1000000)
+	tmp := filepath.Join(b.dir, name+".tmp")
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to buffer webhook: %w", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(b.dir, name)); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to buffer webhook: %w", err)
+	}
+	return nil
+}
+
+// Pop reads and removes the oldest file
+func (b *DiskWebhookBuffer) Pop(ctx context.Context) (*BufferedWebhook, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	files, err := b.files()
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+
+	path := filepath.Join(b.dir, files[0])
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read buffered webhook: %w", err)
+	}
+	if err := os.Remove(path); err != nil {
+		return nil, fmt.Errorf("failed to remove buffered webhook: %w", err)
+	}
+
+	webhook, err := openWebhook(b.cipher, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", files[0], err)
+	}
+	return webhook, nil
+}
+
+// Len returns the number of spooled files
+func (b *DiskWebhookBuffer) Len(ctx context.Context) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	files, err := b.files()
+	return len(files), err
+}
+
+// files returns the names of the spooled webhooks, oldest first
+func (b *DiskWebhookBuffer) files() ([]string, error) {
+	entries, err := os.ReadDir(b.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spool directory: %w", err)
+	}
+
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			files = append(files, entry.Name())
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// sealWebhook marshals a webhook for buffering. With a cipher, the whole
+// record is encrypted, since the body carries the provider's payload.
+func sealWebhook(cipher *Cipher, webhook *BufferedWebhook) ([]byte, error) {
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal buffered webhook: %w", err)
+	}
+	if cipher == nil {
+		return data, nil
+	}
+	sealed, err := cipher.Encrypt(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt buffered webhook: %w", err)
+	}
+	return []byte(sealed), nil
+}
+
+// openWebhook unmarshals a webhook written by sealWebhook
+func openWebhook(cipher *Cipher, data []byte) (*BufferedWebhook, error) {
+	if encrypted(string(data)) {
+		opened, err := cipher.Decrypt(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt buffered webhook: %w", err)
+		}
+		data = []byte(opened)
+	}
+
+	var webhook BufferedWebhook
+	if err := json.Unmarshal(data, &webhook); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal buffered webhook: %w", err)
+	}
+	return &webhook, nil
+}
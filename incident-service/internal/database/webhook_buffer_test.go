@@ -0,0 +1,136 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiskWebhookBuffer(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "spool")
+	buffer, err := NewDiskWebhookBuffer(dir, 2)
+	if err != nil {
+		t.Fatalf("NewDiskWebhookBuffer() error = %v", err)
+	}
+
+	received := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, provider := range []string{"datadog", "sentry"} {
+		webhook := &BufferedWebhook{Provider: provider, Body: []byte(`{"id":1}`), ReceivedAt: received, RequestID: "req-" + provider}
+		if err := buffer.Push(ctx, webhook); err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+	}
+	if err := buffer.Push(ctx, &BufferedWebhook{Provider: "grafana"}); !errors.Is(err, ErrBufferFull) {
+		t.Errorf("expected ErrBufferFull, got %v", err)
+	}
+
+	// Webhooks outlive the buffer that spooled them, and a partially written
+	// file is never read
+	if err := os.WriteFile(filepath.Join(dir, "00000000000000000000-000000.json.tmp"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	buffer, err = NewDiskWebhookBuffer(dir, 2)
+	if err != nil {
+		t.Fatalf("NewDiskWebhookBuffer() error = %v", err)
+	}
+	if n, err := buffer.Len(ctx); err != nil || n != 2 {
+		t.Fatalf("Len() = %d, %v, want 2", n, err)
+	}
+
+	first, err := buffer.Pop(ctx)
+	if err != nil {
+		t.Fatalf("Pop() error = %v", err)
+	}
+	if first.Provider != "datadog" || string(first.Body) != `{"id":1}` || !first.ReceivedAt.Equal(received) || first.RequestID != "req-datadog" {
+		t.Errorf("unexpected first webhook: %+v", first)
+	}
+	if second, err := buffer.Pop(ctx); err != nil || second.Provider != "sentry" {
+		t.Errorf("Pop() = %+v, %v, want the sentry webhook", second, err)
+	}
+	if empty, err := buffer.Pop(ctx); err != nil || empty != nil {
+		t.Errorf("Pop() on an empty buffer = %+v, %v", empty, err)
+	}
+}
+
+func TestDiskWebhookBuffer_Encrypted(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	c, err := NewCipher(testKey(1))
+	if err != nil {
+		t.Fatalf("NewCipher() error = %v", err)
+	}
+
+	// A webhook spooled before encryption was enabled stays readable
+	plain, err := NewDiskWebhookBuffer(dir, 10)
+	if err != nil {
+		t.Fatalf("NewDiskWebhookBuffer() error = %v", err)
+	}
+	if err := plain.Push(ctx, &BufferedWebhook{Provider: "datadog", Body: []byte(`{"id":1}`)}); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	buffer, err := NewDiskWebhookBuffer(dir, 10)
+	if err != nil {
+		t.Fatalf("NewDiskWebhookBuffer() error = %v", err)
+	}
+	buffer.SetCipher(c)
+	if err := buffer.Push(ctx, &BufferedWebhook{Provider: "sentry", Body: []byte(`{"secret":"hunter2"}`)}); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	files, err := buffer.files()
+	if err != nil || len(files) != 2 {
+		t.Fatalf("files() = %v, %v, want 2 files", files, err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, files[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), encryptedPrefix) || strings.Contains(string(data), "sentry") {
+		t.Errorf("expected the spooled webhook encrypted, got %s", data)
+	}
+
+	for _, want := range []string{`{"id":1}`, `{"secret":"hunter2"}`} {
+		webhook, err := buffer.Pop(ctx)
+		if err != nil || webhook == nil || string(webhook.Body) != want {
+			t.Fatalf("Pop() = %+v, %v, want body %s", webhook, err, want)
+		}
+	}
+}
+
+func TestRedisWebhookBuffer(t *testing.T) {
+	ctx := context.Background()
+	client, err := ConnectRedis("localhost:6379", "", 0)
+	if err != nil {
+		t.Skipf("test redis not configured: %v", err)
+	}
+	defer client.Close()
+
+	key := "test:ingestion-buffer:" + time.Now().Format(time.RFC3339Nano)
+	defer client.Del(ctx, key)
+	buffer := NewRedisWebhookBuffer(client, key, 2)
+
+	for _, provider := range []string{"datadog", "sentry"} {
+		if err := buffer.Push(ctx, &BufferedWebhook{Provider: provider}); err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+	}
+	if err := buffer.Push(ctx, &BufferedWebhook{Provider: "grafana"}); !errors.Is(err, ErrBufferFull) {
+		t.Errorf("expected ErrBufferFull, got %v", err)
+	}
+
+	for _, want := range []string{"datadog", "sentry"} {
+		webhook, err := buffer.Pop(ctx)
+		if err != nil || webhook == nil || webhook.Provider != want {
+			t.Fatalf("Pop() = %+v, %v, want %s", webhook, err, want)
+		}
+	}
+	if empty, err := buffer.Pop(ctx); err != nil || empty != nil {
+		t.Errorf("Pop() on an empty buffer = %+v, %v", empty, err)
+	}
+}
@@ -0,0 +1,192 @@
+// Package enrichment gathers context about an incident from MCP servers, such
+// as recent logs, metrics and deploys of its service, before its remediation
+// workflow is dispatched.
+package enrichment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/mcp"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/textutil"
+)
+
+// toolCaller calls the tools of one MCP server
+type toolCaller interface {
+	CallTool(ctx context.Context, name string, arguments map[string]interface{}) (*mcp.ToolResult, error)
+}
+
+// query is a configured query with its argument templates parsed
+type query struct {
+	config    config.EnrichmentQuery
+	arguments map[string]*template.Template
+}
+
+// Enricher runs the configured queries for an incident
+type Enricher struct {
+	config  config.EnrichmentConfig
+	clients map[string]toolCaller
+	queries []query
+}
+
+// NewEnricher creates an enricher querying the servers named by cfg's queries
+func NewEnricher(cfg config.EnrichmentConfig, servers []config.MCPServerConfig) (*Enricher, error) {
+	if err := cfg.Validate(servers); err != nil {
+		return nil, err
+	}
+
+	e := &Enricher{config: cfg, clients: make(map[string]toolCaller)}
+	for _, server := range servers {
+		if server.Type == config.MCPTypeHTTP {
+			e.clients[server.Name] = mcp.NewClient(server.URL(), server.Token())
+		}
+	}
+	for _, q := range cfg.Queries {
+		parsed := query{config: q, arguments: make(map[string]*template.Template)}
+		for name, value := range q.Arguments {
+			tmpl, err := template.New(name).Option("missingkey=zero").Parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse argument %s of query %s: %w", name, q.Name, err)
+			}
+			parsed.arguments[name] = tmpl
+		}
+		e.queries = append(e.queries, parsed)
+	}
+	return e, nil
+}
+
+// Bundle is the context gathered for an incident
+type Bundle struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Sources     []Source  `json:"sources"`
+}
+
+// Source is the result of one query
+type Source struct {
+	Name      string `json:"name"`
+	Server    string `json:"server"`
+	Content   string `json:"content,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// queryData is what argument templates are rendered with
+type queryData struct {
+	IncidentID  string
+	ServiceName string
+	Repository  string
+	Severity    string
+	Since       string
+	Until       string
+}
+
+// Enrich runs every query for the incident at once, within the configured
+// timeout. A query that fails is recorded in its source rather than failing
+// the bundle.
+func (e *Enricher) Enrich(ctx context.Context, incident *models.Incident) *Bundle {
+	ctx, cancel := context.WithTimeout(ctx, e.config.QueryTimeout())
+	defer cancel()
+
+	data := queryData{
+		IncidentID:  incident.ID,
+		ServiceName: incident.ServiceName,
+		Repository:  incident.Repository,
+		Severity:    incident.Severity,
+		Since:       incident.CreatedAt.Add(-e.config.LookbackWindow()).UTC().Format(time.RFC3339),
+		Until:       time.Now().UTC().Format(time.RFC3339),
+	}
+
+	bundle := &Bundle{GeneratedAt: time.Now().UTC(), Sources: make([]Source, len(e.queries))}
+	var wg sync.WaitGroup
+	for i, q := range e.queries {
+		wg.Add(1)
+		go func(i int, q query) {
+			defer wg.Done()
+			bundle.Sources[i] = e.run(ctx, q, data)
+		}(i, q)
+	}
+	wg.Wait()
+	return bundle
+}
+
+// run runs one query
+func (e *Enricher) run(ctx context.Context, q query, data queryData) Source {
+	source := Source{Name: q.config.Name, Server: q.config.Server}
+
+	arguments := make(map[string]interface{}, len(q.arguments))
+	for name, tmpl := range q.arguments {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			source.Error = fmt.Sprintf("failed to render argument %s: %v", name, err)
+			return source
+		}
+		arguments[name] = buf.String()
+	}
+
+	result, err := e.clients[q.config.Server].CallTool(ctx, q.config.Tool, arguments)
+	if err != nil {
+		source.Error = err.Error()
+		return source
+	}
+	if result.IsError {
+		source.Error = result.Text()
+		return source
+	}
+	source.Content = result.Text()
+	return source
+}
+
+// Failed returns the names of the sources whose query failed
+func (b *Bundle) Failed() []string {
+	var failed []string
+	for _, source := range b.Sources {
+		if source.Error != "" {
+			failed = append(failed, source.Name)
+		}
+	}
+	sort.Strings(failed)
+	return failed
+}
+
+// Encode returns the bundle as JSON of at most limit bytes. The contents of
+// the sources share the room left by the rest of the bundle, and those that
+// do not fit are cut and marked truncated.
+func (b *Bundle) Encode(limit int) (string, error) {
+	encoded, err := json.Marshal(b)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal context bundle: %w", err)
+	}
+	if len(encoded) <= limit || len(b.Sources) == 0 {
+		return string(encoded), nil
+	}
+
+	// Shrink the share of each source until the escaped JSON fits
+	share := limit / len(b.Sources)
+	for share > 0 {
+		trimmed := Bundle{GeneratedAt: b.GeneratedAt, Sources: make([]Source, len(b.Sources))}
+		for i, source := range b.Sources {
+			content := textutil.Truncate(source.Content, share)
+			source.Content, source.Truncated = content, len(content) < len(source.Content)
+			source.Error = textutil.Truncate(source.Error, share)
+			trimmed.Sources[i] = source
+		}
+
+		encoded, err = json.Marshal(trimmed)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal context bundle: %w", err)
+		}
+		if len(encoded) <= limit {
+			return string(encoded), nil
+		}
+		share = share * 9 / 10
+	}
+	return "", fmt.Errorf("context bundle does not fit in %d bytes", limit)
+}
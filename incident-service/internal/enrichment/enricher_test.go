@@ -0,0 +1,109 @@
+package enrichment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/mcp"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// fakeCaller answers tool calls with a canned result per tool
+type fakeCaller struct {
+	mu      sync.Mutex
+	results map[string]*mcp.ToolResult
+	calls   map[string]map[string]interface{}
+}
+
+func (f *fakeCaller) CallTool(ctx context.Context, name string, arguments map[string]interface{}) (*mcp.ToolResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls[name] = arguments
+	result, ok := f.results[name]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	return result, nil
+}
+
+func newTestEnricher(t *testing.T, caller *fakeCaller, queries ...config.EnrichmentQuery) *Enricher {
+	t.Helper()
+	servers := []config.MCPServerConfig{{Name: "observability", Type: config.MCPTypeHTTP, Config: map[string]string{"url": "http://mcp.local"}}}
+	enricher, err := NewEnricher(config.EnrichmentConfig{Enabled: true, Queries: queries}, servers)
+	if err != nil {
+		t.Fatalf("NewEnricher() error = %v", err)
+	}
+	enricher.clients["observability"] = caller
+	return enricher
+}
+
+func TestEnricher_Enrich(t *testing.T) {
+	caller := &fakeCaller{
+		results: map[string]*mcp.ToolResult{
+			"search_logs": {Content: []mcp.Content{{Type: "text", Text: "NullPointerException x42"}}},
+			"deploys":     {Content: []mcp.Content{{Type: "text", Text: "no access"}}, IsError: true},
+		},
+		calls: make(map[string]map[string]interface{}),
+	}
+	enricher := newTestEnricher(t, caller,
+		config.EnrichmentQuery{Name: "logs", Server: "observability", Tool: "search_logs", Arguments: map[string]string{"query": "service:{{.ServiceName}} status:error", "from": "{{.Since}}"}},
+		config.EnrichmentQuery{Name: "deploys", Server: "observability", Tool: "deploys"},
+		config.EnrichmentQuery{Name: "metrics", Server: "observability", Tool: "query_metrics"},
+	)
+
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	bundle := enricher.Enrich(context.Background(), &models.Incident{ID: "inc_1", ServiceName: "checkout", CreatedAt: createdAt})
+
+	if got := caller.calls["search_logs"]["query"]; got != "service:checkout status:error" {
+		t.Errorf("expected the query rendered for the service, got %v", got)
+	}
+	if got := caller.calls["search_logs"]["from"]; got != "2024-03-01T11:00:00Z" {
+		t.Errorf("expected the query to look back an hour, got %v", got)
+	}
+	if len(bundle.Sources) != 3 || bundle.Sources[0].Content != "NullPointerException x42" {
+		t.Fatalf("unexpected sources %+v", bundle.Sources)
+	}
+	if bundle.Sources[1].Error != "no access" || !strings.Contains(bundle.Sources[2].Error, "connection refused") {
+		t.Errorf("expected failed queries to be recorded, got %+v", bundle.Sources)
+	}
+	if failed := bundle.Failed(); strings.Join(failed, ",") != "deploys,metrics" {
+		t.Errorf("Failed() = %v", failed)
+	}
+}
+
+func TestBundle_EncodeTruncates(t *testing.T) {
+	bundle := &Bundle{
+		GeneratedAt: time.Now(),
+		Sources: []Source{
+			{Name: "logs", Server: "observability", Content: strings.Repeat("é\"", 5000)},
+			{Name: "deploys", Server: "observability", Content: "v1.2.3 deployed 5m ago"},
+		},
+	}
+
+	encoded, err := bundle.Encode(2000)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if len(encoded) > 2000 {
+		t.Errorf("expected at most 2000 bytes, got %d", len(encoded))
+	}
+
+	var decoded Bundle
+	if err := json.Unmarshal([]byte(encoded), &decoded); err != nil {
+		t.Fatalf("expected valid JSON: %v", err)
+	}
+	if !decoded.Sources[0].Truncated || !utf8.ValidString(decoded.Sources[0].Content) {
+		t.Errorf("expected the long source cut on a character boundary, got %+v", decoded.Sources[0])
+	}
+	if decoded.Sources[1].Truncated || decoded.Sources[1].Content != "v1.2.3 deployed 5m ago" {
+		t.Errorf("expected the short source kept whole, got %+v", decoded.Sources[1])
+	}
+}
@@ -0,0 +1,98 @@
+package errorreporting
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+// tagFields are log fields promoted to searchable tags on reported events
+var tagFields = []string{"request_id", "incident_id", "provider", "repository", "service_name"}
+
+// Setup installs the global Sentry client. When error reporting is disabled no
+// client is installed and every capture is a no-op. The returned function
+// flushes buffered events, waiting at most the given timeout.
+func Setup(cfg config.ErrorReportingConfig) (func(time.Duration) bool, error) {
+	return setup(cfg, nil)
+}
+
+// setup installs the client with an optional transport, used by tests
+func setup(cfg config.ErrorReportingConfig, transport sentry.Transport) (func(time.Duration) bool, error) {
+	if !cfg.Enabled {
+		return func(time.Duration) bool { return true }, nil
+	}
+
+	sampleRate := 1.0
+	if cfg.SampleRate != nil {
+		sampleRate = *cfg.SampleRate
+	}
+
+	err := sentry.Init(sentry.ClientOptions{
+		Dsn:              cfg.DSN,
+		Environment:      cfg.Environment,
+		Release:          cfg.Release,
+		SampleRate:       sampleRate,
+		AttachStacktrace: true,
+		Transport:        transport,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize error reporting: %w", err)
+	}
+
+	return sentry.Flush, nil
+}
+
+// Enabled reports whether events are being sent
+func Enabled() bool {
+	return sentry.CurrentHub().Client() != nil
+}
+
+// CaptureLog reports an error log entry. The message groups events, and the
+// fields are attached as event context, with identifiers promoted to tags.
+func CaptureLog(message string, fields map[string]interface{}) {
+	if !Enabled() {
+		return
+	}
+
+	hub := sentry.CurrentHub().Clone()
+	hub.WithScope(func(scope *sentry.Scope) {
+		scope.SetLevel(sentry.LevelError)
+		applyFields(scope, fields)
+		hub.CaptureMessage(message)
+	})
+}
+
+// CapturePanic reports a recovered panic with the stack of the panicking
+// goroutine. r may be nil for panics outside an HTTP request.
+func CapturePanic(recovered interface{}, r *http.Request, fields map[string]interface{}) {
+	if !Enabled() {
+		return
+	}
+
+	hub := sentry.CurrentHub().Clone()
+	hub.WithScope(func(scope *sentry.Scope) {
+		scope.SetLevel(sentry.LevelFatal)
+		if r != nil {
+			scope.SetRequest(r)
+		}
+		applyFields(scope, fields)
+		hub.Recover(recovered)
+	})
+}
+
+// applyFields attaches log fields to the scope
+func applyFields(scope *sentry.Scope, fields map[string]interface{}) {
+	if len(fields) == 0 {
+		return
+	}
+
+	scope.SetContext("fields", fields)
+	for _, key := range tagFields {
+		if value, ok := fields[key]; ok {
+			scope.SetTag(key, fmt.Sprint(value))
+		}
+	}
+}
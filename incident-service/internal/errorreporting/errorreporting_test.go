@@ -0,0 +1,125 @@
+package errorreporting
+
+import (
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+// recordingTransport keeps events in memory instead of sending them
+type recordingTransport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *recordingTransport) Configure(sentry.ClientOptions) {}
+
+func (t *recordingTransport) Flush(time.Duration) bool { return true }
+
+func (t *recordingTransport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+}
+
+func setupRecording(t *testing.T) *recordingTransport {
+	t.Helper()
+
+	transport := &recordingTransport{}
+	if _, err := setup(config.ErrorReportingConfig{Enabled: true, DSN: "https://public@sentry.example.com/1"}, transport); err != nil {
+		t.Fatalf("setup() error = %v", err)
+	}
+	t.Cleanup(func() { sentry.CurrentHub().BindClient(nil) })
+
+	return transport
+}
+
+func TestSetup_Disabled(t *testing.T) {
+	flush, err := Setup(config.ErrorReportingConfig{})
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	if !flush(time.Second) {
+		t.Error("expected no-op flush to succeed")
+	}
+	if Enabled() {
+		t.Error("expected error reporting to be disabled")
+	}
+
+	// Captures must be safe without a client
+	CaptureLog("failed to do something", map[string]interface{}{"error": "boom"})
+	CapturePanic("boom", nil, nil)
+}
+
+func TestSetup_InvalidDSN(t *testing.T) {
+	if _, err := Setup(config.ErrorReportingConfig{Enabled: true, DSN: "not a dsn"}); err == nil {
+		sentry.CurrentHub().BindClient(nil)
+		t.Fatal("expected error for an invalid DSN")
+	}
+}
+
+func TestCaptureLog(t *testing.T) {
+	transport := setupRecording(t)
+
+	CaptureLog("failed to dispatch workflow", map[string]interface{}{
+		"error":       "connection refused",
+		"incident_id": "inc-1",
+		"request_id":  "abc123",
+	})
+
+	if len(transport.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(transport.events))
+	}
+	event := transport.events[0]
+
+	if event.Message != "failed to dispatch workflow" {
+		t.Errorf("Message = %q", event.Message)
+	}
+	if event.Level != sentry.LevelError {
+		t.Errorf("Level = %q, want error", event.Level)
+	}
+	if event.Tags["incident_id"] != "inc-1" || event.Tags["request_id"] != "abc123" {
+		t.Errorf("expected identifier tags, got %v", event.Tags)
+	}
+	if _, ok := event.Tags["error"]; ok {
+		t.Error("expected error text in context, not tags")
+	}
+	if event.Contexts["fields"]["error"] != "connection refused" {
+		t.Errorf("expected fields context, got %v", event.Contexts["fields"])
+	}
+}
+
+func TestCapturePanic(t *testing.T) {
+	transport := setupRecording(t)
+
+	func() {
+		defer func() {
+			CapturePanic(recover(), httptest.NewRequest("POST", "/api/v1/webhooks/incidents", nil), map[string]interface{}{
+				"provider": "datadog",
+			})
+		}()
+		panic("nil map write")
+	}()
+
+	if len(transport.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(transport.events))
+	}
+	event := transport.events[0]
+
+	if event.Level != sentry.LevelFatal {
+		t.Errorf("Level = %q, want fatal", event.Level)
+	}
+	if event.Request == nil || event.Request.Method != "POST" {
+		t.Errorf("expected request details, got %+v", event.Request)
+	}
+	if event.Tags["provider"] != "datadog" {
+		t.Errorf("expected provider tag, got %v", event.Tags)
+	}
+	if event.Message != "nil map write" {
+		t.Errorf("expected the panic value as the message, got %q", event.Message)
+	}
+}
@@ -0,0 +1,184 @@
+package fixtures
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// keptFields are fields whose values are a provider's vocabulary rather than
+// data, such as states and priorities, which adapters branch on. Their
+// values are kept as they are.
+var keptFields = map[string]bool{
+	"action":           true,
+	"alert_transition": true,
+	"alert_type":       true,
+	"event_type":       true,
+	"level":            true,
+	"platform":         true,
+	"priority":         true,
+	"resource_type":    true,
+	"severity":         true,
+	"source_type_name": true,
+	"state":            true,
+	"status":           true,
+	"type":             true,
+	"urgency":          true,
+	"version":          true,
+}
+
+// keptWords are words of free text that adapters look for, such as the
+// markers of stack traces
+var keptWords = map[string]bool{
+	"at":        true,
+	"Traceback": true,
+	"Stack":     true,
+	"trace":     true,
+}
+
+// Anonymizer replaces the data in webhook payloads with pseudonyms, keeping
+// their structure: object keys, numbers, booleans, timestamps and the values
+// of vocabulary fields are kept, and every other string has each word
+// replaced by a pseudonym of the same length and character classes.
+// Punctuation is kept, as is the key of "key:value" strings such as Datadog
+// tags and URL schemes. A word gets the same pseudonym everywhere, so values
+// that match in a payload still match, but pseudonyms are keyed by a random
+// secret and cannot be reversed.
+type Anonymizer struct {
+	keep   map[string]bool
+	secret []byte
+}
+
+// NewAnonymizer creates an anonymizer that also keeps the values of the
+// given fields
+func NewAnonymizer(keep []string) *Anonymizer {
+	a := &Anonymizer{keep: make(map[string]bool, len(keep)), secret: make([]byte, 32)}
+	for _, field := range keep {
+		a.keep[strings.ToLower(field)] = true
+	}
+	if _, err := rand.Read(a.secret); err != nil {
+		// Without randomness the pseudonyms are still unreadable, only
+		// repeatable
+		copy(a.secret, fmt.Sprint(time.Now().UnixNano()))
+	}
+	return a
+}
+
+// Anonymize returns payload with its data replaced by pseudonyms
+func (a *Anonymizer) Anonymize(payload []byte) (json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to parse payload: %w", err)
+	}
+
+	anonymized, err := json.Marshal(a.value("", value))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+	return anonymized, nil
+}
+
+// value anonymizes the value of the field named key
+func (a *Anonymizer) value(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for field, fieldValue := range v {
+			v[field] = a.value(field, fieldValue)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = a.value(key, item)
+		}
+		return v
+	case string:
+		if keptFields[strings.ToLower(key)] || a.keep[strings.ToLower(key)] {
+			return v
+		}
+		return a.String(v)
+	default:
+		return v
+	}
+}
+
+// String returns the pseudonym of s
+func (a *Anonymizer) String(s string) string {
+	if _, err := time.Parse(time.RFC3339, s); err == nil {
+		return s
+	}
+
+	// Keep the key of "key:value" strings
+	var prefix string
+	if i := strings.IndexByte(s, ':'); i > 0 && !strings.ContainsFunc(s, unicode.IsSpace) {
+		prefix, s = s[:i+1], s[i+1:]
+	}
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	word := make([]rune, 0, len(s))
+	flush := func() {
+		if len(word) > 0 {
+			b.WriteString(a.word(string(word)))
+			word = word[:0]
+		}
+	}
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			word = append(word, r)
+			continue
+		}
+		flush()
+		b.WriteRune(r)
+	}
+	flush()
+	return b.String()
+}
+
+// word returns the pseudonym of a word of letters and digits. A pseudonym
+// that comes out as a kept word is drawn again, so that anonymized text never
+// gains a marker the adapters look for, such as "at" in a stack trace.
+func (a *Anonymizer) word(word string) string {
+	if keptWords[word] {
+		return word
+	}
+
+	for round := uint32(0); ; round++ {
+		if pseudonym := a.pseudonym(word, round); !keptWords[pseudonym] {
+			return pseudonym
+		}
+	}
+}
+
+// pseudonym derives the pseudonym of word in the given round
+func (a *Anonymizer) pseudonym(word string, round uint32) string {
+	var stream []byte
+	pseudonym := make([]rune, 0, len(word))
+	for i, r := range []rune(word) {
+		if i >= len(stream) {
+			mac := hmac.New(sha256.New, a.secret)
+			_ = binary.Write(mac, binary.BigEndian, round)
+			_ = binary.Write(mac, binary.BigEndian, uint32(len(stream)))
+			mac.Write([]byte(word))
+			stream = mac.Sum(stream)
+		}
+		n := rune(stream[i])
+		switch {
+		case unicode.IsDigit(r):
+			pseudonym = append(pseudonym, '0'+n%10)
+		case unicode.IsUpper(r):
+			pseudonym = append(pseudonym, 'A'+n%26)
+		default:
+			pseudonym = append(pseudonym, 'a'+n%26)
+		}
+	}
+	return string(pseudonym)
+}
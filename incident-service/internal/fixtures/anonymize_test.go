@@ -0,0 +1,113 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+)
+
+func TestAnonymize_KeepsStructureAndVocabulary(t *testing.T) {
+	payload := []byte(`{
+		"id": "8123456789",
+		"title": "Payment failed for alice@example.com",
+		"priority": "P1",
+		"tags": ["service:checkout", "env:production"],
+		"date_happened": 1718035200,
+		"occurred_at": "2024-10-14T13:02:11Z",
+		"labels": {"service": "checkout", "severity": "critical"},
+		"custom": {"owner": "Alice"}
+	}`)
+
+	anonymizer := NewAnonymizer([]string{"Owner"})
+	anonymized, err := anonymizer.Anonymize(payload)
+	if err != nil {
+		t.Fatalf("Anonymize() error = %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(anonymized, &got); err != nil {
+		t.Fatalf("anonymized payload is not JSON: %v", err)
+	}
+	for _, secret := range []string{"alice", "example", "checkout", "8123456789"} {
+		if strings.Contains(string(anonymized), secret) {
+			t.Errorf("expected %q to be replaced, got %s", secret, anonymized)
+		}
+	}
+
+	if got["priority"] != "P1" || got["occurred_at"] != "2024-10-14T13:02:11Z" || got["date_happened"] != float64(1718035200) {
+		t.Errorf("expected vocabulary, timestamps and numbers to be kept, got %s", anonymized)
+	}
+	if got["custom"].(map[string]interface{})["owner"] != "Alice" {
+		t.Errorf("expected the configured field to be kept, got %s", anonymized)
+	}
+
+	id := got["id"].(string)
+	if len(id) != len("8123456789") || strings.Trim(id, "0123456789") != "" {
+		t.Errorf("expected a pseudonym of the same form, got %q", id)
+	}
+	title := got["title"].(string)
+	if !strings.Contains(title, "@") || len(title) != len("Payment failed for alice@example.com") {
+		t.Errorf("expected punctuation and lengths to be kept, got %q", title)
+	}
+
+	// The same word gets the same pseudonym everywhere, and tag keys are kept
+	tags := got["tags"].([]interface{})
+	labels := got["labels"].(map[string]interface{})
+	if tags[0] != "service:"+labels["service"].(string) {
+		t.Errorf("expected the service tag to match the service label, got %v and %v", tags[0], labels["service"])
+	}
+	if labels["severity"] != "critical" {
+		t.Errorf("expected the severity to be kept, got %v", labels["severity"])
+	}
+}
+
+func TestAnonymize_PseudonymsDifferBetweenAnonymizers(t *testing.T) {
+	first, second := NewAnonymizer(nil), NewAnonymizer(nil)
+	if first.String("checkout") == second.String("checkout") {
+		t.Error("expected pseudonyms to be keyed by a random secret")
+	}
+	if first.String("checkout") != first.String("checkout") {
+		t.Error("expected an anonymizer to give a word the same pseudonym every time")
+	}
+}
+
+func TestAnonymize_FixturesParseTheSameWay(t *testing.T) {
+	fixtures, err := Load("testdata")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	registry := adapters.NewRegistry()
+	anonymizer := NewAnonymizer(nil)
+	for _, fixture := range fixtures {
+		adapter, _ := registry.Get(fixture.Provider)
+		anonymized, err := anonymizer.Anonymize(fixture.Payload)
+		if err != nil {
+			t.Fatalf("%s/%s: Anonymize() error = %v", fixture.Provider, fixture.Name, err)
+		}
+
+		got := Expect(adapter, anonymized)
+		if got.Error != fixture.Expect.Error || got.Severity != fixture.Expect.Severity || got.StackTrace != fixture.Expect.StackTrace {
+			t.Errorf("%s/%s: expected the anonymized payload to parse like the original, got %+v, want %+v", fixture.Provider, fixture.Name, got, fixture.Expect)
+		}
+		if fixture.Expect.ServiceName != "" && fixture.Expect.ServiceName != adapters.UnknownService && got.ServiceName == fixture.Expect.ServiceName {
+			t.Errorf("%s/%s: expected the service name %q to be replaced", fixture.Provider, fixture.Name, got.ServiceName)
+		}
+	}
+}
+
+func TestAnonymize_PseudonymsAreNeverKeptWords(t *testing.T) {
+	// Of the 676 two-letter words, one would otherwise become "at" about as
+	// often as not
+	anonymizer := NewAnonymizer(nil)
+	for first := 'a'; first <= 'z'; first++ {
+		for second := 'a'; second <= 'z'; second++ {
+			word := string([]rune{first, second})
+			if pseudonym := anonymizer.String(word); keptWords[pseudonym] && pseudonym != word {
+				t.Errorf("%q became the kept word %q", word, pseudonym)
+			}
+		}
+	}
+}
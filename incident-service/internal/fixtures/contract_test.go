@@ -0,0 +1,42 @@
+package fixtures
+
+import (
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+)
+
+// TestContract_AdaptersParseRecordedPayloads checks that every adapter still
+// makes the same incidents of the recorded payloads of each provider schema
+// version. A failure means a change to an adapter breaks providers that still
+// send that version; add a fixture when a provider starts sending a new one.
+func TestContract_AdaptersParseRecordedPayloads(t *testing.T) {
+	fixtures, err := Load("testdata")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	registry := adapters.NewRegistry()
+	covered := make(map[string]int)
+	for _, fixture := range fixtures {
+		fixture := fixture
+		adapter, ok := registry.Get(fixture.Provider)
+		if !ok {
+			t.Errorf("fixture %s/%s is for unknown provider %q", fixture.Provider, fixture.Name, fixture.Provider)
+			continue
+		}
+		covered[fixture.Provider]++
+
+		t.Run(fixture.Provider+"/"+fixture.Name, func(t *testing.T) {
+			if err := fixture.Check(adapter); err != nil {
+				t.Errorf("%s: %v", fixture.Description, err)
+			}
+		})
+	}
+
+	for _, provider := range registry.List() {
+		if covered[provider] == 0 {
+			t.Errorf("no fixtures for provider %s", provider)
+		}
+	}
+}
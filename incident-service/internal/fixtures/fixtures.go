@@ -0,0 +1,121 @@
+// Package fixtures holds recorded provider webhook payloads and the contract
+// they set for the adapters: each fixture pairs a payload with what its
+// adapter must make of it, so that adapters keep parsing the payloads of
+// every provider schema version they have been seen to receive. Fixtures are
+// captured from production webhooks and anonymized before they leave the
+// service.
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/adapters"
+)
+
+// Fixture is a recorded webhook payload and what its adapter makes of it
+type Fixture struct {
+	Name        string          `json:"name,omitempty"` // the file name, without .json
+	Description string          `json:"description,omitempty"`
+	Provider    string          `json:"provider"`
+	CapturedAt  *time.Time      `json:"captured_at,omitempty"`
+	Payload     json.RawMessage `json:"payload"`
+	Expect      Expectation     `json:"expect"`
+}
+
+// Expectation is the part of the parsed incident that the contract covers,
+// or the reason the payload is rejected
+type Expectation struct {
+	ServiceName  string `json:"service_name,omitempty"`
+	Severity     string `json:"severity,omitempty"`
+	ProviderRef  string `json:"provider_ref,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+	StackTrace   bool   `json:"stack_trace,omitempty"`
+	Error        string `json:"error,omitempty"`   // an adapters failure reason, e.g. unsupported_event
+	Version      string `json:"version,omitempty"` // the payload generation, for adapters.Versioned adapters
+}
+
+// Expect parses payload with adapter and describes the result
+func Expect(adapter adapters.WebhookAdapter, payload []byte) Expectation {
+	var version string
+	if versioned, ok := adapter.(adapters.Versioned); ok {
+		version = versioned.PayloadVersion(payload)
+	}
+
+	incident, err := adapter.Parse(payload)
+	if err != nil {
+		return Expectation{Error: adapters.FailureReason(err), Version: version}
+	}
+	return Expectation{
+		ServiceName:  incident.ServiceName,
+		Severity:     incident.Severity,
+		ProviderRef:  incident.ProviderRef,
+		ErrorMessage: incident.ErrorMessage,
+		StackTrace:   incident.StackTrace != nil,
+		Version:      version,
+	}
+}
+
+// Check reports how adapter's parsing of the fixture's payload differs from
+// what the fixture expects
+func (f *Fixture) Check(adapter adapters.WebhookAdapter) error {
+	got := Expect(adapter, f.Payload)
+	var diffs []string
+	for _, field := range []struct {
+		name      string
+		got, want interface{}
+	}{
+		{"version", got.Version, f.Expect.Version},
+		{"error", got.Error, f.Expect.Error},
+		{"service_name", got.ServiceName, f.Expect.ServiceName},
+		{"severity", got.Severity, f.Expect.Severity},
+		{"provider_ref", got.ProviderRef, f.Expect.ProviderRef},
+		{"error_message", got.ErrorMessage, f.Expect.ErrorMessage},
+		{"stack_trace", got.StackTrace, f.Expect.StackTrace},
+	} {
+		if field.got != field.want {
+			diffs = append(diffs, fmt.Sprintf("%s: got %q, want %q", field.name, fmt.Sprint(field.got), fmt.Sprint(field.want)))
+		}
+	}
+	if len(diffs) > 0 {
+		return fmt.Errorf("%s", strings.Join(diffs, "; "))
+	}
+	return nil
+}
+
+// Load reads the fixtures in dir, one JSON file each, laid out as
+// <provider>/<name>.json
+func Load(dir string) ([]Fixture, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*", "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fixtures: %w", err)
+	}
+	sort.Strings(paths)
+
+	fixtures := make([]Fixture, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		var fixture Fixture
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+		}
+		provider := filepath.Base(filepath.Dir(path))
+		if fixture.Provider != provider {
+			return nil, fmt.Errorf("fixture %s is for %q but is filed under %q", path, fixture.Provider, provider)
+		}
+		if len(fixture.Payload) == 0 {
+			return nil, fmt.Errorf("fixture %s has no payload", path)
+		}
+		fixture.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+		fixtures = append(fixtures, fixture)
+	}
+	return fixtures, nil
+}
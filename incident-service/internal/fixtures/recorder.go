@@ -0,0 +1,154 @@
+package fixtures
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Capture is the latest webhook payload of one shape from a provider
+type Capture struct {
+	Provider  string
+	Shape     string // identifies the payload's structure, see Shape
+	Body      []byte
+	Count     int // webhooks of this shape received
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// Recorder keeps the latest payload of each shape that each provider's
+// webhooks take, so that a new schema version is captured as soon as a
+// provider starts sending it however many webhooks of known shapes arrive.
+// Payloads are kept in memory only, raw, and are anonymized when they are
+// turned into fixtures.
+type Recorder struct {
+	limit int // shapes kept per provider
+
+	mu       sync.Mutex
+	captures map[string]map[string]*Capture // by provider, then shape
+}
+
+// NewRecorder creates a recorder that keeps up to limit shapes per
+// provider, forgetting the least recently seen ones beyond that
+func NewRecorder(limit int) *Recorder {
+	return &Recorder{limit: limit, captures: make(map[string]map[string]*Capture)}
+}
+
+// Record captures a webhook payload received from provider at t
+func (r *Recorder) Record(provider string, body []byte, t time.Time) {
+	shape := Shape(body)
+	body = bytes.Clone(body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	shapes, ok := r.captures[provider]
+	if !ok {
+		shapes = make(map[string]*Capture)
+		r.captures[provider] = shapes
+	}
+	if capture, ok := shapes[shape]; ok {
+		capture.Body = body
+		capture.Count++
+		capture.LastSeen = t
+		return
+	}
+
+	if len(shapes) >= r.limit {
+		var oldest *Capture
+		for _, capture := range shapes {
+			if oldest == nil || capture.LastSeen.Before(oldest.LastSeen) {
+				oldest = capture
+			}
+		}
+		delete(shapes, oldest.Shape)
+	}
+	shapes[shape] = &Capture{Provider: provider, Shape: shape, Body: body, Count: 1, FirstSeen: t, LastSeen: t}
+}
+
+// Captures returns the captured payloads of provider, or of every provider
+// when it is empty, by provider and then most recently seen first
+func (r *Recorder) Captures(provider string) []Capture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var captures []Capture
+	for name, shapes := range r.captures {
+		if provider != "" && name != provider {
+			continue
+		}
+		for _, capture := range shapes {
+			captures = append(captures, *capture)
+		}
+	}
+	sort.Slice(captures, func(i, j int) bool {
+		if captures[i].Provider != captures[j].Provider {
+			return captures[i].Provider < captures[j].Provider
+		}
+		return captures[i].LastSeen.After(captures[j].LastSeen)
+	})
+	return captures
+}
+
+// Shape identifies the structure of a JSON payload: the paths and types of
+// its fields, whatever their values. Payloads of the same schema version share
+// a shape unless they leave out optional fields. Payloads that are not JSON
+// all have the shape "invalid".
+func Shape(body []byte) string {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return "invalid"
+	}
+
+	paths := make(map[string]bool)
+	collectPaths("", value, paths)
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// collectPaths adds the path of value and of everything in it to paths.
+// Items of a list share the path of the list. Nested objects of only
+// strings, such as labels and tags, are usually keyed by data, so their keys
+// are left out.
+func collectPaths(path string, value interface{}, paths map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if path != "" && len(v) > 0 && onlyStrings(v) {
+			paths[path+"{string}"] = true
+			return
+		}
+		paths[path+"{}"] = true
+		for key, field := range v {
+			collectPaths(path+"."+key, field, paths)
+		}
+	case []interface{}:
+		paths[path+"[]"] = true
+		for _, item := range v {
+			collectPaths(path+"[]", item, paths)
+		}
+	default:
+		paths[fmt.Sprintf("%s:%T", path, v)] = true
+	}
+}
+
+// onlyStrings reports whether every value of object is a string
+func onlyStrings(object map[string]interface{}) bool {
+	for _, value := range object {
+		if _, ok := value.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package fixtures
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecorder_KeepsLatestPayloadOfEachShape(t *testing.T) {
+	recorder := NewRecorder(2)
+	start := time.Date(2024, 10, 14, 12, 0, 0, 0, time.UTC)
+
+	recorder.Record("grafana", []byte(`{"ruleId": 1, "state": "alerting", "tags": {"service": "a"}}`), start)
+	recorder.Record("grafana", []byte(`{"ruleId": 2, "state": "ok", "tags": {"service": "b", "team": "c"}}`), start.Add(time.Minute))
+	unified := []byte(`{"status": "firing", "alerts": [{"fingerprint": "f"}]}`)
+	recorder.Record("grafana", unified, start.Add(2*time.Minute))
+	recorder.Record("datadog", []byte(`{"id": "1"}`), start)
+
+	captures := recorder.Captures("grafana")
+	if len(captures) != 2 {
+		t.Fatalf("expected 2 shapes, got %d", len(captures))
+	}
+	if captures[0].Count != 1 || captures[1].Count != 2 {
+		t.Errorf("expected the newest shape first and 2 webhooks of the legacy shape, got %+v", captures)
+	}
+	if string(captures[1].Body) != `{"ruleId": 2, "state": "ok", "tags": {"service": "b", "team": "c"}}` {
+		t.Errorf("expected the latest payload of the shape, got %s", captures[1].Body)
+	}
+	if !captures[1].FirstSeen.Equal(start) || !captures[1].LastSeen.Equal(start.Add(time.Minute)) {
+		t.Errorf("unexpected first and last seen: %+v", captures[1])
+	}
+
+	// A third shape evicts the least recently seen one
+	recorder.Record("grafana", []byte(`not json`), start.Add(3*time.Minute))
+	captures = recorder.Captures("grafana")
+	if len(captures) != 2 || captures[0].Shape != "invalid" || captures[1].Shape != Shape(unified) {
+		t.Errorf("expected the legacy shape to be evicted, got %+v", captures)
+	}
+
+	if all := recorder.Captures(""); len(all) != 3 || all[0].Provider != "datadog" {
+		t.Errorf("expected the captures of every provider, got %+v", all)
+	}
+}
+
+func TestShape_IgnoresValues(t *testing.T) {
+	if Shape([]byte(`{"id": "1", "tags": ["a"]}`)) != Shape([]byte(`{"tags": ["b", "c"], "id": "2"}`)) {
+		t.Error("expected payloads differing only in values to share a shape")
+	}
+	if Shape([]byte(`{"id": "1"}`)) == Shape([]byte(`{"id": 1}`)) {
+		t.Error("expected a field changing type to change the shape")
+	}
+	if Shape([]byte(`{"project": "web"}`)) == Shape([]byte(`{"project": {"slug": "web"}}`)) {
+		t.Error("expected a field becoming an object to change the shape")
+	}
+}
@@ -0,0 +1,122 @@
+// Package freeze tracks the deploy freezes of repositories. Remediation
+// still runs during a freeze, but its workflow is told to withhold
+// auto-merge and rollout.
+package freeze
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+// maxCalendarBytes bounds how much of an iCal feed is read
+const maxCalendarBytes = 4 << 20
+
+// Window is a freeze of a repository between Start and End
+type Window struct {
+	Name  string    `json:"name,omitempty"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Calendar holds the freeze windows of each repository: those configured as
+// dates, and those last fetched from the repository's iCal feed. It is safe
+// for concurrent use.
+type Calendar struct {
+	httpClient *http.Client
+	windows    map[string][]Window // repository -> configured windows
+	feeds      map[string]string   // repository -> iCal URL
+
+	mu      sync.RWMutex
+	fetched map[string][]Window // repository -> windows of its feed
+}
+
+// NewCalendar creates a calendar with the configured windows. Feed windows
+// are known once Refresh has fetched them.
+func NewCalendar(cfg config.FreezeConfig) *Calendar {
+	c := &Calendar{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		windows:    make(map[string][]Window),
+		feeds:      make(map[string]string),
+		fetched:    make(map[string][]Window),
+	}
+	for _, calendar := range cfg.Calendars {
+		for _, window := range calendar.Windows {
+			c.windows[calendar.Repository] = append(c.windows[calendar.Repository], Window(window))
+		}
+		if calendar.URL != "" {
+			c.feeds[calendar.Repository] = calendar.URL
+		}
+	}
+	return c
+}
+
+// HasFeeds reports whether any repository has an iCal feed to fetch
+func (c *Calendar) HasFeeds() bool {
+	return len(c.feeds) > 0
+}
+
+// Active returns the freeze of the repository at now that ends last, and
+// false when the repository is not frozen
+func (c *Calendar) Active(repository string, now time.Time) (Window, bool) {
+	c.mu.RLock()
+	fetched := c.fetched[repository]
+	c.mu.RUnlock()
+
+	var active Window
+	found := false
+	for _, windows := range [][]Window{c.windows[repository], fetched} {
+		for _, window := range windows {
+			if now.Before(window.Start) || !now.Before(window.End) {
+				continue
+			}
+			if !found || window.End.After(active.End) {
+				active, found = window, true
+			}
+		}
+	}
+	return active, found
+}
+
+// Refresh fetches the iCal feed of every repository. A repository whose
+// feed cannot be fetched keeps the windows fetched last.
+func (c *Calendar) Refresh(ctx context.Context) error {
+	var errs []error
+	for repository, url := range c.feeds {
+		windows, err := c.fetch(ctx, url)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch freeze calendar of %s: %w", repository, err))
+			continue
+		}
+		c.mu.Lock()
+		c.fetched[repository] = windows
+		c.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// fetch reads the windows of an iCal feed
+func (c *Calendar) fetch(ctx context.Context, url string) ([]Window, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/calendar")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return ParseICal(io.LimitReader(resp.Body, maxCalendarBytes))
+}
@@ -0,0 +1,125 @@
+package freeze
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+const holidayCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Holiday freeze\\, all services\r\n" +
+	"DTSTART:20241220T170000Z\r\n" +
+	"DTEND:20250106T080000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Release day\r\n" +
+	"DTSTART;VALUE=DATE:20240305\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Quarter \r\n" +
+	" end\r\n" +
+	"DTSTART;TZID=Europe/Berlin:20240328T180000\r\n" +
+	"DTEND;TZID=Europe/Berlin:20240402T090000\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Called off\r\n" +
+	"STATUS:CANCELLED\r\n" +
+	"DTSTART:20240601T000000Z\r\n" +
+	"DTEND:20240602T000000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICal(t *testing.T) {
+	windows, err := ParseICal(strings.NewReader(holidayCalendar))
+	if err != nil {
+		t.Fatalf("ParseICal() error = %v", err)
+	}
+
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	want := []Window{
+		{Name: "Holiday freeze, all services", Start: time.Date(2024, 12, 20, 17, 0, 0, 0, time.UTC), End: time.Date(2025, 1, 6, 8, 0, 0, 0, time.UTC)},
+		{Name: "Release day", Start: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{Name: "Quarter end", Start: time.Date(2024, 3, 28, 18, 0, 0, 0, berlin), End: time.Date(2024, 4, 2, 9, 0, 0, 0, berlin)},
+	}
+	if len(windows) != len(want) {
+		t.Fatalf("expected %d windows, got %+v", len(want), windows)
+	}
+	for i := range want {
+		if windows[i].Name != want[i].Name || !windows[i].Start.Equal(want[i].Start) || !windows[i].End.Equal(want[i].End) {
+			t.Errorf("window %d = %+v, want %+v", i, windows[i], want[i])
+		}
+	}
+}
+
+func TestParseICal_RejectsInvalidTimes(t *testing.T) {
+	calendar := "BEGIN:VEVENT\nDTSTART:tomorrow\nEND:VEVENT\n"
+	if _, err := ParseICal(strings.NewReader(calendar)); err == nil {
+		t.Error("expected an error for an unparseable DTSTART")
+	}
+}
+
+func TestCalendar_Active(t *testing.T) {
+	start := time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC)
+	calendar := NewCalendar(config.FreezeConfig{Calendars: []config.FreezeCalendar{{
+		Repository: "org/api",
+		Windows: []config.FreezeWindow{
+			{Name: "holidays", Start: start, End: start.AddDate(0, 0, 14)},
+			{Name: "year end", Start: start.AddDate(0, 0, 5), End: start.AddDate(0, 0, 20)},
+		},
+	}}})
+
+	if _, frozen := calendar.Active("org/api", start.Add(-time.Second)); frozen {
+		t.Error("expected no freeze before the first window")
+	}
+	if window, frozen := calendar.Active("org/api", start.AddDate(0, 0, 7)); !frozen || window.Name != "year end" {
+		t.Errorf("expected the window ending last, got %+v, %v", window, frozen)
+	}
+	if _, frozen := calendar.Active("org/web", start.AddDate(0, 0, 7)); frozen {
+		t.Error("expected other repositories not to be frozen")
+	}
+}
+
+func TestCalendar_RefreshKeepsWindowsOnFailure(t *testing.T) {
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "text/calendar")
+		_, _ = w.Write([]byte(holidayCalendar))
+	}))
+	defer server.Close()
+
+	calendar := NewCalendar(config.FreezeConfig{Calendars: []config.FreezeCalendar{{Repository: "org/api", URL: server.URL}}})
+	if !calendar.HasFeeds() {
+		t.Fatal("expected the calendar to have a feed")
+	}
+	christmas := time.Date(2024, 12, 25, 12, 0, 0, 0, time.UTC)
+	if _, frozen := calendar.Active("org/api", christmas); frozen {
+		t.Fatal("expected no freeze before the feed is fetched")
+	}
+
+	if err := calendar.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if window, frozen := calendar.Active("org/api", christmas); !frozen || window.Name != "Holiday freeze, all services" {
+		t.Errorf("expected the holiday freeze, got %+v, %v", window, frozen)
+	}
+
+	failing.Store(true)
+	if err := calendar.Refresh(context.Background()); err == nil {
+		t.Error("expected an error when the feed fails")
+	}
+	if _, frozen := calendar.Active("org/api", christmas); !frozen {
+		t.Error("expected the windows fetched last to be kept")
+	}
+}
@@ -0,0 +1,172 @@
+package freeze
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// iCal date and date-time layouts
+const (
+	icalDate     = "20060102"
+	icalDateTime = "20060102T150405"
+)
+
+// ParseICal reads the events of an iCal (RFC 5545) calendar as freeze
+// windows. Each event's SUMMARY names its window. Cancelled events are
+// skipped, and recurring events only count their first occurrence.
+func ParseICal(r io.Reader) ([]Window, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var windows []Window
+	var event map[string]property
+	for i, line := range lines {
+		name, prop, ok := parseProperty(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT"):
+			event = map[string]property{}
+		case name == "END" && strings.EqualFold(prop.value, "VEVENT") && event != nil:
+			window, keep, err := eventWindow(event)
+			if err != nil {
+				return nil, fmt.Errorf("invalid event ending on line %d: %w", i+1, err)
+			}
+			if keep {
+				windows = append(windows, window)
+			}
+			event = nil
+		case event != nil:
+			// The first occurrence of a property wins
+			if _, seen := event[name]; !seen {
+				event[name] = prop
+			}
+		}
+	}
+
+	return windows, nil
+}
+
+// property is the value of a content line and its parameters
+type property struct {
+	value  string
+	params map[string]string
+}
+
+// unfold reads the content lines of a calendar, joining the lines that
+// continue the one above them
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+// parseProperty splits a content line such as DTSTART;TZID=Europe/Berlin:20241220T000000
+// into its upper-cased name, value and parameters
+func parseProperty(line string) (string, property, bool) {
+	// The value starts at the first colon outside a quoted parameter value
+	quoted := false
+	colon := -1
+	for i := 0; i < len(line) && colon < 0; i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				colon = i
+			}
+		}
+	}
+	if colon < 0 {
+		return "", property{}, false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	prop := property{value: line[colon+1:], params: map[string]string{}}
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), prop, true
+}
+
+// eventWindow returns the window of an event, and false for events that are
+// cancelled or have no start
+func eventWindow(event map[string]property) (Window, bool, error) {
+	if status, ok := event["STATUS"]; ok && strings.EqualFold(status.value, "CANCELLED") {
+		return Window{}, false, nil
+	}
+	dtstart, ok := event["DTSTART"]
+	if !ok {
+		return Window{}, false, nil
+	}
+
+	start, allDay, err := parseTime(dtstart)
+	if err != nil {
+		return Window{}, false, fmt.Errorf("invalid DTSTART: %w", err)
+	}
+
+	var end time.Time
+	if dtend, ok := event["DTEND"]; ok {
+		if end, _, err = parseTime(dtend); err != nil {
+			return Window{}, false, fmt.Errorf("invalid DTEND: %w", err)
+		}
+	} else if allDay {
+		// An all-day event without an end lasts the day
+		end = start.AddDate(0, 0, 1)
+	}
+	if !end.After(start) {
+		return Window{}, false, nil
+	}
+
+	return Window{Name: unescape(event["SUMMARY"].value), Start: start, End: end}, true, nil
+}
+
+// parseTime parses a DATE or DATE-TIME value, in UTC, in the zone of its
+// TZID parameter, or in UTC when it is floating. It reports whether the value
+// is a date.
+func parseTime(prop property) (time.Time, bool, error) {
+	location := time.UTC
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if loaded, err := time.LoadLocation(tzid); err == nil {
+			location = loaded
+		}
+	}
+
+	value := strings.TrimSpace(prop.value)
+	if prop.params["VALUE"] == "DATE" || len(value) == len(icalDate) {
+		t, err := time.ParseInLocation(icalDate, value, location)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.ParseInLocation(icalDateTime, strings.TrimSuffix(value, "Z"), time.UTC)
+		return t, false, err
+	}
+	t, err := time.ParseInLocation(icalDateTime, value, location)
+	return t, false, err
+}
+
+// unescape resolves the backslash escapes of a text value
+func unescape(text string) string {
+	return strings.NewReplacer(`\\`, `\`, `\,`, ",", `\;`, ";", `\n`, "\n", `\N`, "\n").Replace(text)
+}
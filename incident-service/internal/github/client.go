@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -12,8 +13,15 @@ import (
 	"time"
 
 	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// ErrIncidentQueued is returned by DispatchWorkflow when the repository is at its
+// concurrency limit; the incident is dispatched once a slot frees up
+var ErrIncidentQueued = errors.New("concurrency limit reached, incident queued")
+
 // Client handles GitHub API interactions
 type Client struct {
 	apiURL     string
@@ -21,11 +29,19 @@ type Client struct {
 	httpClient *http.Client
 	workflow   string
 
-	// Concurrency tracking
+	// Concurrency tracking, in memory unless a shared slot store is set
 	mu                  sync.RWMutex
 	activeWorkflows     map[string]int // repository -> active count
 	queuedIncidents     map[string][]*models.Incident // repository -> queued incidents
+	slotLeases          map[string][]SlotLease // repository -> leases of the active slots, oldest first
 	maxWorkflowsPerRepo int
+	slots               SlotStore
+
+	// Dispatch health, rate limits, and circuit breaker
+	health *healthState
+
+	// Serializes the dispatch operations of each repository
+	shards *dispatchShards
 }
 
 // WorkflowDispatchInput represents the inputs for a workflow dispatch
@@ -36,6 +52,32 @@ type WorkflowDispatchInput struct {
 	ServiceName  string `json:"service_name"`
 	Timestamp    string `json:"timestamp"`
 	MCPConfig    string `json:"mcp_config,omitempty"`
+	TraceParent  string `json:"traceparent,omitempty"` // W3C trace context, set when tracing is enabled
+	Runbooks     string `json:"runbooks,omitempty"`    // JSON runbook and known-issue links of the service
+	Context      string `json:"incident_context,omitempty"` // JSON context gathered from MCP servers
+	Freeze       string `json:"freeze,omitempty"`     // "true" while the repository is in a deploy freeze
+	ContextURL   string `json:"context_url,omitempty"` // signed URL of the incident's full context
+	Truncated    string `json:"truncated,omitempty"`   // comma-separated inputs cut short or left out, see NewWorkflowInputs
+}
+
+// DispatchOptions are the optional inputs of a workflow dispatch
+type DispatchOptions struct {
+	Runbooks string // JSON runbook and known-issue links, passed as the runbooks input when not empty
+	Freeze   bool   // the repository is in a deploy freeze, passed as the freeze input
+
+	MaxInputBytes int    // the inputs are truncated to this many bytes together when positive
+	ContextURL    string // signed URL of the incident's full context, passed as the context_url input when not empty
+
+	OnAttempt func(DispatchAttempt) // called after each request to the dispatch API, when set
+}
+
+// DispatchAttempt is one request to the workflow dispatch API. A dispatch
+// makes up to three.
+type DispatchAttempt struct {
+	Attempt    int // from 1
+	StartedAt  time.Time
+	StatusCode int   // 0 when GitHub did not respond
+	Err        error // nil when the workflow was dispatched
 }
 
 // WorkflowDispatchRequest represents the GitHub workflow dispatch API request
@@ -46,38 +88,109 @@ type WorkflowDispatchRequest struct {
 
 // NewClient creates a new GitHub API client
 func NewClient(apiURL, token, workflow string, maxWorkflowsPerRepo int) *Client {
-	return &Client{
+	c := &Client{
 		apiURL:              apiURL,
 		token:               token,
 		workflow:            workflow,
-		httpClient:          &http.Client{Timeout: 30 * time.Second},
+		httpClient:          &http.Client{Timeout: 30 * time.Second, Transport: newTransport(DefaultMaxIdleConns)},
 		activeWorkflows:     make(map[string]int),
 		queuedIncidents:     make(map[string][]*models.Incident),
+		slotLeases:          make(map[string][]SlotLease),
 		maxWorkflowsPerRepo: maxWorkflowsPerRepo,
+		health:              newHealthState(),
+		shards:              newDispatchShards(DefaultDispatchShards),
 	}
+	c.slots = memorySlots{c: c}
+	return c
+}
+
+// SetSlotStore keeps the concurrency state in store, so that replicas
+// sharing it enforce one limit per repository. It must be called before the
+// client is used.
+func (c *Client) SetSlotStore(store SlotStore) {
+	c.slots = store
+}
+
+// SetDispatchShards sets how many shards serialize dispatch operations. It
+// must be called before the client is used.
+func (c *Client) SetDispatchShards(n int) {
+	c.shards.stop()
+	c.shards = newDispatchShards(n)
 }
 
 // DispatchWorkflow triggers a GitHub Actions workflow for an incident
-// Returns workflow run ID if successful, error otherwise
-func (c *Client) DispatchWorkflow(ctx context.Context, incident *models.Incident, branch string) (int64, error) {
+// Returns workflow run ID if successful, error otherwise. opts sets the
+// optional inputs of the workflow.
+func (c *Client) DispatchWorkflow(ctx context.Context, incident *models.Incident, branch string, opts DispatchOptions) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "github.DispatchWorkflow",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("incident.id", incident.ID),
+			attribute.String("github.repository", incident.Repository),
+			attribute.String("github.ref", branch),
+		),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	// The limit check, the dispatch, and taking the slot run on the
+	// repository's shard, so concurrent dispatches never overshoot the limit
+	if shardErr := c.shards.run(ctx, incident.Repository, func() {
+		err = c.dispatch(ctx, span, incident, branch, opts)
+	}); shardErr != nil {
+		return 0, shardErr
+	}
+
+	// We don't have the run ID from the dispatch API, return 0
+	return 0, err
+}
+
+// dispatch dispatches the workflow, or queues the incident when the
+// repository is at its concurrency limit. It runs on the repository's shard.
+func (c *Client) dispatch(ctx context.Context, span trace.Span, incident *models.Incident, branch string, opts DispatchOptions) (err error) {
+	// Replicas sharing the slot store wait for each other
+	unlock, err := c.slots.Lock(ctx, incident.Repository)
+	if err != nil {
+		return fmt.Errorf("failed to lock repository slots: %w", err)
+	}
+	defer unlock()
+
 	// Check concurrency limit
-	if !c.canDispatch(incident.Repository) {
-		c.queueIncident(incident)
-		return 0, fmt.Errorf("concurrency limit reached, incident queued")
+	active, err := c.slots.Active(ctx, incident.Repository)
+	if err != nil {
+		return fmt.Errorf("failed to read active workflows: %w", err)
+	}
+	if active >= c.maxWorkflowsPerRepo {
+		if err := c.slots.Enqueue(ctx, incident); err != nil {
+			return fmt.Errorf("failed to queue incident: %w", err)
+		}
+		return ErrIncidentQueued
 	}
 
-	// Prepare workflow inputs
-	inputs := WorkflowDispatchInput{
-		IncidentID:   incident.ID,
-		ErrorMessage: incident.ErrorMessage,
-		ServiceName:  incident.ServiceName,
-		Timestamp:    incident.CreatedAt.Format(time.RFC3339),
+	// Fail fast while GitHub keeps rejecting dispatches
+	if !c.health.allow() {
+		return ErrCircuitOpen
 	}
+	defer func() {
+		switch {
+		case err == nil:
+			c.health.recordSuccess()
+		case ctx.Err() != nil:
+			// Cancelled by the caller, which says nothing about GitHub's health
+			c.health.abort()
+		default:
+			c.health.recordFailure(err)
+		}
+	}()
 
-	if incident.StackTrace != nil {
-		inputs.StackTrace = *incident.StackTrace
+	// Prepare workflow inputs, within the size GitHub accepts
+	inputs, truncated := NewWorkflowInputs(incident, opts)
+	if len(truncated) > 0 {
+		span.AddEvent("inputs truncated", trace.WithAttributes(attribute.StringSlice("inputs", truncated)))
 	}
 
+	// Let the workflow continue the incident's trace
+	inputs.TraceParent = tracing.TraceParent(ctx)
+
 	request := WorkflowDispatchRequest{
 		Ref:    branch,
 		Inputs: inputs,
@@ -91,38 +204,48 @@ func (c *Client) DispatchWorkflow(ctx context.Context, incident *models.Incident
 			backoff := time.Duration(math.Pow(2, float64(attempt))) * time.Second
 			select {
 			case <-ctx.Done():
-				return 0, ctx.Err()
+				return ctx.Err()
 			case <-time.After(backoff):
 			}
 		}
 
-		err := c.dispatchWorkflowAttempt(ctx, incident.Repository, request)
+		span.AddEvent("dispatch attempt", trace.WithAttributes(attribute.Int("attempt", attempt+1)))
+
+		startedAt := time.Now()
+		statusCode, err := c.dispatchWorkflowAttempt(ctx, incident.Repository, request)
+		if opts.OnAttempt != nil {
+			opts.OnAttempt(DispatchAttempt{Attempt: attempt + 1, StartedAt: startedAt, StatusCode: statusCode, Err: err})
+		}
 		if err == nil {
-			// Success - increment active workflow count
-			c.incrementActive(incident.Repository)
-			// We don't have the run ID from the dispatch API, return 0
-			return 0, nil
+			// Success - increment active workflow count. The workflow runs
+			// even if its slot cannot be recorded, so that is not a failure;
+			// the limit is briefly exceeded until the slot store recovers.
+			if err := c.slots.Acquire(ctx, incident.Repository, incident.ID); err != nil {
+				span.AddEvent("failed to record workflow slot", trace.WithAttributes(attribute.String("error", err.Error())))
+			}
+			return nil
 		}
 
 		lastErr = err
 	}
 
-	return 0, fmt.Errorf("workflow dispatch failed after 3 attempts: %w", lastErr)
+	return fmt.Errorf("workflow dispatch failed after 3 attempts: %w", lastErr)
 }
 
-// dispatchWorkflowAttempt makes a single attempt to dispatch a workflow
-func (c *Client) dispatchWorkflowAttempt(ctx context.Context, repository string, request WorkflowDispatchRequest) error {
+// dispatchWorkflowAttempt makes a single attempt to dispatch a workflow,
+// returning the status code of GitHub's response, if any
+func (c *Client) dispatchWorkflowAttempt(ctx context.Context, repository string, request WorkflowDispatchRequest) (int, error) {
 	// Build API URL: /repos/{owner}/{repo}/actions/workflows/{workflow_id}/dispatches
 	url := fmt.Sprintf("%s/repos/%s/actions/workflows/%s/dispatches", c.apiURL, repository, c.workflow)
 
 	body, err := json.Marshal(request)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
+		return 0, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+c.token)
@@ -132,77 +255,101 @@ func (c *Client) dispatchWorkflowAttempt(ctx context.Context, repository string,
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return 0, fmt.Errorf("request failed: %w", err)
 	}
-	defer resp.Body.Close()
+	defer closeBody(resp.Body)
+
+	c.health.recordRateLimit(resp.Header)
+	c.health.recordStatus(resp.StatusCode)
 
 	if resp.StatusCode != http.StatusNoContent {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
 	}
 
-	return nil
-}
-
-// canDispatch checks if a workflow can be dispatched for the given repository
-func (c *Client) canDispatch(repository string) bool {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-
-	active := c.activeWorkflows[repository]
-	return active < c.maxWorkflowsPerRepo
+	return resp.StatusCode, nil
 }
 
 // queueIncident adds an incident to the queue for a repository
 func (c *Client) queueIncident(incident *models.Incident) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	c.queuedIncidents[incident.Repository] = append(c.queuedIncidents[incident.Repository], incident)
-}
-
-// incrementActive increments the active workflow count for a repository
-func (c *Client) incrementActive(repository string) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	c.activeWorkflows[repository]++
+	_ = c.slots.Enqueue(context.Background(), incident)
 }
 
 // DecrementActive decrements the active workflow count and returns the next queued incident if any
 func (c *Client) DecrementActive(repository string) *models.Incident {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	next, _ := c.ReleaseSlot(context.Background(), repository, "")
+	return next
+}
 
-	if c.activeWorkflows[repository] > 0 {
-		c.activeWorkflows[repository]--
+// ReleaseSlot frees the workflow slot the incident holds in the repository,
+// or its oldest slot when the incident holds none, and returns the next
+// queued incident, if any
+func (c *Client) ReleaseSlot(ctx context.Context, repository, incidentID string) (next *models.Incident, err error) {
+	if shardErr := c.shards.run(ctx, repository, func() {
+		var unlock func()
+		if unlock, err = c.slots.Lock(ctx, repository); err != nil {
+			return
+		}
+		defer unlock()
+		next, err = c.slots.Release(ctx, repository, incidentID)
+	}); shardErr != nil {
+		return nil, shardErr
 	}
-
-	// Check if there are queued incidents
-	queue := c.queuedIncidents[repository]
-	if len(queue) == 0 {
-		return nil
+	if err != nil {
+		return nil, fmt.Errorf("failed to release workflow slot: %w", err)
 	}
+	return next, nil
+}
 
-	// Pop the first incident from the queue
-	incident := queue[0]
-	c.queuedIncidents[repository] = queue[1:]
-
-	return incident
+// RemoveQueued drops an incident from a repository's queue so it is never
+// dispatched, reporting whether it was queued
+func (c *Client) RemoveQueued(repository, incidentID string) (removed bool) {
+	ctx := context.Background()
+	_ = c.shards.run(ctx, repository, func() {
+		unlock, err := c.slots.Lock(ctx, repository)
+		if err != nil {
+			return
+		}
+		defer unlock()
+		removed, _ = c.slots.Remove(ctx, repository, incidentID)
+	})
+	return removed
 }
 
 // GetActiveCount returns the number of active workflows for a repository
 func (c *Client) GetActiveCount(repository string) int {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-
-	return c.activeWorkflows[repository]
+	active, _ := c.slots.Active(context.Background(), repository)
+	return active
 }
 
 // GetQueuedCount returns the number of queued incidents for a repository
 func (c *Client) GetQueuedCount(repository string) int {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	queued, _ := c.slots.Queued(context.Background(), repository)
+	return len(queued)
+}
+
+// RepositoryStats reports the workflow concurrency state of a repository
+type RepositoryStats struct {
+	Active int `json:"active"`
+	Queued int `json:"queued"`
+}
 
-	return len(c.queuedIncidents[repository])
+// Stats returns active and queued counts for every repository with workflows in flight or waiting
+func (c *Client) Stats() map[string]RepositoryStats {
+	stats, err := c.slots.Stats(context.Background())
+	if err != nil {
+		return make(map[string]RepositoryStats)
+	}
+	return stats
+}
+
+// QueuedIncidents returns the IDs of the incidents queued for a repository, next first
+func (c *Client) QueuedIncidents(repository string) []string {
+	queued, _ := c.slots.Queued(context.Background(), repository)
+
+	ids := make([]string, 0, len(queued))
+	for _, incident := range queued {
+		ids = append(ids, incident.ID)
+	}
+	return ids
 }
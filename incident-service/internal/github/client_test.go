@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -54,7 +55,7 @@ func TestProperty6_WorkflowDispatchIncludesRequiredContext(t *testing.T) {
 			// Create client and dispatch workflow
 			client := NewClient(server.URL, "test-token", "test-workflow.yml", 2)
 			ctx := context.Background()
-			_, err := client.DispatchWorkflow(ctx, incident, "main")
+			_, err := client.DispatchWorkflow(ctx, incident, "main", DispatchOptions{})
 
 			if err != nil {
 				t.Logf("Dispatch failed: %v", err)
@@ -160,7 +161,7 @@ func TestProperty7_RetryWithExponentialBackoff(t *testing.T) {
 			ctx := context.Background()
 			
 			startTime := time.Now()
-			_, err := client.DispatchWorkflow(ctx, incident, "main")
+			_, err := client.DispatchWorkflow(ctx, incident, "main", DispatchOptions{})
 			totalDuration := time.Since(startTime)
 
 			// Should fail after 3 attempts
@@ -256,7 +257,7 @@ func TestProperty8_ConcurrencyLimitEnforcement(t *testing.T) {
 					CreatedAt:    time.Now(),
 				}
 
-				_, err := client.DispatchWorkflow(ctx, incident, "main")
+				_, err := client.DispatchWorkflow(ctx, incident, "main", DispatchOptions{})
 				if err != nil {
 					if err.Error() == "concurrency limit reached, incident queued" {
 						queuedCount++
@@ -326,3 +327,207 @@ func TestProperty8_ConcurrencyLimitEnforcement(t *testing.T) {
 
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
+
+func TestRemoveQueued(t *testing.T) {
+	client := NewClient("http://localhost", "test-token", "test-workflow.yml", 1)
+	for _, id := range []string{"inc-1", "inc-2", "inc-3"} {
+		client.queueIncident(&models.Incident{ID: id, Repository: "org/repo"})
+	}
+
+	if !client.RemoveQueued("org/repo", "inc-2") {
+		t.Fatal("expected inc-2 to be removed")
+	}
+	if client.RemoveQueued("org/repo", "inc-2") {
+		t.Error("expected a second removal to report false")
+	}
+	if client.GetQueuedCount("org/repo") != 2 {
+		t.Errorf("expected 2 queued incidents, got %d", client.GetQueuedCount("org/repo"))
+	}
+	if got := client.QueuedIncidents("org/repo"); len(got) != 2 || got[0] != "inc-1" || got[1] != "inc-3" {
+		t.Errorf("expected inc-1 and inc-3 queued, got %v", got)
+	}
+
+	if next := client.DecrementActive("org/repo"); next == nil || next.ID != "inc-1" {
+		t.Errorf("expected inc-1 to be next, got %+v", next)
+	}
+	if next := client.DecrementActive("org/repo"); next == nil || next.ID != "inc-3" {
+		t.Errorf("expected inc-3 to be next, got %+v", next)
+	}
+}
+
+func TestDispatchWorkflow_RunbooksInput(t *testing.T) {
+	var inputs []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Inputs map[string]interface{} `json:"inputs"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		inputs = append(inputs, request.Inputs)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "test-workflow.yml", 5)
+	incident := &models.Incident{ID: "inc_1", ServiceName: "api", Repository: "org/api", ErrorMessage: "boom"}
+
+	runbooks := `{"runbooks":[{"url":"https://wiki.example.com/api"}]}`
+	if _, err := client.DispatchWorkflow(context.Background(), incident, "main", DispatchOptions{Runbooks: runbooks}); err != nil {
+		t.Fatalf("DispatchWorkflow() error = %v", err)
+	}
+	if _, err := client.DispatchWorkflow(context.Background(), incident, "main", DispatchOptions{}); err != nil {
+		t.Fatalf("DispatchWorkflow() error = %v", err)
+	}
+
+	if len(inputs) != 2 {
+		t.Fatalf("expected 2 dispatches, got %d", len(inputs))
+	}
+	if inputs[0]["runbooks"] != runbooks {
+		t.Errorf("runbooks input = %v, want %s", inputs[0]["runbooks"], runbooks)
+	}
+	// Workflows that do not declare the input must not receive it
+	if _, ok := inputs[1]["runbooks"]; ok {
+		t.Error("expected no runbooks input without runbooks")
+	}
+}
+
+func TestDispatchWorkflow_FreezeInput(t *testing.T) {
+	var inputs []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Inputs map[string]interface{} `json:"inputs"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		inputs = append(inputs, request.Inputs)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "test-workflow.yml", 5)
+	incident := &models.Incident{ID: "inc_1", ServiceName: "api", Repository: "org/api", ErrorMessage: "boom"}
+
+	if _, err := client.DispatchWorkflow(context.Background(), incident, "main", DispatchOptions{Freeze: true}); err != nil {
+		t.Fatalf("DispatchWorkflow() error = %v", err)
+	}
+	if _, err := client.DispatchWorkflow(context.Background(), incident, "main", DispatchOptions{}); err != nil {
+		t.Fatalf("DispatchWorkflow() error = %v", err)
+	}
+
+	if len(inputs) != 2 {
+		t.Fatalf("expected 2 dispatches, got %d", len(inputs))
+	}
+	if inputs[0]["freeze"] != "true" {
+		t.Errorf("freeze input = %v, want true", inputs[0]["freeze"])
+	}
+	// Outside a freeze, workflows that do not declare the input keep working
+	if _, ok := inputs[1]["freeze"]; ok {
+		t.Error("expected no freeze input outside a freeze")
+	}
+}
+
+func TestDispatchWorkflow_IncidentContextInput(t *testing.T) {
+	var inputs []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Inputs map[string]interface{} `json:"inputs"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		inputs = append(inputs, request.Inputs)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "test-workflow.yml", 5)
+	bundle := `{"sources":[{"name":"logs","server":"datadog","content":"timeouts"}]}`
+	enriched := &models.Incident{ID: "inc_1", ServiceName: "api", Repository: "org/api", ErrorMessage: "boom", Enrichment: &bundle}
+	plain := &models.Incident{ID: "inc_2", ServiceName: "api", Repository: "org/api", ErrorMessage: "boom"}
+
+	for _, incident := range []*models.Incident{enriched, plain} {
+		if _, err := client.DispatchWorkflow(context.Background(), incident, "main", DispatchOptions{}); err != nil {
+			t.Fatalf("DispatchWorkflow() error = %v", err)
+		}
+	}
+
+	if len(inputs) != 2 {
+		t.Fatalf("expected 2 dispatches, got %d", len(inputs))
+	}
+	if inputs[0]["incident_context"] != bundle {
+		t.Errorf("incident_context input = %v, want %s", inputs[0]["incident_context"], bundle)
+	}
+	if _, ok := inputs[1]["incident_context"]; ok {
+		t.Error("expected no incident_context input without enrichment")
+	}
+}
+
+func TestDispatchWorkflow_TruncatesInputs(t *testing.T) {
+	var inputs map[string]interface{}
+	var size int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Inputs map[string]interface{} `json:"inputs"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		inputs = request.Inputs
+		for _, value := range request.Inputs {
+			size += len(value.(string))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "test-workflow.yml", 5)
+	stackTrace := strings.Repeat("at frame\n", 10000)
+	incident := &models.Incident{ID: "inc_1", ServiceName: "api", Repository: "org/api", ErrorMessage: "boom", StackTrace: &stackTrace}
+
+	contextURL := "https://incidents.example.com/api/v1/incidents/inc_1/context?token=1.abc"
+	opts := DispatchOptions{MaxInputBytes: 4096, ContextURL: contextURL}
+	if _, err := client.DispatchWorkflow(context.Background(), incident, "main", opts); err != nil {
+		t.Fatalf("DispatchWorkflow() error = %v", err)
+	}
+
+	if size > 4096 {
+		t.Errorf("expected the inputs within 4096 bytes, got %d", size)
+	}
+	if inputs["truncated"] != "stack_trace" {
+		t.Errorf("truncated input = %v, want stack_trace", inputs["truncated"])
+	}
+	if inputs["context_url"] != contextURL {
+		t.Errorf("context_url input = %v, want %s", inputs["context_url"], contextURL)
+	}
+	if stackTrace != strings.Repeat("at frame\n", 10000) {
+		t.Error("expected the incident's stack trace left whole")
+	}
+}
+
+func TestDispatchWorkflow_ReportsAttempts(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "test-workflow.yml", 1)
+	var attempts []DispatchAttempt
+	opts := DispatchOptions{OnAttempt: func(attempt DispatchAttempt) { attempts = append(attempts, attempt) }}
+	incident := &models.Incident{ID: "inc_1", ServiceName: "api", Repository: "org/api"}
+	if _, err := client.DispatchWorkflow(context.Background(), incident, "main", opts); err != nil {
+		t.Fatalf("DispatchWorkflow() error = %v", err)
+	}
+
+	if len(attempts) != 2 {
+		t.Fatalf("expected 2 attempts, got %+v", attempts)
+	}
+	if attempts[0].Attempt != 1 || attempts[0].StatusCode != http.StatusBadGateway || attempts[0].Err == nil {
+		t.Errorf("unexpected first attempt: %+v", attempts[0])
+	}
+	if attempts[1].Attempt != 2 || attempts[1].StatusCode != http.StatusNoContent || attempts[1].Err != nil {
+		t.Errorf("unexpected second attempt: %+v", attempts[1])
+	}
+	if !attempts[0].StartedAt.Before(attempts[1].StartedAt) {
+		t.Errorf("expected attempts in order, got %+v", attempts)
+	}
+}
@@ -0,0 +1,93 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// CommitFile creates or replaces a file of a repository in one commit, and
+// returns the file's URL on GitHub. An empty branch is the repository's
+// default branch.
+func (c *Client) CommitFile(ctx context.Context, repository, branch, path, message string, content []byte) (string, error) {
+	endpoint := fmt.Sprintf("/repos/%s/contents/%s", repository, escapePath(path))
+
+	// Replacing a file takes the blob SHA of its current version
+	var existing struct {
+		SHA string `json:"sha"`
+	}
+	query := url.Values{}
+	if branch != "" {
+		query.Set("ref", branch)
+	}
+	if err := c.get(ctx, endpoint, query, &existing); err != nil {
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			return "", fmt.Errorf("failed to get file: %w", err)
+		}
+	}
+
+	request := map[string]string{
+		"message": message,
+		"content": base64.StdEncoding.EncodeToString(content),
+	}
+	if branch != "" {
+		request["branch"] = branch
+	}
+	if existing.SHA != "" {
+		request["sha"] = existing.SHA
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.apiURL+endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer closeBody(resp.Body)
+
+	c.health.recordRateLimit(resp.Header)
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("failed to commit file: %w", &APIError{StatusCode: resp.StatusCode, Body: string(bodyBytes)})
+	}
+
+	var response struct {
+		Content struct {
+			HTMLURL string `json:"html_url"`
+		} `json:"content"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.Content.HTMLURL, nil
+}
+
+// escapePath escapes each segment of a slash-separated repository path
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
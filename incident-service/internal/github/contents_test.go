@@ -0,0 +1,75 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCommitFile(t *testing.T) {
+	files := map[string]string{"postmortems/existing.md": "abc123"}
+	var commits []map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path[len("/repos/org/postmortems/contents/"):]
+		switch r.Method {
+		case "GET":
+			if r.URL.Query().Get("ref") != "main" {
+				t.Errorf("expected the branch as ref, got %q", r.URL.RawQuery)
+			}
+			sha, ok := files[path]
+			if !ok {
+				http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"sha": "` + sha + `"}`))
+		case "PUT":
+			var request map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&request)
+			commits = append(commits, request)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"content": {"html_url": "https://github.com/org/postmortems/blob/main/` + path + `"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "remediate.yml", 10)
+
+	url, err := client.CommitFile(context.Background(), "org/postmortems", "main", "postmortems/new.md", "Add postmortem", []byte("# Postmortem"))
+	if err != nil {
+		t.Fatalf("CommitFile() error = %v", err)
+	}
+	if url != "https://github.com/org/postmortems/blob/main/postmortems/new.md" {
+		t.Errorf("unexpected URL %s", url)
+	}
+	content, _ := base64.StdEncoding.DecodeString(commits[0]["content"])
+	if string(content) != "# Postmortem" || commits[0]["branch"] != "main" || commits[0]["sha"] != "" {
+		t.Errorf("unexpected commit of a new file: %+v", commits[0])
+	}
+
+	if _, err := client.CommitFile(context.Background(), "org/postmortems", "main", "postmortems/existing.md", "Update postmortem", []byte("# Postmortem")); err != nil {
+		t.Fatalf("CommitFile() error = %v", err)
+	}
+	if commits[1]["sha"] != "abc123" {
+		t.Errorf("expected the existing file's SHA to be sent, got %+v", commits[1])
+	}
+}
+
+func TestCommitFile_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+			return
+		}
+		http.Error(w, `{"message": "Resource not accessible by integration"}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "remediate.yml", 10)
+	if _, err := client.CommitFile(context.Background(), "org/postmortems", "", "postmortems/new.md", "Add postmortem", []byte("x")); err == nil {
+		t.Error("expected an error when the commit is refused")
+	}
+}
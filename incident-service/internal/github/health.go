@@ -0,0 +1,306 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ErrCircuitOpen is returned by DispatchWorkflow while the circuit breaker is open
+var ErrCircuitOpen = errors.New("github circuit breaker open")
+
+// BreakerState is the state of the dispatch circuit breaker
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+const (
+	// defaultBreakerThreshold is the number of consecutive failed dispatches that opens the breaker
+	defaultBreakerThreshold = 5
+	// defaultBreakerCooldown is how long the breaker stays open before allowing a trial dispatch
+	defaultBreakerCooldown = time.Minute
+	// lowRateLimitThreshold is the remaining request count below which the client reports degraded
+	lowRateLimitThreshold = 100
+)
+
+// healthState tracks dispatch outcomes, rate limits, and the circuit breaker
+type healthState struct {
+	mu                  sync.Mutex
+	lastSuccess         time.Time
+	lastFailure         time.Time
+	lastError           string
+	consecutiveFailures int
+	serverErrors        int // consecutive dispatch requests answered with a 5xx
+	rateLimitRemaining  int // -1 until GitHub has reported it
+	rateLimitReset      time.Time
+	openedAt            time.Time // zero while the breaker is closed
+	trialInFlight       bool      // a half-open trial dispatch is running
+	threshold           int
+	cooldown            time.Duration
+	now                 func() time.Time
+}
+
+// newHealthState creates health tracking with the default breaker settings
+func newHealthState() *healthState {
+	return &healthState{
+		rateLimitRemaining: -1,
+		threshold:          defaultBreakerThreshold,
+		cooldown:           defaultBreakerCooldown,
+		now:                time.Now,
+	}
+}
+
+// state returns the breaker state; callers must hold mu
+func (h *healthState) state() BreakerState {
+	if h.openedAt.IsZero() {
+		return BreakerClosed
+	}
+	if h.now().Sub(h.openedAt) < h.cooldown {
+		return BreakerOpen
+	}
+	return BreakerHalfOpen
+}
+
+// allow reports whether a dispatch may be attempted. Once the cooldown has
+// elapsed a single trial dispatch is let through to probe GitHub.
+func (h *healthState) allow() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch h.state() {
+	case BreakerClosed:
+		return true
+	case BreakerHalfOpen:
+		if h.trialInFlight {
+			return false
+		}
+		h.trialInFlight = true
+		return true
+	default:
+		return false
+	}
+}
+
+// recordSuccess closes the breaker and resets the failure count
+func (h *healthState) recordSuccess() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastSuccess = h.now()
+	h.consecutiveFailures = 0
+	h.openedAt = time.Time{}
+	h.trialInFlight = false
+}
+
+// recordFailure counts a failed dispatch and opens the breaker at the threshold
+func (h *healthState) recordFailure(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastFailure = h.now()
+	h.lastError = err.Error()
+	h.consecutiveFailures++
+
+	// A failed trial reopens the breaker for another cooldown
+	if h.trialInFlight || h.consecutiveFailures >= h.threshold {
+		h.openedAt = h.now()
+	}
+	h.trialInFlight = false
+}
+
+// abort ends a dispatch without recording an outcome, releasing any half-open trial
+func (h *healthState) abort() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.trialInFlight = false
+}
+
+// recordRateLimit stores the rate limit reported in GitHub response headers
+func (h *healthState) recordRateLimit(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.rateLimitRemaining = remaining
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		h.rateLimitReset = time.Unix(reset, 0)
+	}
+}
+
+// recordStatus counts dispatch responses with a server error. Any other
+// response ends the run, since GitHub answered it properly.
+func (h *healthState) recordStatus(statusCode int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if statusCode >= 500 {
+		h.serverErrors++
+	} else {
+		h.serverErrors = 0
+	}
+}
+
+// HealthStatus is a snapshot of the GitHub client's health
+type HealthStatus struct {
+	Status                  string       `json:"status"` // ok or degraded
+	CircuitBreaker          BreakerState `json:"circuit_breaker"`
+	ConsecutiveFailures     int          `json:"consecutive_failures"`
+	ConsecutiveServerErrors int          `json:"consecutive_server_errors"` // dispatch requests answered with a 5xx in a row
+	LastSuccessfulDispatch  *time.Time   `json:"last_successful_dispatch,omitempty"`
+	LastFailedDispatch      *time.Time   `json:"last_failed_dispatch,omitempty"`
+	LastError               string       `json:"last_error,omitempty"`
+	RateLimitRemaining      *int         `json:"rate_limit_remaining,omitempty"`
+	RateLimitReset          *time.Time   `json:"rate_limit_reset,omitempty"`
+	Warnings                []string     `json:"warnings,omitempty"`
+}
+
+// Health returns the current health of the client. Degraded means dispatches
+// are failing or about to be throttled, not that the service should stop serving.
+func (c *Client) Health() HealthStatus {
+	h := c.health
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	status := HealthStatus{
+		Status:                  "ok",
+		CircuitBreaker:          h.state(),
+		ConsecutiveFailures:     h.consecutiveFailures,
+		ConsecutiveServerErrors: h.serverErrors,
+		LastError:               h.lastError,
+	}
+
+	if !h.lastSuccess.IsZero() {
+		lastSuccess := h.lastSuccess
+		status.LastSuccessfulDispatch = &lastSuccess
+	}
+	if !h.lastFailure.IsZero() {
+		lastFailure := h.lastFailure
+		status.LastFailedDispatch = &lastFailure
+	}
+	if h.rateLimitRemaining >= 0 {
+		remaining := h.rateLimitRemaining
+		status.RateLimitRemaining = &remaining
+		if !h.rateLimitReset.IsZero() {
+			reset := h.rateLimitReset
+			status.RateLimitReset = &reset
+		}
+	}
+
+	if status.CircuitBreaker != BreakerClosed {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("circuit breaker %s after %d consecutive failures", status.CircuitBreaker, h.consecutiveFailures))
+	} else if h.consecutiveFailures > 0 {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("%d consecutive dispatch failures", h.consecutiveFailures))
+	}
+	if h.rateLimitRemaining >= 0 && h.rateLimitRemaining < lowRateLimitThreshold {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("rate limit nearly exhausted: %d requests remaining", h.rateLimitRemaining))
+	}
+	if len(status.Warnings) > 0 {
+		status.Status = "degraded"
+	}
+
+	return status
+}
+
+// Ping checks that the GitHub API is reachable and accepts the token. It asks
+// for the rate limit, which does not count against it.
+func (c *Client) Ping(ctx context.Context) error {
+	var rateLimit struct{}
+	if err := c.get(ctx, "/rate_limit", nil, &rateLimit); err != nil {
+		return fmt.Errorf("failed to reach github: %w", err)
+	}
+	return nil
+}
+
+// breakerStateValues maps breaker states to gauge values
+var breakerStateValues = map[BreakerState]float64{
+	BreakerClosed:   0,
+	BreakerHalfOpen: 1,
+	BreakerOpen:     2,
+}
+
+// MetricsCollector exports the client's health as Prometheus gauges at scrape time
+type MetricsCollector struct {
+	client              *Client
+	lastSuccess         *prometheus.Desc
+	consecutiveFailures *prometheus.Desc
+	rateLimitRemaining  *prometheus.Desc
+	rateLimitReset      *prometheus.Desc
+	breakerState        *prometheus.Desc
+}
+
+// NewMetricsCollector creates a collector for the client's health metrics
+func NewMetricsCollector(client *Client) *MetricsCollector {
+	return &MetricsCollector{
+		client: client,
+		lastSuccess: prometheus.NewDesc(
+			"github_last_successful_dispatch_timestamp_seconds",
+			"Unix time of the last successful workflow dispatch",
+			nil, nil,
+		),
+		consecutiveFailures: prometheus.NewDesc(
+			"github_dispatch_consecutive_failures",
+			"Number of workflow dispatches that have failed since the last success",
+			nil, nil,
+		),
+		rateLimitRemaining: prometheus.NewDesc(
+			"github_rate_limit_remaining",
+			"Remaining GitHub API requests in the current rate limit window",
+			nil, nil,
+		),
+		rateLimitReset: prometheus.NewDesc(
+			"github_rate_limit_reset_timestamp_seconds",
+			"Unix time at which the GitHub API rate limit window resets",
+			nil, nil,
+		),
+		breakerState: prometheus.NewDesc(
+			"github_circuit_breaker_state",
+			"State of the workflow dispatch circuit breaker (0 closed, 1 half-open, 2 open)",
+			nil, nil,
+		),
+	}
+}
+
+// Describe sends the metric descriptors
+func (m *MetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.lastSuccess
+	ch <- m.consecutiveFailures
+	ch <- m.rateLimitRemaining
+	ch <- m.rateLimitReset
+	ch <- m.breakerState
+}
+
+// Collect sends the current metric values. Rate limit metrics are omitted
+// until GitHub has reported them.
+func (m *MetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	health := m.client.Health()
+
+	var lastSuccess float64
+	if health.LastSuccessfulDispatch != nil {
+		lastSuccess = float64(health.LastSuccessfulDispatch.Unix())
+	}
+	ch <- prometheus.MustNewConstMetric(m.lastSuccess, prometheus.GaugeValue, lastSuccess)
+	ch <- prometheus.MustNewConstMetric(m.consecutiveFailures, prometheus.GaugeValue, float64(health.ConsecutiveFailures))
+	ch <- prometheus.MustNewConstMetric(m.breakerState, prometheus.GaugeValue, breakerStateValues[health.CircuitBreaker])
+
+	if health.RateLimitRemaining != nil {
+		ch <- prometheus.MustNewConstMetric(m.rateLimitRemaining, prometheus.GaugeValue, float64(*health.RateLimitRemaining))
+	}
+	if health.RateLimitReset != nil {
+		ch <- prometheus.MustNewConstMetric(m.rateLimitReset, prometheus.GaugeValue, float64(health.RateLimitReset.Unix()))
+	}
+}
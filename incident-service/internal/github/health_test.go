@@ -0,0 +1,143 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestHealthState_CircuitBreaker(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	h := newHealthState()
+	h.now = func() time.Time { return now }
+
+	for i := 0; i < defaultBreakerThreshold-1; i++ {
+		if !h.allow() {
+			t.Fatalf("expected dispatch %d to be allowed before threshold", i+1)
+		}
+		h.recordFailure(errors.New("boom"))
+	}
+	if h.state() != BreakerClosed {
+		t.Fatalf("expected breaker closed below threshold, got %s", h.state())
+	}
+
+	h.recordFailure(errors.New("boom"))
+	if h.state() != BreakerOpen || h.allow() {
+		t.Fatalf("expected open breaker to reject dispatches, got %s", h.state())
+	}
+
+	// After the cooldown a single trial is let through
+	now = now.Add(defaultBreakerCooldown)
+	if !h.allow() {
+		t.Fatal("expected half-open breaker to allow a trial dispatch")
+	}
+	if h.allow() {
+		t.Fatal("expected only one concurrent trial dispatch")
+	}
+
+	// A failed trial reopens the breaker
+	h.recordFailure(errors.New("still down"))
+	if h.state() != BreakerOpen {
+		t.Fatalf("expected failed trial to reopen breaker, got %s", h.state())
+	}
+
+	// A successful trial closes it
+	now = now.Add(defaultBreakerCooldown)
+	if !h.allow() {
+		t.Fatal("expected trial dispatch after second cooldown")
+	}
+	h.recordSuccess()
+	if h.state() != BreakerClosed || h.consecutiveFailures != 0 {
+		t.Fatalf("expected closed breaker after success, got %s with %d failures", h.state(), h.consecutiveFailures)
+	}
+}
+
+func TestClient_HealthTracksDispatches(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "1709726400")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "test-workflow.yml", 10)
+	incident := &models.Incident{ID: "inc-1", Repository: "org/repo", CreatedAt: time.Now()}
+
+	if _, err := client.DispatchWorkflow(context.Background(), incident, "main", DispatchOptions{}); err != nil {
+		t.Fatalf("DispatchWorkflow() error = %v", err)
+	}
+
+	health := client.Health()
+	if health.LastSuccessfulDispatch == nil {
+		t.Error("expected last successful dispatch to be recorded")
+	}
+	if health.RateLimitRemaining == nil || *health.RateLimitRemaining != 42 {
+		t.Errorf("expected 42 remaining requests, got %v", health.RateLimitRemaining)
+	}
+	if health.RateLimitReset == nil || health.RateLimitReset.Unix() != 1709726400 {
+		t.Errorf("expected rate limit reset to be recorded, got %v", health.RateLimitReset)
+	}
+	if health.Status != "degraded" || !strings.Contains(strings.Join(health.Warnings, ";"), "rate limit") {
+		t.Errorf("expected degraded status for low rate limit, got %s %v", health.Status, health.Warnings)
+	}
+
+	// Record a failure directly rather than waiting out the retry backoff
+	client.health.recordFailure(errors.New("unexpected status code 422"))
+	health = client.Health()
+	if health.ConsecutiveFailures != 1 || health.LastError == "" {
+		t.Errorf("expected one recorded failure, got %+v", health)
+	}
+
+	if got := testutil.CollectAndCount(NewMetricsCollector(client)); got != 5 {
+		t.Errorf("expected 5 health metrics, got %d", got)
+	}
+}
+
+func TestClient_DispatchRejectedWhileCircuitOpen(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "test-workflow.yml", 10)
+	for i := 0; i < defaultBreakerThreshold; i++ {
+		client.health.recordFailure(errors.New("boom"))
+	}
+
+	incident := &models.Incident{ID: "inc-1", Repository: "org/repo", CreatedAt: time.Now()}
+	_, err := client.DispatchWorkflow(context.Background(), incident, "main", DispatchOptions{})
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no requests to GitHub while open, got %d", requests)
+	}
+	if client.GetActiveCount("org/repo") != 0 {
+		t.Error("expected rejected dispatch not to count as active")
+	}
+}
+
+func TestHealthState_CountsServerErrors(t *testing.T) {
+	h := newHealthState()
+	for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable} {
+		h.recordStatus(status)
+	}
+	if h.serverErrors != 2 {
+		t.Fatalf("expected 2 consecutive server errors, got %d", h.serverErrors)
+	}
+
+	// A client error is a proper answer, so the run of server errors ends
+	h.recordStatus(http.StatusUnprocessableEntity)
+	if h.serverErrors != 0 {
+		t.Errorf("expected the run ended by a client error, got %d", h.serverErrors)
+	}
+}
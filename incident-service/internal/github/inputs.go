@@ -0,0 +1,121 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/textutil"
+)
+
+// MaxInputBytes is the most GitHub accepts for the inputs of a workflow
+// dispatch together
+const MaxInputBytes = 65535
+
+// traceParentBytes is the length of a W3C traceparent, which the client adds
+// to the inputs after they are truncated
+const traceParentBytes = 55
+
+// truncationMarker ends an input cut short, with the number of bytes left out
+const truncationMarker = "\n... [truncated, %d bytes omitted]"
+
+// NewWorkflowInputs returns the inputs of an incident's workflow dispatch,
+// without the traceparent, and the names of the inputs that were cut short
+// or left out to keep them within opts.MaxInputBytes. The workflow gets those
+// names as the truncated input, and can fetch the whole incident from
+// opts.ContextURL.
+func NewWorkflowInputs(incident *models.Incident, opts DispatchOptions) (WorkflowDispatchInput, []string) {
+	inputs := WorkflowDispatchInput{
+		IncidentID:   incident.ID,
+		ErrorMessage: incident.ErrorMessage,
+		ServiceName:  incident.ServiceName,
+		Timestamp:    incident.CreatedAt.Format(time.RFC3339),
+		Runbooks:     opts.Runbooks,
+		ContextURL:   opts.ContextURL,
+	}
+	if opts.Freeze {
+		inputs.Freeze = "true"
+	}
+	if incident.StackTrace != nil {
+		inputs.StackTrace = *incident.StackTrace
+	}
+	if incident.Enrichment != nil {
+		inputs.Context = *incident.Enrichment
+	}
+
+	if opts.MaxInputBytes <= 0 {
+		return inputs, nil
+	}
+	truncated := inputs.truncate(opts.MaxInputBytes - traceParentBytes)
+	if len(truncated) > 0 {
+		inputs.Truncated = strings.Join(truncated, ",")
+	}
+	return inputs, truncated
+}
+
+// size returns the length of every input together
+func (in *WorkflowDispatchInput) size() int {
+	return len(in.IncidentID) + len(in.ErrorMessage) + len(in.StackTrace) + len(in.ServiceName) +
+		len(in.Timestamp) + len(in.MCPConfig) + len(in.TraceParent) + len(in.Runbooks) +
+		len(in.Context) + len(in.Freeze) + len(in.ContextURL) + len(in.Truncated)
+}
+
+// truncatedNames lists the inputs truncate may change
+var truncatedNames = []string{"stack_trace", "incident_context", "runbooks", "error_message"}
+
+// truncate shortens the inputs to at most limit bytes together, including
+// the truncated input naming the inputs it changed, and returns those names.
+// The stack trace is cut first, keeping its top frames, down to half the
+// limit. The JSON inputs cannot be cut without breaking them, so the MCP
+// context and then the runbooks are left out whole next. Only then is the
+// stack trace cut further, and the error message last.
+func (in *WorkflowDispatchInput) truncate(limit int) []string {
+	limit -= len(strings.Join(truncatedNames, ","))
+
+	changed := make(map[string]bool)
+	shorten := func(name string, value *string, floor int) {
+		excess := in.size() - limit
+		if excess <= 0 || len(*value) <= floor {
+			return
+		}
+		if len(*value)-excess < floor {
+			excess = len(*value) - floor
+		}
+		*value = cut(*value, excess)
+		changed[name] = true
+	}
+	drop := func(name string, value *string) {
+		if in.size() > limit && *value != "" {
+			*value = ""
+			changed[name] = true
+		}
+	}
+
+	shorten("stack_trace", &in.StackTrace, limit/2)
+	drop("incident_context", &in.Context)
+	drop("runbooks", &in.Runbooks)
+	shorten("stack_trace", &in.StackTrace, 0)
+	shorten("error_message", &in.ErrorMessage, 0)
+
+	var truncated []string
+	for _, name := range truncatedNames {
+		if changed[name] {
+			truncated = append(truncated, name)
+		}
+	}
+	return truncated
+}
+
+// cut shortens s by at least excess bytes, ending it with the truncation
+// marker and without splitting a character. What does not fit is dropped
+// entirely.
+func cut(s string, excess int) string {
+	marker := fmt.Sprintf(truncationMarker, len(s))
+	keep := len(s) - excess - len(marker)
+	if keep <= 0 {
+		return ""
+	}
+	kept := textutil.Truncate(s, keep)
+	return kept + fmt.Sprintf(truncationMarker, len(s)-len(kept))
+}
@@ -0,0 +1,83 @@
+package github
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestNewWorkflowInputs_Truncation(t *testing.T) {
+	stackTrace := "Error: boom\n" + strings.Repeat("    at handler (/srv/app/handler.js:10:5)\n", 2000)
+	enrichment := `{"logs":"` + strings.Repeat("x", 3000) + `"}`
+	incident := &models.Incident{
+		ID:           "inc_1",
+		ServiceName:  "api",
+		ErrorMessage: "boom",
+		StackTrace:   &stackTrace,
+		Enrichment:   &enrichment,
+		CreatedAt:    time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+	}
+	runbooks := `{"runbooks":[{"url":"https://wiki.example.com/api"}]}`
+
+	tests := []struct {
+		name          string
+		limit         int
+		wantTruncated []string
+	}{
+		{"no limit", 0, nil},
+		{"within the limit", 200000, nil},
+		{"stack trace cut", 20000, []string{"stack_trace"}},
+		{"context left out", 3000, []string{"stack_trace", "incident_context"}},
+		{"runbooks left out", 250, []string{"stack_trace", "incident_context", "runbooks"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputs, truncated := NewWorkflowInputs(incident, DispatchOptions{Runbooks: runbooks, MaxInputBytes: tt.limit})
+
+			if strings.Join(truncated, ",") != strings.Join(tt.wantTruncated, ",") {
+				t.Fatalf("truncated = %v, want %v", truncated, tt.wantTruncated)
+			}
+			if inputs.Truncated != strings.Join(tt.wantTruncated, ",") {
+				t.Errorf("truncated input = %q, want the truncated inputs", inputs.Truncated)
+			}
+			if tt.limit > 0 && inputs.size()+traceParentBytes > tt.limit {
+				t.Errorf("inputs take %d bytes, over the limit of %d", inputs.size()+traceParentBytes, tt.limit)
+			}
+			if len(truncated) == 0 {
+				if inputs.StackTrace != stackTrace || inputs.Context != enrichment || inputs.Runbooks != runbooks {
+					t.Error("expected the inputs left whole")
+				}
+				return
+			}
+			if !strings.HasPrefix(inputs.StackTrace, "Error: boom\n") || !strings.Contains(inputs.StackTrace, "[truncated, ") {
+				t.Errorf("expected the top of the stack trace kept with a marker, got %q", inputs.StackTrace[:40])
+			}
+			if inputs.ErrorMessage != "boom" {
+				t.Errorf("expected the error message kept, got %q", inputs.ErrorMessage)
+			}
+		})
+	}
+}
+
+func TestCut(t *testing.T) {
+	s := strings.Repeat("é", 100) // 200 bytes
+
+	got := cut(s, 51)
+	if len(got) > len(s)-51 {
+		t.Errorf("cut() returned %d bytes, want at most %d", len(got), len(s)-51)
+	}
+	if !utf8.ValidString(got) {
+		t.Errorf("cut() split a character: %q", got)
+	}
+	if !strings.HasSuffix(got, " bytes omitted]") {
+		t.Errorf("cut() = %q, want the truncation marker", got)
+	}
+
+	if got := cut(s, 190); got != "" {
+		t.Errorf("cut() = %q, want nothing when the marker does not fit", got)
+	}
+}
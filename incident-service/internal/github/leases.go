@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// ExpiredSlot is a workflow slot released because its workflow never called
+// back within the slot TTL
+type ExpiredSlot struct {
+	Repository string
+	IncidentID string // empty for slots restored from a snapshot
+	AcquiredAt time.Time
+	Next       *models.Incident // the queued incident popped into the slot, if any
+}
+
+// Leases returns the leases of the repository's workflow slots, oldest first
+func (c *Client) Leases(ctx context.Context, repository string) ([]SlotLease, error) {
+	leases, err := c.slots.Leases(ctx, repository)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow slot leases: %w", err)
+	}
+	return leases, nil
+}
+
+// ExpireSlots releases every workflow slot leased longer than ttl ago, so that
+// a workflow that never reports back does not stall its repository's queue.
+// The expired slots are returned oldest first, each with the queued incident
+// that is due for dispatch in its place.
+func (c *Client) ExpireSlots(ctx context.Context, ttl time.Duration) ([]ExpiredSlot, error) {
+	stats, err := c.slots.Stats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow slots: %w", err)
+	}
+
+	cutoff := time.Now().Add(-ttl)
+	var expired []ExpiredSlot
+	for repository, entry := range stats {
+		if entry.Active == 0 {
+			continue
+		}
+		var repoErr error
+		if shardErr := c.shards.run(ctx, repository, func() {
+			var unlock func()
+			if unlock, repoErr = c.slots.Lock(ctx, repository); repoErr != nil {
+				return
+			}
+			defer unlock()
+
+			var leases []SlotLease
+			if leases, repoErr = c.slots.Leases(ctx, repository); repoErr != nil {
+				return
+			}
+			for _, lease := range leases {
+				if !lease.AcquiredAt.Before(cutoff) {
+					break
+				}
+				var next *models.Incident
+				if next, repoErr = c.slots.Release(ctx, repository, lease.IncidentID); repoErr != nil {
+					return
+				}
+				expired = append(expired, ExpiredSlot{
+					Repository: repository,
+					IncidentID: lease.IncidentID,
+					AcquiredAt: lease.AcquiredAt,
+					Next:       next,
+				})
+			}
+		}); shardErr != nil {
+			return expired, shardErr
+		}
+		if repoErr != nil {
+			return expired, fmt.Errorf("failed to expire workflow slots of %s: %w", repository, repoErr)
+		}
+	}
+
+	sort.Slice(expired, func(i, j int) bool { return expired[i].AcquiredAt.Before(expired[j].AcquiredAt) })
+	return expired, nil
+}
+
+// ResetSlots frees every workflow slot of the repository, for when its
+// counters no longer match the workflows that are running, and pops as many
+// queued incidents as the repository may run at once. The popped incidents
+// are returned next first and are due for dispatch.
+func (c *Client) ResetSlots(ctx context.Context, repository string) (next []*models.Incident, err error) {
+	if shardErr := c.shards.run(ctx, repository, func() {
+		var unlock func()
+		if unlock, err = c.slots.Lock(ctx, repository); err != nil {
+			return
+		}
+		defer unlock()
+
+		if err = c.slots.Reset(ctx, repository); err != nil {
+			return
+		}
+		for len(next) < c.maxWorkflowsPerRepo {
+			var incident *models.Incident
+			if incident, err = c.slots.Release(ctx, repository, ""); err != nil || incident == nil {
+				return
+			}
+			next = append(next, incident)
+		}
+	}); shardErr != nil {
+		return nil, shardErr
+	}
+	if err != nil {
+		return next, fmt.Errorf("failed to reset workflow slots: %w", err)
+	}
+	return next, nil
+}
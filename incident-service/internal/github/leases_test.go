@@ -0,0 +1,101 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestExpireSlots(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient("https://api.github.com", "test-token", "test-workflow.yml", 2)
+	for _, id := range []string{"inc_1", "inc_2"} {
+		if err := client.slots.Acquire(ctx, "org/checkout", id); err != nil {
+			t.Fatalf("Acquire() error = %v", err)
+		}
+	}
+	client.queueIncident(&models.Incident{ID: "inc_3", Repository: "org/checkout"})
+
+	// inc_1's workflow never called back
+	client.slotLeases["org/checkout"][0].AcquiredAt = time.Now().Add(-7 * time.Hour)
+
+	expired, err := client.ExpireSlots(ctx, 6*time.Hour)
+	if err != nil {
+		t.Fatalf("ExpireSlots() error = %v", err)
+	}
+	if len(expired) != 1 || expired[0].Repository != "org/checkout" || expired[0].IncidentID != "inc_1" {
+		t.Fatalf("ExpireSlots() = %+v, want the slot of inc_1", expired)
+	}
+	if expired[0].Next == nil || expired[0].Next.ID != "inc_3" {
+		t.Errorf("expected inc_3 popped into the expired slot, got %+v", expired[0].Next)
+	}
+	if active := client.GetActiveCount("org/checkout"); active != 1 {
+		t.Errorf("GetActiveCount() = %d, want 1", active)
+	}
+	leases, _ := client.Leases(ctx, "org/checkout")
+	if len(leases) != 1 || leases[0].IncidentID != "inc_2" {
+		t.Errorf("Leases() = %+v, want the lease of inc_2", leases)
+	}
+
+	if expired, _ := client.ExpireSlots(ctx, 6*time.Hour); len(expired) != 0 {
+		t.Errorf("expected no more expired slots, got %+v", expired)
+	}
+}
+
+func TestReleaseSlot_DropsTheIncidentsLease(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient("https://api.github.com", "test-token", "test-workflow.yml", 2)
+	for _, id := range []string{"inc_1", "inc_2"} {
+		if err := client.slots.Acquire(ctx, "org/checkout", id); err != nil {
+			t.Fatalf("Acquire() error = %v", err)
+		}
+	}
+
+	if _, err := client.ReleaseSlot(ctx, "org/checkout", "inc_2"); err != nil {
+		t.Fatalf("ReleaseSlot() error = %v", err)
+	}
+	leases, _ := client.Leases(ctx, "org/checkout")
+	if len(leases) != 1 || leases[0].IncidentID != "inc_1" {
+		t.Errorf("Leases() = %+v, want the lease of inc_1", leases)
+	}
+
+	// Without a lease of its own, the oldest slot is released
+	if _, err := client.ReleaseSlot(ctx, "org/checkout", ""); err != nil {
+		t.Fatalf("ReleaseSlot() error = %v", err)
+	}
+	if leases, _ := client.Leases(ctx, "org/checkout"); len(leases) != 0 {
+		t.Errorf("expected no leases, got %+v", leases)
+	}
+}
+
+func TestResetSlots(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient("https://api.github.com", "test-token", "test-workflow.yml", 2)
+	for i := 0; i < 2; i++ {
+		if err := client.slots.Acquire(ctx, "org/checkout", ""); err != nil {
+			t.Fatalf("Acquire() error = %v", err)
+		}
+	}
+	for _, id := range []string{"inc_1", "inc_2", "inc_3"} {
+		client.queueIncident(&models.Incident{ID: id, Repository: "org/checkout"})
+	}
+
+	next, err := client.ResetSlots(ctx, "org/checkout")
+	if err != nil {
+		t.Fatalf("ResetSlots() error = %v", err)
+	}
+	if len(next) != 2 || next[0].ID != "inc_1" || next[1].ID != "inc_2" {
+		t.Errorf("ResetSlots() = %+v, want inc_1 and inc_2", next)
+	}
+	if active := client.GetActiveCount("org/checkout"); active != 0 {
+		t.Errorf("GetActiveCount() = %d, want 0", active)
+	}
+	if queued := client.QueuedIncidents("org/checkout"); len(queued) != 1 || queued[0] != "inc_3" {
+		t.Errorf("QueuedIncidents() = %v, want [inc_3]", queued)
+	}
+	if leases, _ := client.Leases(ctx, "org/checkout"); len(leases) != 0 {
+		t.Errorf("expected no leases, got %+v", leases)
+	}
+}
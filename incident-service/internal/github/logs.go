@@ -0,0 +1,180 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// maxJobLogBytes bounds how much of a job's log is kept in memory. Failures
+// are reported at the end of a log, so it is the start that is dropped.
+const maxJobLogBytes = 4 << 20
+
+// WorkflowJob is a job of a workflow run
+type WorkflowJob struct {
+	ID         int64          `json:"id"`
+	Name       string         `json:"name"`
+	Status     string         `json:"status"`
+	Conclusion string         `json:"conclusion"`
+	HTMLURL    string         `json:"html_url"`
+	Steps      []WorkflowStep `json:"steps"`
+}
+
+// WorkflowStep is a step of a workflow job
+type WorkflowStep struct {
+	Name       string `json:"name"`
+	Number     int    `json:"number"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+}
+
+// Failed reports whether the job finished unsuccessfully
+func (j *WorkflowJob) Failed() bool {
+	switch j.Conclusion {
+	case "failure", "timed_out", "cancelled":
+		return true
+	}
+	return false
+}
+
+// FailedStep returns the first step of the job that failed, or nil if none did
+func (j *WorkflowJob) FailedStep() *WorkflowStep {
+	for i := range j.Steps {
+		switch j.Steps[i].Conclusion {
+		case "failure", "timed_out", "cancelled":
+			return &j.Steps[i]
+		}
+	}
+	return nil
+}
+
+// GetWorkflowRun returns a run of a workflow
+func (c *Client) GetWorkflowRun(ctx context.Context, repository string, runID int64) (*WorkflowRun, error) {
+	var run WorkflowRun
+	if err := c.get(ctx, fmt.Sprintf("/repos/%s/actions/runs/%d", repository, runID), nil, &run); err != nil {
+		return nil, fmt.Errorf("failed to get workflow run: %w", err)
+	}
+	return &run, nil
+}
+
+// ListRunJobs returns the jobs of the latest attempt of a workflow run
+func (c *Client) ListRunJobs(ctx context.Context, repository string, runID int64) ([]WorkflowJob, error) {
+	query := url.Values{}
+	query.Set("filter", "latest")
+	query.Set("per_page", "100")
+
+	var response struct {
+		Jobs []WorkflowJob `json:"jobs"`
+	}
+	path := fmt.Sprintf("/repos/%s/actions/runs/%d/jobs", repository, runID)
+	if err := c.get(ctx, path, query, &response); err != nil {
+		return nil, fmt.Errorf("failed to list workflow jobs: %w", err)
+	}
+
+	return response.Jobs, nil
+}
+
+// DownloadJobLogs returns the plain text log of a workflow job. GitHub
+// answers with a redirect to the log, which the HTTP client follows without
+// passing on the token. Logs over maxJobLogBytes keep only their end.
+func (c *Client) DownloadJobLogs(ctx context.Context, repository string, jobID int64) (string, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/actions/jobs/%d/logs", c.apiURL, repository, jobID)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download job logs: %w", err)
+	}
+	defer closeBody(resp.Body)
+
+	c.health.recordRateLimit(resp.Header)
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("failed to download job logs: %w", &APIError{StatusCode: resp.StatusCode, Body: string(bodyBytes)})
+	}
+
+	log, err := readTail(resp.Body, maxJobLogBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to read job logs: %w", err)
+	}
+	return log, nil
+}
+
+// readTail reads r to the end, keeping at most its last limit bytes
+func readTail(r io.Reader, limit int) (string, error) {
+	buf := make([]byte, 0, 64<<10)
+	chunk := make([]byte, 32<<10)
+	for {
+		n, err := r.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		if len(buf) > limit {
+			buf = append(buf[:0], buf[len(buf)-limit:]...)
+		}
+		if err == io.EOF {
+			return string(buf), nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// logTimestamp is the timestamp GitHub prefixes every log line with
+var logTimestamp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z ?`)
+
+// ExtractFailure returns the output of the step that failed in a job log,
+// with timestamps stripped. Every step starts with a "##[group]Run" line and
+// GitHub reports failures as "##[error]" lines, so the failing step is the
+// one holding the first error. Without an error line, the end of the log is
+// returned. Output over maxBytes keeps its last lines, and truncated reports
+// that earlier output was cut off.
+func ExtractFailure(log string, maxBytes int) (excerpt string, truncated bool) {
+	lines := strings.Split(strings.ReplaceAll(log, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = logTimestamp.ReplaceAllString(line, "")
+	}
+
+	start, end := 0, len(lines)
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "##[error]") {
+			continue
+		}
+		for j := i; j >= 0; j-- {
+			if strings.HasPrefix(lines[j], "##[group]Run ") {
+				start = j
+				break
+			}
+		}
+		for j := i + 1; j < len(lines); j++ {
+			if strings.HasPrefix(lines[j], "##[group]Run ") {
+				end = j
+				break
+			}
+		}
+		break
+	}
+
+	step := strings.TrimRight(strings.Join(lines[start:end], "\n"), "\n")
+	if len(step) <= maxBytes {
+		return step, false
+	}
+
+	// Cut at a line boundary, so the excerpt does not start mid-line
+	tail := step[len(step)-maxBytes:]
+	if i := strings.IndexByte(tail, '\n'); i >= 0 && i < len(tail)-1 {
+		tail = tail[i+1:]
+	}
+	return tail, true
+}
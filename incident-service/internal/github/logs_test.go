@@ -0,0 +1,109 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDownloadJobLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/org/repo/actions/runs/42/jobs":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"jobs": []map[string]interface{}{
+					{"id": 7, "name": "remediate", "status": "completed", "conclusion": "failure", "steps": []map[string]interface{}{
+						{"name": "Checkout", "number": 1, "conclusion": "success"},
+						{"name": "Run tests", "number": 2, "conclusion": "failure"},
+					}},
+				},
+			})
+		case "/repos/org/repo/actions/jobs/7/logs":
+			// GitHub redirects to the log file
+			http.Redirect(w, r, "/logs/7.txt", http.StatusFound)
+		case "/logs/7.txt":
+			_, _ = w.Write([]byte("2024-03-06T12:00:00.0000000Z ##[error]Process completed with exit code 1.\n"))
+		case "/repos/org/repo/actions/jobs/8/logs":
+			http.Error(w, "gone", http.StatusGone)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "remediate.yml", 10)
+
+	jobs, err := client.ListRunJobs(context.Background(), "org/repo", 42)
+	if err != nil {
+		t.Fatalf("ListRunJobs() error = %v", err)
+	}
+	if len(jobs) != 1 || !jobs[0].Failed() {
+		t.Fatalf("expected one failed job, got %+v", jobs)
+	}
+	if step := jobs[0].FailedStep(); step == nil || step.Name != "Run tests" {
+		t.Errorf("expected the failed step to be Run tests, got %+v", step)
+	}
+
+	log, err := client.DownloadJobLogs(context.Background(), "org/repo", 7)
+	if err != nil {
+		t.Fatalf("DownloadJobLogs() error = %v", err)
+	}
+	if !strings.Contains(log, "exit code 1") {
+		t.Errorf("unexpected log %q", log)
+	}
+
+	_, err = client.DownloadJobLogs(context.Background(), "org/repo", 8)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusGone {
+		t.Errorf("expected a 410 API error for expired logs, got %v", err)
+	}
+}
+
+func TestReadTail(t *testing.T) {
+	log, err := readTail(strings.NewReader(strings.Repeat("a", 100000)+"end"), 10)
+	if err != nil {
+		t.Fatalf("readTail() error = %v", err)
+	}
+	if log != "aaaaaaaend" {
+		t.Errorf("expected the last 10 bytes, got %q", log)
+	}
+}
+
+func TestExtractFailure(t *testing.T) {
+	log := strings.Join([]string{
+		"2024-03-06T12:00:00.0000000Z ##[group]Run actions/checkout@v4",
+		"2024-03-06T12:00:01.0000000Z Syncing repository: org/repo",
+		"2024-03-06T12:00:02.0000000Z ##[endgroup]",
+		"2024-03-06T12:00:03.0000000Z ##[group]Run npm test",
+		"2024-03-06T12:00:03.0000000Z npm test",
+		"2024-03-06T12:00:04.0000000Z ##[endgroup]",
+		"2024-03-06T12:00:05.0000000Z FAIL src/api.test.ts",
+		"2024-03-06T12:00:06.0000000Z ##[error]Process completed with exit code 1.",
+		"2024-03-06T12:00:07.0000000Z ##[group]Run actions/upload-artifact@v4",
+		"2024-03-06T12:00:08.0000000Z ##[endgroup]",
+		"",
+	}, "\n")
+
+	excerpt, truncated := ExtractFailure(log, 1024)
+	want := "##[group]Run npm test\nnpm test\n##[endgroup]\nFAIL src/api.test.ts\n##[error]Process completed with exit code 1."
+	if excerpt != want || truncated {
+		t.Errorf("ExtractFailure() = %q, %v, want %q", excerpt, truncated, want)
+	}
+
+	// Long output keeps its last whole lines
+	excerpt, truncated = ExtractFailure(log, 70)
+	want = "FAIL src/api.test.ts\n##[error]Process completed with exit code 1."
+	if excerpt != want || !truncated {
+		t.Errorf("ExtractFailure() = %q, %v, want %q", excerpt, truncated, want)
+	}
+
+	// Without an error line, the end of the log is kept
+	excerpt, _ = ExtractFailure("2024-03-06T12:00:00Z one\n2024-03-06T12:00:01Z two\n", 4)
+	if excerpt != "two" {
+		t.Errorf("expected the last line, got %q", excerpt)
+	}
+}
@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// WorkflowRun is a run of the remediation workflow
+type WorkflowRun struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	DisplayTitle string    `json:"display_title"`
+	Status       string    `json:"status"`     // queued, in_progress, completed, ...
+	Conclusion   string    `json:"conclusion"` // success, failure, cancelled, timed_out, ... once completed
+	HTMLURL      string    `json:"html_url"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Completed reports whether the run has finished
+func (r *WorkflowRun) Completed() bool {
+	return r.Status == "completed"
+}
+
+// PullRequest is a pull request opened by the remediation workflow
+type PullRequest struct {
+	Number    int        `json:"number"`
+	HTMLURL   string     `json:"html_url"`
+	State     string     `json:"state"`
+	CreatedAt time.Time  `json:"created_at"`
+	MergedAt  *time.Time `json:"merged_at"`
+}
+
+// RemediationBranch returns the branch the remediation action pushes fixes for an incident to
+func RemediationBranch(incidentID string) string {
+	return "fix/incident-" + incidentID
+}
+
+// ListWorkflowRuns returns dispatched runs of the remediation workflow created since the given time
+func (c *Client) ListWorkflowRuns(ctx context.Context, repository string, since time.Time) ([]WorkflowRun, error) {
+	query := url.Values{}
+	query.Set("event", "workflow_dispatch")
+	query.Set("created", ">="+since.UTC().Format(time.RFC3339))
+	query.Set("per_page", "100")
+
+	var response struct {
+		WorkflowRuns []WorkflowRun `json:"workflow_runs"`
+	}
+	path := fmt.Sprintf("/repos/%s/actions/workflows/%s/runs", repository, c.workflow)
+	if err := c.get(ctx, path, query, &response); err != nil {
+		return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+	}
+
+	return response.WorkflowRuns, nil
+}
+
+// FindPullRequest returns the most recent pull request from branch in any state, or nil if there is none
+func (c *Client) FindPullRequest(ctx context.Context, repository, branch string) (*PullRequest, error) {
+	owner := repository
+	if i := strings.Index(repository, "/"); i >= 0 {
+		owner = repository[:i]
+	}
+
+	query := url.Values{}
+	query.Set("state", "all")
+	query.Set("head", owner+":"+branch)
+
+	var pulls []PullRequest
+	if err := c.get(ctx, fmt.Sprintf("/repos/%s/pulls", repository), query, &pulls); err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+
+	if len(pulls) == 0 {
+		return nil, nil
+	}
+	return &pulls[0], nil
+}
+
+// APIError is returned for GitHub API responses with an unexpected status code
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+// get makes an authenticated GET request to the GitHub API and decodes the JSON response into out
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	_, err := c.getWithHeader(ctx, path, query, out)
+	return err
+}
+
+// getWithHeader is get, also returning the response headers
+func (c *Client) getWithHeader(ctx context.Context, path string, query url.Values, out interface{}) (http.Header, error) {
+	endpoint := c.apiURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer closeBody(resp.Body)
+
+	c.health.recordRateLimit(resp.Header)
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return resp.Header, &APIError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp.Header, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return resp.Header, nil
+}
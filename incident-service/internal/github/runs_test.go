@@ -0,0 +1,96 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListWorkflowRuns(t *testing.T) {
+	since := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/org/repo/actions/workflows/remediate.yml/runs" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("created"); got != ">=2024-03-06T12:00:00Z" {
+			t.Errorf("unexpected created filter %q", got)
+		}
+		if got := r.URL.Query().Get("event"); got != "workflow_dispatch" {
+			t.Errorf("unexpected event filter %q", got)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Error("expected bearer token")
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"workflow_runs": []map[string]interface{}{
+				{"id": 42, "display_title": "Remediate incident inc-1", "status": "completed", "conclusion": "failure"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "remediate.yml", 10)
+	runs, err := client.ListWorkflowRuns(context.Background(), "org/repo", since)
+	if err != nil {
+		t.Fatalf("ListWorkflowRuns() error = %v", err)
+	}
+
+	if len(runs) != 1 || runs[0].ID != 42 || !runs[0].Completed() || runs[0].Conclusion != "failure" {
+		t.Errorf("unexpected runs %+v", runs)
+	}
+}
+
+func TestFindPullRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/org/repo/pulls" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		switch r.URL.Query().Get("head") {
+		case "org:fix/incident-inc-1":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"number": 7, "html_url": "https://github.com/org/repo/pull/7", "state": "open"},
+			})
+		default:
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "remediate.yml", 10)
+
+	pr, err := client.FindPullRequest(context.Background(), "org/repo", RemediationBranch("inc-1"))
+	if err != nil {
+		t.Fatalf("FindPullRequest() error = %v", err)
+	}
+	if pr == nil || pr.Number != 7 {
+		t.Fatalf("expected pull request 7, got %+v", pr)
+	}
+
+	pr, err = client.FindPullRequest(context.Background(), "org/repo", RemediationBranch("inc-2"))
+	if err != nil {
+		t.Fatalf("FindPullRequest() error = %v", err)
+	}
+	if pr != nil {
+		t.Errorf("expected no pull request, got %+v", pr)
+	}
+}
+
+func TestGet_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		http.Error(w, `{"message":"API rate limit exceeded"}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "remediate.yml", 10)
+	if _, err := client.ListWorkflowRuns(context.Background(), "org/repo", time.Now()); err == nil {
+		t.Fatal("expected error for a 403 response")
+	}
+	if remaining := client.Health().RateLimitRemaining; remaining == nil || *remaining != 0 {
+		t.Error("expected rate limit to be recorded from read requests")
+	}
+}
@@ -0,0 +1,78 @@
+package github
+
+import (
+	"context"
+	"hash/fnv"
+)
+
+// DefaultDispatchShards is the number of dispatch shards of a new client
+const DefaultDispatchShards = 16
+
+// dispatchShards serializes the dispatch operations of each repository. A
+// repository always hashes to the same shard, and each shard runs its
+// operations one at a time in the order they were submitted. Checking a
+// repository's concurrency limit and taking a slot can then never interleave
+// with another dispatch or release for that repository, and incidents of a
+// repository are dispatched in the order they arrived.
+type dispatchShards struct {
+	queues []chan func()
+}
+
+// newDispatchShards starts n shard workers
+func newDispatchShards(n int) *dispatchShards {
+	if n <= 0 {
+		n = DefaultDispatchShards
+	}
+
+	s := &dispatchShards{queues: make([]chan func(), n)}
+	for i := range s.queues {
+		queue := make(chan func(), 64)
+		s.queues[i] = queue
+		go func() {
+			for op := range queue {
+				op()
+			}
+		}()
+	}
+	return s
+}
+
+// shard returns the index of the shard owning repository
+func (s *dispatchShards) shard(repository string) int {
+	h := fnv.New32a()
+	h.Write([]byte(repository))
+	return int(h.Sum32() % uint32(len(s.queues)))
+}
+
+// run runs op on the repository's shard and waits for it to finish. It gives
+// up with ctx's error if ctx is done before op gets a place in the shard's
+// queue; once queued, op always runs. A panic in op is raised again in the
+// caller, so the shard keeps running.
+func (s *dispatchShards) run(ctx context.Context, repository string, op func()) error {
+	done := make(chan struct{})
+	var panicked interface{}
+	select {
+	case s.queues[s.shard(repository)] <- func() {
+		defer func() {
+			panicked = recover()
+			close(done)
+		}()
+		op()
+	}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	<-done
+	if panicked != nil {
+		panic(panicked)
+	}
+	return nil
+}
+
+// stop stops the shard workers once their queued operations have run
+func (s *dispatchShards) stop() {
+	for _, queue := range s.queues {
+		close(queue)
+	}
+}
@@ -0,0 +1,118 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestDispatchShards_SerializeRepository(t *testing.T) {
+	shards := newDispatchShards(4)
+	defer shards.stop()
+
+	var running, overlaps int32
+	var order []int
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_ = shards.run(context.Background(), "org/repo", func() {
+				if atomic.AddInt32(&running, 1) > 1 {
+					atomic.AddInt32(&overlaps, 1)
+				}
+				order = append(order, i)
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&running, -1)
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	if overlaps != 0 {
+		t.Errorf("expected operations of one repository never to overlap, got %d overlaps", overlaps)
+	}
+	if len(order) != 20 {
+		t.Errorf("expected 20 operations to run, got %d", len(order))
+	}
+}
+
+func TestDispatchShards_StableAssignment(t *testing.T) {
+	shards := newDispatchShards(8)
+	defer shards.stop()
+
+	for i := 0; i < 50; i++ {
+		repository := fmt.Sprintf("org/repo-%d", i)
+		if shards.shard(repository) != shards.shard(repository) {
+			t.Fatalf("%s moved between shards", repository)
+		}
+	}
+	if got := len(newDispatchShards(0).queues); got != DefaultDispatchShards {
+		t.Errorf("expected %d shards by default, got %d", DefaultDispatchShards, got)
+	}
+}
+
+func TestDispatchShards_RaisesPanicInCaller(t *testing.T) {
+	shards := newDispatchShards(1)
+	defer shards.stop()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected the panic to reach the caller")
+			}
+		}()
+		_ = shards.run(context.Background(), "org/repo", func() { panic("boom") })
+	}()
+
+	// The shard survives the panic
+	ran := false
+	_ = shards.run(context.Background(), "org/repo", func() { ran = true })
+	if !ran {
+		t.Error("expected the shard to keep running operations")
+	}
+}
+
+func TestDispatchWorkflow_ConcurrentDispatchesRespectLimit(t *testing.T) {
+	var dispatched int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&dispatched, 1)
+		time.Sleep(5 * time.Millisecond)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "test-workflow.yml", 2)
+
+	var queued int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			incident := &models.Incident{ID: fmt.Sprintf("inc-%d", i), Repository: "org/repo"}
+			if _, err := client.DispatchWorkflow(context.Background(), incident, "main", DispatchOptions{}); err == ErrIncidentQueued {
+				atomic.AddInt32(&queued, 1)
+			} else if err != nil {
+				t.Errorf("DispatchWorkflow() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Without serialization, every dispatch could pass the limit check while
+	// the first ones were still in flight
+	if dispatched != 2 || queued != 8 {
+		t.Errorf("expected 2 dispatched and 8 queued, got %d and %d", dispatched, queued)
+	}
+	if got := client.GetActiveCount("org/repo"); got != 2 {
+		t.Errorf("expected 2 active workflows, got %d", got)
+	}
+}
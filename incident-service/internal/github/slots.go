@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// SlotStore keeps the workflow concurrency state of each repository: how many
+// workflows are running, and which incidents wait for a slot. The client
+// keeps it in memory by default. A shared store lets several replicas of the
+// service enforce one limit and share one queue.
+type SlotStore interface {
+	// Lock keeps other replicas from changing the repository's state until
+	// unlock is called. Replicas of one client are already serialized by
+	// their dispatch shard.
+	Lock(ctx context.Context, repository string) (unlock func(), err error)
+	// Active returns the number of running workflows
+	Active(ctx context.Context, repository string) (int, error)
+	// Acquire takes a slot for the incident's workflow, leased from now
+	Acquire(ctx context.Context, repository, incidentID string) error
+	// Release frees the incident's slot, or the oldest one when the incident
+	// holds none, and pops the next queued incident, if any
+	Release(ctx context.Context, repository, incidentID string) (*models.Incident, error)
+	// Leases returns the leases of the repository's slots, oldest first
+	Leases(ctx context.Context, repository string) ([]SlotLease, error)
+	// Reset frees every slot of the repository, leaving its queue
+	Reset(ctx context.Context, repository string) error
+	// Enqueue appends an incident to its repository's queue
+	Enqueue(ctx context.Context, incident *models.Incident) error
+	// Remove drops an incident from the queue, reporting whether it was queued
+	Remove(ctx context.Context, repository, incidentID string) (bool, error)
+	// Queued returns the incidents queued for a repository, next first
+	Queued(ctx context.Context, repository string) ([]*models.Incident, error)
+	// Stats returns the state of every repository with running or queued workflows
+	Stats(ctx context.Context) (map[string]RepositoryStats, error)
+}
+
+// SlotLease records which incident's workflow holds a slot, and since when.
+// Slots restored from a snapshot have no incident.
+type SlotLease struct {
+	IncidentID string    `json:"incident_id,omitempty"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+// memorySlots is the in-memory SlotStore, kept in the client's maps
+type memorySlots struct {
+	c *Client
+}
+
+func (s memorySlots) Lock(ctx context.Context, repository string) (func(), error) {
+	return func() {}, nil
+}
+
+func (s memorySlots) Active(ctx context.Context, repository string) (int, error) {
+	s.c.mu.RLock()
+	defer s.c.mu.RUnlock()
+
+	return s.c.activeWorkflows[repository], nil
+}
+
+func (s memorySlots) Acquire(ctx context.Context, repository, incidentID string) error {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	s.c.activeWorkflows[repository]++
+	s.c.slotLeases[repository] = append(s.c.slotLeases[repository], SlotLease{IncidentID: incidentID, AcquiredAt: time.Now()})
+	return nil
+}
+
+func (s memorySlots) Release(ctx context.Context, repository, incidentID string) (*models.Incident, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	if s.c.activeWorkflows[repository] > 0 {
+		s.c.activeWorkflows[repository]--
+	}
+	if leases := s.c.slotLeases[repository]; len(leases) > 0 {
+		i := 0
+		for j, lease := range leases {
+			if lease.IncidentID == incidentID {
+				i = j
+				break
+			}
+		}
+		s.c.slotLeases[repository] = append(leases[:i:i], leases[i+1:]...)
+	}
+
+	queue := s.c.queuedIncidents[repository]
+	if len(queue) == 0 {
+		return nil, nil
+	}
+	s.c.queuedIncidents[repository] = queue[1:]
+	return queue[0], nil
+}
+
+func (s memorySlots) Leases(ctx context.Context, repository string) ([]SlotLease, error) {
+	s.c.mu.RLock()
+	defer s.c.mu.RUnlock()
+
+	return append([]SlotLease(nil), s.c.slotLeases[repository]...), nil
+}
+
+func (s memorySlots) Reset(ctx context.Context, repository string) error {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	delete(s.c.activeWorkflows, repository)
+	delete(s.c.slotLeases, repository)
+	return nil
+}
+
+func (s memorySlots) Enqueue(ctx context.Context, incident *models.Incident) error {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	s.c.queuedIncidents[incident.Repository] = append(s.c.queuedIncidents[incident.Repository], incident)
+	return nil
+}
+
+func (s memorySlots) Remove(ctx context.Context, repository, incidentID string) (bool, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	queue := s.c.queuedIncidents[repository]
+	for i, incident := range queue {
+		if incident.ID == incidentID {
+			s.c.queuedIncidents[repository] = append(queue[:i:i], queue[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s memorySlots) Queued(ctx context.Context, repository string) ([]*models.Incident, error) {
+	s.c.mu.RLock()
+	defer s.c.mu.RUnlock()
+
+	return append([]*models.Incident(nil), s.c.queuedIncidents[repository]...), nil
+}
+
+func (s memorySlots) Stats(ctx context.Context) (map[string]RepositoryStats, error) {
+	s.c.mu.RLock()
+	defer s.c.mu.RUnlock()
+
+	stats := make(map[string]RepositoryStats)
+	for repository, active := range s.c.activeWorkflows {
+		if active > 0 {
+			stats[repository] = RepositoryStats{Active: active}
+		}
+	}
+	for repository, queue := range s.c.queuedIncidents {
+		if len(queue) > 0 {
+			entry := stats[repository]
+			entry.Queued = len(queue)
+			stats[repository] = entry
+		}
+	}
+	return stats, nil
+}
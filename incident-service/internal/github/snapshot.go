@@ -0,0 +1,76 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// QueueSnapshot is the workflow concurrency state of the client at one time,
+// saved so that a restarted service keeps its queued remediations
+type QueueSnapshot struct {
+	TakenAt      time.Time
+	Repositories map[string]RepositorySnapshot
+}
+
+// RepositorySnapshot is the concurrency state of one repository
+type RepositorySnapshot struct {
+	Active int      `json:"active"`
+	Queued []string `json:"queued"` // incident IDs, next first
+}
+
+// Snapshot returns the running workflows and queued incidents of every
+// repository with either
+func (c *Client) Snapshot(ctx context.Context) (*QueueSnapshot, error) {
+	stats, err := c.slots.Stats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow slots: %w", err)
+	}
+
+	snapshot := &QueueSnapshot{TakenAt: time.Now(), Repositories: make(map[string]RepositorySnapshot, len(stats))}
+	for repository, entry := range stats {
+		queued, err := c.slots.Queued(ctx, repository)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get queued incidents: %w", err)
+		}
+		repositorySnapshot := RepositorySnapshot{Active: entry.Active, Queued: make([]string, 0, len(queued))}
+		for _, incident := range queued {
+			repositorySnapshot.Queued = append(repositorySnapshot.Queued, incident.ID)
+		}
+		snapshot.Repositories[repository] = repositorySnapshot
+	}
+	return snapshot, nil
+}
+
+// Restore takes the slots of a repository's running workflows and queues its
+// incidents, next first, as a snapshot of an earlier run of the service
+// recorded them. It adds to the repository's state, so it must be called
+// before the client is used.
+func (c *Client) Restore(ctx context.Context, repository string, active int, queued []*models.Incident) (err error) {
+	if shardErr := c.shards.run(ctx, repository, func() {
+		var unlock func()
+		if unlock, err = c.slots.Lock(ctx, repository); err != nil {
+			return
+		}
+		defer unlock()
+
+		for i := 0; i < active; i++ {
+			if err = c.slots.Acquire(ctx, repository, ""); err != nil {
+				return
+			}
+		}
+		for _, incident := range queued {
+			if err = c.slots.Enqueue(ctx, incident); err != nil {
+				return
+			}
+		}
+	}); shardErr != nil {
+		return shardErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restore workflow slots: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package github
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient("https://api.github.com", "test-token", "test-workflow.yml", 1)
+	if err := client.slots.Acquire(ctx, "org/checkout", "inc_0"); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	client.queueIncident(&models.Incident{ID: "inc_1", Repository: "org/checkout"})
+	client.queueIncident(&models.Incident{ID: "inc_2", Repository: "org/checkout"})
+	if err := client.slots.Acquire(ctx, "org/billing", "inc_3"); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	snapshot, err := client.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	want := map[string]RepositorySnapshot{
+		"org/checkout": {Active: 1, Queued: []string{"inc_1", "inc_2"}},
+		"org/billing":  {Active: 1, Queued: []string{}},
+	}
+	if !reflect.DeepEqual(snapshot.Repositories, want) {
+		t.Fatalf("Snapshot() = %+v, want %+v", snapshot.Repositories, want)
+	}
+
+	restarted := NewClient("https://api.github.com", "test-token", "test-workflow.yml", 1)
+	for repository, entry := range snapshot.Repositories {
+		queued := make([]*models.Incident, 0, len(entry.Queued))
+		for _, id := range entry.Queued {
+			queued = append(queued, &models.Incident{ID: id, Repository: repository})
+		}
+		if err := restarted.Restore(ctx, repository, entry.Active, queued); err != nil {
+			t.Fatalf("Restore() error = %v", err)
+		}
+	}
+	if !reflect.DeepEqual(restarted.Stats(), client.Stats()) {
+		t.Errorf("restored stats = %+v, want %+v", restarted.Stats(), client.Stats())
+	}
+
+	// The restored slot still holds back the queue until it is released
+	if next := restarted.DecrementActive("org/checkout"); next == nil || next.ID != "inc_1" {
+		t.Errorf("expected inc_1 dispatched next, got %+v", next)
+	}
+	if got := restarted.QueuedIncidents("org/checkout"); !reflect.DeepEqual(got, []string{"inc_2"}) {
+		t.Errorf("QueuedIncidents() = %v, want [inc_2]", got)
+	}
+}
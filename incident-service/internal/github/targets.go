@@ -0,0 +1,121 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Repository is a GitHub repository
+type Repository struct {
+	FullName      string `json:"full_name"`
+	DefaultBranch string `json:"default_branch"`
+	Archived      bool   `json:"archived"`
+}
+
+// GetRepository returns a repository, or nil if it does not exist or is not
+// visible to the token
+func (c *Client) GetRepository(ctx context.Context, repository string) (*Repository, error) {
+	var repo Repository
+	if err := c.get(ctx, "/repos/"+repository, nil, &repo); err != nil {
+		if notFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	return &repo, nil
+}
+
+// BranchExists reports whether a repository has a branch
+func (c *Client) BranchExists(ctx context.Context, repository, branch string) (bool, error) {
+	var response struct{}
+	path := fmt.Sprintf("/repos/%s/branches/%s", repository, escapePath(branch))
+	if err := c.get(ctx, path, nil, &response); err != nil {
+		if notFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get branch: %w", err)
+	}
+
+	return true, nil
+}
+
+// FileExists reports whether a file exists on a branch of a repository. An
+// empty branch is the repository's default branch.
+func (c *Client) FileExists(ctx context.Context, repository, branch, path string) (bool, error) {
+	query := url.Values{}
+	if branch != "" {
+		query.Set("ref", branch)
+	}
+	var response struct{}
+	if err := c.get(ctx, fmt.Sprintf("/repos/%s/contents/%s", repository, escapePath(path)), query, &response); err != nil {
+		if notFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	return true, nil
+}
+
+// CheckTarget checks that the remediation workflow can be dispatched on a
+// branch of a repository: the repository exists and is not archived, the
+// branch exists, and the workflow is active and present on the branch. It
+// returns the problems found, each of which would fail a dispatch, and an
+// error only when GitHub could not be asked.
+func (c *Client) CheckTarget(ctx context.Context, repository, branch string) ([]string, error) {
+	repo, err := c.GetRepository(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return []string{"repository not found, or not visible to the token"}, nil
+	}
+	if repo.Archived {
+		return []string{"repository is archived"}, nil
+	}
+
+	var problems []string
+	if branch == "" {
+		problems = append(problems, "no branch is set")
+	} else {
+		exists, err := c.BranchExists(ctx, repository, branch)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			problems = append(problems, fmt.Sprintf("branch %s not found", branch))
+		}
+	}
+
+	workflow, err := c.GetWorkflow(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case workflow == nil:
+		problems = append(problems, fmt.Sprintf("workflow %s not found", c.workflow))
+	case workflow.State != "active":
+		problems = append(problems, fmt.Sprintf("workflow %s is %s", workflow.Path, workflow.State))
+	case branch != "" && len(problems) == 0:
+		// A workflow is dispatched from its file on the branch
+		present, err := c.FileExists(ctx, repository, branch, workflow.Path)
+		if err != nil {
+			return nil, err
+		}
+		if !present {
+			problems = append(problems, fmt.Sprintf("workflow %s not found on branch %s", workflow.Path, branch))
+		}
+	}
+
+	return problems, nil
+}
+
+// notFound reports whether err is a 404 from the GitHub API
+func notFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,66 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestCheckTarget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/org/api", "/repos/org/stale":
+			_, _ = w.Write([]byte(`{"full_name": "org/api", "default_branch": "main"}`))
+		case "/repos/org/old":
+			_, _ = w.Write([]byte(`{"full_name": "org/old", "archived": true}`))
+		case "/repos/org/broken":
+			http.Error(w, `{"message": "Server Error"}`, http.StatusInternalServerError)
+		case "/repos/org/api/branches/main", "/repos/org/stale/branches/main", "/repos/org/api/branches/release/1.x":
+			_, _ = w.Write([]byte(`{"name": "main"}`))
+		case "/repos/org/api/actions/workflows/remediate.yml", "/repos/org/stale/actions/workflows/remediate.yml":
+			_, _ = w.Write([]byte(`{"id": 7, "path": ".github/workflows/remediate.yml", "state": "active"}`))
+		case "/repos/org/api/contents/.github/workflows/remediate.yml":
+			if r.URL.Query().Get("ref") == "release/1.x" {
+				http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"path": ".github/workflows/remediate.yml"}`))
+		default:
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "remediate.yml", 10)
+	tests := []struct {
+		name       string
+		repository string
+		branch     string
+		want       []string
+	}{
+		{"dispatchable", "org/api", "main", nil},
+		{"missing repository", "org/missing", "main", []string{"repository not found, or not visible to the token"}},
+		{"archived repository", "org/old", "main", []string{"repository is archived"}},
+		{"missing branch", "org/api", "develop", []string{"branch develop not found"}},
+		{"no branch", "org/api", "", []string{"no branch is set"}},
+		{"workflow not on branch", "org/api", "release/1.x", []string{"workflow .github/workflows/remediate.yml not found on branch release/1.x"}},
+		{"workflow file gone", "org/stale", "main", []string{"workflow .github/workflows/remediate.yml not found on branch main"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems, err := client.CheckTarget(context.Background(), tt.repository, tt.branch)
+			if err != nil {
+				t.Fatalf("CheckTarget() error = %v", err)
+			}
+			if !reflect.DeepEqual(problems, tt.want) {
+				t.Errorf("CheckTarget() = %v, want %v", problems, tt.want)
+			}
+		})
+	}
+
+	if _, err := client.CheckTarget(context.Background(), "org/broken", "main"); err == nil {
+		t.Error("expected error when GitHub fails")
+	}
+}
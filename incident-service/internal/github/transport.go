@@ -0,0 +1,64 @@
+package github
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// DefaultMaxIdleConns is how many idle connections to the GitHub API are
+// kept open for reuse. Every API call goes to the same host, so it is also
+// the per-host limit, and it covers the default dispatch shards and the
+// polling workers running at once.
+const DefaultMaxIdleConns = 32
+
+// maxDrainBytes bounds how much of an unread response body is discarded so
+// its connection can be reused; larger bodies close the connection instead
+const maxDrainBytes = 64 << 10
+
+// newTransport returns the transport shared by every request of a client.
+// The default transport keeps only two idle connections per host, so during
+// an alert storm most dispatches opened a new connection and paid for a full
+// TLS handshake. Connections are kept alive, up to maxIdleConns of them stay
+// open, and TLS sessions are cached so that new connections resume them.
+func newTransport(maxIdleConns int) *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConns,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig: &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			ClientSessionCache: tls.NewLRUClientSessionCache(maxIdleConns),
+		},
+	}
+}
+
+// SetMaxIdleConns sets how many idle connections to the GitHub API are kept
+// open. It must be called before the client is used.
+func (c *Client) SetMaxIdleConns(n int) {
+	c.httpClient.Transport = newTransport(n)
+}
+
+// WrapTransport wraps the transport of the client's requests, for example to
+// inject failures in tests. It must be called after SetMaxIdleConns and
+// before the client is used.
+func (c *Client) WrapTransport(wrap func(http.RoundTripper) http.RoundTripper) {
+	c.httpClient.Transport = wrap(c.httpClient.Transport)
+}
+
+// closeBody reads what is left of a response body and closes it, so that
+// its connection goes back to the pool
+func closeBody(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, maxDrainBytes))
+	_ = body.Close()
+}
@@ -0,0 +1,78 @@
+package github
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestClient_ReusesConnectionsAcrossAPIs(t *testing.T) {
+	var connections int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasSuffix(r.URL.Path, "/jobs"):
+			_, _ = w.Write([]byte(`{"jobs":[]}` + "\n"))
+		case strings.HasSuffix(r.URL.Path, "/pulls"):
+			_, _ = w.Write([]byte("[]\n"))
+		default:
+			_, _ = w.Write([]byte(`{"id":42,"status":"completed"}` + "\n"))
+		}
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "remediate.yml", 5)
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	client.httpClient.Transport.(*http.Transport).TLSClientConfig.RootCAs = roots
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		incident := &models.Incident{ID: "inc_1", ServiceName: "api", Repository: "org/api"}
+		if _, err := client.DispatchWorkflow(ctx, incident, "main", DispatchOptions{}); err != nil {
+			t.Fatalf("DispatchWorkflow() error = %v", err)
+		}
+		if _, err := client.GetWorkflowRun(ctx, "org/api", 42); err != nil {
+			t.Fatalf("GetWorkflowRun() error = %v", err)
+		}
+		if _, err := client.ListRunJobs(ctx, "org/api", 42); err != nil {
+			t.Fatalf("ListRunJobs() error = %v", err)
+		}
+		if _, err := client.FindPullRequest(ctx, "org/api", "fix/inc_1"); err != nil {
+			t.Fatalf("FindPullRequest() error = %v", err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&connections); got != 1 {
+		t.Errorf("expected every request to reuse one connection, got %d connections", got)
+	}
+}
+
+func TestSetMaxIdleConns(t *testing.T) {
+	client := NewClient("https://api.github.com", "test-token", "remediate.yml", 1)
+	transport := client.httpClient.Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != DefaultMaxIdleConns || transport.TLSClientConfig.ClientSessionCache == nil {
+		t.Fatalf("unexpected default transport: %d idle connections per host, session cache %v",
+			transport.MaxIdleConnsPerHost, transport.TLSClientConfig.ClientSessionCache)
+	}
+
+	client.SetMaxIdleConns(64)
+	transport = client.httpClient.Transport.(*http.Transport)
+	if transport.MaxIdleConns != 64 || transport.MaxIdleConnsPerHost != 64 {
+		t.Errorf("expected 64 idle connections, got %d (%d per host)", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+}
@@ -0,0 +1,56 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Workflow is a GitHub Actions workflow of a repository
+type Workflow struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Path  string `json:"path"`
+	State string `json:"state"` // active, disabled_manually, ...
+}
+
+// GetWorkflow returns the remediation workflow of a repository, or nil if the
+// repository has no such workflow or is not visible to the token
+func (c *Client) GetWorkflow(ctx context.Context, repository string) (*Workflow, error) {
+	var workflow Workflow
+	path := fmt.Sprintf("/repos/%s/actions/workflows/%s", repository, c.workflow)
+	if err := c.get(ctx, path, nil, &workflow); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	return &workflow, nil
+}
+
+// TokenScopes returns the OAuth scopes granted to the token. ok is false for
+// tokens that do not report scopes, such as fine-grained personal access
+// tokens and GitHub App tokens.
+func (c *Client) TokenScopes(ctx context.Context) (scopes []string, ok bool, err error) {
+	var rateLimit struct{}
+	header, err := c.getWithHeader(ctx, "/rate_limit", nil, &rateLimit)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to check token: %w", err)
+	}
+
+	value, ok := header["X-Oauth-Scopes"]
+	if !ok {
+		return nil, false, nil
+	}
+	for _, scope := range strings.Split(strings.Join(value, ","), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes, true, nil
+}
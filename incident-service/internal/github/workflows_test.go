@@ -0,0 +1,72 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestGetWorkflow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/org/repo/actions/workflows/remediate.yml":
+			_, _ = w.Write([]byte(`{"id": 7, "name": "Remediate", "path": ".github/workflows/remediate.yml", "state": "active"}`))
+		case "/repos/org/broken/actions/workflows/remediate.yml":
+			http.Error(w, `{"message": "Server Error"}`, http.StatusInternalServerError)
+		default:
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "remediate.yml", 10)
+
+	workflow, err := client.GetWorkflow(context.Background(), "org/repo")
+	if err != nil {
+		t.Fatalf("GetWorkflow() error = %v", err)
+	}
+	if workflow == nil || workflow.ID != 7 || workflow.State != "active" {
+		t.Errorf("unexpected workflow %+v", workflow)
+	}
+
+	workflow, err = client.GetWorkflow(context.Background(), "org/missing")
+	if err != nil || workflow != nil {
+		t.Errorf("expected no workflow for a missing repository, got %+v, %v", workflow, err)
+	}
+
+	if _, err := client.GetWorkflow(context.Background(), "org/broken"); err == nil {
+		t.Error("expected error for a server error")
+	}
+}
+
+func TestTokenScopes(t *testing.T) {
+	scopes := "repo, workflow"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rate_limit" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if scopes != "" {
+			w.Header().Set("X-OAuth-Scopes", scopes)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "remediate.yml", 10)
+
+	got, ok, err := client.TokenScopes(context.Background())
+	if err != nil || !ok {
+		t.Fatalf("TokenScopes() = %v, %v, %v", got, ok, err)
+	}
+	if !reflect.DeepEqual(got, []string{"repo", "workflow"}) {
+		t.Errorf("unexpected scopes %v", got)
+	}
+
+	// Fine-grained tokens do not report scopes
+	scopes = ""
+	if _, ok, err := client.TokenScopes(context.Background()); err != nil || ok {
+		t.Errorf("expected scopes to be unreported, got ok=%v err=%v", ok, err)
+	}
+}
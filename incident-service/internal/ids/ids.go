@@ -0,0 +1,94 @@
+// Package ids generates the IDs of new incidents. By default incidents keep
+// the provider-derived IDs the adapters build, such as inc_dd_<alert ID>.
+// Deployments can switch to ULIDs or UUIDv7s instead, which have one shape
+// whatever the provider and sort by creation time. The provider's own
+// reference is kept apart in the incident's provider_ref either way.
+package ids
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+// Prefix starts every generated incident ID, as it does the legacy ones
+const Prefix = "inc_"
+
+// crockford is the Crockford base32 alphabet ULIDs are written in
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Generator generates incident IDs with the configured strategy. A nil
+// Generator keeps the legacy IDs.
+type Generator struct {
+	strategy string
+	now      func() time.Time
+	random   io.Reader
+}
+
+// New creates a generator for a strategy of config.IncidentIDsConfig
+func New(strategy string) *Generator {
+	return &Generator{
+		strategy: strategy,
+		now:      time.Now,
+		random:   rand.Reader,
+	}
+}
+
+// IncidentID returns the ID of a new incident. legacy is the ID the incident
+// would have had before, which the legacy strategy keeps. It is also kept if
+// no random bytes can be read.
+func (g *Generator) IncidentID(legacy string) string {
+	if g == nil {
+		return legacy
+	}
+
+	if g.strategy != config.IncidentIDULID && g.strategy != config.IncidentIDUUIDv7 {
+		return legacy
+	}
+
+	// 48 bits of milliseconds since the epoch, then 80 random bits
+	var id [16]byte
+	if _, err := io.ReadFull(g.random, id[6:]); err != nil {
+		return legacy
+	}
+	ms := uint64(g.now().UnixMilli())
+	for i := 0; i < 6; i++ {
+		id[i] = byte(ms >> (8 * (5 - i)))
+	}
+
+	if g.strategy == config.IncidentIDULID {
+		return Prefix + encodeULID(id)
+	}
+	return Prefix + encodeUUIDv7(id)
+}
+
+// encodeULID writes 16 bytes as the 26 characters of a ULID
+func encodeULID(id [16]byte) string {
+	out := make([]byte, 26)
+	for i := range out {
+		v := 0
+		for j := 0; j < 5; j++ {
+			// The 128 bits are written from the top, after two padding bits
+			bit := i*5 + j - 2
+			v <<= 1
+			if bit >= 0 && id[bit/8]>>(7-bit%8)&1 == 1 {
+				v |= 1
+			}
+		}
+		out[i] = crockford[v]
+	}
+	return string(out)
+}
+
+// encodeUUIDv7 sets the version and variant bits of a time-prefixed ID and
+// writes it in the usual 8-4-4-4-12 form
+func encodeUUIDv7(id [16]byte) string {
+	id[6] = 0x70 | id[6]&0x0f
+	id[8] = 0x80 | id[8]&0x3f
+
+	s := hex.EncodeToString(id[:])
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:32]
+}
@@ -0,0 +1,68 @@
+package ids
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+func TestIncidentID_Legacy(t *testing.T) {
+	for _, g := range []*Generator{nil, New(config.IncidentIDLegacy), New("")} {
+		if got := g.IncidentID("inc_dd_123"); got != "inc_dd_123" {
+			t.Errorf("expected the legacy ID to be kept, got %q", got)
+		}
+	}
+}
+
+func TestIncidentID_ULID(t *testing.T) {
+	g := New(config.IncidentIDULID)
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return now }
+
+	first := g.IncidentID("inc_dd_123")
+	if !regexp.MustCompile(`^inc_[0-7][0-9A-HJKMNP-TV-Z]{25}$`).MatchString(first) {
+		t.Fatalf("not a ULID: %q", first)
+	}
+	if again := g.IncidentID("inc_dd_123"); again == first {
+		t.Errorf("expected a new ID for every incident, got %q twice", first)
+	}
+
+	// IDs sort by creation time
+	now = now.Add(time.Millisecond)
+	if later := g.IncidentID("inc_dd_123"); later <= first {
+		t.Errorf("expected %q to sort after %q", later, first)
+	}
+}
+
+func TestIncidentID_ULIDTimestamp(t *testing.T) {
+	var id [16]byte
+	ms := uint64(1469918176385)
+	for i := 0; i < 6; i++ {
+		id[i] = byte(ms >> (8 * (5 - i)))
+	}
+	// The timestamp of the example in the ULID specification
+	if got := encodeULID(id)[:10]; got != "01ARYZ6S41" {
+		t.Errorf("timestamp encoded as %q, want 01ARYZ6S41", got)
+	}
+}
+
+func TestIncidentID_UUIDv7(t *testing.T) {
+	g := New(config.IncidentIDUUIDv7)
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return now }
+
+	first := g.IncidentID("inc_pd_P123")
+	if !regexp.MustCompile(`^inc_[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(first) {
+		t.Fatalf("not a UUIDv7: %q", first)
+	}
+	if first[4:17] != "018e08e7-e900" {
+		t.Errorf("expected the creation time in the first bits, got %q", first)
+	}
+
+	now = now.Add(time.Millisecond)
+	if later := g.IncidentID("inc_pd_P123"); later <= first {
+		t.Errorf("expected %q to sort after %q", later, first)
+	}
+}
@@ -0,0 +1,248 @@
+package leader
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+// Lease is a lock shared by all replicas that expires unless it is renewed
+type Lease interface {
+	// Acquire takes the lease for holder, or extends it if holder already has
+	// it, and reports whether holder holds the lease afterwards
+	Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error)
+	// Release gives up the lease if holder has it
+	Release(ctx context.Context, holder string) error
+}
+
+// Worker is a background worker that runs at an interval until stopped
+type Worker interface {
+	Start(interval time.Duration)
+	Stop()
+}
+
+// Logger is the structured logger used by the elector
+type Logger interface {
+	Info(message string, fields map[string]interface{})
+	Warn(message string, fields map[string]interface{})
+	Error(message string, fields map[string]interface{})
+}
+
+// singleton is a registered worker, created anew on every election
+type singleton struct {
+	interval  time.Duration
+	newWorker func() Worker
+}
+
+// Elector campaigns for the lease and runs the registered singleton workers
+// only while this replica holds it
+type Elector struct {
+	lease    Lease
+	holder   string
+	ttl      time.Duration
+	renew    time.Duration
+	isLeader prometheus.Gauge
+	logger   Logger
+	stopCh   chan struct{}
+	now      func() time.Time
+
+	mu         sync.Mutex
+	singletons []singleton
+	running    []Worker
+	wg         sync.WaitGroup
+	leading    bool
+	renewedAt  time.Time
+	stopped    bool
+}
+
+// NewElector creates an elector. isLeader, if set, is 1 while this replica leads.
+func NewElector(cfg config.LeaderElectionConfig, lease Lease, isLeader prometheus.Gauge, logger Logger) *Elector {
+	return &Elector{
+		lease:    lease,
+		holder:   newHolderID(),
+		ttl:      cfg.LeaseTTL(),
+		renew:    cfg.Renewal(),
+		isLeader: isLeader,
+		logger:   logger,
+		stopCh:   make(chan struct{}),
+		now:      time.Now,
+	}
+}
+
+// Holder returns the ID this replica campaigns under
+func (e *Elector) Holder() string {
+	return e.holder
+}
+
+// IsLeader reports whether this replica currently runs the singleton workers
+func (e *Elector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.leading
+}
+
+// Go registers a singleton worker. newWorker is called each time this replica
+// is elected, and the worker is stopped when leadership is lost.
+func (e *Elector) Go(interval time.Duration, newWorker func() Worker) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	s := singleton{interval: interval, newWorker: newWorker}
+	e.singletons = append(e.singletons, s)
+	if e.leading {
+		e.start(s)
+	}
+}
+
+// Run campaigns for the lease until Stop is called
+func (e *Elector) Run() {
+	ticker := time.NewTicker(e.renew)
+	defer ticker.Stop()
+
+	e.campaign()
+	for {
+		select {
+		case <-ticker.C:
+			e.campaign()
+		case <-e.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops campaigning, stops the singleton workers and releases the lease
+// so another replica can take over without waiting for it to expire
+func (e *Elector) Stop() {
+	close(e.stopCh)
+
+	e.mu.Lock()
+	e.stopped = true
+	wasLeading := e.leading
+	e.demote("shutting down")
+	e.mu.Unlock()
+
+	if !wasLeading {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.renew)
+	defer cancel()
+	if err := e.lease.Release(ctx, e.holder); err != nil {
+		e.logger.Warn("failed to release leader lease", map[string]interface{}{
+			"error":  err.Error(),
+			"holder": e.holder,
+		})
+	}
+}
+
+// campaign acquires or renews the lease and starts or stops the singleton
+// workers to match
+func (e *Elector) campaign() {
+	ctx, cancel := context.WithTimeout(context.Background(), e.renew)
+	held, err := e.lease.Acquire(ctx, e.holder, e.ttl)
+	cancel()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.stopped {
+		return
+	}
+
+	now := e.now()
+	if err != nil {
+		e.logger.Error("failed to acquire leader lease", map[string]interface{}{
+			"error":  err.Error(),
+			"holder": e.holder,
+		})
+		// Keep leading while the last renewal still holds, since no other
+		// replica can take the lease before then
+		if e.leading && now.Sub(e.renewedAt) >= e.ttl {
+			e.demote("lease expired")
+		}
+		return
+	}
+
+	if !held {
+		if e.leading {
+			e.demote("lease lost")
+		}
+		return
+	}
+
+	e.renewedAt = now
+	if !e.leading {
+		e.promote()
+	}
+}
+
+// promote starts every registered worker. The caller must hold e.mu.
+func (e *Elector) promote() {
+	e.leading = true
+	if e.isLeader != nil {
+		e.isLeader.Set(1)
+	}
+	e.logger.Info("elected leader, starting singleton workers", map[string]interface{}{
+		"holder":  e.holder,
+		"workers": len(e.singletons),
+	})
+
+	for _, s := range e.singletons {
+		e.start(s)
+	}
+}
+
+// start runs one singleton worker. The caller must hold e.mu.
+func (e *Elector) start(s singleton) {
+	worker := s.newWorker()
+	e.running = append(e.running, worker)
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		worker.Start(s.interval)
+	}()
+}
+
+// demote stops the running workers, newest first, and waits for them to
+// return. The caller must hold e.mu.
+func (e *Elector) demote(reason string) {
+	if !e.leading {
+		return
+	}
+
+	for i := len(e.running) - 1; i >= 0; i-- {
+		e.running[i].Stop()
+	}
+	e.wg.Wait()
+	e.running = nil
+
+	e.leading = false
+	if e.isLeader != nil {
+		e.isLeader.Set(0)
+	}
+	e.logger.Warn("no longer leader, stopped singleton workers", map[string]interface{}{
+		"holder": e.holder,
+		"reason": reason,
+	})
+}
+
+// newHolderID identifies this replica by host name and a random suffix, so
+// two processes on one host never share a lease
+func newHolderID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%s-%x", host, time.Now().UnixNano())
+	}
+	return host + "-" + hex.EncodeToString(b)
+}
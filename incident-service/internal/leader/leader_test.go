@@ -0,0 +1,157 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+// memoryLease is an in-process Lease shared by the electors of a test
+type memoryLease struct {
+	mu        sync.Mutex
+	holder    string
+	expiresAt time.Time
+	err       error
+}
+
+func (l *memoryLease) Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.err != nil {
+		return false, l.err
+	}
+	now := time.Now()
+	if l.holder == "" || l.holder == holder || now.After(l.expiresAt) {
+		l.holder = holder
+		l.expiresAt = now.Add(ttl)
+		return true, nil
+	}
+	return false, nil
+}
+
+func (l *memoryLease) Release(ctx context.Context, holder string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.holder == holder {
+		l.holder = ""
+	}
+	return nil
+}
+
+func (l *memoryLease) setErr(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.err = err
+}
+
+// countingWorker records how many instances are running
+type countingWorker struct {
+	running *int32
+	stopCh  chan struct{}
+}
+
+func (w *countingWorker) Start(interval time.Duration) {
+	atomic.AddInt32(w.running, 1)
+	defer atomic.AddInt32(w.running, -1)
+	<-w.stopCh
+}
+
+func (w *countingWorker) Stop() {
+	close(w.stopCh)
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Info(string, map[string]interface{})  {}
+func (nopLogger) Warn(string, map[string]interface{})  {}
+func (nopLogger) Error(string, map[string]interface{}) {}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func newTestElector(lease Lease, running *int32, gauge prometheus.Gauge) *Elector {
+	elector := NewElector(config.LeaderElectionConfig{LeaseDuration: 60 * time.Millisecond, RenewInterval: 10 * time.Millisecond}, lease, gauge, nopLogger{})
+	elector.Go(time.Minute, func() Worker {
+		return &countingWorker{running: running, stopCh: make(chan struct{})}
+	})
+	return elector
+}
+
+func TestElector_SingleLeaderAndFailover(t *testing.T) {
+	lease := &memoryLease{}
+	var running int32
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_is_leader"})
+
+	first := newTestElector(lease, &running, gauge)
+	second := newTestElector(lease, &running, nil)
+	go first.Run()
+	waitFor(t, "first elector to lead", first.IsLeader)
+	go second.Run()
+
+	// The second replica keeps campaigning without taking over
+	time.Sleep(50 * time.Millisecond)
+	if second.IsLeader() {
+		t.Fatal("expected only one leader")
+	}
+	if got := atomic.LoadInt32(&running); got != 1 {
+		t.Fatalf("expected exactly one running worker, got %d", got)
+	}
+	if got := testutil.ToFloat64(gauge); got != 1 {
+		t.Errorf("expected is_leader gauge 1, got %v", got)
+	}
+
+	// Stopping the leader releases the lease, so the other replica takes over
+	first.Stop()
+	if got := testutil.ToFloat64(gauge); got != 0 {
+		t.Errorf("expected is_leader gauge 0 after stop, got %v", got)
+	}
+	waitFor(t, "second elector to take over", second.IsLeader)
+	if got := atomic.LoadInt32(&running); got != 1 {
+		t.Errorf("expected exactly one running worker after failover, got %d", got)
+	}
+
+	second.Stop()
+	if got := atomic.LoadInt32(&running); got != 0 {
+		t.Errorf("expected no running workers after stop, got %d", got)
+	}
+}
+
+func TestElector_StepsDownWhenLeaseCannotBeRenewed(t *testing.T) {
+	lease := &memoryLease{}
+	var running int32
+
+	elector := newTestElector(lease, &running, nil)
+	go elector.Run()
+	defer elector.Stop()
+	waitFor(t, "elector to lead", elector.IsLeader)
+
+	// Transient errors are tolerated until the last renewal expires
+	lease.setErr(errors.New("redis unavailable"))
+	waitFor(t, "elector to step down", func() bool { return !elector.IsLeader() })
+	if got := atomic.LoadInt32(&running); got != 0 {
+		t.Errorf("expected workers stopped after stepping down, got %d", got)
+	}
+
+	lease.setErr(nil)
+	waitFor(t, "elector to lead again", elector.IsLeader)
+	if got := atomic.LoadInt32(&running); got != 1 {
+		t.Errorf("expected a fresh worker after re-election, got %d", got)
+	}
+}
@@ -0,0 +1,61 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// acquireScript sets the lease if it is free, or extends it if the holder
+// already has it. It returns 1 when the holder has the lease.
+var acquireScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return 1
+end
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// releaseScript deletes the lease only if the holder still has it
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// RedisLease is a Lease stored under a single Redis key
+type RedisLease struct {
+	client redis.Scripter
+	key    string
+}
+
+// NewRedisLease creates a lease stored under key
+func NewRedisLease(client redis.Scripter, key string) *RedisLease {
+	return &RedisLease{
+		client: client,
+		key:    key,
+	}
+}
+
+// Acquire takes or extends the lease for holder
+func (l *RedisLease) Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	held, err := acquireScript.Run(ctx, l.client, []string{l.key}, holder, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lease: %w", err)
+	}
+	return held == 1, nil
+}
+
+// Release deletes the lease if holder has it
+func (l *RedisLease) Release(ctx context.Context, holder string) error {
+	if err := releaseScript.Run(ctx, l.client, []string{l.key}, holder).Err(); err != nil {
+		return fmt.Errorf("failed to release lease: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,343 @@
+// Package markdown renders the Markdown that remediation agents write into
+// HTML that is safe to insert into a page. The renderer only produces a
+// fixed set of elements, escapes all text, and keeps links to safe schemes,
+// so raw HTML in the source is shown as text rather than interpreted.
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxQuoteDepth bounds how deeply block quotes nest before the rest is
+// rendered as text
+const maxQuoteDepth = 8
+
+var (
+	headingLine  = regexp.MustCompile(`^(#{1,6})[ \t]+(.*?)[ \t]*#*[ \t]*$`)
+	ruleLine     = regexp.MustCompile(`^ {0,3}(-[ \t]*-[ \t]*-[- \t]*|\*[ \t]*\*[ \t]*\*[* \t]*|_[ \t]*_[ \t]*_[_ \t]*)$`)
+	bulletItem   = regexp.MustCompile(`^ {0,3}[-*+][ \t]+(.*)$`)
+	orderedItem  = regexp.MustCompile(`^ {0,3}(\d{1,9})[.)][ \t]+(.*)$`)
+	fenceLine    = regexp.MustCompile("^ {0,3}(```+|~~~+)[ \t]*([^`\\s]*)")
+	languageName = regexp.MustCompile(`^[A-Za-z0-9_+#.-]+$`)
+)
+
+// Render converts Markdown to HTML. It supports headings, paragraphs, fenced
+// code blocks, bullet and numbered lists, block quotes, horizontal rules,
+// and inline code, emphasis, links and bare URLs.
+func Render(source string) string {
+	source = strings.ToValidUTF8(source, "�")
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	var b strings.Builder
+	renderBlocks(&b, strings.Split(source, "\n"), 0)
+	return b.String()
+}
+
+func renderBlocks(b *strings.Builder, lines []string, depth int) {
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>")
+			b.WriteString(renderInline(strings.Join(paragraph, "\n")))
+			b.WriteString("</p>\n")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+			i++
+
+		case fenceLine.MatchString(line):
+			flush()
+			i = renderFence(b, lines, i)
+
+		case headingLine.MatchString(line):
+			flush()
+			match := headingLine.FindStringSubmatch(line)
+			level := string(rune('0' + len(match[1])))
+			b.WriteString("<h" + level + ">" + renderInline(match[2]) + "</h" + level + ">\n")
+			i++
+
+		case ruleLine.MatchString(line):
+			flush()
+			b.WriteString("<hr>\n")
+			i++
+
+		case strings.HasPrefix(trimmed, ">") && depth < maxQuoteDepth:
+			flush()
+			var quoted []string
+			for ; i < len(lines); i++ {
+				inner := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(inner, ">") {
+					break
+				}
+				inner = strings.TrimPrefix(inner, ">")
+				quoted = append(quoted, strings.TrimPrefix(inner, " "))
+			}
+			b.WriteString("<blockquote>\n")
+			renderBlocks(b, quoted, depth+1)
+			b.WriteString("</blockquote>\n")
+
+		case bulletItem.MatchString(line):
+			flush()
+			i = renderList(b, lines, i, bulletItem, "ul")
+
+		case orderedItem.MatchString(line):
+			flush()
+			i = renderList(b, lines, i, orderedItem, "ol")
+
+		default:
+			paragraph = append(paragraph, trimmed)
+			i++
+		}
+	}
+	flush()
+}
+
+// renderFence writes the fenced code block starting at lines[start] and
+// returns the index of the line after it. An unclosed fence runs to the end.
+func renderFence(b *strings.Builder, lines []string, start int) int {
+	match := fenceLine.FindStringSubmatch(lines[start])
+	fence, language := match[1], match[2]
+
+	i := start + 1
+	var code []string
+	for ; i < len(lines); i++ {
+		closing := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(closing, fence) && strings.Trim(closing, fence[:1]) == "" {
+			i++
+			break
+		}
+		code = append(code, lines[i])
+	}
+
+	b.WriteString("<pre><code")
+	if language != "" && languageName.MatchString(language) {
+		b.WriteString(` class="language-` + html.EscapeString(language) + `"`)
+	}
+	b.WriteString(">")
+	for _, line := range code {
+		b.WriteString(html.EscapeString(line))
+		b.WriteString("\n")
+	}
+	b.WriteString("</code></pre>\n")
+	return i
+}
+
+// renderList writes the list starting at lines[start], whose items match
+// item, and returns the index of the line after it. Indented lines continue
+// the item above them.
+func renderList(b *strings.Builder, lines []string, start int, item *regexp.Regexp, tag string) int {
+	var items []string
+	i := start
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if match := item.FindStringSubmatch(line); match != nil {
+			items = append(items, match[len(match)-1])
+			continue
+		}
+		indented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		if strings.TrimSpace(line) == "" || !indented {
+			break
+		}
+		items[len(items)-1] += "\n" + strings.TrimSpace(line)
+	}
+
+	b.WriteString("<" + tag)
+	if tag == "ol" {
+		if first, _ := strconv.Atoi(item.FindStringSubmatch(lines[start])[1]); first != 1 {
+			b.WriteString(` start="` + strconv.Itoa(first) + `"`)
+		}
+	}
+	b.WriteString(">\n")
+	for _, text := range items {
+		b.WriteString("<li>" + renderInline(text) + "</li>\n")
+	}
+	b.WriteString("</" + tag + ">\n")
+	return i
+}
+
+// renderInline renders the inline elements of a block's text
+func renderInline(text string) string {
+	var b strings.Builder
+	inline(&b, text, true)
+	return b.String()
+}
+
+// inline writes text with its code spans, emphasis and links rendered.
+// Links are not rendered inside link text.
+func inline(b *strings.Builder, text string, links bool) {
+	for i := 0; i < len(text); {
+		c := text[i]
+		rest := text[i:]
+
+		switch {
+		case c == '\\' && i+1 < len(text) && isPunct(text[i+1]):
+			b.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '`':
+			ticks := len(rest) - len(strings.TrimLeft(rest, "`"))
+			if end := strings.Index(rest[ticks:], rest[:ticks]); end >= 0 {
+				code := strings.TrimSpace(rest[ticks : ticks+end])
+				b.WriteString("<code>" + html.EscapeString(code) + "</code>")
+				i += 2*ticks + end
+				continue
+			}
+			b.WriteString(rest[:ticks])
+			i += ticks
+			continue
+
+		case links && c == '[':
+			if label, target, n, ok := parseLink(rest); ok {
+				writeLink(b, label, target)
+				i += n
+				continue
+			}
+
+		case links && (strings.HasPrefix(rest, "https://") || strings.HasPrefix(rest, "http://")) && !wordBefore(text, i):
+			n := urlLength(rest)
+			writeLink(b, rest[:n], rest[:n])
+			i += n
+			continue
+
+		case (c == '*' || c == '_') && strings.HasPrefix(rest, string([]byte{c, c})):
+			if n, ok := emphasis(b, text, i, rest[:2], "strong", links); ok {
+				i += n
+				continue
+			}
+
+		case c == '*' || c == '_':
+			if n, ok := emphasis(b, text, i, rest[:1], "em", links); ok {
+				i += n
+				continue
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(rest)
+		b.WriteString(html.EscapeString(rest[:size]))
+		i += size
+	}
+}
+
+// emphasis writes the span opened by marker at text[i] and returns its
+// length, or reports false when the marker is not closed. Underscores
+// inside words, as in snake_case names, do not open or close emphasis.
+func emphasis(b *strings.Builder, text string, i int, marker, tag string, links bool) (int, bool) {
+	start := i + len(marker)
+	if start >= len(text) || text[start] == ' ' || (marker[0] == '_' && wordBefore(text, i)) {
+		return 0, false
+	}
+	for search := start; search < len(text); {
+		end := strings.Index(text[search:], marker)
+		if end < 0 {
+			return 0, false
+		}
+		end += search
+		after := end + len(marker)
+		if end > start && text[end-1] != ' ' && !(marker[0] == '_' && after < len(text) && isWord(text[after])) {
+			b.WriteString("<" + tag + ">")
+			inline(b, text[start:end], links)
+			b.WriteString("</" + tag + ">")
+			return after - i, true
+		}
+		search = end + 1
+	}
+	return 0, false
+}
+
+// parseLink parses a [label](target) link at the start of text
+func parseLink(text string) (label, target string, n int, ok bool) {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if !strings.HasPrefix(text[i+1:], "(") {
+				return "", "", 0, false
+			}
+			end := strings.IndexByte(text[i+2:], ')')
+			if end < 0 {
+				return "", "", 0, false
+			}
+			target = strings.TrimSpace(text[i+2 : i+2+end])
+			return text[1:i], target, i + 3 + end, true
+		case '\n':
+			return "", "", 0, false
+		}
+	}
+	return "", "", 0, false
+}
+
+// writeLink writes a link, or only its label when the target is not safe
+func writeLink(b *strings.Builder, label, target string) {
+	href, ok := safeURL(target)
+	if !ok {
+		inline(b, label, false)
+		return
+	}
+	b.WriteString(`<a href="` + html.EscapeString(href) + `" rel="nofollow noopener noreferrer">`)
+	inline(b, label, false)
+	b.WriteString("</a>")
+}
+
+// safeURL returns target when it is an http, https or mailto URL, or a
+// relative one. Other schemes, such as javascript: and data:, are refused.
+func safeURL(target string) (string, bool) {
+	if target == "" || strings.IndexFunc(target, unicode.IsControl) >= 0 || strings.ContainsAny(target, " <>\"") {
+		return "", false
+	}
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return "", false
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "", "http", "https", "mailto":
+		return target, true
+	}
+	return "", false
+}
+
+// urlLength returns the length of the bare URL at the start of text, leaving
+// out trailing punctuation that ends the sentence around it
+func urlLength(text string) int {
+	n := strings.IndexFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '<' || r == '>' || r == '"' || r == '`'
+	})
+	if n < 0 {
+		n = len(text)
+	}
+	for n > 0 && strings.ContainsRune(".,;:!?)'", rune(text[n-1])) {
+		n--
+	}
+	return n
+}
+
+func wordBefore(text string, i int) bool {
+	return i > 0 && isWord(text[i-1])
+}
+
+func isWord(c byte) bool {
+	return c == '_' || c >= utf8.RuneSelf || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isPunct(c byte) bool {
+	return strings.IndexByte("\\`*_{}[]()#+-.!<>|~", c) >= 0
+}
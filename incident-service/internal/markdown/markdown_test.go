@@ -0,0 +1,122 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			name:   "heading and paragraph",
+			source: "## Root cause\n\nThe pool was\nexhausted.",
+			want:   "<h2>Root cause</h2>\n<p>The pool was\nexhausted.</p>\n",
+		},
+		{
+			name:   "emphasis and code",
+			source: "**Fix**: raise `max_conns` to *50* in db_config.go",
+			want:   "<p><strong>Fix</strong>: raise <code>max_conns</code> to <em>50</em> in db_config.go</p>\n",
+		},
+		{
+			name:   "lists",
+			source: "- one\n- two\n  continued\n\n3. three\n4. four",
+			want:   "<ul>\n<li>one</li>\n<li>two\ncontinued</li>\n</ul>\n<ol start=\"3\">\n<li>three</li>\n<li>four</li>\n</ol>\n",
+		},
+		{
+			name:   "fenced code",
+			source: "```go\nif a < b {\n}\n```\nafter",
+			want:   "<pre><code class=\"language-go\">if a &lt; b {\n}\n</code></pre>\n<p>after</p>\n",
+		},
+		{
+			name:   "block quote and rule",
+			source: "> quoted *text*\n\n---",
+			want:   "<blockquote>\n<p>quoted <em>text</em></p>\n</blockquote>\n<hr>\n",
+		},
+		{
+			name:   "links",
+			source: "See [the PR](https://github.com/org/api/pull/7) or https://example.com/runbook.",
+			want:   "<p>See <a href=\"https://github.com/org/api/pull/7\" rel=\"nofollow noopener noreferrer\">the PR</a> or <a href=\"https://example.com/runbook\" rel=\"nofollow noopener noreferrer\">https://example.com/runbook</a>.</p>\n",
+		},
+		{
+			name:   "escaped markers",
+			source: `a \*literal\* star`,
+			want:   "<p>a *literal* star</p>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.source); got != tt.want {
+				t.Errorf("Render() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender_Sanitizes(t *testing.T) {
+	sources := []string{
+		`<script>alert(1)</script>`,
+		`<img src=x onerror=alert(1)>`,
+		`[click](javascript:alert(1))`,
+		`[click](JaVaScRiPt:alert(1))`,
+		`[click](data:text/html;base64,PHNjcmlwdD4=)`,
+		`[x](https://example.com/" onmouseover="alert(1))`,
+		"```\"><script>alert(1)</script>\n<b>\n```",
+		`**<iframe src="https://evil.example">**`,
+		"# <svg onload=alert(1)>",
+		"> <a href=\"javascript:alert(1)\">x</a>",
+	}
+
+	for _, source := range sources {
+		got := strings.ToLower(Render(source))
+		for _, forbidden := range []string{"<script", "<img", "<iframe", "<svg", "<b>", `href="javascript:`, `href="data:`, `" onmouseover`} {
+			if strings.Contains(got, forbidden) {
+				t.Errorf("Render(%q) = %q, contains %q", source, got, forbidden)
+			}
+		}
+	}
+}
+
+func TestRender_KeepsSnakeCase(t *testing.T) {
+	got := Render("set max_idle_conns and pool_size")
+	if strings.Contains(got, "<em>") {
+		t.Errorf("expected underscores inside words to stay literal, got %q", got)
+	}
+}
+
+// renderedTag matches the tags Render produces
+var renderedTag = regexp.MustCompile(`</?(p|h[1-6]|ul|ol|li|pre|code|blockquote|strong|em|a)>|<hr>|<ol start="\d+">|<code class="language-[^"<>]*">|<a href="[^"<>]*" rel="nofollow noopener noreferrer">`)
+
+// Property: whatever the source, the output holds no markup besides the
+// tags the renderer produces itself
+func TestProperty_RenderOnlyProducesKnownTags(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 500
+	properties := gopter.NewProperties(parameters)
+
+	pieces := []string{"<", ">", "\"", "&", "`", "```", "*", "**", "_", "[", "](", ")", "#", "> ", "- ", "1. ", "\n", "\n\n",
+		"javascript:", "https://", "<script>", "<img src=x onerror=alert(1)>", "a", " ", "\\"}
+
+	properties.Property("output has only rendered tags", prop.ForAll(
+		func(indexes []int) bool {
+			var source strings.Builder
+			for _, i := range indexes {
+				source.WriteString(pieces[i])
+			}
+			stripped := renderedTag.ReplaceAllString(Render(source.String()), "")
+			return !strings.ContainsAny(stripped, "<>")
+		},
+		gen.SliceOf(gen.IntRange(0, len(pieces)-1)),
+	))
+
+	properties.TestingRun(t)
+}
@@ -0,0 +1,296 @@
+// Package mcp is a minimal client for Model Context Protocol servers served
+// over the streamable HTTP transport. It supports what the service needs:
+// calling tools and checking that a server answers.
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ProtocolVersion is the MCP revision the client speaks
+const ProtocolVersion = "2025-03-26"
+
+// sessionHeader carries the session ID assigned by the server on initialize
+const sessionHeader = "Mcp-Session-Id"
+
+// Client talks to one MCP server. Every call opens its own session, so a
+// client can be shared and servers may restart between calls.
+type Client struct {
+	url        string
+	token      string
+	httpClient *http.Client
+	nextID     int64
+}
+
+// NewClient creates a client for the server at url. token is sent as a bearer
+// token when it is not empty.
+func NewClient(url, token string) *Client {
+	return &Client{
+		url:        url,
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// ServerInfo identifies a server, as reported on initialize
+type ServerInfo struct {
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	ProtocolVersion string `json:"protocol_version"`
+}
+
+// Content is an item of a tool result
+type Content struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+}
+
+// ToolResult is the result of a tool call
+type ToolResult struct {
+	Content []Content `json:"content"`
+	IsError bool      `json:"isError"`
+}
+
+// Text joins the text items of the result
+func (r *ToolResult) Text() string {
+	var parts []string
+	for _, content := range r.Content {
+		if content.Type == "text" && content.Text != "" {
+			parts = append(parts, content.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// Error is a JSON-RPC error returned by the server
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("mcp error %d: %s", e.Code, e.Message)
+}
+
+type request struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      *int64      `json:"id,omitempty"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+type response struct {
+	ID     *int64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+}
+
+// Initialize opens a session and returns the server's identity
+func (c *Client) Initialize(ctx context.Context) (*ServerInfo, error) {
+	info, session, err := c.initialize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.closeSession(session)
+	return info, nil
+}
+
+// CallTool calls the named tool with arguments. A tool that reports an error
+// returns its result with IsError set, not an error.
+func (c *Client) CallTool(ctx context.Context, name string, arguments map[string]interface{}) (*ToolResult, error) {
+	_, session, err := c.initialize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.closeSession(session)
+
+	var result ToolResult
+	params := map[string]interface{}{"name": name, "arguments": arguments}
+	if err := c.call(ctx, session, "tools/call", params, &result); err != nil {
+		return nil, fmt.Errorf("failed to call tool %s: %w", name, err)
+	}
+	return &result, nil
+}
+
+// initialize performs the initialize handshake and returns the session ID
+// assigned by the server, if any
+func (c *Client) initialize(ctx context.Context) (*ServerInfo, string, error) {
+	params := map[string]interface{}{
+		"protocolVersion": ProtocolVersion,
+		"capabilities":    map[string]interface{}{},
+		"clientInfo":      map[string]string{"name": "incident-service", "version": "1.0.0"},
+	}
+
+	var result struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		ServerInfo      struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"serverInfo"`
+	}
+	id := atomic.AddInt64(&c.nextID, 1)
+	resp, err := c.post(ctx, "", request{JSONRPC: "2.0", ID: &id, Method: "initialize", Params: params})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to initialize: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := decodeResponse(resp, id, &result); err != nil {
+		return nil, "", fmt.Errorf("failed to initialize: %w", err)
+	}
+	session := resp.Header.Get(sessionHeader)
+
+	// The server expects to hear that initialization is complete
+	notified, err := c.post(ctx, session, request{JSONRPC: "2.0", Method: "notifications/initialized"})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to initialize: %w", err)
+	}
+	io.Copy(io.Discard, notified.Body)
+	notified.Body.Close()
+
+	return &ServerInfo{
+		Name:            result.ServerInfo.Name,
+		Version:         result.ServerInfo.Version,
+		ProtocolVersion: result.ProtocolVersion,
+	}, session, nil
+}
+
+// call sends a request in the session and decodes its result into result
+func (c *Client) call(ctx context.Context, session, method string, params, result interface{}) error {
+	id := atomic.AddInt64(&c.nextID, 1)
+	resp, err := c.post(ctx, session, request{JSONRPC: "2.0", ID: &id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return decodeResponse(resp, id, result)
+}
+
+// post sends a JSON-RPC message and checks the HTTP status
+func (c *Client) post(ctx context.Context, session string, msg request) (*http.Response, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	if msg.Method != "initialize" {
+		req.Header.Set("MCP-Protocol-Version", ProtocolVersion)
+	}
+	if session != "" {
+		req.Header.Set(sessionHeader, session)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return resp, nil
+}
+
+// closeSession ends a session the server assigned. Servers that do not
+// support ending sessions answer 405, which is ignored like any failure.
+func (c *Client) closeSession(session string) {
+	if session == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.url, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set(sessionHeader, session)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if resp, err := c.httpClient.Do(req); err == nil {
+		resp.Body.Close()
+	}
+}
+
+// decodeResponse reads the response to request id, sent either as a JSON body
+// or as an event of a server-sent event stream
+func decodeResponse(resp *http.Response, id int64, result interface{}) error {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+
+	var msg *response
+	if mediaType == "text/event-stream" {
+		var err error
+		if msg, err = readEventStream(resp.Body, id); err != nil {
+			return err
+		}
+	} else {
+		msg = &response{}
+		if err := json.NewDecoder(resp.Body).Decode(msg); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	if msg.Error != nil {
+		return msg.Error
+	}
+	if err := json.Unmarshal(msg.Result, result); err != nil {
+		return fmt.Errorf("failed to decode result: %w", err)
+	}
+	return nil
+}
+
+// readEventStream returns the response to request id from an event stream.
+// Requests and notifications the server sends on the stream are skipped.
+func readEventStream(body io.Reader, id int64) (*response, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "data:") {
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+			continue
+		}
+		if line != "" || data.Len() == 0 {
+			continue
+		}
+
+		// A blank line ends the event
+		var msg response
+		if err := json.Unmarshal([]byte(data.String()), &msg); err == nil && msg.ID != nil && *msg.ID == id {
+			return &msg, nil
+		}
+		data.Reset()
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read event stream: %w", err)
+	}
+
+	// The last event may not be followed by a blank line
+	var msg response
+	if err := json.Unmarshal([]byte(data.String()), &msg); err == nil && msg.ID != nil && *msg.ID == id {
+		return &msg, nil
+	}
+	return nil, fmt.Errorf("event stream ended without a response")
+}
@@ -0,0 +1,107 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeServer answers initialize with JSON and tool calls with an event stream
+func fakeServer(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		var msg struct {
+			ID     *int64 `json:"id"`
+			Method string `json:"method"`
+			Params struct {
+				Name      string                 `json:"name"`
+				Arguments map[string]interface{} `json:"arguments"`
+			} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			return
+		}
+
+		switch msg.Method {
+		case "initialize":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set(sessionHeader, "session-1")
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"protocolVersion":%q,"serverInfo":{"name":"logs","version":"2.1.0"}}}`, *msg.ID, ProtocolVersion)
+		case "notifications/initialized":
+			w.WriteHeader(http.StatusAccepted)
+		case "tools/call":
+			if r.Header.Get(sessionHeader) != "session-1" {
+				t.Errorf("expected the session ID on tool calls, got %q", r.Header.Get(sessionHeader))
+			}
+			if msg.Params.Name == "missing" {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"error":{"code":-32602,"message":"unknown tool"}}`, *msg.ID)
+				return
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\"}\n\n")
+			fmt.Fprintf(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"id\":%d,\"result\":{\"content\":[{\"type\":\"text\",\"text\":\"errors for %s\"}]}}\n\n", *msg.ID, msg.Params.Arguments["service"])
+		default:
+			t.Errorf("unexpected method %s", msg.Method)
+		}
+	}))
+}
+
+func TestClient_Initialize(t *testing.T) {
+	server := fakeServer(t, "secret")
+	defer server.Close()
+
+	info, err := NewClient(server.URL, "secret").Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	if info.Name != "logs" || info.Version != "2.1.0" || info.ProtocolVersion != ProtocolVersion {
+		t.Errorf("unexpected server info %+v", info)
+	}
+}
+
+func TestClient_CallTool(t *testing.T) {
+	server := fakeServer(t, "secret")
+	defer server.Close()
+	client := NewClient(server.URL, "secret")
+
+	result, err := client.CallTool(context.Background(), "search_logs", map[string]interface{}{"service": "checkout"})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if result.IsError || result.Text() != "errors for checkout" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	_, err = client.CallTool(context.Background(), "missing", nil)
+	var rpcErr *Error
+	if err == nil || !strings.Contains(err.Error(), "unknown tool") {
+		t.Errorf("expected the server's error, got %v", err)
+	} else if !errors.As(err, &rpcErr) || rpcErr.Code != -32602 {
+		t.Errorf("expected a JSON-RPC error, got %v", err)
+	}
+}
+
+func TestClient_Unauthorized(t *testing.T) {
+	server := fakeServer(t, "secret")
+	defer server.Close()
+
+	if _, err := NewClient(server.URL, "wrong").Initialize(context.Background()); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected a 401 error, got %v", err)
+	}
+}
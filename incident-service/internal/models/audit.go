@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// AuditAction names a change made through the management API
+type AuditAction string
+
+const (
+	AuditIncidentLinked     AuditAction = "incident.link"
+	AuditIncidentUnlinked   AuditAction = "incident.unlink"
+	AuditIncidentMerged     AuditAction = "incident.merge"
+	AuditIncidentRetried    AuditAction = "incident.retry"
+	AuditIncidentSnoozed    AuditAction = "incident.snooze"
+	AuditIncidentUnsnoozed  AuditAction = "incident.unsnooze"
+	AuditIncidentWatched    AuditAction = "incident.watch"
+	AuditIncidentUnwatched  AuditAction = "incident.unwatch"
+	AuditIncidentAttached   AuditAction = "incident.attach"
+	AuditIncidentDetached   AuditAction = "incident.detach"
+	AuditIncidentPostmortem AuditAction = "incident.postmortem"
+	AuditIncidentUpdated    AuditAction = "incident.update"
+	AuditIncidentMapped     AuditAction = "incident.map"
+	AuditQueueReset         AuditAction = "queue.reset"
+)
+
+// AuditEntry records who changed what through the management API, with the
+// affected values before and after the change
+type AuditEntry struct {
+	ID           int64                  `json:"id" db:"id"`
+	Actor        string                 `json:"actor" db:"actor"` // the API key name, or "anonymous" without auth
+	Action       AuditAction            `json:"action" db:"action"`
+	ResourceType string                 `json:"resource_type" db:"resource_type"`
+	ResourceID   string                 `json:"resource_id" db:"resource_id"`
+	Before       map[string]interface{} `json:"before,omitempty" db:"before_value"`
+	After        map[string]interface{} `json:"after,omitempty" db:"after_value"`
+	SourceIP     string                 `json:"source_ip" db:"source_ip"`
+	RequestID    string                 `json:"request_id,omitempty" db:"request_id"`
+	CreatedAt    time.Time              `json:"created_at" db:"created_at"`
+}
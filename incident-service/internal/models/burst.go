@@ -0,0 +1,57 @@
+package models
+
+import (
+	"sync"
+	"time"
+)
+
+// BurstGate counts remediation dispatches within a sliding window, to tell
+// when incidents arrive in a burst. During a burst, only urgent incidents are
+// dispatched right away and the rest wait for it to end.
+type BurstGate struct {
+	threshold int
+	window    time.Duration
+
+	mu       sync.Mutex
+	arrivals []time.Time // dispatches within the window, oldest first
+}
+
+// NewBurstGate creates a gate for bursts of more than threshold dispatches
+// within window. A threshold of zero never reports a burst.
+func NewBurstGate(threshold int, window time.Duration) *BurstGate {
+	return &BurstGate{threshold: threshold, window: window}
+}
+
+// Admit decides whether an incident of severity arriving at now is
+// dispatched right away. It is when no burst is going on, or when the
+// incident is urgent, and is then counted towards the window. Otherwise
+// Admit returns when the burst is due to end.
+func (g *BurstGate) Admit(severity string, now time.Time) (admitted bool, until time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.prune(now)
+	if g.bursting() && !UrgentSeverity(severity) {
+		return false, g.arrivals[len(g.arrivals)-g.threshold].Add(g.window)
+	}
+	g.arrivals = append(g.arrivals, now)
+	return true, time.Time{}
+}
+
+// bursting reports whether the window is full, so that the next incident
+// arrives during a burst. g.mu must be held.
+func (g *BurstGate) bursting() bool {
+	return g.threshold > 0 && len(g.arrivals) >= g.threshold
+}
+
+// prune drops dispatches that fell out of the window. g.mu must be held.
+func (g *BurstGate) prune(now time.Time) {
+	cutoff := now.Add(-g.window)
+	kept := g.arrivals[:0]
+	for _, arrival := range g.arrivals {
+		if arrival.After(cutoff) {
+			kept = append(kept, arrival)
+		}
+	}
+	g.arrivals = kept
+}
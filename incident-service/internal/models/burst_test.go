@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBurstGate_Admit(t *testing.T) {
+	gate := NewBurstGate(2, time.Minute)
+	start := time.Date(2024, 3, 10, 4, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		after     time.Duration
+		severity  string
+		admitted  bool
+		wantUntil time.Duration // after start, when not admitted
+	}{
+		{0, "low", true, 0},
+		{10 * time.Second, "medium", true, 0},
+		{20 * time.Second, "low", false, time.Minute},         // the window is full
+		{30 * time.Second, "critical", true, 0},               // urgent, and counted
+		{40 * time.Second, "", false, 70 * time.Second},       // the second dispatch now fills it
+		{70 * time.Second, "low", true, 0},                    // the first two have left the window
+		{75 * time.Second, "medium", false, 90 * time.Second}, // the critical one and the last fill it
+	}
+	for i, step := range steps {
+		admitted, until := gate.Admit(step.severity, start.Add(step.after))
+		if admitted != step.admitted {
+			t.Fatalf("step %d: admitted = %v, want %v", i, admitted, step.admitted)
+		}
+		if !admitted && !until.Equal(start.Add(step.wantUntil)) {
+			t.Errorf("step %d: until = %v, want %v", i, until, start.Add(step.wantUntil))
+		}
+	}
+
+	// A threshold of zero never defers
+	open := NewBurstGate(0, time.Minute)
+	for i := 0; i < 5; i++ {
+		if admitted, _ := open.Admit("low", start); !admitted {
+			t.Fatalf("expected a gate without threshold to admit every incident")
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// CorrelatedAlert is an alert that another provider sent about the same
+// outage as an incident. It is attached to the incident rather than starting
+// a remediation of its own.
+type CorrelatedAlert struct {
+	ID           int64                  `json:"id" db:"id"`
+	IncidentID   string                 `json:"incident_id" db:"incident_id"`
+	Provider     string                 `json:"provider" db:"provider"`
+	ProviderRef  string                 `json:"provider_ref,omitempty" db:"provider_ref"`
+	ErrorMessage string                 `json:"error_message" db:"error_message"`
+	StackTrace   *string                `json:"stack_trace,omitempty" db:"stack_trace"`
+	Severity     string                 `json:"severity" db:"severity"`
+	Fingerprint  string                 `json:"fingerprint" db:"fingerprint"`
+	ProviderData map[string]interface{} `json:"provider_data,omitempty" db:"provider_data"`
+	ReceivedAt   time.Time              `json:"received_at" db:"received_at"`
+}
+
+// NewCorrelatedAlert returns the alert of an incoming incident, to be attached
+// to the incident it was correlated with
+func NewCorrelatedAlert(incidentID string, alert *Incident) *CorrelatedAlert {
+	return &CorrelatedAlert{
+		IncidentID:   incidentID,
+		Provider:     alert.Provider,
+		ProviderRef:  alert.ProviderRef,
+		ErrorMessage: alert.ErrorMessage,
+		StackTrace:   alert.StackTrace,
+		Severity:     alert.Severity,
+		Fingerprint:  alert.Fingerprint,
+		ProviderData: alert.ProviderData,
+		ReceivedAt:   time.Now(),
+	}
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// IncidentDispatch records the remediation of an incident in one repository,
+// for services whose mapping spans several repositories
+type IncidentDispatch struct {
+	ID             int64          `json:"id" db:"id"`
+	IncidentID     string         `json:"incident_id" db:"incident_id"`
+	Repository     string         `json:"repository" db:"repository"`
+	Branch         string         `json:"branch" db:"branch"`
+	Status         IncidentStatus `json:"status" db:"status"` // pending while queued
+	PullRequestURL *string        `json:"pull_request_url,omitempty" db:"pull_request_url"`
+	Diagnosis      *string        `json:"diagnosis,omitempty" db:"diagnosis"`
+	Error          *string        `json:"error,omitempty" db:"error"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at" db:"updated_at"`
+	TriggeredAt    *time.Time     `json:"triggered_at,omitempty" db:"triggered_at"`
+	CompletedAt    *time.Time     `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// Completed reports whether the repository's remediation has finished
+func (d *IncidentDispatch) Completed() bool {
+	switch d.Status {
+	case StatusPRCreated, StatusNoFixNeeded, StatusFailed, StatusResolved:
+		return true
+	}
+	return false
+}
+
+// DispatchStatus aggregates the outcomes of an incident's dispatches into the
+// status of the incident. While any repository is still being remediated, the
+// incident is in progress, or pending if no workflow has started yet. Once all
+// have finished, a pull request in any repository means the incident has a
+// fix, even if other repositories failed; otherwise any failure fails the
+// incident. An incident without dispatches is pending.
+func DispatchStatus(dispatches []*IncidentDispatch) IncidentStatus {
+	present := make(map[IncidentStatus]bool, len(dispatches))
+	for _, dispatch := range dispatches {
+		present[dispatch.Status] = true
+	}
+
+	switch {
+	case len(dispatches) == 0:
+		return StatusPending
+	case present[StatusInProgress]:
+		return StatusInProgress
+	case present[StatusWorkflowTriggered]:
+		return StatusWorkflowTriggered
+	case present[StatusPending]:
+		// Queued repositories still have to run, but once another repository
+		// has started the incident counts as triggered
+		if len(present) > 1 {
+			return StatusWorkflowTriggered
+		}
+		return StatusPending
+	case present[StatusPRCreated], present[StatusResolved]:
+		return StatusPRCreated
+	case present[StatusFailed]:
+		return StatusFailed
+	}
+	return StatusNoFixNeeded
+}
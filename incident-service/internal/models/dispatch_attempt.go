@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// DispatchAttemptOutcome is the result of one attempt to dispatch a
+// remediation workflow
+type DispatchAttemptOutcome string
+
+const (
+	DispatchAttemptSucceeded   DispatchAttemptOutcome = "succeeded"
+	DispatchAttemptFailed      DispatchAttemptOutcome = "failed"
+	DispatchAttemptCircuitOpen DispatchAttemptOutcome = "circuit_open" // not sent, as GitHub kept rejecting dispatches
+)
+
+// DispatchAttempt records one request to dispatch an incident's remediation
+// workflow to a repository. A dispatch is retried, so it makes up to three
+// attempts, numbered from 1.
+type DispatchAttempt struct {
+	ID          int64                  `json:"id" db:"id"`
+	IncidentID  string                 `json:"incident_id" db:"incident_id"`
+	Repository  string                 `json:"repository" db:"repository"`
+	Attempt     int                    `json:"attempt" db:"attempt"`
+	Outcome     DispatchAttemptOutcome `json:"outcome" db:"outcome"`
+	HTTPStatus  *int                   `json:"http_status,omitempty" db:"http_status"` // absent when GitHub did not respond
+	RunID       *int64                 `json:"run_id,omitempty" db:"run_id"`           // the workflow run, once the workflow reports it
+	Error       *string                `json:"error,omitempty" db:"error"`
+	AttemptedAt time.Time              `json:"attempted_at" db:"attempted_at"`
+}
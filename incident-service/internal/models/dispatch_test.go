@@ -0,0 +1,34 @@
+package models
+
+import "testing"
+
+func TestDispatchStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []IncidentStatus
+		expected IncidentStatus
+	}{
+		{"no dispatches", nil, StatusPending},
+		{"all queued", []IncidentStatus{StatusPending, StatusPending}, StatusPending},
+		{"one running", []IncidentStatus{StatusWorkflowTriggered, StatusPending}, StatusWorkflowTriggered},
+		{"in progress wins", []IncidentStatus{StatusInProgress, StatusWorkflowTriggered, StatusPRCreated}, StatusInProgress},
+		{"queued after a finished one", []IncidentStatus{StatusPRCreated, StatusPending}, StatusWorkflowTriggered},
+		{"running after a failure", []IncidentStatus{StatusFailed, StatusWorkflowTriggered}, StatusWorkflowTriggered},
+		{"fix despite a failure", []IncidentStatus{StatusFailed, StatusPRCreated}, StatusPRCreated},
+		{"fix and nothing to fix", []IncidentStatus{StatusNoFixNeeded, StatusPRCreated}, StatusPRCreated},
+		{"failure and nothing to fix", []IncidentStatus{StatusNoFixNeeded, StatusFailed}, StatusFailed},
+		{"nothing to fix", []IncidentStatus{StatusNoFixNeeded, StatusNoFixNeeded}, StatusNoFixNeeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dispatches := make([]*IncidentDispatch, 0, len(tt.statuses))
+			for _, status := range tt.statuses {
+				dispatches = append(dispatches, &IncidentDispatch{Status: status})
+			}
+			if got := DispatchStatus(dispatches); got != tt.expected {
+				t.Errorf("DispatchStatus() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
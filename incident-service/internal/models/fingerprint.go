@@ -0,0 +1,109 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// fingerprintFrames is how many stack frames contribute to a fingerprint
+const fingerprintFrames = 3
+
+// Volatile parts of error messages and stack frames, replaced in order so that
+// UUIDs and hex values are not broken up by the number pattern
+var fingerprintReplacements = []struct {
+	pattern *regexp.Regexp
+	replace func(match string) string
+}{
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), placeholder("<uuid>")},
+	{regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`), placeholder("<time>")},
+	{regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b`), placeholder("<hex>")},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8,}\b`), hexToken},
+	{regexp.MustCompile(`\d+`), placeholder("<num>")},
+	{regexp.MustCompile(`\s+`), placeholder(" ")},
+}
+
+// placeholder replaces every match with the same text
+func placeholder(text string) func(string) string {
+	return func(string) string { return text }
+}
+
+// hexToken replaces long hex strings such as hashes and trace IDs. Plain words
+// made of the letters a to f are kept, and plain numbers are left to the number
+// pattern.
+func hexToken(match string) string {
+	if strings.IndexAny(match, "0123456789") < 0 || strings.IndexAny(match, "abcdefABCDEF") < 0 {
+		return match
+	}
+	return "<hex>"
+}
+
+// frameLine matches the lines of a stack trace that name a code location:
+// Java and JavaScript "at ..." lines, Python "File ..." lines, and source
+// file paths with a line number
+var frameLine = regexp.MustCompile(`^(at |File "|\S+\.(go|py|js|ts|java|kt|scala|rb|cs|php|rs)[:"(])`)
+
+// NormalizeMessage strips the parts of an error message that change between
+// occurrences of the same error, such as IDs, timestamps, addresses and counts
+func NormalizeMessage(message string) string {
+	normalized := message
+	for _, r := range fingerprintReplacements {
+		normalized = r.pattern.ReplaceAllStringFunc(normalized, r.replace)
+	}
+	return strings.TrimSpace(normalized)
+}
+
+// ComputeFingerprint identifies an error independently of the volatile parts
+// of its message and of the service reporting it. It hashes the normalized
+// message together with the top frames of the stack trace, if any.
+func ComputeFingerprint(errorMessage string, stackTrace *string) string {
+	h := sha256.New()
+	h.Write([]byte(NormalizeMessage(errorMessage)))
+
+	if stackTrace != nil {
+		frames := 0
+		for _, line := range strings.Split(*stackTrace, "\n") {
+			line = strings.TrimSpace(line)
+			if !frameLine.MatchString(line) {
+				continue
+			}
+			h.Write([]byte{'\n'})
+			h.Write([]byte(NormalizeMessage(line)))
+			frames++
+			if frames == fingerprintFrames {
+				break
+			}
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ExactMessageFingerprint identifies an error by its message exactly as the
+// provider sent it
+func ExactMessageFingerprint(errorMessage string) string {
+	sum := sha256.Sum256([]byte("exact_message\n" + errorMessage))
+	return hex.EncodeToString(sum[:])
+}
+
+// ProviderKeyFingerprint identifies the alerts a provider groups under key
+func ProviderKeyFingerprint(provider, key string) string {
+	sum := sha256.Sum256([]byte("provider_key\n" + provider + "\n" + key))
+	return hex.EncodeToString(sum[:])
+}
+
+// ProviderKey returns the key under which the provider itself groups the
+// incident's alerts: the Datadog aggregation key, the Sentry issue, the
+// PagerDuty incident, the Grafana alert rule or the Splunk detector
+// incident. It is empty when the provider gave none.
+func (i *Incident) ProviderKey() string {
+	switch i.Provider {
+	case "datadog":
+		key, _ := i.ProviderData["aggregation_key"].(string)
+		return key
+	case "sentry", "pagerduty", "grafana", "splunk":
+		return i.ProviderRef
+	}
+	return ""
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestNormalizeMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{"numbers", "timeout after 3012ms on attempt 3", "timeout after <num>ms on attempt <num>"},
+		{"uuid", "order 4f1c2a9e-0b7d-4c1e-9a51-2f3d4e5f6a7b not found", "order <uuid> not found"},
+		{"timestamp", "job started 2024-03-06T12:00:01.123Z failed", "job started <time> failed"},
+		{"address", "nil pointer dereference at 0xc000123abc", "nil pointer dereference at <hex>"},
+		{"hash", "trace 9f86d081884c7d65 dropped", "trace <hex> dropped"},
+		{"hex letters only", "cache facade deadbeef missing", "cache facade deadbeef missing"},
+		{"whitespace", "  connection\n\trefused  ", "connection refused"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeMessage(tt.message); got != tt.expected {
+				t.Errorf("NormalizeMessage(%q) = %q, want %q", tt.message, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestComputeFingerprint_StackFrames(t *testing.T) {
+	trace := func(line int) *string {
+		s := fmt.Sprintf(`Traceback (most recent call last):
+  File "/app/handlers.py", line %d, in handle
+    return process(order)
+  File "/app/orders.py", line 88, in process
+    raise ValueError("bad order")
+ValueError: bad order`, line)
+		return &s
+	}
+	otherTrace := `Traceback (most recent call last):
+  File "/app/payments.py", line 12, in charge
+ValueError: bad order`
+
+	base := ComputeFingerprint("ValueError: bad order", trace(42))
+	if got := ComputeFingerprint("ValueError: bad order", trace(57)); got != base {
+		t.Error("expected line numbers in frames to be ignored")
+	}
+	if got := ComputeFingerprint("ValueError: bad order", &otherTrace); got == base {
+		t.Error("expected different frames to give a different fingerprint")
+	}
+	if got := ComputeFingerprint("ValueError: bad order", nil); got == base {
+		t.Error("expected frames to contribute to the fingerprint")
+	}
+}
+
+// Property: messages that differ only in embedded numbers share a fingerprint
+func TestProperty_FingerprintIgnoresNumbers(t *testing.T) {
+	properties := gopter.NewProperties(nil)
+
+	properties.Property("messages differing only in numbers have the same fingerprint", prop.ForAll(
+		func(prefix string, a, b uint32) bool {
+			first := fmt.Sprintf("%s request %d failed", prefix, a)
+			second := fmt.Sprintf("%s request %d failed", prefix, b)
+			return ComputeFingerprint(first, nil) == ComputeFingerprint(second, nil)
+		},
+		gen.AlphaString(),
+		gen.UInt32(),
+		gen.UInt32(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestIncident_ProviderKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		incident Incident
+		expected string
+	}{
+		{"datadog aggregation key", Incident{Provider: "datadog", ProviderRef: "123", ProviderData: map[string]interface{}{"aggregation_key": "checkout-5xx"}}, "checkout-5xx"},
+		{"datadog without aggregation key", Incident{Provider: "datadog", ProviderRef: "123"}, ""},
+		{"sentry issue", Incident{Provider: "sentry", ProviderRef: "4711"}, "4711"},
+		{"splunk incident", Incident{Provider: "splunk", ProviderRef: "FRh7cvUA4AA"}, "FRh7cvUA4AA"},
+		{"unknown provider", Incident{Provider: "custom", ProviderRef: "abc"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.incident.ProviderKey(); got != tt.expected {
+				t.Errorf("ProviderKey() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExactMessageFingerprint(t *testing.T) {
+	if ExactMessageFingerprint("timeout after 3012ms") == ExactMessageFingerprint("timeout after 2001ms") {
+		t.Error("expected messages differing in numbers to give different fingerprints")
+	}
+	if ExactMessageFingerprint("timeout") != ExactMessageFingerprint("timeout") {
+		t.Error("expected the same message to give the same fingerprint")
+	}
+	if ProviderKeyFingerprint("sentry", "4711") == ProviderKeyFingerprint("pagerduty", "4711") {
+		t.Error("expected the same key of different providers to give different fingerprints")
+	}
+}
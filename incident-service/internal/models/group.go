@@ -0,0 +1,31 @@
+package models
+
+// groupStatusPriority orders statuses from most to least significant for a
+// group of incidents. A group is as far along as its least advanced member:
+// any work still running or waiting keeps the group open, and a failure
+// outweighs members that were fixed.
+var groupStatusPriority = []IncidentStatus{
+	StatusInProgress,
+	StatusWorkflowTriggered,
+	StatusPending,
+	StatusFailed,
+	StatusPRCreated,
+	StatusResolved,
+	StatusNoFixNeeded,
+}
+
+// GroupStatus rolls the statuses of a parent incident and its children up
+// into the status of the whole group. An empty group is pending.
+func GroupStatus(members []*Incident) IncidentStatus {
+	present := make(map[IncidentStatus]bool, len(members))
+	for _, member := range members {
+		present[member.Status] = true
+	}
+
+	for _, status := range groupStatusPriority {
+		if present[status] {
+			return status
+		}
+	}
+	return StatusPending
+}
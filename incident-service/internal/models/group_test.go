@@ -0,0 +1,32 @@
+package models
+
+import "testing"
+
+func TestGroupStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []IncidentStatus
+		want     IncidentStatus
+	}{
+		{"empty group", nil, StatusPending},
+		{"single member", []IncidentStatus{StatusResolved}, StatusResolved},
+		{"work in progress keeps group open", []IncidentStatus{StatusResolved, StatusInProgress, StatusFailed}, StatusInProgress},
+		{"pending member keeps group open", []IncidentStatus{StatusResolved, StatusPending}, StatusPending},
+		{"failure outweighs fixes", []IncidentStatus{StatusResolved, StatusFailed, StatusPRCreated}, StatusFailed},
+		{"open pull request before resolution", []IncidentStatus{StatusResolved, StatusPRCreated}, StatusPRCreated},
+		{"all resolved", []IncidentStatus{StatusResolved, StatusResolved, StatusNoFixNeeded}, StatusResolved},
+		{"nothing to fix", []IncidentStatus{StatusNoFixNeeded, StatusNoFixNeeded}, StatusNoFixNeeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			members := make([]*Incident, len(tt.statuses))
+			for i, status := range tt.statuses {
+				members[i] = &Incident{Status: status}
+			}
+			if got := GroupStatus(members); got != tt.want {
+				t.Errorf("GroupStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
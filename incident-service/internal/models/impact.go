@@ -0,0 +1,126 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Impact is the estimated impact of an incident. Every field is optional,
+// and fields left nil are left unchanged when an impact is applied.
+type Impact struct {
+	AffectedUsers   *int64   `json:"affected_users,omitempty"`
+	ErrorRate       *float64 `json:"error_rate,omitempty"` // fraction of requests failing, from 0 to 1
+	DowntimeMinutes *int     `json:"downtime_minutes,omitempty"`
+}
+
+// ImpactPaths locates the impact fields in a provider's payload, as dotted
+// paths such as "attributes.error_rate"
+type ImpactPaths struct {
+	AffectedUsers   string
+	ErrorRate       string
+	DowntimeMinutes string
+}
+
+// Empty reports whether the impact sets no field
+func (im Impact) Empty() bool {
+	return im.AffectedUsers == nil && im.ErrorRate == nil && im.DowntimeMinutes == nil
+}
+
+// Validate checks that the set fields are in range
+func (im Impact) Validate() error {
+	if im.AffectedUsers != nil && *im.AffectedUsers < 0 {
+		return fmt.Errorf("affected_users must not be negative")
+	}
+	if im.ErrorRate != nil && !(*im.ErrorRate >= 0 && *im.ErrorRate <= 1) {
+		return fmt.Errorf("error_rate must be a fraction from 0 to 1")
+	}
+	if im.DowntimeMinutes != nil && *im.DowntimeMinutes < 0 {
+		return fmt.Errorf("downtime_minutes must not be negative")
+	}
+	return nil
+}
+
+// Apply sets the incident's impact fields that the impact sets
+func (im Impact) Apply(incident *Incident) {
+	if im.AffectedUsers != nil {
+		incident.AffectedUsers = im.AffectedUsers
+	}
+	if im.ErrorRate != nil {
+		incident.ErrorRate = im.ErrorRate
+	}
+	if im.DowntimeMinutes != nil {
+		incident.DowntimeMinutes = im.DowntimeMinutes
+	}
+}
+
+// ExtractImpact reads the impact fields found at paths in a provider's payload.
+// Fields whose path is empty, missing, not a number or out of range are left
+// nil. Error rates above 1 are read as percentages.
+func ExtractImpact(data map[string]interface{}, paths ImpactPaths) Impact {
+	var impact Impact
+	if value, ok := lookupNumber(data, paths.AffectedUsers); ok && value >= 0 && value <= math.MaxInt64 {
+		users := int64(value)
+		impact.AffectedUsers = &users
+	}
+	if value, ok := lookupNumber(data, paths.ErrorRate); ok && value >= 0 && value <= 100 {
+		if value > 1 {
+			value /= 100
+		}
+		impact.ErrorRate = &value
+	}
+	if value, ok := lookupNumber(data, paths.DowntimeMinutes); ok && value >= 0 && value <= math.MaxInt32 {
+		minutes := int(value)
+		impact.DowntimeMinutes = &minutes
+	}
+	return impact
+}
+
+// lookupNumber follows a dotted path through nested objects and returns the
+// number at its end. Numbers given as strings are parsed.
+func lookupNumber(data map[string]interface{}, path string) (float64, bool) {
+	if path == "" {
+		return 0, false
+	}
+
+	var value interface{} = data
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return 0, false
+		}
+		if value, ok = object[key]; !ok {
+			return 0, false
+		}
+	}
+
+	var number float64
+	switch v := value.(type) {
+	case float64:
+		number = v
+	case int:
+		number = float64(v)
+	case int64:
+		number = float64(v)
+	case json.Number:
+		parsed, err := v.Float64()
+		if err != nil {
+			return 0, false
+		}
+		number = parsed
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, false
+		}
+		number = parsed
+	default:
+		return 0, false
+	}
+	if math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, false
+	}
+	return number, true
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestImpact_Validate(t *testing.T) {
+	users := func(n int64) *int64 { return &n }
+	rate := func(f float64) *float64 { return &f }
+	minutes := func(n int) *int { return &n }
+
+	tests := []struct {
+		name   string
+		impact Impact
+		valid  bool
+	}{
+		{"empty", Impact{}, true},
+		{"all set", Impact{AffectedUsers: users(1200), ErrorRate: rate(0.25), DowntimeMinutes: minutes(45)}, true},
+		{"zero values", Impact{AffectedUsers: users(0), ErrorRate: rate(0), DowntimeMinutes: minutes(0)}, true},
+		{"full error rate", Impact{ErrorRate: rate(1)}, true},
+		{"negative users", Impact{AffectedUsers: users(-1)}, false},
+		{"error rate as a percentage", Impact{ErrorRate: rate(25)}, false},
+		{"negative error rate", Impact{ErrorRate: rate(-0.1)}, false},
+		{"NaN error rate", Impact{ErrorRate: rate(math.NaN())}, false},
+		{"negative downtime", Impact{DowntimeMinutes: minutes(-5)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.impact.Validate(); (err == nil) != tt.valid {
+				t.Errorf("Validate() error = %v, want valid = %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestExtractImpact(t *testing.T) {
+	var data map[string]interface{}
+	payload := `{
+		"id": "4711",
+		"attributes": {
+			"users_affected": 1500,
+			"error_rate": 12.5,
+			"downtime": "30"
+		},
+		"ratio": 0.04,
+		"broken": {"users": -3, "rate": "high", "downtime": true}
+	}`
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		t.Fatalf("failed to unmarshal payload: %v", err)
+	}
+
+	impact := ExtractImpact(data, ImpactPaths{
+		AffectedUsers:   "attributes.users_affected",
+		ErrorRate:       "attributes.error_rate",
+		DowntimeMinutes: "attributes.downtime",
+	})
+	if impact.AffectedUsers == nil || *impact.AffectedUsers != 1500 {
+		t.Errorf("expected 1500 affected users, got %v", impact.AffectedUsers)
+	}
+	if impact.ErrorRate == nil || *impact.ErrorRate != 0.125 {
+		t.Errorf("expected the percentage to be read as an error rate of 0.125, got %v", impact.ErrorRate)
+	}
+	if impact.DowntimeMinutes == nil || *impact.DowntimeMinutes != 30 {
+		t.Errorf("expected 30 minutes of downtime, got %v", impact.DowntimeMinutes)
+	}
+
+	impact = ExtractImpact(data, ImpactPaths{ErrorRate: "ratio"})
+	if impact.ErrorRate == nil || *impact.ErrorRate != 0.04 {
+		t.Errorf("expected an error rate of 0.04, got %v", impact.ErrorRate)
+	}
+	if impact.AffectedUsers != nil || impact.DowntimeMinutes != nil {
+		t.Errorf("expected fields without a path to be left nil, got %+v", impact)
+	}
+
+	impact = ExtractImpact(data, ImpactPaths{
+		AffectedUsers:   "broken.users",
+		ErrorRate:       "broken.rate",
+		DowntimeMinutes: "broken.downtime",
+	})
+	if !impact.Empty() {
+		t.Errorf("expected invalid values to be ignored, got %+v", impact)
+	}
+
+	impact = ExtractImpact(data, ImpactPaths{AffectedUsers: "id.users", ErrorRate: "missing.rate"})
+	if !impact.Empty() {
+		t.Errorf("expected paths that do not resolve to be ignored, got %+v", impact)
+	}
+}
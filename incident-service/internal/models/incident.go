@@ -17,26 +17,55 @@ const (
 	StatusResolved          IncidentStatus = "resolved"
 	StatusFailed            IncidentStatus = "failed"
 	StatusNoFixNeeded       IncidentStatus = "no_fix_needed"
+	StatusSuppressed        IncidentStatus = "suppressed"       // recorded, but a custom rule skipped its remediation
+	StatusUnmapped          IncidentStatus = "unmapped"         // its service has no repository to remediate in yet
+	StatusManualAttention   IncidentStatus = "manual_attention" // its service reached its daily remediation cap
 )
 
 // Incident represents an incident notification from an observability platform
 type Incident struct {
-	ID             string                 `json:"id" db:"id"`
-	ServiceName    string                 `json:"service_name" db:"service_name"`
-	Repository     string                 `json:"repository" db:"repository"`
-	ErrorMessage   string                 `json:"error_message" db:"error_message"`
-	StackTrace     *string                `json:"stack_trace,omitempty" db:"stack_trace"`
-	Severity       string                 `json:"severity" db:"severity"`
-	Status         IncidentStatus         `json:"status" db:"status"`
-	Provider       string                 `json:"provider" db:"provider"`
-	ProviderData   map[string]interface{} `json:"provider_data" db:"provider_data"`
-	WorkflowRunID  *int64                 `json:"workflow_run_id,omitempty" db:"workflow_run_id"`
-	PullRequestURL *string                `json:"pull_request_url,omitempty" db:"pull_request_url"`
-	Diagnosis      *string                `json:"diagnosis,omitempty" db:"diagnosis"`
-	CreatedAt      time.Time              `json:"created_at" db:"created_at"`
-	UpdatedAt      time.Time              `json:"updated_at" db:"updated_at"`
-	TriggeredAt    *time.Time             `json:"triggered_at,omitempty" db:"triggered_at"`
-	CompletedAt    *time.Time             `json:"completed_at,omitempty" db:"completed_at"`
+	ID                string                 `json:"id" db:"id"`
+	ServiceName       string                 `json:"service_name" db:"service_name"`
+	Repository        string                 `json:"repository" db:"repository"`
+	ErrorMessage      string                 `json:"error_message" db:"error_message"`
+	StackTrace        *string                `json:"stack_trace,omitempty" db:"stack_trace"`
+	Severity          string                 `json:"severity" db:"severity"`
+	Status            IncidentStatus         `json:"status" db:"status"`
+	Provider          string                 `json:"provider" db:"provider"`
+	ProviderData      map[string]interface{} `json:"provider_data" db:"provider_data"`
+	WorkflowRunID     *int64                 `json:"workflow_run_id,omitempty" db:"workflow_run_id"`
+	PullRequestURL    *string                `json:"pull_request_url,omitempty" db:"pull_request_url"`
+	Diagnosis         *string                `json:"diagnosis,omitempty" db:"diagnosis"`
+	CreatedAt         time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time              `json:"updated_at" db:"updated_at"`
+	TriggeredAt       *time.Time             `json:"triggered_at,omitempty" db:"triggered_at"`
+	CompletedAt       *time.Time             `json:"completed_at,omitempty" db:"completed_at"`
+	RetryCount        int                    `json:"retry_count" db:"retry_count"`
+	NextRetryAt       *time.Time             `json:"next_retry_at,omitempty" db:"next_retry_at"`
+	Fingerprint       string                 `json:"fingerprint,omitempty" db:"fingerprint"`
+	OccurrenceCount   int                    `json:"occurrence_count" db:"occurrence_count"`
+	ParentID          *string                `json:"parent_id,omitempty" db:"parent_id"`
+	DeferredUntil     *time.Time             `json:"deferred_until,omitempty" db:"deferred_until"`
+	SnoozedUntil      *time.Time             `json:"snoozed_until,omitempty" db:"snoozed_until"`
+	Team              string                 `json:"team,omitempty" db:"team"`
+	DuplicateOf       *string                `json:"duplicate_of,omitempty" db:"duplicate_of"`
+	TenantID          string                 `json:"tenant_id" db:"tenant_id"`
+	QuotaExceeded     bool                   `json:"quota_exceeded,omitempty" db:"quota_exceeded"`
+	Enrichment        *string                `json:"enrichment,omitempty" db:"enrichment"` // JSON context gathered from MCP servers before dispatch
+	Summary           *IncidentSummary       `json:"summary,omitempty" db:"summary"`
+	SeverityDefaulted bool                   `json:"severity_defaulted,omitempty" db:"severity_defaulted"` // the provider gave no severity that maps
+	SuggestedSeverity string                 `json:"suggested_severity,omitempty" db:"suggested_severity"` // predicted from the error and service history
+	ProviderRef       string                 `json:"provider_ref,omitempty" db:"provider_ref"`             // the provider's own ID of the alert, issue or rule
+	AffectedUsers     *int64                 `json:"affected_users,omitempty" db:"affected_users"`         // estimated users affected
+	ErrorRate         *float64               `json:"error_rate,omitempty" db:"error_rate"`                 // fraction of requests failing, from 0 to 1
+	DowntimeMinutes   *int                   `json:"downtime_minutes,omitempty" db:"downtime_minutes"`
+	ReopenedAt        *time.Time             `json:"reopened_at,omitempty" db:"reopened_at"` // when a recurrence last reopened it
+}
+
+// Snoozed reports whether the incident is snoozed at now, so that it neither
+// triggers remediation nor sends notifications
+func (i *Incident) Snoozed(now time.Time) bool {
+	return i.SnoozedUntil != nil && i.SnoozedUntil.After(now)
 }
 
 // JSONB is a custom type for PostgreSQL JSONB columns
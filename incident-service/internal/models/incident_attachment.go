@@ -0,0 +1,41 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxAttachmentFilenameLength bounds the file name of an attachment
+const maxAttachmentFilenameLength = 255
+
+// IncidentAttachment is a file attached to an incident, such as a
+// screenshot, a log excerpt or a flame graph. The file itself is kept in the
+// blob store under StorageKey.
+type IncidentAttachment struct {
+	ID          string    `json:"id" db:"id"`
+	IncidentID  string    `json:"incident_id" db:"incident_id"`
+	Filename    string    `json:"filename" db:"filename"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	Size        int64     `json:"size" db:"size"`     // bytes
+	SHA256      string    `json:"sha256" db:"sha256"` // hex digest of the file
+	StorageKey  string    `json:"-" db:"storage_key"`
+	UploadedBy  string    `json:"uploaded_by,omitempty" db:"uploaded_by"` // the API key name, or "anonymous" without auth
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// Validate checks that the file name is a plain name, without directories or
+// control characters
+func (a *IncidentAttachment) Validate() error {
+	if a.Filename == "" {
+		return fmt.Errorf("filename is required")
+	}
+	if len(a.Filename) > maxAttachmentFilenameLength {
+		return fmt.Errorf("filename must be at most %d characters", maxAttachmentFilenameLength)
+	}
+	if a.Filename == "." || a.Filename == ".." || strings.ContainsAny(a.Filename, `/\`) || strings.ContainsFunc(a.Filename, unicode.IsControl) {
+		return fmt.Errorf("filename must be a file name without directories or control characters")
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIncidentAttachment_Validate(t *testing.T) {
+	tests := []struct {
+		filename string
+		valid    bool
+	}{
+		{"flamegraph.svg", true},
+		{"checkout 500s.png", true},
+		{"", false},
+		{"..", false},
+		{"../../etc/passwd", false},
+		{`C:\logs\app.log`, false},
+		{"app.log\r\nX-Injected: 1", false},
+		{strings.Repeat("a", 256), false},
+	}
+
+	for _, tt := range tests {
+		attachment := &IncidentAttachment{Filename: tt.filename}
+		if err := attachment.Validate(); (err == nil) != tt.valid {
+			t.Errorf("Validate(%q) error = %v, want valid = %v", tt.filename, err, tt.valid)
+		}
+	}
+}
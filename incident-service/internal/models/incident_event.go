@@ -19,6 +19,41 @@ const (
 	EventDuplicateDetected      IncidentEventType = "duplicate_detected"
 	EventQueuedForRemediation   IncidentEventType = "queued_for_remediation"
 	EventDequeuedForRemediation IncidentEventType = "dequeued_for_remediation"
+	EventIncidentEscalated      IncidentEventType = "incident_escalated"
+	EventReconciled             IncidentEventType = "reconciled"
+	EventRetryScheduled         IncidentEventType = "retry_scheduled"
+	EventRemediationRetried     IncidentEventType = "remediation_retried"
+	EventSLABreached            IncidentEventType = "sla_breached"
+	EventStormDetected          IncidentEventType = "storm_detected"
+	EventStormSuppressed        IncidentEventType = "storm_suppressed"
+	EventStormReleased          IncidentEventType = "storm_released"
+	EventIncidentGrouped        IncidentEventType = "incident_grouped"
+	EventIncidentReopened       IncidentEventType = "incident_reopened"
+	EventRemediationDeferred    IncidentEventType = "remediation_deferred"
+	EventRemediationResumed     IncidentEventType = "remediation_resumed"
+	EventIncidentSnoozed        IncidentEventType = "incident_snoozed"
+	EventIncidentUnsnoozed      IncidentEventType = "incident_unsnoozed"
+	EventIncidentLinked         IncidentEventType = "incident_linked"
+	EventIncidentUnlinked       IncidentEventType = "incident_unlinked"
+	EventIncidentMerged         IncidentEventType = "incident_merged"
+	EventQuotaExceeded          IncidentEventType = "quota_exceeded"
+	EventContextEnriched        IncidentEventType = "context_enriched"
+	EventIncidentSummarized     IncidentEventType = "incident_summarized"
+	EventSeveritySuggested      IncidentEventType = "severity_suggested"
+	EventDispatchedInFreeze     IncidentEventType = "dispatched_in_freeze"
+	EventAttachmentAdded        IncidentEventType = "attachment_added"
+	EventAttachmentRemoved      IncidentEventType = "attachment_removed"
+	EventPostmortemPublished    IncidentEventType = "postmortem_published"
+	EventImpactUpdated          IncidentEventType = "impact_updated"
+	EventRemediationSuppressed  IncidentEventType = "remediation_suppressed"
+	EventRepositoryOverridden   IncidentEventType = "repository_overridden"
+	EventServiceUnmapped        IncidentEventType = "service_unmapped"
+	EventServiceMapped          IncidentEventType = "service_mapped"
+	EventInputsTruncated        IncidentEventType = "workflow_inputs_truncated"
+	EventSlotExpired            IncidentEventType = "workflow_slot_expired"
+	EventAlertCorrelated        IncidentEventType = "alert_correlated"
+	EventRemediationCapped      IncidentEventType = "remediation_capped"
+	EventWorkflowStep           IncidentEventType = "workflow_step"
 )
 
 // IncidentEvent represents an event in the incident lifecycle for audit trail
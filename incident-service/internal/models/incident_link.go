@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// RelatedIncident is an incident linked to another one as related, for
+// example because both have the same root cause
+type RelatedIncident struct {
+	IncidentID  string         `json:"incident_id"`
+	ServiceName string         `json:"service_name"`
+	Status      IncidentStatus `json:"status"`
+	Severity    string         `json:"severity"`
+	Team        string         `json:"team,omitempty"`
+	TenantID    string         `json:"tenant_id"`
+	Reason      string         `json:"reason,omitempty"`
+	LinkedAt    time.Time      `json:"linked_at"`
+}
+
+// LinkKey orders the IDs of two linked incidents. A link is stored once for
+// both directions, under the smaller ID first.
+func LinkKey(a, b string) (string, string) {
+	if b < a {
+		return b, a
+	}
+	return a, b
+}
+
+// IncidentLink is a stored link between two related incidents, as exported
+// for backups
+type IncidentLink struct {
+	IncidentID string    `json:"incident_id"`
+	RelatedID  string    `json:"related_id"`
+	Reason     string    `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
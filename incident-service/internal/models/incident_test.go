@@ -2,6 +2,7 @@ package models
 
 import (
 	"testing"
+	"time"
 
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
@@ -95,3 +96,18 @@ func TestProperty_JSONBRoundTrip(t *testing.T) {
 	parameters.MinSuccessfulTests = 100
 	properties.TestingRun(t, parameters)
 }
+
+func TestIncident_Snoozed(t *testing.T) {
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+
+	if (&Incident{}).Snoozed(now) {
+		t.Error("expected an incident without snooze not to be snoozed")
+	}
+	if !(&Incident{SnoozedUntil: &later}).Snoozed(now) {
+		t.Error("expected an incident snoozed until later to be snoozed")
+	}
+	if (&Incident{SnoozedUntil: &later}).Snoozed(later) {
+		t.Error("expected the snooze to end at snoozed_until")
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// maxWatcherFieldLength bounds the email and Slack user of a watcher
+const maxWatcherFieldLength = 255
+
+// IncidentWatcher is someone subscribed to the notifications of an incident.
+// Watchers are sent the incident's events by email, as a Slack direct
+// message, or both.
+type IncidentWatcher struct {
+	IncidentID string    `json:"incident_id" db:"incident_id"`
+	Email      string    `json:"email,omitempty" db:"email"`
+	SlackUser  string    `json:"slack_user,omitempty" db:"slack_user"` // Slack member ID, such as U024BE7LH
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// Validate checks that the watcher can be reached, by a bare email address
+// or a Slack member ID
+func (w *IncidentWatcher) Validate() error {
+	if w.Email == "" && w.SlackUser == "" {
+		return fmt.Errorf("email or slack_user is required")
+	}
+	if len(w.Email) > maxWatcherFieldLength || len(w.SlackUser) > maxWatcherFieldLength {
+		return fmt.Errorf("email and slack_user must be at most %d characters", maxWatcherFieldLength)
+	}
+	if w.Email != "" {
+		address, err := mail.ParseAddress(w.Email)
+		if err != nil || address.Address != w.Email {
+			return fmt.Errorf("email must be an email address")
+		}
+	}
+	if w.SlackUser != "" && strings.ContainsFunc(w.SlackUser, func(r rune) bool { return r <= ' ' || r == '#' || r == '@' }) {
+		return fmt.Errorf("slack_user must be a Slack member ID")
+	}
+	return nil
+}
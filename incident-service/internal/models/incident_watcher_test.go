@@ -0,0 +1,28 @@
+package models
+
+import "testing"
+
+func TestIncidentWatcher_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		watcher IncidentWatcher
+		wantErr bool
+	}{
+		{"email", IncidentWatcher{Email: "alice@example.com"}, false},
+		{"slack user", IncidentWatcher{SlackUser: "U024BE7LH"}, false},
+		{"both", IncidentWatcher{Email: "alice@example.com", SlackUser: "U024BE7LH"}, false},
+		{"neither", IncidentWatcher{}, true},
+		{"display name", IncidentWatcher{Email: "Alice <alice@example.com>"}, true},
+		{"not an address", IncidentWatcher{Email: "alice"}, true},
+		{"slack handle", IncidentWatcher{SlackUser: "@alice"}, true},
+		{"slack channel", IncidentWatcher{SlackUser: "#incidents"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.watcher.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// NotificationDeliveryStatus represents the outcome of a notification delivery
+type NotificationDeliveryStatus string
+
+const (
+	DeliverySent     NotificationDeliveryStatus = "sent"
+	DeliveryRetrying NotificationDeliveryStatus = "retrying"
+	DeliveryFailed   NotificationDeliveryStatus = "failed"
+)
+
+// NotificationDelivery records the delivery of a notification through one provider
+type NotificationDelivery struct {
+	ID            int64                      `json:"id" db:"id"`
+	IncidentID    *string                    `json:"incident_id,omitempty" db:"incident_id"`
+	EventType     IncidentEventType          `json:"event_type" db:"event_type"`
+	Provider      string                     `json:"provider" db:"provider"`
+	Status        NotificationDeliveryStatus `json:"status" db:"status"`
+	Attempts      int                        `json:"attempts" db:"attempts"`
+	LastError     *string                    `json:"last_error,omitempty" db:"last_error"`
+	Message       json.RawMessage            `json:"-" db:"message"` // rendered message, replayed on retry
+	NextAttemptAt *time.Time                 `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	DeliveredAt   *time.Time                 `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt     time.Time                  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at" db:"updated_at"`
+}
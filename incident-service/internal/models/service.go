@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -10,6 +11,38 @@ type IncidentService struct {
 	repo              IncidentRepository
 	serviceMappings   map[string]ServiceMapping
 	deduplicationTime time.Duration
+
+	// Custom rules, see SetRules
+	rules RuleEvaluator
+
+	// Called for incidents of unmapped services, see SetUnmappedHandler
+	onUnmapped func(incident *Incident)
+
+	// Remediation dispatch, see SetDispatcher
+	dispatcher RemediationDispatcher
+	bursts     *BurstGate
+	now        func() time.Time
+
+	mu sync.Mutex // guards serviceMappings and the dispatch fields
+}
+
+// RemediationDispatcher starts remediation for an incident
+type RemediationDispatcher interface {
+	Dispatch(incident *Incident) error
+}
+
+// RuleEvaluator evaluates custom rules against new incidents
+type RuleEvaluator interface {
+	Outcome(incident *Incident) RuleOutcome
+}
+
+// RuleOutcome is what custom rules decided for an incident's remediation,
+// with the rule that made each decision
+type RuleOutcome struct {
+	SkipRemediation bool
+	SkippedBy       string
+	Repository      string // overrides the service mapping when set
+	RepositorySetBy string
 }
 
 // IncidentRepository defines the interface for incident persistence
@@ -19,7 +52,9 @@ type IncidentRepository interface {
 	Update(incident *Incident) error
 	UpdateStatus(id string, status IncidentStatus) error
 	List() ([]*Incident, error)
-	FindDuplicateIncident(serviceName, errorMessage string, timeWindow time.Duration) (*Incident, error)
+	FindDuplicateIncident(serviceName, fingerprint string, timeWindow time.Duration) (*Incident, error)
+	RecordOccurrence(id string) (int, error)
+	LogEvent(event *IncidentEvent) error
 }
 
 // ServiceMapping maps a service name to a repository
@@ -41,35 +76,86 @@ func NewIncidentService(repo IncidentRepository, mappings []ServiceMapping, dedu
 		repo:              repo,
 		serviceMappings:   mappingMap,
 		deduplicationTime: deduplicationTime,
+		now:               time.Now,
 	}
 }
 
+// SetDispatcher makes CreateIncident dispatch remediation for new incidents
+// of mapped services. Once burstThreshold incidents have been dispatched
+// within burstWindow, only critical and high severity incidents are
+// dispatched right away. The rest are deferred to the end of the burst with
+// a queued_for_remediation event, for the maintenance worker to dispatch. A
+// threshold of zero never defers.
+func (s *IncidentService) SetDispatcher(dispatcher RemediationDispatcher, burstThreshold int, burstWindow time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dispatcher = dispatcher
+	s.bursts = NewBurstGate(burstThreshold, burstWindow)
+}
+
+// SetRules makes CreateIncident apply the outcome of custom rules to new
+// incidents: a repository set by a rule overrides the service mapping, and
+// an incident whose remediation a rule skips is recorded as suppressed
+// without being dispatched
+func (s *IncidentService) SetRules(rules RuleEvaluator) {
+	s.rules = rules
+}
+
+// SetUnmappedHandler makes CreateIncident call handler with each new
+// incident of a service that has no repository mapping, so that someone is
+// told to map it
+func (s *IncidentService) SetUnmappedHandler(handler func(incident *Incident)) {
+	s.onUnmapped = handler
+}
+
 // CreateIncident creates a new incident with deduplication and service mapping
 func (s *IncidentService) CreateIncident(incident *Incident) (*Incident, error) {
+	if incident.Fingerprint == "" {
+		incident.Fingerprint = ComputeFingerprint(incident.ErrorMessage, incident.StackTrace)
+	}
+
 	// Check for duplicates within the time window
-	duplicate, err := s.repo.FindDuplicateIncident(incident.ServiceName, incident.ErrorMessage, s.deduplicationTime)
+	duplicate, err := s.repo.FindDuplicateIncident(incident.ServiceName, incident.Fingerprint, s.deduplicationTime)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check for duplicates: %w", err)
 	}
 
-	// If duplicate found, update and return it
+	// If duplicate found, count the occurrence and return it
 	if duplicate != nil {
-		duplicate.UpdatedAt = time.Now()
-		if err := s.repo.Update(duplicate); err != nil {
-			return nil, fmt.Errorf("failed to update duplicate incident: %w", err)
+		count, err := s.repo.RecordOccurrence(duplicate.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record duplicate occurrence: %w", err)
 		}
+		duplicate.OccurrenceCount = count
+		duplicate.UpdatedAt = time.Now()
 		return duplicate, nil
 	}
 
-	// Map service to repository
+	var outcome RuleOutcome
+	if s.rules != nil {
+		outcome = s.rules.Outcome(incident)
+	}
+
+	// Map service to repository, unless a rule sets the repository
+	s.mu.Lock()
 	mapping, found := s.serviceMappings[incident.ServiceName]
-	if found {
+	s.mu.Unlock()
+	switch {
+	case outcome.Repository != "":
+		incident.Repository = outcome.Repository
+		incident.Status = StatusPending
+		found = true
+	case found:
 		incident.Repository = mapping.Repository
 		incident.Status = StatusPending
-	} else {
-		// Service not mapped - mark as requiring manual setup
+	default:
+		// Service not mapped - held until the service is mapped
 		incident.Repository = ""
-		incident.Status = StatusFailed
+		incident.Status = StatusUnmapped
+	}
+	if outcome.SkipRemediation {
+		incident.Status = StatusSuppressed
 	}
 
 	// Create the incident
@@ -77,9 +163,84 @@ func (s *IncidentService) CreateIncident(incident *Incident) (*Incident, error)
 		return nil, fmt.Errorf("failed to create incident: %w", err)
 	}
 
+	if outcome.Repository != "" {
+		if err := s.logEvent(incident.ID, EventRepositoryOverridden, map[string]interface{}{
+			"rule":              outcome.RepositorySetBy,
+			"repository":        outcome.Repository,
+			"mapped_repository": mapping.Repository,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if outcome.SkipRemediation {
+		if err := s.logEvent(incident.ID, EventRemediationSuppressed, map[string]interface{}{
+			"rule": outcome.SkippedBy,
+		}); err != nil {
+			return nil, err
+		}
+		return incident, nil
+	}
+
+	if !found {
+		if err := s.logEvent(incident.ID, EventServiceUnmapped, map[string]interface{}{
+			"service_name": incident.ServiceName,
+		}); err != nil {
+			return nil, err
+		}
+		if s.onUnmapped != nil {
+			s.onUnmapped(incident)
+		}
+		return incident, nil
+	}
+
+	if err := s.dispatch(incident); err != nil {
+		return nil, err
+	}
+
 	return incident, nil
 }
 
+// dispatch starts remediation for a new incident, or defers it when it
+// arrives during a burst and is not urgent
+func (s *IncidentService) dispatch(incident *Incident) error {
+	s.mu.Lock()
+	dispatcher, bursts := s.dispatcher, s.bursts
+	s.mu.Unlock()
+	if dispatcher == nil {
+		return nil
+	}
+
+	if admitted, until := bursts.Admit(incident.Severity, s.now()); !admitted {
+		incident.DeferredUntil = &until
+		if err := s.repo.Update(incident); err != nil {
+			return fmt.Errorf("failed to defer incident %s: %w", incident.ID, err)
+		}
+		return s.logEvent(incident.ID, EventQueuedForRemediation, map[string]interface{}{
+			"reason":         "burst",
+			"severity":       incident.Severity,
+			"deferred_until": until,
+		})
+	}
+
+	if err := dispatcher.Dispatch(incident); err != nil {
+		return fmt.Errorf("failed to dispatch remediation for incident %s: %w", incident.ID, err)
+	}
+	return nil
+}
+
+// logEvent records an event on the incident's timeline
+func (s *IncidentService) logEvent(incidentID string, eventType IncidentEventType, data map[string]interface{}) error {
+	event := &IncidentEvent{
+		IncidentID: incidentID,
+		EventType:  eventType,
+		EventData:  data,
+	}
+	if err := s.repo.LogEvent(event); err != nil {
+		return fmt.Errorf("failed to log %s event: %w", eventType, err)
+	}
+	return nil
+}
+
 // GetIncident retrieves an incident by ID
 func (s *IncidentService) GetIncident(id string) (*Incident, error) {
 	return s.repo.GetByID(id)
@@ -97,7 +258,9 @@ func (s *IncidentService) UpdateIncidentStatus(id string, status IncidentStatus)
 
 // LookupRepository looks up the repository for a service name
 func (s *IncidentService) LookupRepository(serviceName string) (string, bool) {
+	s.mu.Lock()
 	mapping, found := s.serviceMappings[serviceName]
+	s.mu.Unlock()
 	if !found {
 		return "", false
 	}
@@ -114,7 +277,10 @@ func (s *IncidentService) TransitionStatus(incident *Incident, newStatus Inciden
 		StatusPRCreated: {StatusResolved, StatusFailed},
 		StatusFailed: {StatusPending}, // Allow retry
 		StatusNoFixNeeded: {},
-		StatusResolved: {},
+		StatusResolved: {StatusPending}, // Allow reopen
+		StatusSuppressed: {StatusPending}, // Allow remediating anyway
+		StatusUnmapped: {StatusPending}, // Once the service is mapped
+		StatusManualAttention: {StatusPending}, // Retried by a person
 	}
 
 	allowed := false
@@ -140,6 +306,8 @@ func (s *IncidentService) TransitionStatus(incident *Incident, newStatus Inciden
 	case StatusResolved, StatusFailed, StatusNoFixNeeded:
 		now := time.Now()
 		incident.CompletedAt = &now
+	case StatusPending:
+		incident.CompletedAt = nil
 	}
 
 	return s.repo.Update(incident)
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 type MockIncidentRepository struct {
 	incidents map[string]*Incident
 	created   []*Incident
+	events    []*IncidentEvent
 }
 
 func NewMockIncidentRepository() *MockIncidentRepository {
@@ -27,6 +29,9 @@ func (m *MockIncidentRepository) Create(incident *Incident) error {
 	now := time.Now()
 	incident.CreatedAt = now
 	incident.UpdatedAt = now
+	if incident.OccurrenceCount < 1 {
+		incident.OccurrenceCount = 1
+	}
 	
 	m.incidents[incident.ID] = incident
 	m.created = append(m.created, incident)
@@ -62,11 +67,11 @@ func (m *MockIncidentRepository) List() ([]*Incident, error) {
 	return incidents, nil
 }
 
-func (m *MockIncidentRepository) FindDuplicateIncident(serviceName, errorMessage string, timeWindow time.Duration) (*Incident, error) {
+func (m *MockIncidentRepository) FindDuplicateIncident(serviceName, fingerprint string, timeWindow time.Duration) (*Incident, error) {
 	cutoffTime := time.Now().Add(-timeWindow)
 	for _, incident := range m.incidents {
 		if incident.ServiceName == serviceName &&
-			incident.ErrorMessage == errorMessage &&
+			incident.Fingerprint == fingerprint &&
 			incident.CreatedAt.After(cutoffTime) {
 			return incident, nil
 		}
@@ -74,6 +79,30 @@ func (m *MockIncidentRepository) FindDuplicateIncident(serviceName, errorMessage
 	return nil, nil
 }
 
+func (m *MockIncidentRepository) RecordOccurrence(id string) (int, error) {
+	incident, ok := m.incidents[id]
+	if !ok {
+		return 0, fmt.Errorf("incident not found: %s", id)
+	}
+	incident.OccurrenceCount++
+	return incident.OccurrenceCount, nil
+}
+
+func (m *MockIncidentRepository) LogEvent(event *IncidentEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+// recordingDispatcher records the incidents it is asked to remediate
+type recordingDispatcher struct {
+	dispatched []string
+}
+
+func (d *recordingDispatcher) Dispatch(incident *Incident) error {
+	d.dispatched = append(d.dispatched, incident.ID)
+	return nil
+}
+
 // **Feature: ai-sre-platform, Property 4: Service-to-repository lookup consistency**
 // **Validates: Requirements 2.2**
 func TestProperty_ServiceLookupConsistency(t *testing.T) {
@@ -189,6 +218,51 @@ func TestProperty_DeduplicationWithinTimeWindow(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestCreateIncident_FuzzyDeduplication(t *testing.T) {
+	repo := NewMockIncidentRepository()
+	service := NewIncidentService(repo, []ServiceMapping{{ServiceName: "api", Repository: "org/api", Branch: "main"}}, 5*time.Minute)
+
+	first, err := service.CreateIncident(&Incident{
+		ID:           "inc_1",
+		ServiceName:  "api",
+		ErrorMessage: "request 4f1c2a9e-0b7d-4c1e-9a51-2f3d4e5f6a7b timed out after 3012ms",
+		ProviderData: make(map[string]interface{}),
+	})
+	if err != nil {
+		t.Fatalf("CreateIncident() error = %v", err)
+	}
+	if first.Fingerprint == "" || first.OccurrenceCount != 1 {
+		t.Fatalf("expected a fingerprinted first occurrence, got %q with count %d", first.Fingerprint, first.OccurrenceCount)
+	}
+
+	second, err := service.CreateIncident(&Incident{
+		ID:           "inc_2",
+		ServiceName:  "api",
+		ErrorMessage: "request 9d8e7f6a-5b4c-4d3e-8f2a-1b0c9d8e7f6a timed out after 2871ms",
+		ProviderData: make(map[string]interface{}),
+	})
+	if err != nil {
+		t.Fatalf("CreateIncident() error = %v", err)
+	}
+	if second.ID != "inc_1" || second.OccurrenceCount != 2 {
+		t.Errorf("expected the second occurrence to count against inc_1, got %s with count %d", second.ID, second.OccurrenceCount)
+	}
+
+	// The same error from another service is a separate incident
+	other, err := service.CreateIncident(&Incident{
+		ID:           "inc_3",
+		ServiceName:  "billing",
+		ErrorMessage: "request 9d8e7f6a-5b4c-4d3e-8f2a-1b0c9d8e7f6a timed out after 2871ms",
+		ProviderData: make(map[string]interface{}),
+	})
+	if err != nil {
+		t.Fatalf("CreateIncident() error = %v", err)
+	}
+	if other.ID != "inc_3" || len(repo.created) != 2 {
+		t.Errorf("expected a new incident for another service, got %s with %d created", other.ID, len(repo.created))
+	}
+}
+
 // Unit test for status state machine
 func TestIncidentStatusTransitions(t *testing.T) {
 	repo := NewMockIncidentRepository()
@@ -211,7 +285,8 @@ func TestIncidentStatusTransitions(t *testing.T) {
 		{"pr_created to failed", StatusPRCreated, StatusFailed, false},
 		{"failed to pending", StatusFailed, StatusPending, false},
 		{"pending to resolved", StatusPending, StatusResolved, true}, // Invalid
-		{"resolved to pending", StatusResolved, StatusPending, true}, // Invalid
+		{"resolved to pending", StatusResolved, StatusPending, false}, // Reopen
+		{"no_fix_needed to pending", StatusNoFixNeeded, StatusPending, true}, // Invalid
 	}
 
 	for _, tt := range tests {
@@ -267,7 +342,7 @@ func TestServiceMapping(t *testing.T) {
 	}{
 		{"mapped service", "api-gateway", "org/api-gateway", true, StatusPending},
 		{"another mapped service", "user-service", "org/user-service", true, StatusPending},
-		{"unmapped service", "unknown-service", "", false, StatusFailed},
+		{"unmapped service", "unknown-service", "", false, StatusUnmapped},
 	}
 
 	for _, tt := range tests {
@@ -305,3 +380,214 @@ func TestServiceMapping(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateIncident_SeverityAwareDispatch(t *testing.T) {
+	repo := NewMockIncidentRepository()
+	service := NewIncidentService(repo, []ServiceMapping{{ServiceName: "api", Repository: "org/api", Branch: "main"}}, 5*time.Minute)
+	now := time.Now()
+	service.now = func() time.Time { return now }
+	dispatcher := &recordingDispatcher{}
+	service.SetDispatcher(dispatcher, 2, time.Minute)
+
+	// The first two incidents fit under the threshold; after that only
+	// critical and high severities are dispatched right away
+	errors := []string{"timeout", "refused", "reset", "panic", "deadlock", "overflow", "denied"}
+	for i, severity := range []string{"low", "medium", "low", "critical", "medium", "high", "unknown"} {
+		_, err := service.CreateIncident(&Incident{
+			ID:           fmt.Sprintf("inc_%d", i),
+			ServiceName:  "api",
+			ErrorMessage: "connection " + errors[i],
+			Severity:     severity,
+			ProviderData: make(map[string]interface{}),
+		})
+		if err != nil {
+			t.Fatalf("CreateIncident() error = %v", err)
+		}
+	}
+
+	if got := fmt.Sprint(dispatcher.dispatched); got != "[inc_0 inc_1 inc_3 inc_5]" {
+		t.Errorf("expected the urgent incidents to be dispatched during the burst, got %s", got)
+	}
+
+	// The rest wait for the burst to end, a window after the dispatch that
+	// filled it
+	var deferred []string
+	for _, event := range repo.events {
+		if event.EventType != EventQueuedForRemediation {
+			t.Errorf("unexpected %s event", event.EventType)
+			continue
+		}
+		deferred = append(deferred, event.IncidentID)
+	}
+	if fmt.Sprint(deferred) != "[inc_2 inc_4 inc_6]" {
+		t.Errorf("expected queued_for_remediation events for the deferred incidents, got %v", deferred)
+	}
+	for _, id := range deferred {
+		until := repo.incidents[id].DeferredUntil
+		if until == nil || !until.Equal(now.Add(time.Minute)) {
+			t.Errorf("expected %s deferred to the end of the burst, got %v", id, until)
+		}
+	}
+
+	// Once the burst has ended, incidents are dispatched again
+	now = now.Add(time.Minute)
+	if _, err := service.CreateIncident(&Incident{ID: "inc_7", ServiceName: "api", ErrorMessage: "disk full", Severity: "low"}); err != nil {
+		t.Fatalf("CreateIncident() error = %v", err)
+	}
+	if last := dispatcher.dispatched[len(dispatcher.dispatched)-1]; last != "inc_7" {
+		t.Errorf("expected inc_7 dispatched after the burst, got %v", dispatcher.dispatched)
+	}
+}
+
+// fixedRules returns the same outcome for every incident
+type fixedRules struct {
+	outcome RuleOutcome
+}
+
+func (r fixedRules) Outcome(incident *Incident) RuleOutcome {
+	return r.outcome
+}
+
+func TestCreateIncident_HonorsRuleOutcomes(t *testing.T) {
+	tests := []struct {
+		name           string
+		service        string
+		outcome        RuleOutcome
+		wantStatus     IncidentStatus
+		wantRepository string
+		wantDispatched bool
+		wantEvents     []IncidentEventType
+	}{
+		{
+			name:           "no rule",
+			service:        "api",
+			wantStatus:     StatusPending,
+			wantRepository: "org/api",
+			wantDispatched: true,
+		},
+		{
+			name:           "remediation skipped",
+			service:        "api",
+			outcome:        RuleOutcome{SkipRemediation: true, SkippedBy: "ignore-noise"},
+			wantStatus:     StatusSuppressed,
+			wantRepository: "org/api",
+			wantEvents:     []IncidentEventType{EventRemediationSuppressed},
+		},
+		{
+			name:           "repository overridden",
+			service:        "api",
+			outcome:        RuleOutcome{Repository: "org/api-legacy", RepositorySetBy: "legacy-errors"},
+			wantStatus:     StatusPending,
+			wantRepository: "org/api-legacy",
+			wantDispatched: true,
+			wantEvents:     []IncidentEventType{EventRepositoryOverridden},
+		},
+		{
+			name:           "unmapped service given a repository",
+			service:        "batch",
+			outcome:        RuleOutcome{Repository: "org/batch", RepositorySetBy: "batch-jobs"},
+			wantStatus:     StatusPending,
+			wantRepository: "org/batch",
+			wantDispatched: true,
+			wantEvents:     []IncidentEventType{EventRepositoryOverridden},
+		},
+		{
+			name:           "both",
+			service:        "api",
+			outcome:        RuleOutcome{SkipRemediation: true, SkippedBy: "ignore-noise", Repository: "org/api-legacy", RepositorySetBy: "legacy-errors"},
+			wantStatus:     StatusSuppressed,
+			wantRepository: "org/api-legacy",
+			wantEvents:     []IncidentEventType{EventRepositoryOverridden, EventRemediationSuppressed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockIncidentRepository()
+			service := NewIncidentService(repo, []ServiceMapping{{ServiceName: "api", Repository: "org/api", Branch: "main"}}, 5*time.Minute)
+			dispatcher := &recordingDispatcher{}
+			service.SetDispatcher(dispatcher, 0, time.Minute)
+			service.SetRules(fixedRules{outcome: tt.outcome})
+
+			incident, err := service.CreateIncident(&Incident{
+				ID:           "inc_1",
+				ServiceName:  tt.service,
+				ErrorMessage: "connection refused",
+				Severity:     "high",
+				ProviderData: make(map[string]interface{}),
+			})
+			if err != nil {
+				t.Fatalf("CreateIncident() error = %v", err)
+			}
+
+			if incident.Status != tt.wantStatus || incident.Repository != tt.wantRepository {
+				t.Errorf("got status %s in %q, want %s in %q", incident.Status, incident.Repository, tt.wantStatus, tt.wantRepository)
+			}
+			if repo.incidents["inc_1"] == nil {
+				t.Error("expected the incident to be recorded")
+			}
+			if dispatched := len(dispatcher.dispatched) > 0; dispatched != tt.wantDispatched {
+				t.Errorf("dispatched = %v, want %v", dispatched, tt.wantDispatched)
+			}
+
+			var events []IncidentEventType
+			for _, event := range repo.events {
+				events = append(events, event.EventType)
+			}
+			if fmt.Sprint(events) != fmt.Sprint(tt.wantEvents) {
+				t.Errorf("events = %v, want %v", events, tt.wantEvents)
+			}
+			for _, event := range repo.events {
+				if event.EventData["rule"] == "" {
+					t.Errorf("expected the %s event to name its rule", event.EventType)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateIncident_HoldsUnmappedIncidents(t *testing.T) {
+	repo := NewMockIncidentRepository()
+	service := NewIncidentService(repo, nil, 5*time.Minute)
+	dispatcher := &recordingDispatcher{}
+	service.SetDispatcher(dispatcher, 0, time.Minute)
+	var notified []string
+	service.SetUnmappedHandler(func(incident *Incident) {
+		notified = append(notified, incident.ID)
+	})
+
+	for _, tt := range []struct{ id, service, message string }{
+		{"inc_b", "batch", "job timed out"},
+		{"inc_a", "batch", "disk full"},
+		{"inc_other", "reports", "disk full"},
+	} {
+		incident, err := service.CreateIncident(&Incident{
+			ID:           tt.id,
+			ServiceName:  tt.service,
+			ErrorMessage: tt.message,
+			Severity:     "high",
+		})
+		if err != nil {
+			t.Fatalf("CreateIncident() error = %v", err)
+		}
+		if incident.Status != StatusUnmapped || incident.Repository != "" {
+			t.Fatalf("expected %s to be unmapped, got %s in %q", tt.id, incident.Status, incident.Repository)
+		}
+	}
+	if len(dispatcher.dispatched) != 0 {
+		t.Errorf("expected nothing dispatched for unmapped services, got %v", dispatcher.dispatched)
+	}
+	if fmt.Sprint(notified) != "[inc_b inc_a inc_other]" {
+		t.Errorf("expected the unmapped handler called for each incident, got %v", notified)
+	}
+
+	var unmappedEvents int
+	for _, event := range repo.events {
+		if event.EventType == EventServiceUnmapped {
+			unmappedEvents++
+		}
+	}
+	if unmappedEvents != 3 {
+		t.Errorf("expected a service_unmapped event per incident, got %d", unmappedEvents)
+	}
+}
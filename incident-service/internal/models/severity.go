@@ -0,0 +1,21 @@
+package models
+
+// severityPriority orders severities from most to least urgent
+var severityPriority = []string{"critical", "high", "medium", "low"}
+
+// SeverityRank returns the position of the severity in dispatch order, lower
+// first. Unknown severities rank after all known ones.
+func SeverityRank(severity string) int {
+	for rank, s := range severityPriority {
+		if s == severity {
+			return rank
+		}
+	}
+	return len(severityPriority)
+}
+
+// UrgentSeverity reports whether incidents of the severity are remediated
+// right away even during an alert storm
+func UrgentSeverity(severity string) bool {
+	return SeverityRank(severity) <= SeverityRank("high")
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// IncidentEmbedding is the embedding of an incident's error message
+type IncidentEmbedding struct {
+	IncidentID string
+	Vector     []float64
+}
+
+// SimilarIncident is a past incident whose error resembles another's, with
+// what was found and done about it
+type SimilarIncident struct {
+	IncidentID     string         `json:"incident_id"`
+	ServiceName    string         `json:"service_name"`
+	Status         IncidentStatus `json:"status"`
+	Severity       string         `json:"severity"`
+	Team           string         `json:"team,omitempty"`
+	TenantID       string         `json:"tenant_id"`
+	ErrorMessage   string         `json:"error_message"`
+	Diagnosis      *string        `json:"diagnosis,omitempty"`
+	PullRequestURL *string        `json:"pull_request_url,omitempty"`
+	Score          float64        `json:"score"` // cosine similarity of the error messages, up to 1
+	CreatedAt      time.Time      `json:"created_at"`
+}
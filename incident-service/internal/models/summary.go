@@ -0,0 +1,72 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Root cause categories a summary can suggest
+const (
+	RootCauseCode           = "code"
+	RootCauseConfiguration  = "configuration"
+	RootCauseDependency     = "dependency"
+	RootCauseInfrastructure = "infrastructure"
+	RootCauseCapacity       = "capacity"
+	RootCauseData           = "data"
+	RootCauseNetwork        = "network"
+	RootCauseUnknown        = "unknown"
+)
+
+// RootCauseCategories lists the root cause categories in the order they are
+// offered to the model
+var RootCauseCategories = []string{
+	RootCauseCode,
+	RootCauseConfiguration,
+	RootCauseDependency,
+	RootCauseInfrastructure,
+	RootCauseCapacity,
+	RootCauseData,
+	RootCauseNetwork,
+	RootCauseUnknown,
+}
+
+// NormalizeRootCause returns the known category matching category, or
+// unknown when there is none
+func NormalizeRootCause(category string) string {
+	category = strings.ToLower(strings.TrimSpace(category))
+	for _, known := range RootCauseCategories {
+		if category == known {
+			return known
+		}
+	}
+	return RootCauseUnknown
+}
+
+// IncidentSummary is a short description of an incident written by a
+// language model, with the category of its suspected root cause
+type IncidentSummary struct {
+	Text              string    `json:"text"`
+	RootCauseCategory string    `json:"root_cause_category"`
+	Model             string    `json:"model"`
+	GeneratedAt       time.Time `json:"generated_at"`
+}
+
+// Value implements the driver.Valuer interface
+func (s *IncidentSummary) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// Scan implements the sql.Scanner interface
+func (s *IncidentSummary) Scan(value interface{}) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unexpected summary type %T", value)
+	}
+	return json.Unmarshal(bytes, s)
+}
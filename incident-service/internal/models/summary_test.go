@@ -0,0 +1,12 @@
+package models
+
+import "testing"
+
+func TestNormalizeRootCause(t *testing.T) {
+	if got := NormalizeRootCause(" Network "); got != RootCauseNetwork {
+		t.Errorf("expected network, got %q", got)
+	}
+	if got := NormalizeRootCause("cosmic rays"); got != RootCauseUnknown {
+		t.Errorf("expected unknown categories to be normalized, got %q", got)
+	}
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// WorkflowLog is an excerpt of the output of the step that failed in a
+// remediation workflow run. When the run's logs could not be fetched, Error
+// says why and there is no excerpt.
+type WorkflowLog struct {
+	IncidentID    string    `json:"incident_id" db:"incident_id"`
+	WorkflowRunID int64     `json:"workflow_run_id" db:"workflow_run_id"`
+	Repository    string    `json:"repository" db:"repository"`
+	JobName       string    `json:"job_name,omitempty" db:"job_name"`
+	StepName      string    `json:"step_name,omitempty" db:"step_name"`
+	Conclusion    string    `json:"conclusion,omitempty" db:"conclusion"` // of the job
+	Excerpt       *string   `json:"excerpt,omitempty" db:"excerpt"`
+	Truncated     bool      `json:"truncated" db:"truncated"` // earlier output of the step was cut off
+	Error         *string   `json:"error,omitempty" db:"error"`
+	FetchedAt     time.Time `json:"fetched_at" db:"fetched_at"`
+}
@@ -0,0 +1,137 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// DeliveryStore persists notification delivery attempts
+type DeliveryStore interface {
+	CreateNotificationDelivery(delivery *models.NotificationDelivery) error
+	UpdateNotificationDelivery(delivery *models.NotificationDelivery) error
+	ListDueNotificationDeliveries(before time.Time, limit int) ([]*models.NotificationDelivery, error)
+}
+
+// retryBatchSize limits how many deliveries are retried per check
+const retryBatchSize = 100
+
+// SetStore enables delivery tracking. Every delivery is recorded, and failed
+// deliveries are scheduled for retry by RetryDue.
+func (d *Dispatcher) SetStore(store DeliveryStore) {
+	d.store = store
+}
+
+// record stores the outcome of the first delivery attempt through a provider
+func (d *Dispatcher) record(msg *Message, provider string, sendErr error) error {
+	if d.store == nil {
+		return nil
+	}
+
+	message, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification message: %w", err)
+	}
+
+	delivery := &models.NotificationDelivery{
+		EventType: msg.EventType,
+		Provider:  provider,
+		Message:   message,
+	}
+	if msg.Incident != nil {
+		delivery.IncidentID = &msg.Incident.ID
+	}
+	d.applyResult(delivery, sendErr)
+
+	if err := d.store.CreateNotificationDelivery(delivery); err != nil {
+		return fmt.Errorf("failed to record delivery: %w", err)
+	}
+
+	return nil
+}
+
+// applyResult counts an attempt and updates the delivery status, scheduling a
+// retry with exponential backoff until the attempts are exhausted
+func (d *Dispatcher) applyResult(delivery *models.NotificationDelivery, sendErr error) {
+	now := d.now()
+	delivery.Attempts++
+
+	if sendErr == nil {
+		delivery.Status = models.DeliverySent
+		delivery.LastError = nil
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &now
+		return
+	}
+
+	errMsg := sendErr.Error()
+	delivery.LastError = &errMsg
+
+	if delivery.Attempts >= d.config.Retry.Attempts() {
+		delivery.Status = models.DeliveryFailed
+		delivery.NextAttemptAt = nil
+		return
+	}
+
+	next := now.Add(d.config.Retry.Backoff(delivery.Attempts))
+	delivery.Status = models.DeliveryRetrying
+	delivery.NextAttemptAt = &next
+}
+
+// RetryDue resends failed deliveries whose next attempt is due
+func (d *Dispatcher) RetryDue(ctx context.Context) error {
+	if d.store == nil {
+		return nil
+	}
+
+	deliveries, err := d.store.ListDueNotificationDeliveries(d.now(), retryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, delivery := range deliveries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		sendErr := d.resend(ctx, delivery)
+		d.count(delivery.Provider, delivery.EventType, sendErr)
+		d.applyResult(delivery, sendErr)
+
+		if err := d.store.UpdateNotificationDelivery(delivery); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// resend replays a recorded message through the provider that failed to deliver it
+func (d *Dispatcher) resend(ctx context.Context, delivery *models.NotificationDelivery) error {
+	var notifier Notifier
+	for _, n := range d.notifiers {
+		if n.Name() == delivery.Provider {
+			notifier = n
+			break
+		}
+	}
+	if notifier == nil {
+		return fmt.Errorf("provider %s is no longer configured", delivery.Provider)
+	}
+
+	var msg Message
+	if err := json.Unmarshal(delivery.Message, &msg); err != nil {
+		return fmt.Errorf("failed to unmarshal notification message: %w", err)
+	}
+
+	err := notifier.Send(ctx, &msg)
+	if errors.Is(err, ErrSkipped) {
+		return fmt.Errorf("provider %s no longer has a destination for this notification", delivery.Provider)
+	}
+	return err
+}
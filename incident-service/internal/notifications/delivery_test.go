@@ -0,0 +1,234 @@
+package notifications
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// memoryDeliveryStore keeps notification deliveries in memory
+type memoryDeliveryStore struct {
+	deliveries []*models.NotificationDelivery
+}
+
+func (s *memoryDeliveryStore) CreateNotificationDelivery(delivery *models.NotificationDelivery) error {
+	delivery.ID = int64(len(s.deliveries) + 1)
+	s.deliveries = append(s.deliveries, delivery)
+	return nil
+}
+
+func (s *memoryDeliveryStore) UpdateNotificationDelivery(delivery *models.NotificationDelivery) error {
+	return nil
+}
+
+func (s *memoryDeliveryStore) ListDueNotificationDeliveries(before time.Time, limit int) ([]*models.NotificationDelivery, error) {
+	var due []*models.NotificationDelivery
+	for _, delivery := range s.deliveries {
+		if delivery.Status == models.DeliveryRetrying && !delivery.NextAttemptAt.After(before) {
+			due = append(due, delivery)
+		}
+	}
+	return due, nil
+}
+
+func TestDispatcher_RecordsAndRetriesDeliveries(t *testing.T) {
+	failures := 2
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= failures {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher(config.NotificationsConfig{
+		Webhooks: []config.WebhookConfig{{Name: "cmdb", URL: server.URL}},
+		Retry:    config.RetryConfig{MaxAttempts: 4, InitialBackoff: time.Minute},
+	})
+	store := &memoryDeliveryStore{}
+	dispatcher.SetStore(store)
+
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	dispatcher.now = func() time.Time { return now }
+
+	incident := &models.Incident{ID: "inc_1", ServiceName: "api-gateway"}
+	if err := dispatcher.Notify(context.Background(), models.EventIncidentReceived, incident); err == nil {
+		t.Fatal("expected first delivery to fail")
+	}
+
+	if len(store.deliveries) != 1 {
+		t.Fatalf("expected 1 recorded delivery, got %d", len(store.deliveries))
+	}
+	delivery := store.deliveries[0]
+	if delivery.Status != models.DeliveryRetrying || delivery.Attempts != 1 || *delivery.IncidentID != "inc_1" || delivery.Provider != "webhook:cmdb" {
+		t.Fatalf("unexpected delivery after first attempt: %+v", delivery)
+	}
+	if !delivery.NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("next attempt = %v, want %v", delivery.NextAttemptAt, now.Add(time.Minute))
+	}
+
+	// Not yet due
+	if err := dispatcher.RetryDue(context.Background()); err != nil {
+		t.Fatalf("RetryDue() error = %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected no retry before backoff elapsed, got %d requests", requests)
+	}
+
+	// Second attempt fails and doubles the backoff
+	now = now.Add(time.Minute)
+	if err := dispatcher.RetryDue(context.Background()); err != nil {
+		t.Fatalf("RetryDue() error = %v", err)
+	}
+	if delivery.Attempts != 2 || !delivery.NextAttemptAt.Equal(now.Add(2*time.Minute)) {
+		t.Fatalf("unexpected delivery after second attempt: %+v", delivery)
+	}
+
+	// Third attempt succeeds
+	now = now.Add(2 * time.Minute)
+	if err := dispatcher.RetryDue(context.Background()); err != nil {
+		t.Fatalf("RetryDue() error = %v", err)
+	}
+	if delivery.Status != models.DeliverySent || delivery.Attempts != 3 || delivery.DeliveredAt == nil || delivery.LastError != nil {
+		t.Errorf("unexpected delivery after successful retry: %+v", delivery)
+	}
+}
+
+func TestDispatcher_GivesUpAfterMaxAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher(config.NotificationsConfig{
+		Webhooks: []config.WebhookConfig{{Name: "cmdb", URL: server.URL}},
+		Retry:    config.RetryConfig{MaxAttempts: 2, InitialBackoff: time.Second},
+	})
+	store := &memoryDeliveryStore{}
+	dispatcher.SetStore(store)
+
+	now := time.Now()
+	dispatcher.now = func() time.Time { return now }
+
+	_ = dispatcher.Notify(context.Background(), models.EventIncidentReceived, &models.Incident{ID: "inc_2"})
+
+	now = now.Add(time.Minute)
+	if err := dispatcher.RetryDue(context.Background()); err != nil {
+		t.Fatalf("RetryDue() error = %v", err)
+	}
+
+	delivery := store.deliveries[0]
+	if delivery.Status != models.DeliveryFailed || delivery.Attempts != 2 || delivery.NextAttemptAt != nil || delivery.LastError == nil {
+		t.Errorf("expected delivery to be marked failed, got %+v", delivery)
+	}
+}
+
+func TestDispatcher_DoesNotRecordSkippedProviders(t *testing.T) {
+	dispatcher := NewDispatcher(config.NotificationsConfig{
+		Webhooks: []config.WebhookConfig{{Name: "ticketing", URL: "http://127.0.0.1:0", Events: []string{"incident_failed"}}},
+	})
+	store := &memoryDeliveryStore{}
+	dispatcher.SetStore(store)
+
+	if err := dispatcher.Notify(context.Background(), models.EventIncidentReceived, &models.Incident{ID: "inc_3"}); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if len(store.deliveries) != 0 {
+		t.Errorf("expected skipped provider not to be recorded, got %d deliveries", len(store.deliveries))
+	}
+}
+
+func TestDispatcher_SkipsSnoozedIncidents(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher(config.NotificationsConfig{
+		Webhooks: []config.WebhookConfig{{Name: "cmdb", URL: server.URL}},
+	})
+	store := &memoryDeliveryStore{}
+	dispatcher.SetStore(store)
+
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	dispatcher.now = func() time.Time { return now }
+
+	snoozedUntil := now.Add(time.Hour)
+	incident := &models.Incident{ID: "inc_1", ServiceName: "api-gateway", SnoozedUntil: &snoozedUntil}
+	if err := dispatcher.Notify(context.Background(), models.EventIncidentFailed, incident); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if requests != 0 || len(store.deliveries) != 0 {
+		t.Fatalf("expected nothing sent while snoozed, got %d requests", requests)
+	}
+
+	// Once the snooze expires, notifications flow again
+	dispatcher.now = func() time.Time { return snoozedUntil }
+	if err := dispatcher.Notify(context.Background(), models.EventIncidentFailed, incident); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request after the snooze expired, got %d", requests)
+	}
+}
+
+// countingMetrics counts delivery attempts by provider, event type and status
+type countingMetrics map[string]int
+
+func (m countingMetrics) RecordNotification(provider, eventType, status string) {
+	m[provider+" "+eventType+" "+status]++
+}
+
+func TestDispatcher_CountsDeliveryAttempts(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher(config.NotificationsConfig{
+		Webhooks: []config.WebhookConfig{{Name: "cmdb", URL: server.URL}},
+		Retry:    config.RetryConfig{MaxAttempts: 3, InitialBackoff: time.Minute},
+	})
+	dispatcher.SetStore(&memoryDeliveryStore{})
+	metrics := countingMetrics{}
+	dispatcher.SetMetrics(metrics)
+
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	dispatcher.now = func() time.Time { return now }
+
+	incident := &models.Incident{ID: "inc_1", ServiceName: "api-gateway"}
+	_ = dispatcher.Notify(context.Background(), models.EventIncidentReceived, incident)
+	now = now.Add(time.Minute)
+	if err := dispatcher.RetryDue(context.Background()); err != nil {
+		t.Fatalf("RetryDue() error = %v", err)
+	}
+
+	want := countingMetrics{
+		"webhook:cmdb incident_received error":   1,
+		"webhook:cmdb incident_received success": 1,
+	}
+	if len(metrics) != len(want) {
+		t.Fatalf("counted %v, want %v", metrics, want)
+	}
+	for key, count := range want {
+		if metrics[key] != count {
+			t.Errorf("counted %v, want %v", metrics, want)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// Dispatcher renders incident events and fans them out to notification providers
+type Dispatcher struct {
+	notifiers []Notifier
+	config    config.NotificationsConfig
+	templates *TemplateEngine
+	store     DeliveryStore // nil disables delivery tracking
+	metrics   Metrics       // nil disables delivery metrics
+	watchers  WatcherStore  // nil disables watcher notifications
+	teams     []config.Team
+	now       func() time.Time
+}
+
+// NewDispatcher creates a dispatcher with the providers enabled in configuration
+func NewDispatcher(cfg config.NotificationsConfig) *Dispatcher {
+	d := &Dispatcher{
+		notifiers: make([]Notifier, 0),
+		config:    cfg,
+		templates: NewTemplateEngine(cfg),
+		now:       time.Now,
+	}
+
+	if cfg.Slack.Enabled {
+		d.Register(NewSlackNotifier(cfg.Slack))
+	}
+	if cfg.Teams.Enabled {
+		d.Register(NewTeamsNotifier(cfg.Teams))
+	}
+	if cfg.Email.Enabled {
+		d.Register(NewEmailNotifier(cfg.Email))
+	}
+	if cfg.PagerDuty.Enabled {
+		d.Register(NewPagerDutyNotifier(cfg.PagerDuty))
+	}
+	for _, webhook := range cfg.Webhooks {
+		d.Register(NewWebhookNotifier(webhook))
+	}
+	if cfg.EventBus.Enabled {
+		d.Register(NewEventBusNotifier(cfg.EventBus))
+	}
+
+	return d
+}
+
+// Register adds a notification provider to the dispatcher
+func (d *Dispatcher) Register(notifier Notifier) {
+	d.notifiers = append(d.notifiers, notifier)
+}
+
+// Metrics counts notification delivery attempts
+type Metrics interface {
+	RecordNotification(provider, eventType, status string)
+}
+
+// SetMetrics sets where delivery attempts, first sends and retries alike,
+// are counted
+func (d *Dispatcher) SetMetrics(metrics Metrics) {
+	d.metrics = metrics
+}
+
+// SetTeams sets the teams that own services. An incident's team receives its
+// notifications when no route is configured for the service, and the team's
+// escalation contacts are added to its escalations.
+func (d *Dispatcher) SetTeams(teams []config.Team) {
+	d.teams = teams
+}
+
+// Enabled reports whether any notification provider is registered
+func (d *Dispatcher) Enabled() bool {
+	return len(d.notifiers) > 0
+}
+
+// Notify renders the event for the incident and sends it through every provider,
+// using the route configured for the incident's service. Snoozed incidents send
+// nothing.
+func (d *Dispatcher) Notify(ctx context.Context, eventType models.IncidentEventType, incident *models.Incident) error {
+	if incident.Snoozed(d.now()) {
+		return nil
+	}
+	return d.Send(ctx, &Notification{
+		EventType: eventType,
+		Incident:  incident,
+	})
+}
+
+// Send renders a notification and delivers it through every provider, then
+// to the incident's watchers. Events without a template carry no text, so only
+// structured providers such as webhooks deliver them. Provider failures are
+// collected and returned together so one failing provider does not block the
+// others.
+func (d *Dispatcher) Send(ctx context.Context, n *Notification) error {
+	if !d.Enabled() {
+		return nil
+	}
+
+	eventType, incident := n.EventType, n.Incident
+
+	data := newTemplateData(eventType, incident, d.config.DashboardURL)
+	data.Details = n.Details
+
+	bodies, err := d.templates.Render(&data)
+	if err != nil {
+		return err
+	}
+
+	route := n.Route
+	if route == nil {
+		route = d.config.RouteFor(incident.ServiceName)
+	}
+	if team := d.teamOf(incident); team != nil {
+		if route == nil {
+			route = team.Route()
+		}
+		if eventType == models.EventIncidentEscalated && len(team.EscalationContacts) > 0 {
+			route = withEscalationContacts(route, team.EscalationContacts)
+		}
+	}
+
+	msg := &Message{
+		EventType: eventType,
+		Incident:  incident,
+		Data:      data,
+		Subject:   data.Subject,
+		Text:      data.Text,
+		Bodies:    bodies,
+		Route:     route,
+	}
+
+	return errors.Join(d.deliver(ctx, msg), d.notifyWatchers(ctx, msg))
+}
+
+// teamOf returns the team owning the incident, if it is a configured team
+func (d *Dispatcher) teamOf(incident *models.Incident) *config.Team {
+	if incident == nil || incident.Team == "" {
+		return nil
+	}
+	for i := range d.teams {
+		if d.teams[i].Name == incident.Team {
+			return &d.teams[i]
+		}
+	}
+	return nil
+}
+
+// withEscalationContacts returns a copy of the route that also emails the
+// contacts, leaving the configured route untouched
+func withEscalationContacts(route *config.NotificationRoute, contacts []string) *config.NotificationRoute {
+	escalation := *route
+	recipients := make([]string, 0, len(route.EmailRecipients)+len(contacts))
+	seen := make(map[string]bool, cap(recipients))
+	for _, recipient := range append(append([]string{}, route.EmailRecipients...), contacts...) {
+		if !seen[recipient] {
+			seen[recipient] = true
+			recipients = append(recipients, recipient)
+		}
+	}
+	escalation.EmailRecipients = recipients
+	return &escalation
+}
+
+// SendDigest delivers a digest to the destinations in the route. The text is
+// wrapped in the chat templates for the digest event, or the default ones.
+func (d *Dispatcher) SendDigest(ctx context.Context, route *config.NotificationRoute, subject, text string) error {
+	if !d.Enabled() {
+		return nil
+	}
+
+	data := TemplateData{
+		EventType: EventDigest,
+		Subject:   subject,
+		Text:      text,
+		Color:     severityColor(""),
+	}
+
+	bodies, err := d.templates.Render(&data)
+	if err != nil {
+		return err
+	}
+
+	return d.deliver(ctx, &Message{
+		EventType: EventDigest,
+		Data:      data,
+		Subject:   subject,
+		Text:      data.Text,
+		Bodies:    bodies,
+		Route:     route,
+	})
+}
+
+// deliver sends a message through every provider, recording each delivery
+// and collecting failures
+func (d *Dispatcher) deliver(ctx context.Context, msg *Message) error {
+	var errs []error
+	for _, notifier := range d.notifiers {
+		if msg.Direct && !directProviders[notifier.Name()] {
+			continue
+		}
+		err := notifier.Send(ctx, msg)
+		if errors.Is(err, ErrSkipped) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", notifier.Name(), err))
+		}
+		d.count(notifier.Name(), msg.EventType, err)
+
+		if recordErr := d.record(msg, notifier.Name(), err); recordErr != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", notifier.Name(), recordErr))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// count reports a delivery attempt to the metrics, if set
+func (d *Dispatcher) count(provider string, eventType models.IncidentEventType, sendErr error) {
+	if d.metrics == nil {
+		return
+	}
+	status := "success"
+	if sendErr != nil {
+		status = "error"
+	}
+	d.metrics.RecordNotification(provider, string(eventType), status)
+}
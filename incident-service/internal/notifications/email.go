@@ -0,0 +1,197 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+// emailHTMLTemplate renders the HTML part of notification emails
+var emailHTMLTemplate = template.Must(template.New("email").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #222;">
+  <h2 style="margin-bottom: 4px;">{{.ServiceName}}: {{.EventType}}</h2>
+  <p style="margin-top: 0; color: #666;">Incident {{.IncidentID}} &middot; {{.Severity}} &middot; {{.Provider}}</p>
+  <p style="white-space: pre-wrap;">{{.Text}}</p>
+  <table cellpadding="4" style="border-collapse: collapse;">
+    <tr><td><strong>Status</strong></td><td>{{.Status}}</td></tr>
+    <tr><td><strong>Repository</strong></td><td>{{if .Repository}}{{.Repository}}{{else}}unmapped{{end}}</td></tr>
+    <tr><td><strong>Error</strong></td><td>{{.ErrorMessage}}</td></tr>
+    {{if .Diagnosis}}<tr><td><strong>Diagnosis</strong></td><td style="white-space: pre-wrap;">{{.Diagnosis}}</td></tr>{{end}}
+  </table>
+  <p>
+    {{if .IncidentURL}}<a href="{{.IncidentURL}}">View incident</a>{{end}}
+    {{if and .IncidentURL .PullRequestURL}} &middot; {{end}}
+    {{if .PullRequestURL}}<a href="{{.PullRequestURL}}">View pull request</a>{{end}}
+  </p>
+</body>
+</html>
+`))
+
+// digestHTMLTemplate renders the HTML part of digest emails
+var digestHTMLTemplate = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #222;">
+  <h2>{{.Subject}}</h2>
+  <pre style="font-family: Menlo, Consolas, monospace;">{{.Text}}</pre>
+</body>
+</html>
+`))
+
+// emailHTMLData is the data passed to the HTML email template
+type emailHTMLData struct {
+	TemplateData
+	Subject string
+	Text    string
+}
+
+// sendMailFunc matches smtp.SendMail so tests can capture outgoing mail
+type sendMailFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+// EmailNotifier sends notifications by email over SMTP
+type EmailNotifier struct {
+	config   config.EmailConfig
+	sendMail sendMailFunc
+}
+
+// NewEmailNotifier creates a new SMTP email notifier
+func NewEmailNotifier(cfg config.EmailConfig) *EmailNotifier {
+	return &EmailNotifier{
+		config:   cfg,
+		sendMail: smtp.SendMail,
+	}
+}
+
+// Name returns the provider name
+func (n *EmailNotifier) Name() string {
+	return "email"
+}
+
+// Send emails the message to the recipients for the incident's service and severity
+func (n *EmailNotifier) Send(ctx context.Context, msg *Message) error {
+	if msg.Text == "" {
+		return ErrSkipped
+	}
+
+	recipients := n.recipients(msg)
+	if len(recipients) == 0 {
+		return ErrSkipped
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	body, err := n.buildMessage(msg, recipients)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.SMTPHost)
+	}
+
+	if err := n.sendMail(n.config.SMTPAddr(), auth, n.config.From, recipients, body); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// recipients combines route (or default) recipients with severity recipients, without duplicates.
+// Direct messages only go to the route's recipients.
+func (n *EmailNotifier) recipients(msg *Message) []string {
+	if msg.Direct {
+		if msg.Route == nil {
+			return nil
+		}
+		return msg.Route.EmailRecipients
+	}
+
+	base := n.config.DefaultRecipients
+	if msg.Route != nil && len(msg.Route.EmailRecipients) > 0 {
+		base = msg.Route.EmailRecipients
+	}
+
+	seen := make(map[string]bool)
+	recipients := make([]string, 0, len(base))
+	for _, list := range [][]string{base, n.config.SeverityRecipients[msg.severity()]} {
+		for _, addr := range list {
+			if addr != "" && !seen[addr] {
+				seen[addr] = true
+				recipients = append(recipients, addr)
+			}
+		}
+	}
+
+	return recipients
+}
+
+// buildMessage assembles a multipart/alternative email with plain text and HTML parts
+func (n *EmailNotifier) buildMessage(msg *Message, recipients []string) ([]byte, error) {
+	tmpl := emailHTMLTemplate
+	if msg.Incident == nil {
+		tmpl = digestHTMLTemplate
+	}
+
+	var html bytes.Buffer
+	if err := tmpl.Execute(&html, emailHTMLData{TemplateData: msg.Data, Subject: msg.Subject, Text: msg.Text}); err != nil {
+		return nil, fmt.Errorf("failed to render email: %w", err)
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", writer.Boundary())
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", html.String()},
+	}
+
+	for _, part := range parts {
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create email part: %w", err)
+		}
+
+		// Quoted-printable keeps long stack traces within SMTP line length limits
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, fmt.Errorf("failed to write email part: %w", err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, fmt.Errorf("failed to write email part: %w", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize email: %w", err)
+	}
+
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
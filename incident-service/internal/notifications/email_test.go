@@ -0,0 +1,201 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/quotedprintable"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+type capturedMail struct {
+	addr string
+	from string
+	to   []string
+	body string
+}
+
+func newTestEmailNotifier(cfg config.EmailConfig, captured *[]capturedMail) *EmailNotifier {
+	notifier := NewEmailNotifier(cfg)
+	notifier.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		*captured = append(*captured, capturedMail{addr: addr, from: from, to: to, body: string(msg)})
+		return nil
+	}
+	return notifier
+}
+
+func TestEmailNotifier_RecipientsByServiceAndSeverity(t *testing.T) {
+	cfg := config.EmailConfig{
+		Enabled:           true,
+		SMTPHost:          "smtp.example.com",
+		From:              "sre@example.com",
+		DefaultRecipients: []string{"oncall@example.com"},
+		SeverityRecipients: map[string][]string{
+			"critical": {"managers@example.com", "payments@example.com"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		route    *config.NotificationRoute
+		severity string
+		expected []string
+	}{
+		{"default recipients", nil, "high", []string{"oncall@example.com"}},
+		{
+			"route recipients replace defaults",
+			&config.NotificationRoute{ServiceName: "payment-service", EmailRecipients: []string{"payments@example.com"}},
+			"high",
+			[]string{"payments@example.com"},
+		},
+		{
+			"severity recipients are added without duplicates",
+			&config.NotificationRoute{ServiceName: "payment-service", EmailRecipients: []string{"payments@example.com"}},
+			"critical",
+			[]string{"payments@example.com", "managers@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var captured []capturedMail
+			notifier := newTestEmailNotifier(cfg, &captured)
+
+			msg := &Message{
+				EventType: models.EventIncidentReceived,
+				Incident:  &models.Incident{ID: "inc_1", ServiceName: "payment-service", Severity: tt.severity},
+				Text:      "new incident",
+				Route:     tt.route,
+			}
+			if err := notifier.Send(context.Background(), msg); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+
+			if len(captured) != 1 {
+				t.Fatalf("expected 1 email, got %d", len(captured))
+			}
+			if strings.Join(captured[0].to, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("recipients = %v, want %v", captured[0].to, tt.expected)
+			}
+			if captured[0].addr != "smtp.example.com:587" {
+				t.Errorf("addr = %s, want smtp.example.com:587", captured[0].addr)
+			}
+		})
+	}
+}
+
+func TestDispatcher_EmailIncludesLinks(t *testing.T) {
+	var captured []capturedMail
+	dispatcher := NewDispatcher(config.NotificationsConfig{DashboardURL: "https://sre.example.com/"})
+	dispatcher.Register(newTestEmailNotifier(config.EmailConfig{
+		SMTPHost:          "smtp.example.com",
+		From:              "sre@example.com",
+		DefaultRecipients: []string{"oncall@example.com"},
+	}, &captured))
+
+	prURL := "https://github.com/org/api/pull/7"
+	incident := &models.Incident{
+		ID:             "inc_dd_42",
+		ServiceName:    "api-gateway",
+		Severity:       "high",
+		PullRequestURL: &prURL,
+	}
+
+	if err := dispatcher.Notify(context.Background(), models.EventPRCreated, incident); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if len(captured) != 1 {
+		t.Fatalf("expected 1 email, got %d", len(captured))
+	}
+
+	decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(captured[0].body)))
+	if err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	body := string(decoded)
+
+	for _, want := range []string{
+		"Subject: [HIGH] api-gateway: pr_created (inc_dd_42)",
+		`href="https://sre.example.com/incidents/inc_dd_42"`,
+		`href="https://github.com/org/api/pull/7"`,
+		"text/html; charset=utf-8",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected email to contain %q", want)
+		}
+	}
+}
+
+func TestEmailNotifier_NoRecipients(t *testing.T) {
+	var captured []capturedMail
+	notifier := newTestEmailNotifier(config.EmailConfig{SMTPHost: "smtp.example.com", From: "sre@example.com"}, &captured)
+
+	msg := &Message{
+		EventType: models.EventIncidentReceived,
+		Incident:  &models.Incident{ID: "inc_1", Severity: "low"},
+	}
+	if err := notifier.Send(context.Background(), msg); !errors.Is(err, ErrSkipped) {
+		t.Fatalf("Send() error = %v, want ErrSkipped", err)
+	}
+	if len(captured) != 0 {
+		t.Errorf("expected no email, got %d", len(captured))
+	}
+}
+
+func TestDispatcher_TeamRouting(t *testing.T) {
+	var captured []capturedMail
+	dispatcher := NewDispatcher(config.NotificationsConfig{
+		Routes: []config.NotificationRoute{
+			{ServiceName: "ledger", EmailRecipients: []string{"ledger@example.com"}},
+		},
+	})
+	dispatcher.Register(newTestEmailNotifier(config.EmailConfig{
+		SMTPHost:          "smtp.example.com",
+		From:              "sre@example.com",
+		DefaultRecipients: []string{"oncall@example.com"},
+	}, &captured))
+	dispatcher.SetTeams([]config.Team{{
+		Name:               "payments",
+		Owners:             []string{"payments@example.com"},
+		EscalationContacts: []string{"payments-lead@example.com", "payments@example.com"},
+	}})
+
+	tests := []struct {
+		name      string
+		incident  *models.Incident
+		eventType models.IncidentEventType
+		expected  []string
+	}{
+		{"team owners", &models.Incident{ID: "inc_1", ServiceName: "checkout", Team: "payments"}, models.EventIncidentReceived, []string{"payments@example.com"}},
+		{"service route wins", &models.Incident{ID: "inc_2", ServiceName: "ledger", Team: "payments"}, models.EventIncidentReceived, []string{"ledger@example.com"}},
+		{"no team", &models.Incident{ID: "inc_3", ServiceName: "search"}, models.EventIncidentReceived, []string{"oncall@example.com"}},
+		{"unknown team", &models.Incident{ID: "inc_4", ServiceName: "search", Team: "gone"}, models.EventIncidentReceived, []string{"oncall@example.com"}},
+		{"escalation contacts", &models.Incident{ID: "inc_5", ServiceName: "checkout", Team: "payments"}, models.EventIncidentEscalated, []string{"payments@example.com", "payments-lead@example.com"}},
+		{"escalation contacts added to service route", &models.Incident{ID: "inc_6", ServiceName: "ledger", Team: "payments"}, models.EventIncidentEscalated, []string{"ledger@example.com", "payments-lead@example.com", "payments@example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			captured = nil
+			if err := dispatcher.Notify(context.Background(), tt.eventType, tt.incident); err != nil {
+				t.Fatalf("Notify() error = %v", err)
+			}
+			if len(captured) != 1 {
+				t.Fatalf("expected 1 email, got %d", len(captured))
+			}
+			if strings.Join(captured[0].to, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("recipients = %v, want %v", captured[0].to, tt.expected)
+			}
+		})
+	}
+
+	// The configured route must not pick up escalation contacts
+	if recipients := dispatcher.config.Routes[0].EmailRecipients; len(recipients) != 1 {
+		t.Errorf("expected the service route to be left untouched, got %v", recipients)
+	}
+}
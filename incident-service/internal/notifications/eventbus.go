@@ -0,0 +1,74 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// publisher delivers one event to a message bus
+type publisher interface {
+	// publish sends payload under the event's subject or topic, keyed by
+	// incident so that a consumer sees an incident's events in order
+	publish(ctx context.Context, eventType models.IncidentEventType, key string, payload []byte) error
+}
+
+// EventBusNotifier publishes incident lifecycle events to NATS or Kafka, so
+// that other platform services can consume them without polling the API
+type EventBusNotifier struct {
+	driver    string
+	events    map[models.IncidentEventType]bool
+	publisher publisher
+}
+
+// EventBusPayload is the message published for each event. It matches the
+// outbound webhook payload, so that consumers can share a decoder.
+type EventBusPayload = WebhookPayload
+
+// NewEventBusNotifier creates a notifier for the configured event bus driver
+func NewEventBusNotifier(cfg config.EventBusConfig) *EventBusNotifier {
+	events := make(map[models.IncidentEventType]bool)
+	for _, event := range cfg.EventTypes() {
+		events[models.IncidentEventType(event)] = true
+	}
+
+	n := &EventBusNotifier{driver: cfg.Driver, events: events}
+	switch cfg.Driver {
+	case config.EventBusNATS:
+		n.publisher = newNATSPublisher(cfg.NATS)
+	case config.EventBusKafka:
+		n.publisher = newKafkaPublisher(cfg.Kafka)
+	}
+	return n
+}
+
+// Name returns the provider name
+func (n *EventBusNotifier) Name() string {
+	return "eventbus:" + n.driver
+}
+
+// Send publishes the event if it is one of the configured event types.
+// Digests are not lifecycle events and are never published.
+func (n *EventBusNotifier) Send(ctx context.Context, msg *Message) error {
+	if msg.Incident == nil || !n.events[msg.EventType] {
+		return ErrSkipped
+	}
+	if n.publisher == nil {
+		return fmt.Errorf("unsupported event bus driver %q", n.driver)
+	}
+
+	payload, err := json.Marshal(EventBusPayload{
+		EventType: msg.EventType,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Incident:  msg.Incident,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	return n.publisher.publish(ctx, msg.EventType, msg.Incident.ID, payload)
+}
@@ -0,0 +1,265 @@
+package notifications
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// natsMessage is a message received by fakeNATSServer
+type natsMessage struct {
+	subject string
+	payload []byte
+}
+
+// fakeNATSServer speaks enough of the NATS protocol to accept publishes
+type fakeNATSServer struct {
+	listener net.Listener
+	connects chan string
+	messages chan natsMessage
+	deny     string // subject to answer with a permissions violation
+}
+
+func newFakeNATSServer(t *testing.T) *fakeNATSServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := &fakeNATSServer{
+		listener: listener,
+		connects: make(chan string, 10),
+		messages: make(chan natsMessage, 10),
+	}
+	go s.serve()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *fakeNATSServer) url() string {
+	return "nats://" + s.listener.Addr().String()
+}
+
+func (s *fakeNATSServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeNATSServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	_, _ = conn.Write([]byte(`INFO {"server_id":"test","max_payload":1048576}` + "\r\n"))
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case strings.HasPrefix(line, "CONNECT "):
+			s.connects <- strings.TrimPrefix(line, "CONNECT ")
+		case line == "PING":
+			_, _ = conn.Write([]byte("PONG\r\n"))
+		case strings.HasPrefix(line, "PUB "):
+			fields := strings.Fields(line)
+			size, _ := strconv.Atoi(fields[len(fields)-1])
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+			if fields[1] == s.deny {
+				_, _ = conn.Write([]byte("-ERR 'Permissions Violation for Publish to \"" + fields[1] + "\"'\r\n"))
+				continue
+			}
+			s.messages <- natsMessage{subject: fields[1], payload: payload[:size]}
+		}
+	}
+}
+
+func TestEventBusNotifier_NATS(t *testing.T) {
+	server := newFakeNATSServer(t)
+	notifier := NewEventBusNotifier(config.EventBusConfig{
+		Enabled: true,
+		Driver:  config.EventBusNATS,
+		NATS:    config.NATSConfig{URL: server.url(), Subject: "platform.incidents", Token: "s3cret"},
+	})
+
+	incident := &models.Incident{ID: "inc_1", ServiceName: "api-gateway", Status: models.StatusPRCreated}
+	for _, eventType := range []models.IncidentEventType{models.EventIncidentReceived, models.EventPRCreated} {
+		if err := notifier.Send(context.Background(), &Message{EventType: eventType, Incident: incident}); err != nil {
+			t.Fatalf("Send(%s) error = %v", eventType, err)
+		}
+	}
+
+	// Both events are published over one authenticated connection
+	if len(server.connects) != 1 {
+		t.Fatalf("expected one connection, got %d", len(server.connects))
+	}
+	var options map[string]interface{}
+	if err := json.Unmarshal([]byte(<-server.connects), &options); err != nil {
+		t.Fatalf("failed to decode CONNECT options: %v", err)
+	}
+	if options["auth_token"] != "s3cret" || options["verbose"] != false {
+		t.Errorf("unexpected CONNECT options: %v", options)
+	}
+
+	first, second := <-server.messages, <-server.messages
+	if first.subject != "platform.incidents.incident_received" || second.subject != "platform.incidents.pr_created" {
+		t.Errorf("subjects = %s, %s", first.subject, second.subject)
+	}
+	var payload EventBusPayload
+	if err := json.Unmarshal(second.payload, &payload); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if payload.EventType != models.EventPRCreated || payload.Incident.ID != "inc_1" {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+
+	// A connection the client gave up on is replaced on the next publish
+	notifier.publisher.(*natsPublisher).conn.Close()
+	if err := notifier.Send(context.Background(), &Message{EventType: models.EventIncidentResolved, Incident: incident}); err != nil {
+		t.Fatalf("Send() after close error = %v", err)
+	}
+	if len(server.connects) != 1 {
+		t.Errorf("expected a new connection, got %d", len(server.connects))
+	}
+	if msg := <-server.messages; msg.subject != "platform.incidents.incident_resolved" {
+		t.Errorf("subject = %s", msg.subject)
+	}
+}
+
+func TestEventBusNotifier_NATSError(t *testing.T) {
+	server := newFakeNATSServer(t)
+	server.deny = "incidents.incident_failed"
+	notifier := NewEventBusNotifier(config.EventBusConfig{
+		Enabled: true,
+		Driver:  config.EventBusNATS,
+		NATS:    config.NATSConfig{URL: server.url()},
+	})
+
+	incident := &models.Incident{ID: "inc_1"}
+	err := notifier.Send(context.Background(), &Message{EventType: models.EventIncidentFailed, Incident: incident})
+	if err == nil || !strings.Contains(err.Error(), "Permissions Violation") {
+		t.Fatalf("expected the server error to be returned, got %v", err)
+	}
+
+	// The connection stays usable, and the error is not reported again
+	if err := notifier.Send(context.Background(), &Message{EventType: models.EventIncidentResolved, Incident: incident}); err != nil {
+		t.Fatalf("Send() after error = %v", err)
+	}
+	if msg := <-server.messages; msg.subject != "incidents.incident_resolved" {
+		t.Errorf("subject = %s", msg.subject)
+	}
+	if len(server.connects) != 1 {
+		t.Errorf("expected one connection, got %d", len(server.connects))
+	}
+}
+
+func TestEventBusNotifier_Kafka(t *testing.T) {
+	var (
+		path    string
+		headers http.Header
+		body    []byte
+	)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, headers = r.URL.Path, r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/vnd.kafka.v2+json")
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":42}]}`))
+	}))
+	defer proxy.Close()
+
+	notifier := NewEventBusNotifier(config.EventBusConfig{
+		Enabled: true,
+		Driver:  config.EventBusKafka,
+		Kafka: config.KafkaConfig{
+			RESTProxyURL: proxy.URL + "/",
+			Topic:        "incident-events",
+			Username:     "producer",
+			Password:     "s3cret",
+		},
+	})
+
+	incident := &models.Incident{ID: "inc_1", ServiceName: "api-gateway"}
+	if err := notifier.Send(context.Background(), &Message{EventType: models.EventWorkflowTriggered, Incident: incident}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if path != "/topics/incident-events" {
+		t.Errorf("path = %s", path)
+	}
+	if headers.Get("Content-Type") != kafkaContentType {
+		t.Errorf("Content-Type = %s", headers.Get("Content-Type"))
+	}
+	if user, pass, ok := (&http.Request{Header: headers}).BasicAuth(); !ok || user != "producer" || pass != "s3cret" {
+		t.Errorf("expected basic auth to be set")
+	}
+
+	var request struct {
+		Records []struct {
+			Key   string          `json:"key"`
+			Value EventBusPayload `json:"value"`
+		} `json:"records"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		t.Fatalf("failed to decode records: %v", err)
+	}
+	if len(request.Records) != 1 || request.Records[0].Key != "inc_1" {
+		t.Fatalf("unexpected records: %s", body)
+	}
+	if value := request.Records[0].Value; value.EventType != models.EventWorkflowTriggered || value.Incident.ID != "inc_1" {
+		t.Errorf("unexpected record value: %+v", value)
+	}
+}
+
+func TestEventBusNotifier_KafkaRecordError(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":null,"offset":null,"error_code":40403,"error":"Topic not found"}]}`))
+	}))
+	defer proxy.Close()
+
+	notifier := NewEventBusNotifier(config.EventBusConfig{
+		Enabled: true,
+		Driver:  config.EventBusKafka,
+		Kafka:   config.KafkaConfig{RESTProxyURL: proxy.URL, Topic: "missing"},
+	})
+
+	err := notifier.Send(context.Background(), &Message{EventType: models.EventPRCreated, Incident: &models.Incident{ID: "inc_1"}})
+	if err == nil || !strings.Contains(err.Error(), "Topic not found") {
+		t.Fatalf("expected the record error to be returned, got %v", err)
+	}
+}
+
+func TestEventBusNotifier_SkipsUnpublishedEvents(t *testing.T) {
+	notifier := NewEventBusNotifier(config.EventBusConfig{
+		Enabled: true,
+		Driver:  config.EventBusKafka,
+		Events:  []string{"pr_created"},
+		Kafka:   config.KafkaConfig{RESTProxyURL: "http://127.0.0.1:1", Topic: "incidents"},
+	})
+
+	for _, msg := range []*Message{
+		{EventType: models.EventIncidentReceived, Incident: &models.Incident{ID: "inc_1"}},
+		{EventType: models.EventPRCreated}, // digest
+	} {
+		if err := notifier.Send(context.Background(), msg); !errors.Is(err, ErrSkipped) {
+			t.Errorf("Send(%s) error = %v, want ErrSkipped", msg.EventType, err)
+		}
+	}
+}
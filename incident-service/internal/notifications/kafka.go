@@ -0,0 +1,91 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// kafkaContentType is the Kafka REST Proxy v2 content type for JSON records
+const kafkaContentType = "application/vnd.kafka.json.v2+json"
+
+// kafkaPublisher produces to a Kafka topic through a Kafka REST Proxy
+type kafkaPublisher struct {
+	config     config.KafkaConfig
+	httpClient *http.Client
+}
+
+func newKafkaPublisher(cfg config.KafkaConfig) *kafkaPublisher {
+	return &kafkaPublisher{config: cfg, httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// kafkaRecord is one record of a REST Proxy produce request
+type kafkaRecord struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// kafkaProduceResponse reports the outcome of each record of a produce request
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		Partition *int   `json:"partition"`
+		Offset    *int64 `json:"offset"`
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (p *kafkaPublisher) publish(ctx context.Context, eventType models.IncidentEventType, key string, payload []byte) error {
+	body, err := json.Marshal(map[string][]kafkaRecord{
+		"records": {{Key: key, Value: payload}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal kafka records: %w", err)
+	}
+
+	endpoint := strings.TrimSuffix(p.config.RESTProxyURL, "/") + "/topics/" + url.PathEscape(p.config.Topic)
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, value := range p.config.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", kafkaContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if p.config.Username != "" {
+		req.SetBasicAuth(p.config.Username, p.config.Password)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	// The proxy answers 200 even when the broker rejected a record
+	var produced kafkaProduceResponse
+	if err := json.Unmarshal(respBody, &produced); err != nil {
+		return fmt.Errorf("failed to decode produce response: %w", err)
+	}
+	for _, offset := range produced.Offsets {
+		if offset.ErrorCode != nil || offset.Error != "" {
+			return fmt.Errorf("kafka rejected %s event: %s", eventType, offset.Error)
+		}
+	}
+	return nil
+}
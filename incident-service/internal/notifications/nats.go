@@ -0,0 +1,108 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+const (
+	// natsTimeout bounds connecting, and a publish when the context has no
+	// deadline
+	natsTimeout = 10 * time.Second
+
+	// natsReconnectWait is the pause between attempts to reconnect to the
+	// server after the connection drops
+	natsReconnectWait = 2 * time.Second
+)
+
+// natsPublisher publishes to NATS over one connection, which the client
+// re-establishes when it drops. Each publish is flushed, and only counts as
+// delivered once the server has answered, so that errors such as a denied
+// subject are not lost.
+type natsPublisher struct {
+	config config.NATSConfig
+
+	mu   sync.Mutex
+	conn *nats.Conn
+}
+
+func newNATSPublisher(cfg config.NATSConfig) *natsPublisher {
+	return &natsPublisher{config: cfg}
+}
+
+// subject returns the subject of an event type, such as incidents.pr_created
+func (p *natsPublisher) subject(eventType models.IncidentEventType) string {
+	return p.config.SubjectPrefix() + "." + string(eventType)
+}
+
+func (p *natsPublisher) publish(ctx context.Context, eventType models.IncidentEventType, key string, payload []byte) error {
+	conn, err := p.connection()
+	if err != nil {
+		return err
+	}
+
+	timeout := natsTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	// The last error is kept on the connection, so only an error reported
+	// while this event was published belongs to it
+	before := conn.LastError()
+	if err := conn.Publish(p.subject(eventType), payload); err != nil {
+		return fmt.Errorf("failed to publish: %w", err)
+	}
+	if err := conn.FlushTimeout(timeout); err != nil {
+		return fmt.Errorf("failed to flush publish: %w", err)
+	}
+	if err := conn.LastError(); err != nil && err != before {
+		return fmt.Errorf("nats error: %w", err)
+	}
+	return nil
+}
+
+// connection returns the connection to the server, connecting on first use
+// and after the client has given up on a connection
+func (p *natsPublisher) connection() (*nats.Conn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn != nil && !p.conn.IsClosed() {
+		return p.conn, nil
+	}
+
+	options := []nats.Option{
+		nats.Name("incident-service"),
+		nats.Timeout(natsTimeout),
+		nats.MaxReconnects(-1),
+		nats.ReconnectWait(natsReconnectWait),
+		// Publishes fail while reconnecting instead of being buffered, so
+		// that the delivery is retried rather than possibly sent twice
+		nats.ReconnectBufSize(-1),
+		// Errors are returned from publish instead of logged by the client
+		nats.ErrorHandler(func(*nats.Conn, *nats.Subscription, error) {}),
+	}
+	if p.config.Token != "" {
+		options = append(options, nats.Token(p.config.Token))
+	}
+	if p.config.Username != "" {
+		options = append(options, nats.UserInfo(p.config.Username, p.config.Password))
+	}
+	if p.config.CredentialsFile != "" {
+		options = append(options, nats.UserCredentials(p.config.CredentialsFile))
+	}
+
+	conn, err := nats.Connect(p.config.URL, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+	p.conn = conn
+	return conn, nil
+}
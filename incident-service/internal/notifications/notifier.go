@@ -0,0 +1,54 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// ErrSkipped is returned by providers that have nothing to send for a message,
+// for example because the route has no destination for the provider
+var ErrSkipped = errors.New("notification skipped")
+
+// Notifier defines the interface for notification providers
+type Notifier interface {
+	// Send delivers a rendered message. Providers without a destination
+	// for the message's route return ErrSkipped without sending anything.
+	Send(ctx context.Context, msg *Message) error
+
+	// Name returns the name of the notification provider
+	Name() string
+}
+
+// Notification describes an incident event to be rendered and delivered
+type Notification struct {
+	EventType models.IncidentEventType
+	Incident  *models.Incident
+	Details   map[string]interface{}    // extra event context exposed to templates
+	Route     *config.NotificationRoute // overrides the service route when set
+}
+
+// EventDigest is the event type of scheduled digest messages, which are not tied to an incident
+const EventDigest models.IncidentEventType = "digest"
+
+// Message represents a rendered notification ready for delivery
+type Message struct {
+	EventType models.IncidentEventType
+	Incident  *models.Incident // nil for digests
+	Data      TemplateData
+	Subject   string
+	Text      string
+	Bodies    map[string][]byte         // rendered chat payloads keyed by template format
+	Route     *config.NotificationRoute // nil when the service has no explicit route
+	Direct    bool                      // for one person: only the route's email and Slack destinations receive it
+}
+
+// severity returns the incident severity, or an empty string for digests
+func (m *Message) severity() string {
+	if m.Incident == nil {
+		return ""
+	}
+	return m.Incident.Severity
+}
@@ -0,0 +1,162 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// PagerDutyNotifier mirrors incidents from other providers into PagerDuty via the
+// Events API v2, so on-call paging follows automated remediation state
+type PagerDutyNotifier struct {
+	eventsAPIURL string
+	routingKey   string
+	httpClient   *http.Client
+}
+
+// pagerDutyEvent represents an Events API v2 request body
+type pagerDutyEvent struct {
+	RoutingKey  string                `json:"routing_key"`
+	EventAction string                `json:"event_action"`
+	DedupKey    string                `json:"dedup_key"`
+	Payload     *pagerDutyEventDetail `json:"payload,omitempty"`
+	Links       []pagerDutyLink       `json:"links,omitempty"`
+}
+
+// pagerDutyEventDetail represents the payload of a trigger event
+type pagerDutyEventDetail struct {
+	Summary       string                 `json:"summary"`
+	Source        string                 `json:"source"`
+	Severity      string                 `json:"severity"`
+	CustomDetails map[string]interface{} `json:"custom_details,omitempty"`
+}
+
+// pagerDutyLink represents a link attached to a trigger event
+type pagerDutyLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+// NewPagerDutyNotifier creates a new PagerDuty sync notifier
+func NewPagerDutyNotifier(cfg config.PagerDutySyncConfig) *PagerDutyNotifier {
+	eventsAPIURL := cfg.EventsAPIURL
+	if eventsAPIURL == "" {
+		eventsAPIURL = "https://events.pagerduty.com/v2/enqueue"
+	}
+
+	return &PagerDutyNotifier{
+		eventsAPIURL: eventsAPIURL,
+		routingKey:   cfg.RoutingKey,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the provider name
+func (n *PagerDutyNotifier) Name() string {
+	return "pagerduty"
+}
+
+// Send triggers a PagerDuty incident when an incident is received and resolves it
+// once remediation has done its part. Incidents that originated in PagerDuty are
+// skipped.
+func (n *PagerDutyNotifier) Send(ctx context.Context, msg *Message) error {
+	if msg.Incident == nil || msg.Incident.Provider == "pagerduty" {
+		return ErrSkipped
+	}
+
+	routingKey := n.routingKey
+	if msg.Route != nil && msg.Route.PagerDutyRoutingKey != "" {
+		routingKey = msg.Route.PagerDutyRoutingKey
+	}
+	if routingKey == "" {
+		return ErrSkipped
+	}
+
+	event := pagerDutyEvent{
+		RoutingKey: routingKey,
+		// Our incident ID is stable, so PagerDuty correlates trigger and resolve by it
+		DedupKey: msg.Incident.ID,
+	}
+
+	switch {
+	case msg.EventType == models.EventIncidentReceived:
+		event.EventAction = "trigger"
+		event.Payload = &pagerDutyEventDetail{
+			Summary:  fmt.Sprintf("[%s] %s", msg.Incident.ServiceName, msg.Incident.ErrorMessage),
+			Source:   msg.Incident.ServiceName,
+			Severity: mapPagerDutyEventSeverity(msg.Incident.Severity),
+			CustomDetails: map[string]interface{}{
+				"incident_id": msg.Incident.ID,
+				"provider":    msg.Incident.Provider,
+				"repository":  msg.Incident.Repository,
+			},
+		}
+		if msg.Data.IncidentURL != "" {
+			event.Links = []pagerDutyLink{{Href: msg.Data.IncidentURL, Text: "View incident"}}
+		}
+	case isResolution(msg):
+		event.EventAction = "resolve"
+	default:
+		return ErrSkipped
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pagerduty event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", n.eventsAPIURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// isResolution reports whether the message marks the end of our handling of
+// the incident: a fix is up for review, none was needed, or the incident
+// resolved. A failed remediation is not one, as a person has to take over.
+func isResolution(msg *Message) bool {
+	switch msg.EventType {
+	case models.EventPRCreated, models.EventIncidentResolved:
+		return true
+	case models.EventStatusChanged:
+		return msg.Incident.Status == models.StatusNoFixNeeded
+	default:
+		return false
+	}
+}
+
+// mapPagerDutyEventSeverity maps internal severity to Events API severity
+func mapPagerDutyEventSeverity(severity string) string {
+	switch severity {
+	case "critical":
+		return "critical"
+	case "high":
+		return "error"
+	case "medium":
+		return "warning"
+	default:
+		return "info"
+	}
+}
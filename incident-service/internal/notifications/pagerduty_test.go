@@ -0,0 +1,140 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestPagerDutyNotifier_TriggerAndResolve(t *testing.T) {
+	var events []pagerDutyEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event pagerDutyEvent
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		events = append(events, event)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher(config.NotificationsConfig{
+		PagerDuty: config.PagerDutySyncConfig{
+			Enabled:      true,
+			EventsAPIURL: server.URL,
+			RoutingKey:   "default-key",
+		},
+		Routes: []config.NotificationRoute{
+			{ServiceName: "payment-service", PagerDutyRoutingKey: "payments-key"},
+		},
+	})
+
+	incident := &models.Incident{
+		ID:           "inc_sentry_1",
+		ServiceName:  "payment-service",
+		ErrorMessage: "card declined handler panicked",
+		Severity:     "high",
+		Provider:     "sentry",
+	}
+
+	// The notifications the service sends as the workflow runs and opens a
+	// pull request
+	steps := []struct {
+		eventType models.IncidentEventType
+		status    models.IncidentStatus
+	}{
+		{models.EventIncidentReceived, models.StatusPending},
+		{models.EventWorkflowTriggered, models.StatusWorkflowTriggered}, // not mirrored
+		{models.EventPRCreated, models.StatusPRCreated},
+	}
+	for _, step := range steps {
+		incident.Status = step.status
+		if err := dispatcher.Notify(context.Background(), step.eventType, incident); err != nil {
+			t.Fatalf("Notify(%s) error = %v", step.eventType, err)
+		}
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 pagerduty events, got %d", len(events))
+	}
+
+	trigger, resolve := events[0], events[1]
+	if trigger.EventAction != "trigger" || trigger.RoutingKey != "payments-key" || trigger.DedupKey != incident.ID {
+		t.Errorf("unexpected trigger event: %+v", trigger)
+	}
+	if trigger.Payload == nil || trigger.Payload.Severity != "error" || trigger.Payload.Source != "payment-service" {
+		t.Errorf("unexpected trigger payload: %+v", trigger.Payload)
+	}
+	if resolve.EventAction != "resolve" || resolve.DedupKey != incident.ID {
+		t.Errorf("unexpected resolve event: %+v", resolve)
+	}
+}
+
+func TestPagerDutyNotifier_ResolvesOnOutcome(t *testing.T) {
+	tests := []struct {
+		name        string
+		eventType   models.IncidentEventType
+		status      models.IncidentStatus
+		wantResolve bool
+	}{
+		{"pull request opened", models.EventPRCreated, models.StatusPRCreated, true},
+		{"no fix needed", models.EventStatusChanged, models.StatusNoFixNeeded, true},
+		{"incident resolved", models.EventIncidentResolved, models.StatusResolved, true},
+		{"remediation failed", models.EventIncidentFailed, models.StatusFailed, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actions []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var event pagerDutyEvent
+				_ = json.NewDecoder(r.Body).Decode(&event)
+				actions = append(actions, event.EventAction)
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer server.Close()
+
+			dispatcher := NewDispatcher(config.NotificationsConfig{
+				PagerDuty: config.PagerDutySyncConfig{Enabled: true, EventsAPIURL: server.URL, RoutingKey: "key"},
+			})
+			incident := &models.Incident{ID: "inc_datadog_1", ServiceName: "checkout", Provider: "datadog", Status: models.StatusPending}
+			if err := dispatcher.Notify(context.Background(), models.EventIncidentReceived, incident); err != nil {
+				t.Fatalf("Notify(%s) error = %v", models.EventIncidentReceived, err)
+			}
+			incident.Status = tt.status
+			if err := dispatcher.Notify(context.Background(), tt.eventType, incident); err != nil {
+				t.Fatalf("Notify(%s) error = %v", tt.eventType, err)
+			}
+
+			resolved := len(actions) == 2 && actions[1] == "resolve"
+			if len(actions) == 0 || actions[0] != "trigger" || resolved != tt.wantResolve {
+				t.Errorf("expected trigger and resolve %v, got %v", tt.wantResolve, actions)
+			}
+		})
+	}
+}
+
+func TestPagerDutyNotifier_SkipsPagerDutyIncidents(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	notifier := NewPagerDutyNotifier(config.PagerDutySyncConfig{EventsAPIURL: server.URL, RoutingKey: "key"})
+	msg := &Message{
+		EventType: models.EventIncidentReceived,
+		Incident:  &models.Incident{ID: "inc_pd_1", Provider: "pagerduty"},
+	}
+	if err := notifier.Send(context.Background(), msg); !errors.Is(err, ErrSkipped) {
+		t.Fatalf("Send() error = %v, want ErrSkipped", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no calls for pagerduty-originated incident, got %d", calls)
+	}
+}
@@ -0,0 +1,113 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+// SlackNotifier posts notifications to Slack channels via the Web API
+type SlackNotifier struct {
+	apiURL         string
+	token          string
+	defaultChannel string
+	httpClient     *http.Client
+}
+
+// slackResponse represents the chat.postMessage response body
+type slackResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// NewSlackNotifier creates a new Slack notifier
+func NewSlackNotifier(cfg config.SlackConfig) *SlackNotifier {
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = "https://slack.com/api"
+	}
+
+	return &SlackNotifier{
+		apiURL:         apiURL,
+		token:          cfg.Token,
+		defaultChannel: cfg.DefaultChannel,
+		httpClient:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the provider name
+func (n *SlackNotifier) Name() string {
+	return "slack"
+}
+
+// Send posts the rendered Block Kit payload, or the plain text, to the service's
+// routed channel or the default channel. Direct messages go to the routed
+// member only.
+func (n *SlackNotifier) Send(ctx context.Context, msg *Message) error {
+	blocks, hasBlocks := msg.Bodies[FormatSlack]
+	if msg.Text == "" && !hasBlocks {
+		return ErrSkipped
+	}
+
+	channel := n.defaultChannel
+	if msg.Direct {
+		// Direct messages never fall back to the default channel
+		channel = ""
+	}
+	if msg.Route != nil && msg.Route.SlackChannel != "" {
+		channel = msg.Route.SlackChannel
+	}
+	if channel == "" {
+		return ErrSkipped
+	}
+
+	payload := make(map[string]interface{})
+	if hasBlocks {
+		if err := json.Unmarshal(blocks, &payload); err != nil {
+			return fmt.Errorf("invalid slack payload: %w", err)
+		}
+	}
+	if _, ok := payload["text"]; !ok {
+		payload["text"] = msg.Text
+	}
+	payload["channel"] = channel
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", n.apiURL+"/chat.postMessage", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+n.token)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	// Slack reports API errors with a 200 status and ok=false
+	var result slackResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode slack response: %w", err)
+	}
+	if !result.OK {
+		return fmt.Errorf("slack API error: %s", result.Error)
+	}
+
+	return nil
+}
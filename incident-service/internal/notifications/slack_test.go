@@ -0,0 +1,173 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// slackMessage represents the chat.postMessage request fields checked by tests
+type slackMessage struct {
+	Channel string            `json:"channel"`
+	Text    string            `json:"text"`
+	Blocks  []json.RawMessage `json:"blocks"`
+}
+
+// newSlackTestServer returns a server that records chat.postMessage requests
+func newSlackTestServer(t *testing.T, received *[]slackMessage) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat.postMessage" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer xoxb-test" {
+			t.Errorf("unexpected authorization header %q", r.Header.Get("Authorization"))
+		}
+
+		var msg slackMessage
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+		*received = append(*received, msg)
+
+		_ = json.NewEncoder(w).Encode(slackResponse{OK: true})
+	}))
+}
+
+func TestDispatcher_SlackRouting(t *testing.T) {
+	var received []slackMessage
+	server := newSlackTestServer(t, &received)
+	defer server.Close()
+
+	dispatcher := NewDispatcher(config.NotificationsConfig{
+		Slack: config.SlackConfig{
+			Enabled:        true,
+			APIURL:         server.URL,
+			Token:          "xoxb-test",
+			DefaultChannel: "#incidents",
+		},
+		Routes: []config.NotificationRoute{
+			{ServiceName: "payment-service", SlackChannel: "#payments"},
+		},
+	})
+
+	tests := []struct {
+		name            string
+		serviceName     string
+		expectedChannel string
+	}{
+		{"routed service", "payment-service", "#payments"},
+		{"unrouted service uses default channel", "user-service", "#incidents"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+			incident := &models.Incident{
+				ID:           "inc_test_1",
+				ServiceName:  tt.serviceName,
+				ErrorMessage: "connection refused",
+				Severity:     "high",
+				Provider:     "datadog",
+			}
+
+			if err := dispatcher.Notify(context.Background(), models.EventIncidentReceived, incident); err != nil {
+				t.Fatalf("Notify() error = %v", err)
+			}
+
+			if len(received) != 1 {
+				t.Fatalf("expected 1 slack message, got %d", len(received))
+			}
+			if received[0].Channel != tt.expectedChannel {
+				t.Errorf("channel = %s, want %s", received[0].Channel, tt.expectedChannel)
+			}
+			if !strings.Contains(received[0].Text, "connection refused") {
+				t.Errorf("expected message to contain error, got %q", received[0].Text)
+			}
+			if len(received[0].Blocks) == 0 {
+				t.Error("expected message to be rendered as blocks")
+			}
+		})
+	}
+}
+
+func TestDispatcher_TemplateOverride(t *testing.T) {
+	var received []slackMessage
+	server := newSlackTestServer(t, &received)
+	defer server.Close()
+
+	dispatcher := NewDispatcher(config.NotificationsConfig{
+		Slack: config.SlackConfig{
+			Enabled:        true,
+			APIURL:         server.URL,
+			Token:          "xoxb-test",
+			DefaultChannel: "#incidents",
+		},
+		Templates: map[string]string{
+			"pr_created": "PR for {{.IncidentID}}: {{.PullRequestURL}}",
+		},
+	})
+
+	prURL := "https://github.com/org/repo/pull/1"
+	incident := &models.Incident{
+		ID:             "inc_test_2",
+		ServiceName:    "api-gateway",
+		PullRequestURL: &prURL,
+	}
+
+	if err := dispatcher.Notify(context.Background(), models.EventPRCreated, incident); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("expected 1 slack message, got %d", len(received))
+	}
+	if received[0].Text != "PR for inc_test_2: "+prURL {
+		t.Errorf("text = %q", received[0].Text)
+	}
+}
+
+func TestDispatcher_IgnoresEventsWithoutTemplate(t *testing.T) {
+	var received []slackMessage
+	server := newSlackTestServer(t, &received)
+	defer server.Close()
+
+	dispatcher := NewDispatcher(config.NotificationsConfig{
+		Slack: config.SlackConfig{
+			Enabled:        true,
+			APIURL:         server.URL,
+			Token:          "xoxb-test",
+			DefaultChannel: "#incidents",
+		},
+	})
+
+	incident := &models.Incident{ID: "inc_test_3", ServiceName: "api-gateway"}
+	if err := dispatcher.Notify(context.Background(), models.EventStatusChanged, incident); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if len(received) != 0 {
+		t.Errorf("expected no slack messages, got %d", len(received))
+	}
+}
+
+func TestSlackNotifier_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(slackResponse{OK: false, Error: "channel_not_found"})
+	}))
+	defer server.Close()
+
+	notifier := NewSlackNotifier(config.SlackConfig{
+		APIURL:         server.URL,
+		Token:          "xoxb-test",
+		DefaultChannel: "#missing",
+	})
+
+	err := notifier.Send(context.Background(), &Message{Text: "hello"})
+	if err == nil || !strings.Contains(err.Error(), "channel_not_found") {
+		t.Errorf("expected channel_not_found error, got %v", err)
+	}
+}
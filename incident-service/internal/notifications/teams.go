@@ -0,0 +1,91 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+// TeamsNotifier posts notifications to Microsoft Teams incoming webhooks
+type TeamsNotifier struct {
+	defaultWebhookURL string
+	httpClient        *http.Client
+}
+
+// teamsMessageCard represents a Teams connector MessageCard payload
+type teamsMessageCard struct {
+	Type       string `json:"@type"`
+	Context    string `json:"@context"`
+	Summary    string `json:"summary"`
+	ThemeColor string `json:"themeColor,omitempty"`
+	Text       string `json:"text"`
+}
+
+// NewTeamsNotifier creates a new Microsoft Teams notifier
+func NewTeamsNotifier(cfg config.TeamsConfig) *TeamsNotifier {
+	return &TeamsNotifier{
+		defaultWebhookURL: cfg.DefaultWebhookURL,
+		httpClient:        &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the provider name
+func (n *TeamsNotifier) Name() string {
+	return "teams"
+}
+
+// Send posts the rendered card, or a plain card with the text, to the service's
+// routed webhook or the default webhook
+func (n *TeamsNotifier) Send(ctx context.Context, msg *Message) error {
+	body, hasCard := msg.Bodies[FormatTeams]
+	if msg.Text == "" && !hasCard {
+		return ErrSkipped
+	}
+
+	webhookURL := n.defaultWebhookURL
+	if msg.Route != nil && msg.Route.TeamsWebhookURL != "" {
+		webhookURL = msg.Route.TeamsWebhookURL
+	}
+	if webhookURL == "" {
+		return ErrSkipped
+	}
+
+	if !hasCard {
+		var err error
+		body, err = json.Marshal(teamsMessageCard{
+			Type:    "MessageCard",
+			Context: "http://schema.org/extensions",
+			Summary: msg.Subject,
+			Text:    msg.Text,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal teams message: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestDispatcher_TeamsRouting(t *testing.T) {
+	received := make(map[string]teamsMessageCard)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var card teamsMessageCard
+		_ = json.NewDecoder(r.Body).Decode(&card)
+		received[r.URL.Path] = card
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher(config.NotificationsConfig{
+		Teams: config.TeamsConfig{
+			Enabled:           true,
+			DefaultWebhookURL: server.URL + "/default",
+		},
+		Routes: []config.NotificationRoute{
+			{ServiceName: "payment-service", TeamsWebhookURL: server.URL + "/payments"},
+		},
+	})
+
+	for _, serviceName := range []string{"payment-service", "user-service"} {
+		incident := &models.Incident{
+			ID:           "inc_" + serviceName,
+			ServiceName:  serviceName,
+			ErrorMessage: "timeout",
+			Severity:     "critical",
+			Provider:     "sentry",
+		}
+		if err := dispatcher.Notify(context.Background(), models.EventIncidentReceived, incident); err != nil {
+			t.Fatalf("Notify() error = %v", err)
+		}
+	}
+
+	payments, ok := received["/payments"]
+	if !ok {
+		t.Fatal("expected message on routed webhook")
+	}
+	if payments.Type != "MessageCard" || payments.ThemeColor != "D32F2F" {
+		t.Errorf("unexpected card: %+v", payments)
+	}
+	if !strings.Contains(payments.Text, "inc_payment-service") {
+		t.Errorf("expected card text to reference incident, got %q", payments.Text)
+	}
+
+	if _, ok := received["/default"]; !ok {
+		t.Error("expected message on default webhook")
+	}
+}
+
+func TestTeamsNotifier_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	notifier := NewTeamsNotifier(config.TeamsConfig{DefaultWebhookURL: server.URL})
+	err := notifier.Send(context.Background(), &Message{
+		EventType: models.EventIncidentFailed,
+		Incident:  &models.Incident{ID: "inc_1"},
+		Text:      "failed",
+	})
+	if err == nil {
+		t.Error("expected error for non-2xx response")
+	}
+}
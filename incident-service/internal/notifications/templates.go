@@ -0,0 +1,221 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// Template formats. Text is used by every provider; chat formats are the
+// payloads posted by the matching provider.
+const (
+	FormatText  = "text"
+	FormatSlack = "slack"
+	FormatTeams = "teams"
+)
+
+// defaultTemplateKey selects the chat template used for events without their own
+const defaultTemplateKey = "default"
+
+// defaultTextTemplates are used for events that have no text template configured.
+// Events without a text template are only delivered by structured providers.
+var defaultTextTemplates = map[string]string{
+	string(models.EventIncidentReceived):  "New {{.Severity}} incident in {{.ServiceName}} ({{.Provider}}): {{.ErrorMessage}}\nIncident: {{.IncidentID}}",
+	string(models.EventPRCreated):         "Remediation PR created for {{.ServiceName}} incident {{.IncidentID}}: {{.PullRequestURL}}",
+	string(models.EventIncidentFailed):    "Automated remediation failed for {{.ServiceName}} incident {{.IncidentID}}{{if .Diagnosis}}\nDiagnosis: {{.Diagnosis}}{{end}}",
+	string(models.EventIncidentEscalated): "Escalation ({{.Details.level}}): {{.ServiceName}} incident {{.IncidentID}} needs attention - {{.Details.reason}}\nStatus: {{.Status}}, error: {{.ErrorMessage}}",
+	string(models.EventSLABreached):       "SLA breached: {{.Severity}} incident {{.IncidentID}} in {{.ServiceName}} exceeded {{.Details.timer}} of {{.Details.target}} ({{.Details.elapsed}} elapsed)\nStatus: {{.Status}}",
+	string(models.EventStormDetected):     "Alert storm in {{.ServiceName}}: {{.ErrorMessage}}\nFurther incidents are grouped under {{.IncidentID}} until volume returns to normal",
+	string(models.EventIncidentReopened):  "Resolved {{.ServiceName}} incident {{.IncidentID}} recurred and was reopened: {{.ErrorMessage}}",
+	string(models.EventStormReleased):     "Alert storm in {{.ServiceName}} is over after {{.Details.duration}}: {{.Details.suppressed}} incidents were grouped under {{.IncidentID}}",
+	string(models.EventServiceUnmapped):   "{{.ServiceName}} is not mapped to a repository, so its {{.Severity}} incident {{.IncidentID}} is held until it is: {{.ErrorMessage}}\nMap it with POST /api/v1/services/{{.ServiceName}}/mapping",
+	string(models.EventQuotaExceeded):     "{{.Provider}} exceeded its hourly incident quota with {{.ServiceName}} incident {{.IncidentID}}: {{.ErrorMessage}}\nFurther {{.Provider}} incidents this hour are recorded but not remediated automatically",
+	string(models.EventRemediationCapped): "{{.ServiceName}} reached its daily remediation cap with incident {{.IncidentID}}: {{.ErrorMessage}}\nFurther {{.ServiceName}} incidents today need manual attention and are not remediated automatically",
+}
+
+// defaultSlackTemplate renders the text as a Block Kit message with incident context and links
+const defaultSlackTemplate = `{
+  "text": {{json .Text}},
+  "blocks": [
+    {"type": "section", "text": {"type": "mrkdwn", "text": {{json (truncate 2900 .Text)}}}}
+    {{- if .IncidentID}},
+    {"type": "context", "elements": [{"type": "mrkdwn", "text": {{json (printf "*%s* | %s | %s" (upper .Severity) .ServiceName .IncidentID)}}}]}
+    {{- end}}
+    {{- if or .IncidentURL .PullRequestURL}},
+    {"type": "actions", "elements": [
+      {{- if .IncidentURL}}{"type": "button", "text": {"type": "plain_text", "text": "View incident"}, "url": {{json .IncidentURL}}}{{end}}
+      {{- if and .IncidentURL .PullRequestURL}}, {{end}}
+      {{- if .PullRequestURL}}{"type": "button", "text": {"type": "plain_text", "text": "View pull request"}, "url": {{json .PullRequestURL}}}{{end -}}
+    ]}
+    {{- end}}
+  ]
+}`
+
+// defaultTeamsTemplate renders the text as a connector MessageCard colored by severity.
+// Teams renders single newlines as spaces, so newlines become markdown paragraph breaks.
+const defaultTeamsTemplate = `{
+  "@type": "MessageCard",
+  "@context": "http://schema.org/extensions",
+  "summary": {{json .Subject}},
+  "themeColor": {{json .Color}},
+  "text": {{json (replace .Text "\n" "\n\n")}}
+  {{- if or .IncidentURL .PullRequestURL}},
+  "potentialAction": [
+    {{- if .IncidentURL}}{"@type": "OpenUri", "name": "View incident", "targets": [{"os": "default", "uri": {{json .IncidentURL}}}]}{{end}}
+    {{- if and .IncidentURL .PullRequestURL}}, {{end}}
+    {{- if .PullRequestURL}}{"@type": "OpenUri", "name": "View pull request", "targets": [{"os": "default", "uri": {{json .PullRequestURL}}}]}{{end -}}
+  ]
+  {{- end}}
+}`
+
+// TemplateData is the data available to notification templates
+type TemplateData struct {
+	EventType      models.IncidentEventType
+	IncidentID     string
+	ServiceName    string
+	Repository     string
+	Severity       string
+	Status         models.IncidentStatus
+	Provider       string
+	ErrorMessage   string
+	PullRequestURL string
+	Diagnosis      string
+	IncidentURL    string
+	CreatedAt      time.Time
+	Details        map[string]interface{}
+	Subject        string
+	Text           string // rendered text, available to chat templates
+	Color          string // hex accent color for the severity
+}
+
+// TemplateEngine renders notifications per event type and format, with
+// configured templates overriding the built-in ones
+type TemplateEngine struct {
+	templates map[string]map[string]*template.Template
+}
+
+// NewTemplateEngine creates a template engine from the built-in and configured templates
+func NewTemplateEngine(cfg config.NotificationsConfig) *TemplateEngine {
+	e := &TemplateEngine{
+		templates: map[string]map[string]*template.Template{
+			FormatText:  {},
+			FormatSlack: {},
+			FormatTeams: {},
+		},
+	}
+
+	for event, text := range defaultTextTemplates {
+		e.templates[FormatText][event] = template.Must(newTemplate(event).Parse(text))
+	}
+	e.templates[FormatSlack][defaultTemplateKey] = template.Must(newTemplate("slack").Parse(defaultSlackTemplate))
+	e.templates[FormatTeams][defaultTemplateKey] = template.Must(newTemplate("teams").Parse(defaultTeamsTemplate))
+
+	// Configured templates override the defaults (syntax is checked by config validation)
+	overrides := map[string]map[string]string{
+		FormatText:  cfg.Templates,
+		FormatSlack: cfg.SlackTemplates,
+		FormatTeams: cfg.TeamsTemplates,
+	}
+	for format, templates := range overrides {
+		for event, text := range templates {
+			tmpl, err := newTemplate(event).Parse(text)
+			if err != nil {
+				continue
+			}
+			e.templates[format][event] = tmpl
+		}
+	}
+
+	return e
+}
+
+// newTemplate creates a template with the shared template functions
+func newTemplate(name string) *template.Template {
+	return template.New(name).Funcs(config.TemplateFuncs)
+}
+
+// Render renders the text and chat payloads for the event in data. When the
+// event has a text template the result is stored in data.Text. Chat formats
+// fall back to their default template only when there is text to show.
+func (e *TemplateEngine) Render(data *TemplateData) (map[string][]byte, error) {
+	event := string(data.EventType)
+
+	if tmpl, ok := e.templates[FormatText][event]; ok {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s text: %w", event, err)
+		}
+		data.Text = buf.String()
+	}
+
+	bodies := make(map[string][]byte)
+	for _, format := range []string{FormatSlack, FormatTeams} {
+		tmpl, ok := e.templates[format][event]
+		if !ok && data.Text != "" {
+			tmpl, ok = e.templates[format][defaultTemplateKey]
+		}
+		if !ok {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s %s payload: %w", event, format, err)
+		}
+		if !json.Valid(buf.Bytes()) {
+			return nil, fmt.Errorf("%s template for %s produced invalid JSON", format, event)
+		}
+		bodies[format] = buf.Bytes()
+	}
+
+	return bodies, nil
+}
+
+// newTemplateData flattens an incident into template-friendly fields
+func newTemplateData(eventType models.IncidentEventType, incident *models.Incident, dashboardURL string) TemplateData {
+	data := TemplateData{
+		EventType:    eventType,
+		IncidentID:   incident.ID,
+		ServiceName:  incident.ServiceName,
+		Repository:   incident.Repository,
+		Severity:     incident.Severity,
+		Status:       incident.Status,
+		Provider:     incident.Provider,
+		ErrorMessage: incident.ErrorMessage,
+		CreatedAt:    incident.CreatedAt,
+		Subject:      fmt.Sprintf("[%s] %s: %s (%s)", strings.ToUpper(incident.Severity), incident.ServiceName, eventType, incident.ID),
+		Color:        severityColor(incident.Severity),
+	}
+
+	if incident.PullRequestURL != nil {
+		data.PullRequestURL = *incident.PullRequestURL
+	}
+	if incident.Diagnosis != nil {
+		data.Diagnosis = *incident.Diagnosis
+	}
+	if dashboardURL != "" {
+		data.IncidentURL = strings.TrimSuffix(dashboardURL, "/") + "/incidents/" + incident.ID
+	}
+
+	return data
+}
+
+// severityColor maps incident severity to an accent color
+func severityColor(severity string) string {
+	switch severity {
+	case "critical":
+		return "D32F2F"
+	case "high":
+		return "F57C00"
+	case "medium":
+		return "FBC02D"
+	default:
+		return "1976D2"
+	}
+}
@@ -0,0 +1,120 @@
+package notifications
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestTemplateEngine_DefaultChatPayloads(t *testing.T) {
+	engine := NewTemplateEngine(config.NotificationsConfig{})
+
+	prURL := "https://github.com/org/api/pull/7"
+	incident := &models.Incident{
+		ID:             "inc_1",
+		ServiceName:    "api-gateway",
+		Severity:       "high",
+		PullRequestURL: &prURL,
+	}
+	data := newTemplateData(models.EventPRCreated, incident, "https://sre.example.com")
+
+	bodies, err := engine.Render(&data)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(data.Text, prURL) {
+		t.Errorf("expected text to be rendered, got %q", data.Text)
+	}
+
+	var slack struct {
+		Text   string `json:"text"`
+		Blocks []struct {
+			Type     string `json:"type"`
+			Elements []struct {
+				URL string `json:"url"`
+			} `json:"elements"`
+		} `json:"blocks"`
+	}
+	if err := json.Unmarshal(bodies[FormatSlack], &slack); err != nil {
+		t.Fatalf("invalid slack payload: %v", err)
+	}
+	if slack.Text != data.Text {
+		t.Errorf("slack fallback text = %q, want %q", slack.Text, data.Text)
+	}
+	actions := slack.Blocks[len(slack.Blocks)-1]
+	if actions.Type != "actions" || len(actions.Elements) != 2 || actions.Elements[1].URL != prURL {
+		t.Errorf("unexpected slack actions block: %+v", actions)
+	}
+
+	var teams teamsMessageCard
+	if err := json.Unmarshal(bodies[FormatTeams], &teams); err != nil {
+		t.Fatalf("invalid teams payload: %v", err)
+	}
+	if teams.ThemeColor != "F57C00" || teams.Summary != "[HIGH] api-gateway: pr_created (inc_1)" {
+		t.Errorf("unexpected teams card: %+v", teams)
+	}
+}
+
+func TestTemplateEngine_Overrides(t *testing.T) {
+	engine := NewTemplateEngine(config.NotificationsConfig{
+		Templates: map[string]string{
+			"workflow_triggered": "Remediation started for {{.IncidentID}}",
+		},
+		SlackTemplates: map[string]string{
+			"incident_failed": `{"text": {{json .Text}}, "blocks": [{"type": "header", "text": {"type": "plain_text", "text": {{json (upper .ServiceName)}}}}]}`,
+		},
+	})
+
+	incident := &models.Incident{ID: "inc_2", ServiceName: "billing", Severity: "low"}
+
+	// A text override gives an event without a built-in template chat payloads
+	data := newTemplateData(models.EventWorkflowTriggered, incident, "")
+	bodies, err := engine.Render(&data)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if data.Text != "Remediation started for inc_2" {
+		t.Errorf("text = %q", data.Text)
+	}
+	if _, ok := bodies[FormatSlack]; !ok {
+		t.Error("expected default slack payload for templated event")
+	}
+
+	data = newTemplateData(models.EventIncidentFailed, incident, "")
+	bodies, err = engine.Render(&data)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(string(bodies[FormatSlack]), `"text": "BILLING"`) {
+		t.Errorf("expected slack override to be used, got %s", bodies[FormatSlack])
+	}
+}
+
+func TestTemplateEngine_NoPayloadsWithoutText(t *testing.T) {
+	engine := NewTemplateEngine(config.NotificationsConfig{})
+
+	data := newTemplateData(models.EventStatusChanged, &models.Incident{ID: "inc_3"}, "")
+	bodies, err := engine.Render(&data)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if data.Text != "" || len(bodies) != 0 {
+		t.Errorf("expected nothing rendered, got text %q and %d payloads", data.Text, len(bodies))
+	}
+}
+
+func TestTemplateEngine_InvalidJSON(t *testing.T) {
+	engine := NewTemplateEngine(config.NotificationsConfig{
+		TeamsTemplates: map[string]string{
+			"incident_received": `{"text": {{.Text}}}`,
+		},
+	})
+
+	data := newTemplateData(models.EventIncidentReceived, &models.Incident{ID: "inc_4", ServiceName: "api"}, "")
+	if _, err := engine.Render(&data); err == nil || !strings.Contains(err.Error(), "invalid JSON") {
+		t.Errorf("expected invalid JSON error, got %v", err)
+	}
+}
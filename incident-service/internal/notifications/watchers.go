@@ -0,0 +1,60 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// directProviders are the providers that can reach a single person, and so
+// deliver direct messages
+var directProviders = map[string]bool{
+	"email": true,
+	"slack": true,
+}
+
+// WatcherStore lists the people subscribed to an incident
+type WatcherStore interface {
+	ListIncidentWatchers(incidentID string) ([]*models.IncidentWatcher, error)
+}
+
+// SetWatchers enables watcher notifications. Every notification about an
+// incident is also sent to each of its watchers.
+func (d *Dispatcher) SetWatchers(store WatcherStore) {
+	d.watchers = store
+}
+
+// notifyWatchers sends a copy of the message to each watcher of its
+// incident, by email and as a Slack direct message, as the watcher asked.
+// Deliveries are recorded and retried like any other.
+func (d *Dispatcher) notifyWatchers(ctx context.Context, msg *Message) error {
+	if d.watchers == nil || msg.Incident == nil {
+		return nil
+	}
+
+	watchers, err := d.watchers.ListIncidentWatchers(msg.Incident.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list incident watchers: %w", err)
+	}
+
+	var errs []error
+	for _, watcher := range watchers {
+		direct := *msg
+		direct.Direct = true
+		direct.Route = &config.NotificationRoute{
+			ServiceName:  msg.Incident.ServiceName,
+			SlackChannel: watcher.SlackUser,
+		}
+		if watcher.Email != "" {
+			direct.Route.EmailRecipients = []string{watcher.Email}
+		}
+		if err := d.deliver(ctx, &direct); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
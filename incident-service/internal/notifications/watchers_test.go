@@ -0,0 +1,105 @@
+package notifications
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// memoryWatcherStore keeps incident watchers in memory
+type memoryWatcherStore map[string][]*models.IncidentWatcher
+
+func (s memoryWatcherStore) ListIncidentWatchers(incidentID string) ([]*models.IncidentWatcher, error) {
+	return s[incidentID], nil
+}
+
+func TestDispatcher_NotifiesWatchers(t *testing.T) {
+	var received []slackMessage
+	slackServer := newSlackTestServer(t, &received)
+	defer slackServer.Close()
+
+	webhookRequests := 0
+	webhookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webhookRequests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer webhookServer.Close()
+
+	dispatcher := NewDispatcher(config.NotificationsConfig{
+		Slack: config.SlackConfig{
+			Enabled:        true,
+			APIURL:         slackServer.URL,
+			Token:          "xoxb-test",
+			DefaultChannel: "#incidents",
+		},
+		Webhooks: []config.WebhookConfig{{Name: "cmdb", URL: webhookServer.URL}},
+	})
+	var mails []capturedMail
+	dispatcher.Register(newTestEmailNotifier(config.EmailConfig{
+		Enabled:            true,
+		SMTPHost:           "smtp.example.com",
+		From:               "sre@example.com",
+		DefaultRecipients:  []string{"oncall@example.com"},
+		SeverityRecipients: map[string][]string{"critical": {"managers@example.com"}},
+	}, &mails))
+	dispatcher.SetWatchers(memoryWatcherStore{
+		"inc_1": {
+			{IncidentID: "inc_1", Email: "alice@example.com"},
+			{IncidentID: "inc_1", SlackUser: "U024BE7LH"},
+		},
+	})
+
+	incident := &models.Incident{ID: "inc_1", ServiceName: "api-gateway", Severity: "critical"}
+	if err := dispatcher.Notify(context.Background(), models.EventPRCreated, incident); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	channels := make([]string, 0, len(received))
+	for _, msg := range received {
+		channels = append(channels, msg.Channel)
+	}
+	sort.Strings(channels)
+	if strings.Join(channels, ",") != "#incidents,U024BE7LH" {
+		t.Errorf("expected the channel and the watcher to get Slack messages, got %v", channels)
+	}
+
+	// Watchers get their own email, without the default or severity recipients
+	if len(mails) != 2 {
+		t.Fatalf("expected 2 emails, got %+v", mails)
+	}
+	if got := strings.Join(mails[1].to, ","); got != "alice@example.com" {
+		t.Errorf("expected the watcher's email to go to alice only, got %s", got)
+	}
+
+	// Providers that cannot reach one person are not sent the watcher copies
+	if webhookRequests != 1 {
+		t.Errorf("expected 1 webhook delivery, got %d", webhookRequests)
+	}
+}
+
+func TestDispatcher_WatchersOfSnoozedIncidents(t *testing.T) {
+	var received []slackMessage
+	server := newSlackTestServer(t, &received)
+	defer server.Close()
+
+	dispatcher := NewDispatcher(config.NotificationsConfig{
+		Slack: config.SlackConfig{Enabled: true, APIURL: server.URL, Token: "xoxb-test", DefaultChannel: "#incidents"},
+	})
+	dispatcher.SetWatchers(memoryWatcherStore{"inc_1": {{IncidentID: "inc_1", SlackUser: "U024BE7LH"}}})
+
+	snoozedUntil := dispatcher.now().Add(time.Hour)
+	incident := &models.Incident{ID: "inc_1", ServiceName: "api-gateway", SnoozedUntil: &snoozedUntil}
+	if err := dispatcher.Notify(context.Background(), models.EventPRCreated, incident); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if len(received) != 0 {
+		t.Errorf("expected snoozed incidents to notify no one, got %+v", received)
+	}
+}
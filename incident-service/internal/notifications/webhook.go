@@ -0,0 +1,105 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// WebhookNotifier delivers incident lifecycle events as signed JSON to an external URL
+type WebhookNotifier struct {
+	config     config.WebhookConfig
+	events     map[models.IncidentEventType]bool
+	httpClient *http.Client
+}
+
+// WebhookPayload represents the JSON body sent to outbound webhook subscribers
+type WebhookPayload struct {
+	EventType models.IncidentEventType `json:"event_type"`
+	Timestamp string                   `json:"timestamp"`
+	Incident  *models.Incident         `json:"incident"`
+}
+
+// NewWebhookNotifier creates a new outbound webhook notifier
+func NewWebhookNotifier(cfg config.WebhookConfig) *WebhookNotifier {
+	events := make(map[models.IncidentEventType]bool, len(cfg.Events))
+	for _, event := range cfg.Events {
+		events[models.IncidentEventType(event)] = true
+	}
+
+	return &WebhookNotifier{
+		config:     cfg,
+		events:     events,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the provider name
+func (n *WebhookNotifier) Name() string {
+	return "webhook:" + n.config.Name
+}
+
+// Send posts the event to the subscriber if it is subscribed to the event type.
+// Digests are not lifecycle events and are never delivered to webhooks.
+func (n *WebhookNotifier) Send(ctx context.Context, msg *Message) error {
+	if msg.Incident == nil {
+		return ErrSkipped
+	}
+	if len(n.events) > 0 && !n.events[msg.EventType] {
+		return ErrSkipped
+	}
+
+	body, err := json.Marshal(WebhookPayload{
+		EventType: msg.EventType,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Incident:  msg.Incident,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", n.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for key, value := range n.config.Headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Incident-Event", string(msg.EventType))
+
+	if n.config.Secret != "" {
+		req.Header.Set("X-Incident-Signature", "sha256="+SignPayload(n.config.Secret, body))
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// SignPayload computes the hex-encoded HMAC-SHA256 signature subscribers use to verify deliveries
+func SignPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
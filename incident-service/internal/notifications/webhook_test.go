@@ -0,0 +1,89 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestWebhookNotifier_SignedDelivery(t *testing.T) {
+	var (
+		body    []byte
+		headers http.Header
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		headers = r.Header.Clone()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher(config.NotificationsConfig{
+		Webhooks: []config.WebhookConfig{
+			{
+				Name:    "cmdb",
+				URL:     server.URL,
+				Headers: map[string]string{"X-Api-Key": "cmdb-key"},
+				Secret:  "webhook-secret",
+			},
+		},
+	})
+
+	incident := &models.Incident{ID: "inc_1", ServiceName: "api-gateway", Status: models.StatusWorkflowTriggered}
+
+	// Events without a chat template are still delivered to webhooks
+	if err := dispatcher.Notify(context.Background(), models.EventWorkflowTriggered, incident); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if headers.Get("X-Api-Key") != "cmdb-key" {
+		t.Errorf("expected custom header to be set")
+	}
+	if headers.Get("X-Incident-Event") != "workflow_triggered" {
+		t.Errorf("X-Incident-Event = %s", headers.Get("X-Incident-Event"))
+	}
+	if headers.Get("X-Incident-Signature") != "sha256="+SignPayload("webhook-secret", body) {
+		t.Errorf("signature does not match body")
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if payload.EventType != models.EventWorkflowTriggered || payload.Incident.ID != "inc_1" {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestWebhookNotifier_EventFilter(t *testing.T) {
+	deliveries := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deliveries++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(config.WebhookConfig{
+		Name:   "ticketing",
+		URL:    server.URL,
+		Events: []string{"incident_failed"},
+	})
+
+	incident := &models.Incident{ID: "inc_1"}
+	for _, eventType := range []models.IncidentEventType{models.EventIncidentReceived, models.EventIncidentFailed} {
+		if err := notifier.Send(context.Background(), &Message{EventType: eventType, Incident: incident}); err != nil && !errors.Is(err, ErrSkipped) {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	if deliveries != 1 {
+		t.Errorf("expected 1 delivery, got %d", deliveries)
+	}
+}
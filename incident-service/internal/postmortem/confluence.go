@@ -0,0 +1,159 @@
+package postmortem
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/markdown"
+)
+
+// maxErrorBytes bounds how much of a Confluence error response is read
+const maxErrorBytes = 4 << 10
+
+// Confluence publishes drafts as pages of a Confluence space, through the
+// Confluence REST API with basic authentication
+type Confluence struct {
+	httpClient *http.Client
+	config     config.ConfluenceConfig
+	baseURL    string
+}
+
+// confluencePage is the part of a Confluence page the publisher reads
+type confluencePage struct {
+	ID      string `json:"id"`
+	Version struct {
+		Number int `json:"number"`
+	} `json:"version"`
+	Links struct {
+		Base  string `json:"base"`
+		WebUI string `json:"webui"`
+	} `json:"_links"`
+}
+
+// NewConfluence creates a publisher for the configured space
+func NewConfluence(cfg config.ConfluenceConfig) *Confluence {
+	return &Confluence{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		config:     cfg,
+		baseURL:    strings.TrimRight(cfg.URL, "/"),
+	}
+}
+
+// Publish creates a page for the draft, or updates the page of the same
+// title when the draft was published before, and returns the page's URL
+func (c *Confluence) Publish(ctx context.Context, draft *Draft) (string, error) {
+	existing, err := c.findPage(ctx, draft.Title)
+	if err != nil {
+		return "", err
+	}
+
+	request := map[string]interface{}{
+		"type":  "page",
+		"title": draft.Title,
+		"space": map[string]string{"key": c.config.SpaceKey},
+		"body": map[string]interface{}{
+			"storage": map[string]string{
+				"value":          storageFormat(draft.Markdown),
+				"representation": "storage",
+			},
+		},
+	}
+
+	method, endpoint := http.MethodPost, c.baseURL+"/rest/api/content"
+	if existing != nil {
+		method, endpoint = http.MethodPut, c.baseURL+"/rest/api/content/"+url.PathEscape(existing.ID)
+		request["id"] = existing.ID
+		request["version"] = map[string]int{"number": existing.Version.Number + 1}
+	} else if c.config.ParentID != "" {
+		request["ancestors"] = []map[string]string{{"id": c.config.ParentID}}
+	}
+
+	var page confluencePage
+	if err := c.do(ctx, method, endpoint, request, &page); err != nil {
+		return "", fmt.Errorf("failed to publish page: %w", err)
+	}
+	return c.pageURL(&page), nil
+}
+
+// findPage returns the page of the space with the title, or nil if there is
+// none
+func (c *Confluence) findPage(ctx context.Context, title string) (*confluencePage, error) {
+	query := url.Values{}
+	query.Set("spaceKey", c.config.SpaceKey)
+	query.Set("title", title)
+	query.Set("expand", "version")
+
+	var response struct {
+		Results []confluencePage `json:"results"`
+	}
+	if err := c.do(ctx, http.MethodGet, c.baseURL+"/rest/api/content?"+query.Encode(), nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to look up page: %w", err)
+	}
+	if len(response.Results) == 0 {
+		return nil, nil
+	}
+	return &response.Results[0], nil
+}
+
+// do sends an authenticated request, with body as JSON when not nil, and
+// decodes the JSON response into out
+func (c *Confluence) do(ctx context.Context, method, endpoint string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(c.config.Username, c.config.APIToken)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBytes))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(bodyBytes)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// pageURL returns the address of a page in the Confluence web UI
+func (c *Confluence) pageURL(page *confluencePage) string {
+	base := page.Links.Base
+	if base == "" {
+		base = c.baseURL
+	}
+	return base + page.Links.WebUI
+}
+
+// storageFormat renders Markdown as Confluence storage format, which is
+// XHTML: the renderer's output is already well-formed but for its void
+// elements
+func storageFormat(source string) string {
+	return strings.ReplaceAll(markdown.Render(source), "<hr>", "<hr />")
+}
@@ -0,0 +1,93 @@
+package postmortem
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+func TestConfluence_Publish(t *testing.T) {
+	pages := map[string]int{} // title -> version
+	var requests []map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, token, ok := r.BasicAuth(); !ok || user != "bot@example.com" || token != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/wiki/rest/api/content":
+			if r.URL.Query().Get("spaceKey") != "SRE" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			title := r.URL.Query().Get("title")
+			if version, ok := pages[title]; ok {
+				_, _ = w.Write([]byte(`{"results": [{"id": "99", "version": {"number": ` + strconv.Itoa(version) + `}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"results": []}`))
+		case r.Method == "POST" && r.URL.Path == "/wiki/rest/api/content",
+			r.Method == "PUT" && r.URL.Path == "/wiki/rest/api/content/99":
+			var request map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&request)
+			requests = append(requests, request)
+			pages[request["title"].(string)]++
+			_, _ = w.Write([]byte(`{"id": "99", "_links": {"base": "https://example.atlassian.net/wiki", "webui": "/spaces/SRE/pages/99"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	confluence := NewConfluence(config.ConfluenceConfig{
+		URL:      server.URL + "/wiki/",
+		SpaceKey: "SRE",
+		ParentID: "7",
+		Username: "bot@example.com",
+		APIToken: "secret",
+	})
+	draft := &Draft{Title: "Postmortem: checkout: timeout", Markdown: "# Postmortem\n\n---\n\nA <b>bold</b> claim"}
+
+	url, err := confluence.Publish(context.Background(), draft)
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if url != "https://example.atlassian.net/wiki/spaces/SRE/pages/99" {
+		t.Errorf("unexpected page URL %s", url)
+	}
+	if _, ok := requests[0]["ancestors"]; !ok {
+		t.Errorf("expected a new page under the parent, got %+v", requests[0])
+	}
+	body := requests[0]["body"].(map[string]interface{})["storage"].(map[string]interface{})["value"].(string)
+	if !strings.Contains(body, "<hr />") || !strings.Contains(body, "&lt;b&gt;bold&lt;/b&gt;") {
+		t.Errorf("expected XHTML with escaped text, got %s", body)
+	}
+
+	// Publishing again updates the page
+	if _, err := confluence.Publish(context.Background(), draft); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	version := requests[1]["version"].(map[string]interface{})["number"].(float64)
+	if version != 2 {
+		t.Errorf("expected the page to be updated to version 2, got %+v", requests[1])
+	}
+}
+
+func TestConfluence_PublishError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	confluence := NewConfluence(config.ConfluenceConfig{URL: server.URL, SpaceKey: "SRE", Username: "bot", APIToken: "wrong"})
+	if _, err := confluence.Publish(context.Background(), &Draft{Title: "Postmortem"}); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected the status to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+// Package postmortem drafts postmortems of incidents from what the service
+// recorded about them: the timeline of events, the diagnosis, the pull
+// request and the impact window. Drafts are Markdown with the sections a
+// team fills in by hand left as TODOs.
+package postmortem
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// maxTitleError bounds how much of the error message goes into the title
+const maxTitleError = 80
+
+// maxEventData bounds the event details shown on each timeline line
+const maxEventData = 160
+
+// unsafeFilename matches what is replaced in the file names of drafts
+var unsafeFilename = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// backtickRun matches runs of backticks, to fence text containing them
+var backtickRun = regexp.MustCompile("`+")
+
+// Draft is a postmortem draft of an incident
+type Draft struct {
+	IncidentID string `json:"incident_id"`
+	Title      string `json:"title"`
+	Filename   string `json:"filename"` // name of the draft when committed to a repository
+	Markdown   string `json:"markdown"`
+}
+
+// New drafts the postmortem of an incident from its events, oldest first
+func New(incident *models.Incident, events []*models.IncidentEvent, now time.Time) *Draft {
+	title := fmt.Sprintf("Postmortem: %s: %s", incident.ServiceName, firstLine(incident.ErrorMessage, maxTitleError))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	fmt.Fprintf(&b, "> Draft generated on %s from incident `%s`. Complete the sections marked TODO before review.\n\n",
+		formatTime(now), codeSpan(incident.ID))
+
+	b.WriteString("## Summary\n\n")
+	field(&b, "Incident", "`"+codeSpan(incident.ID)+"`")
+	field(&b, "Service", incident.ServiceName)
+	field(&b, "Severity", incident.Severity)
+	field(&b, "Status", string(incident.Status))
+	provider := incident.Provider
+	if incident.ProviderRef != "" {
+		provider += " (" + incident.ProviderRef + ")"
+	}
+	field(&b, "Provider", provider)
+	field(&b, "Repository", incident.Repository)
+	field(&b, "Team", incident.Team)
+	if incident.Summary != nil {
+		field(&b, "Root cause category", incident.Summary.RootCauseCategory)
+		fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(incident.Summary.Text))
+	}
+
+	b.WriteString("\n## Impact\n\n")
+	field(&b, "Started", formatTime(incident.CreatedAt))
+	if incident.CompletedAt != nil {
+		field(&b, "Ended", formatTime(*incident.CompletedAt))
+		field(&b, "Duration", formatDuration(incident.CompletedAt.Sub(incident.CreatedAt)))
+	} else {
+		field(&b, "Ended", "ongoing")
+		field(&b, "Duration", formatDuration(now.Sub(incident.CreatedAt))+" so far")
+	}
+	field(&b, "Occurrences", fmt.Sprint(max(incident.OccurrenceCount, 1)))
+	if incident.AffectedUsers != nil {
+		field(&b, "Affected users", fmt.Sprint(*incident.AffectedUsers))
+	}
+	if incident.ErrorRate != nil {
+		field(&b, "Error rate", fmt.Sprintf("%.1f%%", *incident.ErrorRate*100))
+	}
+	if incident.DowntimeMinutes != nil {
+		field(&b, "Downtime", formatDuration(time.Duration(*incident.DowntimeMinutes)*time.Minute))
+	}
+	b.WriteString("\nTODO: describe who and what was affected.\n")
+
+	b.WriteString("\n## Error\n\n")
+	writeFence(&b, incident.ErrorMessage)
+
+	b.WriteString("\n## Timeline\n\n")
+	if len(events) == 0 {
+		b.WriteString("No events were recorded.\n")
+	}
+	for _, event := range events {
+		fmt.Fprintf(&b, "- **%s** %s", formatTime(event.CreatedAt), describeEvent(event.EventType))
+		if details := formatEventData(event.EventData); details != "" {
+			fmt.Fprintf(&b, ": `%s`", details)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n## Diagnosis\n\n")
+	if incident.Diagnosis != nil && strings.TrimSpace(*incident.Diagnosis) != "" {
+		b.WriteString(strings.TrimSpace(*incident.Diagnosis) + "\n")
+	} else {
+		b.WriteString("No diagnosis was recorded.\n")
+	}
+
+	b.WriteString("\n## Remediation\n\n")
+	if incident.PullRequestURL != nil && *incident.PullRequestURL != "" {
+		field(&b, "Pull request", *incident.PullRequestURL)
+	} else {
+		field(&b, "Pull request", "none opened")
+	}
+	if incident.WorkflowRunID != nil {
+		field(&b, "Workflow run", fmt.Sprint(*incident.WorkflowRunID))
+	}
+
+	b.WriteString("\n## Root Cause\n\nTODO: explain why the failure happened, not only what failed.\n")
+	b.WriteString("\n## Action Items\n\n- TODO: owner, action, due date\n")
+	b.WriteString("\n## Lessons Learned\n\nTODO: what went well, what went wrong, where we got lucky.\n")
+
+	return &Draft{
+		IncidentID: incident.ID,
+		Title:      title,
+		Filename:   incident.CreatedAt.UTC().Format("2006-01-02") + "-" + unsafeFilename.ReplaceAllString(incident.ID, "_") + ".md",
+		Markdown:   b.String(),
+	}
+}
+
+// field writes a summary line, leaving out empty values
+func field(b *strings.Builder, name, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(b, "- **%s:** %s\n", name, value)
+}
+
+// writeFence writes text as a fenced code block, with a fence longer than
+// any run of backticks in it
+func writeFence(b *strings.Builder, text string) {
+	fence := "```"
+	for _, run := range backtickRun.FindAllString(text, -1) {
+		if len(run) >= len(fence) {
+			fence = strings.Repeat("`", len(run)+1)
+		}
+	}
+	fmt.Fprintf(b, "%stext\n%s\n%s\n", fence, strings.TrimRight(text, "\n"), fence)
+}
+
+// describeEvent turns an event type such as pr_created into "Pr created"
+func describeEvent(eventType models.IncidentEventType) string {
+	text := strings.ReplaceAll(string(eventType), "_", " ")
+	if text == "" {
+		return text
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+// formatEventData lists the event's details as key=value pairs on one line
+func formatEventData(data map[string]interface{}) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", key, data[key]))
+	}
+	return firstLine(codeSpan(strings.Join(strings.Fields(strings.Join(pairs, " ")), " ")), maxEventData)
+}
+
+// codeSpan makes text safe to put between single backticks
+func codeSpan(text string) string {
+	return strings.ReplaceAll(text, "`", "'")
+}
+
+// firstLine returns the first line of text, shortened to at most n runes
+func firstLine(text string, n int) string {
+	text = strings.TrimSpace(text)
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = strings.TrimSpace(text[:i])
+	}
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// formatTime writes a time in UTC, to the second
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05 UTC")
+}
+
+// formatDuration writes a duration rounded to the minute, or to the second
+// below one minute
+func formatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return d.Round(time.Second).String()
+	}
+	d = d.Round(time.Minute)
+	if hours := d / time.Hour; hours > 0 {
+		return fmt.Sprintf("%dh %dm", hours, (d%time.Hour)/time.Minute)
+	}
+	return fmt.Sprintf("%dm", d/time.Minute)
+}
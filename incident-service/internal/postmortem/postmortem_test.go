@@ -0,0 +1,108 @@
+package postmortem
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestNew(t *testing.T) {
+	created := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	completed := created.Add(95 * time.Minute)
+	diagnosis := "The pool was exhausted by a `SELECT` without a timeout."
+	prURL := "https://github.com/org/checkout/pull/42"
+	users, rate, downtime := int64(4200), 0.35, 80
+	incident := &models.Incident{
+		ID:              "inc_dd/123",
+		ServiceName:     "checkout",
+		Repository:      "org/checkout",
+		ErrorMessage:    "pq: too many connections\nat db.go:42",
+		Severity:        "critical",
+		Status:          models.StatusResolved,
+		Provider:        "datadog",
+		ProviderRef:     "123",
+		Diagnosis:       &diagnosis,
+		PullRequestURL:  &prURL,
+		CreatedAt:       created,
+		CompletedAt:     &completed,
+		OccurrenceCount: 3,
+		AffectedUsers:   &users,
+		ErrorRate:       &rate,
+		DowntimeMinutes: &downtime,
+	}
+	events := []*models.IncidentEvent{
+		{EventType: models.EventIncidentReceived, EventData: map[string]interface{}{"provider": "datadog"}, CreatedAt: created},
+		{EventType: models.EventPRCreated, EventData: map[string]interface{}{"pr_url": prURL}, CreatedAt: created.Add(time.Hour)},
+	}
+
+	draft := New(incident, events, completed.Add(time.Hour))
+
+	if draft.Title != "Postmortem: checkout: pq: too many connections" {
+		t.Errorf("unexpected title %q", draft.Title)
+	}
+	if draft.Filename != "2024-03-04-inc_dd_123.md" {
+		t.Errorf("unexpected filename %q", draft.Filename)
+	}
+	for _, want := range []string{
+		"- **Provider:** datadog (123)",
+		"- **Started:** 2024-03-04 10:00:00 UTC",
+		"- **Ended:** 2024-03-04 11:35:00 UTC",
+		"- **Duration:** 1h 35m",
+		"- **Occurrences:** 3",
+		"- **Affected users:** 4200",
+		"- **Error rate:** 35.0%",
+		"- **Downtime:** 1h 20m",
+		"```text\npq: too many connections\nat db.go:42\n```",
+		"- **2024-03-04 10:00:00 UTC** Incident received: `provider=datadog`",
+		"- **2024-03-04 11:00:00 UTC** Pr created: `pr_url=" + prURL + "`",
+		diagnosis,
+		"- **Pull request:** " + prURL,
+		"## Action Items",
+	} {
+		if !strings.Contains(draft.Markdown, want) {
+			t.Errorf("expected the draft to contain %q, got:\n%s", want, draft.Markdown)
+		}
+	}
+}
+
+func TestNew_OngoingIncident(t *testing.T) {
+	created := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	incident := &models.Incident{
+		ID:           "inc_1",
+		ServiceName:  "checkout",
+		ErrorMessage: "panic: ```unexpected``` fence",
+		Status:       models.StatusInProgress,
+		CreatedAt:    created,
+	}
+
+	draft := New(incident, nil, created.Add(20*time.Minute))
+
+	for _, want := range []string{
+		"- **Ended:** ongoing",
+		"- **Duration:** 20m so far",
+		"- **Occurrences:** 1",
+		"````text\npanic: ```unexpected``` fence\n````",
+		"No events were recorded.",
+		"No diagnosis was recorded.",
+		"- **Pull request:** none opened",
+	} {
+		if !strings.Contains(draft.Markdown, want) {
+			t.Errorf("expected the draft to contain %q, got:\n%s", want, draft.Markdown)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		42 * time.Second:               "42s",
+		5*time.Minute + 20*time.Second: "5m",
+		26*time.Hour + 5*time.Minute:   "26h 5m",
+	}
+	for d, want := range tests {
+		if got := formatDuration(d); got != want {
+			t.Errorf("formatDuration(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Package severity suggests a severity for incidents whose provider gave
+// none that maps. Configured keywords decide first. Otherwise a naive Bayes
+// model trained on past incidents of known severity predicts from the words
+// of the error message and the service.
+package severity
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// Methods a suggestion can be made by
+const (
+	MethodKeyword = "keyword"
+	MethodBayes   = "bayes"
+)
+
+// Sample is a past incident of known severity the model learns from
+type Sample struct {
+	ServiceName  string
+	ErrorMessage string
+	Severity     string
+}
+
+// Suggestion is a suggested severity and how it was arrived at
+type Suggestion struct {
+	Severity   string  `json:"severity"`
+	Method     string  `json:"method"`
+	Confidence float64 `json:"confidence"`        // probability of the severity, 1 for keywords
+	Keyword    string  `json:"keyword,omitempty"` // the phrase that matched
+}
+
+// keyword is a configured phrase and the severity it suggests
+type keyword struct {
+	phrase   string
+	severity string
+}
+
+// Classifier suggests severities. It is safe for concurrent use, and can be
+// retrained while it serves suggestions.
+type Classifier struct {
+	keywords      []keyword
+	minSamples    int
+	minConfidence float64
+
+	mu    sync.RWMutex
+	model *model
+}
+
+// NewClassifier creates a classifier with the keywords of cfg and no model
+// until it is trained
+func NewClassifier(cfg config.SeverityConfig) *Classifier {
+	c := &Classifier{
+		minSamples:    cfg.SampleMinimum(),
+		minConfidence: cfg.ConfidenceThreshold(),
+	}
+	for severity, phrases := range cfg.Keywords {
+		for _, phrase := range phrases {
+			c.keywords = append(c.keywords, keyword{phrase: strings.ToLower(strings.TrimSpace(phrase)), severity: severity})
+		}
+	}
+	// The most urgent severity wins when phrases of several match
+	sort.SliceStable(c.keywords, func(i, j int) bool {
+		a, b := models.SeverityRank(c.keywords[i].severity), models.SeverityRank(c.keywords[j].severity)
+		if a != b {
+			return a < b
+		}
+		return c.keywords[i].phrase < c.keywords[j].phrase
+	})
+	return c
+}
+
+// Train replaces the model with one learned from samples. With fewer samples
+// than the configured minimum, the classifier only uses keywords.
+func (c *Classifier) Train(samples []Sample) {
+	var m *model
+	if len(samples) >= c.minSamples {
+		m = train(samples)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.model = m
+}
+
+// Trained reports whether the classifier has a model
+func (c *Classifier) Trained() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.model != nil
+}
+
+// Suggest returns the severity suggested for an incident of the service with
+// the error message. It reports false when no keyword matches and the model
+// is missing or not confident enough.
+func (c *Classifier) Suggest(serviceName, errorMessage string) (Suggestion, bool) {
+	lower := strings.ToLower(errorMessage)
+	for _, k := range c.keywords {
+		if strings.Contains(lower, k.phrase) {
+			return Suggestion{Severity: k.severity, Method: MethodKeyword, Confidence: 1, Keyword: k.phrase}, true
+		}
+	}
+
+	c.mu.RLock()
+	m := c.model
+	c.mu.RUnlock()
+	if m == nil {
+		return Suggestion{}, false
+	}
+
+	severity, probability := m.predict(features(serviceName, errorMessage))
+	if probability < c.minConfidence {
+		return Suggestion{}, false
+	}
+	return Suggestion{Severity: severity, Method: MethodBayes, Confidence: probability}, true
+}
+
+// model is a multinomial naive Bayes model over the features of incidents
+type model struct {
+	classes    []string                  // in order of urgency
+	documents  map[string]int            // incidents per severity
+	counts     map[string]map[string]int // feature counts per severity
+	totals     map[string]int            // feature occurrences per severity
+	vocabulary map[string]bool
+	samples    int
+}
+
+// train learns a model from samples
+func train(samples []Sample) *model {
+	m := &model{
+		documents:  make(map[string]int),
+		counts:     make(map[string]map[string]int),
+		totals:     make(map[string]int),
+		vocabulary: make(map[string]bool),
+		samples:    len(samples),
+	}
+	for _, sample := range samples {
+		if m.counts[sample.Severity] == nil {
+			m.counts[sample.Severity] = make(map[string]int)
+			m.classes = append(m.classes, sample.Severity)
+		}
+		m.documents[sample.Severity]++
+		for _, feature := range features(sample.ServiceName, sample.ErrorMessage) {
+			m.counts[sample.Severity][feature]++
+			m.totals[sample.Severity]++
+			m.vocabulary[feature] = true
+		}
+	}
+	sort.Slice(m.classes, func(i, j int) bool {
+		a, b := models.SeverityRank(m.classes[i]), models.SeverityRank(m.classes[j])
+		if a != b {
+			return a < b
+		}
+		return m.classes[i] < m.classes[j]
+	})
+	return m
+}
+
+// predict returns the most probable severity for the features and its
+// probability. Features never seen in training are ignored.
+func (m *model) predict(feats []string) (string, float64) {
+	vocabulary := float64(len(m.vocabulary))
+	scores := make([]float64, len(m.classes))
+	for i, class := range m.classes {
+		score := math.Log(float64(m.documents[class]) / float64(m.samples))
+		for _, feature := range feats {
+			if !m.vocabulary[feature] {
+				continue
+			}
+			// Laplace smoothing keeps unseen pairs from ruling a class out
+			score += math.Log((float64(m.counts[class][feature]) + 1) / (float64(m.totals[class]) + vocabulary))
+		}
+		scores[i] = score
+	}
+
+	best := 0
+	for i := range scores {
+		if scores[i] > scores[best] {
+			best = i
+		}
+	}
+	// Normalize the log scores into probabilities
+	var sum float64
+	for _, score := range scores {
+		sum += math.Exp(score - scores[best])
+	}
+	return m.classes[best], 1 / sum
+}
+
+// features returns the distinct words of an error message, and the service
+// as a feature of its own so that its history weighs in
+func features(serviceName, errorMessage string) []string {
+	seen := make(map[string]bool)
+	var feats []string
+	if serviceName != "" {
+		feats = append(feats, "service:"+serviceName)
+		seen[feats[0]] = true
+	}
+	words := strings.FieldsFunc(strings.ToLower(errorMessage), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	for _, word := range words {
+		// Short words and numbers such as IDs and ports say little
+		if len(word) < 3 || strings.IndexFunc(word, unicode.IsLetter) < 0 || seen[word] {
+			continue
+		}
+		seen[word] = true
+		feats = append(feats, word)
+	}
+	return feats
+}
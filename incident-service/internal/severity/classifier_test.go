@@ -0,0 +1,87 @@
+package severity
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+// history is a small set of past incidents with a clear pattern
+func history() []Sample {
+	var samples []Sample
+	for i := 0; i < 10; i++ {
+		samples = append(samples,
+			Sample{ServiceName: "payments", ErrorMessage: "database connection pool exhausted", Severity: "critical"},
+			Sample{ServiceName: "search", ErrorMessage: "slow query warning on index rebuild", Severity: "low"},
+		)
+	}
+	return samples
+}
+
+func TestClassifier_Keywords(t *testing.T) {
+	classifier := NewClassifier(config.SeverityConfig{
+		Keywords: map[string][]string{
+			"low":      {"deprecated"},
+			"critical": {"Out of memory"},
+		},
+	})
+
+	suggestion, ok := classifier.Suggest("api", "OUT OF MEMORY while using deprecated API")
+	if !ok {
+		t.Fatal("expected a suggestion")
+	}
+	want := Suggestion{Severity: "critical", Method: MethodKeyword, Confidence: 1, Keyword: "out of memory"}
+	if suggestion != want {
+		t.Errorf("expected the most urgent keyword to win, got %+v", suggestion)
+	}
+
+	if _, ok := classifier.Suggest("api", "connection refused"); ok {
+		t.Error("expected no suggestion without a matching keyword or a model")
+	}
+}
+
+func TestClassifier_Bayes(t *testing.T) {
+	classifier := NewClassifier(config.SeverityConfig{MinSamples: 10})
+	classifier.Train(history())
+	if !classifier.Trained() {
+		t.Fatal("expected the classifier to be trained")
+	}
+
+	suggestion, ok := classifier.Suggest("payments", "connection pool exhausted on replica")
+	if !ok || suggestion.Severity != "critical" || suggestion.Method != MethodBayes {
+		t.Fatalf("expected a critical bayes suggestion, got %+v, %v", suggestion, ok)
+	}
+	if suggestion.Confidence < 0.5 || suggestion.Confidence > 1 {
+		t.Errorf("unexpected confidence %v", suggestion.Confidence)
+	}
+
+	// The service's history weighs in when the words say little
+	suggestion, ok = classifier.Suggest("search", "unexpected failure")
+	if !ok || suggestion.Severity != "low" {
+		t.Errorf("expected the service's usual severity, got %+v, %v", suggestion, ok)
+	}
+}
+
+func TestClassifier_Thresholds(t *testing.T) {
+	classifier := NewClassifier(config.SeverityConfig{MinSamples: 100})
+	classifier.Train(history())
+	if classifier.Trained() {
+		t.Error("expected too few samples to leave the classifier untrained")
+	}
+
+	classifier = NewClassifier(config.SeverityConfig{MinSamples: 10, MinConfidence: 0.99})
+	classifier.Train(history())
+	// Nothing known about the incident, so both severities are as likely
+	if _, ok := classifier.Suggest("checkout", "unexpected failure"); ok {
+		t.Error("expected no suggestion below the confidence threshold")
+	}
+}
+
+func TestFeatures(t *testing.T) {
+	got := features("api", "Timeout after 30s: upstream api-gateway timeout (id 12345)")
+	want := []string{"service:api", "timeout", "after", "30s", "upstream", "api", "gateway"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("features() = %v, want %v", got, want)
+	}
+}
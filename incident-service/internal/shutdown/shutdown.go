@@ -0,0 +1,65 @@
+// Package shutdown coordinates stopping the service's background goroutines
+// so that work in flight finishes before the process exits.
+package shutdown
+
+import (
+	"context"
+	"sync"
+)
+
+// Coordinator tracks long-running goroutines and stops them together
+type Coordinator struct {
+	mu    sync.Mutex
+	stops []func()
+	wg    sync.WaitGroup
+}
+
+// NewCoordinator creates a new shutdown coordinator
+func NewCoordinator() *Coordinator {
+	return &Coordinator{}
+}
+
+// Go runs run in a goroutine until stop is called during Shutdown. run must
+// return once stop has been called, after finishing any work in flight.
+func (c *Coordinator) Go(run func(), stop func()) {
+	c.mu.Lock()
+	c.stops = append(c.stops, stop)
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		run()
+	}()
+}
+
+// Shutdown stops every goroutine, most recently started first, and waits for
+// them to return or for ctx to be done
+func (c *Coordinator) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	stops := c.stops
+	c.stops = nil
+	c.mu.Unlock()
+
+	for i := len(stops) - 1; i >= 0; i-- {
+		stops[i]()
+	}
+
+	return Wait(ctx, &c.wg)
+}
+
+// Wait waits for wg or for ctx to be done, whichever comes first
+func Wait(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
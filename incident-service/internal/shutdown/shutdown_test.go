@@ -0,0 +1,61 @@
+package shutdown
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCoordinator_Shutdown(t *testing.T) {
+	c := NewCoordinator()
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, name)
+	}
+
+	for _, name := range []string{"first", "second"} {
+		name := name
+		stopCh := make(chan struct{})
+		c.Go(func() {
+			<-stopCh
+			// Simulate finishing work in flight
+			time.Sleep(5 * time.Millisecond)
+			record(name + " finished")
+		}, func() {
+			record(name + " stopped")
+			close(stopCh)
+		})
+	}
+
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != 4 {
+		t.Fatalf("expected both goroutines stopped and finished before Shutdown returns, got %v", order)
+	}
+	if order[0] != "second stopped" || order[1] != "first stopped" {
+		t.Errorf("expected goroutines stopped in reverse start order, got %v", order)
+	}
+}
+
+func TestCoordinator_ShutdownTimeout(t *testing.T) {
+	c := NewCoordinator()
+
+	release := make(chan struct{})
+	defer close(release)
+	c.Go(func() { <-release }, func() {})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := c.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected Shutdown to give up at the deadline, got %v", err)
+	}
+}
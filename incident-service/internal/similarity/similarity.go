@@ -0,0 +1,142 @@
+// Package similarity finds past incidents similar to an incident by the
+// embeddings of their error messages. Embeddings come from an
+// OpenAI-compatible embeddings API and are compared by cosine similarity.
+package similarity
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/textutil"
+)
+
+// maxInput bounds how much of an error message is embedded
+const maxInput = 8000
+
+// Embedder turns error messages into embeddings
+type Embedder struct {
+	config     config.SimilarityConfig
+	httpClient *http.Client
+}
+
+// NewEmbedder creates an embedder for the endpoint configured by cfg
+func NewEmbedder(cfg config.SimilarityConfig) (*Embedder, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &Embedder{
+		config:     cfg,
+		httpClient: &http.Client{Timeout: cfg.RequestTimeout()},
+	}, nil
+}
+
+// Model returns the name of the embedding model, which embeddings are
+// stored under
+func (e *Embedder) Model() string {
+	return e.config.Model
+}
+
+type embeddingRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed returns the embedding of the incident's error message
+func (e *Embedder) Embed(ctx context.Context, incident *models.Incident) ([]float64, error) {
+	body, err := json.Marshal(embeddingRequest{Model: e.config.Model, Input: textutil.Truncate(incident.ErrorMessage, maxInput)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := strings.TrimRight(e.config.BaseURL, "/") + "/embeddings"
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.config.APIKey)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var embedding embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&embedding); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(embedding.Data) == 0 || len(embedding.Data[0].Embedding) == 0 {
+		return nil, fmt.Errorf("response has no embedding")
+	}
+	return embedding.Data[0].Embedding, nil
+}
+
+// Match is a candidate incident that resembles the one looked up
+type Match struct {
+	IncidentID string
+	Score      float64
+}
+
+// Rank returns the candidates whose cosine similarity to vector is at least
+// minScore, most similar first, at most limit of them. Candidates embedded
+// with a different number of dimensions are skipped.
+func Rank(vector []float64, candidates []models.IncidentEmbedding, minScore float64, limit int) []Match {
+	var matches []Match
+	for _, candidate := range candidates {
+		score, ok := Cosine(vector, candidate.Vector)
+		if ok && score >= minScore {
+			matches = append(matches, Match{IncidentID: candidate.IncidentID, Score: score})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].IncidentID < matches[j].IncidentID
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// Cosine returns the cosine similarity of two vectors. It reports false when
+// their lengths differ or either is zero.
+func Cosine(a, b []float64) (float64, bool) {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0, false
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0, false
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB)), true
+}
@@ -0,0 +1,119 @@
+package similarity
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestEmbedder_Embed(t *testing.T) {
+	var got embeddingRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			http.NotFound(w, r)
+			return
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+			t.Errorf("expected the api key as bearer token, got %q", auth)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [{"embedding": [0.1, 0.2, 0.3]}]}`))
+	}))
+	defer server.Close()
+
+	embedder, err := NewEmbedder(config.SimilarityConfig{
+		Enabled: true,
+		BaseURL: server.URL + "/v1",
+		APIKey:  "secret",
+		Model:   "text-embedding-3-small",
+	})
+	if err != nil {
+		t.Fatalf("failed to create embedder: %v", err)
+	}
+
+	vector, err := embedder.Embed(context.Background(), &models.Incident{ErrorMessage: "connection refused"})
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if len(vector) != 3 || vector[2] != 0.3 {
+		t.Errorf("unexpected embedding %v", vector)
+	}
+	if got.Model != "text-embedding-3-small" || got.Input != "connection refused" {
+		t.Errorf("unexpected request %+v", got)
+	}
+}
+
+func TestEmbedder_EmbedError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	embedder, err := NewEmbedder(config.SimilarityConfig{Enabled: true, BaseURL: server.URL, Model: "m"})
+	if err != nil {
+		t.Fatalf("failed to create embedder: %v", err)
+	}
+	if _, err := embedder.Embed(context.Background(), &models.Incident{ErrorMessage: "boom"}); err == nil {
+		t.Error("expected an error for a response without an embedding")
+	}
+}
+
+func TestCosine(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   []float64
+		want   float64
+		wantOK bool
+	}{
+		{"same direction", []float64{1, 2}, []float64{2, 4}, 1, true},
+		{"orthogonal", []float64{1, 0}, []float64{0, 1}, 0, true},
+		{"opposite", []float64{1, 0}, []float64{-1, 0}, -1, true},
+		{"different lengths", []float64{1, 0}, []float64{1, 0, 0}, 0, false},
+		{"zero vector", []float64{0, 0}, []float64{1, 0}, 0, false},
+		{"empty", nil, nil, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Cosine(tt.a, tt.b)
+			if ok != tt.wantOK || math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Cosine() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	candidates := []models.IncidentEmbedding{
+		{IncidentID: "inc-far", Vector: []float64{0, 1}},
+		{IncidentID: "inc-close", Vector: []float64{0.9, 0.1}},
+		{IncidentID: "inc-same-b", Vector: []float64{2, 0}},
+		{IncidentID: "inc-same-a", Vector: []float64{1, 0}},
+		{IncidentID: "inc-other-model", Vector: []float64{1, 0, 0}},
+	}
+
+	matches := Rank([]float64{1, 0}, candidates, 0.8, 10)
+	want := []string{"inc-same-a", "inc-same-b", "inc-close"}
+	if len(matches) != len(want) {
+		t.Fatalf("expected %d matches, got %+v", len(want), matches)
+	}
+	for i, id := range want {
+		if matches[i].IncidentID != id {
+			t.Errorf("match %d: expected %s, got %s", i, id, matches[i].IncidentID)
+		}
+	}
+
+	if matches := Rank([]float64{1, 0}, candidates, 0.8, 1); len(matches) != 1 {
+		t.Errorf("expected the limit to apply, got %+v", matches)
+	}
+}
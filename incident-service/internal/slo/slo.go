@@ -0,0 +1,133 @@
+package slo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// Repository defines the queries needed to evaluate SLOs
+type Repository interface {
+	ListWithFilter(filter *database.IncidentFilter) ([]*models.Incident, error)
+}
+
+// Result is the compliance of one SLO over its rolling window
+type Result struct {
+	Name                 string  `json:"name"`
+	Description          string  `json:"description,omitempty"`
+	Severity             string  `json:"severity,omitempty"`
+	ServiceName          string  `json:"service_name,omitempty"`
+	Target               float64 `json:"target"`
+	Within               string  `json:"within"`
+	Window               string  `json:"window"`
+	Eligible             int     `json:"eligible"` // incidents whose outcome is known
+	Met                  int     `json:"met"`
+	Breached             int     `json:"breached"`
+	Pending              int     `json:"pending"` // open and still within the objective
+	Compliance           float64 `json:"compliance"`
+	ErrorBudgetRemaining float64 `json:"error_budget_remaining"` // 1 is untouched, 0 or below is exhausted
+	Compliant            bool    `json:"compliant"`
+}
+
+// Evaluator computes SLO compliance from incident timestamps
+type Evaluator struct {
+	objectives []config.SLO
+	repo       Repository
+	now        func() time.Time
+}
+
+// NewEvaluator creates a new SLO evaluator
+func NewEvaluator(objectives []config.SLO, repo Repository) *Evaluator {
+	return &Evaluator{
+		objectives: objectives,
+		repo:       repo,
+		now:        time.Now,
+	}
+}
+
+// Evaluate computes compliance for every configured SLO
+func (e *Evaluator) Evaluate() ([]Result, error) {
+	now := e.now()
+	results := make([]Result, 0, len(e.objectives))
+
+	for _, objective := range e.objectives {
+		start := now.Add(-objective.RollingWindow())
+		filter := &database.IncidentFilter{StartTime: &start}
+		if objective.ServiceName != "" {
+			serviceName := objective.ServiceName
+			filter.ServiceName = &serviceName
+		}
+
+		incidents, err := e.repo.ListWithFilter(filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list incidents for slo '%s': %w", objective.Name, err)
+		}
+
+		results = append(results, Evaluate(objective, incidents, now))
+	}
+
+	return results, nil
+}
+
+// Evaluate computes compliance for one SLO. An incident meets the objective
+// when a pull request was created within the objective's time limit. Incidents
+// that failed, or are still open past the limit, breach it. Incidents that
+// needed no fix are not counted.
+func Evaluate(objective config.SLO, incidents []*models.Incident, now time.Time) Result {
+	result := Result{
+		Name:        objective.Name,
+		Description: objective.Description,
+		Severity:    objective.Severity,
+		ServiceName: objective.ServiceName,
+		Target:      objective.Target,
+		Within:      objective.Within.String(),
+		Window:      objective.RollingWindow().String(),
+	}
+
+	for _, incident := range incidents {
+		if objective.Severity != "" && incident.Severity != objective.Severity {
+			continue
+		}
+		if objective.ServiceName != "" && incident.ServiceName != objective.ServiceName {
+			continue
+		}
+
+		switch {
+		case incident.Status == models.StatusNoFixNeeded:
+			continue
+		case incident.PullRequestURL != nil && incident.CompletedAt != nil:
+			if incident.CompletedAt.Sub(incident.CreatedAt) <= objective.Within {
+				result.Met++
+			} else {
+				result.Breached++
+			}
+		case incident.Status == models.StatusFailed:
+			result.Breached++
+		case now.Sub(incident.CreatedAt) > objective.Within:
+			result.Breached++
+		default:
+			result.Pending++
+		}
+	}
+
+	result.Eligible = result.Met + result.Breached
+	result.Compliance = 1
+	result.ErrorBudgetRemaining = 1
+	if result.Eligible > 0 {
+		result.Compliance = float64(result.Met) / float64(result.Eligible)
+
+		allowed := (1 - objective.Target) * float64(result.Eligible)
+		switch {
+		case allowed > 0:
+			result.ErrorBudgetRemaining = 1 - float64(result.Breached)/allowed
+		case result.Breached > 0:
+			result.ErrorBudgetRemaining = 0
+		}
+	}
+	result.Compliant = result.Compliance >= objective.Target
+
+	return result
+}
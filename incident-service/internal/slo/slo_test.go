@@ -0,0 +1,202 @@
+package slo
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+var testNow = time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+
+func newIncident(id, severity string, status models.IncidentStatus, age time.Duration, prAfter time.Duration) *models.Incident {
+	incident := &models.Incident{
+		ID:          id,
+		ServiceName: "checkout",
+		Severity:    severity,
+		Status:      status,
+		CreatedAt:   testNow.Add(-age),
+	}
+	if prAfter > 0 {
+		prURL := "https://github.com/org/repo/pull/1"
+		completed := incident.CreatedAt.Add(prAfter)
+		incident.PullRequestURL = &prURL
+		incident.CompletedAt = &completed
+	}
+	return incident
+}
+
+func TestEvaluate(t *testing.T) {
+	objective := config.SLO{
+		Name:     "critical-pr-30m",
+		Severity: "critical",
+		Target:   0.5,
+		Within:   30 * time.Minute,
+	}
+
+	tests := []struct {
+		name           string
+		incidents      []*models.Incident
+		wantEligible   int
+		wantMet        int
+		wantBreached   int
+		wantPending    int
+		wantCompliance float64
+		wantBudget     float64
+		wantCompliant  bool
+	}{
+		{
+			name:           "no incidents is fully compliant",
+			wantCompliance: 1,
+			wantBudget:     1,
+			wantCompliant:  true,
+		},
+		{
+			name: "pull request within the limit meets the objective",
+			incidents: []*models.Incident{
+				newIncident("inc-1", "critical", models.StatusPRCreated, 2*time.Hour, 10*time.Minute),
+				newIncident("inc-2", "critical", models.StatusResolved, 2*time.Hour, 30*time.Minute),
+			},
+			wantEligible:   2,
+			wantMet:        2,
+			wantCompliance: 1,
+			wantBudget:     1,
+			wantCompliant:  true,
+		},
+		{
+			name: "late pull requests, failures and stale open incidents breach",
+			incidents: []*models.Incident{
+				newIncident("inc-1", "critical", models.StatusPRCreated, 2*time.Hour, 10*time.Minute),
+				newIncident("inc-2", "critical", models.StatusPRCreated, 2*time.Hour, 45*time.Minute),
+				newIncident("inc-3", "critical", models.StatusFailed, 2*time.Hour, 0),
+				newIncident("inc-4", "critical", models.StatusInProgress, time.Hour, 0),
+			},
+			wantEligible:   4,
+			wantMet:        1,
+			wantBreached:   3,
+			wantCompliance: 0.25,
+			wantBudget:     -0.5,
+			wantCompliant:  false,
+		},
+		{
+			name: "open incidents within the limit are pending",
+			incidents: []*models.Incident{
+				newIncident("inc-1", "critical", models.StatusPRCreated, 2*time.Hour, 10*time.Minute),
+				newIncident("inc-2", "critical", models.StatusWorkflowTriggered, 5*time.Minute, 0),
+			},
+			wantEligible:   1,
+			wantMet:        1,
+			wantPending:    1,
+			wantCompliance: 1,
+			wantBudget:     1,
+			wantCompliant:  true,
+		},
+		{
+			name: "other severities and no fix needed are excluded",
+			incidents: []*models.Incident{
+				newIncident("inc-1", "high", models.StatusFailed, 2*time.Hour, 0),
+				newIncident("inc-2", "critical", models.StatusNoFixNeeded, 2*time.Hour, 0),
+				newIncident("inc-3", "critical", models.StatusPRCreated, 2*time.Hour, 5*time.Minute),
+				newIncident("inc-4", "critical", models.StatusFailed, 2*time.Hour, 0),
+			},
+			wantEligible:   2,
+			wantMet:        1,
+			wantBreached:   1,
+			wantCompliance: 0.5,
+			wantBudget:     0,
+			wantCompliant:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Evaluate(objective, tt.incidents, testNow)
+
+			if result.Eligible != tt.wantEligible || result.Met != tt.wantMet || result.Breached != tt.wantBreached || result.Pending != tt.wantPending {
+				t.Errorf("counts = eligible %d, met %d, breached %d, pending %d; want %d, %d, %d, %d",
+					result.Eligible, result.Met, result.Breached, result.Pending,
+					tt.wantEligible, tt.wantMet, tt.wantBreached, tt.wantPending)
+			}
+			if math.Abs(result.Compliance-tt.wantCompliance) > 1e-9 {
+				t.Errorf("Compliance = %v, want %v", result.Compliance, tt.wantCompliance)
+			}
+			if math.Abs(result.ErrorBudgetRemaining-tt.wantBudget) > 1e-9 {
+				t.Errorf("ErrorBudgetRemaining = %v, want %v", result.ErrorBudgetRemaining, tt.wantBudget)
+			}
+			if result.Compliant != tt.wantCompliant {
+				t.Errorf("Compliant = %v, want %v", result.Compliant, tt.wantCompliant)
+			}
+		})
+	}
+}
+
+func TestEvaluate_FullTargetHasNoBudget(t *testing.T) {
+	objective := config.SLO{Name: "all", Target: 1, Within: time.Hour}
+
+	result := Evaluate(objective, []*models.Incident{
+		newIncident("inc-1", "low", models.StatusFailed, 2*time.Hour, 0),
+	}, testNow)
+
+	if result.ErrorBudgetRemaining != 0 {
+		t.Errorf("ErrorBudgetRemaining = %v, want 0", result.ErrorBudgetRemaining)
+	}
+	if result.Compliant {
+		t.Error("expected objective with a breach at target 1 to be out of compliance")
+	}
+}
+
+type stubRepository struct {
+	incidents []*models.Incident
+	filters   []*database.IncidentFilter
+	err       error
+}
+
+func (r *stubRepository) ListWithFilter(filter *database.IncidentFilter) ([]*models.Incident, error) {
+	r.filters = append(r.filters, filter)
+	return r.incidents, r.err
+}
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	repo := &stubRepository{incidents: []*models.Incident{
+		newIncident("inc-1", "critical", models.StatusPRCreated, 2*time.Hour, 10*time.Minute),
+	}}
+	evaluator := NewEvaluator([]config.SLO{
+		{Name: "critical", Severity: "critical", Target: 0.9, Within: 30 * time.Minute},
+		{Name: "checkout", ServiceName: "checkout", Target: 0.9, Within: 30 * time.Minute, Window: 24 * time.Hour},
+	}, repo)
+	evaluator.now = func() time.Time { return testNow }
+
+	results, err := evaluator.Evaluate()
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	if got := *repo.filters[0].StartTime; !got.Equal(testNow.Add(-30 * 24 * time.Hour)) {
+		t.Errorf("expected default 30 day window, got start %v", got)
+	}
+	if repo.filters[0].ServiceName != nil {
+		t.Error("expected no service filter for the severity objective")
+	}
+	if got := *repo.filters[1].StartTime; !got.Equal(testNow.Add(-24 * time.Hour)) {
+		t.Errorf("expected 24h window, got start %v", got)
+	}
+	if repo.filters[1].ServiceName == nil || *repo.filters[1].ServiceName != "checkout" {
+		t.Error("expected service filter for the service objective")
+	}
+}
+
+func TestEvaluator_EvaluateError(t *testing.T) {
+	repo := &stubRepository{err: errors.New("connection refused")}
+	evaluator := NewEvaluator([]config.SLO{{Name: "critical", Target: 0.9, Within: time.Minute}}, repo)
+
+	if _, err := evaluator.Evaluate(); err == nil {
+		t.Fatal("expected error when the repository fails")
+	}
+}
@@ -0,0 +1,153 @@
+package storm
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+// Storm is a burst of incidents from one service that is being collapsed into
+// a single storm incident
+type Storm struct {
+	ID          string // ID of the storm incident
+	ServiceName string
+	StartedAt   time.Time
+	Suppressed  int // incidents grouped under the storm so far
+}
+
+// Detector counts incidents per service over a sliding window and tracks the
+// storms in progress. It is safe for concurrent use.
+type Detector struct {
+	window       time.Duration
+	threshold    int
+	releaseBelow int
+	newID        func() string
+
+	mu       sync.Mutex
+	services map[string]*serviceVolume
+}
+
+// serviceVolume holds the recent arrivals and the active storm of one service
+type serviceVolume struct {
+	arrivals []time.Time // oldest first
+	storm    *Storm
+}
+
+// NewDetector creates a storm detector
+func NewDetector(cfg config.StormConfig) *Detector {
+	return &Detector{
+		window:       cfg.WindowSize(),
+		threshold:    cfg.StartThreshold(),
+		releaseBelow: cfg.ReleaseThreshold(),
+		newID:        newStormID,
+		services:     make(map[string]*serviceVolume),
+	}
+}
+
+// SetIDGenerator sets how storm incident IDs are generated. generate is
+// given the random ID the detector would use otherwise. It must be called
+// before the detector is used.
+func (d *Detector) SetIDGenerator(generate func(legacy string) string) {
+	d.newID = func() string { return generate(newStormID()) }
+}
+
+// Observe records an incident from the service. If the service is in a storm,
+// or this incident pushes its volume over the threshold, it returns a snapshot
+// of the storm the incident belongs to and whether this incident started it.
+func (d *Detector) Observe(serviceName string, at time.Time) (*Storm, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	volume, ok := d.services[serviceName]
+	if !ok {
+		volume = &serviceVolume{}
+		d.services[serviceName] = volume
+	}
+	volume.prune(at.Add(-d.window))
+	volume.arrivals = append(volume.arrivals, at)
+
+	started := false
+	if volume.storm == nil {
+		if len(volume.arrivals) < d.threshold {
+			return nil, false
+		}
+		volume.storm = &Storm{
+			ID:          d.newID(),
+			ServiceName: serviceName,
+			StartedAt:   at,
+		}
+		started = true
+	}
+
+	volume.storm.Suppressed++
+	snapshot := *volume.storm
+	return &snapshot, started
+}
+
+// Cancel drops the service's storm, for when its storm incident could not be
+// stored. The next incident over the threshold starts a new storm.
+func (d *Detector) Cancel(serviceName, stormID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if volume, ok := d.services[serviceName]; ok && volume.storm != nil && volume.storm.ID == stormID {
+		volume.storm = nil
+	}
+}
+
+// Active returns a snapshot of every storm in progress
+func (d *Detector) Active() []Storm {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	storms := make([]Storm, 0)
+	for _, volume := range d.services {
+		if volume.storm != nil {
+			storms = append(storms, *volume.storm)
+		}
+	}
+	return storms
+}
+
+// Release ends every storm whose service volume has fallen below the release
+// threshold, and returns them. Services without recent incidents are forgotten.
+func (d *Detector) Release(now time.Time) []Storm {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	released := make([]Storm, 0)
+	for serviceName, volume := range d.services {
+		volume.prune(now.Add(-d.window))
+
+		if volume.storm != nil && len(volume.arrivals) < d.releaseBelow {
+			released = append(released, *volume.storm)
+			volume.storm = nil
+		}
+		if volume.storm == nil && len(volume.arrivals) == 0 {
+			delete(d.services, serviceName)
+		}
+	}
+	return released
+}
+
+// prune drops arrivals older than cutoff
+func (v *serviceVolume) prune(cutoff time.Time) {
+	i := 0
+	for i < len(v.arrivals) && v.arrivals[i].Before(cutoff) {
+		i++
+	}
+	v.arrivals = v.arrivals[i:]
+}
+
+// newStormID returns a random ID for a storm incident
+func newStormID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("inc_storm_%x", time.Now().UnixNano())
+	}
+	return "inc_storm_" + hex.EncodeToString(b)
+}
@@ -0,0 +1,103 @@
+package storm
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+)
+
+func TestDetector_StartsJoinsAndReleasesStorm(t *testing.T) {
+	start := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	detector := NewDetector(config.StormConfig{Window: time.Minute, Threshold: 3, ReleaseBelow: 2})
+
+	for i := 0; i < 2; i++ {
+		if storm, _ := detector.Observe("api", start.Add(time.Duration(i)*time.Second)); storm != nil {
+			t.Fatalf("incident %d: expected no storm below the threshold", i)
+		}
+	}
+
+	storm, started := detector.Observe("api", start.Add(2*time.Second))
+	if storm == nil || !started {
+		t.Fatal("expected the third incident to start a storm")
+	}
+	if storm.ServiceName != "api" || storm.ID == "" || storm.Suppressed != 1 {
+		t.Errorf("unexpected storm %+v", storm)
+	}
+
+	joined, started := detector.Observe("api", start.Add(3*time.Second))
+	if joined == nil || started || joined.ID != storm.ID || joined.Suppressed != 2 {
+		t.Errorf("expected the next incident to join storm %s, got %+v started=%v", storm.ID, joined, started)
+	}
+
+	// Other services are counted separately
+	if other, _ := detector.Observe("billing", start.Add(3*time.Second)); other != nil {
+		t.Errorf("expected no storm for another service, got %+v", other)
+	}
+
+	// Still four incidents within the window
+	if released := detector.Release(start.Add(30 * time.Second)); len(released) != 0 {
+		t.Errorf("expected the storm to continue, released %v", released)
+	}
+	if active := detector.Active(); len(active) != 1 {
+		t.Errorf("expected one active storm, got %d", len(active))
+	}
+
+	// One incident within the window is below release_below
+	detector.Observe("api", start.Add(50*time.Second))
+	released := detector.Release(start.Add(65 * time.Second))
+	if len(released) != 1 || released[0].ID != storm.ID || released[0].Suppressed != 3 {
+		t.Fatalf("expected storm %s released with 3 suppressed incidents, got %+v", storm.ID, released)
+	}
+	if active := detector.Active(); len(active) != 0 {
+		t.Errorf("expected no active storms after release, got %d", len(active))
+	}
+
+	if next, _ := detector.Observe("api", start.Add(66*time.Second)); next != nil {
+		t.Errorf("expected incidents after release to be handled individually, got %+v", next)
+	}
+}
+
+func TestDetector_Cancel(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	detector := NewDetector(config.StormConfig{Threshold: 2})
+
+	detector.Observe("api", now)
+	storm, started := detector.Observe("api", now)
+	if !started {
+		t.Fatal("expected a storm to start")
+	}
+
+	detector.Cancel("api", "inc_storm_other")
+	if len(detector.Active()) != 1 {
+		t.Fatal("expected cancelling another storm ID to be ignored")
+	}
+
+	detector.Cancel("api", storm.ID)
+	if len(detector.Active()) != 0 {
+		t.Fatal("expected the storm to be cancelled")
+	}
+
+	// Volume is still above the threshold, so the next incident starts a new storm
+	next, started := detector.Observe("api", now)
+	if next == nil || !started || next.ID == storm.ID {
+		t.Errorf("expected a new storm after cancel, got %+v started=%v", next, started)
+	}
+}
+
+func TestDetector_SetIDGenerator(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	detector := NewDetector(config.StormConfig{Threshold: 1})
+	detector.SetIDGenerator(func(legacy string) string {
+		if !strings.HasPrefix(legacy, "inc_storm_") {
+			t.Errorf("expected the legacy storm ID, got %q", legacy)
+		}
+		return "inc_generated"
+	})
+
+	storm, started := detector.Observe("api", now)
+	if !started || storm.ID != "inc_generated" {
+		t.Errorf("expected the storm to get the generated ID, got %+v", storm)
+	}
+}
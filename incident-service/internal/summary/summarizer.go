@@ -0,0 +1,168 @@
+// Package summary writes short summaries of incidents, with the category of
+// their suspected root cause, using a language model served behind an
+// OpenAI-compatible chat completions API.
+package summary
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/textutil"
+)
+
+// maxStackTrace bounds how much of a stack trace is sent to the model
+const maxStackTrace = 4000
+
+// systemPrompt tells the model what to answer with
+var systemPrompt = `You summarize production incidents for on-call engineers.
+Given an incident, answer with a JSON object with two fields:
+"summary": at most three plain sentences saying what failed, where, and the likely cause;
+"category": the suspected root cause, one of ` + strings.Join(models.RootCauseCategories, ", ") + `.
+Answer with the JSON object only.`
+
+// Summarizer asks a language model to summarize incidents
+type Summarizer struct {
+	config     config.SummaryConfig
+	httpClient *http.Client
+}
+
+// NewSummarizer creates a summarizer for the endpoint configured by cfg
+func NewSummarizer(cfg config.SummaryConfig) (*Summarizer, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &Summarizer{
+		config:     cfg,
+		httpClient: &http.Client{Timeout: cfg.RequestTimeout()},
+	}, nil
+}
+
+type message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type completionRequest struct {
+	Model          string            `json:"model"`
+	Messages       []message         `json:"messages"`
+	Temperature    float64           `json:"temperature"`
+	MaxTokens      int               `json:"max_tokens"`
+	ResponseFormat map[string]string `json:"response_format"`
+}
+
+type completionResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message message `json:"message"`
+	} `json:"choices"`
+}
+
+// answer is the JSON object the model is asked for
+type answer struct {
+	Summary  string `json:"summary"`
+	Category string `json:"category"`
+}
+
+// Summarize asks the model for a summary of the incident. A category the
+// model makes up is recorded as unknown.
+func (s *Summarizer) Summarize(ctx context.Context, incident *models.Incident) (*models.IncidentSummary, error) {
+	body, err := json.Marshal(completionRequest{
+		Model: s.config.Model,
+		Messages: []message{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: describe(incident)},
+		},
+		MaxTokens:      s.config.AnswerTokens(),
+		ResponseFormat: map[string]string{"type": "json_object"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := strings.TrimRight(s.config.BaseURL, "/") + "/chat/completions"
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.config.APIKey)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var completion completionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(completion.Choices) == 0 {
+		return nil, fmt.Errorf("response has no choices")
+	}
+
+	parsed, err := parseAnswer(completion.Choices[0].Message.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	model := completion.Model
+	if model == "" {
+		model = s.config.Model
+	}
+	return &models.IncidentSummary{
+		Text:              parsed.Summary,
+		RootCauseCategory: models.NormalizeRootCause(parsed.Category),
+		Model:             model,
+		GeneratedAt:       time.Now().UTC(),
+	}, nil
+}
+
+// describe renders the incident for the model
+func describe(incident *models.Incident) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Service: %s\n", incident.ServiceName)
+	fmt.Fprintf(&b, "Repository: %s\n", incident.Repository)
+	fmt.Fprintf(&b, "Severity: %s\n", incident.Severity)
+	fmt.Fprintf(&b, "Provider: %s\n", incident.Provider)
+	fmt.Fprintf(&b, "Error: %s\n", incident.ErrorMessage)
+	if incident.StackTrace != nil && *incident.StackTrace != "" {
+		fmt.Fprintf(&b, "Stack trace:\n%s\n", textutil.Truncate(*incident.StackTrace, maxStackTrace))
+	}
+	return b.String()
+}
+
+// parseAnswer reads the JSON object out of the model's reply. Models that
+// ignore the response format tend to wrap it in prose or a code fence, so
+// the outermost braces are used.
+func parseAnswer(content string) (*answer, error) {
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("reply is not a JSON object: %q", textutil.Truncate(content, 200))
+	}
+
+	var parsed answer
+	if err := json.Unmarshal([]byte(content[start:end+1]), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to decode reply: %w", err)
+	}
+	parsed.Summary = strings.TrimSpace(parsed.Summary)
+	if parsed.Summary == "" {
+		return nil, fmt.Errorf("reply has no summary")
+	}
+	return &parsed, nil
+}
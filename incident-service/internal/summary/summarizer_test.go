@@ -0,0 +1,144 @@
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// fakeModel serves chat completions answering with reply
+func fakeModel(t *testing.T, reply string, requests *[]completionRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("expected the api key as bearer token, got %q", got)
+		}
+		var req completionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		*requests = append(*requests, req)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"model": "gpt-4o-mini-2024-07-18",
+			"choices": []map[string]interface{}{
+				{"message": map[string]string{"role": "assistant", "content": reply}},
+			},
+		})
+	}))
+}
+
+func newTestSummarizer(t *testing.T, url string) *Summarizer {
+	summarizer, err := NewSummarizer(config.SummaryConfig{
+		Enabled: true,
+		BaseURL: url + "/v1/",
+		APIKey:  "secret",
+		Model:   "gpt-4o-mini",
+	})
+	if err != nil {
+		t.Fatalf("failed to create summarizer: %v", err)
+	}
+	return summarizer
+}
+
+func TestSummarizer_Summarize(t *testing.T) {
+	var requests []completionRequest
+	server := fakeModel(t, `{"summary": "The checkout service ran out of database connections.", "category": "Capacity"}`, &requests)
+	defer server.Close()
+
+	stackTrace := "at db.Pool.Acquire"
+	incident := &models.Incident{
+		ID:           "inc-1",
+		ServiceName:  "checkout",
+		Repository:   "org/checkout",
+		Severity:     "high",
+		Provider:     "datadog",
+		ErrorMessage: "connection pool exhausted",
+		StackTrace:   &stackTrace,
+	}
+	result, err := newTestSummarizer(t, server.URL).Summarize(context.Background(), incident)
+	if err != nil {
+		t.Fatalf("Summarize failed: %v", err)
+	}
+
+	if result.Text != "The checkout service ran out of database connections." {
+		t.Errorf("unexpected summary %q", result.Text)
+	}
+	if result.RootCauseCategory != models.RootCauseCapacity {
+		t.Errorf("expected the capacity category, got %q", result.RootCauseCategory)
+	}
+	if result.Model != "gpt-4o-mini-2024-07-18" {
+		t.Errorf("expected the model reported by the endpoint, got %q", result.Model)
+	}
+	if result.GeneratedAt.IsZero() {
+		t.Error("expected the generation time to be set")
+	}
+
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+	req := requests[0]
+	if req.Model != "gpt-4o-mini" || req.MaxTokens != 300 {
+		t.Errorf("unexpected request %+v", req)
+	}
+	if len(req.Messages) != 2 || !strings.Contains(req.Messages[1].Content, "connection pool exhausted") ||
+		!strings.Contains(req.Messages[1].Content, "at db.Pool.Acquire") {
+		t.Errorf("expected the incident to be described to the model, got %+v", req.Messages)
+	}
+}
+
+func TestSummarizer_SummarizeErrors(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer failing.Close()
+
+	var requests []completionRequest
+	prose := fakeModel(t, "I cannot tell what happened.", &requests)
+	defer prose.Close()
+
+	for name, url := range map[string]string{"error status": failing.URL, "no json": prose.URL} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := newTestSummarizer(t, url).Summarize(context.Background(), &models.Incident{ID: "inc-1"}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestParseAnswer(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		summary  string
+		category string
+		wantErr  bool
+	}{
+		{"plain", `{"summary":"Bad deploy.","category":"code"}`, "Bad deploy.", "code", false},
+		{"code fence", "```json\n{\"summary\": \" Bad deploy. \", \"category\": \"code\"}\n```", "Bad deploy.", "code", false},
+		{"empty summary", `{"summary":"","category":"code"}`, "", "", true},
+		{"not json", "no idea", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseAnswer(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAnswer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (parsed.Summary != tt.summary || parsed.Category != tt.category) {
+				t.Errorf("unexpected answer %+v", parsed)
+			}
+		})
+	}
+}
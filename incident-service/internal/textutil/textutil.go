@@ -0,0 +1,20 @@
+// Package textutil holds string helpers shared by the packages that bound
+// what they send to other services, such as LLM prompts, embeddings and
+// workflow inputs.
+package textutil
+
+import "unicode/utf8"
+
+// Truncate cuts s to at most n bytes without splitting a character
+func Truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	if n < 0 {
+		n = 0
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,39 @@
+package textutil
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		n    int
+		want string
+	}{
+		{"short", "boom", 10, "boom"},
+		{"exact", "boom", 4, "boom"},
+		{"ascii", "connection refused", 10, "connection"},
+		{"inside a character", "naïve", 3, "na"},
+		{"after a character", "naïve", 4, "naï"},
+		{"zero", "boom", 0, ""},
+		{"negative", "boom", -1, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Truncate(tt.s, tt.n); got != tt.want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+			}
+		})
+	}
+
+	s := strings.Repeat("日本", 50)
+	for n := 0; n <= len(s); n++ {
+		got := Truncate(s, n)
+		if len(got) > n || !utf8.ValidString(got) || n-len(got) >= utf8.UTFMax {
+			t.Fatalf("Truncate(s, %d) = %d bytes, valid %v", n, len(got), utf8.ValidString(got))
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func useRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestSetup_Disabled(t *testing.T) {
+	shutdown, err := Setup(context.Background(), config.TracingConfig{})
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown() error = %v", err)
+	}
+}
+
+func TestTraceParent(t *testing.T) {
+	if got := TraceParent(context.Background()); got != "" {
+		t.Errorf("TraceParent() without span = %q, want empty", got)
+	}
+
+	useRecorder(t)
+
+	ctx, span := Start(context.Background(), "test")
+	defer span.End()
+
+	sc := span.SpanContext()
+	want := fmt.Sprintf("00-%s-%s-01", sc.TraceID(), sc.SpanID())
+	if got := TraceParent(ctx); got != want {
+		t.Errorf("TraceParent() = %q, want %q", got, want)
+	}
+}
+
+func TestEnd_RecordsError(t *testing.T) {
+	recorder := useRecorder(t)
+
+	_, ok := Start(context.Background(), "ok")
+	End(ok, nil)
+	_, failed := Start(context.Background(), "failed")
+	End(failed, errors.New("boom"))
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 ended spans, got %d", len(spans))
+	}
+	if spans[0].Status().Code != codes.Unset {
+		t.Errorf("expected unset status for successful span, got %v", spans[0].Status().Code)
+	}
+	if spans[1].Status().Code != codes.Error || spans[1].Status().Description != "boom" {
+		t.Errorf("expected error status for failed span, got %+v", spans[1].Status())
+	}
+	if len(spans[1].Events()) != 1 {
+		t.Errorf("expected error event on failed span, got %d events", len(spans[1].Events()))
+	}
+}
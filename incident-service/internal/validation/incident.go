@@ -0,0 +1,163 @@
+// Package validation checks and cleans the incident fields parsed from
+// provider webhooks before they are stored, so that bad input is rejected
+// with the fields at fault instead of failing in the database.
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// Field limits, in characters. The short fields match their database
+// columns; the long ones bound what is worth storing and sending to the
+// remediation workflow.
+const (
+	MaxIDLength           = 255
+	MaxServiceNameLength  = 255
+	MaxRepositoryLength   = 255
+	MaxProviderLength     = 50
+	MaxProviderRefLength  = 255
+	MaxErrorMessageLength = 64 << 10
+	MaxStackTraceLength   = 1 << 20
+)
+
+// Severities are the severities an incident may have
+var Severities = []string{"critical", "high", "medium", "low"}
+
+// FieldError describes why one field of an incident is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is returned for an incident with invalid fields, listing each of them
+type Error struct {
+	Fields []FieldError
+}
+
+func (e *Error) Error() string {
+	parts := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		parts[i] = field.Field + " " + field.Message
+	}
+	return "invalid incident: " + strings.Join(parts, "; ")
+}
+
+// Incident cleans the text fields of an incident in place and checks them.
+// Invalid UTF-8 is replaced, and control characters are removed, except for
+// line breaks and tabs in the error message and stack trace. NUL characters,
+// which PostgreSQL cannot store, are removed from the provider data. It
+// returns an *Error listing every field that is missing, too long, or has a
+// value the platform does not accept.
+func Incident(incident *models.Incident) error {
+	incident.ID = cleanLine(incident.ID)
+	incident.ServiceName = cleanLine(incident.ServiceName)
+	incident.Repository = cleanLine(incident.Repository)
+	incident.Severity = cleanLine(incident.Severity)
+	incident.Provider = cleanLine(incident.Provider)
+	incident.ProviderRef = cleanLine(incident.ProviderRef)
+	incident.ErrorMessage = cleanText(incident.ErrorMessage)
+	if incident.StackTrace != nil {
+		stackTrace := cleanText(*incident.StackTrace)
+		incident.StackTrace = &stackTrace
+	}
+	if incident.ProviderData != nil {
+		incident.ProviderData = stripNUL(incident.ProviderData).(map[string]interface{})
+	}
+
+	var errs []FieldError
+	check := func(field, value string, required bool, max int) {
+		switch {
+		case required && value == "":
+			errs = append(errs, FieldError{Field: field, Message: "is required"})
+		case utf8.RuneCountInString(value) > max:
+			errs = append(errs, FieldError{Field: field, Message: fmt.Sprintf("must be at most %d characters", max)})
+		}
+	}
+	check("id", incident.ID, true, MaxIDLength)
+	check("service_name", incident.ServiceName, true, MaxServiceNameLength)
+	check("repository", incident.Repository, false, MaxRepositoryLength)
+	check("provider", incident.Provider, true, MaxProviderLength)
+	check("provider_ref", incident.ProviderRef, false, MaxProviderRefLength)
+	check("error_message", incident.ErrorMessage, true, MaxErrorMessageLength)
+	if incident.StackTrace != nil {
+		check("stack_trace", *incident.StackTrace, false, MaxStackTraceLength)
+	}
+	if !knownSeverity(incident.Severity) {
+		errs = append(errs, FieldError{
+			Field:   "severity",
+			Message: "must be one of " + strings.Join(Severities, ", "),
+		})
+	}
+
+	if len(errs) > 0 {
+		return &Error{Fields: errs}
+	}
+	return nil
+}
+
+func knownSeverity(severity string) bool {
+	for _, known := range Severities {
+		if severity == known {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanLine returns a single-line value with valid UTF-8, no control
+// characters, and no surrounding space
+func cleanLine(value string) string {
+	value = strings.ToValidUTF8(value, "�")
+	value = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, value)
+	return strings.TrimSpace(value)
+}
+
+// cleanText returns multi-line text with valid UTF-8, "\n" line breaks, and
+// no control characters besides line breaks and tabs
+func cleanText(value string) string {
+	value = strings.ToValidUTF8(value, "�")
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if r == '\r' {
+			return '\n'
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, value)
+}
+
+// stripNUL removes NUL characters from the strings and keys of decoded JSON
+func stripNUL(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return strings.ReplaceAll(v, "\x00", "")
+	case map[string]interface{}:
+		cleaned := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			cleaned[strings.ReplaceAll(key, "\x00", "")] = stripNUL(item)
+		}
+		return cleaned
+	case []interface{}:
+		for i, item := range v {
+			v[i] = stripNUL(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,103 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func validIncident() *models.Incident {
+	stackTrace := "at main.go:10"
+	return &models.Incident{
+		ID:           "inc_123",
+		ServiceName:  "api-gateway",
+		Repository:   "org/api-gateway",
+		ErrorMessage: "connection refused",
+		StackTrace:   &stackTrace,
+		Severity:     "high",
+		Provider:     "datadog",
+		ProviderData: map[string]interface{}{},
+	}
+}
+
+func TestIncident_Valid(t *testing.T) {
+	if err := Incident(validIncident()); err != nil {
+		t.Errorf("Incident() error = %v", err)
+	}
+}
+
+func TestIncident_Sanitizes(t *testing.T) {
+	incident := validIncident()
+	incident.ServiceName = " api-\x1b[31mgateway\x00 "
+	incident.ErrorMessage = "line one\r\nline\ttwo\x07\xff"
+	stackTrace := "frame\x00one\nframe two"
+	incident.StackTrace = &stackTrace
+	incident.ProviderData = map[string]interface{}{
+		"tags": []interface{}{"env:\x00prod"},
+		"alert": map[string]interface{}{
+			"title\x00": "disk\x00 full",
+		},
+	}
+
+	if err := Incident(incident); err != nil {
+		t.Fatalf("Incident() error = %v", err)
+	}
+
+	if incident.ServiceName != "api-[31mgateway" {
+		t.Errorf("expected control characters and surrounding space removed, got %q", incident.ServiceName)
+	}
+	if incident.ErrorMessage != "line one\nline\ttwo�" {
+		t.Errorf("expected line breaks and tabs kept and invalid UTF-8 replaced, got %q", incident.ErrorMessage)
+	}
+	if *incident.StackTrace != "frameone\nframe two" {
+		t.Errorf("unexpected stack trace %q", *incident.StackTrace)
+	}
+	if tags := incident.ProviderData["tags"].([]interface{}); tags[0] != "env:prod" {
+		t.Errorf("expected NUL removed from provider data, got %q", tags[0])
+	}
+	if alert := incident.ProviderData["alert"].(map[string]interface{}); alert["title"] != "disk full" {
+		t.Errorf("expected NUL removed from provider data keys and values, got %v", alert)
+	}
+}
+
+func TestIncident_FieldErrors(t *testing.T) {
+	incident := validIncident()
+	incident.ServiceName = strings.Repeat("a", MaxServiceNameLength+1)
+	incident.ErrorMessage = "\x00\x01"
+	incident.Severity = "urgent"
+
+	err := Incident(incident)
+	var invalid *Error
+	if !errors.As(err, &invalid) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+
+	fields := map[string]string{}
+	for _, field := range invalid.Fields {
+		fields[field.Field] = field.Message
+	}
+	if len(fields) != 3 {
+		t.Errorf("expected 3 invalid fields, got %v", invalid.Fields)
+	}
+	if fields["service_name"] != "must be at most 255 characters" {
+		t.Errorf("unexpected service_name error %q", fields["service_name"])
+	}
+	// Only control characters is as good as empty
+	if fields["error_message"] != "is required" {
+		t.Errorf("unexpected error_message error %q", fields["error_message"])
+	}
+	if !strings.HasPrefix(fields["severity"], "must be one of") {
+		t.Errorf("unexpected severity error %q", fields["severity"])
+	}
+}
+
+func TestIncident_LengthInCharacters(t *testing.T) {
+	// Limits count characters, like the database columns, not bytes
+	incident := validIncident()
+	incident.ServiceName = strings.Repeat("é", MaxServiceNameLength)
+	if err := Incident(incident); err != nil {
+		t.Errorf("Incident() error = %v", err)
+	}
+}
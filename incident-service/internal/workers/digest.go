@@ -0,0 +1,237 @@
+package workers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/notifications"
+)
+
+// DigestRepository defines the queries needed to build digests
+type DigestRepository interface {
+	GetStatistics(filter *database.IncidentFilter) (*database.IncidentStatistics, error)
+	GetTopFailingServices(start, end time.Time, limit int) ([]database.ServiceFailureCount, error)
+	ListWithFilter(filter *database.IncidentFilter) ([]*models.Incident, error)
+}
+
+// openStatuses are the statuses of incidents still awaiting an outcome
+var openStatuses = []models.IncidentStatus{
+	models.StatusPending,
+	models.StatusWorkflowTriggered,
+	models.StatusInProgress,
+}
+
+// maxDigestOpenIncidents limits how many open incidents are listed individually
+const maxDigestOpenIncidents = 10
+
+// digestTemplate renders the body of digest notifications
+var digestTemplate = template.Must(template.New("digest").Parse(`{{.Title}}
+{{.Start.Format "2006-01-02 15:04"}} - {{.End.Format "2006-01-02 15:04"}} UTC
+
+Incidents: {{.Stats.TotalIncidents}} ({{.Stats.ResolvedIncidents}} resolved, {{.Stats.FailedIncidents}} failed)
+Success rate: {{printf "%.1f" .SuccessRate}}%
+MTTR: {{.MTTR}}
+{{if or .Stats.AffectedUsers .Stats.DowntimeMinutes .Stats.PeakErrorRate}}Impact: {{.Stats.AffectedUsers}} users affected, {{.Stats.DowntimeMinutes}}m downtime, peak error rate {{printf "%.1f" .PeakErrorRate}}%
+{{end}}{{if .TopFailing}}
+Top failing services:
+{{range .TopFailing}}  - {{.ServiceName}}: {{.Failures}} failed
+{{end}}{{end}}
+Open incidents: {{len .Open}}
+{{range .OpenShown}}  - {{.ID}} [{{.Severity}}] {{.ServiceName}} ({{.Status}})
+{{end}}{{if gt (len .Open) (len .OpenShown)}}  ... and {{.OpenMore}} more
+{{end}}{{if .DashboardURL}}
+{{.DashboardURL}}
+{{end}}`))
+
+// digestData is the data passed to the digest template
+type digestData struct {
+	Title         string
+	Start         time.Time
+	End           time.Time
+	Stats         *database.IncidentStatistics
+	SuccessRate   float64
+	PeakErrorRate float64
+	MTTR          string
+	TopFailing    []database.ServiceFailureCount
+	Open          []*models.Incident
+	OpenShown     []*models.Incident
+	OpenMore      int
+	DashboardURL  string
+}
+
+// DigestWorker sends scheduled summaries of incident activity to channels and email
+type DigestWorker struct {
+	repo         DigestRepository
+	notifier     *notifications.Dispatcher
+	schedules    []config.DigestSchedule
+	topServices  int
+	dashboardURL string
+	logger       Logger
+	lastSent     map[string]time.Time
+	stopCh       chan struct{}
+	now          func() time.Time
+}
+
+// NewDigestWorker creates a new digest worker
+func NewDigestWorker(cfg config.DigestConfig, dashboardURL string, repo DigestRepository, notifier *notifications.Dispatcher, logger Logger) *DigestWorker {
+	topServices := cfg.TopServices
+	if topServices <= 0 {
+		topServices = 5
+	}
+
+	return &DigestWorker{
+		repo:         repo,
+		notifier:     notifier,
+		schedules:    cfg.Schedules,
+		topServices:  topServices,
+		dashboardURL: strings.TrimRight(dashboardURL, "/"),
+		logger:       logger,
+		lastSent:     make(map[string]time.Time),
+		stopCh:       make(chan struct{}),
+		now:          time.Now,
+	}
+}
+
+// Start checks for due digests at the given interval until Stop is called
+func (w *DigestWorker) Start(interval time.Duration) {
+	started("digest", interval)
+	defer stopped("digest")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("digest")
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if err := w.Check(ctx); err != nil {
+				w.logger.Error("digest check failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+			cancel()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the digest worker
+func (w *DigestWorker) Stop() {
+	close(w.stopCh)
+}
+
+// Check sends every digest whose scheduled time has passed since it was last sent.
+// Slots that passed before the first check are skipped, and a digest that fails
+// to send is retried on the next check.
+func (w *DigestWorker) Check(ctx context.Context) error {
+	now := w.now()
+
+	for i := range w.schedules {
+		schedule := &w.schedules[i]
+
+		run, err := schedule.LastRun(now)
+		if err != nil {
+			return fmt.Errorf("invalid digest schedule '%s': %w", schedule.Name, err)
+		}
+
+		lastSent, seen := w.lastSent[schedule.Name]
+		if !seen {
+			w.lastSent[schedule.Name] = run
+			continue
+		}
+		if !run.After(lastSent) {
+			continue
+		}
+
+		subject, text, err := w.Build(schedule, run)
+		if err != nil {
+			return err
+		}
+
+		if err := w.notifier.SendDigest(ctx, schedule.Route(), subject, text); err != nil {
+			return fmt.Errorf("failed to send digest '%s': %w", schedule.Name, err)
+		}
+
+		w.lastSent[schedule.Name] = run
+		w.logger.Info("Digest sent", map[string]interface{}{
+			"digest": schedule.Name,
+			"period": schedule.Period().String(),
+		})
+	}
+
+	return nil
+}
+
+// Build renders the digest covering the schedule's period ending at end
+func (w *DigestWorker) Build(schedule *config.DigestSchedule, end time.Time) (string, string, error) {
+	start := end.Add(-schedule.Period())
+
+	stats, err := w.repo.GetStatistics(&database.IncidentFilter{StartTime: &start, EndTime: &end})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get digest statistics: %w", err)
+	}
+
+	topFailing, err := w.repo.GetTopFailingServices(start, end, w.topServices)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get top failing services: %w", err)
+	}
+
+	var open []*models.Incident
+	for _, status := range openStatuses {
+		status := status
+		incidents, err := w.repo.ListWithFilter(&database.IncidentFilter{Status: &status})
+		if err != nil {
+			return "", "", fmt.Errorf("failed to list open incidents: %w", err)
+		}
+		open = append(open, incidents...)
+	}
+
+	// Oldest open incidents are the most interesting to readers
+	sort.Slice(open, func(i, j int) bool {
+		return open[i].CreatedAt.Before(open[j].CreatedAt)
+	})
+
+	title := "Daily incident digest"
+	if schedule.Frequency == "weekly" {
+		title = "Weekly incident digest"
+	}
+
+	data := digestData{
+		Title:         title,
+		Start:         start.UTC(),
+		End:           end.UTC(),
+		Stats:         stats,
+		SuccessRate:   stats.SuccessRate * 100,
+		PeakErrorRate: stats.PeakErrorRate * 100,
+		MTTR:          "n/a",
+		TopFailing:    topFailing,
+		Open:          open,
+		OpenShown:     open,
+		DashboardURL:  w.dashboardURL,
+	}
+	if stats.MeanTimeToResolve > 0 {
+		data.MTTR = time.Duration(stats.MeanTimeToResolve * float64(time.Second)).Round(time.Second).String()
+	}
+	if len(open) > maxDigestOpenIncidents {
+		data.OpenShown = open[:maxDigestOpenIncidents]
+		data.OpenMore = len(open) - maxDigestOpenIncidents
+	}
+
+	var buf bytes.Buffer
+	if err := digestTemplate.Execute(&buf, data); err != nil {
+		return "", "", fmt.Errorf("failed to render digest: %w", err)
+	}
+
+	subject := fmt.Sprintf("%s: %s", title, end.UTC().Format("2006-01-02"))
+	return subject, buf.String(), nil
+}
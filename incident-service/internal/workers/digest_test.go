@@ -0,0 +1,182 @@
+package workers
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/notifications"
+)
+
+func (m *mockRepository) GetStatistics(filter *database.IncidentFilter) (*database.IncidentStatistics, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := &database.IncidentStatistics{}
+	var resolutionTotal float64
+	var resolutionCount int
+	for _, incident := range m.incidents {
+		if filter != nil && filter.StartTime != nil && incident.CreatedAt.Before(*filter.StartTime) {
+			continue
+		}
+		if filter != nil && filter.EndTime != nil && incident.CreatedAt.After(*filter.EndTime) {
+			continue
+		}
+
+		stats.TotalIncidents++
+		switch incident.Status {
+		case models.StatusResolved, models.StatusPRCreated:
+			stats.ResolvedIncidents++
+		case models.StatusFailed:
+			stats.FailedIncidents++
+		}
+		if incident.CompletedAt != nil {
+			resolutionTotal += incident.CompletedAt.Sub(incident.CreatedAt).Seconds()
+			resolutionCount++
+		}
+		if incident.AffectedUsers != nil {
+			stats.AffectedUsers += *incident.AffectedUsers
+		}
+		if incident.DowntimeMinutes != nil {
+			stats.DowntimeMinutes += int64(*incident.DowntimeMinutes)
+		}
+		if incident.ErrorRate != nil && *incident.ErrorRate > stats.PeakErrorRate {
+			stats.PeakErrorRate = *incident.ErrorRate
+		}
+	}
+
+	if stats.TotalIncidents > 0 {
+		stats.SuccessRate = float64(stats.ResolvedIncidents) / float64(stats.TotalIncidents)
+	}
+	if resolutionCount > 0 {
+		stats.MeanTimeToResolve = resolutionTotal / float64(resolutionCount)
+	}
+
+	return stats, nil
+}
+
+func (m *mockRepository) GetTopFailingServices(start, end time.Time, limit int) ([]database.ServiceFailureCount, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counts := make(map[string]int)
+	for _, incident := range m.incidents {
+		if incident.Status == models.StatusFailed && !incident.CreatedAt.Before(start) && !incident.CreatedAt.After(end) {
+			counts[incident.ServiceName]++
+		}
+	}
+
+	result := make([]database.ServiceFailureCount, 0, len(counts))
+	for service, failures := range counts {
+		result = append(result, database.ServiceFailureCount{ServiceName: service, Failures: failures})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Failures != result[j].Failures {
+			return result[i].Failures > result[j].Failures
+		}
+		return result[i].ServiceName < result[j].ServiceName
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+func TestDigestWorker_SendsDailyDigestOnSchedule(t *testing.T) {
+	now := time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC)
+	completed := now.Add(-2 * time.Hour)
+	users, rate, minutes := int64(2500), 0.125, 40
+
+	repo := newMockRepository(
+		&models.Incident{ID: "inc_1", ServiceName: "payment-service", Severity: "high", Status: models.StatusPRCreated, CreatedAt: now.Add(-3 * time.Hour), CompletedAt: &completed},
+		&models.Incident{ID: "inc_2", ServiceName: "payment-service", Severity: "high", Status: models.StatusFailed, CreatedAt: now.Add(-4 * time.Hour),
+			AffectedUsers: &users, ErrorRate: &rate, DowntimeMinutes: &minutes},
+		&models.Incident{ID: "inc_3", ServiceName: "api-gateway", Severity: "low", Status: models.StatusFailed, CreatedAt: now.Add(-5 * time.Hour)},
+		&models.Incident{ID: "inc_4", ServiceName: "api-gateway", Severity: "critical", Status: models.StatusInProgress, CreatedAt: now.Add(-1 * time.Hour)},
+	)
+	dispatcher, recorder := newRecordingDispatcher()
+
+	worker := NewDigestWorker(config.DigestConfig{
+		Schedules: []config.DigestSchedule{
+			{Name: "daily-sre", Frequency: "daily", Time: "09:00", SlackChannel: "#sre-digest"},
+		},
+	}, "https://sre.example.com/", repo, dispatcher, nopLogger{})
+	worker.now = func() time.Time { return now }
+
+	// The 09:00 slot has not been reached yet
+	if err := worker.Check(context.Background()); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(recorder.messages) != 0 {
+		t.Fatalf("expected no digest before the scheduled time, got %d", len(recorder.messages))
+	}
+
+	now = now.Add(90 * time.Minute)
+	if err := worker.Check(context.Background()); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(recorder.messages) != 1 {
+		t.Fatalf("expected 1 digest, got %d", len(recorder.messages))
+	}
+
+	msg := recorder.messages[0]
+	if msg.EventType != notifications.EventDigest || msg.Incident != nil {
+		t.Errorf("unexpected digest message: %+v", msg)
+	}
+	if msg.Route == nil || msg.Route.SlackChannel != "#sre-digest" {
+		t.Errorf("digest not routed to the schedule's channel: %+v", msg.Route)
+	}
+	if msg.Subject != "Daily incident digest: 2024-03-04" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+
+	for _, want := range []string{
+		"Incidents: 4 (1 resolved, 2 failed)",
+		"Success rate: 25.0%",
+		"MTTR: 1h0m0s",
+		"Impact: 2500 users affected, 40m downtime, peak error rate 12.5%",
+		"  - api-gateway: 1 failed",
+		"  - payment-service: 1 failed",
+		"Open incidents: 1",
+		"  - inc_4 [critical] api-gateway (in_progress)",
+		"https://sre.example.com",
+	} {
+		if !strings.Contains(msg.Text, want) {
+			t.Errorf("digest missing %q:\n%s", want, msg.Text)
+		}
+	}
+
+	// The same slot is only sent once
+	now = now.Add(time.Hour)
+	if err := worker.Check(context.Background()); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(recorder.messages) != 1 {
+		t.Errorf("expected digest to be sent once per slot, got %d", len(recorder.messages))
+	}
+}
+
+func TestDigestWorker_SkipsSlotsMissedBeforeStart(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	dispatcher, recorder := newRecordingDispatcher()
+
+	worker := NewDigestWorker(config.DigestConfig{
+		Schedules: []config.DigestSchedule{
+			{Name: "weekly", Frequency: "weekly", Weekday: "monday", EmailRecipients: []string{"managers@example.com"}},
+		},
+	}, "", newMockRepository(), dispatcher, nopLogger{})
+	worker.now = func() time.Time { return now }
+
+	if err := worker.Check(context.Background()); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(recorder.messages) != 0 {
+		t.Errorf("expected no digest for a slot missed before startup, got %d", len(recorder.messages))
+	}
+}
@@ -0,0 +1,183 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/notifications"
+)
+
+// EscalationRepository defines the persistence operations needed for escalation
+type EscalationRepository interface {
+	ListWithFilter(filter *database.IncidentFilter) ([]*models.Incident, error)
+	GetEventsByIncidentID(incidentID string) ([]*models.IncidentEvent, error)
+	LogEvent(event *models.IncidentEvent) error
+}
+
+// escalationStatuses are the statuses an incident can be escalated from
+var escalationStatuses = []models.IncidentStatus{
+	models.StatusWorkflowTriggered,
+	models.StatusInProgress,
+	models.StatusFailed,
+}
+
+// EscalationWorker periodically escalates incidents that are stuck in remediation
+// or keep failing, notifying each configured level at most once per incident
+type EscalationWorker struct {
+	repo     EscalationRepository
+	notifier *notifications.Dispatcher
+	levels   []config.EscalationLevel
+	logger   Logger
+	stopCh   chan struct{}
+	now      func() time.Time
+}
+
+// NewEscalationWorker creates a new escalation worker
+func NewEscalationWorker(cfg config.EscalationConfig, repo EscalationRepository, notifier *notifications.Dispatcher, logger Logger) *EscalationWorker {
+	return &EscalationWorker{
+		repo:     repo,
+		notifier: notifier,
+		levels:   cfg.Levels,
+		logger:   logger,
+		stopCh:   make(chan struct{}),
+		now:      time.Now,
+	}
+}
+
+// Start runs escalation checks at the given interval until Stop is called
+func (w *EscalationWorker) Start(interval time.Duration) {
+	started("escalation", interval)
+	defer stopped("escalation")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("escalation")
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if err := w.Check(ctx); err != nil {
+				w.logger.Error("escalation check failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+			cancel()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the escalation worker
+func (w *EscalationWorker) Stop() {
+	close(w.stopCh)
+}
+
+// Check evaluates all escalation candidates once
+func (w *EscalationWorker) Check(ctx context.Context) error {
+	for _, status := range escalationStatuses {
+		status := status
+		incidents, err := w.repo.ListWithFilter(&database.IncidentFilter{Status: &status})
+		if err != nil {
+			return fmt.Errorf("failed to list %s incidents: %w", status, err)
+		}
+
+		for _, incident := range incidents {
+			if err := w.evaluate(ctx, incident); err != nil {
+				w.logger.Error("failed to evaluate incident escalation", map[string]interface{}{
+					"error":       err.Error(),
+					"incident_id": incident.ID,
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// evaluate escalates a single incident if it has reached a level it was not yet escalated to
+func (w *EscalationWorker) evaluate(ctx context.Context, incident *models.Incident) error {
+	events, err := w.repo.GetEventsByIncidentID(incident.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get events: %w", err)
+	}
+
+	failures := 0
+	currentLevel := -1
+	for _, event := range events {
+		switch event.EventType {
+		case models.EventIncidentFailed:
+			failures++
+		case models.EventIncidentEscalated:
+			if index, ok := intFromEventData(event.EventData, "level_index"); ok && index > currentLevel {
+				currentLevel = index
+			}
+		}
+	}
+
+	// Failed incidents are no longer running, so only the failure count applies to them
+	var stuckFor time.Duration
+	if incident.Status != models.StatusFailed {
+		since := incident.CreatedAt
+		if incident.TriggeredAt != nil {
+			since = *incident.TriggeredAt
+		}
+		stuckFor = w.now().Sub(since)
+	}
+
+	targetLevel := -1
+	reason := ""
+	for i, level := range w.levels {
+		switch {
+		case level.After > 0 && stuckFor >= level.After:
+			targetLevel = i
+			reason = fmt.Sprintf("stuck in %s for %s", incident.Status, stuckFor.Round(time.Minute))
+		case level.AfterFailures > 0 && failures >= level.AfterFailures:
+			targetLevel = i
+			reason = fmt.Sprintf("remediation failed %d times", failures)
+		}
+	}
+
+	if targetLevel <= currentLevel {
+		return nil
+	}
+
+	level := w.levels[targetLevel]
+	details := map[string]interface{}{
+		"level":       level.Name,
+		"level_index": targetLevel,
+		"reason":      reason,
+		"failures":    failures,
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: incident.ID,
+		EventType:  models.EventIncidentEscalated,
+		EventData:  details,
+	}
+	if err := w.repo.LogEvent(event); err != nil {
+		return fmt.Errorf("failed to log escalation event: %w", err)
+	}
+
+	w.logger.Warn("incident escalated", map[string]interface{}{
+		"incident_id": incident.ID,
+		"level":       level.Name,
+		"reason":      reason,
+	})
+
+	if err := w.notifier.Send(ctx, &notifications.Notification{
+		EventType: models.EventIncidentEscalated,
+		Incident:  incident,
+		Details:   details,
+		Route:     level.Route(),
+	}); err != nil {
+		return fmt.Errorf("failed to send escalation notification: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package workers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/notifications"
+)
+
+// mockRepository is an in-memory repository for worker tests
+type mockRepository struct {
+	mu        sync.Mutex
+	incidents []*models.Incident
+	events    map[string][]*models.IncidentEvent
+}
+
+func newMockRepository(incidents ...*models.Incident) *mockRepository {
+	return &mockRepository{
+		incidents: incidents,
+		events:    make(map[string][]*models.IncidentEvent),
+	}
+}
+
+func (m *mockRepository) ListWithFilter(filter *database.IncidentFilter) ([]*models.Incident, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]*models.Incident, 0)
+	for _, incident := range m.incidents {
+		if filter != nil && filter.Status != nil && incident.Status != *filter.Status {
+			continue
+		}
+		result = append(result, incident)
+	}
+	return result, nil
+}
+
+func (m *mockRepository) GetEventsByIncidentID(incidentID string) ([]*models.IncidentEvent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.events[incidentID], nil
+}
+
+func (m *mockRepository) LogEvent(event *models.IncidentEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	event.CreatedAt = time.Now()
+	m.events[event.IncidentID] = append(m.events[event.IncidentID], event)
+	return nil
+}
+
+func (m *mockRepository) eventsOfType(incidentID string, eventType models.IncidentEventType) []*models.IncidentEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]*models.IncidentEvent, 0)
+	for _, event := range m.events[incidentID] {
+		if event.EventType == eventType {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// recordingNotifier captures messages sent through a dispatcher
+type recordingNotifier struct {
+	mu       sync.Mutex
+	messages []*notifications.Message
+}
+
+func (n *recordingNotifier) Name() string { return "recording" }
+
+func (n *recordingNotifier) Send(ctx context.Context, msg *notifications.Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.messages = append(n.messages, msg)
+	return nil
+}
+
+// nopLogger discards log output
+type nopLogger struct{}
+
+func (nopLogger) Info(string, map[string]interface{})  {}
+func (nopLogger) Warn(string, map[string]interface{})  {}
+func (nopLogger) Error(string, map[string]interface{}) {}
+
+func newRecordingDispatcher() (*notifications.Dispatcher, *recordingNotifier) {
+	recorder := &recordingNotifier{}
+	dispatcher := notifications.NewDispatcher(config.NotificationsConfig{})
+	dispatcher.Register(recorder)
+	return dispatcher, recorder
+}
+
+func TestEscalationWorker_StuckIncidentEscalatesThroughLevels(t *testing.T) {
+	now := time.Now()
+	triggeredAt := now.Add(-45 * time.Minute)
+	incident := &models.Incident{
+		ID:          "inc_stuck",
+		ServiceName: "api-gateway",
+		Status:      models.StatusInProgress,
+		CreatedAt:   triggeredAt,
+		TriggeredAt: &triggeredAt,
+	}
+
+	repo := newMockRepository(incident)
+	dispatcher, recorder := newRecordingDispatcher()
+
+	cfg := config.EscalationConfig{
+		Enabled: true,
+		Levels: []config.EscalationLevel{
+			{Name: "channel", After: 30 * time.Minute, SlackChannel: "#incidents"},
+			{Name: "on-call", After: time.Hour, PagerDutyRoutingKey: "oncall-key"},
+			{Name: "manager", After: 2 * time.Hour, SlackChannel: "#eng-managers"},
+		},
+	}
+	worker := NewEscalationWorker(cfg, repo, dispatcher, nopLogger{})
+	worker.now = func() time.Time { return now }
+
+	// First check escalates to the channel level
+	if err := worker.Check(context.Background()); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	// A repeated check at the same time must not re-notify
+	if err := worker.Check(context.Background()); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	if len(recorder.messages) != 1 {
+		t.Fatalf("expected 1 escalation notification, got %d", len(recorder.messages))
+	}
+	if recorder.messages[0].Route.SlackChannel != "#incidents" {
+		t.Errorf("expected channel level route, got %+v", recorder.messages[0].Route)
+	}
+
+	// Ninety minutes stuck reaches the on-call level
+	worker.now = func() time.Time { return now.Add(45 * time.Minute) }
+	if err := worker.Check(context.Background()); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	escalations := repo.eventsOfType(incident.ID, models.EventIncidentEscalated)
+	if len(escalations) != 2 {
+		t.Fatalf("expected 2 escalation events, got %d", len(escalations))
+	}
+	if escalations[1].EventData["level"] != "on-call" {
+		t.Errorf("expected on-call escalation, got %v", escalations[1].EventData["level"])
+	}
+	if len(recorder.messages) != 2 || recorder.messages[1].Route.PagerDutyRoutingKey != "oncall-key" {
+		t.Errorf("expected on-call notification")
+	}
+}
+
+func TestEscalationWorker_RepeatedFailures(t *testing.T) {
+	incident := &models.Incident{
+		ID:          "inc_failing",
+		ServiceName: "payment-service",
+		Status:      models.StatusFailed,
+		CreatedAt:   time.Now().Add(-10 * time.Hour),
+	}
+
+	repo := newMockRepository(incident)
+	for i := 0; i < 2; i++ {
+		_ = repo.LogEvent(&models.IncidentEvent{IncidentID: incident.ID, EventType: models.EventIncidentFailed})
+	}
+
+	dispatcher, recorder := newRecordingDispatcher()
+	cfg := config.EscalationConfig{
+		Enabled: true,
+		Levels: []config.EscalationLevel{
+			{Name: "channel", After: 30 * time.Minute, AfterFailures: 2},
+			{Name: "manager", AfterFailures: 3},
+		},
+	}
+	worker := NewEscalationWorker(cfg, repo, dispatcher, nopLogger{})
+
+	if err := worker.Check(context.Background()); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	escalations := repo.eventsOfType(incident.ID, models.EventIncidentEscalated)
+	if len(escalations) != 1 || escalations[0].EventData["level"] != "channel" {
+		t.Fatalf("expected channel escalation after 2 failures, got %v", escalations)
+	}
+	if len(recorder.messages) != 1 || recorder.messages[0].EventType != models.EventIncidentEscalated {
+		t.Errorf("expected escalation notification")
+	}
+
+	// Failed incidents do not escalate on elapsed time alone
+	_ = repo.LogEvent(&models.IncidentEvent{IncidentID: incident.ID, EventType: models.EventIncidentFailed})
+	if err := worker.Check(context.Background()); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	escalations = repo.eventsOfType(incident.ID, models.EventIncidentEscalated)
+	if len(escalations) != 2 || escalations[1].EventData["level"] != "manager" {
+		t.Errorf("expected manager escalation after 3 failures, got %d events", len(escalations))
+	}
+}
@@ -0,0 +1,68 @@
+package workers
+
+import (
+	"context"
+	"time"
+)
+
+// FreezeCalendar is a freeze calendar whose iCal feeds can be fetched again
+type FreezeCalendar interface {
+	Refresh(ctx context.Context) error
+}
+
+// FreezeCalendarWorker periodically fetches the iCal feeds of the freeze
+// calendar. The calendar lives in memory, so every replica fetches its own.
+type FreezeCalendarWorker struct {
+	calendar FreezeCalendar
+	logger   Logger
+	stopCh   chan struct{}
+}
+
+// NewFreezeCalendarWorker creates a new freeze calendar worker
+func NewFreezeCalendarWorker(calendar FreezeCalendar, logger Logger) *FreezeCalendarWorker {
+	return &FreezeCalendarWorker{
+		calendar: calendar,
+		logger:   logger,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start fetches the feeds immediately and then at the given interval until
+// Stop is called
+func (w *FreezeCalendarWorker) Start(interval time.Duration) {
+	started("freeze_calendar", interval)
+	defer stopped("freeze_calendar")
+
+	w.refresh(interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("freeze_calendar")
+			w.refresh(interval)
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the freeze calendar worker
+func (w *FreezeCalendarWorker) Stop() {
+	close(w.stopCh)
+}
+
+// refresh fetches the feeds once, logging any failure. Repositories whose
+// feed failed keep the windows fetched last.
+func (w *FreezeCalendarWorker) refresh(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := w.calendar.Refresh(ctx); err != nil {
+		w.logger.Warn("failed to refresh freeze calendars", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package workers
+
+import (
+	"sync"
+	"time"
+)
+
+// Heartbeat is when a background worker running in this process started and
+// last ran its check
+type Heartbeat struct {
+	Interval  time.Duration
+	StartedAt time.Time
+	LastRunAt time.Time // zero until the first tick
+}
+
+// Stale reports whether the worker missed more than one run, which means it
+// is stuck in a check or its goroutine is blocked
+func (h Heartbeat) Stale(now time.Time) bool {
+	last := h.LastRunAt
+	if last.IsZero() {
+		last = h.StartedAt
+	}
+	return now.Sub(last) > 2*h.Interval
+}
+
+// heartbeats are kept per process rather than per worker, so that the health
+// check sees every worker without each one being handed to the server
+var heartbeats = struct {
+	sync.Mutex
+	workers map[string]Heartbeat
+}{workers: make(map[string]Heartbeat)}
+
+// started records that the named worker started running at the interval
+func started(name string, interval time.Duration) {
+	heartbeats.Lock()
+	defer heartbeats.Unlock()
+	heartbeats.workers[name] = Heartbeat{Interval: interval, StartedAt: time.Now()}
+}
+
+// beat records that the named worker ran its check
+func beat(name string) {
+	heartbeats.Lock()
+	defer heartbeats.Unlock()
+	if heartbeat, ok := heartbeats.workers[name]; ok {
+		heartbeat.LastRunAt = time.Now()
+		heartbeats.workers[name] = heartbeat
+	}
+}
+
+// stopped forgets the named worker, e.g. a singleton worker on a replica that
+// lost the leader lease
+func stopped(name string) {
+	heartbeats.Lock()
+	defer heartbeats.Unlock()
+	delete(heartbeats.workers, name)
+}
+
+// Heartbeats returns the heartbeats of the workers running in this process
+// by worker name
+func Heartbeats() map[string]Heartbeat {
+	heartbeats.Lock()
+	defer heartbeats.Unlock()
+	snapshot := make(map[string]Heartbeat, len(heartbeats.workers))
+	for name, heartbeat := range heartbeats.workers {
+		snapshot[name] = heartbeat
+	}
+	return snapshot
+}
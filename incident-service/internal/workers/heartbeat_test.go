@@ -0,0 +1,30 @@
+package workers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHeartbeats(t *testing.T) {
+	started("heartbeat_test", time.Minute)
+	if heartbeat, ok := Heartbeats()["heartbeat_test"]; !ok || !heartbeat.LastRunAt.IsZero() {
+		t.Fatalf("expected a started worker that did not run yet, got %+v", heartbeat)
+	}
+
+	beat("heartbeat_test")
+	heartbeat := Heartbeats()["heartbeat_test"]
+	if heartbeat.LastRunAt.IsZero() {
+		t.Errorf("expected the run recorded, got %+v", heartbeat)
+	}
+	if heartbeat.Stale(heartbeat.LastRunAt.Add(90 * time.Second)) {
+		t.Errorf("expected a worker within its interval not stale")
+	}
+	if !heartbeat.Stale(heartbeat.LastRunAt.Add(3 * time.Minute)) {
+		t.Errorf("expected a worker that missed a run stale")
+	}
+
+	stopped("heartbeat_test")
+	if _, ok := Heartbeats()["heartbeat_test"]; ok {
+		t.Errorf("expected the stopped worker forgotten")
+	}
+}
@@ -0,0 +1,115 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+)
+
+// IngestionBufferWorker processes the webhooks buffered during a database
+// outage once the database is reachable again
+type IngestionBufferWorker struct {
+	buffer  database.WebhookBuffer
+	health  func() error
+	process func(ctx context.Context, webhook *database.BufferedWebhook)
+	depth   prometheus.Gauge
+	logger  Logger
+	stopCh  chan struct{}
+}
+
+// NewIngestionBufferWorker creates a worker draining buffer into process
+// while health reports the database as reachable
+func NewIngestionBufferWorker(buffer database.WebhookBuffer, health func() error, process func(ctx context.Context, webhook *database.BufferedWebhook), depth prometheus.Gauge, logger Logger) *IngestionBufferWorker {
+	return &IngestionBufferWorker{
+		buffer:  buffer,
+		health:  health,
+		process: process,
+		depth:   depth,
+		logger:  logger,
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// Start drains the buffer at the given interval until Stop is called
+func (w *IngestionBufferWorker) Start(interval time.Duration) {
+	started("ingestion_buffer", interval)
+	defer stopped("ingestion_buffer")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("ingestion_buffer")
+			w.Drain(context.Background())
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the ingestion buffer worker
+func (w *IngestionBufferWorker) Stop() {
+	close(w.stopCh)
+}
+
+// Drain processes the webhooks buffered when it started, oldest first. It
+// stops early when the database becomes unreachable again; webhooks that
+// fail to store in the meantime are buffered again behind the others, so a
+// pass never processes the same webhook twice. It returns how many webhooks
+// it processed.
+func (w *IngestionBufferWorker) Drain(ctx context.Context) int {
+	pending, err := w.buffer.Len(ctx)
+	if err != nil {
+		w.logger.Error("failed to read webhook buffer", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return 0
+	}
+	w.depth.Set(float64(pending))
+	if pending == 0 {
+		return 0
+	}
+
+	processed := 0
+	for ; processed < pending; processed++ {
+		select {
+		case <-w.stopCh:
+			return processed
+		default:
+		}
+
+		if err := w.health(); err != nil {
+			w.logger.Warn("database still unavailable, webhooks stay buffered", map[string]interface{}{
+				"error":    err.Error(),
+				"buffered": pending - processed,
+			})
+			break
+		}
+
+		webhook, err := w.buffer.Pop(ctx)
+		if err != nil {
+			w.logger.Error("failed to pop buffered webhook", map[string]interface{}{
+				"error": err.Error(),
+			})
+			break
+		}
+		if webhook == nil {
+			break
+		}
+		w.process(ctx, webhook)
+	}
+
+	if processed > 0 {
+		w.logger.Info("drained webhook buffer", map[string]interface{}{
+			"processed": processed,
+		})
+	}
+	if depth, err := w.buffer.Len(ctx); err == nil {
+		w.depth.Set(float64(depth))
+	}
+	return processed
+}
@@ -0,0 +1,87 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+)
+
+// memoryWebhookBuffer is an in-memory database.WebhookBuffer
+type memoryWebhookBuffer struct {
+	webhooks []*database.BufferedWebhook
+}
+
+func (b *memoryWebhookBuffer) Push(ctx context.Context, webhook *database.BufferedWebhook) error {
+	b.webhooks = append(b.webhooks, webhook)
+	return nil
+}
+
+func (b *memoryWebhookBuffer) Pop(ctx context.Context) (*database.BufferedWebhook, error) {
+	if len(b.webhooks) == 0 {
+		return nil, nil
+	}
+	webhook := b.webhooks[0]
+	b.webhooks = b.webhooks[1:]
+	return webhook, nil
+}
+
+func (b *memoryWebhookBuffer) Len(ctx context.Context) (int, error) {
+	return len(b.webhooks), nil
+}
+
+func TestIngestionBufferWorker_Drain(t *testing.T) {
+	buffer := &memoryWebhookBuffer{}
+	for _, provider := range []string{"datadog", "sentry", "grafana"} {
+		_ = buffer.Push(context.Background(), &database.BufferedWebhook{Provider: provider})
+	}
+	depth := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_buffer_depth"})
+
+	databaseErr := errors.New("connection refused")
+	var processed []string
+	worker := NewIngestionBufferWorker(buffer, func() error { return databaseErr }, func(ctx context.Context, webhook *database.BufferedWebhook) {
+		processed = append(processed, webhook.Provider)
+	}, depth, nopLogger{})
+
+	// Nothing is drained while the database is down
+	if n := worker.Drain(context.Background()); n != 0 || len(processed) != 0 {
+		t.Fatalf("Drain() = %d while the database is down", n)
+	}
+	if got := testutil.ToFloat64(depth); got != 3 {
+		t.Errorf("expected buffer depth 3, got %v", got)
+	}
+
+	databaseErr = nil
+	if n := worker.Drain(context.Background()); n != 3 {
+		t.Fatalf("Drain() = %d, want 3", n)
+	}
+	if len(processed) != 3 || processed[0] != "datadog" || processed[2] != "grafana" {
+		t.Errorf("expected webhooks in arrival order, got %v", processed)
+	}
+	if got := testutil.ToFloat64(depth); got != 0 {
+		t.Errorf("expected empty buffer, got depth %v", got)
+	}
+}
+
+func TestIngestionBufferWorker_RebufferedWebhooksWaitForNextPass(t *testing.T) {
+	buffer := &memoryWebhookBuffer{}
+	_ = buffer.Push(context.Background(), &database.BufferedWebhook{Provider: "datadog"})
+	_ = buffer.Push(context.Background(), &database.BufferedWebhook{Provider: "sentry"})
+
+	// The database fails again mid-pass, so every webhook is buffered again
+	attempts := 0
+	worker := NewIngestionBufferWorker(buffer, func() error { return nil }, func(ctx context.Context, webhook *database.BufferedWebhook) {
+		attempts++
+		_ = buffer.Push(ctx, webhook)
+	}, prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_buffer_depth"}), nopLogger{})
+
+	if n := worker.Drain(context.Background()); n != 2 || attempts != 2 {
+		t.Errorf("Drain() = %d with %d attempts, want each webhook tried once", n, attempts)
+	}
+	if n, _ := buffer.Len(context.Background()); n != 2 {
+		t.Errorf("expected both webhooks to stay buffered, got %d", n)
+	}
+}
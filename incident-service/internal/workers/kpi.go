@@ -0,0 +1,117 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+)
+
+// KPIRepository defines the queries needed to compute KPI gauges
+type KPIRepository interface {
+	CountOpenIncidents() ([]database.OpenIncidentCount, error)
+	GetStatistics(filter *database.IncidentFilter) (*database.IncidentStatistics, error)
+}
+
+// KPIGauges are the gauges refreshed by the KPI worker
+type KPIGauges struct {
+	OpenIncidents *prometheus.GaugeVec // labels: status, severity
+	SuccessRate   prometheus.Gauge
+	MTTR          prometheus.Gauge
+	LastRefresh   prometheus.Gauge
+}
+
+// KPIWorker periodically recomputes business KPIs from the database so they
+// can be alerted on directly instead of derived from raw counters
+type KPIWorker struct {
+	repo   KPIRepository
+	gauges KPIGauges
+	window time.Duration
+	logger Logger
+	stopCh chan struct{}
+	now    func() time.Time
+}
+
+// NewKPIWorker creates a new KPI worker
+func NewKPIWorker(cfg config.KPIConfig, repo KPIRepository, gauges KPIGauges, logger Logger) *KPIWorker {
+	return &KPIWorker{
+		repo:   repo,
+		gauges: gauges,
+		window: cfg.RollingWindow(),
+		logger: logger,
+		stopCh: make(chan struct{}),
+		now:    time.Now,
+	}
+}
+
+// Start refreshes the gauges immediately and then at the given interval until Stop is called
+func (w *KPIWorker) Start(interval time.Duration) {
+	started("kpi", interval)
+	defer stopped("kpi")
+
+	w.refresh(interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("kpi")
+			w.refresh(interval)
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the KPI worker
+func (w *KPIWorker) Stop() {
+	close(w.stopCh)
+}
+
+// refresh runs one refresh, logging any failure
+func (w *KPIWorker) refresh(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := w.Refresh(ctx); err != nil {
+		w.logger.Error("kpi refresh failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+}
+
+// Refresh recomputes all KPI gauges once
+func (w *KPIWorker) Refresh(ctx context.Context) error {
+	counts, err := w.repo.CountOpenIncidents()
+	if err != nil {
+		return fmt.Errorf("failed to count open incidents: %w", err)
+	}
+
+	end := w.now()
+	start := end.Add(-w.window)
+	stats, err := w.repo.GetStatistics(&database.IncidentFilter{StartTime: &start, EndTime: &end})
+	if err != nil {
+		return fmt.Errorf("failed to get incident statistics: %w", err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Reset so status/severity pairs with no open incidents drop out instead of going stale
+	w.gauges.OpenIncidents.Reset()
+	for _, count := range counts {
+		w.gauges.OpenIncidents.WithLabelValues(string(count.Status), count.Severity).Set(float64(count.Count))
+	}
+
+	w.gauges.SuccessRate.Set(stats.SuccessRate)
+	w.gauges.MTTR.Set(stats.MeanTimeToResolve)
+	w.gauges.LastRefresh.Set(float64(end.Unix()))
+
+	return nil
+}
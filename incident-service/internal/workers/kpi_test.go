@@ -0,0 +1,100 @@
+package workers
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func (m *mockRepository) CountOpenIncidents() ([]database.OpenIncidentCount, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	type key struct {
+		status   models.IncidentStatus
+		severity string
+	}
+	counts := make(map[key]int)
+	for _, incident := range m.incidents {
+		switch incident.Status {
+		case models.StatusPending, models.StatusWorkflowTriggered, models.StatusInProgress:
+			counts[key{incident.Status, incident.Severity}]++
+		}
+	}
+
+	result := make([]database.OpenIncidentCount, 0, len(counts))
+	for k, count := range counts {
+		result = append(result, database.OpenIncidentCount{Status: k.status, Severity: k.severity, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Status != result[j].Status {
+			return result[i].Status < result[j].Status
+		}
+		return result[i].Severity < result[j].Severity
+	})
+
+	return result, nil
+}
+
+func newTestKPIGauges() KPIGauges {
+	return KPIGauges{
+		OpenIncidents: prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "incidents_open"}, []string{"status", "severity"}),
+		SuccessRate:   prometheus.NewGauge(prometheus.GaugeOpts{Name: "incident_success_rate"}),
+		MTTR:          prometheus.NewGauge(prometheus.GaugeOpts{Name: "incident_mttr_seconds"}),
+		LastRefresh:   prometheus.NewGauge(prometheus.GaugeOpts{Name: "incident_kpi_last_refresh_timestamp_seconds"}),
+	}
+}
+
+func TestKPIWorker_Refresh(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	completed := now.Add(-time.Hour)
+
+	repo := newMockRepository(
+		&models.Incident{ID: "inc-1", Status: models.StatusPending, Severity: "critical", CreatedAt: now.Add(-time.Hour)},
+		&models.Incident{ID: "inc-2", Status: models.StatusPending, Severity: "critical", CreatedAt: now.Add(-time.Hour)},
+		&models.Incident{ID: "inc-3", Status: models.StatusInProgress, Severity: "low", CreatedAt: now.Add(-time.Hour)},
+		&models.Incident{ID: "inc-4", Status: models.StatusPRCreated, Severity: "high", CreatedAt: now.Add(-3 * time.Hour), CompletedAt: &completed},
+		// Outside the rolling window, so excluded from success rate and MTTR
+		&models.Incident{ID: "inc-5", Status: models.StatusFailed, Severity: "high", CreatedAt: now.Add(-48 * time.Hour)},
+	)
+
+	gauges := newTestKPIGauges()
+	worker := NewKPIWorker(config.KPIConfig{}, repo, gauges, nopLogger{})
+	worker.now = func() time.Time { return now }
+
+	if err := worker.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if got := testutil.ToFloat64(gauges.OpenIncidents.WithLabelValues("pending", "critical")); got != 2 {
+		t.Errorf("expected 2 open pending critical incidents, got %v", got)
+	}
+	if got := testutil.ToFloat64(gauges.OpenIncidents.WithLabelValues("in_progress", "low")); got != 1 {
+		t.Errorf("expected 1 open in_progress low incident, got %v", got)
+	}
+	if got := testutil.ToFloat64(gauges.SuccessRate); got != 0.25 {
+		t.Errorf("expected success rate 0.25, got %v", got)
+	}
+	if got := testutil.ToFloat64(gauges.MTTR); got != (2 * time.Hour).Seconds() {
+		t.Errorf("expected MTTR of 2h, got %vs", got)
+	}
+	if got := testutil.ToFloat64(gauges.LastRefresh); got != float64(now.Unix()) {
+		t.Errorf("expected last refresh %d, got %v", now.Unix(), got)
+	}
+
+	// Resolving the in-progress incident removes its series on the next refresh
+	repo.incidents[2].Status = models.StatusResolved
+	if err := worker.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if got := testutil.CollectAndCount(gauges.OpenIncidents); got != 1 {
+		t.Errorf("expected 1 open incident series after resolution, got %d", got)
+	}
+}
@@ -0,0 +1,150 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// MaintenanceRepository defines the persistence operations needed to resume
+// remediations deferred by a maintenance window
+type MaintenanceRepository interface {
+	ListWithFilter(filter *database.IncidentFilter) ([]*models.Incident, error)
+	Update(incident *models.Incident) error
+	LogEvent(event *models.IncidentEvent) error
+}
+
+// MaintenanceWorker periodically dispatches the remediation of incidents
+// whose maintenance window has closed, if they are still pending
+type MaintenanceWorker struct {
+	repo     MaintenanceRepository
+	dispatch IncidentDispatcher
+	logger   Logger
+	stopCh   chan struct{}
+	now      func() time.Time
+}
+
+// NewMaintenanceWorker creates a new maintenance worker
+func NewMaintenanceWorker(repo MaintenanceRepository, dispatch IncidentDispatcher, logger Logger) *MaintenanceWorker {
+	return &MaintenanceWorker{
+		repo:     repo,
+		dispatch: dispatch,
+		logger:   logger,
+		stopCh:   make(chan struct{}),
+		now:      time.Now,
+	}
+}
+
+// Start runs maintenance checks at the given interval until Stop is called
+func (w *MaintenanceWorker) Start(interval time.Duration) {
+	started("maintenance", interval)
+	defer stopped("maintenance")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("maintenance")
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if err := w.Check(ctx); err != nil {
+				w.logger.Error("maintenance check failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+			cancel()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the maintenance worker
+func (w *MaintenanceWorker) Stop() {
+	close(w.stopCh)
+}
+
+// Check dispatches every pending incident whose deferral has ended, most
+// severe first and oldest first within a severity, so that incidents
+// deferred by a burst of dispatches are remediated in order of urgency
+func (w *MaintenanceWorker) Check(ctx context.Context) error {
+	status := models.StatusPending
+	incidents, err := w.repo.ListWithFilter(&database.IncidentFilter{Status: &status})
+	if err != nil {
+		return fmt.Errorf("failed to list pending incidents: %w", err)
+	}
+
+	now := w.now()
+	var due []*models.Incident
+	for _, incident := range incidents {
+		// A snoozed incident keeps its deferral until the snooze expires
+		if incident.DeferredUntil == nil || incident.DeferredUntil.After(now) || incident.Snoozed(now) {
+			continue
+		}
+		due = append(due, incident)
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		if a, b := models.SeverityRank(due[i].Severity), models.SeverityRank(due[j].Severity); a != b {
+			return a < b
+		}
+		return due[i].CreatedAt.Before(due[j].CreatedAt)
+	})
+
+	for _, incident := range due {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := w.resume(ctx, incident); err != nil {
+			w.logger.Error("failed to resume deferred remediation", map[string]interface{}{
+				"error":       err.Error(),
+				"incident_id": incident.ID,
+			})
+		}
+	}
+
+	return nil
+}
+
+// resume clears the deferral and dispatches the incident. The deferral is
+// cleared first, so an incident queued behind the repository's concurrency
+// limit is not dispatched again by the next check. A window that is open
+// again by then defers the remediation once more.
+func (w *MaintenanceWorker) resume(ctx context.Context, incident *models.Incident) error {
+	deferredUntil := *incident.DeferredUntil
+	incident.DeferredUntil = nil
+	if err := w.repo.Update(incident); err != nil {
+		return fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: incident.ID,
+		EventType:  models.EventRemediationResumed,
+		EventData: map[string]interface{}{
+			"deferred_until": deferredUntil,
+		},
+	}
+	if err := w.repo.LogEvent(event); err != nil {
+		w.logger.Error("failed to log remediation resumed event", map[string]interface{}{
+			"error":       err.Error(),
+			"incident_id": incident.ID,
+		})
+	}
+
+	w.logger.Info("maintenance window closed, dispatching deferred remediation", map[string]interface{}{
+		"incident_id": incident.ID,
+		"repository":  incident.Repository,
+	})
+
+	if w.dispatch != nil {
+		if err := w.dispatch(ctx, incident); err != nil {
+			return fmt.Errorf("failed to dispatch deferred remediation: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestMaintenanceWorker_Check(t *testing.T) {
+	now := time.Date(2024, 3, 10, 4, 0, 0, 0, time.UTC)
+	closed := now.Add(-time.Minute)
+	open := now.Add(time.Hour)
+
+	incidents := map[string]*models.Incident{
+		"closed":     {ID: "closed", Repository: "org/a", Status: models.StatusPending, DeferredUntil: &closed},
+		"still-open": {ID: "still-open", Repository: "org/a", Status: models.StatusPending, DeferredUntil: &open},
+		"never":      {ID: "never", Repository: "org/a", Status: models.StatusPending},
+		"resolved":   {ID: "resolved", Repository: "org/a", Status: models.StatusResolved, DeferredUntil: &closed},
+	}
+	repo := newMockRepository()
+	for _, incident := range incidents {
+		repo.incidents = append(repo.incidents, incident)
+	}
+
+	var dispatched []string
+	worker := NewMaintenanceWorker(repo, func(ctx context.Context, incident *models.Incident) error {
+		dispatched = append(dispatched, incident.ID)
+		return nil
+	}, nopLogger{})
+	worker.now = func() time.Time { return now }
+
+	if err := worker.Check(context.Background()); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	if len(dispatched) != 1 || dispatched[0] != "closed" {
+		t.Errorf("expected only the incident whose window closed to be dispatched, got %v", dispatched)
+	}
+	if incidents["closed"].DeferredUntil != nil {
+		t.Error("expected the deferral to be cleared before dispatch")
+	}
+	events := repo.eventsOfType("closed", models.EventRemediationResumed)
+	if len(events) != 1 || events[0].EventData["deferred_until"] != closed {
+		t.Errorf("expected a remediation resumed event, got %v", events)
+	}
+
+	for _, id := range []string{"still-open", "never", "resolved"} {
+		if len(repo.eventsOfType(id, models.EventRemediationResumed)) != 0 {
+			t.Errorf("%s: expected no resumed event", id)
+		}
+	}
+}
+
+func TestMaintenanceWorker_Check_MostSevereFirst(t *testing.T) {
+	now := time.Date(2024, 3, 10, 4, 0, 0, 0, time.UTC)
+	ended := now.Add(-time.Second)
+
+	repo := newMockRepository()
+	for i, tt := range []struct{ id, severity string }{
+		{"low-old", "low"},
+		{"unknown", ""},
+		{"medium", "medium"},
+		{"low-new", "low"},
+		{"critical", "critical"},
+	} {
+		repo.incidents = append(repo.incidents, &models.Incident{
+			ID:            tt.id,
+			Repository:    "org/a",
+			Severity:      tt.severity,
+			Status:        models.StatusPending,
+			DeferredUntil: &ended,
+			CreatedAt:     now.Add(time.Duration(i-10) * time.Minute),
+		})
+	}
+
+	var dispatched []string
+	worker := NewMaintenanceWorker(repo, func(ctx context.Context, incident *models.Incident) error {
+		dispatched = append(dispatched, incident.ID)
+		return nil
+	}, nopLogger{})
+	worker.now = func() time.Time { return now }
+
+	if err := worker.Check(context.Background()); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if got := fmt.Sprint(dispatched); got != "[critical medium low-old low-new unknown]" {
+		t.Errorf("expected deferred incidents dispatched most severe first, got %s", got)
+	}
+}
@@ -0,0 +1,54 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/notifications"
+)
+
+// NotificationRetryWorker periodically resends failed notification deliveries
+type NotificationRetryWorker struct {
+	notifier *notifications.Dispatcher
+	logger   Logger
+	stopCh   chan struct{}
+}
+
+// NewNotificationRetryWorker creates a new notification retry worker
+func NewNotificationRetryWorker(notifier *notifications.Dispatcher, logger Logger) *NotificationRetryWorker {
+	return &NotificationRetryWorker{
+		notifier: notifier,
+		logger:   logger,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start retries due deliveries at the given interval until Stop is called
+func (w *NotificationRetryWorker) Start(interval time.Duration) {
+	started("notification_retry", interval)
+	defer stopped("notification_retry")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("notification_retry")
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if err := w.notifier.RetryDue(ctx); err != nil {
+				w.logger.Error("notification retry failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+			cancel()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the notification retry worker
+func (w *NotificationRetryWorker) Stop() {
+	close(w.stopCh)
+}
@@ -0,0 +1,169 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// QueueSnapshotRepository defines the persistence operations needed to save
+// and restore the workflow slots and queues
+type QueueSnapshotRepository interface {
+	SaveQueueSnapshot(snapshot *github.QueueSnapshot) error
+	GetQueueSnapshot() (*github.QueueSnapshot, error)
+	GetByID(id string) (*models.Incident, error)
+	ListDispatches(incidentID string) ([]*models.IncidentDispatch, error)
+}
+
+// QueueState is the workflow concurrency state of the GitHub client
+type QueueState interface {
+	Snapshot(ctx context.Context) (*github.QueueSnapshot, error)
+	Restore(ctx context.Context, repository string, active int, queued []*models.Incident) error
+}
+
+// QueueSnapshotWorker periodically saves the in-memory workflow slots and
+// queues to the database, so that a restarted service restores them and
+// dispatches the incidents that were waiting for a slot
+type QueueSnapshotWorker struct {
+	state  QueueState
+	repo   QueueSnapshotRepository
+	logger Logger
+	stopCh chan struct{}
+	saved  map[string]github.RepositorySnapshot
+}
+
+// NewQueueSnapshotWorker creates a new queue snapshot worker
+func NewQueueSnapshotWorker(state QueueState, repo QueueSnapshotRepository, logger Logger) *QueueSnapshotWorker {
+	return &QueueSnapshotWorker{
+		state:  state,
+		repo:   repo,
+		logger: logger,
+		stopCh: make(chan struct{}),
+	}
+}
+
+// Start saves a snapshot at the given interval until Stop is called
+func (w *QueueSnapshotWorker) Start(interval time.Duration) {
+	started("queue_snapshot", interval)
+	defer stopped("queue_snapshot")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("queue_snapshot")
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if err := w.Save(ctx); err != nil {
+				w.logger.Error("queue snapshot failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+			cancel()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the queue snapshot worker. It does not save a last snapshot;
+// call Save once nothing dispatches anymore.
+func (w *QueueSnapshotWorker) Stop() {
+	close(w.stopCh)
+}
+
+// Save stores a snapshot of the slots and queues, unless they are as the
+// last snapshot saved them
+func (w *QueueSnapshotWorker) Save(ctx context.Context) error {
+	snapshot, err := w.state.Snapshot(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to take queue snapshot: %w", err)
+	}
+	if w.saved != nil && reflect.DeepEqual(snapshot.Repositories, w.saved) {
+		return nil
+	}
+	if err := w.repo.SaveQueueSnapshot(snapshot); err != nil {
+		return fmt.Errorf("failed to save queue snapshot: %w", err)
+	}
+	w.saved = snapshot.Repositories
+	return nil
+}
+
+// Restore takes the slots and queues the incidents of the last snapshot saved,
+// returning the number of incidents queued again. Incidents that are no longer
+// waiting for remediation in the repository, because they were resolved,
+// dispatched or deleted since, are left out.
+func (w *QueueSnapshotWorker) Restore(ctx context.Context) (int, error) {
+	snapshot, err := w.repo.GetQueueSnapshot()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get queue snapshot: %w", err)
+	}
+	if snapshot == nil {
+		return 0, nil
+	}
+
+	restored := 0
+	for repository, entry := range snapshot.Repositories {
+		queued := make([]*models.Incident, 0, len(entry.Queued))
+		for _, id := range entry.Queued {
+			incident, err := w.waiting(id, repository)
+			if err != nil {
+				w.logger.Warn("failed to restore queued incident", map[string]interface{}{
+					"error":       err.Error(),
+					"incident_id": id,
+					"repository":  repository,
+				})
+				continue
+			}
+			if incident != nil {
+				queued = append(queued, incident)
+			}
+		}
+		if err := w.state.Restore(ctx, repository, entry.Active, queued); err != nil {
+			return restored, err
+		}
+		restored += len(queued)
+	}
+
+	w.logger.Info("workflow queues restored", map[string]interface{}{
+		"repositories": len(snapshot.Repositories),
+		"incidents":    restored,
+		"taken_at":     snapshot.TakenAt,
+	})
+	return restored, nil
+}
+
+// waiting returns the incident to queue for the repository, or nil when it is
+// no longer waiting for remediation there. An incident fanned out to several
+// repositories is queued for the others as a copy, waiting until its dispatch
+// to that repository is recorded as triggered.
+func (w *QueueSnapshotWorker) waiting(id, repository string) (*models.Incident, error) {
+	incident, err := w.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+	if incident.Repository == repository {
+		if incident.Status != models.StatusPending {
+			return nil, nil
+		}
+		return incident, nil
+	}
+
+	dispatches, err := w.repo.ListDispatches(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dispatches: %w", err)
+	}
+	for _, dispatch := range dispatches {
+		if dispatch.Repository == repository && dispatch.Status == models.StatusPending {
+			child := *incident
+			child.Repository = repository
+			return &child, nil
+		}
+	}
+	return nil, nil
+}
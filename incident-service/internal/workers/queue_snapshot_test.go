@@ -0,0 +1,102 @@
+package workers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// snapshotRepository stores one queue snapshot in memory
+type snapshotRepository struct {
+	*mockRepository
+	snapshot   *github.QueueSnapshot
+	saves      int
+	dispatches map[string][]*models.IncidentDispatch
+}
+
+func (r *snapshotRepository) SaveQueueSnapshot(snapshot *github.QueueSnapshot) error {
+	r.snapshot = snapshot
+	r.saves++
+	return nil
+}
+
+func (r *snapshotRepository) GetQueueSnapshot() (*github.QueueSnapshot, error) {
+	return r.snapshot, nil
+}
+
+func (r *snapshotRepository) ListDispatches(incidentID string) ([]*models.IncidentDispatch, error) {
+	return r.dispatches[incidentID], nil
+}
+
+func newSnapshotClient() *github.Client {
+	return github.NewClient("https://api.github.com", "test-token", "test-workflow.yml", 1)
+}
+
+func TestQueueSnapshotWorker_SaveAndRestore(t *testing.T) {
+	ctx := context.Background()
+	repo := &snapshotRepository{
+		mockRepository: newMockRepository(
+			&models.Incident{ID: "inc_pending", Repository: "org/checkout", Status: models.StatusPending},
+			&models.Incident{ID: "inc_resolved", Repository: "org/checkout", Status: models.StatusResolved},
+			&models.Incident{ID: "inc_fanned_out", Repository: "org/checkout", Status: models.StatusWorkflowTriggered},
+		),
+		dispatches: map[string][]*models.IncidentDispatch{
+			"inc_fanned_out": {{IncidentID: "inc_fanned_out", Repository: "org/infra", Status: models.StatusPending}},
+		},
+	}
+	repo.snapshot = &github.QueueSnapshot{Repositories: map[string]github.RepositorySnapshot{
+		"org/checkout": {Active: 1, Queued: []string{"inc_resolved", "inc_pending", "inc_deleted"}},
+		"org/infra":    {Active: 1, Queued: []string{"inc_fanned_out"}},
+	}}
+
+	client := newSnapshotClient()
+	worker := NewQueueSnapshotWorker(client, repo, nopLogger{})
+	restored, err := worker.Restore(ctx)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if restored != 2 {
+		t.Errorf("expected 2 incidents restored, got %d", restored)
+	}
+	if got := client.QueuedIncidents("org/checkout"); !reflect.DeepEqual(got, []string{"inc_pending"}) {
+		t.Errorf("org/checkout queue = %v, want only the pending incident", got)
+	}
+	if got := client.GetActiveCount("org/checkout"); got != 1 {
+		t.Errorf("org/checkout active = %d, want 1", got)
+	}
+	if next := client.DecrementActive("org/infra"); next == nil || next.ID != "inc_fanned_out" || next.Repository != "org/infra" {
+		t.Errorf("expected the fanned out incident queued for org/infra, got %+v", next)
+	}
+
+	if err := worker.Save(ctx); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	want := map[string]github.RepositorySnapshot{"org/checkout": {Active: 1, Queued: []string{"inc_pending"}}}
+	if !reflect.DeepEqual(repo.snapshot.Repositories, want) {
+		t.Errorf("saved %+v, want %+v", repo.snapshot.Repositories, want)
+	}
+
+	// An unchanged state is not saved again
+	if err := worker.Save(ctx); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if repo.saves != 1 {
+		t.Errorf("expected 1 save, got %d", repo.saves)
+	}
+}
+
+func TestQueueSnapshotWorker_RestoreWithoutSnapshot(t *testing.T) {
+	client := newSnapshotClient()
+	worker := NewQueueSnapshotWorker(client, &snapshotRepository{mockRepository: newMockRepository()}, nopLogger{})
+
+	restored, err := worker.Restore(context.Background())
+	if err != nil || restored != 0 {
+		t.Fatalf("Restore() = %d, %v, want nothing restored", restored, err)
+	}
+	if stats := client.Stats(); len(stats) != 0 {
+		t.Errorf("expected no slots or queues, got %+v", stats)
+	}
+}
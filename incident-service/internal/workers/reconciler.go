@@ -0,0 +1,287 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/notifications"
+)
+
+// ReconcileRepository defines the persistence operations needed for reconciliation
+type ReconcileRepository interface {
+	ListWithFilter(filter *database.IncidentFilter) ([]*models.Incident, error)
+	Update(incident *models.Incident) error
+	LogEvent(event *models.IncidentEvent) error
+}
+
+// GitHubState looks up what actually happened to a dispatched remediation
+type GitHubState interface {
+	ListWorkflowRuns(ctx context.Context, repository string, since time.Time) ([]github.WorkflowRun, error)
+	FindPullRequest(ctx context.Context, repository, branch string) (*github.PullRequest, error)
+}
+
+// SlotReleaser frees the workflow concurrency slot an incident holds in a
+// repository and dispatches the next queued incident
+type SlotReleaser func(ctx context.Context, repository, incidentID string)
+
+// reconcileStatuses are the statuses that wait on a workflow-status callback
+var reconcileStatuses = []models.IncidentStatus{
+	models.StatusWorkflowTriggered,
+	models.StatusInProgress,
+}
+
+// Reconciler periodically cross-checks dispatched incidents against their
+// GitHub workflow runs and pull requests, and repairs incidents whose
+// workflow-status callback never arrived
+type Reconciler struct {
+	repo        ReconcileRepository
+	github      GitHubState
+	notifier    *notifications.Dispatcher
+	release     SlotReleaser
+	gracePeriod time.Duration
+	logger      Logger
+	stopCh      chan struct{}
+	now         func() time.Time
+}
+
+// NewReconciler creates a new reconciliation worker
+func NewReconciler(cfg config.ReconciliationConfig, repo ReconcileRepository, githubState GitHubState, notifier *notifications.Dispatcher, release SlotReleaser, logger Logger) *Reconciler {
+	return &Reconciler{
+		repo:        repo,
+		github:      githubState,
+		notifier:    notifier,
+		release:     release,
+		gracePeriod: cfg.GracePeriod(),
+		logger:      logger,
+		stopCh:      make(chan struct{}),
+		now:         time.Now,
+	}
+}
+
+// Start runs reconciliation at the given interval until Stop is called
+func (w *Reconciler) Start(interval time.Duration) {
+	started("reconciler", interval)
+	defer stopped("reconciler")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("reconciler")
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if err := w.Reconcile(ctx); err != nil {
+				w.logger.Error("reconciliation failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+			cancel()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the reconciliation worker
+func (w *Reconciler) Stop() {
+	close(w.stopCh)
+}
+
+// Reconcile checks every dispatched incident older than the grace period once
+func (w *Reconciler) Reconcile(ctx context.Context) error {
+	cutoff := w.now().Add(-w.gracePeriod)
+
+	byRepository := make(map[string][]*models.Incident)
+	for _, status := range reconcileStatuses {
+		status := status
+		incidents, err := w.repo.ListWithFilter(&database.IncidentFilter{Status: &status})
+		if err != nil {
+			return fmt.Errorf("failed to list %s incidents: %w", status, err)
+		}
+
+		for _, incident := range incidents {
+			if dispatchedAt(incident).After(cutoff) || incident.Repository == "" {
+				continue
+			}
+			byRepository[incident.Repository] = append(byRepository[incident.Repository], incident)
+		}
+	}
+
+	for repository, incidents := range byRepository {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// One run listing covers every incident in the repository
+		since := dispatchedAt(incidents[0])
+		for _, incident := range incidents[1:] {
+			if at := dispatchedAt(incident); at.Before(since) {
+				since = at
+			}
+		}
+
+		runs, err := w.github.ListWorkflowRuns(ctx, repository, since.Add(-time.Minute))
+		if err != nil {
+			w.logger.Error("failed to list workflow runs for reconciliation", map[string]interface{}{
+				"error":      err.Error(),
+				"repository": repository,
+			})
+			continue
+		}
+
+		for _, incident := range incidents {
+			if err := w.reconcile(ctx, incident, runs); err != nil {
+				w.logger.Error("failed to reconcile incident", map[string]interface{}{
+					"error":       err.Error(),
+					"incident_id": incident.ID,
+					"repository":  repository,
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// reconcile repairs a single incident if GitHub shows a state it has not caught up with
+func (w *Reconciler) reconcile(ctx context.Context, incident *models.Incident, runs []github.WorkflowRun) error {
+	pr, err := w.github.FindPullRequest(ctx, incident.Repository, github.RemediationBranch(incident.ID))
+	if err != nil {
+		return err
+	}
+	// A pull request from before this dispatch belongs to an earlier fix that
+	// did not hold, such as that of a reopened incident
+	if pr != nil && pr.CreatedAt.Before(dispatchedAt(incident)) {
+		pr = nil
+	}
+	run := matchWorkflowRun(incident, runs)
+
+	previous := incident.Status
+	details := map[string]interface{}{
+		"previous_status": previous,
+	}
+
+	var completedAt time.Time
+	switch {
+	case pr != nil:
+		incident.Status = models.StatusPRCreated
+		incident.PullRequestURL = &pr.HTMLURL
+		completedAt = pr.CreatedAt
+		details["source"] = "pull_request"
+		details["pull_request_url"] = pr.HTMLURL
+	case run == nil:
+		return nil
+	case run.Completed() && run.Conclusion == "success":
+		incident.Status = models.StatusNoFixNeeded
+		completedAt = run.UpdatedAt
+		details["source"] = "workflow_run"
+	case run.Completed():
+		incident.Status = models.StatusFailed
+		completedAt = run.UpdatedAt
+		details["source"] = "workflow_run"
+	case incident.Status == models.StatusWorkflowTriggered && run.Status == "in_progress":
+		incident.Status = models.StatusInProgress
+		details["source"] = "workflow_run"
+	default:
+		return nil
+	}
+
+	if run != nil {
+		runID := run.ID
+		incident.WorkflowRunID = &runID
+		details["workflow_run_id"] = run.ID
+		details["workflow_run_url"] = run.HTMLURL
+		details["workflow_conclusion"] = run.Conclusion
+	}
+	if !completedAt.IsZero() {
+		incident.CompletedAt = &completedAt
+	}
+	details["status"] = incident.Status
+
+	if err := w.repo.Update(incident); err != nil {
+		return fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: incident.ID,
+		EventType:  models.EventReconciled,
+		EventData:  details,
+	}
+	if err := w.repo.LogEvent(event); err != nil {
+		w.logger.Error("failed to log reconciled event", map[string]interface{}{
+			"error":       err.Error(),
+			"incident_id": incident.ID,
+		})
+	}
+
+	w.logger.Warn("incident reconciled with github", map[string]interface{}{
+		"incident_id":     incident.ID,
+		"repository":      incident.Repository,
+		"previous_status": previous,
+		"status":          incident.Status,
+	})
+
+	switch incident.Status {
+	case models.StatusPRCreated:
+		w.notify(ctx, models.EventPRCreated, incident)
+	case models.StatusFailed:
+		w.notify(ctx, models.EventIncidentFailed, incident)
+	default:
+		w.notify(ctx, models.EventStatusChanged, incident)
+	}
+
+	// The callback that would have freed the slot never came
+	if incident.Status != models.StatusInProgress && w.release != nil {
+		w.release(ctx, incident.Repository, incident.ID)
+	}
+
+	return nil
+}
+
+// notify sends a notification, logging any failure
+func (w *Reconciler) notify(ctx context.Context, eventType models.IncidentEventType, incident *models.Incident) {
+	if w.notifier == nil {
+		return
+	}
+	if err := w.notifier.Notify(ctx, eventType, incident); err != nil {
+		w.logger.Error("failed to send notification", map[string]interface{}{
+			"error":       err.Error(),
+			"event_type":  eventType,
+			"incident_id": incident.ID,
+		})
+	}
+}
+
+// matchWorkflowRun finds the incident's run, by recorded run ID or by the
+// incident ID in the run name. Runs are listed newest first, so the latest
+// attempt wins.
+func matchWorkflowRun(incident *models.Incident, runs []github.WorkflowRun) *github.WorkflowRun {
+	for i := range runs {
+		run := &runs[i]
+		if incident.WorkflowRunID != nil && *incident.WorkflowRunID != 0 {
+			if run.ID == *incident.WorkflowRunID {
+				return run
+			}
+			continue
+		}
+		if strings.Contains(run.DisplayTitle, incident.ID) || strings.Contains(run.Name, incident.ID) {
+			return run
+		}
+	}
+	return nil
+}
+
+// dispatchedAt returns when the incident's workflow was dispatched, falling back to its creation time
+func dispatchedAt(incident *models.Incident) time.Time {
+	if incident.TriggeredAt != nil {
+		return *incident.TriggeredAt
+	}
+	return incident.CreatedAt
+}
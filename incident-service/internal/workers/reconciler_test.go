@@ -0,0 +1,142 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/notifications"
+)
+
+func (m *mockRepository) Update(incident *models.Incident) error {
+	return nil
+}
+
+// fakeGitHub serves canned workflow runs and pull requests
+type fakeGitHub struct {
+	runs  map[string][]github.WorkflowRun // repository -> runs, newest first
+	pulls map[string]*github.PullRequest  // branch -> pull request
+}
+
+func (f *fakeGitHub) ListWorkflowRuns(ctx context.Context, repository string, since time.Time) ([]github.WorkflowRun, error) {
+	return f.runs[repository], nil
+}
+
+func (f *fakeGitHub) FindPullRequest(ctx context.Context, repository, branch string) (*github.PullRequest, error) {
+	return f.pulls[branch], nil
+}
+
+func TestReconciler_Reconcile(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	triggered := now.Add(-time.Hour)
+	recent := now.Add(-time.Minute)
+	prCreated := now.Add(-30 * time.Minute)
+
+	incidents := map[string]*models.Incident{
+		"inc-pr":       {ID: "inc-pr", Repository: "org/repo", Status: models.StatusInProgress, TriggeredAt: &triggered},
+		"inc-failed":   {ID: "inc-failed", Repository: "org/repo", Status: models.StatusWorkflowTriggered, TriggeredAt: &triggered},
+		"inc-nofix":    {ID: "inc-nofix", Repository: "org/repo", Status: models.StatusInProgress, TriggeredAt: &triggered},
+		"inc-running":  {ID: "inc-running", Repository: "org/repo", Status: models.StatusWorkflowTriggered, TriggeredAt: &triggered},
+		"inc-unknown":  {ID: "inc-unknown", Repository: "org/repo", Status: models.StatusWorkflowTriggered, TriggeredAt: &triggered},
+		"inc-recent":   {ID: "inc-recent", Repository: "org/repo", Status: models.StatusWorkflowTriggered, TriggeredAt: &recent},
+		"inc-resolved": {ID: "inc-resolved", Repository: "org/repo", Status: models.StatusResolved, TriggeredAt: &triggered},
+		"inc-reopened": {ID: "inc-reopened", Repository: "org/repo", Status: models.StatusWorkflowTriggered, TriggeredAt: &triggered},
+	}
+	repo := newMockRepository()
+	for _, incident := range incidents {
+		repo.incidents = append(repo.incidents, incident)
+	}
+
+	gh := &fakeGitHub{
+		runs: map[string][]github.WorkflowRun{
+			"org/repo": {
+				{ID: 6, DisplayTitle: "Remediate incident inc-reopened", Status: "in_progress"},
+				{ID: 5, DisplayTitle: "Remediate incident inc-recent", Status: "completed", Conclusion: "failure"},
+				{ID: 4, DisplayTitle: "Remediate incident inc-running", Status: "in_progress"},
+				{ID: 3, DisplayTitle: "Remediate incident inc-nofix", Status: "completed", Conclusion: "success", UpdatedAt: now.Add(-20 * time.Minute)},
+				{ID: 2, DisplayTitle: "Remediate incident inc-failed", Status: "completed", Conclusion: "timed_out", UpdatedAt: now.Add(-10 * time.Minute)},
+				{ID: 1, DisplayTitle: "Remediate incident inc-pr", Status: "completed", Conclusion: "success"},
+			},
+		},
+		pulls: map[string]*github.PullRequest{
+			github.RemediationBranch("inc-pr"):     {Number: 9, HTMLURL: "https://github.com/org/repo/pull/9", CreatedAt: prCreated},
+			github.RemediationBranch("inc-recent"): {Number: 10, HTMLURL: "https://github.com/org/repo/pull/10"},
+			// Merged by the fix that did not hold, before the incident was reopened
+			github.RemediationBranch("inc-reopened"): {Number: 3, HTMLURL: "https://github.com/org/repo/pull/3", CreatedAt: triggered.Add(-24 * time.Hour)},
+		},
+	}
+
+	var released []string
+	release := func(ctx context.Context, repository, incidentID string) {
+		released = append(released, repository)
+	}
+
+	reconciler := NewReconciler(config.ReconciliationConfig{MinAge: 15 * time.Minute}, repo, gh, notifications.NewDispatcher(config.NotificationsConfig{}), release, nopLogger{})
+	reconciler.now = func() time.Time { return now }
+
+	if err := reconciler.Reconcile(context.Background()); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	tests := []struct {
+		id         string
+		wantStatus models.IncidentStatus
+		wantEvent  bool
+	}{
+		{"inc-pr", models.StatusPRCreated, true},
+		{"inc-failed", models.StatusFailed, true},
+		{"inc-nofix", models.StatusNoFixNeeded, true},
+		{"inc-running", models.StatusInProgress, true},
+		{"inc-unknown", models.StatusWorkflowTriggered, false},
+		{"inc-recent", models.StatusWorkflowTriggered, false},
+		{"inc-resolved", models.StatusResolved, false},
+		{"inc-reopened", models.StatusInProgress, true},
+	}
+	for _, tt := range tests {
+		incident := incidents[tt.id]
+		if incident.Status != tt.wantStatus {
+			t.Errorf("%s: status = %s, want %s", tt.id, incident.Status, tt.wantStatus)
+		}
+		events := repo.eventsOfType(tt.id, models.EventReconciled)
+		if tt.wantEvent != (len(events) == 1) {
+			t.Errorf("%s: expected reconciled event %v, got %d events", tt.id, tt.wantEvent, len(events))
+		}
+	}
+
+	pr := incidents["inc-pr"]
+	if pr.PullRequestURL == nil || *pr.PullRequestURL != "https://github.com/org/repo/pull/9" {
+		t.Errorf("expected pull request URL to be recorded, got %v", pr.PullRequestURL)
+	}
+	if pr.CompletedAt == nil || !pr.CompletedAt.Equal(prCreated) {
+		t.Errorf("expected completion at PR creation, got %v", pr.CompletedAt)
+	}
+	if pr.WorkflowRunID == nil || *pr.WorkflowRunID != 1 {
+		t.Errorf("expected workflow run ID 1, got %v", pr.WorkflowRunID)
+	}
+
+	event := repo.eventsOfType("inc-failed", models.EventReconciled)[0]
+	if event.EventData["previous_status"] != models.StatusWorkflowTriggered || event.EventData["workflow_conclusion"] != "timed_out" {
+		t.Errorf("unexpected reconciled event data %v", event.EventData)
+	}
+
+	// Finished incidents free their slot, the one still running keeps it
+	if len(released) != 3 {
+		t.Errorf("expected 3 released slots, got %d", len(released))
+	}
+}
+
+func TestMatchWorkflowRun_ByRunID(t *testing.T) {
+	runID := int64(2)
+	incident := &models.Incident{ID: "inc-1", WorkflowRunID: &runID}
+	runs := []github.WorkflowRun{
+		{ID: 3, DisplayTitle: "Remediate incident inc-1"},
+		{ID: 2, DisplayTitle: "Remediate"},
+	}
+
+	if run := matchWorkflowRun(incident, runs); run == nil || run.ID != 2 {
+		t.Errorf("expected the recorded run to win, got %+v", run)
+	}
+}
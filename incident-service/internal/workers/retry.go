@@ -0,0 +1,210 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// RetryRepository defines the persistence operations needed to retry failed incidents
+type RetryRepository interface {
+	ListWithFilter(filter *database.IncidentFilter) ([]*models.Incident, error)
+	Update(incident *models.Incident) error
+	LogEvent(event *models.IncidentEvent) error
+}
+
+// IncidentDispatcher triggers the remediation workflow for a pending incident
+type IncidentDispatcher func(ctx context.Context, incident *models.Incident) error
+
+// RetryScheduler periodically re-dispatches failed incidents, backing off
+// exponentially between attempts up to a maximum number of retries
+type RetryScheduler struct {
+	repo     RetryRepository
+	dispatch IncidentDispatcher
+	policy   config.RemediationRetryConfig
+	logger   Logger
+	stopCh   chan struct{}
+	now      func() time.Time
+}
+
+// NewRetryScheduler creates a new retry scheduler
+func NewRetryScheduler(cfg config.RemediationRetryConfig, repo RetryRepository, dispatch IncidentDispatcher, logger Logger) *RetryScheduler {
+	return &RetryScheduler{
+		repo:     repo,
+		dispatch: dispatch,
+		policy:   cfg,
+		logger:   logger,
+		stopCh:   make(chan struct{}),
+		now:      time.Now,
+	}
+}
+
+// Start runs retry checks at the given interval until Stop is called
+func (w *RetryScheduler) Start(interval time.Duration) {
+	started("remediation_retry", interval)
+	defer stopped("remediation_retry")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("remediation_retry")
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if err := w.Check(ctx); err != nil {
+				w.logger.Error("remediation retry check failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+			cancel()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the retry scheduler
+func (w *RetryScheduler) Stop() {
+	close(w.stopCh)
+}
+
+// Check schedules a retry for newly failed incidents and re-dispatches those whose backoff has elapsed
+func (w *RetryScheduler) Check(ctx context.Context) error {
+	status := models.StatusFailed
+	incidents, err := w.repo.ListWithFilter(&database.IncidentFilter{Status: &status})
+	if err != nil {
+		return fmt.Errorf("failed to list failed incidents: %w", err)
+	}
+
+	maxRetries := w.policy.Retries()
+	now := w.now()
+
+	for _, incident := range incidents {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if incident.RetryCount >= maxRetries || incident.Repository == "" || incident.Snoozed(now) {
+			continue
+		}
+
+		if incident.NextRetryAt == nil {
+			// Only failures within the retry window get a retry scheduled
+			failedAt := finishedAt(incident)
+			if now.Sub(failedAt) > w.policy.RetryWindow() {
+				continue
+			}
+			if err := w.schedule(incident, failedAt.Add(w.policy.Backoff(incident.RetryCount))); err != nil {
+				w.logger.Error("failed to schedule remediation retry", map[string]interface{}{
+					"error":       err.Error(),
+					"incident_id": incident.ID,
+				})
+			}
+			continue
+		}
+
+		if incident.NextRetryAt.After(now) {
+			continue
+		}
+
+		if err := w.retry(ctx, incident); err != nil {
+			w.logger.Error("failed to retry remediation", map[string]interface{}{
+				"error":       err.Error(),
+				"incident_id": incident.ID,
+			})
+		}
+	}
+
+	return nil
+}
+
+// schedule records when a failed incident will next be retried
+func (w *RetryScheduler) schedule(incident *models.Incident, at time.Time) error {
+	incident.NextRetryAt = &at
+	if err := w.repo.Update(incident); err != nil {
+		return fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: incident.ID,
+		EventType:  models.EventRetryScheduled,
+		EventData: map[string]interface{}{
+			"attempt":       incident.RetryCount + 1,
+			"max_retries":   w.policy.Retries(),
+			"next_retry_at": at,
+		},
+	}
+	if err := w.repo.LogEvent(event); err != nil {
+		w.logger.Error("failed to log retry scheduled event", map[string]interface{}{
+			"error":       err.Error(),
+			"incident_id": incident.ID,
+		})
+	}
+
+	w.logger.Info("remediation retry scheduled", map[string]interface{}{
+		"incident_id":   incident.ID,
+		"repository":    incident.Repository,
+		"attempt":       incident.RetryCount + 1,
+		"next_retry_at": at,
+	})
+
+	return nil
+}
+
+// retry resets a failed incident to pending and dispatches it again
+func (w *RetryScheduler) retry(ctx context.Context, incident *models.Incident) error {
+	incident.RetryCount++
+	incident.NextRetryAt = nil
+	incident.Status = models.StatusPending
+	incident.TriggeredAt = nil
+	incident.CompletedAt = nil
+	incident.WorkflowRunID = nil
+	incident.PullRequestURL = nil
+
+	if err := w.repo.Update(incident); err != nil {
+		return fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: incident.ID,
+		EventType:  models.EventRemediationRetried,
+		EventData: map[string]interface{}{
+			"attempt":     incident.RetryCount,
+			"max_retries": w.policy.Retries(),
+		},
+	}
+	if err := w.repo.LogEvent(event); err != nil {
+		w.logger.Error("failed to log remediation retried event", map[string]interface{}{
+			"error":       err.Error(),
+			"incident_id": incident.ID,
+		})
+	}
+
+	w.logger.Info("retrying failed remediation", map[string]interface{}{
+		"incident_id": incident.ID,
+		"repository":  incident.Repository,
+		"attempt":     incident.RetryCount,
+	})
+
+	// A failed dispatch marks the incident failed again, and the next check schedules another retry
+	if w.dispatch != nil {
+		if err := w.dispatch(ctx, incident); err != nil {
+			return fmt.Errorf("failed to dispatch retry: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// finishedAt returns when the incident reached its current terminal status,
+// falling back to its last update
+func finishedAt(incident *models.Incident) time.Time {
+	if incident.CompletedAt != nil {
+		return *incident.CompletedAt
+	}
+	return incident.UpdatedAt
+}
@@ -0,0 +1,115 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestRetryScheduler_Check(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	justFailed := now.Add(-time.Minute)
+	due := now.Add(-time.Second)
+	later := now.Add(10 * time.Minute)
+	snoozedUntil := now.Add(time.Hour)
+	longAgo := now.Add(-48 * time.Hour)
+	runID := int64(42)
+
+	incidents := map[string]*models.Incident{
+		"new-failure": {ID: "new-failure", Repository: "org/a", Status: models.StatusFailed, CompletedAt: &justFailed, RetryCount: 1},
+		"due":         {ID: "due", Repository: "org/a", Status: models.StatusFailed, CompletedAt: &justFailed, NextRetryAt: &due, WorkflowRunID: &runID},
+		"not-due":     {ID: "not-due", Repository: "org/a", Status: models.StatusFailed, CompletedAt: &justFailed, NextRetryAt: &later},
+		"exhausted":   {ID: "exhausted", Repository: "org/a", Status: models.StatusFailed, CompletedAt: &justFailed, RetryCount: 3},
+		"too-old":     {ID: "too-old", Repository: "org/a", Status: models.StatusFailed, CompletedAt: &longAgo},
+		"unmapped":    {ID: "unmapped", Status: models.StatusFailed, CompletedAt: &justFailed},
+		"resolved":    {ID: "resolved", Repository: "org/a", Status: models.StatusResolved, CompletedAt: &justFailed},
+		"snoozed":     {ID: "snoozed", Repository: "org/a", Status: models.StatusFailed, CompletedAt: &justFailed, SnoozedUntil: &snoozedUntil},
+	}
+	repo := newMockRepository()
+	for _, incident := range incidents {
+		repo.incidents = append(repo.incidents, incident)
+	}
+
+	var dispatched []string
+	scheduler := NewRetryScheduler(
+		config.RemediationRetryConfig{MaxRetries: 3, InitialBackoff: 5 * time.Minute, MaxBackoff: time.Hour},
+		repo,
+		func(ctx context.Context, incident *models.Incident) error {
+			dispatched = append(dispatched, incident.ID)
+			return nil
+		},
+		nopLogger{},
+	)
+	scheduler.now = func() time.Time { return now }
+
+	if err := scheduler.Check(context.Background()); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	// The second retry backs off twice as long as the first
+	scheduled := incidents["new-failure"]
+	if scheduled.NextRetryAt == nil || !scheduled.NextRetryAt.Equal(justFailed.Add(10*time.Minute)) {
+		t.Errorf("expected retry scheduled 10m after failure, got %v", scheduled.NextRetryAt)
+	}
+	if scheduled.Status != models.StatusFailed {
+		t.Errorf("expected scheduled incident to stay failed, got %s", scheduled.Status)
+	}
+	if len(repo.eventsOfType("new-failure", models.EventRetryScheduled)) != 1 {
+		t.Error("expected a retry scheduled event")
+	}
+
+	retried := incidents["due"]
+	if retried.Status != models.StatusPending || retried.RetryCount != 1 {
+		t.Errorf("expected due incident pending on retry 1, got %s on retry %d", retried.Status, retried.RetryCount)
+	}
+	if retried.NextRetryAt != nil || retried.CompletedAt != nil || retried.WorkflowRunID != nil {
+		t.Error("expected the previous attempt to be cleared")
+	}
+	events := repo.eventsOfType("due", models.EventRemediationRetried)
+	if len(events) != 1 || events[0].EventData["attempt"] != 1 {
+		t.Errorf("expected a remediation retried event for attempt 1, got %v", events)
+	}
+	if len(dispatched) != 1 || dispatched[0] != "due" {
+		t.Errorf("expected only the due incident to be dispatched, got %v", dispatched)
+	}
+
+	for _, id := range []string{"not-due", "exhausted", "too-old", "unmapped", "resolved", "snoozed"} {
+		if len(repo.eventsOfType(id, models.EventRetryScheduled))+len(repo.eventsOfType(id, models.EventRemediationRetried)) != 0 {
+			t.Errorf("%s: expected no retry activity", id)
+		}
+	}
+	if incidents["too-old"].NextRetryAt != nil {
+		t.Error("expected failures outside the retry window not to be scheduled")
+	}
+}
+
+func TestRetryScheduler_DispatchFailure(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	due := now.Add(-time.Second)
+	incident := &models.Incident{ID: "inc-1", Repository: "org/a", Status: models.StatusFailed, UpdatedAt: now.Add(-time.Hour), NextRetryAt: &due}
+
+	repo := newMockRepository(incident)
+	scheduler := NewRetryScheduler(
+		config.RemediationRetryConfig{},
+		repo,
+		func(ctx context.Context, incident *models.Incident) error {
+			incident.Status = models.StatusFailed
+			return errors.New("github unavailable")
+		},
+		nopLogger{},
+	)
+	scheduler.now = func() time.Time { return now }
+
+	if err := scheduler.Check(context.Background()); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	// The attempt still counts, so the next check schedules the following retry
+	if incident.RetryCount != 1 || incident.NextRetryAt != nil {
+		t.Errorf("expected retry 1 with no retry scheduled yet, got %d, %v", incident.RetryCount, incident.NextRetryAt)
+	}
+}
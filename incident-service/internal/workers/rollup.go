@@ -0,0 +1,100 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+)
+
+// RollupRepository defines the persistence operations needed to materialize rollups
+type RollupRepository interface {
+	RefreshRollups(granularity database.RollupGranularity, start, end time.Time) (int64, error)
+}
+
+// RollupWorker periodically recomputes the recent hourly and daily incident
+// rollups, so statistics queries read small summary buckets instead of
+// scanning the incidents table
+type RollupWorker struct {
+	repo      RollupRepository
+	lookbacks map[database.RollupGranularity]time.Duration
+	logger    Logger
+	stopCh    chan struct{}
+	now       func() time.Time
+}
+
+// NewRollupWorker creates a new rollup worker
+func NewRollupWorker(cfg config.RollupConfig, repo RollupRepository, logger Logger) *RollupWorker {
+	return &RollupWorker{
+		repo: repo,
+		lookbacks: map[database.RollupGranularity]time.Duration{
+			database.RollupHourly: cfg.HourlyWindow(),
+			database.RollupDaily:  cfg.DailyWindow(),
+		},
+		logger: logger,
+		stopCh: make(chan struct{}),
+		now:    time.Now,
+	}
+}
+
+// Start refreshes the rollups immediately and then at the given interval until Stop is called
+func (w *RollupWorker) Start(interval time.Duration) {
+	started("rollup", interval)
+	defer stopped("rollup")
+
+	w.refresh(interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("rollup")
+			w.refresh(interval)
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the rollup worker
+func (w *RollupWorker) Stop() {
+	close(w.stopCh)
+}
+
+// refresh runs one refresh, logging any failure
+func (w *RollupWorker) refresh(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := w.Refresh(ctx); err != nil {
+		w.logger.Error("rollup refresh failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+}
+
+// Refresh recomputes every bucket within the lookback windows, up to and
+// including the current, still open bucket. Incidents change status after
+// they are created, so recent buckets are rewritten on every refresh.
+func (w *RollupWorker) Refresh(ctx context.Context) error {
+	now := w.now()
+
+	for _, granularity := range []database.RollupGranularity{database.RollupHourly, database.RollupDaily} {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		start := granularity.Truncate(now.Add(-w.lookbacks[granularity]))
+		end := granularity.Next(granularity.Truncate(now))
+
+		if _, err := w.repo.RefreshRollups(granularity, start, end); err != nil {
+			return fmt.Errorf("failed to refresh %s rollups: %w", granularity, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+)
+
+type rollupCall struct {
+	granularity database.RollupGranularity
+	start, end  time.Time
+}
+
+type recordingRollupRepository struct {
+	calls []rollupCall
+}
+
+func (r *recordingRollupRepository) RefreshRollups(granularity database.RollupGranularity, start, end time.Time) (int64, error) {
+	r.calls = append(r.calls, rollupCall{granularity, start, end})
+	return 1, nil
+}
+
+func TestRollupWorker_Refresh(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 34, 0, 0, time.UTC)
+	repo := &recordingRollupRepository{}
+
+	worker := NewRollupWorker(config.RollupConfig{HourlyLookback: 6 * time.Hour, DailyLookback: 48 * time.Hour}, repo, nopLogger{})
+	worker.now = func() time.Time { return now }
+
+	if err := worker.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	expected := []rollupCall{
+		{database.RollupHourly, time.Date(2024, 3, 6, 6, 0, 0, 0, time.UTC), time.Date(2024, 3, 6, 13, 0, 0, 0, time.UTC)},
+		{database.RollupDaily, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC)},
+	}
+	if len(repo.calls) != len(expected) {
+		t.Fatalf("expected %d refreshes, got %+v", len(expected), repo.calls)
+	}
+	for i, call := range repo.calls {
+		if call.granularity != expected[i].granularity || !call.start.Equal(expected[i].start) || !call.end.Equal(expected[i].end) {
+			t.Errorf("refresh %d = %+v, want %+v", i, call, expected[i])
+		}
+	}
+}
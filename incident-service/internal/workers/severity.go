@@ -0,0 +1,92 @@
+package workers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/severity"
+)
+
+// SeverityRepository defines the query needed to train the severity classifier
+type SeverityRepository interface {
+	ListSeveritySamples(since time.Time, limit int) ([]severity.Sample, error)
+}
+
+// SeverityTrainer periodically retrains the severity classifier on recent
+// incidents, so that its suggestions follow how severities are set. The
+// model lives in memory, so every replica trains its own.
+type SeverityTrainer struct {
+	config     config.SeverityConfig
+	repo       SeverityRepository
+	classifier *severity.Classifier
+	logger     Logger
+	stopCh     chan struct{}
+	now        func() time.Time
+}
+
+// NewSeverityTrainer creates a new severity trainer
+func NewSeverityTrainer(cfg config.SeverityConfig, repo SeverityRepository, classifier *severity.Classifier, logger Logger) *SeverityTrainer {
+	return &SeverityTrainer{
+		config:     cfg,
+		repo:       repo,
+		classifier: classifier,
+		logger:     logger,
+		stopCh:     make(chan struct{}),
+		now:        time.Now,
+	}
+}
+
+// Start trains the classifier immediately and then at the configured
+// interval until Stop is called
+func (w *SeverityTrainer) Start() {
+	started("severity_trainer", w.config.RetrainInterval())
+	defer stopped("severity_trainer")
+
+	w.train()
+
+	ticker := time.NewTicker(w.config.RetrainInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("severity_trainer")
+			w.train()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the severity trainer
+func (w *SeverityTrainer) Stop() {
+	close(w.stopCh)
+}
+
+// train runs one training, logging any failure
+func (w *SeverityTrainer) train() {
+	samples, err := w.Train()
+	if err != nil {
+		w.logger.Error("severity classifier training failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+	w.logger.Info("severity classifier trained", map[string]interface{}{
+		"samples": samples,
+		"trained": w.classifier.Trained(),
+	})
+}
+
+// Train retrains the classifier once and returns how many incidents it
+// learned from. On failure the classifier keeps its previous model.
+func (w *SeverityTrainer) Train() (int, error) {
+	since := w.now().Add(-w.config.TrainingWindow())
+	samples, err := w.repo.ListSeveritySamples(since, w.config.SampleLimit())
+	if err != nil {
+		return 0, fmt.Errorf("failed to list severity samples: %w", err)
+	}
+	w.classifier.Train(samples)
+	return len(samples), nil
+}
@@ -0,0 +1,68 @@
+package workers
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/severity"
+)
+
+func (m *mockRepository) ListSeveritySamples(since time.Time, limit int) ([]severity.Sample, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var incidents []*models.Incident
+	for _, incident := range m.incidents {
+		if !incident.CreatedAt.Before(since) && !incident.SeverityDefaulted {
+			incidents = append(incidents, incident)
+		}
+	}
+	sort.Slice(incidents, func(i, j int) bool { return incidents[i].CreatedAt.After(incidents[j].CreatedAt) })
+	if len(incidents) > limit {
+		incidents = incidents[:limit]
+	}
+
+	samples := make([]severity.Sample, 0, len(incidents))
+	for _, incident := range incidents {
+		samples = append(samples, severity.Sample{
+			ServiceName:  incident.ServiceName,
+			ErrorMessage: incident.ErrorMessage,
+			Severity:     incident.Severity,
+		})
+	}
+	return samples, nil
+}
+
+func TestSeverityTrainer_Train(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	repo := newMockRepository(
+		&models.Incident{ID: "inc-1", ServiceName: "payments", ErrorMessage: "pool exhausted", Severity: "critical", CreatedAt: now.Add(-time.Hour)},
+		&models.Incident{ID: "inc-2", ServiceName: "payments", ErrorMessage: "pool exhausted", Severity: "critical", CreatedAt: now.Add(-2 * time.Hour)},
+		&models.Incident{ID: "inc-3", ServiceName: "search", ErrorMessage: "slow query", Severity: "low", CreatedAt: now.Add(-3 * time.Hour)},
+		// Defaulted severities and incidents past the lookback are not learned from
+		&models.Incident{ID: "inc-4", ServiceName: "search", ErrorMessage: "slow query", Severity: "medium", SeverityDefaulted: true, CreatedAt: now.Add(-time.Hour)},
+		&models.Incident{ID: "inc-5", ServiceName: "search", ErrorMessage: "slow query", Severity: "high", CreatedAt: now.Add(-48 * time.Hour)},
+	)
+
+	cfg := config.SeverityConfig{Enabled: true, Lookback: 24 * time.Hour, MinSamples: 3}
+	classifier := severity.NewClassifier(cfg)
+	trainer := NewSeverityTrainer(cfg, repo, classifier, nopLogger{})
+	trainer.now = func() time.Time { return now }
+
+	samples, err := trainer.Train()
+	if err != nil {
+		t.Fatalf("Train failed: %v", err)
+	}
+	if samples != 3 {
+		t.Errorf("expected 3 samples, got %d", samples)
+	}
+	if !classifier.Trained() {
+		t.Fatal("expected the classifier to be trained")
+	}
+	if suggestion, ok := classifier.Suggest("payments", "pool exhausted"); !ok || suggestion.Severity != "critical" {
+		t.Errorf("expected a critical suggestion, got %+v, %v", suggestion, ok)
+	}
+}
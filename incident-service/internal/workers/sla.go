@@ -0,0 +1,238 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/notifications"
+)
+
+// SLA timers, used as the timer name in sla_breached events
+const (
+	SLATimeToTrigger = "time_to_trigger"
+	SLATimeToPR      = "time_to_pr"
+	SLATimeToResolve = "time_to_resolve"
+)
+
+// SLARepository defines the persistence operations needed to track SLA timers
+type SLARepository interface {
+	ListWithFilter(filter *database.IncidentFilter) ([]*models.Incident, error)
+	GetEventsByIncidentID(incidentID string) ([]*models.IncidentEvent, error)
+	LogEvent(event *models.IncidentEvent) error
+}
+
+// SLAWorker periodically checks every recent incident against the SLA target
+// for its severity, and reports each timer at most once per incident when it
+// runs past its limit
+type SLAWorker struct {
+	repo     SLARepository
+	notifier *notifications.Dispatcher
+	config   config.SLAConfig
+	logger   Logger
+	stopCh   chan struct{}
+	now      func() time.Time
+}
+
+// NewSLAWorker creates a new SLA worker
+func NewSLAWorker(cfg config.SLAConfig, repo SLARepository, notifier *notifications.Dispatcher, logger Logger) *SLAWorker {
+	return &SLAWorker{
+		repo:     repo,
+		notifier: notifier,
+		config:   cfg,
+		logger:   logger,
+		stopCh:   make(chan struct{}),
+		now:      time.Now,
+	}
+}
+
+// Start runs SLA checks at the given interval until Stop is called
+func (w *SLAWorker) Start(interval time.Duration) {
+	started("sla", interval)
+	defer stopped("sla")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("sla")
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if err := w.Check(ctx); err != nil {
+				w.logger.Error("sla check failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+			cancel()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the SLA worker
+func (w *SLAWorker) Stop() {
+	close(w.stopCh)
+}
+
+// Check evaluates the SLA timers of every incident young enough to still be
+// within, or just past, its longest limit
+func (w *SLAWorker) Check(ctx context.Context) error {
+	now := w.now()
+	// A day of slack catches timers that ran out while the service was down
+	since := now.Add(-w.config.Longest() - 24*time.Hour)
+
+	incidents, err := w.repo.ListWithFilter(&database.IncidentFilter{StartTime: &since})
+	if err != nil {
+		return fmt.Errorf("failed to list incidents: %w", err)
+	}
+
+	for _, incident := range incidents {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		target := w.config.TargetFor(incident.Severity)
+		if target == nil {
+			continue
+		}
+
+		if err := w.evaluate(ctx, incident, target, now); err != nil {
+			w.logger.Error("failed to evaluate incident sla", map[string]interface{}{
+				"error":       err.Error(),
+				"incident_id": incident.ID,
+			})
+		}
+	}
+
+	return nil
+}
+
+// evaluate reports every timer of the incident that is past its limit and not yet reported
+func (w *SLAWorker) evaluate(ctx context.Context, incident *models.Incident, target *config.SLATarget, now time.Time) error {
+	var breaches []slaBreach
+	for _, timer := range []struct {
+		name  string
+		limit time.Duration
+	}{
+		{SLATimeToTrigger, target.TimeToTrigger},
+		{SLATimeToPR, target.TimeToPR},
+		{SLATimeToResolve, target.TimeToResolve},
+	} {
+		if timer.limit <= 0 {
+			continue
+		}
+		elapsed, running := slaElapsed(incident, timer.name, now)
+		if elapsed > timer.limit {
+			breaches = append(breaches, slaBreach{timer: timer.name, limit: timer.limit, elapsed: elapsed, running: running})
+		}
+	}
+
+	if len(breaches) == 0 {
+		return nil
+	}
+
+	events, err := w.repo.GetEventsByIncidentID(incident.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get events: %w", err)
+	}
+	reported := make(map[string]bool)
+	for _, event := range events {
+		if event.EventType == models.EventSLABreached {
+			if timer, ok := event.EventData["timer"].(string); ok {
+				reported[timer] = true
+			}
+		}
+	}
+
+	for _, breach := range breaches {
+		if reported[breach.timer] {
+			continue
+		}
+		if err := w.breach(ctx, incident, breach); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// slaBreach is a timer that ran past its limit
+type slaBreach struct {
+	timer   string
+	limit   time.Duration
+	elapsed time.Duration
+	running bool // the timer has not stopped yet
+}
+
+// breach records and notifies a single SLA breach
+func (w *SLAWorker) breach(ctx context.Context, incident *models.Incident, breach slaBreach) error {
+	details := map[string]interface{}{
+		"timer":    breach.timer,
+		"severity": incident.Severity,
+		"target":   breach.limit.String(),
+		"elapsed":  breach.elapsed.Round(time.Second).String(),
+		"running":  breach.running,
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: incident.ID,
+		EventType:  models.EventSLABreached,
+		EventData:  details,
+	}
+	if err := w.repo.LogEvent(event); err != nil {
+		return fmt.Errorf("failed to log sla breach event: %w", err)
+	}
+
+	w.logger.Warn("incident sla breached", map[string]interface{}{
+		"incident_id": incident.ID,
+		"severity":    incident.Severity,
+		"timer":       breach.timer,
+		"target":      breach.limit.String(),
+	})
+
+	if w.notifier != nil {
+		if err := w.notifier.Send(ctx, &notifications.Notification{
+			EventType: models.EventSLABreached,
+			Incident:  incident,
+			Details:   details,
+		}); err != nil {
+			return fmt.Errorf("failed to send sla breach notification: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// slaElapsed returns how long the timer has run for the incident and whether
+// it is still running. A timer stops when its milestone is reached, or for
+// good when the incident ends without reaching it.
+func slaElapsed(incident *models.Incident, timer string, now time.Time) (time.Duration, bool) {
+	switch timer {
+	case SLATimeToTrigger:
+		if incident.TriggeredAt != nil {
+			return incident.TriggeredAt.Sub(incident.CreatedAt), false
+		}
+		if incident.Status == models.StatusNoFixNeeded {
+			return 0, false
+		}
+	case SLATimeToPR:
+		if incident.PullRequestURL != nil {
+			return finishedAt(incident).Sub(incident.CreatedAt), false
+		}
+		if incident.Status == models.StatusNoFixNeeded {
+			return 0, false
+		}
+	case SLATimeToResolve:
+		switch incident.Status {
+		case models.StatusResolved, models.StatusPRCreated, models.StatusNoFixNeeded:
+			return finishedAt(incident).Sub(incident.CreatedAt), false
+		}
+	}
+
+	return now.Sub(incident.CreatedAt), true
+}
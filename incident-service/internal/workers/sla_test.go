@@ -0,0 +1,110 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+func TestSLAWorker_ReportsEachBreachOnce(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-time.Hour)
+	triggeredLate := created.Add(10 * time.Minute)
+	triggeredOnTime := created.Add(time.Minute)
+	completed := created.Add(20 * time.Minute)
+	prURL := "https://github.com/org/a/pull/1"
+
+	incidents := map[string]*models.Incident{
+		// Triggered late and still without a pull request
+		"late": {ID: "late", Severity: "critical", Status: models.StatusInProgress, CreatedAt: created, TriggeredAt: &triggeredLate},
+		// Every milestone reached in time
+		"on-time": {ID: "on-time", Severity: "critical", Status: models.StatusPRCreated, CreatedAt: created, TriggeredAt: &triggeredOnTime, CompletedAt: &completed, PullRequestURL: &prURL},
+		// Never needed a fix, so the trigger and PR timers never ran
+		"no-fix": {ID: "no-fix", Severity: "critical", Status: models.StatusNoFixNeeded, CreatedAt: created, CompletedAt: &completed},
+		// Falls back to the default target
+		"low": {ID: "low", Severity: "low", Status: models.StatusPending, CreatedAt: created},
+	}
+	repo := newMockRepository()
+	for _, incident := range incidents {
+		repo.incidents = append(repo.incidents, incident)
+	}
+	dispatcher, recorder := newRecordingDispatcher()
+
+	worker := NewSLAWorker(config.SLAConfig{
+		Enabled: true,
+		Targets: []config.SLATarget{
+			{Severity: "critical", TimeToTrigger: 5 * time.Minute, TimeToPR: 30 * time.Minute, TimeToResolve: 4 * time.Hour},
+			{TimeToResolve: 30 * time.Minute},
+		},
+	}, repo, dispatcher, nopLogger{})
+	worker.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if err := worker.Check(context.Background()); err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+	}
+
+	events := repo.eventsOfType("late", models.EventSLABreached)
+	if len(events) != 2 {
+		t.Fatalf("expected trigger and pr breaches for late incident, got %d events", len(events))
+	}
+	timers := map[string]map[string]interface{}{}
+	for _, event := range events {
+		timers[event.EventData["timer"].(string)] = event.EventData
+	}
+	if data := timers[SLATimeToTrigger]; data == nil || data["running"] != false || data["elapsed"] != "10m0s" {
+		t.Errorf("expected a stopped trigger breach after 10m, got %v", data)
+	}
+	if data := timers[SLATimeToPR]; data == nil || data["running"] != true || data["target"] != "30m0s" {
+		t.Errorf("expected a running pr breach against 30m, got %v", data)
+	}
+
+	for _, id := range []string{"on-time", "no-fix"} {
+		if got := repo.eventsOfType(id, models.EventSLABreached); len(got) != 0 {
+			t.Errorf("%s: expected no breaches, got %d", id, len(got))
+		}
+	}
+
+	low := repo.eventsOfType("low", models.EventSLABreached)
+	if len(low) != 1 || low[0].EventData["timer"] != SLATimeToResolve {
+		t.Errorf("expected the default resolve target to apply to low severity, got %v", low)
+	}
+
+	if len(recorder.messages) != 3 {
+		t.Errorf("expected one notification per breach, got %d", len(recorder.messages))
+	}
+}
+
+func TestSLAElapsed(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-time.Hour)
+	updated := created.Add(40 * time.Minute)
+	prURL := "https://github.com/org/a/pull/1"
+
+	tests := []struct {
+		name        string
+		incident    *models.Incident
+		timer       string
+		wantElapsed time.Duration
+		wantRunning bool
+	}{
+		{"pending trigger", &models.Incident{Status: models.StatusPending, CreatedAt: created}, SLATimeToTrigger, time.Hour, true},
+		{"failed without pr keeps running", &models.Incident{Status: models.StatusFailed, CreatedAt: created, UpdatedAt: updated}, SLATimeToPR, time.Hour, true},
+		{"pr without completion uses last update", &models.Incident{Status: models.StatusPRCreated, CreatedAt: created, UpdatedAt: updated, PullRequestURL: &prURL}, SLATimeToPR, 40 * time.Minute, false},
+		{"resolved", &models.Incident{Status: models.StatusResolved, CreatedAt: created, UpdatedAt: updated}, SLATimeToResolve, 40 * time.Minute, false},
+		{"no fix needed skips trigger", &models.Incident{Status: models.StatusNoFixNeeded, CreatedAt: created}, SLATimeToTrigger, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elapsed, running := slaElapsed(tt.incident, tt.timer, now)
+			if elapsed != tt.wantElapsed || running != tt.wantRunning {
+				t.Errorf("slaElapsed() = (%v, %v), want (%v, %v)", elapsed, running, tt.wantElapsed, tt.wantRunning)
+			}
+		})
+	}
+}
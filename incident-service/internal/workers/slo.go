@@ -0,0 +1,105 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/slo"
+)
+
+// SLOGauges are the gauges refreshed by the SLO worker, each labelled by SLO name
+type SLOGauges struct {
+	Compliance           *prometheus.GaugeVec
+	Target               *prometheus.GaugeVec
+	ErrorBudgetRemaining *prometheus.GaugeVec
+	Eligible             *prometheus.GaugeVec
+}
+
+// SLOWorker periodically evaluates remediation SLOs and exports their
+// compliance and remaining error budget
+type SLOWorker struct {
+	evaluator *slo.Evaluator
+	gauges    SLOGauges
+	logger    Logger
+	stopCh    chan struct{}
+}
+
+// NewSLOWorker creates a new SLO worker
+func NewSLOWorker(evaluator *slo.Evaluator, gauges SLOGauges, logger Logger) *SLOWorker {
+	return &SLOWorker{
+		evaluator: evaluator,
+		gauges:    gauges,
+		logger:    logger,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start refreshes the gauges immediately and then at the given interval until Stop is called
+func (w *SLOWorker) Start(interval time.Duration) {
+	started("slo", interval)
+	defer stopped("slo")
+
+	w.refresh(interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("slo")
+			w.refresh(interval)
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the SLO worker
+func (w *SLOWorker) Stop() {
+	close(w.stopCh)
+}
+
+// refresh runs one refresh, logging any failure
+func (w *SLOWorker) refresh(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := w.Refresh(ctx); err != nil {
+		w.logger.Error("slo refresh failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+}
+
+// Refresh evaluates every SLO once and updates the gauges
+func (w *SLOWorker) Refresh(ctx context.Context) error {
+	results, err := w.evaluator.Evaluate()
+	if err != nil {
+		return fmt.Errorf("failed to evaluate slos: %w", err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		w.gauges.Compliance.WithLabelValues(result.Name).Set(result.Compliance)
+		w.gauges.Target.WithLabelValues(result.Name).Set(result.Target)
+		w.gauges.ErrorBudgetRemaining.WithLabelValues(result.Name).Set(result.ErrorBudgetRemaining)
+		w.gauges.Eligible.WithLabelValues(result.Name).Set(float64(result.Eligible))
+
+		if !result.Compliant {
+			w.logger.Warn("slo out of compliance", map[string]interface{}{
+				"slo":                    result.Name,
+				"compliance":             result.Compliance,
+				"target":                 result.Target,
+				"error_budget_remaining": result.ErrorBudgetRemaining,
+			})
+		}
+	}
+
+	return nil
+}
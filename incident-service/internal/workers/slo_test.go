@@ -0,0 +1,54 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/slo"
+)
+
+func TestSLOWorker_Refresh(t *testing.T) {
+	created := time.Now().Add(-2 * time.Hour)
+	fast := created.Add(10 * time.Minute)
+	prURL := "https://github.com/org/repo/pull/1"
+
+	repo := newMockRepository(
+		&models.Incident{ID: "inc-1", Status: models.StatusPRCreated, Severity: "critical", CreatedAt: created, CompletedAt: &fast, PullRequestURL: &prURL},
+		&models.Incident{ID: "inc-2", Status: models.StatusFailed, Severity: "critical", CreatedAt: created},
+	)
+
+	evaluator := slo.NewEvaluator([]config.SLO{
+		{Name: "critical-pr-30m", Severity: "critical", Target: 0.9, Within: 30 * time.Minute},
+	}, repo)
+
+	gauges := SLOGauges{
+		Compliance:           prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "slo_compliance_ratio"}, []string{"slo"}),
+		Target:               prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "slo_target_ratio"}, []string{"slo"}),
+		ErrorBudgetRemaining: prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "slo_error_budget_remaining_ratio"}, []string{"slo"}),
+		Eligible:             prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "slo_eligible_incidents"}, []string{"slo"}),
+	}
+
+	worker := NewSLOWorker(evaluator, gauges, nopLogger{})
+	if err := worker.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if got := testutil.ToFloat64(gauges.Compliance.WithLabelValues("critical-pr-30m")); got != 0.5 {
+		t.Errorf("expected compliance 0.5, got %v", got)
+	}
+	if got := testutil.ToFloat64(gauges.Target.WithLabelValues("critical-pr-30m")); got != 0.9 {
+		t.Errorf("expected target 0.9, got %v", got)
+	}
+	if got := testutil.ToFloat64(gauges.Eligible.WithLabelValues("critical-pr-30m")); got != 2 {
+		t.Errorf("expected 2 eligible incidents, got %v", got)
+	}
+	// One breach against an allowance of 0.2 overspends the budget fivefold
+	if got := testutil.ToFloat64(gauges.ErrorBudgetRemaining.WithLabelValues("critical-pr-30m")); got > -3.9 || got < -4.1 {
+		t.Errorf("expected error budget remaining of -4, got %v", got)
+	}
+}
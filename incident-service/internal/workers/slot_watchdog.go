@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"context"
+	"time"
+)
+
+// SlotExpirer releases the workflow slots held past their TTL
+type SlotExpirer func(ctx context.Context) error
+
+// SlotWatchdogWorker periodically releases workflow slots whose workflows
+// never reported back, so that a lost callback does not stall a repository's
+// queue for good. Slots live in memory unless they are kept in Redis, so
+// every replica runs its own watchdog.
+type SlotWatchdogWorker struct {
+	expire SlotExpirer
+	logger Logger
+	stopCh chan struct{}
+}
+
+// NewSlotWatchdogWorker creates a new slot watchdog worker
+func NewSlotWatchdogWorker(expire SlotExpirer, logger Logger) *SlotWatchdogWorker {
+	return &SlotWatchdogWorker{
+		expire: expire,
+		logger: logger,
+		stopCh: make(chan struct{}),
+	}
+}
+
+// Start checks the slots at the given interval until Stop is called
+func (w *SlotWatchdogWorker) Start(interval time.Duration) {
+	started("slot_watchdog", interval)
+	defer stopped("slot_watchdog")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("slot_watchdog")
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if err := w.expire(ctx); err != nil {
+				w.logger.Error("failed to expire workflow slots", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+			cancel()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the slot watchdog worker
+func (w *SlotWatchdogWorker) Stop() {
+	close(w.stopCh)
+}
@@ -0,0 +1,193 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/notifications"
+)
+
+// StaleRepository defines the persistence operations needed to time out incidents
+type StaleRepository interface {
+	ListWithFilter(filter *database.IncidentFilter) ([]*models.Incident, error)
+	Update(incident *models.Incident) error
+	LogEvent(event *models.IncidentEvent) error
+}
+
+// QueueRemover drops an incident from its repository's dispatch queue,
+// reporting whether it was queued
+type QueueRemover func(repository, incidentID string) bool
+
+// StaleIncidentWorker periodically fails incidents that have been stuck in a
+// non-terminal status beyond the configured timeouts
+type StaleIncidentWorker struct {
+	repo              StaleRepository
+	notifier          *notifications.Dispatcher
+	release           SlotReleaser
+	removeQueued      QueueRemover
+	pendingTimeout    time.Duration
+	inProgressTimeout time.Duration
+	logger            Logger
+	stopCh            chan struct{}
+	now               func() time.Time
+}
+
+// NewStaleIncidentWorker creates a new stale incident worker
+func NewStaleIncidentWorker(cfg config.StaleIncidentsConfig, repo StaleRepository, notifier *notifications.Dispatcher, release SlotReleaser, removeQueued QueueRemover, logger Logger) *StaleIncidentWorker {
+	return &StaleIncidentWorker{
+		repo:              repo,
+		notifier:          notifier,
+		release:           release,
+		removeQueued:      removeQueued,
+		pendingTimeout:    cfg.PendingTimeout,
+		inProgressTimeout: cfg.InProgressTimeout,
+		logger:            logger,
+		stopCh:            make(chan struct{}),
+		now:               time.Now,
+	}
+}
+
+// Start runs stale incident checks at the given interval until Stop is called
+func (w *StaleIncidentWorker) Start(interval time.Duration) {
+	started("stale_incidents", interval)
+	defer stopped("stale_incidents")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("stale_incidents")
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if err := w.Check(ctx); err != nil {
+				w.logger.Error("stale incident check failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+			cancel()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the stale incident worker
+func (w *StaleIncidentWorker) Stop() {
+	close(w.stopCh)
+}
+
+// Check times out every incident past its status threshold once
+func (w *StaleIncidentWorker) Check(ctx context.Context) error {
+	thresholds := map[models.IncidentStatus]time.Duration{
+		models.StatusPending:           w.pendingTimeout,
+		models.StatusWorkflowTriggered: w.inProgressTimeout,
+		models.StatusInProgress:        w.inProgressTimeout,
+	}
+
+	for _, status := range []models.IncidentStatus{models.StatusPending, models.StatusWorkflowTriggered, models.StatusInProgress} {
+		threshold := thresholds[status]
+		if threshold <= 0 {
+			continue
+		}
+
+		status := status
+		incidents, err := w.repo.ListWithFilter(&database.IncidentFilter{Status: &status})
+		if err != nil {
+			return fmt.Errorf("failed to list %s incidents: %w", status, err)
+		}
+
+		for _, incident := range incidents {
+			// Pending incidents wait from creation or from when a recurrence
+			// reopened them, or from the end of the maintenance window that
+			// deferred them, and dispatched ones from dispatch
+			since := incident.CreatedAt
+			if status != models.StatusPending {
+				since = dispatchedAt(incident)
+			} else {
+				if incident.ReopenedAt != nil && incident.ReopenedAt.After(since) {
+					since = *incident.ReopenedAt
+				}
+				if incident.DeferredUntil != nil && incident.DeferredUntil.After(since) {
+					since = *incident.DeferredUntil
+				}
+			}
+
+			stuckFor := w.now().Sub(since)
+			if stuckFor < threshold {
+				continue
+			}
+
+			if err := w.timeout(ctx, incident, stuckFor, threshold); err != nil {
+				w.logger.Error("failed to time out stale incident", map[string]interface{}{
+					"error":       err.Error(),
+					"incident_id": incident.ID,
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// timeout fails a stale incident and releases whatever remediation capacity it holds
+func (w *StaleIncidentWorker) timeout(ctx context.Context, incident *models.Incident, stuckFor, threshold time.Duration) error {
+	previous := incident.Status
+	now := w.now()
+	incident.Status = models.StatusFailed
+	incident.CompletedAt = &now
+
+	if err := w.repo.Update(incident); err != nil {
+		return fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	event := &models.IncidentEvent{
+		IncidentID: incident.ID,
+		EventType:  models.EventIncidentFailed,
+		EventData: map[string]interface{}{
+			"reason":          "timeout",
+			"previous_status": previous,
+			"stuck_for":       stuckFor.Round(time.Second).String(),
+			"threshold":       threshold.String(),
+		},
+	}
+	if err := w.repo.LogEvent(event); err != nil {
+		w.logger.Error("failed to log timeout event", map[string]interface{}{
+			"error":       err.Error(),
+			"incident_id": incident.ID,
+		})
+	}
+
+	w.logger.Warn("stale incident timed out", map[string]interface{}{
+		"incident_id":     incident.ID,
+		"repository":      incident.Repository,
+		"previous_status": previous,
+		"stuck_for":       stuckFor.Round(time.Second).String(),
+	})
+
+	if w.notifier != nil {
+		if err := w.notifier.Notify(ctx, models.EventIncidentFailed, incident); err != nil {
+			w.logger.Error("failed to send notification", map[string]interface{}{
+				"error":       err.Error(),
+				"event_type":  models.EventIncidentFailed,
+				"incident_id": incident.ID,
+			})
+		}
+	}
+
+	// A pending incident may be waiting for a slot; a dispatched one holds one
+	switch {
+	case previous == models.StatusPending:
+		if w.removeQueued != nil && incident.Repository != "" {
+			w.removeQueued(incident.Repository, incident.ID)
+		}
+	case w.release != nil && incident.Repository != "":
+		w.release(ctx, incident.Repository, incident.ID)
+	}
+
+	return nil
+}
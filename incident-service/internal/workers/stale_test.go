@@ -0,0 +1,100 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/notifications"
+)
+
+func TestStaleIncidentWorker_Check(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	longAgo := now.Add(-3 * time.Hour)
+	recently := now.Add(-10 * time.Minute)
+
+	incidents := map[string]*models.Incident{
+		"pending-stale": {ID: "pending-stale", Repository: "org/queued", Status: models.StatusPending, CreatedAt: now.Add(-2 * time.Hour)},
+		"pending-fresh": {ID: "pending-fresh", Repository: "org/queued", Status: models.StatusPending, CreatedAt: recently},
+		// Received long ago, but a recurrence just reopened it
+		"pending-reopened": {ID: "pending-reopened", Repository: "org/queued", Status: models.StatusPending, CreatedAt: longAgo, ReopenedAt: &recently},
+		"triggered-stale":  {ID: "triggered-stale", Repository: "org/a", Status: models.StatusWorkflowTriggered, CreatedAt: longAgo, TriggeredAt: &longAgo},
+		"running-stale":    {ID: "running-stale", Repository: "org/b", Status: models.StatusInProgress, CreatedAt: longAgo, TriggeredAt: &longAgo},
+		// Created long ago but only dispatched recently, e.g. after waiting in the queue
+		"running-fresh": {ID: "running-fresh", Repository: "org/b", Status: models.StatusInProgress, CreatedAt: longAgo, TriggeredAt: &recently},
+		"failed":        {ID: "failed", Repository: "org/b", Status: models.StatusFailed, CreatedAt: longAgo},
+	}
+	repo := newMockRepository()
+	for _, incident := range incidents {
+		repo.incidents = append(repo.incidents, incident)
+	}
+
+	var released, removed []string
+	worker := NewStaleIncidentWorker(
+		config.StaleIncidentsConfig{PendingTimeout: time.Hour, InProgressTimeout: 2 * time.Hour},
+		repo,
+		notifications.NewDispatcher(config.NotificationsConfig{}),
+		func(ctx context.Context, repository, incidentID string) { released = append(released, repository) },
+		func(repository, incidentID string) bool { removed = append(removed, incidentID); return true },
+		nopLogger{},
+	)
+	worker.now = func() time.Time { return now }
+
+	if err := worker.Check(context.Background()); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	for id, wantFailed := range map[string]bool{
+		"pending-stale":    true,
+		"pending-fresh":    false,
+		"pending-reopened": false,
+		"triggered-stale":  true,
+		"running-stale":    true,
+		"running-fresh":    false,
+	} {
+		incident := incidents[id]
+		if failed := incident.Status == models.StatusFailed; failed != wantFailed {
+			t.Errorf("%s: status = %s, want failed %v", id, incident.Status, wantFailed)
+		}
+
+		events := repo.eventsOfType(id, models.EventIncidentFailed)
+		if wantFailed != (len(events) == 1) {
+			t.Fatalf("%s: expected timeout event %v, got %d events", id, wantFailed, len(events))
+		}
+		if wantFailed {
+			if events[0].EventData["reason"] != "timeout" {
+				t.Errorf("%s: expected timeout reason, got %v", id, events[0].EventData)
+			}
+			if incident.CompletedAt == nil || !incident.CompletedAt.Equal(now) {
+				t.Errorf("%s: expected completion time to be set", id)
+			}
+		}
+	}
+
+	if len(repo.eventsOfType("failed", models.EventIncidentFailed)) != 0 {
+		t.Error("expected already failed incidents to be left alone")
+	}
+	if len(removed) != 1 || removed[0] != "pending-stale" {
+		t.Errorf("expected the stale pending incident to leave the queue, got %v", removed)
+	}
+	if len(released) != 2 {
+		t.Errorf("expected 2 released slots, got %v", released)
+	}
+}
+
+func TestStaleIncidentWorker_DisabledThreshold(t *testing.T) {
+	longAgo := time.Now().Add(-48 * time.Hour)
+	incident := &models.Incident{ID: "inc-1", Status: models.StatusPending, CreatedAt: longAgo}
+	repo := newMockRepository(incident)
+
+	worker := NewStaleIncidentWorker(config.StaleIncidentsConfig{InProgressTimeout: time.Hour}, repo, nil, nil, nil, nopLogger{})
+	if err := worker.Check(context.Background()); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	if incident.Status != models.StatusPending {
+		t.Errorf("expected pending incident to be kept without a pending timeout, got %s", incident.Status)
+	}
+}
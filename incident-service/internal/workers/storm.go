@@ -0,0 +1,123 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/notifications"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/storm"
+)
+
+// StormRepository defines the persistence operations needed to release storms
+type StormRepository interface {
+	GetByID(id string) (*models.Incident, error)
+	LogEvent(event *models.IncidentEvent) error
+}
+
+// StormWorker periodically ends the alert storms whose services are back to
+// normal volume, so their new incidents are remediated individually again
+type StormWorker struct {
+	detector *storm.Detector
+	repo     StormRepository
+	notifier *notifications.Dispatcher
+	logger   Logger
+	stopCh   chan struct{}
+	now      func() time.Time
+}
+
+// NewStormWorker creates a new storm release worker
+func NewStormWorker(detector *storm.Detector, repo StormRepository, notifier *notifications.Dispatcher, logger Logger) *StormWorker {
+	return &StormWorker{
+		detector: detector,
+		repo:     repo,
+		notifier: notifier,
+		logger:   logger,
+		stopCh:   make(chan struct{}),
+		now:      time.Now,
+	}
+}
+
+// Start runs storm release checks at the given interval until Stop is called
+func (w *StormWorker) Start(interval time.Duration) {
+	started("storm", interval)
+	defer stopped("storm")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("storm")
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			w.Check(ctx)
+			cancel()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the storm worker
+func (w *StormWorker) Stop() {
+	close(w.stopCh)
+}
+
+// Check releases every storm whose service volume has normalized
+func (w *StormWorker) Check(ctx context.Context) {
+	now := w.now()
+	for _, released := range w.detector.Release(now) {
+		if err := w.release(ctx, released, now); err != nil {
+			w.logger.Error("failed to record storm release", map[string]interface{}{
+				"error":    err.Error(),
+				"storm_id": released.ID,
+			})
+		}
+	}
+}
+
+// release records and notifies the end of a storm on its storm incident
+func (w *StormWorker) release(ctx context.Context, released storm.Storm, now time.Time) error {
+	duration := now.Sub(released.StartedAt).Round(time.Second)
+	details := map[string]interface{}{
+		"service_name": released.ServiceName,
+		"suppressed":   released.Suppressed,
+		"duration":     duration.String(),
+	}
+
+	w.logger.Info("alert storm released", map[string]interface{}{
+		"storm_id":     released.ID,
+		"service_name": released.ServiceName,
+		"suppressed":   released.Suppressed,
+		"duration":     duration.String(),
+	})
+
+	event := &models.IncidentEvent{
+		IncidentID: released.ID,
+		EventType:  models.EventStormReleased,
+		EventData:  details,
+	}
+	if err := w.repo.LogEvent(event); err != nil {
+		return fmt.Errorf("failed to log storm released event: %w", err)
+	}
+
+	if w.notifier == nil {
+		return nil
+	}
+
+	incident, err := w.repo.GetByID(released.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get storm incident: %w", err)
+	}
+	if err := w.notifier.Send(ctx, &notifications.Notification{
+		EventType: models.EventStormReleased,
+		Incident:  incident,
+		Details:   details,
+	}); err != nil {
+		return fmt.Errorf("failed to send storm released notification: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/storm"
+)
+
+func (m *mockRepository) GetByID(id string) (*models.Incident, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, incident := range m.incidents {
+		if incident.ID == id {
+			return incident, nil
+		}
+	}
+	return nil, fmt.Errorf("incident not found: %s", id)
+}
+
+func TestStormWorker_ReleasesQuietStorms(t *testing.T) {
+	start := time.Now()
+	detector := storm.NewDetector(config.StormConfig{Window: time.Minute, Threshold: 2, ReleaseBelow: 1})
+	detector.Observe("api", start)
+	active, started := detector.Observe("api", start)
+	if !started {
+		t.Fatal("expected a storm to start")
+	}
+
+	repo := newMockRepository(&models.Incident{ID: active.ID, ServiceName: "api", Provider: "storm"})
+	dispatcher, recorder := newRecordingDispatcher()
+	worker := NewStormWorker(detector, repo, dispatcher, nopLogger{})
+
+	// Still within the window
+	worker.now = func() time.Time { return start.Add(30 * time.Second) }
+	worker.Check(context.Background())
+	if len(repo.eventsOfType(active.ID, models.EventStormReleased)) != 0 {
+		t.Fatal("expected the storm to continue while volume is high")
+	}
+
+	worker.now = func() time.Time { return start.Add(2 * time.Minute) }
+	worker.Check(context.Background())
+
+	events := repo.eventsOfType(active.ID, models.EventStormReleased)
+	if len(events) != 1 {
+		t.Fatalf("expected one storm released event, got %d", len(events))
+	}
+	if events[0].EventData["suppressed"] != 1 || events[0].EventData["duration"] != "2m0s" {
+		t.Errorf("unexpected release details %v", events[0].EventData)
+	}
+	if len(recorder.messages) != 1 {
+		t.Errorf("expected a release notification, got %d", len(recorder.messages))
+	}
+
+	// Released storms are reported once
+	worker.Check(context.Background())
+	if len(repo.eventsOfType(active.ID, models.EventStormReleased)) != 1 {
+		t.Error("expected no further release events")
+	}
+}
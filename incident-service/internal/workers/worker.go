@@ -0,0 +1,23 @@
+package workers
+
+// Logger is the structured logger used by background workers
+type Logger interface {
+	Info(message string, fields map[string]interface{})
+	Warn(message string, fields map[string]interface{})
+	Error(message string, fields map[string]interface{})
+}
+
+// intFromEventData reads an integer stored in event data, which decodes from
+// JSONB as float64 but is an int when the event was built in memory
+func intFromEventData(data map[string]interface{}, key string) (int, bool) {
+	switch v := data[key].(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,265 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/database"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// WorkflowLogRepository defines the persistence operations needed to store workflow logs
+type WorkflowLogRepository interface {
+	ListWithFilter(filter *database.IncidentFilter) ([]*models.Incident, error)
+	HasWorkflowLog(incidentID string, runID int64) (bool, error)
+	SaveWorkflowLog(log *models.WorkflowLog) error
+}
+
+// WorkflowLogSource reads the runs, jobs, and logs of remediation workflows
+type WorkflowLogSource interface {
+	ListWorkflowRuns(ctx context.Context, repository string, since time.Time) ([]github.WorkflowRun, error)
+	GetWorkflowRun(ctx context.Context, repository string, runID int64) (*github.WorkflowRun, error)
+	ListRunJobs(ctx context.Context, repository string, runID int64) ([]github.WorkflowJob, error)
+	DownloadJobLogs(ctx context.Context, repository string, jobID int64) (string, error)
+}
+
+// WorkflowLogWorker periodically downloads the logs of the workflow runs of
+// failed incidents and stores an excerpt of the step that failed, so that
+// the failure can be read without going to GitHub. Each run is fetched once.
+type WorkflowLogWorker struct {
+	repo     WorkflowLogRepository
+	github   WorkflowLogSource
+	lookback time.Duration
+	maxBytes int
+	logger   Logger
+	stopCh   chan struct{}
+	now      func() time.Time
+}
+
+// NewWorkflowLogWorker creates a new workflow log worker
+func NewWorkflowLogWorker(cfg config.WorkflowLogsConfig, repo WorkflowLogRepository, source WorkflowLogSource, logger Logger) *WorkflowLogWorker {
+	return &WorkflowLogWorker{
+		repo:     repo,
+		github:   source,
+		lookback: cfg.LookbackWindow(),
+		maxBytes: cfg.ExcerptLimit(),
+		logger:   logger,
+		stopCh:   make(chan struct{}),
+		now:      time.Now,
+	}
+}
+
+// Start fetches workflow logs at the given interval until Stop is called
+func (w *WorkflowLogWorker) Start(interval time.Duration) {
+	started("workflow_logs", interval)
+	defer stopped("workflow_logs")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			beat("workflow_logs")
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if err := w.FetchLogs(ctx); err != nil {
+				w.logger.Error("failed to fetch workflow logs", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+			cancel()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the workflow log worker
+func (w *WorkflowLogWorker) Stop() {
+	close(w.stopCh)
+}
+
+// FetchLogs stores the log excerpts of the failed incidents created within
+// the lookback window whose runs have not been fetched yet
+func (w *WorkflowLogWorker) FetchLogs(ctx context.Context) error {
+	status := models.StatusFailed
+	since := w.now().Add(-w.lookback)
+	incidents, err := w.repo.ListWithFilter(&database.IncidentFilter{Status: &status, StartTime: &since})
+	if err != nil {
+		return fmt.Errorf("failed to list failed incidents: %w", err)
+	}
+
+	// Incidents whose callback did not carry the run ID are matched to a
+	// run the way the reconciler does, with one run listing per repository
+	runsByRepository := make(map[string][]github.WorkflowRun)
+
+	for _, incident := range incidents {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if incident.Repository == "" {
+			continue
+		}
+
+		runID := int64(0)
+		if incident.WorkflowRunID != nil {
+			runID = *incident.WorkflowRunID
+		}
+		if runID == 0 {
+			runs, listed := runsByRepository[incident.Repository]
+			if !listed {
+				runs, err = w.github.ListWorkflowRuns(ctx, incident.Repository, since.Add(-time.Minute))
+				if err != nil {
+					w.logger.Error("failed to list workflow runs for logs", map[string]interface{}{
+						"error":      err.Error(),
+						"repository": incident.Repository,
+					})
+					continue
+				}
+				runsByRepository[incident.Repository] = runs
+			}
+			run := matchWorkflowRun(incident, runs)
+			if run == nil {
+				continue
+			}
+			runID = run.ID
+		}
+
+		if err := w.fetch(ctx, incident, runID); err != nil {
+			w.logger.Error("failed to fetch workflow log", map[string]interface{}{
+				"error":           err.Error(),
+				"incident_id":     incident.ID,
+				"repository":      incident.Repository,
+				"workflow_run_id": runID,
+			})
+		}
+	}
+
+	return nil
+}
+
+// fetch stores the log excerpt of one run, unless it already has one. The
+// remediation action reports its outcome from inside the run, so the logs
+// are only fetched once the run has completed.
+func (w *WorkflowLogWorker) fetch(ctx context.Context, incident *models.Incident, runID int64) error {
+	fetched, err := w.repo.HasWorkflowLog(incident.ID, runID)
+	if err != nil {
+		return err
+	}
+	if fetched {
+		return nil
+	}
+
+	run, err := w.github.GetWorkflowRun(ctx, incident.Repository, runID)
+	if err != nil {
+		return w.giveUpIfGone(incident, runID, err)
+	}
+	if !run.Completed() {
+		return nil
+	}
+
+	jobs, err := w.github.ListRunJobs(ctx, incident.Repository, runID)
+	if err != nil {
+		return w.giveUpIfGone(incident, runID, err)
+	}
+	job := failedJob(jobs)
+	if job == nil {
+		return w.save(&models.WorkflowLog{
+			IncidentID:    incident.ID,
+			WorkflowRunID: runID,
+			Repository:    incident.Repository,
+			Error:         stringPtr("workflow run has no jobs"),
+		})
+	}
+
+	log := &models.WorkflowLog{
+		IncidentID:    incident.ID,
+		WorkflowRunID: runID,
+		Repository:    incident.Repository,
+		JobName:       job.Name,
+		Conclusion:    job.Conclusion,
+	}
+	if step := job.FailedStep(); step != nil {
+		log.StepName = step.Name
+	}
+
+	text, err := w.github.DownloadJobLogs(ctx, incident.Repository, job.ID)
+	if err != nil {
+		if !logsGone(err) {
+			return err
+		}
+		log.Error = stringPtr(err.Error())
+		return w.save(log)
+	}
+
+	excerpt, truncated := github.ExtractFailure(text, w.maxBytes)
+	log.Excerpt = &excerpt
+	log.Truncated = truncated
+	if err := w.save(log); err != nil {
+		return err
+	}
+
+	w.logger.Info("workflow log stored", map[string]interface{}{
+		"incident_id":     incident.ID,
+		"repository":      incident.Repository,
+		"workflow_run_id": runID,
+		"job":             log.JobName,
+		"step":            log.StepName,
+	})
+	return nil
+}
+
+// giveUpIfGone records that a run's logs are unavailable when GitHub no
+// longer has the run, and otherwise returns err so the run is tried again
+func (w *WorkflowLogWorker) giveUpIfGone(incident *models.Incident, runID int64, err error) error {
+	if !logsGone(err) {
+		return err
+	}
+	return w.save(&models.WorkflowLog{
+		IncidentID:    incident.ID,
+		WorkflowRunID: runID,
+		Repository:    incident.Repository,
+		Error:         stringPtr(err.Error()),
+	})
+}
+
+func (w *WorkflowLogWorker) save(log *models.WorkflowLog) error {
+	log.FetchedAt = w.now()
+	if err := w.repo.SaveWorkflowLog(log); err != nil {
+		return fmt.Errorf("failed to save workflow log: %w", err)
+	}
+	return nil
+}
+
+// failedJob returns the first job of a run that failed, or the last job
+// when none did, since the action can report a failure from a green job
+func failedJob(jobs []github.WorkflowJob) *github.WorkflowJob {
+	for i := range jobs {
+		if jobs[i].Failed() {
+			return &jobs[i]
+		}
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+	return &jobs[len(jobs)-1]
+}
+
+// logsGone reports whether GitHub answered that the run or its logs do not
+// exist, which happens once logs pass their retention period
+func logsGone(err error) bool {
+	var apiErr *github.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusGone
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
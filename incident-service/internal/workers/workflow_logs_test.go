@@ -0,0 +1,141 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-org/ai-sre-platform/incident-service/internal/config"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/github"
+	"github.com/your-org/ai-sre-platform/incident-service/internal/models"
+)
+
+// workflowLogRepository adds workflow log storage to the mock repository
+type workflowLogRepository struct {
+	*mockRepository
+	logs map[string]*models.WorkflowLog // incident ID and run ID -> log
+}
+
+func workflowLogKey(incidentID string, runID int64) string {
+	return fmt.Sprintf("%s/%d", incidentID, runID)
+}
+
+func (r *workflowLogRepository) HasWorkflowLog(incidentID string, runID int64) (bool, error) {
+	_, ok := r.logs[workflowLogKey(incidentID, runID)]
+	return ok, nil
+}
+
+func (r *workflowLogRepository) SaveWorkflowLog(log *models.WorkflowLog) error {
+	r.logs[workflowLogKey(log.IncidentID, log.WorkflowRunID)] = log
+	return nil
+}
+
+// fakeWorkflowLogs serves canned runs, jobs, and job logs
+type fakeWorkflowLogs struct {
+	fakeGitHub
+	jobs      map[int64][]github.WorkflowJob // run ID -> jobs
+	jobLogs   map[int64]string               // job ID -> log
+	downloads int
+}
+
+func (f *fakeWorkflowLogs) GetWorkflowRun(ctx context.Context, repository string, runID int64) (*github.WorkflowRun, error) {
+	for _, run := range f.runs[repository] {
+		if run.ID == runID {
+			run := run
+			return &run, nil
+		}
+	}
+	return nil, &github.APIError{StatusCode: http.StatusNotFound, Body: "Not Found"}
+}
+
+func (f *fakeWorkflowLogs) ListRunJobs(ctx context.Context, repository string, runID int64) ([]github.WorkflowJob, error) {
+	return f.jobs[runID], nil
+}
+
+func (f *fakeWorkflowLogs) DownloadJobLogs(ctx context.Context, repository string, jobID int64) (string, error) {
+	f.downloads++
+	log, ok := f.jobLogs[jobID]
+	if !ok {
+		return "", &github.APIError{StatusCode: http.StatusGone, Body: "logs have expired"}
+	}
+	return log, nil
+}
+
+func TestWorkflowLogWorker_FetchLogs(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	runFailed, runRunning, runExpired := int64(2), int64(3), int64(4)
+
+	repo := &workflowLogRepository{
+		mockRepository: newMockRepository(
+			&models.Incident{ID: "inc-failed", Repository: "org/repo", Status: models.StatusFailed, WorkflowRunID: &runFailed},
+			&models.Incident{ID: "inc-running", Repository: "org/repo", Status: models.StatusFailed, WorkflowRunID: &runRunning},
+			&models.Incident{ID: "inc-expired", Repository: "org/repo", Status: models.StatusFailed, WorkflowRunID: &runExpired},
+			// Matched to its run by the incident ID in the run title
+			&models.Incident{ID: "inc-untracked", Repository: "org/repo", Status: models.StatusFailed},
+			&models.Incident{ID: "inc-fixed", Repository: "org/repo", Status: models.StatusPRCreated, WorkflowRunID: &runFailed},
+		),
+		logs: make(map[string]*models.WorkflowLog),
+	}
+
+	gh := &fakeWorkflowLogs{
+		fakeGitHub: fakeGitHub{runs: map[string][]github.WorkflowRun{
+			"org/repo": {
+				{ID: 5, DisplayTitle: "Remediate incident inc-untracked", Status: "completed", Conclusion: "failure"},
+				{ID: 4, Status: "completed", Conclusion: "failure"},
+				{ID: 3, Status: "in_progress"},
+				{ID: 2, Status: "completed", Conclusion: "failure"},
+			},
+		}},
+		jobs: map[int64][]github.WorkflowJob{
+			2: {{ID: 20, Name: "remediate", Conclusion: "failure", Steps: []github.WorkflowStep{
+				{Name: "Checkout", Number: 1, Conclusion: "success"},
+				{Name: "Run tests", Number: 2, Conclusion: "failure"},
+			}}},
+			4: {{ID: 40, Name: "remediate", Conclusion: "failure"}},
+			5: {{ID: 50, Name: "remediate", Conclusion: "failure"}},
+		},
+		jobLogs: map[int64]string{
+			20: "2024-03-06T11:00:00Z ##[group]Run npm test\n2024-03-06T11:00:01Z ##[error]Process completed with exit code 1.\n",
+			50: "2024-03-06T11:00:00Z ##[error]Agent gave up\n",
+		},
+	}
+
+	worker := NewWorkflowLogWorker(config.WorkflowLogsConfig{}, repo, gh, nopLogger{})
+	worker.now = func() time.Time { return now }
+
+	if err := worker.FetchLogs(context.Background()); err != nil {
+		t.Fatalf("FetchLogs() error = %v", err)
+	}
+
+	log := repo.logs[workflowLogKey("inc-failed", 2)]
+	if log == nil || log.Excerpt == nil || log.StepName != "Run tests" || log.JobName != "remediate" {
+		t.Fatalf("expected the failing step of run 2 to be stored, got %+v", log)
+	}
+	if !strings.Contains(*log.Excerpt, "exit code 1") || strings.Contains(*log.Excerpt, "2024-03-06") {
+		t.Errorf("unexpected excerpt %q", *log.Excerpt)
+	}
+	if _, ok := repo.logs[workflowLogKey("inc-running", 3)]; ok {
+		t.Error("expected a run still in progress to be left for later")
+	}
+	if log := repo.logs[workflowLogKey("inc-expired", 4)]; log == nil || log.Error == nil || log.Excerpt != nil {
+		t.Errorf("expected expired logs to be recorded as unavailable, got %+v", log)
+	}
+	if log := repo.logs[workflowLogKey("inc-untracked", 5)]; log == nil || log.Excerpt == nil {
+		t.Errorf("expected the run matched by title to be stored, got %+v", log)
+	}
+	if len(repo.logs) != 3 {
+		t.Errorf("expected 3 stored logs, got %d", len(repo.logs))
+	}
+
+	// Runs are fetched once
+	downloads := gh.downloads
+	if err := worker.FetchLogs(context.Background()); err != nil {
+		t.Fatalf("FetchLogs() error = %v", err)
+	}
+	if gh.downloads != downloads {
+		t.Errorf("expected stored runs not to be downloaded again, got %d more downloads", gh.downloads-downloads)
+	}
+}
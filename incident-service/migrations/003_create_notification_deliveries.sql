@@ -0,0 +1,20 @@
+-- Create notification_deliveries table to track notification attempts
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id SERIAL PRIMARY KEY,
+    incident_id VARCHAR(255),
+    event_type VARCHAR(100) NOT NULL,
+    provider VARCHAR(255) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    message JSONB NOT NULL DEFAULT '{}',
+    next_attempt_at TIMESTAMP,
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE
+);
+
+-- Create indexes for common queries
+CREATE INDEX idx_notification_deliveries_incident_id ON notification_deliveries(incident_id);
+CREATE INDEX idx_notification_deliveries_retry ON notification_deliveries(next_attempt_at) WHERE status = 'retrying';
@@ -0,0 +1,5 @@
+-- Track automatic remediation retries per incident
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS retry_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS next_retry_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_incidents_next_retry_at ON incidents(next_retry_at) WHERE status = 'failed';
@@ -0,0 +1,18 @@
+-- Create incident_rollups table with pre-aggregated incident counts per time bucket
+CREATE TABLE IF NOT EXISTS incident_rollups (
+    granularity VARCHAR(10) NOT NULL,
+    bucket_start TIMESTAMP NOT NULL,
+    service_name VARCHAR(255) NOT NULL,
+    severity VARCHAR(50) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    total INTEGER NOT NULL DEFAULT 0,
+    resolved INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    completed INTEGER NOT NULL DEFAULT 0,
+    resolution_seconds DOUBLE PRECISION NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (granularity, bucket_start, service_name, severity, provider)
+);
+
+-- Create indexes for common queries
+CREATE INDEX idx_incident_rollups_bucket ON incident_rollups(granularity, bucket_start);
@@ -0,0 +1,5 @@
+-- Fingerprint incidents for fuzzy deduplication and count repeat occurrences
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS fingerprint VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS occurrence_count INTEGER NOT NULL DEFAULT 1;
+
+CREATE INDEX IF NOT EXISTS idx_incidents_fingerprint ON incidents(service_name, fingerprint, created_at);
@@ -0,0 +1,5 @@
+-- Group related incidents under a parent incident. Not a foreign key: storm
+-- members can be stored while their storm incident is still being written.
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS parent_id VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_incidents_parent_id ON incidents(parent_id) WHERE parent_id IS NOT NULL;
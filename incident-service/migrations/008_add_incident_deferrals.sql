@@ -0,0 +1,4 @@
+-- Remediation deferred by a maintenance window is dispatched once the window closes
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS deferred_until TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_incidents_deferred_until ON incidents(deferred_until) WHERE deferred_until IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Snoozed incidents neither trigger remediation nor send notifications until the snooze expires
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS snoozed_until TIMESTAMP;
@@ -0,0 +1,9 @@
+-- Record the team owning each incident's service, taken from the service mapping at ingestion
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS team VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_incidents_team ON incidents(team);
+
+-- Break rollups down by team as well
+ALTER TABLE incident_rollups ADD COLUMN IF NOT EXISTS team VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE incident_rollups DROP CONSTRAINT IF EXISTS incident_rollups_pkey;
+ALTER TABLE incident_rollups ADD PRIMARY KEY (granularity, bucket_start, service_name, severity, provider, team);
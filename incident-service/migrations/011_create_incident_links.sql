@@ -0,0 +1,15 @@
+-- Create incident_links table relating incidents that share a root cause.
+-- Links are symmetric and stored once, with the smaller ID first.
+CREATE TABLE IF NOT EXISTS incident_links (
+    incident_id VARCHAR(255) NOT NULL,
+    related_id VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (incident_id, related_id),
+    CHECK (incident_id < related_id),
+    FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE,
+    FOREIGN KEY (related_id) REFERENCES incidents(id) ON DELETE CASCADE
+);
+
+-- Create indexes for common queries
+CREATE INDEX idx_incident_links_related_id ON incident_links(related_id);
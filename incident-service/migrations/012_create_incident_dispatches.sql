@@ -0,0 +1,18 @@
+-- Create incident_dispatches table tracking the remediation of an incident in
+-- each repository of a service that spans several repositories
+CREATE TABLE IF NOT EXISTS incident_dispatches (
+    id SERIAL PRIMARY KEY,
+    incident_id VARCHAR(255) NOT NULL,
+    repository VARCHAR(255) NOT NULL,
+    branch VARCHAR(255) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    pull_request_url TEXT,
+    diagnosis TEXT,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    triggered_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    UNIQUE (incident_id, repository),
+    FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE
+);
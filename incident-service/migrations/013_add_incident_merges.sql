@@ -0,0 +1,5 @@
+-- Record the incident a merged incident was folded into. Merged incidents are
+-- duplicates: their events and provider data live on the target from then on.
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS duplicate_of VARCHAR(255) REFERENCES incidents(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_incidents_duplicate_of ON incidents(duplicate_of) WHERE duplicate_of IS NOT NULL;
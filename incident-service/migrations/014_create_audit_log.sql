@@ -0,0 +1,19 @@
+-- Create audit_log table recording every change made through the management
+-- API, with who made it and from where
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(100) NOT NULL,
+    resource_type VARCHAR(50) NOT NULL,
+    resource_id VARCHAR(255) NOT NULL,
+    before_value JSONB,
+    after_value JSONB,
+    source_ip VARCHAR(64) NOT NULL DEFAULT '',
+    request_id VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for common queries
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at DESC);
+CREATE INDEX idx_audit_log_resource ON audit_log(resource_type, resource_id);
+CREATE INDEX idx_audit_log_actor ON audit_log(actor);
@@ -0,0 +1,19 @@
+-- Record the tenant of each incident, taken from the service mapping at ingestion
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+
+CREATE INDEX IF NOT EXISTS idx_incidents_tenant_id ON incidents(tenant_id, created_at DESC);
+
+-- Events carry their incident's tenant, so that they can be isolated without a join
+ALTER TABLE incident_events ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+
+UPDATE incident_events e
+SET tenant_id = i.tenant_id
+FROM incidents i
+WHERE i.id = e.incident_id AND e.tenant_id <> i.tenant_id;
+
+CREATE INDEX IF NOT EXISTS idx_incident_events_tenant_id ON incident_events(tenant_id);
+
+-- Break rollups down by tenant as well
+ALTER TABLE incident_rollups ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE incident_rollups DROP CONSTRAINT IF EXISTS incident_rollups_pkey;
+ALTER TABLE incident_rollups ADD PRIMARY KEY (granularity, bucket_start, service_name, severity, provider, team, tenant_id);
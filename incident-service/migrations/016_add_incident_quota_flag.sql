@@ -0,0 +1,3 @@
+-- Flag incidents received after their provider exceeded its hourly quota, which
+-- are recorded but not remediated automatically
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS quota_exceeded BOOLEAN NOT NULL DEFAULT FALSE;
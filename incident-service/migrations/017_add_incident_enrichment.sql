@@ -0,0 +1,2 @@
+-- Context gathered from MCP servers before an incident's workflow is dispatched
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS enrichment TEXT;
@@ -0,0 +1,2 @@
+-- Summary and suspected root cause category written by a language model
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS summary JSONB;
@@ -0,0 +1,10 @@
+-- Create incident_embeddings table holding the embedding of each incident's
+-- error message, used to find similar past incidents. Embeddings of
+-- different models cannot be compared, so each records its model.
+CREATE TABLE IF NOT EXISTS incident_embeddings (
+    incident_id VARCHAR(255) PRIMARY KEY,
+    model VARCHAR(255) NOT NULL,
+    embedding DOUBLE PRECISION[] NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE
+);
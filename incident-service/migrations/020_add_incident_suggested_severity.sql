@@ -0,0 +1,4 @@
+-- Whether the provider gave no severity that maps, and the severity the
+-- classifier suggests from the error and the service's history
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS severity_defaulted BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS suggested_severity VARCHAR(50) NOT NULL DEFAULT '';
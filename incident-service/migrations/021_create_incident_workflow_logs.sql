@@ -0,0 +1,17 @@
+-- Create incident_workflow_logs table holding an excerpt of the failing step
+-- of each failed remediation workflow run. A row with an error records that
+-- the run's logs could not be fetched, so they are not requested again.
+CREATE TABLE IF NOT EXISTS incident_workflow_logs (
+    incident_id VARCHAR(255) NOT NULL,
+    workflow_run_id BIGINT NOT NULL,
+    repository VARCHAR(255) NOT NULL,
+    job_name VARCHAR(255) NOT NULL DEFAULT '',
+    step_name VARCHAR(255) NOT NULL DEFAULT '',
+    conclusion VARCHAR(50) NOT NULL DEFAULT '',
+    excerpt TEXT,
+    truncated BOOLEAN NOT NULL DEFAULT FALSE,
+    error TEXT,
+    fetched_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (incident_id, workflow_run_id),
+    FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE
+);
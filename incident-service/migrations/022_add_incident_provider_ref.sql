@@ -0,0 +1,11 @@
+-- The provider's own reference for an incident, kept apart from the incident
+-- ID so that IDs can be generated independently of the provider
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS provider_ref VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Existing incidents carry the reference in their provider-derived ID
+UPDATE incidents SET provider_ref = substring(id FROM '^inc_(?:dd|pd|sentry)_(.+)$')
+WHERE provider_ref = '' AND id ~ '^inc_(dd|pd|sentry)_.+$';
+UPDATE incidents SET provider_ref = substring(id FROM '^inc_grafana_(.+)_[0-9]+$')
+WHERE provider_ref = '' AND id ~ '^inc_grafana_.+_[0-9]+$';
+
+CREATE INDEX IF NOT EXISTS idx_incidents_provider_ref ON incidents(provider, provider_ref);
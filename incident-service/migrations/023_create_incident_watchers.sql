@@ -0,0 +1,12 @@
+-- Create incident_watchers table holding the people subscribed to the
+-- notifications of an incident. A watcher is reached by email, by a Slack
+-- direct message, or both; an unused destination is stored as ''.
+CREATE TABLE IF NOT EXISTS incident_watchers (
+    incident_id VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    slack_user VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (incident_id, email, slack_user),
+    CHECK (email <> '' OR slack_user <> ''),
+    FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE
+);
@@ -0,0 +1,17 @@
+-- Create incident_attachments table holding the files attached to incidents,
+-- such as screenshots, log excerpts or flame graphs. The bytes are kept in the
+-- configured blob store under storage_key; only their metadata is kept here.
+CREATE TABLE IF NOT EXISTS incident_attachments (
+    id VARCHAR(64) PRIMARY KEY,
+    incident_id VARCHAR(255) NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    storage_key VARCHAR(512) NOT NULL,
+    uploaded_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_incident_attachments_incident_id ON incident_attachments(incident_id, created_at);
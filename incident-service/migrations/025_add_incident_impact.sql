@@ -0,0 +1,10 @@
+-- Record the estimated impact of incidents, set through the API or extracted
+-- from provider data. Each field is optional.
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS affected_users BIGINT;
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS error_rate DOUBLE PRECISION;
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS downtime_minutes INTEGER;
+
+-- Sum impact into rollups as well, keeping the highest error rate of a bucket
+ALTER TABLE incident_rollups ADD COLUMN IF NOT EXISTS affected_users BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE incident_rollups ADD COLUMN IF NOT EXISTS downtime_minutes BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE incident_rollups ADD COLUMN IF NOT EXISTS peak_error_rate DOUBLE PRECISION NOT NULL DEFAULT 0;
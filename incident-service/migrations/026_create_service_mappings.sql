@@ -0,0 +1,11 @@
+-- Create service_mappings table holding the service mappings made through the
+-- API, for services that incidents arrived from before they were mapped in
+-- the configuration. Mappings in the configuration take precedence.
+CREATE TABLE IF NOT EXISTS service_mappings (
+    service_name VARCHAR(255) PRIMARY KEY,
+    repository VARCHAR(255) NOT NULL,
+    branch VARCHAR(255) NOT NULL DEFAULT 'main',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,9 @@
+-- Create dispatch_queue_snapshots table holding the last snapshot of the
+-- in-memory workflow slots and queues, one row per repository, restored when
+-- the service starts so that a restart does not lose queued remediations
+CREATE TABLE IF NOT EXISTS dispatch_queue_snapshots (
+    repository VARCHAR(255) PRIMARY KEY,
+    active INTEGER NOT NULL DEFAULT 0,
+    queued JSONB NOT NULL DEFAULT '[]',
+    taken_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
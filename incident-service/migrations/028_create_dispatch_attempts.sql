@@ -0,0 +1,17 @@
+-- Create dispatch_attempts table recording every request made to dispatch the
+-- remediation workflow of an incident, retries included, so that a failed
+-- dispatch can be debugged from the incident
+CREATE TABLE IF NOT EXISTS dispatch_attempts (
+    id SERIAL PRIMARY KEY,
+    incident_id VARCHAR(255) NOT NULL,
+    repository VARCHAR(255) NOT NULL,
+    attempt INTEGER NOT NULL,
+    outcome VARCHAR(50) NOT NULL,
+    http_status INTEGER,
+    run_id BIGINT,
+    error TEXT,
+    attempted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_dispatch_attempts_incident_id ON dispatch_attempts(incident_id);
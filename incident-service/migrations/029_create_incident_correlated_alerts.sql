@@ -0,0 +1,19 @@
+-- Create incident_correlated_alerts table holding the alerts other providers
+-- sent about the same outage as an incident, attached to it instead of
+-- creating incidents of their own. Stack traces and provider data are
+-- encrypted like those of incidents.
+CREATE TABLE IF NOT EXISTS incident_correlated_alerts (
+    id SERIAL PRIMARY KEY,
+    incident_id VARCHAR(255) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    provider_ref VARCHAR(255) NOT NULL DEFAULT '',
+    error_message TEXT NOT NULL,
+    stack_trace TEXT,
+    severity VARCHAR(50) NOT NULL DEFAULT '',
+    fingerprint VARCHAR(64) NOT NULL DEFAULT '',
+    provider_data JSONB,
+    received_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_incident_correlated_alerts_incident_id ON incident_correlated_alerts(incident_id);
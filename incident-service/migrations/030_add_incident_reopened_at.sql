@@ -0,0 +1,3 @@
+-- A reopened incident waits for remediation from when it was reopened, not
+-- from when it was first received
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS reopened_at TIMESTAMP;